@@ -0,0 +1,31 @@
+package config
+
+const (
+	// AdminUserKindUser is used for the admin users which log in from mission control or space-cli
+	AdminUserKindUser = "user"
+	// AdminUserKindServiceAccount is used for the admin users which are used by automated systems like ci pipelines
+	AdminUserKindServiceAccount = "service-account"
+)
+
+// AdminUsers describes all the admin users and service accounts registered in the cluster
+type AdminUsers map[string]*AdminUserConfig // Key here is resource id --> clusterId--noProject--admin-user--userId
+
+// Get checks if id exists in the admin users and returns it
+func (users AdminUsers) Get(id string) (*AdminUserConfig, bool) {
+	for _, user := range users {
+		if user.ID == id {
+			return user, true
+		}
+	}
+	return nil, false
+}
+
+// AdminUserConfig describes an admin user or service account along with the roles granted to it. The roles use the
+// same resource / verb / attribute model as the config permissions of integrations
+type AdminUserConfig struct {
+	ID          string                  `json:"id" yaml:"id" mapstructure:"id"`
+	Kind        string                  `json:"kind" yaml:"kind" mapstructure:"kind"` // `user` or `service-account`
+	Key         string                  `json:"key" yaml:"key" mapstructure:"key"`    // The bcrypt hash of the key used to login
+	Description string                  `json:"description" yaml:"description" mapstructure:"description"`
+	Roles       []IntegrationPermission `json:"roles" yaml:"roles" mapstructure:"roles"`
+}
@@ -12,12 +12,21 @@ type Config struct {
 	Integrations     Integrations     `json:"integrations" yaml:"integrations" mapstructure:"integrations"`
 	IntegrationHooks IntegrationHooks `json:"integrationsHooks" yaml:"integrationsHooks" mapstructure:"integrationsHooks"`
 	CacheConfig      *CacheConfig     `json:"cacheConfig" yaml:"cacheConfig" mapstructure:"cacheConfig"`
+	AdminUsers       AdminUsers       `json:"adminUsers" yaml:"adminUsers" mapstructure:"adminUsers"`
 }
 
 // ClusterConfig holds the cluster level configuration
 type ClusterConfig struct {
-	LetsEncryptEmail string `json:"letsencryptEmail" yaml:"letsencryptEmail" mapstructure:"letsencryptEmail"`
-	EnableTelemetry  bool   `json:"enableTelemetry" yaml:"enableTelemetry" mapstructure:"enableTelemetry"`
+	LetsEncryptEmail string          `json:"letsencryptEmail" yaml:"letsencryptEmail" mapstructure:"letsencryptEmail"`
+	EnableTelemetry  bool            `json:"enableTelemetry" yaml:"enableTelemetry" mapstructure:"enableTelemetry"`
+	AuditLog         *AuditLogConfig `json:"auditLog,omitempty" yaml:"auditLog,omitempty" mapstructure:"auditLog"`
+}
+
+// AuditLogConfig describes where the audit log of the admin and config operations of the cluster is stored
+type AuditLogConfig struct {
+	Enabled bool   `json:"enabled" yaml:"enabled" mapstructure:"enabled"`
+	Project string `json:"project" yaml:"project" mapstructure:"project"`
+	DBAlias string `json:"dbAlias" yaml:"dbAlias" mapstructure:"dbAlias"`
 }
 
 // Projects is a map which stores config information of all project in a cluster
@@ -47,9 +56,15 @@ type EventingTriggers map[string]*EventingTrigger // Key here is resource id -->
 // FileStoreRules is a map which stores database config information
 type FileStoreRules map[string]*FileRule // Key here is resource id --> clusterId--projectId--resourceType--fileRuleId
 
+// FileStores is a map which stores the config of the named file stores of a project
+type FileStores map[string]*FileStoreConfig // Key here is resource id --> clusterId--projectId--resourceType--alias
+
 // IngressRoutes is a map which stores database config information
 type IngressRoutes map[string]*Route // Key here is resource id --> clusterId--projectId--resourceType--routeId
 
+// GraphQLPersistedQueries is a map which stores the persisted graphql queries
+type GraphQLPersistedQueries map[string]*GraphQLPersistedQuery // Key here is resource id --> clusterId--projectId--resourceType--queryHash
+
 // Project holds the project level configuration
 type Project struct {
 	ProjectConfig *ProjectConfig `json:"projectConfig" yaml:"projectConfig" mapstructure:"projectConfig"`
@@ -65,6 +80,7 @@ type Project struct {
 	EventingTriggers EventingTriggers `json:"eventingTriggers" yaml:"eventingTriggers" mapstructure:"eventingTriggers"`
 
 	FileStoreConfig *FileStoreConfig `json:"fileStoreConfig" yaml:"fileStoreConfig" mapstructure:"fileStoreConfig"`
+	FileStores      FileStores       `json:"fileStores" yaml:"fileStores" mapstructure:"fileStores"`
 	FileStoreRules  FileStoreRules   `json:"fileStoreRules" yaml:"fileStoreRules" mapstructure:"fileStoreRules"`
 
 	Auths Auths `json:"auths" yaml:"auths" mapstructure:"auths"`
@@ -75,18 +91,88 @@ type Project struct {
 	IngressGlobal *GlobalRoutesConfig `json:"ingressGlobal" yaml:"ingressGlobal" mapstructure:"ingressGlobal"`
 
 	RemoteService Services `json:"remoteServices" yaml:"remoteServices" mapstructure:"remoteServices"`
+
+	GraphQLPersistedQueries GraphQLPersistedQueries `json:"graphqlPersistedQueries" yaml:"graphqlPersistedQueries" mapstructure:"graphqlPersistedQueries"`
 }
 
 // ProjectConfig stores information of individual project
 type ProjectConfig struct {
-	ID                 string    `json:"id,omitempty" yaml:"id,omitempty" mapstructure:"id"`
-	Name               string    `json:"name,omitempty" yaml:"name,omitempty" mapstructure:"name"`
-	Secrets            []*Secret `json:"secrets,omitempty" yaml:"secrets,omitempty" mapstructure:"secrets"`
-	SecretSource       string    `json:"secretSource,omitempty" yaml:"secretSource,omitempty" mapstructure:"secretSource"`
-	IsIntegration      bool      `json:"isIntegration,omitempty" yaml:"isIntegration,omitempty" mapstructure:"isIntegration"`
-	AESKey             string    `json:"aesKey,omitempty" yaml:"aesKey,omitempty" mapstructure:"aesKey"`
-	DockerRegistry     string    `json:"dockerRegistry,omitempty" yaml:"dockerRegistry,omitempty" mapstructure:"dockerRegistry"`
-	ContextTimeGraphQL int       `json:"contextTimeGraphQL,omitempty" yaml:"contextTimeGraphQL,omitempty" mapstructure:"contextTimeGraphQL"` // contextTime sets the timeout of query
+	ID                 string         `json:"id,omitempty" yaml:"id,omitempty" mapstructure:"id"`
+	Name               string         `json:"name,omitempty" yaml:"name,omitempty" mapstructure:"name"`
+	Secrets            []*Secret      `json:"secrets,omitempty" yaml:"secrets,omitempty" mapstructure:"secrets"`
+	SecretSource       string         `json:"secretSource,omitempty" yaml:"secretSource,omitempty" mapstructure:"secretSource"`
+	IsIntegration      bool           `json:"isIntegration,omitempty" yaml:"isIntegration,omitempty" mapstructure:"isIntegration"`
+	AESKey             string         `json:"aesKey,omitempty" yaml:"aesKey,omitempty" mapstructure:"aesKey"`
+	DockerRegistry     string         `json:"dockerRegistry,omitempty" yaml:"dockerRegistry,omitempty" mapstructure:"dockerRegistry"`
+	ContextTimeGraphQL int            `json:"contextTimeGraphQL,omitempty" yaml:"contextTimeGraphQL,omitempty" mapstructure:"contextTimeGraphQL"` // contextTime sets the timeout of query
+	RateLimits         []*RateLimit   `json:"rateLimits,omitempty" yaml:"rateLimits,omitempty" mapstructure:"rateLimits"`
+	GraphQLLimits      *GraphQLLimits `json:"graphqlLimits,omitempty" yaml:"graphqlLimits,omitempty" mapstructure:"graphqlLimits"`
+
+	// PersistedQueriesOnly restricts the graphql endpoint to the persisted queries of the project
+	PersistedQueriesOnly bool `json:"persistedQueriesOnly,omitempty" yaml:"persistedQueriesOnly,omitempty" mapstructure:"persistedQueriesOnly"`
+
+	// GraphQLIntrospection controls who can run introspection queries on the graphql endpoint. Defaults to enabled
+	GraphQLIntrospection GraphQLIntrospection `json:"graphqlIntrospection,omitempty" yaml:"graphqlIntrospection,omitempty" mapstructure:"graphqlIntrospection"`
+
+	// Cors is the cors policy of the apis and ingress routes of the project. All origins are allowed if it isn't provided
+	Cors *Cors `json:"cors,omitempty" yaml:"cors,omitempty" mapstructure:"cors"`
+}
+
+// Cors describes a cross origin resource sharing policy. Preflight requests are answered from this policy
+type Cors struct {
+	AllowedOrigins   []string `json:"allowedOrigins" yaml:"allowedOrigins" mapstructure:"allowedOrigins"`                     // an origin may contain a single wildcard like https://*.example.com
+	AllowedMethods   []string `json:"allowedMethods,omitempty" yaml:"allowedMethods,omitempty" mapstructure:"allowedMethods"` // defaults to GET, POST and HEAD
+	AllowedHeaders   []string `json:"allowedHeaders,omitempty" yaml:"allowedHeaders,omitempty" mapstructure:"allowedHeaders"` // defaults to Accept, Content-Type and X-Requested-With
+	ExposedHeaders   []string `json:"exposedHeaders,omitempty" yaml:"exposedHeaders,omitempty" mapstructure:"exposedHeaders"`
+	AllowCredentials bool     `json:"allowCredentials,omitempty" yaml:"allowCredentials,omitempty" mapstructure:"allowCredentials"`
+	MaxAge           int      `json:"maxAge,omitempty" yaml:"maxAge,omitempty" mapstructure:"maxAge"` // in seconds
+}
+
+// GraphQLPersistedQuery stores a graphql query registered ahead of time. Clients refer to it with the sha256 hash of
+// the query in the automatic persisted queries format of apollo
+type GraphQLPersistedQuery struct {
+	ID    string `json:"id" yaml:"id" mapstructure:"id"` // hex encoded sha256 hash of the query
+	Name  string `json:"name,omitempty" yaml:"name,omitempty" mapstructure:"name"`
+	Query string `json:"query" yaml:"query" mapstructure:"query"`
+	Rule  *Rule  `json:"rule,omitempty" yaml:"rule,omitempty" mapstructure:"rule"` // rule checked before the query gets executed
+}
+
+// GraphQLIntrospection describes who can run introspection queries on the graphql endpoint
+type GraphQLIntrospection string
+
+const (
+	// GraphQLIntrospectionEnabled allows anyone to run introspection queries
+	GraphQLIntrospectionEnabled GraphQLIntrospection = "enabled"
+
+	// GraphQLIntrospectionToken only allows the requests carrying a valid token to run introspection queries
+	GraphQLIntrospectionToken GraphQLIntrospection = "token"
+
+	// GraphQLIntrospectionDisabled rejects all introspection queries
+	GraphQLIntrospectionDisabled GraphQLIntrospection = "disabled"
+)
+
+// GraphQLLimits describes the limits enforced on graphql queries before they get executed. A limit is disabled when set to zero
+type GraphQLLimits struct {
+	MaxDepth         int `json:"maxDepth,omitempty" yaml:"maxDepth,omitempty" mapstructure:"maxDepth"`                         // maximum nesting of fields
+	MaxAliases       int `json:"maxAliases,omitempty" yaml:"maxAliases,omitempty" mapstructure:"maxAliases"`                   // maximum number of aliased fields
+	MaxCost          int `json:"maxCost,omitempty" yaml:"maxCost,omitempty" mapstructure:"maxCost"`                            // maximum estimated number of documents fetched
+	MaxExecutionTime int `json:"maxExecutionTime,omitempty" yaml:"maxExecutionTime,omitempty" mapstructure:"maxExecutionTime"` // in milliseconds
+}
+
+// RateLimit describes a token bucket rate limit policy. Each client gets its own bucket which holds up to `burst`
+// tokens and gets refilled with `requests` tokens every `interval` seconds. A request consumes a single token
+type RateLimit struct {
+	ID        string   `json:"id" yaml:"id" mapstructure:"id"`
+	Endpoints []string `json:"endpoints,omitempty" yaml:"endpoints,omitempty" mapstructure:"endpoints"` // crud, graphql, services, files or ingress. The policy applies to all endpoints if empty
+	KeyType   string   `json:"keyType" yaml:"keyType" mapstructure:"keyType"`                           // ip, claim or apiKey
+	Claim     string   `json:"claim,omitempty" yaml:"claim,omitempty" mapstructure:"claim"`             // field of the jwt claims used to identify the client for key type claim
+	Header    string   `json:"header,omitempty" yaml:"header,omitempty" mapstructure:"header"`          // header carrying the api key for key type apiKey. Defaults to X-API-Key
+	Requests  int64    `json:"requests" yaml:"requests" mapstructure:"requests"`
+	Interval  int64    `json:"interval" yaml:"interval" mapstructure:"interval"`            // in seconds
+	Burst     int64    `json:"burst,omitempty" yaml:"burst,omitempty" mapstructure:"burst"` // defaults to requests
+
+	// TrustedProxies are the cidrs of the proxies allowed to set the X-Forwarded-For and X-Real-IP headers for key type ip
+	TrustedProxies []string `json:"trustedProxies,omitempty" yaml:"trustedProxies,omitempty" mapstructure:"trustedProxies"`
 }
 
 // DriverConfig stores the parameters for drivers of Databases.
@@ -142,6 +228,7 @@ type EventingSchema struct {
 
 // FileStoreConfig stores information of file store config
 type FileStoreConfig struct {
+	ID             string `json:"id,omitempty" yaml:"id,omitempty" mapstructure:"id"` // alias of a named file store. It is empty for the default file store
 	Enabled        bool   `json:"enabled" yaml:"enabled" mapstructure:"enabled"`
 	StoreType      string `json:"storeType" yaml:"storeType" mapstructure:"storeType"`
 	Conn           string `json:"conn" yaml:"conn" mapstructure:"conn"`
@@ -150,6 +237,9 @@ type FileStoreConfig struct {
 	Secret         string `json:"secret" yaml:"secret" mapstructure:"secret"`
 	DisableSSL     *bool  `json:"disableSSL,omitempty" yaml:"disableSSL,omitempty" mapstructure:"disableSSL"`
 	ForcePathStyle *bool  `json:"forcePathStyle,omitempty" yaml:"forcePathStyle,omitempty" mapstructure:"forcePathStyle"`
+
+	// UploadsBucket is the bucket where gcp storage stages the parts of resumable uploads. It must not be the bucket of the store
+	UploadsBucket string `json:"uploadsBucket,omitempty" yaml:"uploadsBucket,omitempty" mapstructure:"uploadsBucket"`
 }
 
 // CacheConfig describes the config of the caching module
@@ -291,7 +381,25 @@ type Auths map[string]*AuthStub // The key here is the sign in method
 type AuthStub struct {
 	ID      string `json:"id" yaml:"id" mapstructure:"id"`
 	Enabled bool   `json:"enabled" yaml:"enabled" mapstructure:"enabled"`
-	Secret  string `json:"secret" yaml:"secret" mapstructure:"secret"`
+	Secret  string `json:"secret" yaml:"secret" mapstructure:"secret"` // client secret in case of oauth2 and oidc providers
+
+	// The following fields are only used by the oauth2 and oidc providers
+	Type                string   `json:"type,omitempty" yaml:"type,omitempty" mapstructure:"type"` // oauth2 or oidc
+	ClientID            string   `json:"clientId,omitempty" yaml:"clientId,omitempty" mapstructure:"clientId"`
+	Issuer              string   `json:"issuer,omitempty" yaml:"issuer,omitempty" mapstructure:"issuer"` // used to discover the endpoints of an oidc provider
+	AuthURL             string   `json:"authUrl,omitempty" yaml:"authUrl,omitempty" mapstructure:"authUrl"`
+	TokenURL            string   `json:"tokenUrl,omitempty" yaml:"tokenUrl,omitempty" mapstructure:"tokenUrl"`
+	UserInfoURL         string   `json:"userInfoUrl,omitempty" yaml:"userInfoUrl,omitempty" mapstructure:"userInfoUrl"`
+	RedirectURL         string   `json:"redirectUrl,omitempty" yaml:"redirectUrl,omitempty" mapstructure:"redirectUrl"` // callback url of the gateway registered with the provider
+	Scopes              []string `json:"scopes,omitempty" yaml:"scopes,omitempty" mapstructure:"scopes"`
+	AllowedRedirectURLs []string `json:"allowedRedirectUrls,omitempty" yaml:"allowedRedirectUrls,omitempty" mapstructure:"allowedRedirectUrls"` // urls of the app the user may be sent back to after signing in
+	EmailField          string   `json:"emailField,omitempty" yaml:"emailField,omitempty" mapstructure:"emailField"`                            // defaults to email
+	NameField           string   `json:"nameField,omitempty" yaml:"nameField,omitempty" mapstructure:"nameField"`                               // defaults to name
+	DefaultRole         string   `json:"defaultRole,omitempty" yaml:"defaultRole,omitempty" mapstructure:"defaultRole"`                         // role of the users signing up through the provider
+
+	// TrustEmail marks the emails returned by the provider as verified even if it doesn't return the email_verified
+	// claim. Existing accounts with the same email are handed over to the users signing in through the provider
+	TrustEmail bool `json:"trustEmail,omitempty" yaml:"trustEmail,omitempty" mapstructure:"trustEmail"`
 }
 
 // ServicesModule holds the config for the service module
@@ -401,6 +509,7 @@ type FileStore struct {
 // FileRule is the authorization object at the file rule level
 type FileRule struct {
 	ID     string           `json:"id" yaml:"id" mapstructure:"id"`
+	Store  string           `json:"store,omitempty" yaml:"store,omitempty" mapstructure:"store"` // alias of the file store the rule applies to. Defaults to the default file store
 	Prefix string           `json:"prefix" yaml:"prefix" mapstructure:"prefix"`
 	Rule   map[string]*Rule `json:"rule" yaml:"rule" mapstructure:"rule"` // The key can be create, read, delete
 }
@@ -445,8 +554,34 @@ type EventingTrigger struct {
 	Claims          string            `json:"claims" yaml:"claims" mapstructure:"claims"`
 	Filter          *Rule             `json:"filter" yaml:"filter" mapstructure:"filter"`
 	TriggerType     string            `json:"triggerType" yaml:"triggerType" mapstructure:"triggerType"`
+	RetryPolicy     *RetryPolicy      `json:"retryPolicy,omitempty" yaml:"retryPolicy,omitempty" mapstructure:"retryPolicy"`
+	Schedule        string            `json:"schedule,omitempty" yaml:"schedule,omitempty" mapstructure:"schedule"` // Cron expression for triggers of type CRON
+	TimeZone        string            `json:"timeZone,omitempty" yaml:"timeZone,omitempty" mapstructure:"timeZone"` // Time zone of the schedule. Default value - UTC
 }
 
+// RetryPolicy describes how failed invocations of an eventing trigger are retried. Retries are limited by the retries field of the trigger
+type RetryPolicy struct {
+	Backoff                 BackoffType `json:"backoff,omitempty" yaml:"backoff,omitempty" mapstructure:"backoff"`                         // Default value - exponential
+	InitialInterval         int         `json:"initialInterval,omitempty" yaml:"initialInterval,omitempty" mapstructure:"initialInterval"` // InitialInterval is in milliseconds. Default value - 5000
+	MaxInterval             int         `json:"maxInterval,omitempty" yaml:"maxInterval,omitempty" mapstructure:"maxInterval"`             // MaxInterval is in milliseconds. Default value - 300000
+	Jitter                  float64     `json:"jitter,omitempty" yaml:"jitter,omitempty" mapstructure:"jitter"`                            // Jitter is the fraction (0 to 1) by which the interval is randomly varied
+	NonRetryableStatusCodes []int       `json:"nonRetryableStatusCodes,omitempty" yaml:"nonRetryableStatusCodes,omitempty" mapstructure:"nonRetryableStatusCodes"`
+}
+
+// BackoffType describes how the interval between retries grows
+type BackoffType string
+
+const (
+	// BackoffFixed waits for the initial interval between every retry
+	BackoffFixed BackoffType = "fixed"
+
+	// BackoffLinear increases the interval by the initial interval after every retry
+	BackoffLinear BackoffType = "linear"
+
+	// BackoffExponential doubles the interval after every retry
+	BackoffExponential BackoffType = "exponential"
+)
+
 // SchemaObject is the body of the request for adding schema
 type SchemaObject struct {
 	ID     string `json:"id,omitempty" yaml:"id,omitempty" mapstructure:"id"`
@@ -9,6 +9,7 @@ func GenerateEmptyConfig() *Config {
 		Integrations:     make(Integrations),
 		IntegrationHooks: make(IntegrationHooks),
 		CacheConfig:      new(CacheConfig),
+		AdminUsers:       make(AdminUsers),
 	}
 }
 
@@ -25,11 +26,13 @@ func GenerateEmptyProject(project *ProjectConfig) *Project {
 		EventingRules:           make(map[string]*Rule),
 		EventingTriggers:        make(map[string]*EventingTrigger),
 		FileStoreConfig:         new(FileStoreConfig),
+		FileStores:              FileStores{},
 		FileStoreRules:          FileStoreRules{},
 		Auths:                   make(Auths),
 		LetsEncrypt:             new(LetsEncrypt),
 		IngressRoutes:           make(IngressRoutes),
 		IngressGlobal:           new(GlobalRoutesConfig),
 		RemoteService:           make(Services),
+		GraphQLPersistedQueries: make(GraphQLPersistedQueries),
 	}
 }
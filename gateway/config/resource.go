@@ -13,12 +13,14 @@ var ResourceFetchingOrder = []Resource{
 	ResourceDatabaseSchema,
 	ResourceDatabasePreparedQuery,
 	ResourceFileStoreConfig,
+	ResourceFileStoreAlias,
 	ResourceFileStoreRule,
 	ResourceEventingConfig,
 	ResourceEventingTrigger,
 	ResourceEventingRule,
 	ResourceEventingSchema,
 	ResourceRemoteService,
+	ResourceGraphQLPersistedQuery,
 	ResourceIngressGlobal,
 	ResourceIngressRoute,
 	ResourceAuthProvider,
@@ -27,6 +29,7 @@ var ResourceFetchingOrder = []Resource{
 	ResourceIntegration,
 	ResourceIntegrationHook,
 	ResourceCacheConfig,
+	ResourceAdminUser,
 }
 
 // Resource is a resource type
@@ -59,6 +62,8 @@ const (
 
 	// ResourceFileStoreConfig is a resource
 	ResourceFileStoreConfig Resource = "filestore-config"
+	// ResourceFileStoreAlias is a resource. It is a named file store of a project which is referred to by its alias
+	ResourceFileStoreAlias Resource = "filestore-alias"
 	// ResourceFileStoreRule is a resource
 	ResourceFileStoreRule Resource = "filestore-rule"
 
@@ -73,6 +78,9 @@ const (
 	// ResourceRemoteService is a resource
 	ResourceRemoteService Resource = "remote-service"
 
+	// ResourceGraphQLPersistedQuery is a resource
+	ResourceGraphQLPersistedQuery Resource = "graphql-persisted-query"
+
 	// ResourceIntegration is a resource
 	ResourceIntegration Resource = "integration"
 	// ResourceIntegrationHook is a resource
@@ -83,6 +91,9 @@ const (
 	// ResourceCacheConfig is a resource
 	ResourceCacheConfig Resource = "cache-config"
 
+	// ResourceAdminUser is a resource
+	ResourceAdminUser Resource = "admin-user"
+
 	// ResourceDeployService is a resource
 	// ResourceDeployService Resource = "service"
 	// ResourceDeployServiceRoute is a resource
@@ -51,6 +51,8 @@ type Route struct {
 	Rule             *Rule         `json:"rule" yaml:"rule" mapstructure:"rule"`
 	IsRouteCacheable bool          `json:"isRouteCacheable" yaml:"isRouteCacheable" mapstructure:"isRouteCacheable"`
 	CacheOptions     []string      `json:"cacheOptions" yaml:"cacheOptions" mapstructure:"cacheOptions"`
+	RateLimit        *RateLimit    `json:"rateLimit,omitempty" yaml:"rateLimit,omitempty" mapstructure:"rateLimit"` // overrides the ingress rate limits of the project
+	Cors             *Cors         `json:"cors,omitempty" yaml:"cors,omitempty" mapstructure:"cors"`                // overrides the cors policy of the project
 	Modify           struct {
 		Tmpl            TemplatingEngine `json:"template,omitempty" yaml:"template,omitempty" mapstructure:"template"`
 		ReqTmpl         string           `json:"requestTemplate" yaml:"requestTemplate" mapstructure:"requestTemplate"`
@@ -112,6 +114,14 @@ const (
 
 	// RouteExact is used for matching the url exactly as it is
 	RouteExact RouteURLType = "exact"
+
+	// RoutePath is used for matching the url against a path template like `/users/{id}/orders/{orderId}`.
+	// A parameter ending with `*` (e.g. `{rest*}`) matches the remaining part of the url
+	RoutePath RouteURLType = "path"
+
+	// RouteRegex is used for matching the complete url against a regular expression. Named groups
+	// are captured as path parameters
+	RouteRegex RouteURLType = "regex"
 )
 
 // RouteTargetType describes how the target should be selected
@@ -1,6 +1,6 @@
 #!/bin/sh
 # NOTE: Run this script from the gateway directory
-# 1) ensure port 4122,3306,5432,1433,27017 are not busy
+# 1) ensure port 4122,3306,5432,1433,27017,10000 are not busy
 # 2) ensure docker & golang is installed
 set -e
 
@@ -120,3 +120,14 @@ docker rm -f integration-mongo
 
 sudo kill -9 `sudo lsof -t -i:4122`
 rm ../../../config.yaml &
+
+cd ../../filestore/azureblob
+
+# azure blob storage test
+echo "starting azurite container, it will take 10 seconds"
+docker run --name integration-azurite -p 10000:10000 -d mcr.microsoft.com/azure-storage/azurite azurite-blob --blobHost 0.0.0.0
+sleep 10
+echo "running integration tests for azure blob storage"
+go test -tags file_integration -conn "UseDevelopmentStorage=true"
+echo "removing azurite container"
+docker rm -f integration-azurite
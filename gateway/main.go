@@ -16,6 +16,7 @@ import (
 	"github.com/spaceuptech/space-cloud/gateway/config"
 	"github.com/spaceuptech/space-cloud/gateway/server"
 	"github.com/spaceuptech/space-cloud/gateway/utils"
+	"github.com/spaceuptech/space-cloud/gateway/utils/tracing"
 )
 
 var essentialFlags = []cli.Flag{
@@ -121,6 +122,24 @@ var essentialFlags = []cli.Flag{
 		EnvVar: "DISABLE_METRICS",
 	},
 
+	// Flags for distributed tracing
+	cli.StringFlag{
+		Name:   "otlp-endpoint",
+		Usage:  "The OTLP/HTTP endpoint of the OpenTelemetry collector to export traces to (for example http://localhost:4318)",
+		EnvVar: "OTEL_EXPORTER_OTLP_ENDPOINT",
+	},
+	cli.StringFlag{
+		Name:   "otlp-headers",
+		Usage:  "Comma separated key=value pairs sent as headers to the OpenTelemetry collector",
+		EnvVar: "OTEL_EXPORTER_OTLP_HEADERS",
+	},
+	cli.Float64Flag{
+		Name:   "tracing-sample-ratio",
+		Usage:  "The ratio of traces started by space cloud which get sampled",
+		EnvVar: "TRACING_SAMPLE_RATIO",
+		Value:  1,
+	},
+
 	// Flag to disable downloading mission-control
 	cli.BoolFlag{
 		Name:   "disable-ui",
@@ -203,6 +222,19 @@ func actionRun(c *cli.Context) error {
 
 	helpers.Logger.LogInfo("start", fmt.Sprintf("Starting node with id - %s", nodeID), nil)
 
+	// Export traces only if an OpenTelemetry collector has been provided
+	if otlpEndpoint := c.String("otlp-endpoint"); otlpEndpoint != "" {
+		headers, err := tracing.ParseHeaders(c.String("otlp-headers"))
+		if err != nil {
+			return err
+		}
+		exporter := tracing.NewOTLPExporter(otlpEndpoint, headers,
+			tracing.String("service.name", "space-cloud-gateway"), tracing.String("service.version", utils.BuildVersion),
+			tracing.String("service.instance.id", nodeID), tracing.String("space_cloud.cluster.id", clusterID))
+		tracing.SetTracer(tracing.New(exporter, c.Float64("tracing-sample-ratio")))
+		helpers.Logger.LogInfo("start", fmt.Sprintf("Exporting traces to %s", otlpEndpoint), nil)
+	}
+
 	// Set the ssl config
 	ssl := &config.SSL{}
 	if sslEnable {
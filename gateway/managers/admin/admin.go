@@ -14,12 +14,14 @@ type Manager struct {
 	lock         sync.RWMutex
 	user         *config.AdminUser
 	integrations config.Integrations
+	adminUsers   config.AdminUsers
 
 	services model.ScServices
 	isProd   bool
 
 	syncMan        model.SyncManAdminInterface
 	integrationMan IntegrationInterface
+	auditMan       AuditInterface
 
 	nodeID, clusterID string
 }
@@ -50,6 +52,13 @@ func (m *Manager) SetIntegrationMan(i IntegrationInterface) {
 	m.integrationMan = i
 }
 
+// SetAuditMan sets audit manager
+func (m *Manager) SetAuditMan(a AuditInterface) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.auditMan = a
+}
+
 // SetIntegrationConfig sets integration config
 func (m *Manager) SetIntegrationConfig(integrations config.Integrations) {
 	m.lock.Lock()
@@ -0,0 +1,32 @@
+package admin
+
+import (
+	"context"
+
+	"github.com/spaceuptech/space-cloud/gateway/model"
+)
+
+// recordAudit records the entry in the audit log. The outcome defaults to failure if an error is provided and to
+// success otherwise. The lock must be held by the caller
+func (m *Manager) recordAudit(ctx context.Context, entry *model.AuditLogEntry, err error) {
+	if m.auditMan == nil {
+		return
+	}
+
+	if err != nil {
+		entry.Message = err.Error()
+		if entry.Outcome == "" {
+			entry.Outcome = model.AuditOutcomeFailure
+		}
+	}
+	if entry.Outcome == "" {
+		entry.Outcome = model.AuditOutcomeSuccess
+	}
+	m.auditMan.Record(ctx, entry)
+}
+
+// getActor returns the id of the user or integration the claims belong to
+func getActor(claims map[string]interface{}) string {
+	actor, _ := claims["id"].(string)
+	return actor
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/golang-jwt/jwt"
 	"github.com/spaceuptech/helpers"
 
+	"github.com/spaceuptech/space-cloud/gateway/model"
 	"github.com/spaceuptech/space-cloud/gateway/utils"
 )
 
@@ -16,21 +17,35 @@ func (m *Manager) GenerateToken(ctx context.Context, token string, tokenClaims m
 	m.lock.RLock()
 	defer m.lock.RUnlock()
 
+	// The id of the token being generated is recorded as the resource id in the audit log
+	entry := &model.AuditLogEntry{Kind: model.AuditKindToken, Resource: "admin-token", Verb: "create"}
+	entry.ResourceID, _ = tokenClaims["id"].(string)
+
 	claims, err := m.parseToken(ctx, token)
 	if err != nil {
+		entry.Outcome = model.AuditOutcomeDenied
+		m.recordAudit(ctx, entry, err)
 		return "", err
 	}
+	entry.Actor = getActor(claims)
 
 	res := m.integrationMan.HandleConfigAuth(ctx, "admin-token", "create", claims, nil)
 	if res.CheckResponse() {
 		if err := res.Error(); err != nil {
+			entry.Outcome = model.AuditOutcomeDenied
+			m.recordAudit(ctx, entry, err)
 			return "", err
 		}
 
-		return m.createToken(tokenClaims)
+		newToken, err := m.createToken(tokenClaims)
+		m.recordAudit(ctx, entry, err)
+		return newToken, err
 	}
 
-	return "", errors.New("only integrations are allowed to generate token")
+	err = errors.New("only integrations are allowed to generate token")
+	entry.Outcome = model.AuditOutcomeDenied
+	m.recordAudit(ctx, entry, err)
+	return "", err
 }
 
 func (m *Manager) createToken(tokenClaims map[string]interface{}) (string, error) {
@@ -5,6 +5,8 @@ import (
 	"net/http"
 
 	"github.com/spaceuptech/helpers"
+	"golang.org/x/crypto/bcrypt"
+
 	"github.com/spaceuptech/space-cloud/gateway/model"
 )
 
@@ -26,6 +28,7 @@ func (m *Manager) Login(ctx context.Context, user, pass string) (int, string, er
 	if hookResponse.CheckResponse() {
 		// Check if an error occurred
 		if err := hookResponse.Error(); err != nil {
+			m.recordAudit(ctx, &model.AuditLogEntry{Kind: model.AuditKindLogin, Actor: user, Resource: params.Resource, Verb: params.Op, Outcome: model.AuditOutcomeDenied}, err)
 			return hookResponse.Status(), "", err
 		}
 
@@ -33,16 +36,30 @@ func (m *Manager) Login(ctx context.Context, user, pass string) (int, string, er
 		res := hookResponse.Result().(map[string]interface{})
 
 		// Return the token
+		m.recordAudit(ctx, &model.AuditLogEntry{Kind: model.AuditKindLogin, Actor: user, Resource: params.Resource, Verb: params.Op}, nil)
 		return http.StatusOK, res["token"].(string), nil
 	}
 
 	if m.user.User == user && m.user.Pass == pass {
 		token, err := m.createToken(map[string]interface{}{"id": user, "role": "admin"})
+		m.recordAudit(ctx, &model.AuditLogEntry{Kind: model.AuditKindLogin, Actor: user, Resource: params.Resource, Verb: params.Op}, err)
+		if err != nil {
+			return http.StatusInternalServerError, "", err
+		}
+		return http.StatusOK, token, nil
+	}
+
+	// Admin users and service accounts get a token which is restricted to their roles
+	if u, p := m.adminUsers.Get(user); p && bcrypt.CompareHashAndPassword([]byte(u.Key), []byte(pass)) == nil {
+		token, err := m.createToken(map[string]interface{}{"id": u.ID, "role": u.Kind})
+		m.recordAudit(ctx, &model.AuditLogEntry{Kind: model.AuditKindLogin, Actor: user, Resource: params.Resource, Verb: params.Op}, err)
 		if err != nil {
 			return http.StatusInternalServerError, "", err
 		}
 		return http.StatusOK, token, nil
 	}
 
-	return http.StatusUnauthorized, "", helpers.Logger.LogError(helpers.GetRequestID(ctx), "Invalid username or password provided", nil, map[string]interface{}{"user": user})
+	err := helpers.Logger.LogError(helpers.GetRequestID(ctx), "Invalid username or password provided", nil, map[string]interface{}{"user": user})
+	m.recordAudit(ctx, &model.AuditLogEntry{Kind: model.AuditKindLogin, Actor: user, Resource: params.Resource, Verb: params.Op, Outcome: model.AuditOutcomeDenied}, err)
+	return http.StatusUnauthorized, "", err
 }
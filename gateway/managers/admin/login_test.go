@@ -6,8 +6,10 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/mock"
+	"golang.org/x/crypto/bcrypt"
 
 	"github.com/spaceuptech/space-cloud/gateway/config"
+	"github.com/spaceuptech/space-cloud/gateway/model"
 )
 
 func TestManager_Login(t *testing.T) {
@@ -25,6 +27,7 @@ func TestManager_Login(t *testing.T) {
 		args            args
 		integrationArgs []mockArgs
 		want            int
+		wantOutcome     string
 		wantErr         bool
 	}{
 		{
@@ -40,8 +43,9 @@ func TestManager_Login(t *testing.T) {
 					paramsReturned: []interface{}{mockIntegrationResponse{}},
 				},
 			},
-			want:    http.StatusOK,
-			wantErr: false,
+			want:        http.StatusOK,
+			wantOutcome: model.AuditOutcomeSuccess,
+			wantErr:     false,
 		},
 		{
 			name: "Invalid login credentials provided",
@@ -56,8 +60,42 @@ func TestManager_Login(t *testing.T) {
 					paramsReturned: []interface{}{mockIntegrationResponse{}},
 				},
 			},
-			want:    http.StatusUnauthorized,
-			wantErr: true,
+			want:        http.StatusUnauthorized,
+			wantOutcome: model.AuditOutcomeDenied,
+			wantErr:     true,
+		},
+		{
+			name: "valid credentials of an admin user provided",
+			args: args{
+				user: "dev",
+				pass: "dev-key",
+			},
+			integrationArgs: []mockArgs{
+				{
+					method:         "InvokeHook",
+					args:           []interface{}{mock.Anything},
+					paramsReturned: []interface{}{mockIntegrationResponse{}},
+				},
+			},
+			want:        http.StatusOK,
+			wantOutcome: model.AuditOutcomeSuccess,
+		},
+		{
+			name: "invalid key of an admin user provided",
+			args: args{
+				user: "dev",
+				pass: "123",
+			},
+			integrationArgs: []mockArgs{
+				{
+					method:         "InvokeHook",
+					args:           []interface{}{mock.Anything},
+					paramsReturned: []interface{}{mockIntegrationResponse{}},
+				},
+			},
+			want:        http.StatusUnauthorized,
+			wantOutcome: model.AuditOutcomeDenied,
+			wantErr:     true,
 		},
 		{
 			name: "integration hijack - success",
@@ -75,7 +113,8 @@ func TestManager_Login(t *testing.T) {
 					}},
 				},
 			},
-			want: http.StatusOK,
+			want:        http.StatusOK,
+			wantOutcome: model.AuditOutcomeSuccess,
 		},
 		{
 			name: "integration hijack - hook failure",
@@ -91,8 +130,9 @@ func TestManager_Login(t *testing.T) {
 					}},
 				},
 			},
-			want:    http.StatusInternalServerError,
-			wantErr: true,
+			want:        http.StatusInternalServerError,
+			wantOutcome: model.AuditOutcomeDenied,
+			wantErr:     true,
 		},
 		{
 			name: "integration hijack - failure response",
@@ -108,11 +148,17 @@ func TestManager_Login(t *testing.T) {
 					}},
 				},
 			},
-			want:    http.StatusForbidden,
-			wantErr: true,
+			want:        http.StatusForbidden,
+			wantOutcome: model.AuditOutcomeDenied,
+			wantErr:     true,
 		},
 	}
 	m := New("nodeID", "clusterID", true, &config.AdminUser{User: "admin", Pass: "123", Secret: "some-secret"})
+	key, err := bcrypt.GenerateFromPassword([]byte("dev-key"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatalf("Unable to hash key - %v", err)
+	}
+	m.SetAdminUsers(config.AdminUsers{"clusterID--noProject--admin-user--dev": {ID: "dev", Kind: config.AdminUserKindUser, Key: string(key)}})
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			i := &mockIntegrationManager{}
@@ -122,6 +168,12 @@ func TestManager_Login(t *testing.T) {
 
 			m.integrationMan = i
 
+			a := &mockAuditManager{}
+			a.On("Record", mock.Anything, mock.MatchedBy(func(entry *model.AuditLogEntry) bool {
+				return entry.Kind == model.AuditKindLogin && entry.Actor == tt.args.user && entry.Outcome == tt.wantOutcome
+			})).Return()
+			m.auditMan = a
+
 			got, _, err := m.Login(context.Background(), tt.args.user, tt.args.pass)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Login() error = %v, wantErr %v", err, tt.wantErr)
@@ -130,6 +182,7 @@ func TestManager_Login(t *testing.T) {
 			if got != tt.want {
 				t.Errorf("Login() got = %v, want %v", got, tt.want)
 			}
+			a.AssertExpectations(t)
 		})
 	}
 }
@@ -3,9 +3,6 @@ package admin
 import (
 	"context"
 	"net/http"
-	"strings"
-
-	"github.com/spaceuptech/helpers"
 
 	"github.com/spaceuptech/space-cloud/gateway/config"
 	"github.com/spaceuptech/space-cloud/gateway/model"
@@ -29,14 +26,33 @@ func (m *Manager) IsTokenValid(ctx context.Context, token, resource, op string,
 		return model.RequestParams{}, nil
 	}
 
+	// Only the denials are recorded in the audit log. The operations which are allowed record their own outcome
+	entry := &model.AuditLogEntry{Kind: model.AuditKindAuthorization, Project: attr["project"], Resource: resource, Verb: op, Outcome: model.AuditOutcomeDenied}
+
 	claims, err := m.parseToken(ctx, token)
 	if err != nil {
+		m.recordAudit(ctx, entry, err)
 		return model.RequestParams{}, err
 	}
 
+	// Admin users and service accounts are only allowed to perform the operations their roles permit
+	if isAdminUserRequest(claims) {
+		user, err := m.getAdminUser(ctx, claims)
+		if err == nil {
+			err = checkAdminUserPermissions(ctx, user, resource, op, attr)
+		}
+		if err != nil {
+			entry.Actor = getActor(claims)
+			m.recordAudit(ctx, entry, err)
+			return model.RequestParams{}, err
+		}
+	}
+
 	// Check if its an integration request and return the integration response if its an integration request
 	res := m.integrationMan.HandleConfigAuth(ctx, resource, op, claims, attr)
 	if res.CheckResponse() && res.Error() != nil {
+		entry.Actor = getActor(claims)
+		m.recordAudit(ctx, entry, res.Error())
 		return model.RequestParams{}, res.Error()
 	}
 
@@ -44,33 +60,6 @@ func (m *Manager) IsTokenValid(ctx context.Context, token, resource, op string,
 	return model.RequestParams{Resource: resource, Op: op, Attributes: attr, Claims: claims}, nil
 }
 
-// CheckIfAdmin simply checks the token
-func (m *Manager) CheckIfAdmin(ctx context.Context, token string) error {
-	m.lock.RLock()
-	defer m.lock.RUnlock()
-
-	if !m.isProd {
-		return nil
-	}
-
-	claims, err := m.parseToken(ctx, token)
-	if err != nil {
-		return err
-	}
-
-	// Check if role is admin
-	role, p := claims["role"]
-	if !p {
-		return helpers.Logger.LogError(helpers.GetRequestID(ctx), "Invalid token provided. Claim `role` is absent.", nil, nil)
-	}
-
-	if !strings.Contains(role.(string), "admin") {
-		return helpers.Logger.LogError(helpers.GetRequestID(ctx), "Only admins are authorised to make this request.", nil, nil)
-	}
-
-	return nil
-}
-
 // IsDBConfigValid checks if the database config is valid
 func (m *Manager) IsDBConfigValid(config config.DatabaseConfigs) error {
 	m.lock.RLock()
@@ -131,13 +120,32 @@ func (m *Manager) ValidateProjectSyncOperation(c *config.Config, project *config
 func (m *Manager) RefreshToken(ctx context.Context, token string) (string, error) {
 	m.lock.RLock()
 	defer m.lock.RUnlock()
+	entry := &model.AuditLogEntry{Kind: model.AuditKindToken, Resource: "admin-token", Verb: "refresh"}
+
 	// Parse the token to get userID and userRole
 	tokenClaims, err := m.parseToken(ctx, token)
 	if err != nil {
+		entry.Outcome = model.AuditOutcomeDenied
+		m.recordAudit(ctx, entry, err)
 		return "", err
 	}
+	entry.Actor = getActor(tokenClaims)
+	entry.ResourceID = entry.Actor
+
+	// The claims of admin users and service accounts are regenerated so that the token reflects the current state of the user
+	if isAdminUserRequest(tokenClaims) {
+		user, err := m.getAdminUser(ctx, tokenClaims)
+		if err != nil {
+			entry.Outcome = model.AuditOutcomeDenied
+			m.recordAudit(ctx, entry, err)
+			return "", err
+		}
+		tokenClaims = map[string]interface{}{"id": user.ID, "role": user.Kind}
+	}
+
 	// Create a new token
 	newToken, err := m.createToken(tokenClaims)
+	m.recordAudit(ctx, entry, err)
 	if err != nil {
 		return "", err
 	}
@@ -171,5 +179,17 @@ func (m *Manager) GetPermissions(ctx context.Context, params model.RequestParams
 		return hookResponse.Status(), hookResponse.Result(), nil
 	}
 
+	// Admin users and service accounts only have the permissions granted by their roles
+	if isAdminUserRequest(params.Claims) {
+		m.lock.RLock()
+		defer m.lock.RUnlock()
+
+		user, err := m.getAdminUser(ctx, params.Claims)
+		if err != nil {
+			return http.StatusUnauthorized, nil, err
+		}
+		return http.StatusOK, getRolePermissions(user.Roles), nil
+	}
+
 	return http.StatusOK, []interface{}{map[string]interface{}{"project": "*", "resource": "*", "verb": "*"}}, nil
 }
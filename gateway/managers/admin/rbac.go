@@ -0,0 +1,138 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spaceuptech/helpers"
+
+	"github.com/spaceuptech/space-cloud/gateway/config"
+	"github.com/spaceuptech/space-cloud/gateway/utils"
+)
+
+// resourceProjectSecrets is the resource required to read the secrets and aes key of a project
+const resourceProjectSecrets = "project-secrets"
+
+// explicitResources are the resources which can't be granted through a wildcard. They need to be listed in the role
+var explicitResources = []string{"creds", "internal-token", "admin-user", resourceProjectSecrets}
+
+// SetAdminUsers sets the admin users and service accounts of the cluster
+func (m *Manager) SetAdminUsers(users config.AdminUsers) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.adminUsers = users
+}
+
+// isAdminUserRequest checks if the claims belong to an admin user or service account
+func isAdminUserRequest(claims map[string]interface{}) bool {
+	role, _ := claims["role"].(string)
+	return role == config.AdminUserKindUser || role == config.AdminUserKindServiceAccount
+}
+
+// getAdminUser returns the admin user the claims belong to. It returns an error if the user has been deleted or its
+// kind has changed since the token was issued. The lock must be held by the caller
+func (m *Manager) getAdminUser(ctx context.Context, claims map[string]interface{}) (*config.AdminUserConfig, error) {
+	id := getActor(claims)
+	user, p := m.adminUsers.Get(id)
+	if !p || user.Kind != claims["role"] {
+		return nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Admin user (%s) does not exist", id), nil, nil)
+	}
+	return user, nil
+}
+
+// CanReadProjectSecrets checks if the token is allowed to read the secrets and aes key of the project. Unlike
+// IsTokenValid, denials aren't recorded in the audit log since the project config is still returned without them
+func (m *Manager) CanReadProjectSecrets(ctx context.Context, token, projectID string) bool {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	if !m.isProd {
+		return true
+	}
+
+	claims, err := m.parseToken(ctx, token)
+	if err != nil {
+		return false
+	}
+
+	attr := map[string]string{"project": projectID}
+	if isAdminUserRequest(claims) {
+		user, err := m.getAdminUser(ctx, claims)
+		if err != nil || !hasPermission(user.Roles, resourceProjectSecrets, "read", attr) {
+			return false
+		}
+	}
+
+	res := m.integrationMan.HandleConfigAuth(ctx, resourceProjectSecrets, "read", claims, attr)
+	return !res.CheckResponse() || res.Error() == nil
+}
+
+// checkAdminUserPermissions checks if any of the roles of the admin user allow the operation
+func checkAdminUserPermissions(ctx context.Context, user *config.AdminUserConfig, resource, op string, attr map[string]string) error {
+	if hasPermission(user.Roles, resource, op, attr) {
+		return nil
+	}
+	return helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Admin user (%s) does not have the required permissions", user.ID), nil, map[string]interface{}{"resource": resource, "verb": op, "attributes": attr})
+}
+
+func hasPermission(roles []config.IntegrationPermission, resource, op string, attr map[string]string) bool {
+	for _, role := range roles {
+		// Check if the resource types match
+		if !matchResource(role.Resources, resource) {
+			continue
+		}
+
+		// Check if the op matches
+		if !utils.StringExists(role.Verbs, "*", op) {
+			continue
+		}
+
+		// Check if the attributes match
+		if !matchAttributes(role.Attributes, attr) {
+			continue
+		}
+
+		return true
+	}
+	return false
+}
+
+func matchResource(resources []string, resource string) bool {
+	if utils.StringExists(resources, resource) {
+		return true
+	}
+	return utils.StringExists(resources, "*") && !utils.StringExists(explicitResources, resource)
+}
+
+// matchAttributes checks if the attributes of the request are allowed. An attribute restricted by the role must be
+// present in the request even if the role allows all of its values. Hence a role for all projects doesn't grant
+// access to the cluster level resources
+func matchAttributes(allowed config.Attributes, attr map[string]string) bool {
+	for k, values := range allowed {
+		val, p := attr[k]
+		if !p || !utils.StringExists(values, "*", val) {
+			return false
+		}
+	}
+	return true
+}
+
+// getRolePermissions converts the roles to the format returned by `GetPermissions`
+func getRolePermissions(roles []config.IntegrationPermission) []interface{} {
+	permissions := make([]interface{}, 0)
+	for _, role := range roles {
+		projects := role.Attributes["project"]
+		if len(projects) == 0 {
+			projects = []string{"*"}
+		}
+
+		for _, project := range projects {
+			for _, resource := range role.Resources {
+				for _, verb := range role.Verbs {
+					permissions = append(permissions, map[string]interface{}{"project": project, "resource": resource, "verb": verb})
+				}
+			}
+		}
+	}
+	return permissions
+}
@@ -0,0 +1,325 @@
+package admin
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/spaceuptech/space-cloud/gateway/config"
+	"github.com/spaceuptech/space-cloud/gateway/model"
+)
+
+func newRBACTestManager() *Manager {
+	m := New("nodeID", "clusterID", false, &config.AdminUser{User: "admin", Pass: "123", Secret: "some-secret"})
+	m.SetAdminUsers(config.AdminUsers{
+		"clusterID--noProject--admin-user--viewer": {ID: "viewer", Kind: config.AdminUserKindUser, Roles: []config.IntegrationPermission{
+			{Resources: []string{"*"}, Verbs: []string{"read"}, Attributes: config.Attributes{"project": []string{"*"}}},
+		}},
+		"clusterID--noProject--admin-user--editor": {ID: "editor", Kind: config.AdminUserKindUser, Roles: []config.IntegrationPermission{
+			{Resources: []string{"*"}, Verbs: []string{"*"}, Attributes: config.Attributes{"project": []string{"*"}}},
+		}},
+		"clusterID--noProject--admin-user--operator": {ID: "operator", Kind: config.AdminUserKindUser, Roles: []config.IntegrationPermission{
+			{Resources: []string{"*"}, Verbs: []string{"*"}},
+		}},
+		"clusterID--noProject--admin-user--ci": {ID: "ci", Kind: config.AdminUserKindServiceAccount, Roles: []config.IntegrationPermission{
+			{Resources: []string{"db-rule"}, Verbs: []string{"read", "modify"}, Attributes: config.Attributes{"project": []string{"myproject"}}},
+		}},
+	})
+	return m
+}
+
+func TestManager_IsTokenValid_adminUsers(t *testing.T) {
+	tests := []struct {
+		name     string
+		claims   map[string]interface{}
+		resource string
+		op       string
+		attr     map[string]string
+		wantErr  bool
+	}{
+		{
+			name:     "read only user can read any project",
+			claims:   map[string]interface{}{"id": "viewer", "role": config.AdminUserKindUser},
+			resource: "db-rule",
+			op:       "read",
+			attr:     map[string]string{"project": "otherproject", "db": "db"},
+		},
+		{
+			name:     "read only user of all projects cannot read cluster level resources",
+			claims:   map[string]interface{}{"id": "viewer", "role": config.AdminUserKindUser},
+			resource: "cluster",
+			op:       "read",
+			attr:     map[string]string{},
+			wantErr:  true,
+		},
+		{
+			name:     "cluster wide user can read cluster level resources",
+			claims:   map[string]interface{}{"id": "operator", "role": config.AdminUserKindUser},
+			resource: "cluster",
+			op:       "read",
+			attr:     map[string]string{},
+		},
+		{
+			name:     "user which can modify all projects cannot modify admin users",
+			claims:   map[string]interface{}{"id": "editor", "role": config.AdminUserKindUser},
+			resource: "admin-user",
+			op:       "modify",
+			attr:     map[string]string{},
+			wantErr:  true,
+		},
+		{
+			name:     "admin users are not granted through a wildcard",
+			claims:   map[string]interface{}{"id": "operator", "role": config.AdminUserKindUser},
+			resource: "admin-user",
+			op:       "modify",
+			attr:     map[string]string{},
+			wantErr:  true,
+		},
+		{
+			name:     "user which can modify all projects can modify any project",
+			claims:   map[string]interface{}{"id": "editor", "role": config.AdminUserKindUser},
+			resource: "db-rule",
+			op:       "modify",
+			attr:     map[string]string{"project": "otherproject"},
+		},
+		{
+			name:     "read only user cannot modify",
+			claims:   map[string]interface{}{"id": "viewer", "role": config.AdminUserKindUser},
+			resource: "db-rule",
+			op:       "modify",
+			attr:     map[string]string{"project": "myproject"},
+			wantErr:  true,
+		},
+		{
+			name:     "credentials are not granted through a wildcard",
+			claims:   map[string]interface{}{"id": "viewer", "role": config.AdminUserKindUser},
+			resource: "creds",
+			op:       "read",
+			wantErr:  true,
+		},
+		{
+			name:     "internal tokens are not granted through a wildcard",
+			claims:   map[string]interface{}{"id": "viewer", "role": config.AdminUserKindUser},
+			resource: "internal-token",
+			op:       "access",
+			attr:     map[string]string{"project": "myproject"},
+			wantErr:  true,
+		},
+		{
+			name:     "service account can modify the rules of its project",
+			claims:   map[string]interface{}{"id": "ci", "role": config.AdminUserKindServiceAccount},
+			resource: "db-rule",
+			op:       "modify",
+			attr:     map[string]string{"project": "myproject", "db": "db", "col": "users"},
+		},
+		{
+			name:     "service account cannot modify the rules of another project",
+			claims:   map[string]interface{}{"id": "ci", "role": config.AdminUserKindServiceAccount},
+			resource: "db-rule",
+			op:       "modify",
+			attr:     map[string]string{"project": "otherproject"},
+			wantErr:  true,
+		},
+		{
+			name:     "service account cannot modify other resources of its project",
+			claims:   map[string]interface{}{"id": "ci", "role": config.AdminUserKindServiceAccount},
+			resource: "db-schema",
+			op:       "modify",
+			attr:     map[string]string{"project": "myproject"},
+			wantErr:  true,
+		},
+		{
+			name:     "service account cannot access cluster level resources",
+			claims:   map[string]interface{}{"id": "ci", "role": config.AdminUserKindServiceAccount},
+			resource: "db-rule",
+			op:       "read",
+			attr:     map[string]string{},
+			wantErr:  true,
+		},
+		{
+			name:     "deleted user is denied",
+			claims:   map[string]interface{}{"id": "intern", "role": config.AdminUserKindUser},
+			resource: "db-rule",
+			op:       "read",
+			attr:     map[string]string{"project": "myproject"},
+			wantErr:  true,
+		},
+		{
+			name:     "token of a different kind is denied",
+			claims:   map[string]interface{}{"id": "ci", "role": config.AdminUserKindUser},
+			resource: "db-rule",
+			op:       "read",
+			attr:     map[string]string{"project": "myproject"},
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i := &mockIntegrationManager{}
+			i.On("HandleConfigAuth", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(mockIntegrationResponse{})
+
+			m := newRBACTestManager()
+			m.isProd = true
+			m.integrationMan = i
+
+			token, err := m.createToken(tt.claims)
+			if err != nil {
+				t.Fatalf("createToken() error = %v", err)
+			}
+			if _, err := m.IsTokenValid(context.Background(), token, tt.resource, tt.op, tt.attr); (err != nil) != tt.wantErr {
+				t.Errorf("IsTokenValid() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestManager_CanReadProjectSecrets(t *testing.T) {
+	tests := []struct {
+		name      string
+		claims    map[string]interface{}
+		projectID string
+		want      bool
+	}{
+		{
+			name:      "admin can read the secrets",
+			claims:    map[string]interface{}{"id": "admin", "role": "admin"},
+			projectID: "myproject",
+			want:      true,
+		},
+		{
+			name:      "secrets are not granted through a wildcard",
+			claims:    map[string]interface{}{"id": "viewer", "role": config.AdminUserKindUser},
+			projectID: "myproject",
+		},
+		{
+			name:      "explicit grant allows reading the secrets of the project",
+			claims:    map[string]interface{}{"id": "ci", "role": config.AdminUserKindServiceAccount},
+			projectID: "myproject",
+			want:      true,
+		},
+		{
+			name:      "explicit grant doesn't allow reading the secrets of another project",
+			claims:    map[string]interface{}{"id": "ci", "role": config.AdminUserKindServiceAccount},
+			projectID: "otherproject",
+		},
+		{
+			name:      "deleted user cannot read the secrets",
+			claims:    map[string]interface{}{"id": "intern", "role": config.AdminUserKindUser},
+			projectID: "myproject",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i := &mockIntegrationManager{}
+			i.On("HandleConfigAuth", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(mockIntegrationResponse{})
+
+			m := newRBACTestManager()
+			m.isProd = true
+			m.integrationMan = i
+			ci, _ := m.adminUsers.Get("ci")
+			ci.Roles = append(ci.Roles, config.IntegrationPermission{Resources: []string{"project-secrets"}, Verbs: []string{"read"}, Attributes: config.Attributes{"project": []string{"myproject"}}})
+
+			token, err := m.createToken(tt.claims)
+			if err != nil {
+				t.Fatalf("createToken() error = %v", err)
+			}
+			if got := m.CanReadProjectSecrets(context.Background(), token, tt.projectID); got != tt.want {
+				t.Errorf("CanReadProjectSecrets() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestManager_RefreshToken_adminUsers(t *testing.T) {
+	tests := []struct {
+		name       string
+		claims     map[string]interface{}
+		wantClaims map[string]interface{}
+		wantErr    bool
+	}{
+		{
+			name:       "claims are regenerated from the current user",
+			claims:     map[string]interface{}{"id": "ci", "role": config.AdminUserKindServiceAccount, "scope": "stale"},
+			wantClaims: map[string]interface{}{"id": "ci", "role": config.AdminUserKindServiceAccount},
+		},
+		{
+			name:    "deleted user cannot refresh its token",
+			claims:  map[string]interface{}{"id": "intern", "role": config.AdminUserKindUser},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newRBACTestManager()
+
+			token, err := m.createToken(tt.claims)
+			if err != nil {
+				t.Fatalf("createToken() error = %v", err)
+			}
+			got, err := m.RefreshToken(context.Background(), token)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("RefreshToken() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			claims, err := m.parseToken(context.Background(), got)
+			if err != nil {
+				t.Fatalf("parseToken() error = %v", err)
+			}
+			delete(claims, "exp")
+			if !reflect.DeepEqual(claims, tt.wantClaims) {
+				t.Errorf("RefreshToken() claims = %v, want %v", claims, tt.wantClaims)
+			}
+		})
+	}
+}
+
+func TestManager_GetPermissions(t *testing.T) {
+	tests := []struct {
+		name    string
+		claims  map[string]interface{}
+		want    interface{}
+		wantErr bool
+	}{
+		{
+			name:   "admin has all the permissions",
+			claims: map[string]interface{}{"id": "admin", "role": "admin"},
+			want:   []interface{}{map[string]interface{}{"project": "*", "resource": "*", "verb": "*"}},
+		},
+		{
+			name:   "service account has the permissions of its roles",
+			claims: map[string]interface{}{"id": "ci", "role": config.AdminUserKindServiceAccount},
+			want: []interface{}{
+				map[string]interface{}{"project": "myproject", "resource": "db-rule", "verb": "read"},
+				map[string]interface{}{"project": "myproject", "resource": "db-rule", "verb": "modify"},
+			},
+		},
+		{
+			name:    "deleted user has no permissions",
+			claims:  map[string]interface{}{"id": "intern", "role": config.AdminUserKindUser},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i := &mockIntegrationManager{}
+			i.On("InvokeHook", mock.Anything).Return(mockIntegrationResponse{})
+
+			m := newRBACTestManager()
+			m.integrationMan = i
+
+			params := model.RequestParams{Claims: tt.claims}
+			_, got, err := m.GetPermissions(context.Background(), params)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetPermissions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetPermissions() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -12,3 +12,8 @@ type IntegrationInterface interface {
 	HandleConfigAuth(ctx context.Context, resource, op string, claims map[string]interface{}, attr map[string]string) config.IntegrationAuthResponse
 	InvokeHook(ctx context.Context, params model.RequestParams) config.IntegrationAuthResponse
 }
+
+// AuditInterface is used to describe the features of the audit manager we need
+type AuditInterface interface {
+	Record(ctx context.Context, entry *model.AuditLogEntry)
+}
@@ -47,3 +47,11 @@ func (m mockIntegrationResponse) Error() error {
 	}
 	return errors.New(m.err)
 }
+
+type mockAuditManager struct {
+	mock.Mock
+}
+
+func (m *mockAuditManager) Record(ctx context.Context, entry *model.AuditLogEntry) {
+	m.Called(ctx, entry)
+}
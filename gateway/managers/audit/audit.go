@@ -0,0 +1,172 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/segmentio/ksuid"
+	"github.com/spaceuptech/helpers"
+
+	"github.com/spaceuptech/space-cloud/gateway/config"
+	"github.com/spaceuptech/space-cloud/gateway/model"
+	"github.com/spaceuptech/space-cloud/gateway/utils"
+)
+
+const defaultAuditLogLimit int64 = 100
+
+// Manager records the audit log of the admin and config operations of the cluster
+type Manager struct {
+	lock sync.RWMutex
+
+	nodeID string
+	config *config.AuditLogConfig
+
+	modules ModulesInterface
+}
+
+// New creates a new instance of the audit manager
+func New(nodeID string) *Manager {
+	return &Manager{nodeID: nodeID}
+}
+
+// SetModules sets the modules the audit log is stored through
+func (m *Manager) SetModules(modules ModulesInterface) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.modules = modules
+}
+
+// SetConfig sets the config of the audit log. A nil config disables the audit log
+func (m *Manager) SetConfig(c *config.AuditLogConfig) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.config = c
+}
+
+func (m *Manager) isEnabled() bool {
+	return m.config != nil && m.config.Enabled && m.modules != nil
+}
+
+// Record appends the entry to the audit log. The operation being audited has already taken place at this point, so
+// a failure to record it is only logged
+func (m *Manager) Record(ctx context.Context, entry *model.AuditLogEntry) {
+	if m == nil {
+		return
+	}
+
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	if !m.isEnabled() {
+		return
+	}
+
+	entry.ID = ksuid.New().String()
+	entry.Timestamp = time.Now().UTC().Format(time.RFC3339Nano)
+	entry.NodeID = m.nodeID
+	if entry.RequestID == "" {
+		entry.RequestID = helpers.GetRequestID(ctx)
+	}
+
+	crud, err := m.modules.GetCrudModuleForAudit(m.config.Project)
+	if err != nil {
+		_ = helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to record entry in audit log", err, map[string]interface{}{"kind": entry.Kind, "resourceId": entry.ResourceID})
+		return
+	}
+
+	doc := map[string]interface{}{}
+	if err := mapstructure.Decode(entry, &doc); err != nil {
+		_ = helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to record entry in audit log", err, map[string]interface{}{"kind": entry.Kind, "resourceId": entry.ResourceID})
+		return
+	}
+
+	createRequest := &model.CreateRequest{Document: doc, Operation: utils.One}
+	if err := crud.InternalCreate(ctx, m.config.DBAlias, m.config.Project, utils.TableAuditLogs, createRequest, true); err != nil {
+		_ = helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to record entry in audit log", err, map[string]interface{}{"kind": entry.Kind, "resourceId": entry.ResourceID})
+	}
+}
+
+// ListAuditLogs lists the entries of the audit log matching the filter. The latest entries are returned first
+func (m *Manager) ListAuditLogs(ctx context.Context, filter *model.AuditLogFilter) ([]*model.AuditLogEntry, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	if !m.isEnabled() {
+		return nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), "Audit log is not enabled for this cluster", nil, nil)
+	}
+
+	find, err := generateAuditLogFind(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultAuditLogLimit
+	}
+	if limit > model.DefaultFetchLimit {
+		limit = model.DefaultFetchLimit
+	}
+	skip := filter.Skip
+
+	crud, err := m.modules.GetCrudModuleForAudit(m.config.Project)
+	if err != nil {
+		return nil, err
+	}
+
+	attr := map[string]string{"project": m.config.Project, "db": m.config.DBAlias, "col": utils.TableAuditLogs}
+	reqParams := model.RequestParams{Resource: "db-read", Op: "access", Attributes: attr}
+	readRequest := &model.ReadRequest{Find: find, Operation: utils.All, Options: &model.ReadOptions{Sort: []string{"-timestamp"}, Limit: &limit, Skip: &skip}}
+	results, _, err := crud.Read(ctx, m.config.DBAlias, utils.TableAuditLogs, readRequest, reqParams)
+	if err != nil {
+		return nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to read entries of audit log", err, nil)
+	}
+
+	entries := make([]*model.AuditLogEntry, 0)
+	for _, result := range results.([]interface{}) {
+		entry := new(model.AuditLogEntry)
+		if err := mapstructure.WeakDecode(result, entry); err != nil {
+			return nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Could not covert object (%v) to audit log entry", result), err, nil)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// generateAuditLogFind generates the find clause for the filter
+func generateAuditLogFind(filter *model.AuditLogFilter) (map[string]interface{}, error) {
+	find := map[string]interface{}{}
+	for field, value := range map[string]string{
+		"kind":        filter.Kind,
+		"actor":       filter.Actor,
+		"project":     filter.Project,
+		"resource":    filter.Resource,
+		"resource_id": filter.ResourceID,
+		"verb":        filter.Verb,
+		"outcome":     filter.Outcome,
+		"request_id":  filter.RequestID,
+	} {
+		if value != "" {
+			find[field] = value
+		}
+	}
+
+	ts := map[string]interface{}{}
+	for op, value := range map[string]string{"$gte": filter.From, "$lte": filter.To} {
+		if value == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timestamp (%s) provided - %v", value, err)
+		}
+		ts[op] = t.UTC().Format(time.RFC3339Nano)
+	}
+	if len(ts) > 0 {
+		find["timestamp"] = ts
+	}
+	return find, nil
+}
@@ -0,0 +1,175 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/spaceuptech/space-cloud/gateway/config"
+	"github.com/spaceuptech/space-cloud/gateway/model"
+	"github.com/spaceuptech/space-cloud/gateway/utils"
+)
+
+type mockModules struct {
+	mock.Mock
+}
+
+func (m *mockModules) GetCrudModuleForAudit(projectID string) (model.CrudAuditInterface, error) {
+	c := m.Called(projectID)
+	return c.Get(0).(model.CrudAuditInterface), c.Error(1)
+}
+
+type mockCrud struct {
+	mock.Mock
+}
+
+func (m *mockCrud) InternalCreate(ctx context.Context, dbAlias, project, col string, req *model.CreateRequest, isIgnoreMetrics bool) error {
+	return m.Called(ctx, dbAlias, project, col, req, isIgnoreMetrics).Error(0)
+}
+
+func (m *mockCrud) Read(ctx context.Context, dbAlias, col string, req *model.ReadRequest, params model.RequestParams) (interface{}, *model.SQLMetaData, error) {
+	c := m.Called(ctx, dbAlias, col, req, params)
+	return c.Get(0), nil, c.Error(1)
+}
+
+func TestManager_Record(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      *config.AuditLogConfig
+		createError error
+		wantCreate  bool
+	}{
+		{
+			name: "audit log is not configured",
+		},
+		{
+			name:   "audit log is disabled",
+			config: &config.AuditLogConfig{Enabled: false, Project: "myproject", DBAlias: "db"},
+		},
+		{
+			name:       "entry is recorded",
+			config:     &config.AuditLogConfig{Enabled: true, Project: "myproject", DBAlias: "db"},
+			wantCreate: true,
+		},
+		{
+			name:        "failure to record the entry is only logged",
+			config:      &config.AuditLogConfig{Enabled: true, Project: "myproject", DBAlias: "db"},
+			createError: errors.New("database is down"),
+			wantCreate:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			crud := new(mockCrud)
+			modules := new(mockModules)
+			if tt.wantCreate {
+				modules.On("GetCrudModuleForAudit", "myproject").Return(crud, nil)
+				crud.On("InternalCreate", mock.Anything, "db", "myproject", utils.TableAuditLogs, mock.MatchedBy(func(req *model.CreateRequest) bool {
+					doc, ok := req.Document.(map[string]interface{})
+					return ok && req.Operation == utils.One && doc["_id"] != "" && doc["timestamp"] != "" && doc["node_id"] == "node1" &&
+						doc["actor"] == "admin" && doc["resource_id"] == "chicago--myproject--db-rule--db-users-rule" && doc["verb"] == "update" && doc["outcome"] == model.AuditOutcomeSuccess
+				}), true).Return(tt.createError)
+			}
+
+			m := New("node1")
+			m.SetModules(modules)
+			m.SetConfig(tt.config)
+			m.Record(context.Background(), &model.AuditLogEntry{Kind: model.AuditKindConfig, Actor: "admin", ResourceID: "chicago--myproject--db-rule--db-users-rule", Verb: "update", Outcome: model.AuditOutcomeSuccess})
+
+			modules.AssertExpectations(t)
+			crud.AssertExpectations(t)
+		})
+	}
+}
+
+func TestManager_ListAuditLogs(t *testing.T) {
+	enabled := &config.AuditLogConfig{Enabled: true, Project: "myproject", DBAlias: "db"}
+	limit, skip := int64(100), int64(0)
+	params := model.RequestParams{Resource: "db-read", Op: "access", Attributes: map[string]string{"project": "myproject", "db": "db", "col": utils.TableAuditLogs}}
+
+	tests := []struct {
+		name        string
+		config      *config.AuditLogConfig
+		filter      *model.AuditLogFilter
+		wantRequest *model.ReadRequest
+		results     interface{}
+		readError   error
+		want        []*model.AuditLogEntry
+		wantErr     bool
+	}{
+		{
+			name:    "audit log is not enabled",
+			filter:  &model.AuditLogFilter{},
+			wantErr: true,
+		},
+		{
+			name:    "invalid timestamp provided",
+			config:  enabled,
+			filter:  &model.AuditLogFilter{From: "yesterday"},
+			wantErr: true,
+		},
+		{
+			name:   "entries matching the filter are returned",
+			config: enabled,
+			filter: &model.AuditLogFilter{Actor: "admin", Verb: "delete", ResourceID: "chicago--myproject--db-rule--db-users-rule", From: "2021-01-01T00:00:00+05:30"},
+			wantRequest: &model.ReadRequest{
+				Find: map[string]interface{}{
+					"actor":       "admin",
+					"verb":        "delete",
+					"resource_id": "chicago--myproject--db-rule--db-users-rule",
+					"timestamp":   map[string]interface{}{"$gte": "2020-12-31T18:30:00Z"},
+				},
+				Operation: utils.All,
+				Options:   &model.ReadOptions{Sort: []string{"-timestamp"}, Limit: &limit, Skip: &skip},
+			},
+			results: []interface{}{map[string]interface{}{"_id": "1", "actor": "admin", "verb": "delete", "outcome": "success", "resource_id": "chicago--myproject--db-rule--db-users-rule"}},
+			want:    []*model.AuditLogEntry{{ID: "1", Actor: "admin", Verb: "delete", Outcome: "success", ResourceID: "chicago--myproject--db-rule--db-users-rule"}},
+		},
+		{
+			name:   "limit is capped",
+			config: enabled,
+			filter: &model.AuditLogFilter{Limit: 5000, Skip: 10},
+			wantRequest: func() *model.ReadRequest {
+				limit, skip := int64(model.DefaultFetchLimit), int64(10)
+				return &model.ReadRequest{Find: map[string]interface{}{}, Operation: utils.All, Options: &model.ReadOptions{Sort: []string{"-timestamp"}, Limit: &limit, Skip: &skip}}
+			}(),
+			results: []interface{}{},
+			want:    []*model.AuditLogEntry{},
+		},
+		{
+			name:        "unable to read the entries",
+			config:      enabled,
+			filter:      &model.AuditLogFilter{},
+			wantRequest: &model.ReadRequest{Find: map[string]interface{}{}, Operation: utils.All, Options: &model.ReadOptions{Sort: []string{"-timestamp"}, Limit: &limit, Skip: &skip}},
+			readError:   errors.New("database is down"),
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			crud := new(mockCrud)
+			modules := new(mockModules)
+			if tt.wantRequest != nil {
+				modules.On("GetCrudModuleForAudit", "myproject").Return(crud, nil)
+				crud.On("Read", mock.Anything, "db", utils.TableAuditLogs, tt.wantRequest, params).Return(tt.results, tt.readError)
+			}
+
+			m := New("node1")
+			m.SetModules(modules)
+			m.SetConfig(tt.config)
+			got, err := m.ListAuditLogs(context.Background(), tt.filter)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ListAuditLogs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ListAuditLogs() got = %v, want %v", got, tt.want)
+			}
+
+			modules.AssertExpectations(t)
+			crud.AssertExpectations(t)
+		})
+	}
+}
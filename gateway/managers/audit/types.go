@@ -0,0 +1,10 @@
+package audit
+
+import (
+	"github.com/spaceuptech/space-cloud/gateway/model"
+)
+
+// ModulesInterface is an interface consisting of functions of the modules module used by the audit manager
+type ModulesInterface interface {
+	GetCrudModuleForAudit(projectID string) (model.CrudAuditInterface, error)
+}
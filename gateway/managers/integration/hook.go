@@ -72,6 +72,7 @@ func (m *Manager) invokeHooks(ctx context.Context, params model.RequestParams) a
 		status, err := invokeHook(ctx, hook.URL, scToken, params, &res)
 		if err != nil {
 			err = helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Unable to make request to hook (%s) in integration (%s)", hook.ID, hook.IntegrationID), err, nil)
+			m.recordHookDecision(ctx, params, hook, model.AuditOutcomeFailure, err.Error())
 
 			// Return error if this was a hook with hijack permission
 			if hook.Kind == "hijack" {
@@ -88,20 +89,23 @@ func (m *Manager) invokeHooks(ctx context.Context, params model.RequestParams) a
 		case config.ErrorHookResponse:
 			// Simply log the error. No big deal. The hook can always hijack and throw error if this was serious
 			_ = helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Hook (%s) in integration (%s) sent error response - %s", hook.ID, hook.IntegrationID, res.Error), err, nil)
+			m.recordHookDecision(ctx, params, hook, model.AuditOutcomeFailure, fmt.Sprintf("Hook (%s) sent error response - %s", hook.ID, res.Error))
 
 		case config.IgnoreHookResponse:
-			// Do nothing
+			m.recordHookDecision(ctx, params, hook, model.AuditOutcomeSuccess, fmt.Sprintf("Hook (%s) ignored the request", hook.ID))
 
 		case config.HijackHookResponse:
 			// Check if hook hook has permission to hijack
 			if hook.Kind != "hijack" {
 				helpers.Logger.LogWarn(helpers.GetRequestID(ctx), fmt.Sprintf("Hook (%s) in integration (%s) does not has permission to hijack", hook.ID, hook.IntegrationID), nil)
+				m.recordHookDecision(ctx, params, hook, model.AuditOutcomeFailure, fmt.Sprintf("Hook (%s) does not have permission to hijack the request", hook.ID))
 				break
 			}
 
 			// We will skip hijack responses if a previous hook has already claimed this
 			if hookResponse.checkResponse {
 				helpers.Logger.LogWarn(helpers.GetRequestID(ctx), fmt.Sprintf("Cannot process highjack action of hook (%s) in integration (%s) since it has already been claimed by integration (%s)", hook.ID, hook.IntegrationID, hookResponse.integration), nil)
+				m.recordHookDecision(ctx, params, hook, model.AuditOutcomeFailure, fmt.Sprintf("Hook (%s) cannot hijack the request since it has already been claimed by integration (%s)", hook.ID, hookResponse.integration))
 				break
 			}
 
@@ -117,7 +121,10 @@ func (m *Manager) invokeHooks(ctx context.Context, params model.RequestParams) a
 			// Set error if exists
 			if res.Error != "" {
 				hookResponse.err = errors.New(res.Error)
+				m.recordHookDecision(ctx, params, hook, model.AuditOutcomeDenied, fmt.Sprintf("Hook (%s) hijacked the request with error - %s", hook.ID, res.Error))
+				break
 			}
+			m.recordHookDecision(ctx, params, hook, model.AuditOutcomeSuccess, fmt.Sprintf("Hook (%s) hijacked the request", hook.ID))
 		}
 	}
 
@@ -126,6 +133,24 @@ func (m *Manager) invokeHooks(ctx context.Context, params model.RequestParams) a
 	return hookResponse
 }
 
+// recordHookDecision records the decision taken by the hook in the audit log
+func (m *Manager) recordHookDecision(ctx context.Context, params model.RequestParams, hook *config.IntegrationHook, outcome, message string) {
+	if m.auditMan == nil {
+		return
+	}
+
+	m.auditMan.Record(ctx, &model.AuditLogEntry{
+		Kind:       model.AuditKindHook,
+		Actor:      hook.IntegrationID,
+		Project:    params.Attributes["project"],
+		Resource:   params.Resource,
+		ResourceID: hook.ID,
+		Verb:       params.Op,
+		Outcome:    outcome,
+		Message:    message,
+	})
+}
+
 // HasPermissionForHook checks if a hook has the permission to hijack call
 func HasPermissionForHook(config *config.IntegrationConfig, hook *config.IntegrationHook) bool {
 	return hasPermissionForHook(config.ConfigPermissions, hook) || hasPermissionForHook(config.APIPermissions, hook)
@@ -11,12 +11,13 @@ type Manager struct {
 	lock sync.RWMutex
 
 	adminMan adminManager
+	auditMan auditManager
 
 	integrationConfig     config.Integrations
 	integrationHookConfig config.IntegrationHooks
 }
 
 // New creates a new instance of the integration module
-func New(adminMan adminManager) *Manager {
-	return &Manager{adminMan: adminMan, integrationConfig: make(config.Integrations), integrationHookConfig: make(config.IntegrationHooks)}
+func New(adminMan adminManager, auditMan auditManager) *Manager {
+	return &Manager{adminMan: adminMan, auditMan: auditMan, integrationConfig: make(config.Integrations), integrationHookConfig: make(config.IntegrationHooks)}
 }
@@ -1,13 +1,20 @@
 package integration
 
 import (
+	"context"
 	"net/http"
+
+	"github.com/spaceuptech/space-cloud/gateway/model"
 )
 
 type adminManager interface {
 	GetInternalAccessToken() (string, error)
 }
 
+type auditManager interface {
+	Record(ctx context.Context, entry *model.AuditLogEntry)
+}
+
 type authResponse struct {
 	checkResponse bool
 	err           error
@@ -3,6 +3,7 @@ package managers
 import (
 	"github.com/spaceuptech/space-cloud/gateway/config"
 	"github.com/spaceuptech/space-cloud/gateway/managers/admin"
+	"github.com/spaceuptech/space-cloud/gateway/managers/audit"
 	"github.com/spaceuptech/space-cloud/gateway/managers/integration"
 	"github.com/spaceuptech/space-cloud/gateway/managers/syncman"
 )
@@ -12,21 +13,24 @@ type Managers struct {
 	adminMan       *admin.Manager
 	syncMan        *syncman.Manager
 	integrationMan *integration.Manager
+	auditMan       *audit.Manager
 }
 
 // New creates a new managers instance
 func New(nodeID, clusterID, storeType, runnerAddr string, isDev bool, adminUserInfo *config.AdminUser, ssl *config.SSL) (*Managers, error) {
 	// Create the fundamental modules
+	auditMan := audit.New(nodeID)
 	adminMan := admin.New(nodeID, clusterID, isDev, adminUserInfo)
-	i := integration.New(adminMan)
-	syncMan, err := syncman.New(nodeID, clusterID, storeType, runnerAddr, adminMan, i, ssl)
+	i := integration.New(adminMan, auditMan)
+	syncMan, err := syncman.New(nodeID, clusterID, storeType, runnerAddr, adminMan, i, auditMan, ssl)
 	if err != nil {
 		return nil, err
 	}
 	adminMan.SetSyncMan(syncMan)
 	adminMan.SetIntegrationMan(i)
+	adminMan.SetAuditMan(auditMan)
 
-	return &Managers{adminMan: adminMan, syncMan: syncMan, integrationMan: i}, nil
+	return &Managers{adminMan: adminMan, syncMan: syncMan, integrationMan: i, auditMan: auditMan}, nil
 }
 
 // Admin returns the admin manager
@@ -43,3 +47,8 @@ func (m *Managers) Sync() *syncman.Manager {
 func (m *Managers) Integration() *integration.Manager {
 	return m.integrationMan
 }
+
+// Audit returns the audit manager
+func (m *Managers) Audit() *audit.Manager {
+	return m.auditMan
+}
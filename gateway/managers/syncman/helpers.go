@@ -136,6 +136,19 @@ func (s *Manager) validateResource(ctx context.Context, eventType string, resour
 		}
 		return false, nil
 
+	case config.ResourceAdminUser:
+		switch eventType {
+		case config.ResourceAddEvent, config.ResourceUpdateEvent:
+			value := new(config.AdminUserConfig)
+			if err := mapstructure.Decode(resource, value); err != nil {
+				return false, helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("invalid type provided for resource (%s) expecting (%v) got (%v)", resourceType, "config.AdminUserConfig{}", reflect.TypeOf(resource)), nil, nil)
+			}
+
+			if reflect.DeepEqual(globalConfig.AdminUsers[resourceID], value) {
+				return true, nil
+			}
+		}
+		return false, nil
 	}
 
 	if resourceType == config.ResourceProject {
@@ -291,6 +304,19 @@ func (s *Manager) validateResource(ctx context.Context, eventType string, resour
 			}
 		}
 		return false, nil
+	case config.ResourceFileStoreAlias:
+		switch eventType {
+		case config.ResourceAddEvent, config.ResourceUpdateEvent:
+			value := new(config.FileStoreConfig)
+			if err := mapstructure.Decode(resource, value); err != nil {
+				return false, helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("invalid type provided for resource (%s) expecting (%v) got (%v)", resourceType, "config.FileStoreConfig{}", reflect.TypeOf(resource)), nil, nil)
+			}
+
+			if reflect.DeepEqual(project.FileStores[resourceID], value) {
+				return true, nil
+			}
+		}
+		return false, nil
 	case config.ResourceFileStoreRule:
 		switch eventType {
 		case config.ResourceAddEvent, config.ResourceUpdateEvent:
@@ -356,6 +382,19 @@ func (s *Manager) validateResource(ctx context.Context, eventType string, resour
 			}
 		}
 		return false, nil
+	case config.ResourceGraphQLPersistedQuery:
+		switch eventType {
+		case config.ResourceAddEvent, config.ResourceUpdateEvent:
+			value := new(config.GraphQLPersistedQuery)
+			if err := mapstructure.Decode(resource, value); err != nil {
+				return false, helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("invalid type provided for resource (%s) expecting (%v) got (%v)", resourceType, "config.GraphQLPersistedQuery{}", reflect.TypeOf(resource)), nil, nil)
+			}
+
+			if reflect.DeepEqual(project.GraphQLPersistedQueries[resourceID], value) {
+				return true, nil
+			}
+		}
+		return false, nil
 	default:
 		return false, fmt.Errorf("unknown resource type (%s) provided", resourceType)
 	}
@@ -444,6 +483,25 @@ func updateResource(ctx context.Context, eventType string, globalConfig *config.
 		}
 
 		return nil
+
+	case config.ResourceAdminUser:
+		switch eventType {
+		case config.ResourceAddEvent, config.ResourceUpdateEvent:
+			value := new(config.AdminUserConfig)
+			if err := mapstructure.Decode(resource, value); err != nil {
+				return helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("invalid type provided for resource (%s) expecting (%v) got (%v)", resourceType, "config.AdminUserConfig{}", reflect.TypeOf(resource)), nil, nil)
+			}
+
+			if globalConfig.AdminUsers == nil {
+				globalConfig.AdminUsers = config.AdminUsers{resourceID: value}
+			} else {
+				globalConfig.AdminUsers[resourceID] = value
+			}
+
+		case config.ResourceDeleteEvent:
+			delete(globalConfig.AdminUsers, resourceID)
+		}
+		return nil
 	}
 
 	// check project level resources
@@ -654,6 +712,25 @@ func updateResource(ctx context.Context, eventType string, globalConfig *config.
 
 		return nil
 
+	case config.ResourceFileStoreAlias:
+		switch eventType {
+		case config.ResourceAddEvent, config.ResourceUpdateEvent:
+			value := new(config.FileStoreConfig)
+			if err := mapstructure.Decode(resource, value); err != nil {
+				return helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("invalid type provided for resource (%s) expecting (%v) got (%v)", resourceType, "config.FileStoreConfig{}", reflect.TypeOf(resource)), nil, nil)
+			}
+
+			if project.FileStores == nil {
+				project.FileStores = config.FileStores{resourceID: value}
+			} else {
+				project.FileStores[resourceID] = value
+			}
+		case config.ResourceDeleteEvent:
+			delete(project.FileStores, resourceID)
+		}
+
+		return nil
+
 	case config.ResourceFileStoreRule:
 		switch eventType {
 		case config.ResourceAddEvent, config.ResourceUpdateEvent:
@@ -739,11 +816,129 @@ func updateResource(ctx context.Context, eventType string, globalConfig *config.
 
 		return nil
 
+	case config.ResourceGraphQLPersistedQuery:
+		switch eventType {
+		case config.ResourceAddEvent, config.ResourceUpdateEvent:
+			value := new(config.GraphQLPersistedQuery)
+			if err := mapstructure.Decode(resource, value); err != nil {
+				return helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("invalid type provided for resource (%s) expecting (%v) got (%v)", resourceType, "config.GraphQLPersistedQuery{}", reflect.TypeOf(resource)), nil, nil)
+			}
+
+			if project.GraphQLPersistedQueries == nil {
+				project.GraphQLPersistedQueries = config.GraphQLPersistedQueries{resourceID: value}
+			} else {
+				project.GraphQLPersistedQueries[resourceID] = value
+			}
+
+		case config.ResourceDeleteEvent:
+			delete(project.GraphQLPersistedQueries, resourceID)
+		}
+
+		return nil
+
 	default:
 		return fmt.Errorf("unknown resource type (%s) provided", resourceType)
 	}
 }
 
+// getResource returns the current value of a project level resource. It returns false if the resource doesn't exist
+// NOTE: any resource added to updateResource() should also be reflected here
+func getResource(ctx context.Context, globalConfig *config.Config, resourceID string) (interface{}, bool, error) {
+	if globalConfig == nil {
+		return nil, false, nil
+	}
+
+	_, projectID, resourceType, err := splitResourceID(ctx, resourceID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	project, ok := globalConfig.Projects[projectID]
+	if !ok {
+		return nil, false, nil
+	}
+
+	var value interface{}
+	switch resourceType {
+	case config.ResourceProject:
+		if project.ProjectConfig != nil {
+			value = project.ProjectConfig
+		}
+	case config.ResourceAuthProvider:
+		if v, ok := project.Auths[resourceID]; ok && v != nil {
+			value = v
+		}
+	case config.ResourceDatabaseConfig:
+		if v, ok := project.DatabaseConfigs[resourceID]; ok && v != nil {
+			value = v
+		}
+	case config.ResourceDatabaseSchema:
+		if v, ok := project.DatabaseSchemas[resourceID]; ok && v != nil {
+			value = v
+		}
+	case config.ResourceDatabaseRule:
+		if v, ok := project.DatabaseRules[resourceID]; ok && v != nil {
+			value = v
+		}
+	case config.ResourceDatabasePreparedQuery:
+		if v, ok := project.DatabasePreparedQueries[resourceID]; ok && v != nil {
+			value = v
+		}
+	case config.ResourceEventingConfig:
+		if project.EventingConfig != nil {
+			value = project.EventingConfig
+		}
+	case config.ResourceEventingSchema:
+		if v, ok := project.EventingSchemas[resourceID]; ok && v != nil {
+			value = v
+		}
+	case config.ResourceEventingRule:
+		if v, ok := project.EventingRules[resourceID]; ok && v != nil {
+			value = v
+		}
+	case config.ResourceEventingTrigger:
+		if v, ok := project.EventingTriggers[resourceID]; ok && v != nil {
+			value = v
+		}
+	case config.ResourceFileStoreConfig:
+		if project.FileStoreConfig != nil {
+			value = project.FileStoreConfig
+		}
+	case config.ResourceFileStoreAlias:
+		if v, ok := project.FileStores[resourceID]; ok && v != nil {
+			value = v
+		}
+	case config.ResourceFileStoreRule:
+		if v, ok := project.FileStoreRules[resourceID]; ok && v != nil {
+			value = v
+		}
+	case config.ResourceProjectLetsEncrypt:
+		if project.LetsEncrypt != nil {
+			value = project.LetsEncrypt
+		}
+	case config.ResourceIngressRoute:
+		if v, ok := project.IngressRoutes[resourceID]; ok && v != nil {
+			value = v
+		}
+	case config.ResourceIngressGlobal:
+		if project.IngressGlobal != nil {
+			value = project.IngressGlobal
+		}
+	case config.ResourceRemoteService:
+		if v, ok := project.RemoteService[resourceID]; ok && v != nil {
+			value = v
+		}
+	case config.ResourceGraphQLPersistedQuery:
+		if v, ok := project.GraphQLPersistedQueries[resourceID]; ok && v != nil {
+			value = v
+		}
+	default:
+		return nil, false, fmt.Errorf("unknown resource type (%s) provided", resourceType)
+	}
+
+	return value, value != nil, nil
+}
+
 // CheckIfLeaderGateway tells if the provided gateway is the current leader gateway or not
 func (s *Manager) CheckIfLeaderGateway(nodeID string) (bool, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -11,6 +11,7 @@ import (
 	"golang.org/x/net/context"
 
 	"github.com/spaceuptech/space-cloud/gateway/utils"
+	"github.com/spaceuptech/space-cloud/gateway/utils/tracing"
 )
 
 // MakeHTTPRequest fires an http request and returns a response
@@ -31,6 +32,9 @@ func (s *Manager) MakeHTTPRequest(ctx context.Context, method, url, token, scTok
 	req.Header.Add("Content-Type", "application/json")
 	req.Header.Add("x-sc-token", "Bearer "+scToken)
 
+	// Propagate the trace context to the service
+	tracing.Inject(ctx, req.Header)
+
 	// Create a http client and fire the request
 	client := &http.Client{}
 
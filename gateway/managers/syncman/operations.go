@@ -106,14 +106,23 @@ func (s *Manager) SetClusterConfig(ctx context.Context, req *config.ClusterConfi
 	// Acquire a lock
 	s.lock.Lock()
 	defer s.lock.Unlock()
+
+	// Create the table of the audit log before anything gets recorded in it
+	if req.AuditLog != nil && req.AuditLog.Enabled {
+		if status, err := s.applyAuditLogSchema(ctx, req.AuditLog, params); err != nil {
+			return status, err
+		}
+	}
+
 	s.projectConfig.ClusterConfig = req
 	resourceID := config.GenerateResourceID(s.clusterID, "noProject", config.ResourceCluster, "cluster")
-	if err := s.store.SetResource(ctx, resourceID, s.projectConfig.ClusterConfig); err != nil {
+	if err := s.setClusterResource(ctx, config.ResourceUpdateEvent, resourceID, s.projectConfig.ClusterConfig, params); err != nil {
 		return http.StatusInternalServerError, err
 	}
 
 	s.globalModules.SetMetricsConfig(s.projectConfig.ClusterConfig.EnableTelemetry)
 	s.modules.LetsEncrypt().SetLetsEncryptEmail(req.LetsEncryptEmail)
+	s.setAuditLogConfig(req)
 
 	return http.StatusOK, nil
 }
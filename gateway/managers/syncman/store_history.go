@@ -0,0 +1,87 @@
+package syncman
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/spaceuptech/helpers"
+
+	"github.com/spaceuptech/space-cloud/gateway/model"
+)
+
+// configHistoryLimit is the number of changes retained in the config history of a project
+const configHistoryLimit = 1000
+
+// HistoryStore abstracts the implementation of the config history storage operations
+type HistoryStore interface {
+	// AddHistory assigns the next version of the project to the entry and stores it
+	AddHistory(ctx context.Context, projectID string, entry *model.ConfigHistoryEntry) error
+	// GetHistory returns the history of the project sorted by version
+	GetHistory(ctx context.Context, projectID string) ([]*model.ConfigHistoryEntry, error)
+}
+
+// LocalHistoryStore stores the config history in a json file next to the config file
+type LocalHistoryStore struct {
+	lock    sync.Mutex
+	path    string
+	entries map[string][]*model.ConfigHistoryEntry
+}
+
+// NewLocalHistoryStore creates a new local history store for the provided config file
+func NewLocalHistoryStore(configPath string) *LocalHistoryStore {
+	path := strings.TrimSuffix(configPath, filepath.Ext(configPath)) + ".history.json"
+
+	entries := map[string][]*model.ConfigHistoryEntry{}
+	data, err := ioutil.ReadFile(path)
+	if err == nil {
+		if err := json.Unmarshal(data, &entries); err != nil {
+			_ = helpers.Logger.LogError("syncman-history", "Unable to parse config history file, starting with an empty history", err, map[string]interface{}{"path": path})
+			entries = map[string][]*model.ConfigHistoryEntry{}
+		}
+	} else if !os.IsNotExist(err) {
+		_ = helpers.Logger.LogError("syncman-history", "Unable to read config history file, starting with an empty history", err, map[string]interface{}{"path": path})
+	}
+
+	return &LocalHistoryStore{path: path, entries: entries}
+}
+
+// AddHistory stores the entry in the history of the project
+func (s *LocalHistoryStore) AddHistory(ctx context.Context, projectID string, entry *model.ConfigHistoryEntry) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	entries := s.entries[projectID]
+	entry.Version = 1
+	if len(entries) > 0 {
+		entry.Version = entries[len(entries)-1].Version + 1
+	}
+	entries = append(entries, entry)
+	if len(entries) > configHistoryLimit {
+		entries = entries[len(entries)-configHistoryLimit:]
+	}
+	s.entries[projectID] = entries
+
+	data, err := json.Marshal(s.entries)
+	if err != nil {
+		return helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to marshal config history", err, nil)
+	}
+	if err := ioutil.WriteFile(s.path, data, 0644); err != nil {
+		return helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to write config history file", err, map[string]interface{}{"path": s.path})
+	}
+	return nil
+}
+
+// GetHistory returns the history of the project
+func (s *LocalHistoryStore) GetHistory(ctx context.Context, projectID string) ([]*model.ConfigHistoryEntry, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	entries := make([]*model.ConfigHistoryEntry, len(s.entries[projectID]))
+	copy(entries, s.entries[projectID])
+	return entries, nil
+}
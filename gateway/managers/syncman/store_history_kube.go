@@ -0,0 +1,105 @@
+package syncman
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/spaceuptech/helpers"
+	v1 "k8s.io/api/core/v1"
+	kubeErrors "k8s.io/apimachinery/pkg/api/errors"
+	v12 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/spaceuptech/space-cloud/gateway/model"
+)
+
+// KubeHistoryStore stores every entry of the config history in a separate config map
+type KubeHistoryStore struct {
+	clusterID string
+	kube      *kubernetes.Clientset
+}
+
+// NewKubeHistoryStore creates a new kube history store
+func NewKubeHistoryStore(clusterID string, kube *kubernetes.Clientset) *KubeHistoryStore {
+	return &KubeHistoryStore{clusterID: clusterID, kube: kube}
+}
+
+// NOTE: the config maps of the history don't have the clusterId label so that they aren't picked up as resources by the kube store
+func (s *KubeHistoryStore) labelSelector(projectID string) string {
+	return fmt.Sprintf("kind=config-history,historyOf=%s,projectId=%s", s.clusterID, projectID)
+}
+
+// AddHistory stores the entry in the history of the project
+func (s *KubeHistoryStore) AddHistory(ctx context.Context, projectID string, entry *model.ConfigHistoryEntry) error {
+	// Gateways of the cluster might add an entry at the same time. The config map name is derived from the version,
+	// so we simply retry with the next version if we lose the race
+	for retry := 0; retry < 5; retry++ {
+		entries, err := s.GetHistory(ctx, projectID)
+		if err != nil {
+			return err
+		}
+
+		entry.Version = 1
+		if len(entries) > 0 {
+			entry.Version = entries[len(entries)-1].Version + 1
+		}
+
+		data, err := json.Marshal(entry)
+		if err != nil {
+			return helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to marshal config history entry", err, nil)
+		}
+
+		configMap := &v1.ConfigMap{
+			ObjectMeta: v12.ObjectMeta{
+				Name: makeIDConfigMapCompatible(fmt.Sprintf("history--%s--%s--%d", s.clusterID, projectID, entry.Version)),
+				Labels: map[string]string{
+					"kind":      "config-history",
+					"historyOf": s.clusterID,
+					"projectId": projectID,
+				},
+			},
+			Data: map[string]string{"entry": string(data)},
+		}
+		_, err = s.kube.CoreV1().ConfigMaps(spaceCloud).Create(ctx, configMap, v12.CreateOptions{})
+		if kubeErrors.IsAlreadyExists(err) {
+			continue
+		}
+		if err != nil {
+			return helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to store config history entry in kube store", err, map[string]interface{}{"project": projectID})
+		}
+
+		// Remove the entries which are no longer retained
+		for i := 0; i < len(entries)+1-configHistoryLimit; i++ {
+			name := makeIDConfigMapCompatible(fmt.Sprintf("history--%s--%s--%d", s.clusterID, projectID, entries[i].Version))
+			if err := s.kube.CoreV1().ConfigMaps(spaceCloud).Delete(ctx, name, v12.DeleteOptions{}); err != nil && !kubeErrors.IsNotFound(err) {
+				_ = helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to remove old config history entry from kube store", err, map[string]interface{}{"project": projectID})
+			}
+		}
+		return nil
+	}
+
+	return helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to store config history entry in kube store due to concurrent updates", nil, map[string]interface{}{"project": projectID})
+}
+
+// GetHistory returns the history of the project
+func (s *KubeHistoryStore) GetHistory(ctx context.Context, projectID string) ([]*model.ConfigHistoryEntry, error) {
+	list, err := s.kube.CoreV1().ConfigMaps(spaceCloud).List(ctx, v12.ListOptions{LabelSelector: s.labelSelector(projectID)})
+	if err != nil {
+		return nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Unable to list config history of project (%s)", projectID), err, nil)
+	}
+
+	entries := make([]*model.ConfigHistoryEntry, 0, len(list.Items))
+	for _, item := range list.Items {
+		entry := new(model.ConfigHistoryEntry)
+		if err := json.Unmarshal([]byte(item.Data["entry"]), entry); err != nil {
+			_ = helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to unmarshal config history entry, skipping it", err, map[string]interface{}{"name": item.Name})
+			continue
+		}
+		entries = append(entries, entry)
+	}
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Version < entries[j].Version })
+	return entries, nil
+}
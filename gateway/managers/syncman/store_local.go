@@ -18,10 +18,7 @@ type LocalStore struct {
 
 // NewLocalStore creates a new local store
 func NewLocalStore(nodeID string, ssl *config.SSL) (*LocalStore, error) {
-	configPath := os.Getenv("CONFIG")
-	if configPath == "" {
-		configPath = "config.yaml"
-	}
+	configPath := getLocalConfigPath()
 	// Load the configFile from path if provided
 	conf, err := config.LoadConfigFromFile(configPath)
 	if err != nil {
@@ -40,6 +37,14 @@ func NewLocalStore(nodeID string, ssl *config.SSL) (*LocalStore, error) {
 	return &LocalStore{configPath: configPath, globalConfig: conf, services: append(services, &model.Service{ID: "single-node-cluster"})}, nil
 }
 
+func getLocalConfigPath() string {
+	configPath := os.Getenv("CONFIG")
+	if configPath == "" {
+		configPath = "config.yaml"
+	}
+	return configPath
+}
+
 // Register registers space cloud to the local store
 func (s *LocalStore) Register() {}
 
@@ -35,10 +35,12 @@ type Manager struct {
 	storeType string
 	store     Store
 	services  model.ScServices
+	history   HistoryStore
 
 	// For authentication
 	adminMan       AdminSyncmanInterface
 	integrationMan integrationInterface
+	auditMan       auditInterface
 
 	// Modules
 	modules       ModulesInterface
@@ -46,10 +48,10 @@ type Manager struct {
 }
 
 // New creates a new instance of the sync manager
-func New(nodeID, clusterID, storeType, runnerAddr string, adminMan AdminSyncmanInterface, integrationMan integrationInterface, ssl *config.SSL) (*Manager, error) {
+func New(nodeID, clusterID, storeType, runnerAddr string, adminMan AdminSyncmanInterface, integrationMan integrationInterface, auditMan auditInterface, ssl *config.SSL) (*Manager, error) {
 
 	// Create a new manager instance
-	m := &Manager{nodeID: nodeID, clusterID: clusterID, storeType: storeType, runnerAddr: runnerAddr, adminMan: adminMan, integrationMan: integrationMan}
+	m := &Manager{nodeID: nodeID, clusterID: clusterID, storeType: storeType, runnerAddr: runnerAddr, adminMan: adminMan, integrationMan: integrationMan, auditMan: auditMan}
 
 	// Initialise the consul client if enabled
 	var s Store
@@ -57,8 +59,14 @@ func New(nodeID, clusterID, storeType, runnerAddr string, adminMan AdminSyncmanI
 	switch storeType {
 	case "local":
 		s, err = NewLocalStore(nodeID, ssl)
+		m.history = NewLocalHistoryStore(getLocalConfigPath())
 	case "kube":
-		s, err = NewKubeStore(clusterID)
+		var kubeStore *KubeStore
+		kubeStore, err = NewKubeStore(clusterID)
+		if err == nil {
+			s = kubeStore
+			m.history = NewKubeHistoryStore(clusterID, kubeStore.kube)
+		}
 	default:
 		return nil, helpers.Logger.LogError(helpers.GetRequestID(context.TODO()), fmt.Sprintf("Cannot initialize syncaman as invalid store type (%v) provided", storeType), nil, nil)
 	}
@@ -119,6 +127,7 @@ func (s *Manager) Start(port int) error {
 
 	s.adminMan.SetServices(config.ResourceAddEvent, s.services)
 	s.adminMan.SetIntegrationConfig(globalConfig.Integrations)
+	s.adminMan.SetAdminUsers(globalConfig.AdminUsers)
 	_ = s.integrationMan.SetConfig(globalConfig.Integrations, globalConfig.IntegrationHooks)
 
 	s.leader.AddCallBack("admin-set-service", func() {
@@ -141,6 +150,9 @@ func (s *Manager) Start(port int) error {
 		s.modules.LetsEncrypt().SetLetsEncryptEmail(globalConfig.ClusterConfig.LetsEncryptEmail)
 	}
 
+	// Set audit log config
+	s.setAuditLogConfig(globalConfig.ClusterConfig)
+
 	s.projectConfig = globalConfig
 
 	// Set initial project config
@@ -177,90 +189,106 @@ func (s *Manager) Start(port int) error {
 			return
 		}
 
-		switch resourceType {
-		case config.ResourceProject:
-			if eventType == config.ResourceDeleteEvent {
-				s.modules.Delete(projectID)
-				return
-			}
-			_ = s.modules.SetProjectConfig(ctx, s.projectConfig.Projects[projectID].ProjectConfig)
+		s.applyResourceToModules(ctx, eventType, projectID, resourceType)
+	}); err != nil {
+		return err
+	}
+
+	helpers.Logger.LogDebug(helpers.GetRequestID(context.TODO()), "Exiting syncman start", nil)
+	return nil
+}
+
+// applyResourceToModules applies the config of the resource type present in the global config to the modules.
+// The lock must be held by the caller
+func (s *Manager) applyResourceToModules(ctx context.Context, eventType, projectID string, resourceType config.Resource) {
+	switch resourceType {
+	case config.ResourceProject:
+		if eventType == config.ResourceDeleteEvent {
+			s.modules.Delete(projectID)
+			return
+		}
+		_ = s.modules.SetProjectConfig(ctx, s.projectConfig.Projects[projectID].ProjectConfig)
 
-		case config.ResourceAuthProvider:
-			_ = s.modules.SetUsermanConfig(ctx, projectID, s.projectConfig.Projects[projectID].Auths)
+	case config.ResourceAuthProvider:
+		_ = s.modules.SetUsermanConfig(ctx, projectID, s.projectConfig.Projects[projectID].Auths)
 
-		case config.ResourceDatabaseConfig:
-			p := s.projectConfig.Projects[projectID]
-			_ = s.modules.SetDatabaseConfig(ctx, projectID, p.DatabaseConfigs, p.DatabaseSchemas, p.DatabaseRules, p.DatabasePreparedQueries)
+	case config.ResourceDatabaseConfig:
+		p := s.projectConfig.Projects[projectID]
+		_ = s.modules.SetDatabaseConfig(ctx, projectID, p.DatabaseConfigs, p.DatabaseSchemas, p.DatabaseRules, p.DatabasePreparedQueries)
 
-		case config.ResourceDatabaseSchema:
-			_ = s.modules.SetDatabaseSchemaConfig(ctx, projectID, s.projectConfig.Projects[projectID].DatabaseSchemas)
+	case config.ResourceDatabaseSchema:
+		_ = s.modules.SetDatabaseSchemaConfig(ctx, projectID, s.projectConfig.Projects[projectID].DatabaseSchemas)
 
-		case config.ResourceDatabaseRule:
-			_ = s.modules.SetDatabaseRulesConfig(ctx, projectID, s.projectConfig.Projects[projectID].DatabaseRules)
+	case config.ResourceDatabaseRule:
+		_ = s.modules.SetDatabaseRulesConfig(ctx, projectID, s.projectConfig.Projects[projectID].DatabaseRules)
 
-		case config.ResourceDatabasePreparedQuery:
-			_ = s.modules.SetDatabasePreparedQueryConfig(ctx, projectID, s.projectConfig.Projects[projectID].DatabasePreparedQueries)
+	case config.ResourceDatabasePreparedQuery:
+		_ = s.modules.SetDatabasePreparedQueryConfig(ctx, projectID, s.projectConfig.Projects[projectID].DatabasePreparedQueries)
 
-		case config.ResourceEventingConfig:
-			p := s.projectConfig.Projects[projectID]
-			_ = s.modules.SetEventingConfig(ctx, projectID, p.EventingConfig, p.EventingRules, p.EventingSchemas, p.EventingTriggers)
+	case config.ResourceEventingConfig:
+		p := s.projectConfig.Projects[projectID]
+		_ = s.modules.SetEventingConfig(ctx, projectID, p.EventingConfig, p.EventingRules, p.EventingSchemas, p.EventingTriggers)
 
-		case config.ResourceEventingSchema:
-			_ = s.modules.SetEventingSchemaConfig(ctx, projectID, s.projectConfig.Projects[projectID].EventingSchemas)
+	case config.ResourceEventingSchema:
+		_ = s.modules.SetEventingSchemaConfig(ctx, projectID, s.projectConfig.Projects[projectID].EventingSchemas)
 
-		case config.ResourceEventingRule:
-			_ = s.modules.SetEventingRuleConfig(ctx, projectID, s.projectConfig.Projects[projectID].EventingRules)
+	case config.ResourceEventingRule:
+		_ = s.modules.SetEventingRuleConfig(ctx, projectID, s.projectConfig.Projects[projectID].EventingRules)
 
-		case config.ResourceEventingTrigger:
-			_ = s.modules.SetEventingTriggerConfig(ctx, projectID, s.projectConfig.Projects[projectID].EventingTriggers)
+	case config.ResourceEventingTrigger:
+		_ = s.modules.SetEventingTriggerConfig(ctx, projectID, s.projectConfig.Projects[projectID].EventingTriggers)
 
-		case config.ResourceFileStoreConfig:
-			_ = s.modules.SetFileStoreConfig(ctx, projectID, s.projectConfig.Projects[projectID].FileStoreConfig)
+	case config.ResourceFileStoreConfig:
+		_ = s.modules.SetFileStoreConfig(ctx, projectID, s.projectConfig.Projects[projectID].FileStoreConfig)
 
-		case config.ResourceFileStoreRule:
-			_ = s.modules.SetFileStoreSecurityRuleConfig(ctx, projectID, s.projectConfig.Projects[projectID].FileStoreRules)
+	case config.ResourceFileStoreAlias:
+		_ = s.modules.SetFileStoresConfig(ctx, projectID, s.projectConfig.Projects[projectID].FileStores)
 
-		case config.ResourceProjectLetsEncrypt:
-			_ = s.modules.SetLetsencryptConfig(ctx, projectID, s.projectConfig.Projects[projectID].LetsEncrypt)
+	case config.ResourceFileStoreRule:
+		_ = s.modules.SetFileStoreSecurityRuleConfig(ctx, projectID, s.projectConfig.Projects[projectID].FileStoreRules)
 
-		case config.ResourceIngressRoute:
-			_ = s.modules.SetIngressRouteConfig(ctx, projectID, s.projectConfig.Projects[projectID].IngressRoutes)
+	case config.ResourceProjectLetsEncrypt:
+		_ = s.modules.SetLetsencryptConfig(ctx, projectID, s.projectConfig.Projects[projectID].LetsEncrypt)
 
-		case config.ResourceIngressGlobal:
-			_ = s.modules.SetIngressGlobalRouteConfig(ctx, projectID, s.projectConfig.Projects[projectID].IngressGlobal)
+	case config.ResourceIngressRoute:
+		_ = s.modules.SetIngressRouteConfig(ctx, projectID, s.projectConfig.Projects[projectID].IngressRoutes)
 
-		case config.ResourceRemoteService:
-			_ = s.modules.SetRemoteServiceConfig(ctx, projectID, s.projectConfig.Projects[projectID].RemoteService)
+	case config.ResourceIngressGlobal:
+		_ = s.modules.SetIngressGlobalRouteConfig(ctx, projectID, s.projectConfig.Projects[projectID].IngressGlobal)
 
-		case config.ResourceCluster:
-			s.globalModules.SetMetricsConfig(s.projectConfig.ClusterConfig.EnableTelemetry)
-			s.modules.LetsEncrypt().SetLetsEncryptEmail(s.projectConfig.ClusterConfig.LetsEncryptEmail)
+	case config.ResourceRemoteService:
+		_ = s.modules.SetRemoteServiceConfig(ctx, projectID, s.projectConfig.Projects[projectID].RemoteService)
 
-		case config.ResourceIntegration:
-			if err := s.integrationMan.SetIntegrations(s.projectConfig.Integrations); err != nil {
-				_ = helpers.Logger.LogError(helpers.GetRequestID(context.TODO()), "Unable to apply integration config", err, nil)
-				return
-			}
-			s.adminMan.SetIntegrationConfig(s.projectConfig.Integrations)
+	case config.ResourceGraphQLPersistedQuery:
+		_ = s.modules.SetGraphQLPersistedQueryConfig(ctx, projectID, s.projectConfig.Projects[projectID].GraphQLPersistedQueries)
 
-		case config.ResourceIntegrationHook:
-			s.integrationMan.SetIntegrationHooks(s.projectConfig.IntegrationHooks)
+	case config.ResourceCluster:
+		s.globalModules.SetMetricsConfig(s.projectConfig.ClusterConfig.EnableTelemetry)
+		s.modules.LetsEncrypt().SetLetsEncryptEmail(s.projectConfig.ClusterConfig.LetsEncryptEmail)
+		s.setAuditLogConfig(s.projectConfig.ClusterConfig)
 
-		case config.ResourceCacheConfig:
-			if err := s.modules.Caching().SetCachingConfig(ctx, s.projectConfig.CacheConfig); err != nil {
-				_ = helpers.Logger.LogError(helpers.GetRequestID(context.TODO()), "Unable to apply admin config provided by other space cloud service", err, map[string]interface{}{})
-				return
-			}
-		default:
-			_ = helpers.Logger.LogError(helpers.GetRequestID(context.TODO()), "Unknown resource type provided", err, map[string]interface{}{"resourceType": resourceType})
+	case config.ResourceIntegration:
+		if err := s.integrationMan.SetIntegrations(s.projectConfig.Integrations); err != nil {
+			_ = helpers.Logger.LogError(helpers.GetRequestID(context.TODO()), "Unable to apply integration config", err, nil)
 			return
 		}
-	}); err != nil {
-		return err
-	}
+		s.adminMan.SetIntegrationConfig(s.projectConfig.Integrations)
 
-	helpers.Logger.LogDebug(helpers.GetRequestID(context.TODO()), "Exiting syncman start", nil)
-	return nil
+	case config.ResourceIntegrationHook:
+		s.integrationMan.SetIntegrationHooks(s.projectConfig.IntegrationHooks)
+
+	case config.ResourceCacheConfig:
+		if err := s.modules.Caching().SetCachingConfig(ctx, s.projectConfig.CacheConfig); err != nil {
+			_ = helpers.Logger.LogError(helpers.GetRequestID(context.TODO()), "Unable to apply admin config provided by other space cloud service", err, map[string]interface{}{})
+			return
+		}
+
+	case config.ResourceAdminUser:
+		s.adminMan.SetAdminUsers(s.projectConfig.AdminUsers)
+	default:
+		_ = helpers.Logger.LogError(helpers.GetRequestID(context.TODO()), "Unknown resource type provided", nil, map[string]interface{}{"resourceType": resourceType})
+		return
+	}
 }
 
 // SetGlobalConfig sets the global config. This must be called before the Start command.
@@ -0,0 +1,156 @@
+package syncman
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/spaceuptech/helpers"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/spaceuptech/space-cloud/gateway/config"
+	"github.com/spaceuptech/space-cloud/gateway/model"
+)
+
+// SetAdminUser adds or updates an admin user or service account. The key provided is hashed before it is stored.
+// The existing key is retained if an existing user is updated without a key
+func (s *Manager) SetAdminUser(ctx context.Context, user *config.AdminUserConfig, params model.RequestParams) (int, error) {
+	// Check if the request has been hijacked
+	hookResponse := s.integrationMan.InvokeHook(ctx, params)
+	if hookResponse.CheckResponse() {
+		// Check if an error occurred
+		if err := hookResponse.Error(); err != nil {
+			return hookResponse.Status(), err
+		}
+
+		// Gracefully return
+		return hookResponse.Status(), nil
+	}
+
+	if err := validateAdminUser(ctx, user); err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	resourceID := config.GenerateResourceID(s.clusterID, "noProject", config.ResourceAdminUser, user.ID)
+	existing, exists := s.projectConfig.AdminUsers[resourceID]
+
+	switch {
+	case user.Key != "":
+		hash, err := bcrypt.GenerateFromPassword([]byte(user.Key), bcrypt.DefaultCost)
+		if err != nil {
+			return http.StatusInternalServerError, helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to hash key of admin user", err, nil)
+		}
+		user.Key = string(hash)
+	case exists:
+		user.Key = existing.Key
+	default:
+		return http.StatusBadRequest, helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Key must be provided while creating admin user (%s)", user.ID), nil, nil)
+	}
+
+	if s.projectConfig.AdminUsers == nil {
+		s.projectConfig.AdminUsers = make(config.AdminUsers)
+	}
+	s.projectConfig.AdminUsers[resourceID] = user
+	s.adminMan.SetAdminUsers(s.projectConfig.AdminUsers)
+
+	event := config.ResourceAddEvent
+	if exists {
+		event = config.ResourceUpdateEvent
+	}
+	if err := s.setClusterResource(ctx, event, resourceID, user, params); err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	return http.StatusOK, nil
+}
+
+// DeleteAdminUser deletes an admin user or service account. The tokens issued to it stop working right away
+func (s *Manager) DeleteAdminUser(ctx context.Context, id string, params model.RequestParams) (int, error) {
+	// Check if the request has been hijacked
+	hookResponse := s.integrationMan.InvokeHook(ctx, params)
+	if hookResponse.CheckResponse() {
+		// Check if an error occurred
+		if err := hookResponse.Error(); err != nil {
+			return hookResponse.Status(), err
+		}
+
+		// Gracefully return
+		return hookResponse.Status(), nil
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	resourceID := config.GenerateResourceID(s.clusterID, "noProject", config.ResourceAdminUser, id)
+	if _, p := s.projectConfig.AdminUsers[resourceID]; !p {
+		return http.StatusBadRequest, helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Admin user (%s) does not exist", id), nil, nil)
+	}
+
+	delete(s.projectConfig.AdminUsers, resourceID)
+	s.adminMan.SetAdminUsers(s.projectConfig.AdminUsers)
+
+	if err := s.deleteClusterResource(ctx, resourceID, params); err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	return http.StatusOK, nil
+}
+
+// GetAdminUsers returns the admin users and service accounts. The hashed keys are never returned
+func (s *Manager) GetAdminUsers(ctx context.Context, id string, params model.RequestParams) (int, []interface{}, error) {
+	// Check if the request has been hijacked
+	hookResponse := s.integrationMan.InvokeHook(ctx, params)
+	if hookResponse.CheckResponse() {
+		// Check if an error occurred
+		if err := hookResponse.Error(); err != nil {
+			return hookResponse.Status(), nil, err
+		}
+
+		// Gracefully return
+		return hookResponse.Status(), hookResponse.Result().([]interface{}), nil
+	}
+
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	result := make([]interface{}, 0)
+	for _, user := range s.projectConfig.AdminUsers {
+		if id != "*" && id != user.ID {
+			continue
+		}
+
+		u := *user
+		u.Key = ""
+		result = append(result, &u)
+	}
+
+	if len(result) > 0 || id == "*" {
+		return http.StatusOK, result, nil
+	}
+
+	return http.StatusBadRequest, nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Admin user (%s) not found", id), nil, nil)
+}
+
+func validateAdminUser(ctx context.Context, user *config.AdminUserConfig) error {
+	if user.ID == "" {
+		return helpers.Logger.LogError(helpers.GetRequestID(ctx), "Id of admin user cannot be empty", nil, nil)
+	}
+
+	switch user.Kind {
+	case "":
+		user.Kind = config.AdminUserKindUser
+	case config.AdminUserKindUser, config.AdminUserKindServiceAccount:
+	default:
+		return helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Invalid kind (%s) provided for admin user (%s)", user.Kind, user.ID), nil, nil)
+	}
+
+	for i, role := range user.Roles {
+		if len(role.Resources) == 0 || len(role.Verbs) == 0 {
+			return helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Role (%d) of admin user (%s) must have at least one resource and verb", i, user.ID), nil, nil)
+		}
+	}
+	return nil
+}
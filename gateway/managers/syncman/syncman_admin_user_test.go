@@ -0,0 +1,109 @@
+package syncman
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/spaceuptech/space-cloud/gateway/config"
+	"github.com/spaceuptech/space-cloud/gateway/model"
+)
+
+func TestManager_SetAdminUser(t *testing.T) {
+	resourceID := "chicago--noProject--admin-user--ci"
+	roles := []config.IntegrationPermission{{Resources: []string{"db-rule"}, Verbs: []string{"modify"}, Attributes: config.Attributes{"project": []string{"myproject"}}}}
+
+	tests := []struct {
+		name      string
+		existing  config.AdminUsers
+		user      *config.AdminUserConfig
+		wantKey   string
+		wantKind  string
+		wantEvent string
+		wantErr   bool
+	}{
+		{
+			name:      "new user is created with a hashed key",
+			user:      &config.AdminUserConfig{ID: "ci", Kind: config.AdminUserKindServiceAccount, Key: "ci-key", Roles: roles},
+			wantKey:   "ci-key",
+			wantKind:  config.AdminUserKindServiceAccount,
+			wantEvent: config.ResourceAddEvent,
+		},
+		{
+			name:      "kind defaults to user",
+			user:      &config.AdminUserConfig{ID: "ci", Key: "ci-key", Roles: roles},
+			wantKey:   "ci-key",
+			wantKind:  config.AdminUserKindUser,
+			wantEvent: config.ResourceAddEvent,
+		},
+		{
+			name:      "existing key is retained when updating without a key",
+			existing:  config.AdminUsers{resourceID: &config.AdminUserConfig{ID: "ci", Kind: config.AdminUserKindServiceAccount, Key: "existing-hash"}},
+			user:      &config.AdminUserConfig{ID: "ci", Kind: config.AdminUserKindServiceAccount, Roles: roles},
+			wantKind:  config.AdminUserKindServiceAccount,
+			wantEvent: config.ResourceUpdateEvent,
+		},
+		{
+			name:    "key is required while creating a user",
+			user:    &config.AdminUserConfig{ID: "ci", Roles: roles},
+			wantErr: true,
+		},
+		{
+			name:    "invalid kind provided",
+			user:    &config.AdminUserConfig{ID: "ci", Kind: "robot", Key: "ci-key"},
+			wantErr: true,
+		},
+		{
+			name:    "role without verbs provided",
+			user:    &config.AdminUserConfig{ID: "ci", Key: "ci-key", Roles: []config.IntegrationPermission{{Resources: []string{"*"}}}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := new(mockStoreInterface)
+			admin := new(mockAdminSyncmanInterface)
+			audit := new(mockAuditInterface)
+			if !tt.wantErr {
+				store.On("SetResource", mock.Anything, resourceID, tt.user).Return(nil)
+				admin.On("SetAdminUsers", mock.Anything).Return()
+				audit.On("Record", mock.Anything, mock.MatchedBy(func(entry *model.AuditLogEntry) bool {
+					return entry.ResourceID == resourceID && entry.Verb == tt.wantEvent
+				})).Return()
+			}
+
+			s := &Manager{
+				clusterID:      "chicago",
+				projectConfig:  &config.Config{AdminUsers: tt.existing},
+				store:          store,
+				adminMan:       admin,
+				auditMan:       audit,
+				integrationMan: &mockIntegrationManager{skip: true},
+			}
+			if _, err := s.SetAdminUser(context.Background(), tt.user, model.RequestParams{}); (err != nil) != tt.wantErr {
+				t.Fatalf("SetAdminUser() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			stored := s.projectConfig.AdminUsers[resourceID]
+			if stored.Kind != tt.wantKind {
+				t.Errorf("SetAdminUser() kind = %v, want %v", stored.Kind, tt.wantKind)
+			}
+			if tt.wantKey == "" {
+				if stored.Key != tt.existing[resourceID].Key {
+					t.Errorf("SetAdminUser() key = %v, want %v", stored.Key, tt.existing[resourceID].Key)
+				}
+			} else if err := bcrypt.CompareHashAndPassword([]byte(stored.Key), []byte(tt.wantKey)); err != nil {
+				t.Errorf("SetAdminUser() key is not the hash of the provided key - %v", err)
+			}
+
+			store.AssertExpectations(t)
+			admin.AssertExpectations(t)
+			audit.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package syncman
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/spaceuptech/helpers"
+
+	"github.com/spaceuptech/space-cloud/gateway/config"
+	"github.com/spaceuptech/space-cloud/gateway/model"
+	"github.com/spaceuptech/space-cloud/gateway/utils"
+)
+
+// setClusterResource writes a cluster level resource to the store and records the change in the audit log.
+// The lock must be held by the caller
+func (s *Manager) setClusterResource(ctx context.Context, event, resourceID string, value interface{}, params model.RequestParams) error {
+	err := s.store.SetResource(ctx, resourceID, value)
+	s.recordConfigChange(ctx, event, resourceID, params, err)
+	return err
+}
+
+// deleteClusterResource deletes a cluster level resource from the store and records the change in the audit log.
+// The lock must be held by the caller
+func (s *Manager) deleteClusterResource(ctx context.Context, resourceID string, params model.RequestParams) error {
+	err := s.store.DeleteResource(ctx, resourceID)
+	s.recordConfigChange(ctx, config.ResourceDeleteEvent, resourceID, params, err)
+	return err
+}
+
+// recordConfigChange records the mutation of the resource in the audit log along with its outcome
+func (s *Manager) recordConfigChange(ctx context.Context, event, resourceID string, params model.RequestParams, err error) {
+	if s.auditMan == nil {
+		return
+	}
+
+	entry := &model.AuditLogEntry{Kind: model.AuditKindConfig, RequestID: params.RequestID, ResourceID: resourceID, Verb: event, Outcome: model.AuditOutcomeSuccess}
+	entry.Actor, _ = params.Claims["id"].(string)
+	if _, projectID, resourceType, splitErr := splitResourceID(ctx, resourceID); splitErr == nil {
+		entry.Resource = string(resourceType)
+		if projectID != "noProject" {
+			entry.Project = projectID
+		}
+	}
+	if err != nil {
+		entry.Outcome = model.AuditOutcomeFailure
+		entry.Message = err.Error()
+	}
+	s.auditMan.Record(ctx, entry)
+}
+
+// applyAuditLogSchema creates the table the audit log is stored in and denies all access to it through the crud api.
+// The lock must be held by the caller
+func (s *Manager) applyAuditLogSchema(ctx context.Context, c *config.AuditLogConfig, params model.RequestParams) (int, error) {
+	projectConfig, err := s.getConfigWithoutLock(ctx, c.Project)
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	dbConfig, p := s.checkIfDbAliasExists(projectConfig.DatabaseConfigs, c.DBAlias)
+	if !p {
+		return http.StatusBadRequest, helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Unknown db alias (%s) provided while setting audit log config", c.DBAlias), nil, nil)
+	}
+
+	rules := map[string]*config.Rule{"create": {Rule: "deny"}, "read": {Rule: "deny"}, "update": {Rule: "deny"}, "delete": {Rule: "deny"}}
+	if err := s.applySchemas(ctx, c.Project, c.DBAlias, projectConfig, config.CrudStub{
+		Collections: map[string]*config.TableRule{utils.TableAuditLogs: {Schema: utils.SchemaAuditLogs, Rules: rules}},
+		DBName:      dbConfig.DBName,
+	}, params); err != nil {
+		return http.StatusInternalServerError, err
+	}
+	return s.setCollectionRules(ctx, projectConfig, c.Project, c.DBAlias, utils.TableAuditLogs, &config.DatabaseRule{Rules: rules}, params)
+}
+
+// setAuditLogConfig applies the audit log config present in the cluster config to the audit manager
+func (s *Manager) setAuditLogConfig(clusterConfig *config.ClusterConfig) {
+	if s.auditMan == nil {
+		return
+	}
+
+	var c *config.AuditLogConfig
+	if clusterConfig != nil {
+		c = clusterConfig.AuditLog
+	}
+	s.auditMan.SetConfig(c)
+}
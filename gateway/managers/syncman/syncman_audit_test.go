@@ -0,0 +1,79 @@
+package syncman
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/spaceuptech/space-cloud/gateway/config"
+	"github.com/spaceuptech/space-cloud/gateway/model"
+)
+
+func TestManager_recordConfigChange(t *testing.T) {
+	ruleResourceID := "chicago--myproject--db-rule--db-users-rule"
+	hookResourceID := "chicago--noProject--integration-hook--hook1"
+	params := model.RequestParams{RequestID: "req1", Claims: map[string]interface{}{"id": "admin"}}
+	globalConfig := &config.Config{Projects: config.Projects{"myproject": &config.Project{
+		DatabaseRules: config.DatabaseRules{ruleResourceID: &config.DatabaseRule{DbAlias: "db", Table: "users"}},
+	}}}
+
+	tests := []struct {
+		name      string
+		apply     func(s *Manager) error
+		storeCall []interface{}
+		storeErr  error
+		wantEntry *model.AuditLogEntry
+		wantErr   bool
+	}{
+		{
+			name: "new project level resource is recorded as add",
+			apply: func(s *Manager) error {
+				return s.setResource(context.Background(), "chicago--myproject--db-rule--db-posts-rule", &config.DatabaseRule{}, params)
+			},
+			storeCall: []interface{}{"SetResource", mock.Anything, "chicago--myproject--db-rule--db-posts-rule", &config.DatabaseRule{}},
+			wantEntry: &model.AuditLogEntry{Kind: model.AuditKindConfig, RequestID: "req1", Actor: "admin", Project: "myproject", Resource: "db-rule", ResourceID: "chicago--myproject--db-rule--db-posts-rule", Verb: config.ResourceAddEvent, Outcome: model.AuditOutcomeSuccess},
+		},
+		{
+			name: "existing project level resource is recorded as update",
+			apply: func(s *Manager) error {
+				return s.setResource(context.Background(), ruleResourceID, &config.DatabaseRule{}, params)
+			},
+			storeCall: []interface{}{"SetResource", mock.Anything, ruleResourceID, &config.DatabaseRule{}},
+			wantEntry: &model.AuditLogEntry{Kind: model.AuditKindConfig, RequestID: "req1", Actor: "admin", Project: "myproject", Resource: "db-rule", ResourceID: ruleResourceID, Verb: config.ResourceUpdateEvent, Outcome: model.AuditOutcomeSuccess},
+		},
+		{
+			name:      "failure to delete resource is recorded",
+			apply:     func(s *Manager) error { return s.deleteResource(context.Background(), ruleResourceID, params) },
+			storeCall: []interface{}{"DeleteResource", mock.Anything, ruleResourceID},
+			storeErr:  errors.New("store is down"),
+			wantEntry: &model.AuditLogEntry{Kind: model.AuditKindConfig, RequestID: "req1", Actor: "admin", Project: "myproject", Resource: "db-rule", ResourceID: ruleResourceID, Verb: config.ResourceDeleteEvent, Outcome: model.AuditOutcomeFailure, Message: "store is down"},
+			wantErr:   true,
+		},
+		{
+			name: "cluster level resource is recorded without project",
+			apply: func(s *Manager) error {
+				return s.setClusterResource(context.Background(), config.ResourceAddEvent, hookResourceID, &config.IntegrationHook{ID: "hook1"}, params)
+			},
+			storeCall: []interface{}{"SetResource", mock.Anything, hookResourceID, &config.IntegrationHook{ID: "hook1"}},
+			wantEntry: &model.AuditLogEntry{Kind: model.AuditKindConfig, RequestID: "req1", Actor: "admin", Resource: "integration-hook", ResourceID: hookResourceID, Verb: config.ResourceAddEvent, Outcome: model.AuditOutcomeSuccess},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := new(mockStoreInterface)
+			audit := new(mockAuditInterface)
+			store.On(tt.storeCall[0].(string), tt.storeCall[1:]...).Return(tt.storeErr)
+			audit.On("Record", mock.Anything, tt.wantEntry).Return()
+
+			s := &Manager{clusterID: "chicago", projectConfig: globalConfig, store: store, auditMan: audit}
+			if err := tt.apply(s); (err != nil) != tt.wantErr {
+				t.Errorf("apply() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			store.AssertExpectations(t)
+			audit.AssertExpectations(t)
+		})
+	}
+}
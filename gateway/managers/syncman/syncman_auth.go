@@ -46,7 +46,7 @@ func (s *Manager) SetUserManagement(ctx context.Context, project, provider strin
 		return http.StatusInternalServerError, err
 	}
 
-	if err := s.store.SetResource(ctx, resourceID, value); err != nil {
+	if err := s.setResource(ctx, resourceID, value, reqParams); err != nil {
 		return http.StatusInternalServerError, err
 	}
 
@@ -123,7 +123,7 @@ func (s *Manager) DeleteUserManagement(ctx context.Context, project, provider st
 		return http.StatusInternalServerError, err
 	}
 
-	if err := s.store.DeleteResource(ctx, resourceID); err != nil {
+	if err := s.deleteResource(ctx, resourceID, reqParams); err != nil {
 		return http.StatusInternalServerError, err
 	}
 
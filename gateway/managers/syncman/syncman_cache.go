@@ -40,7 +40,7 @@ func (s *Manager) SetCacheConfig(ctx context.Context, cacheConfig *config.CacheC
 	s.projectConfig.CacheConfig = cacheConfig
 
 	resourceID := config.GenerateResourceID(s.clusterID, "noProject", config.ResourceCacheConfig, "cache")
-	if err := s.store.SetResource(ctx, resourceID, cacheConfig); err != nil {
+	if err := s.setClusterResource(ctx, config.ResourceUpdateEvent, resourceID, cacheConfig, params); err != nil {
 		return http.StatusInternalServerError, err
 	}
 
@@ -61,11 +61,11 @@ func (s *Manager) SetDeleteCollection(ctx context.Context, project, dbAlias, col
 		return http.StatusInternalServerError, helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to set crud config", err, nil)
 	}
 
-	if err := s.store.DeleteResource(ctx, resourceID); err != nil {
+	if err := s.deleteResource(ctx, resourceID, params); err != nil {
 		return http.StatusInternalServerError, err
 	}
 
-	if err := s.store.DeleteResource(ctx, dbRulesResourceID); err != nil {
+	if err := s.deleteResource(ctx, dbRulesResourceID, params); err != nil {
 		return http.StatusInternalServerError, err
 	}
 
@@ -107,7 +107,7 @@ func (s *Manager) SetDatabaseConnection(ctx context.Context, project, dbAlias st
 		return http.StatusInternalServerError, helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to set crud config", err, nil)
 	}
 
-	if err := s.store.SetResource(ctx, resourceID, v); err != nil {
+	if err := s.setResource(ctx, resourceID, v, params); err != nil {
 		return http.StatusInternalServerError, err
 	}
 
@@ -177,7 +177,7 @@ func (s *Manager) RemoveDatabaseConfig(ctx context.Context, project, dbAlias str
 
 	// Delete resources from store
 	for _, resourceID := range resourceIDs {
-		if err := s.store.DeleteResource(ctx, resourceID); err != nil {
+		if err := s.deleteResource(ctx, resourceID, params); err != nil {
 			return http.StatusInternalServerError, err
 		}
 	}
@@ -293,7 +293,7 @@ func (s *Manager) SetPreparedQueries(ctx context.Context, project, dbAlias, id s
 		return http.StatusInternalServerError, helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to set database prepared query config", err, nil)
 	}
 
-	if err := s.store.SetResource(ctx, resourceID, v); err != nil {
+	if err := s.setResource(ctx, resourceID, v, params); err != nil {
 		return http.StatusInternalServerError, err
 	}
 
@@ -335,7 +335,7 @@ func (s *Manager) RemovePreparedQueries(ctx context.Context, project, dbAlias, i
 		return http.StatusInternalServerError, helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to set database prepared query config", err, nil)
 	}
 
-	if err := s.store.DeleteResource(ctx, resourceID); err != nil {
+	if err := s.deleteResource(ctx, resourceID, params); err != nil {
 		return http.StatusInternalServerError, err
 	}
 
@@ -390,7 +390,7 @@ func (s *Manager) SetModifySchema(ctx context.Context, project, dbAlias, col str
 		return http.StatusInternalServerError, helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to set crud config", err, nil)
 	}
 
-	if err := s.store.SetResource(ctx, resourceID, v); err != nil {
+	if err := s.setResource(ctx, resourceID, v, params); err != nil {
 		return http.StatusInternalServerError, err
 	}
 
@@ -420,10 +420,10 @@ func (s *Manager) SetCollectionRules(ctx context.Context, project, dbAlias, col
 		return http.StatusBadRequest, err
 	}
 
-	return s.setCollectionRules(ctx, projectConfig, project, dbAlias, col, v)
+	return s.setCollectionRules(ctx, projectConfig, project, dbAlias, col, v, params)
 }
 
-func (s *Manager) setCollectionRules(ctx context.Context, projectConfig *config.Project, project, dbAlias, col string, v *config.DatabaseRule) (int, error) {
+func (s *Manager) setCollectionRules(ctx context.Context, projectConfig *config.Project, project, dbAlias, col string, v *config.DatabaseRule, params model.RequestParams) (int, error) {
 	// update collection rules & is realtime in config
 	if _, p := s.checkIfDbAliasExists(projectConfig.DatabaseConfigs, dbAlias); !p {
 		return http.StatusBadRequest, helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Unable to set collection/table rules as provided db alias (%s) does not exists", dbAlias), nil, nil)
@@ -442,7 +442,7 @@ func (s *Manager) setCollectionRules(ctx context.Context, projectConfig *config.
 		return http.StatusInternalServerError, helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to set database rule config", err, nil)
 	}
 
-	if err := s.store.SetResource(ctx, resourceID, v); err != nil {
+	if err := s.setResource(ctx, resourceID, v, params); err != nil {
 		return http.StatusInternalServerError, err
 	}
 
@@ -488,7 +488,7 @@ func (s *Manager) DeleteCollectionRules(ctx context.Context, project, dbAlias, c
 		return http.StatusInternalServerError, helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to set database rules config", err, nil)
 	}
 
-	if err := s.store.DeleteResource(ctx, resourceID); err != nil {
+	if err := s.deleteResource(ctx, resourceID, params); err != nil {
 		return http.StatusInternalServerError, err
 	}
 
@@ -543,7 +543,7 @@ func (s *Manager) SetReloadSchema(ctx context.Context, dbAlias, project string,
 	}
 
 	for resourceID, v := range projectConfig.DatabaseSchemas {
-		if err := s.store.SetResource(ctx, resourceID, v); err != nil {
+		if err := s.setResource(ctx, resourceID, v, params); err != nil {
 			return http.StatusInternalServerError, err
 		}
 	}
@@ -598,7 +598,7 @@ func (s *Manager) SetSchemaInspection(ctx context.Context, project, dbAlias, col
 		return http.StatusBadRequest, helpers.Logger.LogError(helpers.GetRequestID(ctx), "error setting crud config", err, nil)
 	}
 
-	if err := s.store.SetResource(ctx, resourceID, v); err != nil {
+	if err := s.setResource(ctx, resourceID, v, params); err != nil {
 		return http.StatusInternalServerError, err
 	}
 
@@ -647,11 +647,11 @@ func (s *Manager) RemoveCollection(ctx context.Context, project, dbAlias, col st
 		return http.StatusInternalServerError, helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to set crud config", err, nil)
 	}
 
-	if err := s.store.DeleteResource(ctx, dbSchemaResourceID); err != nil {
+	if err := s.deleteResource(ctx, dbSchemaResourceID, params); err != nil {
 		return http.StatusInternalServerError, err
 	}
 
-	if err := s.store.DeleteResource(ctx, dbRulesResourceID); err != nil {
+	if err := s.deleteResource(ctx, dbRulesResourceID, params); err != nil {
 		return http.StatusInternalServerError, err
 	}
 
@@ -681,14 +681,14 @@ func (s *Manager) SetModifyAllSchema(ctx context.Context, dbAlias, project strin
 		return http.StatusBadRequest, err
 	}
 
-	if err := s.applySchemas(ctx, project, dbAlias, projectConfig, v); err != nil {
+	if err := s.applySchemas(ctx, project, dbAlias, projectConfig, v, params); err != nil {
 		return http.StatusInternalServerError, err
 	}
 
 	return http.StatusOK, nil
 }
 
-func (s *Manager) applySchemas(ctx context.Context, project, dbAlias string, projectConfig *config.Project, v config.CrudStub) error {
+func (s *Manager) applySchemas(ctx context.Context, project, dbAlias string, projectConfig *config.Project, v config.CrudStub, params model.RequestParams) error {
 
 	// update schema in config
 	if _, p := s.checkIfDbAliasExists(projectConfig.DatabaseConfigs, dbAlias); !p {
@@ -720,7 +720,7 @@ func (s *Manager) applySchemas(ctx context.Context, project, dbAlias string, pro
 	}
 
 	for resourceID, v := range dbSchemas {
-		if err := s.store.SetResource(ctx, resourceID, v); err != nil {
+		if err := s.setResource(ctx, resourceID, v, params); err != nil {
 			return helpers.Logger.LogError(helpers.GetRequestID(ctx), "error setting crud config", err, nil)
 		}
 	}
@@ -1552,7 +1552,7 @@ func TestManager_applySchemas(t *testing.T) {
 			tt.s.store = &mockStore
 			tt.s.integrationMan = &mockIntegrationManager{skip: true}
 
-			if err := tt.s.applySchemas(context.Background(), tt.args.project, tt.args.dbAlias, tt.args.projectConfig, tt.args.v); (err != nil) != tt.wantErr {
+			if err := tt.s.applySchemas(context.Background(), tt.args.project, tt.args.dbAlias, tt.args.projectConfig, tt.args.v, model.RequestParams{}); (err != nil) != tt.wantErr {
 				t.Errorf("Manager.applySchemas() error = %v, wantErr %v", err, tt.wantErr)
 			}
 
@@ -47,7 +47,7 @@ func (s *Manager) SetEventingRule(ctx context.Context, project, ruleName string,
 		return http.StatusInternalServerError, helpers.Logger.LogError(helpers.GetRequestID(ctx), "error setting eventing config", err, nil)
 	}
 
-	if err := s.store.SetResource(ctx, resourceID, value); err != nil {
+	if err := s.setResource(ctx, resourceID, value, params); err != nil {
 		return http.StatusInternalServerError, err
 	}
 
@@ -84,7 +84,7 @@ func (s *Manager) SetDeleteEventingRule(ctx context.Context, project, ruleName s
 		return http.StatusInternalServerError, helpers.Logger.LogError(helpers.GetRequestID(ctx), "error setting eventing config", err, nil)
 	}
 
-	if err := s.store.DeleteResource(ctx, resourceID); err != nil {
+	if err := s.deleteResource(ctx, resourceID, params); err != nil {
 		return http.StatusInternalServerError, err
 	}
 
@@ -133,21 +133,21 @@ func (s *Manager) SetEventingConfig(ctx context.Context, project, dbAlias string
 				utils.TableInvocationLogs: {Schema: utils.SchemaInvocationLogs, Rules: map[string]*config.Rule{"create": {Rule: "deny"}, "read": {Rule: "deny"}, "update": {Rule: "deny"}, "delete": {Rule: "deny"}}},
 			},
 			DBName: dbConfig.DBName,
-		}); err != nil {
+		}, params); err != nil {
 			return http.StatusInternalServerError, err
 		}
-		status, err := s.setCollectionRules(ctx, projectConfig, project, dbAlias, utils.TableEventingLogs, &config.DatabaseRule{Rules: map[string]*config.Rule{"create": {Rule: "deny"}, "read": {Rule: "deny"}, "update": {Rule: "deny"}, "delete": {Rule: "deny"}}})
+		status, err := s.setCollectionRules(ctx, projectConfig, project, dbAlias, utils.TableEventingLogs, &config.DatabaseRule{Rules: map[string]*config.Rule{"create": {Rule: "deny"}, "read": {Rule: "deny"}, "update": {Rule: "deny"}, "delete": {Rule: "deny"}}}, params)
 		if err != nil {
 			return status, err
 		}
-		status, err = s.setCollectionRules(ctx, projectConfig, project, dbAlias, utils.TableInvocationLogs, &config.DatabaseRule{Rules: map[string]*config.Rule{"create": {Rule: "deny"}, "read": {Rule: "deny"}, "update": {Rule: "deny"}, "delete": {Rule: "deny"}}})
+		status, err = s.setCollectionRules(ctx, projectConfig, project, dbAlias, utils.TableInvocationLogs, &config.DatabaseRule{Rules: map[string]*config.Rule{"create": {Rule: "deny"}, "read": {Rule: "deny"}, "update": {Rule: "deny"}, "delete": {Rule: "deny"}}}, params)
 		if err != nil {
 			return status, err
 		}
 	}
 
 	resourceID := config.GenerateResourceID(s.clusterID, project, config.ResourceEventingConfig, "eventing")
-	if err := s.store.SetResource(ctx, resourceID, projectConfig.EventingConfig); err != nil {
+	if err := s.setResource(ctx, resourceID, projectConfig.EventingConfig, params); err != nil {
 		return http.StatusInternalServerError, err
 	}
 
@@ -214,7 +214,7 @@ func (s *Manager) SetEventingSchema(ctx context.Context, project string, evType
 		return http.StatusInternalServerError, helpers.Logger.LogError(helpers.GetRequestID(ctx), "error setting eventing config", err, nil)
 	}
 
-	if err := s.store.SetResource(ctx, resourceID, v); err != nil {
+	if err := s.setResource(ctx, resourceID, v, params); err != nil {
 		return http.StatusInternalServerError, err
 	}
 
@@ -250,7 +250,7 @@ func (s *Manager) SetDeleteEventingSchema(ctx context.Context, project string, e
 		return http.StatusInternalServerError, helpers.Logger.LogError(helpers.GetRequestID(ctx), "error setting eventing config", err, nil)
 	}
 
-	if err := s.store.DeleteResource(ctx, resourceID); err != nil {
+	if err := s.deleteResource(ctx, resourceID, params); err != nil {
 		return http.StatusInternalServerError, err
 	}
 
@@ -292,7 +292,7 @@ func (s *Manager) SetEventingSecurityRules(ctx context.Context, project, evType
 		return http.StatusInternalServerError, helpers.Logger.LogError(helpers.GetRequestID(ctx), "error setting eventing config", err, nil)
 	}
 
-	if err := s.store.SetResource(ctx, resourceID, rule); err != nil {
+	if err := s.setResource(ctx, resourceID, rule, params); err != nil {
 		return http.StatusInternalServerError, err
 	}
 
@@ -329,7 +329,7 @@ func (s *Manager) SetDeleteEventingSecurityRules(ctx context.Context, project, e
 		return http.StatusInternalServerError, helpers.Logger.LogError(helpers.GetRequestID(ctx), "error setting eventing config", err, nil)
 	}
 
-	if err := s.store.DeleteResource(ctx, resourceID); err != nil {
+	if err := s.deleteResource(ctx, resourceID, params); err != nil {
 		return http.StatusInternalServerError, err
 	}
 
@@ -9,6 +9,7 @@ import (
 
 	"github.com/spaceuptech/space-cloud/gateway/config"
 	"github.com/spaceuptech/space-cloud/gateway/model"
+	"github.com/spaceuptech/space-cloud/gateway/utils"
 )
 
 // SetFileStore sets the file store module
@@ -41,7 +42,7 @@ func (s *Manager) SetFileStore(ctx context.Context, project string, value *confi
 	}
 
 	resourceID := config.GenerateResourceID(s.clusterID, project, config.ResourceFileStoreConfig, "filestore")
-	if err := s.store.SetResource(ctx, resourceID, value); err != nil {
+	if err := s.setResource(ctx, resourceID, value, params); err != nil {
 		return http.StatusInternalServerError, err
 	}
 
@@ -83,7 +84,7 @@ func (s *Manager) SetFileRule(ctx context.Context, project, id string, value *co
 		return http.StatusInternalServerError, err
 	}
 
-	if err := s.store.SetResource(ctx, resourceID, value); err != nil {
+	if err := s.setResource(ctx, resourceID, value, params); err != nil {
 		return http.StatusInternalServerError, err
 	}
 
@@ -120,7 +121,7 @@ func (s *Manager) SetDeleteFileRule(ctx context.Context, project, filename strin
 		return http.StatusInternalServerError, err
 	}
 
-	if err := s.store.DeleteResource(ctx, resourceID); err != nil {
+	if err := s.deleteResource(ctx, resourceID, params); err != nil {
 		return http.StatusInternalServerError, err
 	}
 
@@ -191,3 +192,128 @@ func (s *Manager) GetFileStoreRules(ctx context.Context, project, ruleID string,
 	}
 	return http.StatusOK, fileRules, nil
 }
+
+// SetFileStoreAlias sets a named file store of the project
+func (s *Manager) SetFileStoreAlias(ctx context.Context, project, alias string, value *config.FileStoreConfig, params model.RequestParams) (int, error) {
+	// Check if the request has been hijacked
+	hookResponse := s.integrationMan.InvokeHook(ctx, params)
+	if hookResponse.CheckResponse() {
+		// Check if an error occurred
+		if err := hookResponse.Error(); err != nil {
+			return hookResponse.Status(), err
+		}
+
+		// Gracefully return
+		return hookResponse.Status(), nil
+	}
+
+	if alias == "" || alias == utils.DefaultFileStore {
+		return http.StatusBadRequest, helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Invalid alias (%s) provided for file store", alias), nil, nil)
+	}
+
+	// Acquire a lock
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	value.ID = alias
+	projectConfig, err := s.getConfigWithoutLock(ctx, project)
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	resourceID := config.GenerateResourceID(s.clusterID, project, config.ResourceFileStoreAlias, alias)
+	if projectConfig.FileStores == nil {
+		projectConfig.FileStores = config.FileStores{resourceID: value}
+	} else {
+		projectConfig.FileStores[resourceID] = value
+	}
+
+	if err := s.modules.SetFileStoresConfig(ctx, project, projectConfig.FileStores); err != nil {
+		return http.StatusInternalServerError, helpers.Logger.LogError(helpers.GetRequestID(ctx), "error setting named file stores", err, nil)
+	}
+
+	if err := s.setResource(ctx, resourceID, value, params); err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	return http.StatusOK, nil
+}
+
+// DeleteFileStoreAlias deletes a named file store of the project
+func (s *Manager) DeleteFileStoreAlias(ctx context.Context, project, alias string, params model.RequestParams) (int, error) {
+	// Check if the request has been hijacked
+	hookResponse := s.integrationMan.InvokeHook(ctx, params)
+	if hookResponse.CheckResponse() {
+		// Check if an error occurred
+		if err := hookResponse.Error(); err != nil {
+			return hookResponse.Status(), err
+		}
+
+		// Gracefully return
+		return hookResponse.Status(), nil
+	}
+
+	// Acquire a lock
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	projectConfig, err := s.getConfigWithoutLock(ctx, project)
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	resourceID := config.GenerateResourceID(s.clusterID, project, config.ResourceFileStoreAlias, alias)
+	if _, ok := projectConfig.FileStores[resourceID]; !ok {
+		return http.StatusBadRequest, helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("File store (%s) does not exists", alias), nil, nil)
+	}
+	delete(projectConfig.FileStores, resourceID)
+
+	if err := s.modules.SetFileStoresConfig(ctx, project, projectConfig.FileStores); err != nil {
+		return http.StatusInternalServerError, helpers.Logger.LogError(helpers.GetRequestID(ctx), "error setting named file stores", err, nil)
+	}
+
+	if err := s.deleteResource(ctx, resourceID, params); err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	return http.StatusOK, nil
+}
+
+// GetFileStoreAliases gets the named file stores of the project
+func (s *Manager) GetFileStoreAliases(ctx context.Context, project, alias string, params model.RequestParams) (int, []interface{}, error) {
+	// Check if the request has been hijacked
+	hookResponse := s.integrationMan.InvokeHook(ctx, params)
+	if hookResponse.CheckResponse() {
+		// Check if an error occurred
+		if err := hookResponse.Error(); err != nil {
+			return hookResponse.Status(), nil, err
+		}
+
+		// Gracefully return
+		return hookResponse.Status(), hookResponse.Result().([]interface{}), nil
+	}
+
+	// Acquire a lock
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	projectConfig, err := s.getConfigWithoutLock(ctx, project)
+	if err != nil {
+		return http.StatusBadRequest, nil, err
+	}
+
+	if alias != "*" {
+		resourceID := config.GenerateResourceID(s.clusterID, project, config.ResourceFileStoreAlias, alias)
+		fileStore, ok := projectConfig.FileStores[resourceID]
+		if ok {
+			return http.StatusOK, []interface{}{fileStore}, nil
+		}
+		return http.StatusBadRequest, nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("File store (%s) does not exists", alias), fmt.Errorf("file store not found in config"), nil)
+	}
+
+	fileStores := []interface{}{}
+	for _, value := range projectConfig.FileStores {
+		fileStores = append(fileStores, value)
+	}
+	return http.StatusOK, fileStores, nil
+}
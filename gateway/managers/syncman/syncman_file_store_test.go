@@ -0,0 +1,152 @@
+package syncman
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/spaceuptech/space-cloud/gateway/config"
+	"github.com/spaceuptech/space-cloud/gateway/model"
+)
+
+func TestManager_SetFileStoreAlias(t *testing.T) {
+	resourceID := "chicago--myproject--filestore-alias--media"
+
+	tests := []struct {
+		name      string
+		alias     string
+		existing  config.FileStores
+		value     *config.FileStoreConfig
+		wantEvent string
+		wantErr   bool
+	}{
+		{
+			name:      "new file store is added",
+			alias:     "media",
+			value:     &config.FileStoreConfig{Enabled: true, StoreType: "amazon-s3", Bucket: "media"},
+			wantEvent: config.ResourceAddEvent,
+		},
+		{
+			name:      "existing file store is updated",
+			alias:     "media",
+			existing:  config.FileStores{resourceID: &config.FileStoreConfig{ID: "media", Enabled: true, StoreType: "local", Conn: "/tmp"}},
+			value:     &config.FileStoreConfig{Enabled: true, StoreType: "amazon-s3", Bucket: "media"},
+			wantEvent: config.ResourceUpdateEvent,
+		},
+		{
+			name:    "alias of the default file store provided",
+			alias:   "default",
+			value:   &config.FileStoreConfig{Enabled: true, StoreType: "local", Conn: "/tmp"},
+			wantErr: true,
+		},
+		{
+			name:    "alias not provided",
+			value:   &config.FileStoreConfig{Enabled: true, StoreType: "local", Conn: "/tmp"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := new(mockStoreInterface)
+			modules := new(mockModulesInterface)
+			audit := new(mockAuditInterface)
+			if !tt.wantErr {
+				store.On("SetResource", mock.Anything, resourceID, tt.value).Return(nil)
+				modules.On("SetFileStoresConfig", mock.Anything, "myproject", mock.MatchedBy(func(stores config.FileStores) bool {
+					return stores[resourceID] == tt.value
+				})).Return(nil)
+				audit.On("Record", mock.Anything, mock.MatchedBy(func(entry *model.AuditLogEntry) bool {
+					return entry.ResourceID == resourceID && entry.Verb == tt.wantEvent
+				})).Return()
+			}
+
+			project := config.GenerateEmptyProject(&config.ProjectConfig{ID: "myproject"})
+			project.FileStores = tt.existing
+			s := &Manager{
+				clusterID:      "chicago",
+				projectConfig:  &config.Config{Projects: config.Projects{"myproject": project}},
+				store:          store,
+				modules:        modules,
+				auditMan:       audit,
+				integrationMan: &mockIntegrationManager{skip: true},
+			}
+			if _, err := s.SetFileStoreAlias(context.Background(), "myproject", tt.alias, tt.value, model.RequestParams{}); (err != nil) != tt.wantErr {
+				t.Fatalf("SetFileStoreAlias() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if tt.value.ID != tt.alias {
+				t.Errorf("SetFileStoreAlias() id = %v, want %v", tt.value.ID, tt.alias)
+			}
+
+			store.AssertExpectations(t)
+			modules.AssertExpectations(t)
+			audit.AssertExpectations(t)
+		})
+	}
+}
+
+func TestManager_DeleteFileStoreAlias(t *testing.T) {
+	resourceID := "chicago--myproject--filestore-alias--media"
+	otherResourceID := "chicago--myproject--filestore-alias--exports"
+
+	tests := []struct {
+		name    string
+		alias   string
+		wantErr bool
+	}{
+		{
+			name:  "file store is deleted",
+			alias: "media",
+		},
+		{
+			name:    "file store does not exist",
+			alias:   "scratch",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := new(mockStoreInterface)
+			modules := new(mockModulesInterface)
+			audit := new(mockAuditInterface)
+			if !tt.wantErr {
+				store.On("DeleteResource", mock.Anything, resourceID).Return(nil)
+				modules.On("SetFileStoresConfig", mock.Anything, "myproject", mock.MatchedBy(func(stores config.FileStores) bool {
+					_, ok := stores[resourceID]
+					return !ok && len(stores) == 1
+				})).Return(nil)
+				audit.On("Record", mock.Anything, mock.MatchedBy(func(entry *model.AuditLogEntry) bool {
+					return entry.ResourceID == resourceID && entry.Verb == config.ResourceDeleteEvent
+				})).Return()
+			}
+
+			project := config.GenerateEmptyProject(&config.ProjectConfig{ID: "myproject"})
+			project.FileStores = config.FileStores{
+				resourceID:      &config.FileStoreConfig{ID: "media", Enabled: true, StoreType: "local", Conn: "/tmp"},
+				otherResourceID: &config.FileStoreConfig{ID: "exports", Enabled: true, StoreType: "local", Conn: "/tmp"},
+			}
+			s := &Manager{
+				clusterID:      "chicago",
+				projectConfig:  &config.Config{Projects: config.Projects{"myproject": project}},
+				store:          store,
+				modules:        modules,
+				auditMan:       audit,
+				integrationMan: &mockIntegrationManager{skip: true},
+			}
+			if _, err := s.DeleteFileStoreAlias(context.Background(), "myproject", tt.alias, model.RequestParams{}); (err != nil) != tt.wantErr {
+				t.Fatalf("DeleteFileStoreAlias() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			store.AssertExpectations(t)
+			modules.AssertExpectations(t)
+			audit.AssertExpectations(t)
+		})
+	}
+}
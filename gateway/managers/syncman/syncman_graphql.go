@@ -0,0 +1,147 @@
+package syncman
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/spaceuptech/helpers"
+
+	"github.com/spaceuptech/space-cloud/gateway/config"
+	"github.com/spaceuptech/space-cloud/gateway/model"
+)
+
+// SetGraphQLPersistedQuery adds a persisted graphql query. The id of the query must be the sha256 hash of the query
+func (s *Manager) SetGraphQLPersistedQuery(ctx context.Context, project, id string, value *config.GraphQLPersistedQuery, params model.RequestParams) (int, error) {
+	// Check if the request has been hijacked
+	hookResponse := s.integrationMan.InvokeHook(ctx, params)
+	if hookResponse.CheckResponse() {
+		// Check if an error occurred
+		if err := hookResponse.Error(); err != nil {
+			return hookResponse.Status(), err
+		}
+
+		// Gracefully return
+		return hookResponse.Status(), nil
+	}
+
+	// Clients may send the hash in upper case. We store it in lower case to match the hash computed by the graphql module
+	id = strings.ToLower(id)
+	if err := validatePersistedQuery(id, value); err != nil {
+		return http.StatusBadRequest, helpers.Logger.LogError(helpers.GetRequestID(ctx), err.Error(), nil, nil)
+	}
+
+	// Acquire a lock
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	value.ID = id
+	projectConfig, err := s.getConfigWithoutLock(ctx, project)
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	resourceID := config.GenerateResourceID(s.clusterID, project, config.ResourceGraphQLPersistedQuery, id)
+	if projectConfig.GraphQLPersistedQueries == nil {
+		projectConfig.GraphQLPersistedQueries = config.GraphQLPersistedQueries{resourceID: value}
+	} else {
+		projectConfig.GraphQLPersistedQueries[resourceID] = value
+	}
+
+	if err := s.modules.SetGraphQLPersistedQueryConfig(ctx, project, projectConfig.GraphQLPersistedQueries); err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	if err := s.setResource(ctx, resourceID, value, params); err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	return http.StatusOK, nil
+}
+
+// DeleteGraphQLPersistedQuery deletes a persisted graphql query
+func (s *Manager) DeleteGraphQLPersistedQuery(ctx context.Context, project, id string, params model.RequestParams) (int, error) {
+	// Check if the request has been hijacked
+	hookResponse := s.integrationMan.InvokeHook(ctx, params)
+	if hookResponse.CheckResponse() {
+		// Check if an error occurred
+		if err := hookResponse.Error(); err != nil {
+			return hookResponse.Status(), err
+		}
+
+		// Gracefully return
+		return hookResponse.Status(), nil
+	}
+
+	// Acquire a lock
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	projectConfig, err := s.getConfigWithoutLock(ctx, project)
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	resourceID := config.GenerateResourceID(s.clusterID, project, config.ResourceGraphQLPersistedQuery, strings.ToLower(id))
+	delete(projectConfig.GraphQLPersistedQueries, resourceID)
+
+	if err := s.modules.SetGraphQLPersistedQueryConfig(ctx, project, projectConfig.GraphQLPersistedQueries); err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	if err := s.deleteResource(ctx, resourceID, params); err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	return http.StatusOK, nil
+}
+
+// GetGraphQLPersistedQueries gets the persisted graphql queries
+func (s *Manager) GetGraphQLPersistedQueries(ctx context.Context, project, id string, params model.RequestParams) (int, []interface{}, error) {
+	// Check if the request has been hijacked
+	hookResponse := s.integrationMan.InvokeHook(ctx, params)
+	if hookResponse.CheckResponse() {
+		// Check if an error occurred
+		if err := hookResponse.Error(); err != nil {
+			return hookResponse.Status(), nil, err
+		}
+
+		// Gracefully return
+		return hookResponse.Status(), hookResponse.Result().([]interface{}), nil
+	}
+
+	// Acquire a lock
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	projectConfig, err := s.getConfigWithoutLock(ctx, project)
+	if err != nil {
+		return http.StatusBadRequest, nil, err
+	}
+
+	if id != "*" {
+		query, ok := projectConfig.GraphQLPersistedQueries[config.GenerateResourceID(s.clusterID, project, config.ResourceGraphQLPersistedQuery, strings.ToLower(id))]
+		if !ok {
+			return http.StatusBadRequest, nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Persisted query with id (%s) does not exists", id), nil, nil)
+		}
+		return http.StatusOK, []interface{}{query}, nil
+	}
+
+	queries := []interface{}{}
+	for _, value := range projectConfig.GraphQLPersistedQueries {
+		queries = append(queries, value)
+	}
+	return http.StatusOK, queries, nil
+}
+
+// validatePersistedQuery checks if the id of the persisted query is the sha256 hash of its query
+func validatePersistedQuery(id string, value *config.GraphQLPersistedQuery) error {
+	hash := sha256.Sum256([]byte(value.Query))
+	if hex.EncodeToString(hash[:]) != id {
+		return fmt.Errorf("Persisted query id (%s) is not the sha256 hash of the query", id)
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+package syncman
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/spaceuptech/space-cloud/gateway/config"
+	"github.com/spaceuptech/space-cloud/gateway/model"
+)
+
+func TestManager_SetGraphQLPersistedQuery(t *testing.T) {
+	// sha256 hash of the query `{ __typename }`
+	const hash = "7f56e67dd21ab3f30d1ff8b7bed08893f0a0db86449836189b361dd1e56ddb4b"
+
+	type mockArgs struct {
+		method         string
+		args           []interface{}
+		paramsReturned []interface{}
+	}
+	type args struct {
+		ctx     context.Context
+		project string
+		id      string
+		value   *config.GraphQLPersistedQuery
+	}
+	tests := []struct {
+		name            string
+		args            args
+		modulesMockArgs []mockArgs
+		storeMockArgs   []mockArgs
+		wantErr         bool
+	}{
+		{
+			name:    "Project config not found",
+			args:    args{ctx: context.Background(), project: "test", id: hash, value: &config.GraphQLPersistedQuery{Query: "{ __typename }"}},
+			wantErr: true,
+		},
+		{
+			name:    "Id is not the hash of the query",
+			args:    args{ctx: context.Background(), project: "myproject", id: hash, value: &config.GraphQLPersistedQuery{Query: "{ __schema { types { name } } }"}},
+			wantErr: true,
+		},
+		{
+			name: "Persisted query is added with a lower case id",
+			args: args{ctx: context.Background(), project: "myproject", id: "7F56E67DD21AB3F30D1FF8B7BED08893F0A0DB86449836189B361DD1E56DDB4B", value: &config.GraphQLPersistedQuery{Name: "typename", Query: "{ __typename }"}},
+			modulesMockArgs: []mockArgs{
+				{
+					method: "SetGraphQLPersistedQueryConfig",
+					args: []interface{}{mock.Anything, "myproject", config.GraphQLPersistedQueries{
+						config.GenerateResourceID("chicago", "myproject", config.ResourceGraphQLPersistedQuery, hash): &config.GraphQLPersistedQuery{ID: hash, Name: "typename", Query: "{ __typename }"},
+					}},
+					paramsReturned: []interface{}{nil},
+				},
+			},
+			storeMockArgs: []mockArgs{
+				{
+					method:         "SetResource",
+					args:           []interface{}{mock.Anything, config.GenerateResourceID("chicago", "myproject", config.ResourceGraphQLPersistedQuery, hash), &config.GraphQLPersistedQuery{ID: hash, Name: "typename", Query: "{ __typename }"}},
+					paramsReturned: []interface{}{nil},
+				},
+			},
+		},
+		{
+			name: "Unable to set graphql config",
+			args: args{ctx: context.Background(), project: "myproject", id: hash, value: &config.GraphQLPersistedQuery{Query: "{ __typename }"}},
+			modulesMockArgs: []mockArgs{
+				{
+					method:         "SetGraphQLPersistedQueryConfig",
+					args:           []interface{}{mock.Anything, "myproject", mock.Anything},
+					paramsReturned: []interface{}{errors.New("unable to set config")},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Unable to set resource",
+			args: args{ctx: context.Background(), project: "myproject", id: hash, value: &config.GraphQLPersistedQuery{Query: "{ __typename }"}},
+			modulesMockArgs: []mockArgs{
+				{
+					method:         "SetGraphQLPersistedQueryConfig",
+					args:           []interface{}{mock.Anything, "myproject", mock.Anything},
+					paramsReturned: []interface{}{nil},
+				},
+			},
+			storeMockArgs: []mockArgs{
+				{
+					method:         "SetResource",
+					args:           []interface{}{mock.Anything, mock.Anything, mock.Anything},
+					paramsReturned: []interface{}{errors.New("unable to set resource")},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Manager{
+				clusterID: "chicago",
+				projectConfig: &config.Config{
+					Projects: config.Projects{
+						"myproject": &config.Project{
+							ProjectConfig: &config.ProjectConfig{ID: "myproject"},
+						},
+					},
+				},
+			}
+
+			mockModules := mockModulesInterface{}
+			mockStore := mockStoreInterface{}
+
+			for _, m := range tt.modulesMockArgs {
+				mockModules.On(m.method, m.args...).Return(m.paramsReturned...)
+			}
+			for _, m := range tt.storeMockArgs {
+				mockStore.On(m.method, m.args...).Return(m.paramsReturned...)
+			}
+
+			s.modules = &mockModules
+			s.store = &mockStore
+			s.integrationMan = &mockIntegrationManager{skip: true}
+
+			_, err := s.SetGraphQLPersistedQuery(tt.args.ctx, tt.args.project, tt.args.id, tt.args.value, model.RequestParams{})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Manager.SetGraphQLPersistedQuery() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			mockModules.AssertExpectations(t)
+			mockStore.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,563 @@
+package syncman
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/spaceuptech/helpers"
+
+	"github.com/spaceuptech/space-cloud/gateway/config"
+	"github.com/spaceuptech/space-cloud/gateway/model"
+)
+
+// setResource writes the resource to the store and records the change in the config history of the project and in
+// the audit log. Only project level resources are versioned. The lock must be held by the caller
+func (s *Manager) setResource(ctx context.Context, resourceID string, value interface{}, params model.RequestParams) error {
+	previous, _, err := getResource(ctx, s.projectConfig, resourceID)
+	if err != nil {
+		return err
+	}
+	return s.setResourceWithPrevious(ctx, resourceID, previous, value, params)
+}
+
+// setResourceWithPrevious is used instead of setResource by the callers which modify the global config before writing
+// the resource to the store
+func (s *Manager) setResourceWithPrevious(ctx context.Context, resourceID string, previous, value interface{}, params model.RequestParams) error {
+	event := config.ResourceUpdateEvent
+	if previous == nil {
+		event = config.ResourceAddEvent
+	}
+
+	// The stores update the global config in place, so the entry needs to be prepared before writing the resource
+	var entry *model.ConfigHistoryEntry
+	if s.history != nil {
+		var err error
+		entry, err = newConfigHistoryEntry(ctx, event, resourceID, previous, value, params)
+		if err != nil {
+			return err
+		}
+	}
+
+	err := s.store.SetResource(ctx, resourceID, value)
+	s.recordConfigChange(ctx, event, resourceID, params, err)
+	if err != nil {
+		return err
+	}
+
+	if entry != nil {
+		s.addHistory(ctx, entry)
+	}
+	return nil
+}
+
+// deleteResource deletes the resource from the store and records the change in the config history of the project and
+// in the audit log. The lock must be held by the caller
+func (s *Manager) deleteResource(ctx context.Context, resourceID string, params model.RequestParams) error {
+	previous, ok, err := getResource(ctx, s.projectConfig, resourceID)
+	if err != nil {
+		return err
+	}
+
+	var entry *model.ConfigHistoryEntry
+	if s.history != nil {
+		entry, err = newConfigHistoryEntry(ctx, config.ResourceDeleteEvent, resourceID, previous, nil, params)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = s.store.DeleteResource(ctx, resourceID)
+	s.recordConfigChange(ctx, config.ResourceDeleteEvent, resourceID, params, err)
+	if err != nil {
+		return err
+	}
+
+	// Deleting a resource which doesn't exist isn't a change
+	if ok && entry != nil {
+		s.addHistory(ctx, entry)
+	}
+	return nil
+}
+
+// addHistory stores the entry in the config history. The resource has already been applied at this point, so a failure
+// to record it is only logged
+func (s *Manager) addHistory(ctx context.Context, entry *model.ConfigHistoryEntry) {
+	_, projectID, _, _ := splitResourceID(ctx, entry.ResourceID)
+	if err := s.history.AddHistory(ctx, projectID, entry); err != nil {
+		_ = helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to record change in config history", err, map[string]interface{}{"resourceId": entry.ResourceID})
+	}
+}
+
+func newConfigHistoryEntry(ctx context.Context, event, resourceID string, previous, value interface{}, params model.RequestParams) (*model.ConfigHistoryEntry, error) {
+	_, _, resourceType, err := splitResourceID(ctx, resourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	previous, err = normaliseConfigValue(ctx, previous)
+	if err != nil {
+		return nil, err
+	}
+	value, err = normaliseConfigValue(ctx, value)
+	if err != nil {
+		return nil, err
+	}
+
+	author, _ := params.Claims["id"].(string)
+	return &model.ConfigHistoryEntry{
+		ResourceID:   resourceID,
+		ResourceType: string(resourceType),
+		Event:        event,
+		Author:       author,
+		Timestamp:    time.Now().UTC(),
+		Previous:     previous,
+		Value:        value,
+	}, nil
+}
+
+// normaliseConfigValue converts the typed config into its json representation so that values loaded from the history
+// can be compared with the current config
+func normaliseConfigValue(ctx context.Context, value interface{}) (interface{}, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to marshal config value", err, nil)
+	}
+
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to unmarshal config value", err, nil)
+	}
+	return v, nil
+}
+
+// GetConfigHistory returns the config history of the project. The history is filtered by resource id if provided
+func (s *Manager) GetConfigHistory(ctx context.Context, project, resourceID string, params model.RequestParams) (int, []interface{}, error) {
+	// Check if the request has been hijacked
+	hookResponse := s.integrationMan.InvokeHook(ctx, params)
+	if hookResponse.CheckResponse() {
+		// Check if an error occurred
+		if err := hookResponse.Error(); err != nil {
+			return hookResponse.Status(), nil, err
+		}
+
+		// Gracefully return
+		return hookResponse.Status(), hookResponse.Result().([]interface{}), nil
+	}
+
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	entries, status, err := s.getHistoryWithoutLock(ctx, project)
+	if err != nil {
+		return status, nil, err
+	}
+
+	result := []interface{}{}
+	for _, entry := range entries {
+		if resourceID == "" || entry.ResourceID == resourceID {
+			result = append(result, entry)
+		}
+	}
+	return http.StatusOK, result, nil
+}
+
+// configSecretKeys are the keys of the json representation of a resource which hold its secrets
+var configSecretKeys = map[config.Resource][]string{
+	config.ResourceProject:         {"secrets", "aesKey"},
+	config.ResourceDatabaseConfig:  {"conn"},
+	config.ResourceFileStoreConfig: {"conn", "secret"},
+	config.ResourceAuthProvider:    {"secret"},
+}
+
+// RemoveConfigHistorySecrets returns copies of the config history entries without the secrets, aes key and connection
+// strings of the resources. The entries stored in the config history are left untouched
+func RemoveConfigHistorySecrets(entries []interface{}) []interface{} {
+	result := make([]interface{}, len(entries))
+	for i, v := range entries {
+		result[i] = v
+		entry, ok := v.(*model.ConfigHistoryEntry)
+		if !ok || entry == nil {
+			continue
+		}
+
+		keys, ok := configSecretKeys[config.Resource(entry.ResourceType)]
+		if !ok {
+			continue
+		}
+
+		redacted := *entry
+		redacted.Previous = removeConfigKeys(entry.Previous, keys)
+		redacted.Value = removeConfigKeys(entry.Value, keys)
+		result[i] = &redacted
+	}
+	return result
+}
+
+// RemoveConfigDiffSecrets returns the differences of the resource without the ones of its secrets, aes key and connection
+// strings. The differences of an added or deleted resource are returned without those keys
+func RemoveConfigDiffSecrets(ctx context.Context, resourceID string, diffs []interface{}) []interface{} {
+	_, _, resourceType, err := splitResourceID(ctx, resourceID)
+	if err != nil {
+		return diffs
+	}
+	keys, ok := configSecretKeys[resourceType]
+	if !ok {
+		return diffs
+	}
+
+	result := make([]interface{}, 0, len(diffs))
+	for _, v := range diffs {
+		diff, ok := v.(*model.ConfigDiff)
+		if !ok || diff == nil {
+			result = append(result, v)
+			continue
+		}
+
+		if diff.Path == "." {
+			result = append(result, &model.ConfigDiff{Path: diff.Path, From: removeConfigKeys(diff.From, keys), To: removeConfigKeys(diff.To, keys)})
+			continue
+		}
+		if !isConfigKey(strings.SplitN(diff.Path, ".", 2)[0], keys) {
+			result = append(result, diff)
+		}
+	}
+	return result
+}
+
+// removeConfigKeys returns a shallow copy of the json representation of a resource without the provided keys
+func removeConfigKeys(value interface{}, keys []string) interface{} {
+	obj, ok := value.(map[string]interface{})
+	if !ok {
+		return value
+	}
+
+	result := make(map[string]interface{}, len(obj))
+	for key, v := range obj {
+		if !isConfigKey(key, keys) {
+			result[key] = v
+		}
+	}
+	return result
+}
+
+func isConfigKey(key string, keys []string) bool {
+	for _, k := range keys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+// GetConfigHistoryDiff returns the differences of a resource between two versions of the project. The latest version is used if
+// the to version is zero
+func (s *Manager) GetConfigHistoryDiff(ctx context.Context, project, resourceID string, from, to int64, params model.RequestParams) (int, []interface{}, error) {
+	// Check if the request has been hijacked
+	hookResponse := s.integrationMan.InvokeHook(ctx, params)
+	if hookResponse.CheckResponse() {
+		// Check if an error occurred
+		if err := hookResponse.Error(); err != nil {
+			return hookResponse.Status(), nil, err
+		}
+
+		// Gracefully return
+		return hookResponse.Status(), hookResponse.Result().([]interface{}), nil
+	}
+
+	if to == 0 {
+		to = math.MaxInt64
+	}
+
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	entries, status, err := s.getHistoryWithoutLock(ctx, project)
+	if err != nil {
+		return status, nil, err
+	}
+
+	fromValue, _, ok := getValueAtVersion(entries, resourceID, from)
+	if !ok {
+		return http.StatusBadRequest, nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("No config history found for resource (%s)", resourceID), nil, nil)
+	}
+	toValue, _, _ := getValueAtVersion(entries, resourceID, to)
+
+	result := []interface{}{}
+	for _, diff := range diffConfigValues("", fromValue, toValue) {
+		result = append(result, diff)
+	}
+	return http.StatusOK, result, nil
+}
+
+// RollbackConfig rolls back a resource or the entire project to the provided version or point in time. The rollback itself
+// is recorded in the config history, so it can be undone as well. It returns the ids of the resources which were changed
+func (s *Manager) RollbackConfig(ctx context.Context, project string, req *model.ConfigRollbackRequest, params model.RequestParams) (int, []interface{}, error) {
+	// Check if the request has been hijacked
+	hookResponse := s.integrationMan.InvokeHook(ctx, params)
+	if hookResponse.CheckResponse() {
+		// Check if an error occurred
+		if err := hookResponse.Error(); err != nil {
+			return hookResponse.Status(), nil, err
+		}
+
+		// Gracefully return
+		return hookResponse.Status(), hookResponse.Result().([]interface{}), nil
+	}
+
+	if (req.Version > 0) == (req.Timestamp != nil) {
+		return http.StatusBadRequest, nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), "Either the version or the timestamp needs to be provided to roll back config", nil, nil)
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if _, err := s.getConfigWithoutLock(ctx, project); err != nil {
+		return http.StatusBadRequest, nil, err
+	}
+
+	entries, status, err := s.getHistoryWithoutLock(ctx, project)
+	if err != nil {
+		return status, nil, err
+	}
+
+	version := req.Version
+	if req.Timestamp != nil {
+		version = 0
+		for _, entry := range entries {
+			if !entry.Timestamp.After(*req.Timestamp) {
+				version = entry.Version
+			}
+		}
+	}
+
+	// Find the resources which have changed after the version
+	changed := map[string]bool{}
+	found := false
+	for _, entry := range entries {
+		if req.ResourceID != "" && entry.ResourceID != req.ResourceID {
+			continue
+		}
+		found = true
+		if entry.Version > version {
+			changed[entry.ResourceID] = true
+		}
+	}
+	if req.ResourceID != "" && !found {
+		return http.StatusBadRequest, nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("No config history found for resource (%s)", req.ResourceID), nil, nil)
+	}
+
+	type rollbackItem struct {
+		resourceID   string
+		resourceType config.Resource
+		value        interface{}
+		exists       bool
+	}
+	var sets, deletes []rollbackItem
+	for resourceID := range changed {
+		_, _, resourceType, err := splitResourceID(ctx, resourceID)
+		if err != nil {
+			return http.StatusInternalServerError, nil, err
+		}
+
+		value, exists, _ := getValueAtVersion(entries, resourceID, version)
+		current, currentExists, err := getResource(ctx, s.projectConfig, resourceID)
+		if err != nil {
+			return http.StatusInternalServerError, nil, err
+		}
+		current, err = normaliseConfigValue(ctx, current)
+		if err != nil {
+			return http.StatusInternalServerError, nil, err
+		}
+
+		switch {
+		case exists && (!currentExists || !reflect.DeepEqual(current, value)):
+			sets = append(sets, rollbackItem{resourceID, resourceType, value, exists})
+		case !exists && currentExists && !isSingletonResource(resourceType):
+			deletes = append(deletes, rollbackItem{resourceID, resourceType, nil, exists})
+		}
+	}
+
+	// Resources are deleted in the reverse order in which they are fetched and set in the same order
+	order := map[config.Resource]int{}
+	for i, resourceType := range config.ResourceFetchingOrder {
+		order[resourceType] = i
+	}
+	sort.Slice(deletes, func(i, j int) bool {
+		if order[deletes[i].resourceType] != order[deletes[j].resourceType] {
+			return order[deletes[i].resourceType] > order[deletes[j].resourceType]
+		}
+		return deletes[i].resourceID < deletes[j].resourceID
+	})
+	sort.Slice(sets, func(i, j int) bool {
+		if order[sets[i].resourceType] != order[sets[j].resourceType] {
+			return order[sets[i].resourceType] < order[sets[j].resourceType]
+		}
+		return sets[i].resourceID < sets[j].resourceID
+	})
+
+	result := []interface{}{}
+	for _, item := range append(deletes, sets...) {
+		event := config.ResourceUpdateEvent
+		if item.exists {
+			// Database schemas are applied to the database before they are stored, just like while modifying a schema
+			if item.resourceType == config.ResourceDatabaseSchema {
+				if err := s.applyDatabaseSchema(ctx, project, item.resourceID, item.value); err != nil {
+					return http.StatusInternalServerError, nil, err
+				}
+			}
+			if err := s.setResource(ctx, item.resourceID, item.value, params); err != nil {
+				return http.StatusInternalServerError, nil, err
+			}
+		} else {
+			event = config.ResourceDeleteEvent
+			if err := s.deleteResource(ctx, item.resourceID, params); err != nil {
+				return http.StatusInternalServerError, nil, err
+			}
+		}
+
+		// Not every store updates the global config, so we apply the resource ourselves before applying it to the modules
+		if err := updateResource(ctx, event, s.projectConfig, item.resourceID, item.resourceType, item.value); err != nil {
+			return http.StatusInternalServerError, nil, err
+		}
+		s.applyResourceToModules(ctx, event, project, item.resourceType)
+		result = append(result, item.resourceID)
+	}
+
+	return http.StatusOK, result, nil
+}
+
+// applyDatabaseSchema runs the ddl of a database schema which is being rolled back. Rolling back the addition of a schema
+// only untracks the table, since dropping it would lose data which the config history cannot restore
+func (s *Manager) applyDatabaseSchema(ctx context.Context, project, resourceID string, value interface{}) error {
+	schema := new(config.DatabaseSchema)
+	if err := mapstructure.Decode(value, schema); err != nil {
+		return helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Invalid value of database schema (%s) in config history", resourceID), err, nil)
+	}
+
+	projectConfig, err := s.getConfigWithoutLock(ctx, project)
+	if err != nil {
+		return err
+	}
+	dbConfig, ok := projectConfig.DatabaseConfigs[config.GenerateResourceID(s.clusterID, project, config.ResourceDatabaseConfig, schema.DbAlias)]
+	if !ok {
+		return helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Unable to roll back schema as db alias (%s) does not exists", schema.DbAlias), nil, nil)
+	}
+
+	schemaMod, err := s.modules.GetSchemaModuleForSyncMan(project)
+	if err != nil {
+		return err
+	}
+	return schemaMod.SchemaModifyAll(ctx, schema.DbAlias, dbConfig.DBName, config.DatabaseSchemas{resourceID: schema})
+}
+
+func (s *Manager) getHistoryWithoutLock(ctx context.Context, project string) ([]*model.ConfigHistoryEntry, int, error) {
+	if s.history == nil {
+		return nil, http.StatusNotImplemented, helpers.Logger.LogError(helpers.GetRequestID(ctx), "Config history is not available for the current store", nil, nil)
+	}
+
+	entries, err := s.history.GetHistory(ctx, project)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	return entries, http.StatusOK, nil
+}
+
+// getValueAtVersion returns the value of the resource at the provided version. The second return value is false if the
+// resource didn't exist at that version, while the third one is false if the resource isn't present in the history
+func getValueAtVersion(entries []*model.ConfigHistoryEntry, resourceID string, version int64) (interface{}, bool, bool) {
+	var last, next *model.ConfigHistoryEntry
+	for _, entry := range entries {
+		if entry.ResourceID != resourceID {
+			continue
+		}
+		if entry.Version <= version {
+			last = entry
+		} else if next == nil {
+			next = entry
+		}
+	}
+
+	switch {
+	case last != nil:
+		return last.Value, last.Event != config.ResourceDeleteEvent, true
+	case next != nil:
+		// The resource wasn't changed till the version, so it had the value present before its first recorded change
+		return next.Previous, next.Event != config.ResourceAddEvent, true
+	default:
+		return nil, false, false
+	}
+}
+
+// isSingletonResource tells if the resource is a part of every project and hence can't be deleted
+func isSingletonResource(resourceType config.Resource) bool {
+	switch resourceType {
+	case config.ResourceProject, config.ResourceEventingConfig, config.ResourceFileStoreConfig, config.ResourceProjectLetsEncrypt, config.ResourceIngressGlobal:
+		return true
+	default:
+		return false
+	}
+}
+
+// diffConfigValues returns the differences between the json representations of two config values
+func diffConfigValues(path string, from, to interface{}) []*model.ConfigDiff {
+	fromMap, fromIsMap := from.(map[string]interface{})
+	toMap, toIsMap := to.(map[string]interface{})
+	if fromIsMap && toIsMap {
+		keys := map[string]struct{}{}
+		for key := range fromMap {
+			keys[key] = struct{}{}
+		}
+		for key := range toMap {
+			keys[key] = struct{}{}
+		}
+		sortedKeys := make([]string, 0, len(keys))
+		for key := range keys {
+			sortedKeys = append(sortedKeys, key)
+		}
+		sort.Strings(sortedKeys)
+
+		diffs := []*model.ConfigDiff{}
+		for _, key := range sortedKeys {
+			diffs = append(diffs, diffConfigValues(joinConfigPath(path, key), fromMap[key], toMap[key])...)
+		}
+		return diffs
+	}
+
+	fromArray, fromIsArray := from.([]interface{})
+	toArray, toIsArray := to.([]interface{})
+	if fromIsArray && toIsArray && len(fromArray) == len(toArray) {
+		diffs := []*model.ConfigDiff{}
+		for i := range fromArray {
+			diffs = append(diffs, diffConfigValues(joinConfigPath(path, strconv.Itoa(i)), fromArray[i], toArray[i])...)
+		}
+		return diffs
+	}
+
+	if reflect.DeepEqual(from, to) {
+		return nil
+	}
+	if path == "" {
+		path = "."
+	}
+	return []*model.ConfigDiff{{Path: path, From: from, To: to}}
+}
+
+func joinConfigPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
@@ -122,7 +122,11 @@ func (s *Manager) EnableIntegration(ctx context.Context, integrationConfig *conf
 	s.adminMan.SetIntegrationConfig(integrations)
 	s.projectConfig.Integrations = integrations
 
-	if err := s.store.SetResource(ctx, resourceID, integrationConfig); err != nil {
+	event := config.ResourceUpdateEvent
+	if proj != nil {
+		event = config.ResourceAddEvent
+	}
+	if err := s.setClusterResource(ctx, event, resourceID, integrationConfig, params); err != nil {
 		return http.StatusInternalServerError, err
 	}
 
@@ -134,7 +138,7 @@ func (s *Manager) EnableIntegration(ctx context.Context, integrationConfig *conf
 
 		// Update the store
 		rID := config.GenerateResourceID(s.clusterID, proj.ID, config.ResourceProject, proj.ID)
-		if err := s.store.SetResource(ctx, rID, proj); err != nil {
+		if err := s.setClusterResource(ctx, config.ResourceAddEvent, rID, proj, params); err != nil {
 			return http.StatusInternalServerError, err
 		}
 	}
@@ -184,7 +188,7 @@ func (s *Manager) RemoveIntegration(ctx context.Context, id string, params model
 		if hook.IntegrationID == id {
 			resourceID := config.GenerateResourceID(s.clusterID, "noProject", config.ResourceIntegrationHook, hook.ID)
 			delete(s.projectConfig.IntegrationHooks, resourceID)
-			if err := s.store.DeleteResource(ctx, resourceID); err != nil {
+			if err := s.deleteClusterResource(ctx, resourceID, params); err != nil {
 				return http.StatusInternalServerError, err
 			}
 		}
@@ -195,10 +199,12 @@ func (s *Manager) RemoveIntegration(ctx context.Context, id string, params model
 	_ = s.integrationMan.SetConfig(s.projectConfig.Integrations, s.projectConfig.IntegrationHooks)
 
 	// Update the stores
-	if err := s.store.DeleteProject(ctx, id); err != nil {
+	err = s.store.DeleteProject(ctx, id)
+	s.recordConfigChange(ctx, config.ResourceDeleteEvent, projectResourceID, params, err)
+	if err != nil {
 		return http.StatusInternalServerError, err
 	}
-	if err := s.store.DeleteResource(ctx, integrationResourceID); err != nil {
+	if err := s.deleteClusterResource(ctx, integrationResourceID, params); err != nil {
 		return http.StatusInternalServerError, err
 	}
 
@@ -277,11 +283,15 @@ func (s *Manager) AddIntegrationHook(ctx context.Context, integrationID string,
 
 	// Add the hook and store the config
 	resourceID = config.GenerateResourceID(s.clusterID, "noProject", config.ResourceIntegrationHook, hookConfig.ID)
+	event := config.ResourceAddEvent
+	if _, p := s.projectConfig.IntegrationHooks[resourceID]; p {
+		event = config.ResourceUpdateEvent
+	}
 	s.projectConfig.IntegrationHooks[resourceID] = hookConfig
 	s.integrationMan.SetIntegrationHooks(s.projectConfig.IntegrationHooks)
 
 	// Store the config in the store
-	if err := s.store.SetResource(ctx, resourceID, hookConfig); err != nil {
+	if err := s.setClusterResource(ctx, event, resourceID, hookConfig, params); err != nil {
 		return http.StatusInternalServerError, err
 	}
 
@@ -318,7 +328,7 @@ func (s *Manager) RemoveIntegrationHook(ctx context.Context, integrationID, hook
 	s.integrationMan.SetIntegrationHooks(s.projectConfig.IntegrationHooks)
 
 	// Store the config in the store
-	if err := s.store.DeleteResource(ctx, resourceID); err != nil {
+	if err := s.deleteClusterResource(ctx, resourceID, params); err != nil {
 		return http.StatusInternalServerError, err
 	}
 
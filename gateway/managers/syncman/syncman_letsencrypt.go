@@ -40,7 +40,7 @@ func (s *Manager) SetProjectLetsEncryptDomains(ctx context.Context, project stri
 	}
 
 	resourceID := config.GenerateResourceID(s.clusterID, project, config.ResourceProjectLetsEncrypt, "letsencrypt")
-	if err := s.store.SetResource(ctx, resourceID, c); err != nil {
+	if err := s.setResource(ctx, resourceID, c, params); err != nil {
 		return http.StatusInternalServerError, err
 	}
 
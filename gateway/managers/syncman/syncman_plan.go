@@ -0,0 +1,585 @@
+package syncman
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/spaceuptech/helpers"
+
+	"github.com/spaceuptech/space-cloud/gateway/config"
+	"github.com/spaceuptech/space-cloud/gateway/model"
+	"github.com/spaceuptech/space-cloud/gateway/modules/global/cors"
+	tmpl2 "github.com/spaceuptech/space-cloud/gateway/utils/tmpl"
+)
+
+// specPlanner describes how a spec object gets stored in the config of a project
+type specPlanner struct {
+	resourceType config.Resource
+	// ids returns the ids used to generate the resource id from the meta of the spec object
+	ids func(meta map[string]string) []string
+	// decode converts the spec into the value the setter would store. The current value is nil if the resource doesn't exist
+	decode func(meta map[string]string, data []byte, current interface{}) (interface{}, error)
+}
+
+var specPlanners = map[string]specPlanner{
+	"project": {
+		resourceType: config.ResourceProject,
+		ids:          func(meta map[string]string) []string { return []string{meta["project"]} },
+		decode: func(meta map[string]string, data []byte, _ interface{}) (interface{}, error) {
+			v := new(config.ProjectConfig)
+			if err := json.Unmarshal(data, v); err != nil {
+				return nil, err
+			}
+			v.ID = meta["project"]
+			setProjectConfigDefaults(v)
+			return v, nil
+		},
+	},
+	"auth-providers": {
+		resourceType: config.ResourceAuthProvider,
+		ids:          func(meta map[string]string) []string { return []string{meta["id"]} },
+		decode: func(meta map[string]string, data []byte, _ interface{}) (interface{}, error) {
+			v := new(config.AuthStub)
+			if err := json.Unmarshal(data, v); err != nil {
+				return nil, err
+			}
+			v.ID = meta["id"]
+			return v, nil
+		},
+	},
+	"db-config": {
+		resourceType: config.ResourceDatabaseConfig,
+		ids:          func(meta map[string]string) []string { return []string{getSpecDBAlias(meta)} },
+		decode: func(meta map[string]string, data []byte, _ interface{}) (interface{}, error) {
+			v := new(config.DatabaseConfig)
+			if err := json.Unmarshal(data, v); err != nil {
+				return nil, err
+			}
+			v.DbAlias = getSpecDBAlias(meta)
+			return v, nil
+		},
+	},
+	"db-prepared-query": {
+		resourceType: config.ResourceDatabasePreparedQuery,
+		ids:          func(meta map[string]string) []string { return []string{getSpecDBAlias(meta), meta["id"]} },
+		decode: func(meta map[string]string, data []byte, _ interface{}) (interface{}, error) {
+			v := new(config.DatbasePreparedQuery)
+			if err := json.Unmarshal(data, v); err != nil {
+				return nil, err
+			}
+			v.ID = meta["id"]
+			v.DbAlias = getSpecDBAlias(meta)
+			return v, nil
+		},
+	},
+	"db-schema": {
+		resourceType: config.ResourceDatabaseSchema,
+		ids:          func(meta map[string]string) []string { return []string{getSpecDBAlias(meta), meta["col"]} },
+		decode: func(meta map[string]string, data []byte, _ interface{}) (interface{}, error) {
+			v := new(config.DatabaseSchema)
+			if err := json.Unmarshal(data, v); err != nil {
+				return nil, err
+			}
+			v.DbAlias = getSpecDBAlias(meta)
+			v.Table = meta["col"]
+			return v, nil
+		},
+	},
+	"db-rules": {
+		resourceType: config.ResourceDatabaseRule,
+		ids:          func(meta map[string]string) []string { return []string{getSpecDBAlias(meta), meta["col"], "rule"} },
+		decode: func(meta map[string]string, data []byte, _ interface{}) (interface{}, error) {
+			v := new(config.DatabaseRule)
+			if err := json.Unmarshal(data, v); err != nil {
+				return nil, err
+			}
+			v.DbAlias = getSpecDBAlias(meta)
+			v.Table = meta["col"]
+			return v, nil
+		},
+	},
+	"eventing-config": {
+		resourceType: config.ResourceEventingConfig,
+		ids:          func(meta map[string]string) []string { return []string{"eventing"} },
+		decode: func(meta map[string]string, data []byte, current interface{}) (interface{}, error) {
+			c := new(config.Eventing)
+			if err := json.Unmarshal(data, c); err != nil {
+				return nil, err
+			}
+			// The internal rules are managed by the gateway and are retained on every update
+			v := &config.EventingConfig{Enabled: c.Enabled, DBAlias: c.DBAlias}
+			if current != nil {
+				v.InternalRules = current.(*config.EventingConfig).InternalRules
+			}
+			return v, nil
+		},
+	},
+	"eventing-rule": {
+		resourceType: config.ResourceEventingRule,
+		ids:          func(meta map[string]string) []string { return []string{meta["id"]} },
+		decode: func(meta map[string]string, data []byte, _ interface{}) (interface{}, error) {
+			v := new(config.Rule)
+			if err := json.Unmarshal(data, v); err != nil {
+				return nil, err
+			}
+			v.ID = meta["id"]
+			return v, nil
+		},
+	},
+	"eventing-schema": {
+		resourceType: config.ResourceEventingSchema,
+		ids:          func(meta map[string]string) []string { return []string{meta["id"]} },
+		decode: func(meta map[string]string, data []byte, _ interface{}) (interface{}, error) {
+			v := new(config.EventingSchema)
+			if err := json.Unmarshal(data, v); err != nil {
+				return nil, err
+			}
+			v.ID = meta["id"]
+			return v, nil
+		},
+	},
+	"eventing-triggers": {
+		resourceType: config.ResourceEventingTrigger,
+		ids:          func(meta map[string]string) []string { return []string{meta["id"]} },
+		decode: func(meta map[string]string, data []byte, _ interface{}) (interface{}, error) {
+			v := new(config.EventingTrigger)
+			if err := json.Unmarshal(data, v); err != nil {
+				return nil, err
+			}
+			v.ID = meta["id"]
+			return v, nil
+		},
+	},
+	"filestore-config": {
+		resourceType: config.ResourceFileStoreConfig,
+		ids:          func(meta map[string]string) []string { return []string{"filestore"} },
+		decode: func(meta map[string]string, data []byte, _ interface{}) (interface{}, error) {
+			v := new(config.FileStoreConfig)
+			if err := json.Unmarshal(data, v); err != nil {
+				return nil, err
+			}
+			return v, nil
+		},
+	},
+	"filestore-alias": {
+		resourceType: config.ResourceFileStoreAlias,
+		ids:          func(meta map[string]string) []string { return []string{meta["id"]} },
+		decode: func(meta map[string]string, data []byte, _ interface{}) (interface{}, error) {
+			v := new(config.FileStoreConfig)
+			if err := json.Unmarshal(data, v); err != nil {
+				return nil, err
+			}
+			v.ID = meta["id"]
+			return v, nil
+		},
+	},
+	"filestore-rule": {
+		resourceType: config.ResourceFileStoreRule,
+		ids:          func(meta map[string]string) []string { return []string{meta["id"]} },
+		decode: func(meta map[string]string, data []byte, _ interface{}) (interface{}, error) {
+			v := new(config.FileRule)
+			if err := json.Unmarshal(data, v); err != nil {
+				return nil, err
+			}
+			v.ID = meta["id"]
+			return v, nil
+		},
+	},
+	"graphql-persisted-query": {
+		resourceType: config.ResourceGraphQLPersistedQuery,
+		ids:          func(meta map[string]string) []string { return []string{strings.ToLower(meta["id"])} },
+		decode: func(meta map[string]string, data []byte, _ interface{}) (interface{}, error) {
+			v := new(config.GraphQLPersistedQuery)
+			if err := json.Unmarshal(data, v); err != nil {
+				return nil, err
+			}
+			v.ID = strings.ToLower(meta["id"])
+			return v, nil
+		},
+	},
+	"ingress-global": {
+		resourceType: config.ResourceIngressGlobal,
+		ids:          func(meta map[string]string) []string { return []string{"global"} },
+		decode: func(meta map[string]string, data []byte, _ interface{}) (interface{}, error) {
+			v := new(config.GlobalRoutesConfig)
+			if err := json.Unmarshal(data, v); err != nil {
+				return nil, err
+			}
+			return v, nil
+		},
+	},
+	"ingress-routes": {
+		resourceType: config.ResourceIngressRoute,
+		ids:          func(meta map[string]string) []string { return []string{meta["id"]} },
+		decode: func(meta map[string]string, data []byte, _ interface{}) (interface{}, error) {
+			v := new(config.Route)
+			if err := json.Unmarshal(data, v); err != nil {
+				return nil, err
+			}
+			v.ID = meta["id"]
+			return v, nil
+		},
+	},
+	"letsencrypt": {
+		resourceType: config.ResourceProjectLetsEncrypt,
+		ids:          func(meta map[string]string) []string { return []string{"letsencrypt"} },
+		decode: func(meta map[string]string, data []byte, _ interface{}) (interface{}, error) {
+			v := new(config.LetsEncrypt)
+			if err := json.Unmarshal(data, v); err != nil {
+				return nil, err
+			}
+			return v, nil
+		},
+	},
+	"remote-services": {
+		resourceType: config.ResourceRemoteService,
+		ids:          func(meta map[string]string) []string { return []string{meta["id"]} },
+		decode: func(meta map[string]string, data []byte, _ interface{}) (interface{}, error) {
+			v := new(config.Service)
+			if err := json.Unmarshal(data, v); err != nil {
+				return nil, err
+			}
+			v.ID = meta["id"]
+			return v, nil
+		},
+	},
+}
+
+// getSpecDBAlias returns the db alias of a spec object. Older specs of prepared queries use the db key instead
+func getSpecDBAlias(meta map[string]string) string {
+	if dbAlias, ok := meta["dbAlias"]; ok {
+		return dbAlias
+	}
+	return meta["db"]
+}
+
+// GetSpecAuthParams returns the resource and the attributes the endpoint applying the spec authorises the request with.
+// It returns false if the spec type isn't a part of the gateway config
+func GetSpecAuthParams(spec *model.SpecObject) (string, map[string]string, bool) {
+	planner, ok := specPlanners[spec.Type]
+	if !ok {
+		return "", nil, false
+	}
+
+	resource := planner.resourceType
+	attr := map[string]string{"project": spec.Meta["project"]}
+	switch resource {
+	case config.ResourceDatabaseConfig:
+		attr["db"] = getSpecDBAlias(spec.Meta)
+	case config.ResourceDatabasePreparedQuery:
+		attr["db"] = getSpecDBAlias(spec.Meta)
+		attr["id"] = spec.Meta["id"]
+	case config.ResourceDatabaseSchema, config.ResourceDatabaseRule:
+		attr["db"] = getSpecDBAlias(spec.Meta)
+		attr["col"] = spec.Meta["col"]
+	case config.ResourceRemoteService:
+		attr["service"] = spec.Meta["id"]
+	case config.ResourceFileStoreAlias:
+		// Aliases are managed with the permissions of the file store config
+		resource = config.ResourceFileStoreConfig
+		attr["id"] = spec.Meta["id"]
+	case config.ResourceAuthProvider, config.ResourceEventingRule, config.ResourceEventingSchema, config.ResourceEventingTrigger,
+		config.ResourceFileStoreRule, config.ResourceGraphQLPersistedQuery, config.ResourceIngressRoute:
+		attr["id"] = spec.Meta["id"]
+	}
+	return string(resource), attr, true
+}
+
+// PlanConfig returns the changes applying the spec objects would make to the config of the cluster without persisting
+// anything. Every spec gets validated and the ddl queries of database schemas are generated. The specs are planned in
+// order, so a spec sees the changes of the specs before it
+func (s *Manager) PlanConfig(ctx context.Context, specs []*model.SpecObject) (int, []interface{}, error) {
+	items, checks, err := s.planSpecs(ctx, specs)
+	if err != nil {
+		return http.StatusInternalServerError, nil, err
+	}
+
+	// Connecting to the databases can take a while, so it is done after releasing the lock
+	for _, check := range checks {
+		s.planDatabaseConfig(ctx, check.projectID, check.dbConfig, check.item)
+	}
+	return http.StatusOK, items, nil
+}
+
+// connectionCheck is a database connection to be checked once the specs have been planned
+type connectionCheck struct {
+	projectID string
+	dbConfig  *config.DatabaseConfig
+	item      *model.ConfigPlanItem
+}
+
+func (s *Manager) planSpecs(ctx context.Context, specs []*model.SpecObject) ([]interface{}, []connectionCheck, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	// The resources which would be changed by the specs planned so far
+	pending := map[string]interface{}{}
+
+	items := make([]interface{}, 0, len(specs))
+	var checks []connectionCheck
+	for _, spec := range specs {
+		item, err := s.planSpec(ctx, spec, pending)
+		if err != nil {
+			return nil, nil, err
+		}
+		items = append(items, item)
+
+		// The decoded value is owned by the plan, hence it can be used after releasing the lock
+		if dbConfig, ok := pending[item.ResourceID].(*config.DatabaseConfig); ok && item.ResourceID != "" && len(item.Errors) == 0 {
+			checks = append(checks, connectionCheck{projectID: spec.Meta["project"], dbConfig: dbConfig, item: item})
+		}
+	}
+	return items, checks, nil
+}
+
+func (s *Manager) planSpec(ctx context.Context, spec *model.SpecObject, pending map[string]interface{}) (*model.ConfigPlanItem, error) {
+	item := &model.ConfigPlanItem{Type: spec.Type, Meta: spec.Meta}
+
+	planner, ok := specPlanners[spec.Type]
+	if !ok {
+		item.Action = model.ConfigPlanActionUnsupported
+		item.Warnings = append(item.Warnings, fmt.Sprintf("Spec type (%s) is not a part of the gateway config", spec.Type))
+		return item, nil
+	}
+
+	projectID := spec.Meta["project"]
+	if projectID == "" {
+		item.Errors = append(item.Errors, "Project not provided in the meta of the spec")
+		return item, nil
+	}
+	resourceID := config.GenerateResourceID(s.clusterID, projectID, planner.resourceType, planner.ids(spec.Meta)...)
+	item.ResourceID = resourceID
+
+	projectResourceID := config.GenerateResourceID(s.clusterID, projectID, config.ResourceProject, projectID)
+	_, isProjectPending := pending[projectResourceID]
+	if _, ok := s.projectConfig.Projects[projectID]; !ok && !isProjectPending && planner.resourceType != config.ResourceProject {
+		item.Errors = append(item.Errors, fmt.Sprintf("Unknown project (%s) provided", projectID))
+		return item, nil
+	}
+
+	// A spec planned earlier in the same batch takes precedence over the config of the cluster
+	current, exists := pending[resourceID]
+	if !exists {
+		var err error
+		current, exists, err = getResource(ctx, s.projectConfig, resourceID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	data, err := json.Marshal(spec.Spec)
+	if err != nil {
+		return nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to marshal spec", err, nil)
+	}
+	value, err := planner.decode(spec.Meta, data, current)
+	if err != nil {
+		item.Errors = append(item.Errors, fmt.Sprintf("Invalid spec provided: %v", err))
+		return item, nil
+	}
+	pending[resourceID] = value
+
+	from, err := normaliseConfigValue(ctx, current)
+	if err != nil {
+		return nil, err
+	}
+	to, err := normaliseConfigValue(ctx, value)
+	if err != nil {
+		return nil, err
+	}
+	item.Diff = diffConfigValues("", from, to)
+	switch {
+	case !exists:
+		item.Action = model.ConfigPlanActionCreate
+	case len(item.Diff) > 0:
+		item.Action = model.ConfigPlanActionUpdate
+	default:
+		item.Action = model.ConfigPlanActionNoChange
+	}
+
+	item.Errors = append(item.Errors, validateConfigValue(value)...)
+
+	if v, ok := value.(*config.DatabaseSchema); ok {
+		s.planDatabaseSchema(ctx, projectID, resourceID, v, pending, item)
+	}
+	return item, nil
+}
+
+// planDatabaseConfig checks if the gateway can connect to the database
+func (s *Manager) planDatabaseConfig(ctx context.Context, projectID string, v *config.DatabaseConfig, item *model.ConfigPlanItem) {
+	crudMod, err := s.modules.GetCrudModuleForSyncMan(projectID)
+	if err != nil {
+		item.Warnings = append(item.Warnings, "Connection string can't be checked until the project is created")
+		return
+	}
+	if err := crudMod.CheckConnection(ctx, v); err != nil {
+		item.Errors = append(item.Errors, fmt.Sprintf("Unable to connect to database (%s): %v", v.DbAlias, err))
+	}
+}
+
+// planDatabaseSchema generates the ddl queries which would be run to modify the table
+func (s *Manager) planDatabaseSchema(ctx context.Context, projectID, resourceID string, v *config.DatabaseSchema, pending map[string]interface{}, item *model.ConfigPlanItem) {
+	var dbConfig *config.DatabaseConfig
+	if project, ok := s.projectConfig.Projects[projectID]; ok {
+		dbConfig, _ = s.checkIfDbAliasExists(project.DatabaseConfigs, v.DbAlias)
+	}
+	if dbConfig == nil {
+		dbConfigID := config.GenerateResourceID(s.clusterID, projectID, config.ResourceDatabaseConfig, v.DbAlias)
+		if _, ok := pending[dbConfigID]; ok {
+			item.Warnings = append(item.Warnings, fmt.Sprintf("Queries can't be generated until database (%s) is added", v.DbAlias))
+			return
+		}
+		item.Errors = append(item.Errors, fmt.Sprintf("Unable to modify schema provided db alias (%s) does not exists", v.DbAlias))
+		return
+	}
+
+	schemaMod, err := s.modules.GetSchemaModuleForSyncMan(projectID)
+	if err != nil {
+		item.Warnings = append(item.Warnings, fmt.Sprintf("Queries can't be generated: %v", err))
+		return
+	}
+	queries, err := schemaMod.GetSchemaModifyAllQueries(ctx, v.DbAlias, dbConfig.DBName, config.DatabaseSchemas{resourceID: v})
+	if err != nil {
+		item.Errors = append(item.Errors, fmt.Sprintf("Invalid schema provided: %v", err))
+		return
+	}
+	item.Queries = queries[v.Table]
+}
+
+// validateConfigValue returns the problems found in the rules and templates of a config value
+func validateConfigValue(value interface{}) []string {
+	errs := []string{}
+	switch v := value.(type) {
+	case *config.DatabaseRule:
+		errs = append(errs, validateRules("rules", v.Rules)...)
+	case *config.Rule:
+		errs = append(errs, validateRule("rule", v)...)
+	case *config.FileRule:
+		errs = append(errs, validateRules("rule", v.Rule)...)
+	case *config.EventingTrigger:
+		if v.Filter != nil {
+			errs = append(errs, validateRule("filter", v.Filter)...)
+		}
+		errs = append(errs, validateTemplate("requestTemplate", v.Tmpl, v.RequestTemplate)...)
+		errs = append(errs, validateTemplate("claims", v.Tmpl, v.Claims)...)
+	case *config.Service:
+		endpoints := make([]string, 0, len(v.Endpoints))
+		for key := range v.Endpoints {
+			endpoints = append(endpoints, key)
+		}
+		sort.Strings(endpoints)
+		for _, key := range endpoints {
+			endpoint := v.Endpoints[key]
+			if endpoint == nil {
+				continue
+			}
+			path := joinConfigPath("endpoints", key)
+			if endpoint.Rule != nil {
+				errs = append(errs, validateRule(joinConfigPath(path, "rule"), endpoint.Rule)...)
+			}
+			errs = append(errs, validateTemplate(joinConfigPath(path, "requestTemplate"), endpoint.Tmpl, endpoint.ReqTmpl)...)
+			errs = append(errs, validateTemplate(joinConfigPath(path, "graphTemplate"), endpoint.Tmpl, endpoint.GraphTmpl)...)
+			errs = append(errs, validateTemplate(joinConfigPath(path, "responseTemplate"), endpoint.Tmpl, endpoint.ResTmpl)...)
+			errs = append(errs, validateTemplate(joinConfigPath(path, "claims"), endpoint.Tmpl, endpoint.Claims)...)
+		}
+	case *config.Route:
+		if v.Rule != nil {
+			errs = append(errs, validateRule("rule", v.Rule)...)
+		}
+		errs = append(errs, validateTemplate("modify.requestTemplate", v.Modify.Tmpl, v.Modify.ReqTmpl)...)
+		errs = append(errs, validateTemplate("modify.responseTemplate", v.Modify.Tmpl, v.Modify.ResTmpl)...)
+		if err := cors.ValidatePolicy(v.Cors); err != nil {
+			errs = append(errs, fmt.Sprintf("cors: invalid cors policy provided: %v", err))
+		}
+	case *config.GraphQLPersistedQuery:
+		if err := validatePersistedQuery(v.ID, v); err != nil {
+			errs = append(errs, err.Error())
+		}
+	case *config.ProjectConfig:
+		if err := validateProjectConfig(v); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	return errs
+}
+
+func validateRules(path string, rules map[string]*config.Rule) []string {
+	keys := make([]string, 0, len(rules))
+	for key := range rules {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	errs := []string{}
+	for _, key := range keys {
+		if rules[key] == nil {
+			continue
+		}
+		errs = append(errs, validateRule(joinConfigPath(path, key), rules[key])...)
+	}
+	return errs
+}
+
+// validateRule checks if the security rule can be evaluated by the auth module
+func validateRule(path string, rule *config.Rule) []string {
+	errs := []string{}
+	switch rule.Rule {
+	case "allow", "deny", "authenticated":
+	case "match":
+		switch rule.Type {
+		case "string", "number", "bool", "date":
+		default:
+			errs = append(errs, fmt.Sprintf("%s: invalid variable data type (%s) provided", path, rule.Type))
+		}
+	case "and", "or":
+		if len(rule.Clauses) == 0 {
+			errs = append(errs, fmt.Sprintf("%s: no clauses provided for rule (%s)", path, rule.Rule))
+		}
+		for i, clause := range rule.Clauses {
+			if clause == nil {
+				continue
+			}
+			errs = append(errs, validateRule(joinConfigPath(path, fmt.Sprintf("clauses.%d", i)), clause)...)
+		}
+	case "webhook":
+		if rule.URL == "" {
+			errs = append(errs, fmt.Sprintf("%s: url not provided for rule (webhook)", path))
+		}
+		errs = append(errs, validateTemplate(joinConfigPath(path, "claims"), rule.Template, rule.Claims)...)
+		errs = append(errs, validateTemplate(joinConfigPath(path, "requestTemplate"), rule.Template, rule.ReqTmpl)...)
+	case "query":
+		if rule.DB == "" || rule.Col == "" {
+			errs = append(errs, fmt.Sprintf("%s: db and col need to be provided for rule (query)", path))
+		}
+	case "force":
+		if rule.Field == "" {
+			errs = append(errs, fmt.Sprintf("%s: field not provided for rule (force)", path))
+		}
+	case "remove", "encrypt", "decrypt", "hash":
+		if rule.Fields == nil {
+			errs = append(errs, fmt.Sprintf("%s: fields not provided for rule (%s)", path, rule.Rule))
+		}
+	default:
+		errs = append(errs, fmt.Sprintf("%s: invalid rule type (%s) provided", path, rule.Rule))
+	}
+
+	if rule.Clause != nil {
+		errs = append(errs, validateRule(joinConfigPath(path, "clause"), rule.Clause)...)
+	}
+	return errs
+}
+
+// validateTemplate checks if the template can be parsed. Go is the default templating engine
+func validateTemplate(path string, engine config.TemplatingEngine, tmpl string) []string {
+	if tmpl == "" || (engine != "" && engine != config.TemplatingEngineGo) {
+		return nil
+	}
+	if _, err := template.New(path).Funcs(tmpl2.CreateGoFuncMaps(nil)).Parse(tmpl); err != nil {
+		return []string{fmt.Sprintf("%s: invalid template provided: %v", path, err)}
+	}
+	return nil
+}
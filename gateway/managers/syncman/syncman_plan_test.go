@@ -0,0 +1,342 @@
+package syncman
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/spaceuptech/space-cloud/gateway/config"
+	"github.com/spaceuptech/space-cloud/gateway/model"
+)
+
+func TestManager_PlanConfig(t *testing.T) {
+	usersRuleID := config.GenerateResourceID("chicago", "myproject", config.ResourceDatabaseRule, "db", "users", "rule")
+	usersSchemaID := config.GenerateResourceID("chicago", "myproject", config.ResourceDatabaseSchema, "db", "users")
+	dbConfigID := config.GenerateResourceID("chicago", "myproject", config.ResourceDatabaseConfig, "db")
+	dbConfig := &config.DatabaseConfig{DbAlias: "db", Type: "postgres", DBName: "public", Conn: "postgres://localhost:5432", Enabled: true}
+
+	type mockArgs struct {
+		method         string
+		args           []interface{}
+		paramsReturned []interface{}
+	}
+	tests := []struct {
+		name            string
+		specs           []*model.SpecObject
+		modulesMockArgs []mockArgs
+		crudMockArgs    []mockArgs
+		schemaMockArgs  []mockArgs
+		want            []interface{}
+	}{
+		{
+			name:  "spec type which isn't a part of the gateway config",
+			specs: []*model.SpecObject{{Type: "service", Meta: map[string]string{"project": "myproject", "id": "greeter"}}},
+			want: []interface{}{&model.ConfigPlanItem{
+				Type:     "service",
+				Meta:     map[string]string{"project": "myproject", "id": "greeter"},
+				Action:   model.ConfigPlanActionUnsupported,
+				Warnings: []string{"Spec type (service) is not a part of the gateway config"},
+			}},
+		},
+		{
+			name:  "unknown project",
+			specs: []*model.SpecObject{{Type: "db-rules", Meta: map[string]string{"project": "otherproject", "dbAlias": "db", "col": "users"}}},
+			want: []interface{}{&model.ConfigPlanItem{
+				Type:       "db-rules",
+				Meta:       map[string]string{"project": "otherproject", "dbAlias": "db", "col": "users"},
+				ResourceID: config.GenerateResourceID("chicago", "otherproject", config.ResourceDatabaseRule, "db", "users", "rule"),
+				Errors:     []string{"Unknown project (otherproject) provided"},
+			}},
+		},
+		{
+			name: "rules are created, updated and left unchanged",
+			specs: []*model.SpecObject{
+				{Type: "db-rules", Meta: map[string]string{"project": "myproject", "dbAlias": "db", "col": "posts"}, Spec: map[string]interface{}{"rules": map[string]interface{}{"read": map[string]interface{}{"rule": "allow"}}}},
+				{Type: "db-rules", Meta: map[string]string{"project": "myproject", "dbAlias": "db", "col": "users"}, Spec: map[string]interface{}{"rules": map[string]interface{}{"read": map[string]interface{}{"rule": "deny"}}}},
+				{Type: "db-rules", Meta: map[string]string{"project": "myproject", "dbAlias": "db", "col": "users"}, Spec: map[string]interface{}{"rules": map[string]interface{}{"read": map[string]interface{}{"rule": "deny"}}}},
+			},
+			want: []interface{}{
+				&model.ConfigPlanItem{
+					Type:       "db-rules",
+					Meta:       map[string]string{"project": "myproject", "dbAlias": "db", "col": "posts"},
+					ResourceID: config.GenerateResourceID("chicago", "myproject", config.ResourceDatabaseRule, "db", "posts", "rule"),
+					Action:     model.ConfigPlanActionCreate,
+					Diff: []*model.ConfigDiff{{Path: ".", From: nil, To: map[string]interface{}{
+						"col": "posts", "dbAlias": "db", "rules": map[string]interface{}{"read": map[string]interface{}{"rule": "allow"}},
+					}}},
+				},
+				&model.ConfigPlanItem{
+					Type:       "db-rules",
+					Meta:       map[string]string{"project": "myproject", "dbAlias": "db", "col": "users"},
+					ResourceID: usersRuleID,
+					Action:     model.ConfigPlanActionUpdate,
+					Diff:       []*model.ConfigDiff{{Path: "rules.read.rule", From: "allow", To: "deny"}},
+				},
+				&model.ConfigPlanItem{
+					Type:       "db-rules",
+					Meta:       map[string]string{"project": "myproject", "dbAlias": "db", "col": "users"},
+					ResourceID: usersRuleID,
+					Action:     model.ConfigPlanActionNoChange,
+					Diff:       []*model.ConfigDiff{},
+				},
+			},
+		},
+		{
+			name: "invalid rules and templates are reported",
+			specs: []*model.SpecObject{
+				{Type: "db-rules", Meta: map[string]string{"project": "myproject", "dbAlias": "db", "col": "users"}, Spec: map[string]interface{}{"rules": map[string]interface{}{
+					"read":   map[string]interface{}{"rule": "allow"},
+					"create": map[string]interface{}{"rule": "and", "clauses": []interface{}{map[string]interface{}{"rule": "match", "type": "text"}, map[string]interface{}{"rule": "magic"}}},
+				}}},
+				{Type: "eventing-triggers", Meta: map[string]string{"project": "myproject", "id": "welcome"}, Spec: map[string]interface{}{"type": "DB_INSERT", "url": "http://mailer", "requestTemplate": "{{ .doc "}},
+			},
+			want: []interface{}{
+				&model.ConfigPlanItem{
+					Type:       "db-rules",
+					Meta:       map[string]string{"project": "myproject", "dbAlias": "db", "col": "users"},
+					ResourceID: usersRuleID,
+					Action:     model.ConfigPlanActionUpdate,
+					Diff: []*model.ConfigDiff{{Path: "rules.create", From: nil, To: map[string]interface{}{
+						"rule": "and", "clauses": []interface{}{map[string]interface{}{"rule": "match", "type": "text"}, map[string]interface{}{"rule": "magic"}},
+					}}},
+					Errors: []string{
+						"rules.create.clauses.0: invalid variable data type (text) provided",
+						"rules.create.clauses.1: invalid rule type (magic) provided",
+					},
+				},
+				&model.ConfigPlanItem{
+					Type:       "eventing-triggers",
+					Meta:       map[string]string{"project": "myproject", "id": "welcome"},
+					ResourceID: config.GenerateResourceID("chicago", "myproject", config.ResourceEventingTrigger, "welcome"),
+					Action:     model.ConfigPlanActionCreate,
+					Diff: []*model.ConfigDiff{{Path: ".", From: nil, To: map[string]interface{}{
+						"type": "DB_INSERT", "url": "http://mailer", "requestTemplate": "{{ .doc ", "id": "welcome", "retries": float64(0), "timeout": float64(0),
+						"options": nil, "claims": "", "filter": nil, "triggerType": "",
+					}}},
+					Errors: []string{"requestTemplate: invalid template provided: template: requestTemplate:1: unclosed action"},
+				},
+			},
+		},
+		{
+			name: "project config gets the defaults and policies of the setter",
+			specs: []*model.SpecObject{
+				{Type: "project", Meta: map[string]string{"project": "myproject"}, Spec: map[string]interface{}{"rateLimits": []interface{}{map[string]interface{}{"id": "ip", "keyType": "ip", "requests": 0, "interval": 1}}}},
+			},
+			want: []interface{}{
+				&model.ConfigPlanItem{
+					Type:       "project",
+					Meta:       map[string]string{"project": "myproject"},
+					ResourceID: config.GenerateResourceID("chicago", "myproject", config.ResourceProject, "myproject"),
+					Action:     model.ConfigPlanActionUpdate,
+					Diff: []*model.ConfigDiff{
+						{Path: "contextTimeGraphQL", From: nil, To: float64(10)},
+						{Path: "rateLimits", From: nil, To: []interface{}{map[string]interface{}{"id": "ip", "keyType": "ip", "requests": float64(0), "interval": float64(1)}}},
+					},
+					Errors: []string{"invalid rate limit policy (ip): requests and interval must be greater than zero"},
+				},
+			},
+		},
+		{
+			name: "connection string of the database is checked",
+			specs: []*model.SpecObject{
+				{Type: "db-config", Meta: map[string]string{"project": "myproject", "dbAlias": "db"}, Spec: map[string]interface{}{"type": "postgres", "name": "public", "conn": "postgres://remote:5432", "enabled": true}},
+			},
+			modulesMockArgs: []mockArgs{
+				{
+					method: "GetCrudModuleForSyncMan",
+					args:   []interface{}{"myproject"},
+				},
+			},
+			crudMockArgs: []mockArgs{
+				{
+					method:         "CheckConnection",
+					args:           []interface{}{mock.Anything, &config.DatabaseConfig{DbAlias: "db", Type: "postgres", DBName: "public", Conn: "postgres://remote:5432", Enabled: true}},
+					paramsReturned: []interface{}{errors.New("connection refused")},
+				},
+			},
+			want: []interface{}{
+				&model.ConfigPlanItem{
+					Type:       "db-config",
+					Meta:       map[string]string{"project": "myproject", "dbAlias": "db"},
+					ResourceID: dbConfigID,
+					Action:     model.ConfigPlanActionUpdate,
+					Diff:       []*model.ConfigDiff{{Path: "conn", From: "postgres://localhost:5432", To: "postgres://remote:5432"}},
+					Errors:     []string{"Unable to connect to database (db): connection refused"},
+				},
+			},
+		},
+		{
+			name: "ddl queries of the schema are generated without running them",
+			specs: []*model.SpecObject{
+				{Type: "db-schema", Meta: map[string]string{"project": "myproject", "dbAlias": "db", "col": "users"}, Spec: map[string]interface{}{"schema": "type users { id: ID! @primary }"}},
+				{Type: "db-schema", Meta: map[string]string{"project": "myproject", "dbAlias": "newdb", "col": "users"}, Spec: map[string]interface{}{"schema": "type users { id: ID! @primary }"}},
+			},
+			modulesMockArgs: []mockArgs{
+				{
+					method: "GetSchemaModuleForSyncMan",
+					args:   []interface{}{"myproject"},
+				},
+			},
+			schemaMockArgs: []mockArgs{
+				{
+					method:         "GetSchemaModifyAllQueries",
+					args:           []interface{}{mock.Anything, "db", "public", config.DatabaseSchemas{usersSchemaID: &config.DatabaseSchema{Table: "users", DbAlias: "db", Schema: "type users { id: ID! @primary }"}}},
+					paramsReturned: []interface{}{map[string][]string{"users": {"CREATE TABLE public.users (id varchar(50) PRIMARY KEY NOT NULL);"}}, nil},
+				},
+			},
+			want: []interface{}{
+				&model.ConfigPlanItem{
+					Type:       "db-schema",
+					Meta:       map[string]string{"project": "myproject", "dbAlias": "db", "col": "users"},
+					ResourceID: usersSchemaID,
+					Action:     model.ConfigPlanActionCreate,
+					Diff:       []*model.ConfigDiff{{Path: ".", From: nil, To: map[string]interface{}{"col": "users", "dbAlias": "db", "schema": "type users { id: ID! @primary }"}}},
+					Queries:    []string{"CREATE TABLE public.users (id varchar(50) PRIMARY KEY NOT NULL);"},
+				},
+				&model.ConfigPlanItem{
+					Type:       "db-schema",
+					Meta:       map[string]string{"project": "myproject", "dbAlias": "newdb", "col": "users"},
+					ResourceID: config.GenerateResourceID("chicago", "myproject", config.ResourceDatabaseSchema, "newdb", "users"),
+					Action:     model.ConfigPlanActionCreate,
+					Diff:       []*model.ConfigDiff{{Path: ".", From: nil, To: map[string]interface{}{"col": "users", "dbAlias": "newdb", "schema": "type users { id: ID! @primary }"}}},
+					Errors:     []string{"Unable to modify schema provided db alias (newdb) does not exists"},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Manager{
+				clusterID: "chicago",
+				projectConfig: &config.Config{
+					Projects: config.Projects{
+						"myproject": &config.Project{
+							ProjectConfig:   &config.ProjectConfig{ID: "myproject"},
+							DatabaseConfigs: config.DatabaseConfigs{dbConfigID: dbConfig},
+							DatabaseRules:   config.DatabaseRules{usersRuleID: &config.DatabaseRule{Table: "users", DbAlias: "db", Rules: map[string]*config.Rule{"read": {Rule: "allow"}}}},
+						},
+					},
+				},
+			}
+
+			mockModules := mockModulesInterface{}
+			mockCrud := mockCrudSyncManInterface{}
+			mockSchema := mockSchemaEventingInterface{}
+			for _, m := range tt.modulesMockArgs {
+				switch m.method {
+				case "GetCrudModuleForSyncMan":
+					mockModules.On(m.method, m.args...).Return(&mockCrud, nil)
+				case "GetSchemaModuleForSyncMan":
+					mockModules.On(m.method, m.args...).Return(&mockSchema, nil)
+				}
+			}
+			for _, m := range tt.crudMockArgs {
+				mockCrud.On(m.method, m.args...).Return(m.paramsReturned...)
+			}
+			for _, m := range tt.schemaMockArgs {
+				mockSchema.On(m.method, m.args...).Return(m.paramsReturned...)
+			}
+			s.modules = &mockModules
+
+			_, got, err := s.PlanConfig(context.Background(), tt.specs)
+			if err != nil {
+				t.Fatalf("Manager.PlanConfig() error = %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("Manager.PlanConfig() returned %d items, want %d", len(got), len(tt.want))
+			}
+			for i := range got {
+				if !reflect.DeepEqual(got[i], tt.want[i]) {
+					gotJSON, _ := json.Marshal(got[i])
+					wantJSON, _ := json.Marshal(tt.want[i])
+					t.Errorf("Manager.PlanConfig() item %d = %s, want %s", i, gotJSON, wantJSON)
+				}
+			}
+
+			mockModules.AssertExpectations(t)
+			mockCrud.AssertExpectations(t)
+			mockSchema.AssertExpectations(t)
+
+			// Planning must never modify the config of the cluster
+			if rules := s.projectConfig.Projects["myproject"].DatabaseRules; len(rules) != 1 || rules[usersRuleID].Rules["read"].Rule != "allow" {
+				t.Errorf("Manager.PlanConfig() modified the config of the cluster")
+			}
+		})
+	}
+}
+
+func TestGetSpecAuthParams(t *testing.T) {
+	tests := []struct {
+		name         string
+		spec         *model.SpecObject
+		wantResource string
+		wantAttr     map[string]string
+		wantOk       bool
+	}{
+		{
+			name:         "project",
+			spec:         &model.SpecObject{Type: "project", Meta: map[string]string{"project": "myproject"}},
+			wantResource: "project",
+			wantAttr:     map[string]string{"project": "myproject"},
+			wantOk:       true,
+		},
+		{
+			name:         "db rule",
+			spec:         &model.SpecObject{Type: "db-rules", Meta: map[string]string{"project": "myproject", "dbAlias": "db", "col": "users"}},
+			wantResource: "db-rule",
+			wantAttr:     map[string]string{"project": "myproject", "db": "db", "col": "users"},
+			wantOk:       true,
+		},
+		{
+			name:         "prepared query with old db key",
+			spec:         &model.SpecObject{Type: "db-prepared-query", Meta: map[string]string{"project": "myproject", "db": "db", "id": "q1"}},
+			wantResource: "db-prepared-query",
+			wantAttr:     map[string]string{"project": "myproject", "db": "db", "id": "q1"},
+			wantOk:       true,
+		},
+		{
+			name:         "remote service",
+			spec:         &model.SpecObject{Type: "remote-services", Meta: map[string]string{"project": "myproject", "id": "payments"}},
+			wantResource: "remote-service",
+			wantAttr:     map[string]string{"project": "myproject", "service": "payments"},
+			wantOk:       true,
+		},
+		{
+			name:         "filestore alias",
+			spec:         &model.SpecObject{Type: "filestore-alias", Meta: map[string]string{"project": "myproject", "id": "images"}},
+			wantResource: "filestore-config",
+			wantAttr:     map[string]string{"project": "myproject", "id": "images"},
+			wantOk:       true,
+		},
+		{
+			name:         "eventing trigger",
+			spec:         &model.SpecObject{Type: "eventing-triggers", Meta: map[string]string{"project": "myproject", "id": "on-signup"}},
+			wantResource: "eventing-trigger",
+			wantAttr:     map[string]string{"project": "myproject", "id": "on-signup"},
+			wantOk:       true,
+		},
+		{
+			name:   "spec not a part of the gateway config",
+			spec:   &model.SpecObject{Type: "service", Meta: map[string]string{"project": "myproject", "id": "greeter"}},
+			wantOk: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resource, attr, ok := GetSpecAuthParams(tt.spec)
+			if ok != tt.wantOk {
+				t.Fatalf("GetSpecAuthParams() ok = %v, want %v", ok, tt.wantOk)
+			}
+			if resource != tt.wantResource {
+				t.Errorf("GetSpecAuthParams() resource = %v, want %v", resource, tt.wantResource)
+			}
+			if !reflect.DeepEqual(attr, tt.wantAttr) {
+				t.Errorf("GetSpecAuthParams() attr = %v, want %v", attr, tt.wantAttr)
+			}
+		})
+	}
+}
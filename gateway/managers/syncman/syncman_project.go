@@ -9,6 +9,8 @@ import (
 
 	"github.com/spaceuptech/space-cloud/gateway/config"
 	"github.com/spaceuptech/space-cloud/gateway/model"
+	"github.com/spaceuptech/space-cloud/gateway/modules/global/cors"
+	"github.com/spaceuptech/space-cloud/gateway/modules/global/ratelimit"
 )
 
 // ApplyProjectConfig creates the config for the project
@@ -33,9 +35,9 @@ func (s *Manager) ApplyProjectConfig(ctx context.Context, project *config.Projec
 		return http.StatusUpgradeRequired, helpers.Logger.LogError(helpers.GetRequestID(ctx), "Upgrade your plan to create more projects", nil, nil)
 	}
 
-	// set default context time
-	if project.ContextTimeGraphQL == 0 {
-		project.ContextTimeGraphQL = 10
+	setProjectConfigDefaults(project)
+	if err := validateProjectConfig(project); err != nil {
+		return http.StatusBadRequest, helpers.Logger.LogError(helpers.GetRequestID(ctx), "Invalid project config provided", err, nil)
 	}
 
 	// Generate internal access token
@@ -43,6 +45,13 @@ func (s *Manager) ApplyProjectConfig(ctx context.Context, project *config.Projec
 	if err != nil {
 		return http.StatusInternalServerError, err
 	}
+	// The project config is replaced in place below, so we need to capture the previous value for the config history
+	resourceID := config.GenerateResourceID(s.clusterID, project.ID, config.ResourceProject, project.ID)
+	previous, _, err := getResource(ctx, s.projectConfig, resourceID)
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+
 	p, ok := s.projectConfig.Projects[project.ID]
 	if ok {
 		p.ProjectConfig = project
@@ -61,7 +70,7 @@ func (s *Manager) ApplyProjectConfig(ctx context.Context, project *config.Projec
 		return http.StatusInternalServerError, err
 	}
 
-	if err := s.store.SetResource(ctx, config.GenerateResourceID(s.clusterID, project.ID, config.ResourceProject, project.ID), project); err != nil {
+	if err := s.setResourceWithPrevious(ctx, resourceID, previous, project, params); err != nil {
 		return http.StatusInternalServerError, err
 	}
 
@@ -101,7 +110,9 @@ func (s *Manager) DeleteProjectConfig(ctx context.Context, projectID string, par
 	// NOTE: we are not deleting project here as, the watcher of config maps will eventually delete the project
 	s.modules.Delete(projectID)
 
-	if err := s.store.DeleteProject(ctx, projectID); err != nil {
+	err = s.store.DeleteProject(ctx, projectID)
+	s.recordConfigChange(ctx, config.ResourceDeleteEvent, config.GenerateResourceID(s.clusterID, projectID, config.ResourceProject, projectID), params, err)
+	if err != nil {
 		return http.StatusInternalServerError, err
 	}
 
@@ -162,3 +173,26 @@ func (s *Manager) GetTokenForMissionControl(ctx context.Context, projectID strin
 
 	return http.StatusOK, token, nil
 }
+
+// setProjectConfigDefaults sets the default values of the fields not provided in the project config
+func setProjectConfigDefaults(project *config.ProjectConfig) {
+	if project.ContextTimeGraphQL == 0 {
+		project.ContextTimeGraphQL = 10
+	}
+}
+
+// validateProjectConfig checks the policies of the project config which are enforced by the global modules
+func validateProjectConfig(project *config.ProjectConfig) error {
+	if err := ratelimit.ValidatePolicies(project.RateLimits); err != nil {
+		return err
+	}
+	if err := cors.ValidatePolicy(project.Cors); err != nil {
+		return err
+	}
+	switch project.GraphQLIntrospection {
+	case "", config.GraphQLIntrospectionEnabled, config.GraphQLIntrospectionToken, config.GraphQLIntrospectionDisabled:
+		return nil
+	default:
+		return fmt.Errorf("invalid graphql introspection (%s) provided", project.GraphQLIntrospection)
+	}
+}
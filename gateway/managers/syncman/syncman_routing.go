@@ -9,10 +9,17 @@ import (
 
 	"github.com/spaceuptech/space-cloud/gateway/config"
 	"github.com/spaceuptech/space-cloud/gateway/model"
+	"github.com/spaceuptech/space-cloud/gateway/modules/global/cors"
 )
 
 // SetProjectRoutes sets a projects routes
 func (s *Manager) SetProjectRoutes(ctx context.Context, project string, c config.Routes) (int, error) {
+	for _, route := range c {
+		if err := cors.ValidatePolicy(route.Cors); err != nil {
+			return http.StatusBadRequest, helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Invalid cors policy provided for route (%s)", route.ID), err, nil)
+		}
+	}
+
 	// Acquire a lock
 	s.lock.Lock()
 	defer s.lock.Unlock()
@@ -35,7 +42,7 @@ func (s *Manager) SetProjectRoutes(ctx context.Context, project string, c config
 	}
 
 	for resourceID, route := range ingressRoutes {
-		if err := s.store.SetResource(ctx, resourceID, route); err != nil {
+		if err := s.setResource(ctx, resourceID, route, model.RequestParams{}); err != nil {
 			return http.StatusInternalServerError, err
 		}
 	}
@@ -75,6 +82,10 @@ func (s *Manager) SetProjectRoute(ctx context.Context, project, id string, c *co
 		return hookResponse.Status(), nil
 	}
 
+	if err := cors.ValidatePolicy(c.Cors); err != nil {
+		return http.StatusBadRequest, helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Invalid cors policy provided for route (%s)", id), err, nil)
+	}
+
 	// Acquire a lock
 	s.lock.Lock()
 	defer s.lock.Unlock()
@@ -96,7 +107,7 @@ func (s *Manager) SetProjectRoute(ctx context.Context, project, id string, c *co
 		return http.StatusInternalServerError, err
 	}
 
-	if err := s.store.SetResource(ctx, resourceID, c); err != nil {
+	if err := s.setResource(ctx, resourceID, c, params); err != nil {
 		return http.StatusInternalServerError, err
 	}
 
@@ -136,7 +147,7 @@ func (s *Manager) DeleteProjectRoute(ctx context.Context, project, routeID strin
 			return http.StatusInternalServerError, err
 		}
 
-		if err := s.store.DeleteResource(ctx, resourceID); err != nil {
+		if err := s.deleteResource(ctx, resourceID, params); err != nil {
 			return http.StatusInternalServerError, err
 		}
 
@@ -216,7 +227,7 @@ func (s *Manager) SetGlobalRouteConfig(ctx context.Context, project string, glob
 
 	// Finally lets store the config
 	resourceID := config.GenerateResourceID(s.clusterID, project, config.ResourceIngressGlobal, "global")
-	if err := s.store.SetResource(ctx, resourceID, globalConfig); err != nil {
+	if err := s.setResource(ctx, resourceID, globalConfig, params); err != nil {
 		return http.StatusInternalServerError, err
 	}
 
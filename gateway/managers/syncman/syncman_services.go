@@ -46,7 +46,7 @@ func (s *Manager) SetService(ctx context.Context, project, service string, value
 		return http.StatusInternalServerError, err
 	}
 
-	if err := s.store.SetResource(ctx, resourceID, value); err != nil {
+	if err := s.setResource(ctx, resourceID, value, params); err != nil {
 		return http.StatusInternalServerError, err
 	}
 
@@ -83,7 +83,7 @@ func (s *Manager) DeleteService(ctx context.Context, project, service string, pa
 		return http.StatusInternalServerError, err
 	}
 
-	if err := s.store.DeleteResource(ctx, resourceID); err != nil {
+	if err := s.deleteResource(ctx, resourceID, params); err != nil {
 		return http.StatusInternalServerError, err
 	}
 
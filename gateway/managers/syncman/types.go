@@ -17,6 +17,7 @@ type AdminSyncmanInterface interface {
 	SetServices(eventType string, services model.ScServices)
 	ValidateProjectSyncOperation(c *config.Config, project *config.ProjectConfig) bool
 	SetIntegrationConfig(integrations config.Integrations)
+	SetAdminUsers(users config.AdminUsers)
 
 	// For integrations
 	GetIntegrationToken(id string) (string, error)
@@ -29,6 +30,11 @@ type integrationInterface interface {
 	InvokeHook(context.Context, model.RequestParams) config.IntegrationAuthResponse
 }
 
+type auditInterface interface {
+	SetConfig(c *config.AuditLogConfig)
+	Record(ctx context.Context, entry *model.AuditLogEntry)
+}
+
 // ModulesInterface is an interface consisting of functions of the modules module used by syncman
 type ModulesInterface interface {
 	// SetInitialProjectConfig sets the config all modules
@@ -45,11 +51,16 @@ type ModulesInterface interface {
 
 	// SetFileStoreConfig sets the config of auth and filestore modules
 	SetFileStoreConfig(ctx context.Context, projectID string, fileStore *config.FileStoreConfig) error
+	// SetFileStoresConfig sets the config of the named file stores
+	SetFileStoresConfig(ctx context.Context, projectID string, fileStores config.FileStores) error
 	SetFileStoreSecurityRuleConfig(ctx context.Context, projectID string, fileRule config.FileStoreRules) error
 
 	// SetServicesConfig sets the config of auth and functions modules
 	SetRemoteServiceConfig(ctx context.Context, projectID string, services config.Services) error
 
+	// SetGraphQLPersistedQueryConfig sets the persisted queries of the graphql module
+	SetGraphQLPersistedQueryConfig(ctx context.Context, projectID string, queries config.GraphQLPersistedQueries) error
+
 	SetLetsencryptConfig(ctx context.Context, projectID string, c *config.LetsEncrypt) error
 
 	SetIngressRouteConfig(ctx context.Context, projectID string, routes config.IngressRoutes) error
@@ -67,6 +78,7 @@ type ModulesInterface interface {
 	// Getters
 	GetSchemaModuleForSyncMan(projectID string) (model.SchemaEventingInterface, error)
 	GetAuthModuleForSyncMan(projectID string) (model.AuthSyncManInterface, error)
+	GetCrudModuleForSyncMan(projectID string) (model.CrudSyncManInterface, error)
 	LetsEncrypt() *letsencrypt.LetsEncrypt
 	Routing() *routing.Routing
 	Caching() *caching.Cache
@@ -84,6 +84,10 @@ func (m *mockAdminSyncmanInterface) SetIntegrationConfig(integrations config.Int
 	m.Called(integrations)
 }
 
+func (m *mockAdminSyncmanInterface) SetAdminUsers(users config.AdminUsers) {
+	m.Called(users)
+}
+
 func (m *mockAdminSyncmanInterface) ValidateIntegrationSyncOperation(integrations config.Integrations) error {
 	return m.Called(integrations).Error(0)
 }
@@ -158,6 +162,10 @@ func (m *mockModulesInterface) SetFileStoreConfig(ctx context.Context, projectID
 	return c.Error(0)
 }
 
+func (m *mockModulesInterface) SetFileStoresConfig(ctx context.Context, projectID string, fileStores config.FileStores) error {
+	return m.Called(ctx, projectID, fileStores).Error(0)
+}
+
 func (m *mockModulesInterface) SetFileStoreSecurityRuleConfig(ctx context.Context, projectID string, fileRule config.FileStoreRules) error {
 	return m.Called(ctx, projectID, fileRule).Error(0)
 }
@@ -166,6 +174,10 @@ func (m *mockModulesInterface) SetRemoteServiceConfig(ctx context.Context, proje
 	return m.Called(ctx, projectID, services).Error(0)
 }
 
+func (m *mockModulesInterface) SetGraphQLPersistedQueryConfig(ctx context.Context, projectID string, queries config.GraphQLPersistedQueries) error {
+	return m.Called(ctx, projectID, queries).Error(0)
+}
+
 func (m *mockModulesInterface) SetLetsencryptConfig(ctx context.Context, projectID string, c *config.LetsEncrypt) error {
 	return m.Called(ctx, projectID, c).Error(0)
 }
@@ -240,6 +252,19 @@ func (m *mockModulesInterface) GetAuthModuleForSyncMan(projectID string) (model.
 	return c.Get(0).(model.AuthSyncManInterface), c.Error(1)
 }
 
+func (m *mockModulesInterface) GetCrudModuleForSyncMan(projectID string) (model.CrudSyncManInterface, error) {
+	c := m.Called(projectID)
+	return c.Get(0).(model.CrudSyncManInterface), c.Error(1)
+}
+
+type mockCrudSyncManInterface struct {
+	mock.Mock
+}
+
+func (m *mockCrudSyncManInterface) CheckConnection(ctx context.Context, dbConfig *config.DatabaseConfig) error {
+	return m.Called(ctx, dbConfig).Error(0)
+}
+
 type mockStoreInterface struct {
 	mock.Mock
 }
@@ -323,6 +348,11 @@ func (m *mockSchemaEventingInterface) SchemaModifyAll(ctx context.Context, dbAli
 	return c.Error(0)
 }
 
+func (m *mockSchemaEventingInterface) GetSchemaModifyAllQueries(ctx context.Context, dbAlias, logicalDBName string, dbSchemas config.DatabaseSchemas) (map[string][]string, error) {
+	c := m.Called(ctx, dbAlias, logicalDBName, dbSchemas)
+	return c.Get(0).(map[string][]string), c.Error(1)
+}
+
 func (m *mockSchemaEventingInterface) SchemaInspection(ctx context.Context, dbAlias, project, col string, realSchema model.Collection) (string, error) {
 	c := m.Called(ctx, dbAlias, project, col, realSchema)
 	return c.String(0), c.Error(1)
@@ -336,3 +366,15 @@ func (m *mockSchemaEventingInterface) GetSchemaForDB(ctx context.Context, dbAlia
 	c := m.Called(ctx, dbAlias, col, format)
 	return c.Get(0).([]interface{}), c.Error(1)
 }
+
+type mockAuditInterface struct {
+	mock.Mock
+}
+
+func (m *mockAuditInterface) SetConfig(c *config.AuditLogConfig) {
+	m.Called(c)
+}
+
+func (m *mockAuditInterface) Record(ctx context.Context, entry *model.AuditLogEntry) {
+	m.Called(ctx, entry)
+}
@@ -0,0 +1,55 @@
+package model
+
+const (
+	// AuditKindConfig is used for the mutations of the config
+	AuditKindConfig = "config"
+	// AuditKindLogin is used for the admin logins
+	AuditKindLogin = "login"
+	// AuditKindToken is used for the generation and refresh of admin tokens
+	AuditKindToken = "token"
+	// AuditKindAuthorization is used for the authorization decisions of the admin api
+	AuditKindAuthorization = "authorization"
+	// AuditKindHook is used for the decisions taken by integration hooks
+	AuditKindHook = "hook"
+)
+
+const (
+	// AuditOutcomeSuccess indicates that the operation was performed successfully
+	AuditOutcomeSuccess = "success"
+	// AuditOutcomeFailure indicates that the operation was allowed but could not be performed
+	AuditOutcomeFailure = "failure"
+	// AuditOutcomeDenied indicates that the actor was not allowed to perform the operation
+	AuditOutcomeDenied = "denied"
+)
+
+// AuditLogEntry is the format in which the entries of the audit log are persisted on disk
+type AuditLogEntry struct {
+	ID         string `struct:"_id" json:"_id" bson:"_id" mapstructure:"_id"`
+	Timestamp  string `struct:"timestamp" json:"timestamp" bson:"timestamp" mapstructure:"timestamp"` // RFC3339Nano timestamp
+	RequestID  string `struct:"request_id" json:"request_id" bson:"request_id" mapstructure:"request_id"`
+	NodeID     string `struct:"node_id" json:"node_id" bson:"node_id" mapstructure:"node_id"`
+	Kind       string `struct:"kind" json:"kind" bson:"kind" mapstructure:"kind"`
+	Actor      string `struct:"actor" json:"actor" bson:"actor" mapstructure:"actor"`
+	Project    string `struct:"project" json:"project" bson:"project" mapstructure:"project"`
+	Resource   string `struct:"resource" json:"resource" bson:"resource" mapstructure:"resource"`
+	ResourceID string `struct:"resource_id" json:"resource_id" bson:"resource_id" mapstructure:"resource_id"`
+	Verb       string `struct:"verb" json:"verb" bson:"verb" mapstructure:"verb"`
+	Outcome    string `struct:"outcome" json:"outcome" bson:"outcome" mapstructure:"outcome"`
+	Message    string `struct:"message" json:"message" bson:"message" mapstructure:"message"`
+}
+
+// AuditLogFilter selects the entries of the audit log. Empty fields match all the entries
+type AuditLogFilter struct {
+	Kind       string `json:"kind,omitempty"`
+	Actor      string `json:"actor,omitempty"`
+	Project    string `json:"project,omitempty"`
+	Resource   string `json:"resource,omitempty"`
+	ResourceID string `json:"resourceId,omitempty"`
+	Verb       string `json:"verb,omitempty"`
+	Outcome    string `json:"outcome,omitempty"`
+	RequestID  string `json:"requestId,omitempty"`
+	From       string `json:"from,omitempty"` // RFC3339 timestamp
+	To         string `json:"to,omitempty"`   // RFC3339 timestamp
+	Limit      int64  `json:"limit,omitempty"`
+	Skip       int64  `json:"skip,omitempty"`
+}
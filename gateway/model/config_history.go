@@ -0,0 +1,30 @@
+package model
+
+import "time"
+
+// ConfigHistoryEntry describes a single change applied to a config resource
+type ConfigHistoryEntry struct {
+	Version      int64       `json:"version"`
+	ResourceID   string      `json:"resourceId"`
+	ResourceType string      `json:"resourceType"`
+	Event        string      `json:"event"`
+	Author       string      `json:"author"`
+	Timestamp    time.Time   `json:"timestamp"`
+	Previous     interface{} `json:"previous"`
+	Value        interface{} `json:"value"`
+}
+
+// ConfigDiff describes a single difference between two versions of a config resource
+type ConfigDiff struct {
+	Path string      `json:"path"`
+	From interface{} `json:"from"`
+	To   interface{} `json:"to"`
+}
+
+// ConfigRollbackRequest describes the point in time a resource or an entire project needs to be rolled back to.
+// Either the version or the timestamp needs to be provided. An empty resource id rolls back the entire project
+type ConfigRollbackRequest struct {
+	ResourceID string     `json:"resourceId"`
+	Version    int64      `json:"version"`
+	Timestamp  *time.Time `json:"timestamp"`
+}
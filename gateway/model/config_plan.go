@@ -0,0 +1,24 @@
+package model
+
+const (
+	// ConfigPlanActionCreate indicates that the resource doesn't exist and will be created
+	ConfigPlanActionCreate = "create"
+	// ConfigPlanActionUpdate indicates that the resource exists and will be changed
+	ConfigPlanActionUpdate = "update"
+	// ConfigPlanActionNoChange indicates that the resource already matches the spec
+	ConfigPlanActionNoChange = "no-change"
+	// ConfigPlanActionUnsupported indicates that the spec isn't a part of the gateway config and can't be planned
+	ConfigPlanActionUnsupported = "unsupported"
+)
+
+// ConfigPlanItem describes the effect applying a single spec object would have on the config of the cluster
+type ConfigPlanItem struct {
+	Type       string            `json:"type"`
+	Meta       map[string]string `json:"meta"`
+	ResourceID string            `json:"resourceId,omitempty"`
+	Action     string            `json:"action"`
+	Diff       []*ConfigDiff     `json:"diff,omitempty"`
+	Queries    []string          `json:"queries,omitempty"`
+	Errors     []string          `json:"errors,omitempty"`
+	Warnings   []string          `json:"warnings,omitempty"`
+}
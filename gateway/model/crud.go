@@ -32,6 +32,8 @@ type ReadOptions struct {
 	Sort       []string         `json:"sort"`
 	Skip       *int64           `json:"skip"`
 	Limit      *int64           `json:"limit"`
+	After      *string          `json:"after"`  // cursor of the document after which the results begin
+	Before     *string          `json:"before"` // cursor of the document before which the results end
 	Distinct   *string          `json:"distinct"`
 	Join       []*JoinOption    `json:"join"`
 	ReturnType string           `json:"returnType"`
@@ -124,6 +126,9 @@ const (
 	// SQLServer is the type used for MsSQL
 	SQLServer DBType = "sqlserver"
 
+	// SQLite is the type used for SQLite
+	SQLite DBType = "sqlite"
+
 	// DefaultValidate is used for default validation operation
 	DefaultValidate = "default"
 
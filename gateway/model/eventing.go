@@ -16,6 +16,30 @@ type EventDocument struct {
 	Status         string      `structs:"status" json:"status" bson:"status" mapstructure:"status"`
 	Remark         string      `structs:"remark" json:"remark" bson:"remark" mapstructure:"remark"`
 	TriggerType    string      `structs:"trigger_type,omitempty" json:"trigger_type,omitempty" bson:"trigger_type" mapstructure:"trigger_type"`
+	Retries        int         `structs:"retries,omitempty" json:"retries,omitempty" bson:"retries,omitempty" mapstructure:"retries"`                     // The number of times the event has been retried
+	NextAttempt    string      `structs:"next_attempt,omitempty" json:"next_attempt,omitempty" bson:"next_attempt,omitempty" mapstructure:"next_attempt"` // The time stamp of when a rescheduled event should get retried
+}
+
+// DLQFilter selects the events of the dead letter queue. The ids take precedence over the other filters
+type DLQFilter struct {
+	IDs     []string `json:"ids,omitempty"`
+	Trigger string   `json:"trigger,omitempty"`
+	Status  string   `json:"status,omitempty"` // Defaults to failed
+	From    string   `json:"from,omitempty"`   // RFC3339 timestamp
+	To      string   `json:"to,omitempty"`     // RFC3339 timestamp
+	Limit   int64    `json:"limit,omitempty"`
+	Skip    int64    `json:"skip,omitempty"`
+
+	// All needs to be set to replay or discard events without filtering them by id, trigger or time
+	All bool `json:"all,omitempty"`
+}
+
+// DLQReplayRequest is the http body received to replay events of the dead letter queue
+type DLQReplayRequest struct {
+	DLQFilter
+
+	// Payload replaces the payload of the event before it is replayed. It can only be provided while replaying a single event
+	Payload interface{} `json:"payload,omitempty"`
 }
 
 // InvocationDocument is the format in which the invocation are persistent on disk
@@ -1,15 +1,41 @@
 package model
 
-import "io"
+import (
+	"io"
+	"time"
+)
 
 // File is the struct returned for file reads
 type File struct {
 	File  io.Reader
 	Close func() error
+
+	Info  *FileInfo  // Stat information of the file
+	Range *FileRange // Range of the file being read. It is nil when the entire file is read
+}
+
+// FileInfo is the stat information of a file
+type FileInfo struct {
+	Size        int64      `json:"size,omitempty"`
+	ModifiedAt  *time.Time `json:"modifiedAt,omitempty"`
+	ContentType string     `json:"contentType,omitempty"`
+	ETag        string     `json:"etag,omitempty"`
+}
+
+// FileRange is the range of bytes to be read from a file. Both the start and end are inclusive
+type FileRange struct {
+	Start int64
+	End   int64
+}
+
+// Length returns the number of bytes in the range
+func (r *FileRange) Length() int64 {
+	return r.End - r.Start + 1
 }
 
 // CreateFileRequest is the request received to create a new file or directory
 type CreateFileRequest struct {
+	Store   string                 `json:"store,omitempty"` // alias of the file store
 	Meta    map[string]interface{} `json:"meta"`
 	Path    string                 `json:"path"`
 	Name    string                 `json:"name"`
@@ -25,21 +51,76 @@ type DeleteFileRequest struct {
 
 // ListFilesRequest is the request made to browse the contents inside a directory
 type ListFilesRequest struct {
-	Path string `json:"path"`
-	Type string `json:"type"` // Type could be dir, file or all
+	Store string `json:"store,omitempty"` // alias of the file store
+	Path  string `json:"path"`
+	Type  string `json:"type"` // Type could be dir, file or all
 }
 
 // ListFilesResponse is the response given to browse the contents inside a directory
 type ListFilesResponse struct {
 	Name string `json:"name"`
 	Type string `json:"type"` // Type could be dir or file
+	FileInfo
 }
 
 // FilePayload is body of request to file module
 type FilePayload struct {
-	Meta map[string]interface{} `json:"meta"`
-	Path string                 `json:"path"`
-	Type string                 `json:"type,omitempty"`
+	Meta  map[string]interface{} `json:"meta"`
+	Path  string                 `json:"path"`
+	Type  string                 `json:"type,omitempty"`
+	Store string                 `json:"store,omitempty"` // alias of the file store
+}
+
+// SignedURLRequest is the request made to create a presigned url to read or create a file
+type SignedURLRequest struct {
+	Store  string                 `json:"store,omitempty"` // alias of the file store
+	Op     FileOpType             `json:"op"`              // Either read or create
+	Path   string                 `json:"path"`
+	Name   string                 `json:"name,omitempty"` // Name of the file to be created
+	Meta   map[string]interface{} `json:"meta,omitempty"`
+	Expiry int64                  `json:"expiry,omitempty"` // Validity of the url in seconds
+}
+
+// SignedURLResponse is the response given for a presigned url request
+type SignedURLResponse struct {
+	URL       string `json:"url"`
+	Method    string `json:"method"`
+	ExpiresAt string `json:"expiresAt"`
+}
+
+// SignedFileRequest is a request made using a url signed by the gateway
+type SignedFileRequest struct {
+	Store     string
+	Op        FileOpType
+	Path      string
+	Name      string
+	Meta      string // Json encoded meta of the file to be created
+	Expires   int64  // Unix timestamp after which the url is invalid
+	Signature string
+}
+
+// FileUpload is a resumable upload of a file. It is authorised once when the upload is created
+type FileUpload struct {
+	ID        string                 `json:"id"` // id of the upload in the file store
+	Store     string                 `json:"store"`
+	Path      string                 `json:"path"`
+	Name      string                 `json:"name"`
+	Meta      map[string]interface{} `json:"meta,omitempty"`
+	CreatedAt int64                  `json:"createdAt"` // Unix timestamp at which the upload was created
+}
+
+// FileUploadPart is a chunk of a resumable upload
+type FileUploadPart struct {
+	Number int    `json:"number"`
+	Size   int64  `json:"size"`
+	ETag   string `json:"etag,omitempty"`
+}
+
+// FileUploadResponse is the response given for a resumable upload
+type FileUploadResponse struct {
+	ID        string            `json:"id"`
+	ExpiresAt string            `json:"expiresAt"`
+	Parts     []*FileUploadPart `json:"parts,omitempty"` // Parts which have been uploaded so far
 }
 
 // FileReader is a function type used for file streaming
@@ -7,6 +7,18 @@ type GraphQLRequest struct {
 	Query         string                 `json:"query"`
 	OperationName string                 `json:"operationName"`
 	Variables     map[string]interface{} `json:"variables"`
+	Extensions    *GraphQLExtensions     `json:"extensions,omitempty"`
+}
+
+// GraphQLExtensions holds the extensions of a graphql request
+type GraphQLExtensions struct {
+	PersistedQuery *PersistedQueryExtension `json:"persistedQuery,omitempty"`
+}
+
+// PersistedQueryExtension refers to a persisted query in the automatic persisted queries format of apollo
+type PersistedQueryExtension struct {
+	Version    int    `json:"version"`
+	SHA256Hash string `json:"sha256Hash"`
 }
 
 // ReadRequestKey is the key type for the dataloader
@@ -2,20 +2,27 @@ package model
 
 import (
 	"context"
+	"time"
 )
 
-// MetricCrudHook is used to log a database operation
-type MetricCrudHook func(project, dbAlias, col string, count int64, op OperationType)
+// MetricCrudHook is used to log a database operation along with its latency and the error if the operation failed
+type MetricCrudHook func(project, dbAlias, col string, count int64, op OperationType, latency time.Duration, err error)
 
-// MetricFileHook is used to log a file operation
-type MetricFileHook func(project, storeType string, op OperationType)
+// MetricFileHook is used to log a file operation along with its latency and the error if the operation failed
+type MetricFileHook func(project, storeType string, op OperationType, latency time.Duration, err error)
 
-// MetricFunctionHook is used to log a function operation
-type MetricFunctionHook func(project, service, function string)
+// MetricFunctionHook is used to log a function operation along with its latency and the error if the invocation failed
+type MetricFunctionHook func(project, service, function string, latency time.Duration, err error)
 
 // MetricEventingHook is used to log a eventing operation
 type MetricEventingHook func(project, eventingType string)
 
+// MetricEventingDeliveryHook is used to log the delivery of an event to the webhook of a trigger
+type MetricEventingDeliveryHook func(project, trigger string, latency time.Duration, err error)
+
+// MetricCacheHook is used to log a cache lookup
+type MetricCacheHook func(project, resource string, isHit bool)
+
 // CreateIntentHook is used to log a create intent
 type CreateIntentHook func(ctx context.Context, dbAlias, col string, req *CreateRequest) (*EventIntent, error)
 
@@ -34,6 +41,7 @@ type StageEventHook func(ctx context.Context, intent *EventIntent, err error)
 // EventingModule is the interface to mock the eventing module
 type EventingModule interface {
 	CreateFileIntentHook(ctx context.Context, req *CreateFileRequest) (*EventIntent, error)
-	DeleteFileIntentHook(ctx context.Context, path string, meta map[string]interface{}) (*EventIntent, error)
+	CreateSignedFileIntentHook(ctx context.Context, req *CreateFileRequest, expiry time.Duration) (*EventIntent, error)
+	DeleteFileIntentHook(ctx context.Context, store, path string, meta map[string]interface{}) (*EventIntent, error)
 	HookStage(ctx context.Context, intent *EventIntent, err error)
 }
@@ -184,3 +184,19 @@ type IndexType struct {
 	// IsPrimary specifies whether the column has a index
 	IsPrimary bool `db:"IS_PRIMARY"`
 }
+
+// EmbeddedIndexes describes all the indexes of a collection in the embedded database. Since the embedded
+// database has no query language, it is passed as json to its RawBatch method during schema creation
+type EmbeddedIndexes struct {
+	Table   string           `json:"table"`
+	Indexes []*EmbeddedIndex `json:"indexes"`
+}
+
+// EmbeddedIndex describes a single index of the embedded database
+type EmbeddedIndex struct {
+	Name     string   `json:"name"`
+	IsUnique bool     `json:"isUnique"`
+	Fields   []string `json:"fields"`
+	// Sort stores the sort order of each field. It can be either (asc) or (desc)
+	Sort []string `json:"sort"`
+}
@@ -29,6 +29,7 @@ type CrudAuthInterface interface {
 // SchemaEventingInterface is an interface consisting of functions of schema module used by eventing module
 type SchemaEventingInterface interface {
 	SchemaModifyAll(ctx context.Context, dbAlias, logicalDBName string, dbSchemas config.DatabaseSchemas) error
+	GetSchemaModifyAllQueries(ctx context.Context, dbAlias, logicalDBName string, dbSchemas config.DatabaseSchemas) (map[string][]string, error)
 	SchemaInspection(ctx context.Context, dbAlias, project, col string, realSchema Collection) (string, error)
 	GetSchema(dbAlias, col string) (Fields, bool)
 	GetSchemaForDB(ctx context.Context, dbAlias, col, format string) ([]interface{}, error)
@@ -43,6 +44,12 @@ type CrudEventingInterface interface {
 	GetSchema(dbAlias, col string) (Fields, bool)
 }
 
+// CrudAuditInterface is an interface consisting of functions of crud module used by the audit manager
+type CrudAuditInterface interface {
+	InternalCreate(ctx context.Context, dbAlias, project, col string, req *CreateRequest, isIgnoreMetrics bool) error
+	Read(ctx context.Context, dbAlias, col string, req *ReadRequest, params RequestParams) (interface{}, *SQLMetaData, error)
+}
+
 // AuthEventingInterface is an interface consisting of functions of auth module used by Eventing module
 type AuthEventingInterface interface {
 	CreateToken(ctx context.Context, tokenClaims TokenClaims) (string, error)
@@ -58,14 +65,21 @@ type AuthSyncManInterface interface {
 	GetMissionControlToken(ctx context.Context, claims map[string]interface{}) (string, error)
 }
 
+// CrudSyncManInterface is an interface consisting of functions of crud module used by sync man
+type CrudSyncManInterface interface {
+	CheckConnection(ctx context.Context, dbConfig *config.DatabaseConfig) error
+}
+
 // FilestoreEventingInterface is an interface consisting of functions of Filestore module used by Eventing module
 type FilestoreEventingInterface interface {
-	DoesExists(ctx context.Context, project, token, path string) error
+	DoesExists(ctx context.Context, project, token, store, path string) error
+	GetFileInfo(ctx context.Context, project, token, store, path string) (int, *FileInfo, error)
 }
 
 // AuthFilestoreInterface is an interface consisting of functions of auth module used by Filestore module
 type AuthFilestoreInterface interface {
-	IsFileOpAuthorised(ctx context.Context, project, token, path string, op FileOpType, args map[string]interface{}) (*PostProcess, error)
+	IsFileOpAuthorised(ctx context.Context, project, token, store, path string, op FileOpType, args map[string]interface{}) (*PostProcess, error)
+	GetAESKey() []byte
 }
 
 // AuthCrudInterface is an interface consisting of functions of auth module used by crud module
@@ -28,6 +28,7 @@ type Module struct {
 	eventingRules    map[string]*config.Rule
 	project          string
 	fileStoreType    string
+	fileStoreTypes   map[string]string // store type of the named file stores keyed by alias
 	makeHTTPRequest  utils.TypeMakeHTTPRequest
 	aesKey           []byte
 
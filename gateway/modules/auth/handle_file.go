@@ -13,12 +13,12 @@ import (
 )
 
 // IsFileOpAuthorised checks if the caller is authorized to make the request
-func (m *Module) IsFileOpAuthorised(ctx context.Context, project, token, path string, op model.FileOpType, args map[string]interface{}) (*model.PostProcess, error) {
+func (m *Module) IsFileOpAuthorised(ctx context.Context, project, token, store, path string, op model.FileOpType, args map[string]interface{}) (*model.PostProcess, error) {
 	m.RLock()
 	defer m.RUnlock()
 
-	// Get the rules corresponding to the requested path
-	params, rules, err := m.getFileRule(path)
+	// Get the rules of the store corresponding to the requested path
+	params, rules, err := m.getFileRule(store, path)
 	if err != nil {
 		return nil, err
 	}
@@ -60,10 +60,15 @@ func (m *Module) IsFileOpAuthorised(ctx context.Context, project, token, path st
 	return m.matchRule(ctx, project, rule, map[string]interface{}{"args": args}, auth, model.ReturnWhereStub{})
 }
 
-func (m *Module) getFileRule(path string) (map[string]interface{}, *config.FileRule, error) {
+func (m *Module) getFileRule(store, path string) (map[string]interface{}, *config.FileRule, error) {
 	pathParams := make(map[string]interface{})
+	store = utils.GetFileStoreAlias(store)
+	storeType := m.fileStoreType
+	if store != utils.DefaultFileStore {
+		storeType = m.fileStoreTypes[store]
+	}
 	ps := "/"
-	if m.fileStoreType == string(utils.Local) {
+	if storeType == string(utils.Local) {
 		ps = string(os.PathSeparator)
 	}
 
@@ -79,6 +84,10 @@ func (m *Module) getFileRule(path string) (map[string]interface{}, *config.FileR
 	}
 
 	for _, r := range m.fileRules {
+		// Skip the rules which belong to another store
+		if utils.GetFileStoreAlias(r.Store) != store {
+			continue
+		}
 
 		rulePath := strings.Split(r.Prefix, ps)
 
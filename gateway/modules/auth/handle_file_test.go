@@ -31,11 +31,17 @@ func TestGetFileRule(t *testing.T) {
 		Prefix: ps + "folder/suyash",
 		Rule:   map[string]*config.Rule{"rule": &config.Rule{Rule: "deny"}},
 	}
+	fileRule4 := &config.FileRule{
+		Prefix: ps + "folder",
+		Store:  "media",
+		Rule:   map[string]*config.Rule{"rule": &config.Rule{Rule: "allow"}},
+	}
 
 	var mod = []struct {
 		module        *Module
 		IsErrExpected bool
 		testName      string
+		store         string
 		path          string
 		pathParams    map[string]interface{}
 		result        *config.FileRule
@@ -64,6 +70,20 @@ func TestGetFileRule(t *testing.T) {
 			result:     &config.FileRule{ID: "", Prefix: "/folder/:suyash", Rule: map[string]*config.Rule{"rule": {Rule: "allow"}}},
 			pathParams: map[string]interface{}{"suyash": ":suyash"},
 		},
+		{
+			testName: "Valid Test Case-Rule of named store", IsErrExpected: false, store: "media", path: ps + "folder",
+			module:     &Module{fileRules: []*config.FileRule{fileRule1, fileRule4}, fileStoreTypes: map[string]string{"media": "amazon-s3"}},
+			pathParams: map[string]interface{}{},
+			result:     &config.FileRule{ID: "", Prefix: "/folder", Store: "media", Rule: map[string]*config.Rule{"rule": {Rule: "allow"}}},
+		},
+		{
+			testName: "Test Case-Rule of named store is not used for the default store", IsErrExpected: true, path: ps + "folder",
+			module: &Module{fileRules: []*config.FileRule{fileRule4}},
+		},
+		{
+			testName: "Test Case-Rule of default store is not used for a named store", IsErrExpected: true, store: "media", path: ps + "folder",
+			module: &Module{fileRules: []*config.FileRule{fileRule1}},
+		},
 		{
 			testName: "Test case-Rule and Actual Path do not match", IsErrExpected: true, path: ps + "folder" + ps + "file",
 			module: &Module{fileRules: []*config.FileRule{fileRule3, fileRule3, fileRule3}},
@@ -81,7 +101,7 @@ func TestGetFileRule(t *testing.T) {
 	for _, test := range mod {
 		t.Run(test.testName, func(t *testing.T) {
 
-			data, rules, err1 := (test.module).getFileRule(test.path)
+			data, rules, err1 := (test.module).getFileRule(test.store, test.path)
 			if (err1 != nil) != test.IsErrExpected {
 				t.Error(data, rules, err1)
 			}
@@ -156,7 +176,7 @@ func TestIsFileOpAuthorised(t *testing.T) {
 	}
 	for _, test := range authMatchQuery {
 		t.Run(test.testName, func(t *testing.T) {
-			result, err := (test.module).IsFileOpAuthorised(context.Background(), test.project, test.token, test.path, test.op, test.args)
+			result, err := (test.module).IsFileOpAuthorised(context.Background(), test.project, test.token, "", test.path, test.op, test.args)
 			if (err != nil) != test.IsErrExpected {
 				t.Error("Got Error-", err, "Want Error-", test.IsErrExpected)
 			}
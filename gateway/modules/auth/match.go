@@ -15,6 +15,7 @@ import (
 	"github.com/spaceuptech/space-cloud/gateway/model"
 	authHelpers "github.com/spaceuptech/space-cloud/gateway/modules/auth/helpers"
 	"github.com/spaceuptech/space-cloud/gateway/utils"
+	"github.com/spaceuptech/space-cloud/gateway/utils/tracing"
 )
 
 // MatchRule checks if the rule is matched or not
@@ -26,6 +27,17 @@ func (m *Module) MatchRule(ctx context.Context, project string, rule *config.Rul
 }
 
 func (m *Module) matchRule(ctx context.Context, project string, rule *config.Rule, args, auth map[string]interface{}, returnWhere model.ReturnWhereStub) (*model.PostProcess, error) {
+	ctx, span := tracing.StartSpan(ctx, "auth.rule "+rule.Rule, tracing.SpanKindInternal, tracing.String("auth.project", project), tracing.String("auth.rule.type", rule.Rule))
+	if rule.Name != "" {
+		span.SetAttributes(tracing.String("auth.rule.name", rule.Name))
+	}
+	postProcess, err := m.evaluateRule(ctx, project, rule, args, auth, returnWhere)
+	span.RecordError(err)
+	span.End()
+	return postProcess, err
+}
+
+func (m *Module) evaluateRule(ctx context.Context, project string, rule *config.Rule, args, auth map[string]interface{}, returnWhere model.ReturnWhereStub) (*model.PostProcess, error) {
 	if project != m.project {
 		return nil, formatError(ctx, rule, errors.New("invalid project details provided"))
 	}
@@ -112,6 +124,8 @@ func (m *Module) matchFunc(ctx context.Context, rule *config.Rule, MakeHTTPReque
 		return formatError(ctx, rule, err)
 	}
 
+	tracing.SpanFromContext(ctx).SetAttributes(tracing.String("http.url", rule.URL))
+
 	var result interface{}
 	if err := MakeHTTPRequest(ctx, http.MethodPost, rule.URL, token, scToken, obj, &result); err != nil {
 		return formatError(ctx, rule, err)
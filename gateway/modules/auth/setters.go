@@ -87,6 +87,16 @@ func (m *Module) SetFileStoreType(fileStoreType string) {
 	m.fileStoreType = fileStoreType
 }
 
+// SetFileStoreTypes sets the store types of the named file stores
+func (m *Module) SetFileStoreTypes(stores config.FileStores) {
+	m.Lock()
+	defer m.Unlock()
+	m.fileStoreTypes = make(map[string]string, len(stores))
+	for _, store := range stores {
+		m.fileStoreTypes[store.ID] = store.StoreType
+	}
+}
+
 // SetEventingRules sets the eventing config
 func (m *Module) SetEventingRules(eventingRules config.EventingRules) {
 	m.Lock()
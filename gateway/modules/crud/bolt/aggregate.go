@@ -2,13 +2,383 @@ package bolt
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/spaceuptech/helpers"
+	"go.etcd.io/bbolt"
 
 	"github.com/spaceuptech/space-cloud/gateway/model"
+	"github.com/spaceuptech/space-cloud/gateway/utils"
 )
 
-// Aggregate performs a bolt db pipeline aggregation
+// Aggregate performs a bolt db pipeline aggregation. The pipeline follows the syntax of mongo
+// and supports the $match, $sort, $skip, $limit, $project, $group and $count stages
 func (b *Bolt) Aggregate(ctx context.Context, col string, req *model.AggregateRequest) (interface{}, error) {
-	return nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), "aggregate operation not supported for selected database", nil, nil)
+	stages, ok := req.Pipeline.([]interface{})
+	if !ok {
+		return nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Invalid type (%T) provided for aggregation pipeline expecting array", req.Pipeline), nil, nil)
+	}
+
+	var docs []map[string]interface{}
+	if err := b.client.View(func(tx *bbolt.Tx) error {
+		var err error
+		docs, err = b.scanCollection(tx, col)
+		return err
+	}); err != nil {
+		return nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to read documents from bbolt db", err, nil)
+	}
+
+	for _, s := range stages {
+		stage, ok := s.(map[string]interface{})
+		if !ok || len(stage) != 1 {
+			return nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), "Each stage of the aggregation pipeline must be an object with a single key", nil, nil)
+		}
+		var err error
+		for name, value := range stage {
+			docs, err = runPipelineStage(ctx, name, value, docs)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	switch req.Operation {
+	case utils.One:
+		if len(docs) == 0 {
+			return nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), "No result found", nil, nil)
+		}
+		return docs[0], nil
+	case utils.All:
+		return toInterfaceArray(docs), nil
+	default:
+		return nil, utils.ErrInvalidParams
+	}
+}
+
+func runPipelineStage(ctx context.Context, name string, value interface{}, docs []map[string]interface{}) ([]map[string]interface{}, error) {
+	switch name {
+	case "$match":
+		find, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), "Value of $match stage must be an object", nil, nil)
+		}
+		return filterDocs(docs, find), nil
+
+	case "$sort":
+		// Go maps don't preserve the order of keys. Hence only a single field can be sorted in a stage
+		obj, ok := value.(map[string]interface{})
+		if !ok || len(obj) != 1 {
+			return nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), "Value of $sort stage must be an object with a single field, use multiple $sort stages in the reverse order to sort on multiple fields", nil, nil)
+		}
+		for field, order := range obj {
+			if toFloat(order) < 0 {
+				field = "-" + field
+			}
+			sortDocs(docs, []string{field})
+		}
+		return docs, nil
+
+	case "$skip", "$limit":
+		n, ok := toInt64(value)
+		if !ok {
+			return nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Value of %s stage must be an integer", name), nil, nil)
+		}
+		if name == "$skip" {
+			return paginate(docs, &n, nil), nil
+		}
+		return paginate(docs, nil, &n), nil
+
+	case "$project":
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), "Value of $project stage must be an object", nil, nil)
+		}
+		selection := make(map[string]int32, len(obj))
+		for k, v := range obj {
+			if b, ok := v.(bool); ok {
+				if b {
+					v = 1
+				} else {
+					v = 0
+				}
+			}
+			selection[k] = int32(toFloat(v))
+		}
+		projected := make([]map[string]interface{}, len(docs))
+		for i, doc := range docs {
+			projected[i] = projectDoc(doc, selection)
+		}
+		return projected, nil
+
+	case "$count":
+		field, ok := value.(string)
+		if !ok {
+			return nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), "Value of $count stage must be a string", nil, nil)
+		}
+		return []map[string]interface{}{{field: int64(len(docs))}}, nil
+
+	case "$group":
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), "Value of $group stage must be an object", nil, nil)
+		}
+		return groupStage(ctx, obj, docs)
+
+	default:
+		return nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Aggregation stage (%s) is not supported by embedded database", name), nil, nil)
+	}
+}
+
+// groupStage groups the documents by the `_id` expression and computes the accumulators of each group
+func groupStage(ctx context.Context, stage map[string]interface{}, docs []map[string]interface{}) ([]map[string]interface{}, error) {
+	keys, groups := groupDocs(docs, func(doc map[string]interface{}) interface{} {
+		return evaluateExpression(stage["_id"], doc)
+	})
+
+	result := make([]map[string]interface{}, 0, len(keys))
+	for _, key := range keys {
+		group := groups[key]
+		out := map[string]interface{}{"_id": evaluateExpression(stage["_id"], group[0])}
+		for field, v := range stage {
+			if field == "_id" {
+				continue
+			}
+			accumulator, ok := v.(map[string]interface{})
+			if !ok || len(accumulator) != 1 {
+				return nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Invalid accumulator provided for field (%s) of $group stage", field), nil, nil)
+			}
+			for op, expression := range accumulator {
+				values := make([]interface{}, len(group))
+				for i, doc := range group {
+					values[i] = evaluateExpression(expression, doc)
+				}
+				value, err := accumulate(ctx, strings.TrimPrefix(op, "$"), values)
+				if err != nil {
+					return nil, err
+				}
+				out[field] = value
+			}
+		}
+		result = append(result, out)
+	}
+	return result, nil
+}
+
+// evaluateExpression evaluates a mongo style expression where strings prefixed with `$` refer to the fields of the document
+func evaluateExpression(expression interface{}, doc map[string]interface{}) interface{} {
+	switch v := expression.(type) {
+	case string:
+		if strings.HasPrefix(v, "$") {
+			value, _ := loadField(strings.TrimPrefix(v, "$"), doc)
+			return value
+		}
+		return v
+	case map[string]interface{}:
+		obj := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			obj[k] = evaluateExpression(e, doc)
+		}
+		return obj
+	}
+	return expression
+}
+
+// aggregateDocs performs the group by and aggregate operations of a read request. The result has the same
+// structure as the other databases where the values of the aggregate functions are nested in the `aggregate` field
+func aggregateDocs(ctx context.Context, docs []map[string]interface{}, groupBy []interface{}, aggregate map[string][]string) ([]map[string]interface{}, error) {
+	groupFields := make([]string, len(groupBy))
+	for i, field := range groupBy {
+		groupFields[i] = fmt.Sprintf("%v", field)
+	}
+
+	keys, groups := groupDocs(docs, func(doc map[string]interface{}) interface{} {
+		values := make([]interface{}, len(groupFields))
+		for i, field := range groupFields {
+			values[i], _ = loadField(field, doc)
+		}
+		return values
+	})
+
+	result := make([]map[string]interface{}, 0, len(keys))
+	for _, key := range keys {
+		group := groups[key]
+		out := map[string]interface{}{}
+		for _, field := range groupFields {
+			if value, ok := loadField(field, group[0]); ok {
+				_ = utils.StoreValueInObject(field, value, out)
+			}
+		}
+
+		functions := map[string]interface{}{}
+		for function, columns := range aggregate {
+			for _, column := range columns {
+				returnField, columnName, isTableFormat := splitAggregateColumn(column)
+
+				values := make([]interface{}, len(group))
+				for i, doc := range group {
+					values[i], _ = loadField(columnName, doc)
+				}
+				value, err := accumulate(ctx, function, values)
+				if err != nil {
+					return nil, err
+				}
+
+				if isTableFormat {
+					out[returnField] = value
+					continue
+				}
+
+				// The count function can be used without any column
+				columnKey := strings.Join(strings.Split(columnName, "."), "__")
+				if columnKey == "" {
+					functions[function] = value
+					continue
+				}
+				funcValue, ok := functions[function].(map[string]interface{})
+				if !ok {
+					funcValue = map[string]interface{}{}
+					functions[function] = funcValue
+				}
+				funcValue[columnKey] = value
+			}
+		}
+		if len(functions) > 0 {
+			out[utils.GraphQLAggregate] = functions
+		}
+		result = append(result, out)
+	}
+	return result, nil
+}
+
+// getAggregateSortFields maps the sort fields which refer to an aggregated column to the field where the aggregated
+// value is stored. This allows sorting on the aggregated columns just like the other databases
+func getAggregateSortFields(sortFields []string, groupBy []interface{}, aggregate map[string][]string) []string {
+	groupFields := map[string]bool{}
+	for _, field := range groupBy {
+		groupFields[fmt.Sprintf("%v", field)] = true
+	}
+
+	fields := make([]string, len(sortFields))
+	for i, f := range sortFields {
+		fields[i] = f
+		prefix := ""
+		if strings.HasPrefix(f, "-") {
+			prefix = "-"
+		}
+		field := strings.TrimPrefix(f, "-")
+		if groupFields[field] {
+			continue
+		}
+
+		// Iterate over the functions in a fixed order so that the result is deterministic
+		functions := make([]string, 0, len(aggregate))
+		for function := range aggregate {
+			functions = append(functions, function)
+		}
+		sort.Strings(functions)
+		for _, function := range functions {
+			for _, column := range aggregate[function] {
+				returnField, columnName, isTableFormat := splitAggregateColumn(column)
+				if columnName != field {
+					continue
+				}
+				if isTableFormat {
+					fields[i] = prefix + returnField
+				} else {
+					fields[i] = fmt.Sprintf("%s%s.%s.%s", prefix, utils.GraphQLAggregate, function, strings.Join(strings.Split(columnName, "."), "__"))
+				}
+				break
+			}
+			if fields[i] != f {
+				break
+			}
+		}
+	}
+	return fields
+}
+
+// splitAggregateColumn splits a column of the form `returnField:column` or `returnField:column:table`
+func splitAggregateColumn(column string) (string, string, bool) {
+	arr := strings.Split(column, ":")
+	switch len(arr) {
+	case 1:
+		return arr[0], arr[0], false
+	case 2:
+		return arr[0], arr[1], false
+	}
+	return arr[0], arr[1], arr[2] == "table"
+}
+
+// groupDocs groups the documents by the key returned for each document. The keys are returned in the order they were first seen
+func groupDocs(docs []map[string]interface{}, getKey func(doc map[string]interface{}) interface{}) ([]string, map[string][]map[string]interface{}) {
+	keys := make([]string, 0)
+	groups := map[string][]map[string]interface{}{}
+	for _, doc := range docs {
+		data, _ := json.Marshal(getKey(doc))
+		key := string(data)
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], doc)
+	}
+	return keys, groups
+}
+
+// accumulate applies an aggregate function on the values of a group. Null values are ignored just like sql databases
+func accumulate(ctx context.Context, function string, values []interface{}) (interface{}, error) {
+	switch function {
+	case "count":
+		return int64(len(values)), nil
+	case "sum", "avg":
+		var sum float64
+		var n int
+		for _, value := range values {
+			if getTypeRank(value) != 1 {
+				continue
+			}
+			sum += toFloat(value)
+			n++
+		}
+		if function == "sum" {
+			return sum, nil
+		}
+		if n == 0 {
+			return nil, nil
+		}
+		return sum / float64(n), nil
+	case "min", "max":
+		var result interface{}
+		for _, value := range values {
+			if value == nil {
+				continue
+			}
+			c := compareValues(value, result)
+			if result == nil || (function == "min" && c < 0) || (function == "max" && c > 0) {
+				result = value
+			}
+		}
+		return result, nil
+	case "first", "last":
+		if len(values) == 0 {
+			return nil, nil
+		}
+		if function == "first" {
+			return values[0], nil
+		}
+		return values[len(values)-1], nil
+	case "push":
+		return values, nil
+	default:
+		return nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf(`Unknown aggregate funcion %s`, function), nil, nil)
+	}
+}
+
+func toInt64(value interface{}) (int64, bool) {
+	if getTypeRank(value) != 1 {
+		return 0, false
+	}
+	return int64(toFloat(value)), true
 }
@@ -0,0 +1,106 @@
+package bolt
+
+import (
+	"context"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/spaceuptech/space-cloud/gateway/model"
+	"github.com/spaceuptech/space-cloud/gateway/utils"
+)
+
+func TestBolt_Aggregate(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     *model.AggregateRequest
+		want    interface{}
+		wantErr bool
+	}{
+		{
+			name: "match, group and sort",
+			req: &model.AggregateRequest{
+				Operation: utils.All,
+				Pipeline: []interface{}{
+					map[string]interface{}{"$match": map[string]interface{}{"team": "admin"}},
+					map[string]interface{}{"$group": map[string]interface{}{
+						"_id":   "$isPrimary",
+						"total": map[string]interface{}{"$sum": "$project_count"},
+						"max":   map[string]interface{}{"$max": "$project_count"},
+					}},
+					map[string]interface{}{"$sort": map[string]interface{}{"total": -1}},
+				},
+			},
+			want: []interface{}{
+				map[string]interface{}{"_id": true, "total": float64(162), "max": float64(100)},
+				map[string]interface{}{"_id": false, "total": float64(15), "max": float64(15)},
+			},
+		},
+		{
+			name: "sort, skip, limit and project",
+			req: &model.AggregateRequest{
+				Operation: utils.All,
+				Pipeline: []interface{}{
+					map[string]interface{}{"$sort": map[string]interface{}{"project_count": 1}},
+					map[string]interface{}{"$skip": float64(1)},
+					map[string]interface{}{"$limit": float64(2)},
+					map[string]interface{}{"$project": map[string]interface{}{"name": true}},
+				},
+			},
+			want: []interface{}{
+				map[string]interface{}{"name": "sharad"},
+				map[string]interface{}{"name": "noorain"},
+			},
+		},
+		{
+			name: "count a single document",
+			req: &model.AggregateRequest{
+				Operation: utils.One,
+				Pipeline: []interface{}{
+					map[string]interface{}{"$match": map[string]interface{}{"isPrimary": true}},
+					map[string]interface{}{"$count": "total"},
+				},
+			},
+			want: map[string]interface{}{"total": int64(3)},
+		},
+		{
+			name: "unsupported stage",
+			req: &model.AggregateRequest{
+				Operation: utils.All,
+				Pipeline:  []interface{}{map[string]interface{}{"$lookup": map[string]interface{}{}}},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "invalid pipeline",
+			req:     &model.AggregateRequest{Operation: utils.All, Pipeline: map[string]interface{}{}},
+			wantErr: true,
+		},
+	}
+
+	b, err := Init(true, "aggregate.db", "bucketName")
+	if err != nil {
+		t.Fatal("error initializing database")
+	}
+
+	if err := createDatabaseWithTestData(b); err != nil {
+		t.Fatal("error test data cannot be created for executing aggregate test", err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := b.Aggregate(context.Background(), "project_details", tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Aggregate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Aggregate() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	utils.CloseTheCloser(b)
+	if err := os.Remove("aggregate.db"); err != nil {
+		t.Error("error removing database file")
+	}
+}
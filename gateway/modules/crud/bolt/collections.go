@@ -1,7 +1,6 @@
 package bolt
 
 import (
-	"bytes"
 	"context"
 	"fmt"
 	"strings"
@@ -42,22 +41,15 @@ func (b *Bolt) GetCollections(ctx context.Context) ([]utils.DatabaseCollections,
 // DeleteCollection deletes collection / tables name of specified database
 func (b *Bolt) DeleteCollection(ctx context.Context, col string) error {
 	err := b.client.Update(func(tx *bbolt.Tx) error {
-		b := tx.Bucket([]byte(b.bucketName))
-
-		if b == nil {
+		bucket := tx.Bucket([]byte(b.bucketName))
+		if bucket == nil {
 			return nil
 		}
 
-		c := b.Cursor()
-
-		prefix := []byte(col)
-		for key, _ := c.Seek(prefix); key != nil && bytes.HasPrefix(key, prefix); key, _ = c.Next() {
-			err := b.Delete(key)
-			if err != nil {
-				return helpers.Logger.LogError(helpers.GetRequestID(ctx), "error deleting collection from embedded db", err, nil)
-			}
+		if err := deleteKeysWithPrefix(bucket, []byte(col+"/")); err != nil {
+			return helpers.Logger.LogError(helpers.GetRequestID(ctx), "error deleting collection from embedded db", err, nil)
 		}
-		return nil
+		return b.dropCollectionIndexes(tx, col)
 	})
 	if err != nil {
 		return helpers.Logger.LogError(helpers.GetRequestID(ctx), "error deleting collection from embedded db", err, nil)
@@ -32,40 +32,50 @@ func (b *Bolt) Create(ctx context.Context, col string, req *model.CreateRequest)
 		}
 
 		if err := b.client.Update(func(tx *bbolt.Tx) error {
+			bucket, err := tx.CreateBucketIfNotExists([]byte(b.bucketName))
+			if err != nil {
+				return helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("error creating bucket in bboltdb while inserting- %v", err), nil, nil)
+			}
+
+			indexes, err := b.getIndexes(tx, col)
+			if err != nil {
+				return helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to load indexes from bbolt db", err, nil)
+			}
 
 			for _, objToSet := range objs {
+				doc, ok := objToSet.(map[string]interface{})
+				if !ok {
+					return helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to insert data into bboltdb cannot assert document to map", nil, nil)
+				}
+
 				// get _id from create request
-				id, ok := objToSet.(map[string]interface{})["_id"]
+				id, ok := doc["_id"]
 				if !ok {
 					return helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to insert data _id not found in create request", nil, nil)
 				}
 				// check if specified already exists in database
-				count, _, _, _, err := b.Read(ctx, col, &model.ReadRequest{
-					Find: map[string]interface{}{
-						"_id": id,
-					},
-					Operation: utils.Count,
-				})
-				if err != nil {
-					return helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to read existing data", err, nil)
-				}
-				if count > 0 {
+				key := []byte(fmt.Sprintf("%s/%s", col, id))
+				if bucket.Get(key) != nil {
 					return helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to insert data already exists", nil, nil)
 				}
 
-				b, err := tx.CreateBucketIfNotExists([]byte(b.bucketName))
-				if err != nil {
-					return helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("error creating bucket in bboltdb while inserting- %v", err), nil, nil)
-				}
-
 				// store value as json string
 				value, err := json.Marshal(&objToSet)
 				if err != nil {
 					return helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("error marshalling while inserting in bboltdb - %v", err), nil, nil)
 				}
 
+				// update the secondary indexes using the stored form of the document
+				storedDoc := map[string]interface{}{}
+				if err := json.Unmarshal(value, &storedDoc); err != nil {
+					return helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to unmarshal data while inserting in bboltdb", err, nil)
+				}
+				if err := b.addIndexEntries(ctx, tx, col, indexes, storedDoc); err != nil {
+					return err
+				}
+
 				// insert document in bucket
-				if err = b.Put([]byte(fmt.Sprintf("%s/%s", col, id)), value); err != nil {
+				if err = bucket.Put(key, value); err != nil {
 					return helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("error inserting in bbolt db - %v", err), nil, nil)
 				}
 			}
@@ -1,10 +1,9 @@
 package bolt
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
 	"errors"
+	"fmt"
 
 	"github.com/spaceuptech/helpers"
 	"go.etcd.io/bbolt"
@@ -21,26 +20,31 @@ func (b *Bolt) Delete(ctx context.Context, col string, req *model.DeleteRequest)
 		if err := b.client.Update(func(tx *bbolt.Tx) error {
 			// Assume bucket exists and has keys
 			bucket := tx.Bucket([]byte(b.bucketName))
-			c := bucket.Cursor()
+			if bucket == nil {
+				return nil
+			}
+
+			indexes, err := b.getIndexes(tx, col)
+			if err != nil {
+				return helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to load indexes from bbolt db", err, nil)
+			}
 
-			// get all keys matching the prefix
-			prefix := []byte(col + "/")
-			for k, v := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, v = c.Next() {
-				result := map[string]interface{}{}
-				if err := json.Unmarshal(v, &result); err != nil {
-					return helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to unmarshal data of bbolt db", err, nil)
+			docs, err := b.findDocs(tx, col, req.Find)
+			if err != nil {
+				return helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to unmarshal data of bbolt db", err, nil)
+			}
+			for _, doc := range docs {
+				if err := b.removeIndexEntries(ctx, tx, col, indexes, doc); err != nil {
+					return err
+				}
+				// delete data
+				if err := bucket.Delete([]byte(fmt.Sprintf("%s/%s", col, doc["_id"]))); err != nil {
+					return helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to delete bbolt key", err, nil)
 				}
-				// if valid then delete
-				if utils.Validate(string(model.EmbeddedDB), req.Find, result) {
-					// delete data
-					if err := bucket.Delete(k); err != nil {
-						return helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to delete bbolt key", err, nil)
-					}
-					count++
-					if req.Operation == utils.One {
-						// exit the loop
-						break
-					}
+				count++
+				if req.Operation == utils.One {
+					// exit the loop
+					break
 				}
 			}
 			return nil
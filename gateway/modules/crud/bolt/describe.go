@@ -2,13 +2,95 @@ package bolt
 
 import (
 	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
 
 	"github.com/spaceuptech/helpers"
+	"go.etcd.io/bbolt"
 
 	"github.com/spaceuptech/space-cloud/gateway/model"
 )
 
-// DescribeTable return a structure of sql table
+// DescribeTable infers the structure of a collection from its documents. The type of a field is one of
+// string, integer, float, boolean or json. A field is nullable if it's missing or null in any document
 func (b *Bolt) DescribeTable(ctx context.Context, col string) ([]model.InspectorFieldType, []model.IndexType, error) {
-	return nil, nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), "Describe table operation not supported for selected database", nil, nil)
+	var docs []map[string]interface{}
+	var indexes []*model.EmbeddedIndex
+	if err := b.client.View(func(tx *bbolt.Tx) error {
+		var err error
+		docs, err = b.scanCollection(tx, col)
+		if err != nil {
+			return err
+		}
+		indexes, err = b.getIndexes(tx, col)
+		return err
+	}); err != nil {
+		return nil, nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Unable to describe collection (%s) of bbolt db", col), err, nil)
+	}
+
+	fieldTypes := map[string]string{}
+	fieldCounts := map[string]int{}
+	for _, doc := range docs {
+		for field, value := range doc {
+			if value == nil {
+				continue
+			}
+			fieldCounts[field]++
+			fieldType := getFieldType(value)
+			if currentType, ok := fieldTypes[field]; ok && currentType != fieldType {
+				// Integers widen to floats while any other mix of types is stored as json
+				if (currentType == "integer" && fieldType == "float") || (currentType == "float" && fieldType == "integer") {
+					fieldType = "float"
+				} else {
+					fieldType = "json"
+				}
+			}
+			fieldTypes[field] = fieldType
+		}
+	}
+
+	// Sort the fields so that the result is deterministic
+	fieldNames := make([]string, 0, len(fieldTypes))
+	for field := range fieldTypes {
+		fieldNames = append(fieldNames, field)
+	}
+	sort.Strings(fieldNames)
+
+	fields := make([]model.InspectorFieldType, 0, len(fieldNames))
+	for _, field := range fieldNames {
+		fieldNull := "YES"
+		if fieldCounts[field] == len(docs) {
+			fieldNull = "NO"
+		}
+		fields = append(fields, model.InspectorFieldType{TableName: col, ColumnName: field, FieldType: fieldTypes[field], FieldNull: fieldNull})
+	}
+
+	indexTypes := make([]model.IndexType, 0)
+	for _, index := range indexes {
+		for i, field := range index.Fields {
+			indexSort := model.DefaultIndexSort
+			if i < len(index.Sort) && index.Sort[i] != "" {
+				indexSort = strings.ToLower(index.Sort[i])
+			}
+			indexTypes = append(indexTypes, model.IndexType{TableName: col, ColumnName: field, IndexName: index.Name, Order: i + 1, Sort: indexSort, IsUnique: index.IsUnique})
+		}
+	}
+	return fields, indexTypes, nil
+}
+
+func getFieldType(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case float64:
+		if v == math.Trunc(v) {
+			return "integer"
+		}
+		return "float"
+	}
+	return "json"
 }
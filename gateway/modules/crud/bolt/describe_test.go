@@ -0,0 +1,65 @@
+package bolt
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/spaceuptech/space-cloud/gateway/model"
+	"github.com/spaceuptech/space-cloud/gateway/utils"
+)
+
+func TestBolt_DescribeTable(t *testing.T) {
+	b, err := Init(true, "describe.db", "bucketName")
+	if err != nil {
+		t.Fatal("error initializing database")
+	}
+	defer func() {
+		utils.CloseTheCloser(b)
+		if err := os.Remove("describe.db"); err != nil {
+			t.Error("error removing database file")
+		}
+	}()
+
+	ctx := context.Background()
+	docs := []interface{}{
+		map[string]interface{}{"_id": "1", "name": "sharad", "age": 10, "score": 1.5, "isPrimary": true, "address": map[string]interface{}{"city": "mumbai"}},
+		map[string]interface{}{"_id": "2", "name": "jayesh", "age": 20, "score": 2, "isPrimary": false, "mixed": "a"},
+		map[string]interface{}{"_id": "3", "name": "ali", "age": nil, "score": 3, "isPrimary": false, "mixed": 1},
+	}
+	if _, err := b.Create(ctx, "users", &model.CreateRequest{Operation: utils.All, Document: docs}); err != nil {
+		t.Fatal("error test data cannot be created for executing describe test", err)
+	}
+	data, _ := json.Marshal(&model.EmbeddedIndexes{Table: "users", Indexes: []*model.EmbeddedIndex{{Name: "index__users__group", Fields: []string{"name", "age"}, Sort: []string{"asc", "desc"}}}})
+	if err := b.RawBatch(ctx, []string{string(data)}); err != nil {
+		t.Fatal("error creating indexes for executing describe test", err)
+	}
+
+	fields, indexes, err := b.DescribeTable(ctx, "users")
+	if err != nil {
+		t.Fatalf("DescribeTable() error = %v", err)
+	}
+
+	wantFields := []model.InspectorFieldType{
+		{TableName: "users", ColumnName: "_id", FieldType: "string", FieldNull: "NO"},
+		{TableName: "users", ColumnName: "address", FieldType: "json", FieldNull: "YES"},
+		{TableName: "users", ColumnName: "age", FieldType: "integer", FieldNull: "YES"},
+		{TableName: "users", ColumnName: "isPrimary", FieldType: "boolean", FieldNull: "NO"},
+		{TableName: "users", ColumnName: "mixed", FieldType: "json", FieldNull: "YES"},
+		{TableName: "users", ColumnName: "name", FieldType: "string", FieldNull: "NO"},
+		{TableName: "users", ColumnName: "score", FieldType: "float", FieldNull: "NO"},
+	}
+	if !reflect.DeepEqual(fields, wantFields) {
+		t.Errorf("DescribeTable() fields = %v, want %v", fields, wantFields)
+	}
+
+	wantIndexes := []model.IndexType{
+		{TableName: "users", ColumnName: "name", IndexName: "index__users__group", Order: 1, Sort: "asc"},
+		{TableName: "users", ColumnName: "age", IndexName: "index__users__group", Order: 2, Sort: "desc"},
+	}
+	if !reflect.DeepEqual(indexes, wantIndexes) {
+		t.Errorf("DescribeTable() indexes = %v, want %v", indexes, wantIndexes)
+	}
+}
@@ -0,0 +1,290 @@
+package bolt
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"go.etcd.io/bbolt"
+
+	"github.com/spaceuptech/space-cloud/gateway/model"
+	"github.com/spaceuptech/space-cloud/gateway/utils"
+)
+
+// scanCollection returns all the documents of a collection
+func (b *Bolt) scanCollection(tx *bbolt.Tx, col string) ([]map[string]interface{}, error) {
+	docs := make([]map[string]interface{}, 0)
+	bucket := tx.Bucket([]byte(b.bucketName))
+	if bucket == nil {
+		return docs, nil
+	}
+
+	cursor := bucket.Cursor()
+	prefix := []byte(col + "/")
+	for k, v := cursor.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, v = cursor.Next() {
+		doc := map[string]interface{}{}
+		if err := json.Unmarshal(v, &doc); err != nil {
+			return nil, err
+		}
+		docs = append(docs, doc)
+	}
+	return docs, nil
+}
+
+// findDocs returns the documents of a collection which match the find clause. A secondary index is used
+// to look up the documents if one covers the find clause, else the entire collection is scanned
+func (b *Bolt) findDocs(tx *bbolt.Tx, col string, find map[string]interface{}) ([]map[string]interface{}, error) {
+	bucket := tx.Bucket([]byte(b.bucketName))
+	if bucket == nil {
+		return []map[string]interface{}{}, nil
+	}
+
+	var ids []string
+	if id, ok := find["_id"]; ok && isScalar(id) {
+		ids = []string{fmt.Sprintf("%v", id)}
+	} else {
+		indexedIDs, isIndexed, err := b.findIndexedIDs(tx, col, find)
+		if err != nil {
+			return nil, err
+		}
+		if !isIndexed {
+			docs, err := b.scanCollection(tx, col)
+			if err != nil {
+				return nil, err
+			}
+			return filterDocs(docs, find), nil
+		}
+		ids = indexedIDs
+	}
+
+	docs := make([]map[string]interface{}, 0, len(ids))
+	for _, id := range ids {
+		value := bucket.Get([]byte(fmt.Sprintf("%s/%s", col, id)))
+		if value == nil {
+			continue
+		}
+		doc := map[string]interface{}{}
+		if err := json.Unmarshal(value, &doc); err != nil {
+			return nil, err
+		}
+		docs = append(docs, doc)
+	}
+	return filterDocs(docs, find), nil
+}
+
+func filterDocs(docs []map[string]interface{}, find map[string]interface{}) []map[string]interface{} {
+	filtered := make([]map[string]interface{}, 0, len(docs))
+	for _, doc := range docs {
+		if utils.Validate(string(model.EmbeddedDB), find, doc) {
+			filtered = append(filtered, doc)
+		}
+	}
+	return filtered
+}
+
+// loadField returns the value of a field of a document. Nested fields are separated by a dot
+func loadField(field string, doc map[string]interface{}) (interface{}, bool) {
+	if value, p := doc[field]; p {
+		return value, true
+	}
+
+	var value interface{} = doc
+	for _, key := range strings.Split(field, ".") {
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		value, ok = obj[key]
+		if !ok {
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+// sortDocs sorts the documents in place based on the provided sort fields. Fields prefixed with `-` are sorted in the descending order
+func sortDocs(docs []map[string]interface{}, fields []string) {
+	if len(fields) == 0 {
+		return
+	}
+	sort.SliceStable(docs, func(i, j int) bool {
+		for _, field := range fields {
+			isDescending := strings.HasPrefix(field, "-")
+			field = strings.TrimPrefix(field, "-")
+
+			v1, _ := loadField(field, docs[i])
+			v2, _ := loadField(field, docs[j])
+			c := compareValues(v1, v2)
+			if c == 0 {
+				continue
+			}
+			if isDescending {
+				return c > 0
+			}
+			return c < 0
+		}
+		return false
+	})
+}
+
+// compareValues compares two values of a document. Values of different types are ordered
+// similar to mongo which is null, numbers, strings, objects, arrays and finally booleans
+func compareValues(v1, v2 interface{}) int {
+	r1, r2 := getTypeRank(v1), getTypeRank(v2)
+	if r1 != r2 {
+		if r1 < r2 {
+			return -1
+		}
+		return 1
+	}
+
+	switch r1 {
+	case 1:
+		n1, n2 := toFloat(v1), toFloat(v2)
+		switch {
+		case n1 < n2:
+			return -1
+		case n1 > n2:
+			return 1
+		}
+		return 0
+	case 2:
+		return strings.Compare(v1.(string), v2.(string))
+	case 5:
+		b1, b2 := v1.(bool), v2.(bool)
+		switch {
+		case b1 == b2:
+			return 0
+		case !b1:
+			return -1
+		}
+		return 1
+	case 3, 4:
+		d1, _ := json.Marshal(v1)
+		d2, _ := json.Marshal(v2)
+		return bytes.Compare(d1, d2)
+	}
+	return 0
+}
+
+func getTypeRank(value interface{}) int {
+	switch value.(type) {
+	case nil:
+		return 0
+	case int, int32, int64, float32, float64:
+		return 1
+	case string:
+		return 2
+	case map[string]interface{}:
+		return 3
+	case []interface{}:
+		return 4
+	case bool:
+		return 5
+	}
+	return 6
+}
+
+func toFloat(value interface{}) float64 {
+	switch v := value.(type) {
+	case int:
+		return float64(v)
+	case int32:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case float32:
+		return float64(v)
+	case float64:
+		return v
+	}
+	return 0
+}
+
+// paginate applies the skip and limit options on the documents
+func paginate(docs []map[string]interface{}, skip, limit *int64) []map[string]interface{} {
+	if skip != nil {
+		if *skip >= int64(len(docs)) {
+			return []map[string]interface{}{}
+		}
+		if *skip > 0 {
+			docs = docs[*skip:]
+		}
+	}
+	if limit != nil && *limit >= 0 && *limit < int64(len(docs)) {
+		docs = docs[:*limit]
+	}
+	return docs
+}
+
+// projectDoc returns a new document with only the selected fields. All fields are returned if nothing is selected
+func projectDoc(doc map[string]interface{}, selection map[string]int32) map[string]interface{} {
+	if len(selection) == 0 {
+		return doc
+	}
+
+	// Mongo style projections either include or exclude fields
+	isExclusion := true
+	for _, v := range selection {
+		if v != 0 {
+			isExclusion = false
+			break
+		}
+	}
+
+	if isExclusion {
+		result := make(map[string]interface{}, len(doc))
+		for k, v := range doc {
+			result[k] = v
+		}
+		for field := range selection {
+			_ = deleteField(field, result)
+		}
+		return result
+	}
+
+	result := make(map[string]interface{}, len(selection))
+	for field, v := range selection {
+		if v == 0 {
+			continue
+		}
+		if value, ok := loadField(field, doc); ok {
+			_ = utils.StoreValueInObject(field, value, result)
+		}
+	}
+	return result
+}
+
+func deleteField(field string, doc map[string]interface{}) bool {
+	if _, p := doc[field]; p {
+		delete(doc, field)
+		return true
+	}
+	keys := strings.Split(field, ".")
+	obj := doc
+	for _, key := range keys[:len(keys)-1] {
+		next, ok := obj[key].(map[string]interface{})
+		if !ok {
+			return false
+		}
+		// Copy the nested object so that the stored document isn't modified
+		copied := make(map[string]interface{}, len(next))
+		for k, v := range next {
+			copied[k] = v
+		}
+		obj[key] = copied
+		obj = copied
+	}
+	delete(obj, keys[len(keys)-1])
+	return true
+}
+
+func toInterfaceArray(docs []map[string]interface{}) []interface{} {
+	array := make([]interface{}, len(docs))
+	for i, doc := range docs {
+		array[i] = doc
+	}
+	return array
+}
@@ -0,0 +1,290 @@
+package bolt
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/spaceuptech/helpers"
+	"go.etcd.io/bbolt"
+
+	"github.com/spaceuptech/space-cloud/gateway/model"
+)
+
+// The definitions and the entries of the secondary indexes are stored in their own buckets so that
+// they don't show up as collections. Definitions are stored as `col/index` and entries as `col/index/values/id`
+func (b *Bolt) indexDefinitionsBucket() []byte {
+	return []byte(b.bucketName + "__index_definitions")
+}
+
+func (b *Bolt) indexEntriesBucket() []byte {
+	return []byte(b.bucketName + "__index_entries")
+}
+
+// getIndexes returns the definitions of all the indexes of a collection
+func (b *Bolt) getIndexes(tx *bbolt.Tx, col string) ([]*model.EmbeddedIndex, error) {
+	indexes := make([]*model.EmbeddedIndex, 0)
+	bucket := tx.Bucket(b.indexDefinitionsBucket())
+	if bucket == nil {
+		return indexes, nil
+	}
+
+	cursor := bucket.Cursor()
+	prefix := []byte(col + "/")
+	for k, v := cursor.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, v = cursor.Next() {
+		index := new(model.EmbeddedIndex)
+		if err := json.Unmarshal(v, index); err != nil {
+			return nil, err
+		}
+		indexes = append(indexes, index)
+	}
+	return indexes, nil
+}
+
+// syncIndexes makes sure that the collection has exactly the provided indexes. Indexes which have changed are rebuilt
+func (b *Bolt) syncIndexes(ctx context.Context, tx *bbolt.Tx, req *model.EmbeddedIndexes) error {
+	currentIndexes, err := b.getIndexes(tx, req.Table)
+	if err != nil {
+		return helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Unable to load indexes of collection (%s) from bbolt db", req.Table), err, nil)
+	}
+
+	definitions, err := tx.CreateBucketIfNotExists(b.indexDefinitionsBucket())
+	if err != nil {
+		return helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to create index definitions bucket in bbolt db", err, nil)
+	}
+
+	realIndexes := make(map[string]*model.EmbeddedIndex, len(req.Indexes))
+	for _, index := range req.Indexes {
+		realIndexes[index.Name] = index
+	}
+
+	// Drop the indexes which no longer exist or have changed
+	for _, currentIndex := range currentIndexes {
+		realIndex, ok := realIndexes[currentIndex.Name]
+		if ok && isSameIndex(currentIndex, realIndex) {
+			delete(realIndexes, currentIndex.Name)
+			continue
+		}
+		if err := b.dropIndex(tx, req.Table, currentIndex.Name); err != nil {
+			return helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Unable to drop index (%s) of collection (%s) from bbolt db", currentIndex.Name, req.Table), err, nil)
+		}
+	}
+
+	// Create the new indexes along with the entries of the existing documents
+	for _, index := range req.Indexes {
+		if _, ok := realIndexes[index.Name]; !ok {
+			continue
+		}
+
+		data, err := json.Marshal(index)
+		if err != nil {
+			return err
+		}
+		if err := definitions.Put([]byte(fmt.Sprintf("%s/%s", req.Table, index.Name)), data); err != nil {
+			return helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Unable to store index (%s) of collection (%s) in bbolt db", index.Name, req.Table), err, nil)
+		}
+
+		docs, err := b.scanCollection(tx, req.Table)
+		if err != nil {
+			return helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Unable to read collection (%s) from bbolt db", req.Table), err, nil)
+		}
+		for _, doc := range docs {
+			if err := b.addIndexEntries(ctx, tx, req.Table, []*model.EmbeddedIndex{index}, doc); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// dropIndex deletes the definition and all the entries of an index
+func (b *Bolt) dropIndex(tx *bbolt.Tx, col, name string) error {
+	if bucket := tx.Bucket(b.indexDefinitionsBucket()); bucket != nil {
+		if err := bucket.Delete([]byte(fmt.Sprintf("%s/%s", col, name))); err != nil {
+			return err
+		}
+	}
+	return deleteKeysWithPrefix(tx.Bucket(b.indexEntriesBucket()), []byte(fmt.Sprintf("%s/%s/", col, name)))
+}
+
+// dropCollectionIndexes deletes the definitions and the entries of all the indexes of a collection
+func (b *Bolt) dropCollectionIndexes(tx *bbolt.Tx, col string) error {
+	if err := deleteKeysWithPrefix(tx.Bucket(b.indexDefinitionsBucket()), []byte(col+"/")); err != nil {
+		return err
+	}
+	return deleteKeysWithPrefix(tx.Bucket(b.indexEntriesBucket()), []byte(col+"/"))
+}
+
+// addIndexEntries adds the entries of a document to the provided indexes. An error is returned
+// if another document with the same values already exists in a unique index
+func (b *Bolt) addIndexEntries(ctx context.Context, tx *bbolt.Tx, col string, indexes []*model.EmbeddedIndex, doc map[string]interface{}) error {
+	if len(indexes) == 0 {
+		return nil
+	}
+
+	bucket, err := tx.CreateBucketIfNotExists(b.indexEntriesBucket())
+	if err != nil {
+		return helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to create index entries bucket in bbolt db", err, nil)
+	}
+
+	id := fmt.Sprintf("%v", doc["_id"])
+	for _, index := range indexes {
+		values, isNull, err := getIndexValues(index, doc)
+		if err != nil {
+			return err
+		}
+
+		// Null values never conflict with each other just like sql databases
+		prefix := getIndexEntryPrefix(col, index.Name, values)
+		if index.IsUnique && !isNull {
+			cursor := bucket.Cursor()
+			for k, _ := cursor.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, _ = cursor.Next() {
+				if string(k[len(prefix):]) != id {
+					return helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Unique index (%s) of collection (%s) violated for value (%s)", index.Name, col, values), nil, nil)
+				}
+			}
+		}
+
+		if err := bucket.Put(append(prefix, []byte(id)...), []byte{}); err != nil {
+			return helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Unable to store entry of index (%s) in bbolt db", index.Name), err, nil)
+		}
+	}
+	return nil
+}
+
+// removeIndexEntries removes the entries of a document from the provided indexes
+func (b *Bolt) removeIndexEntries(ctx context.Context, tx *bbolt.Tx, col string, indexes []*model.EmbeddedIndex, doc map[string]interface{}) error {
+	bucket := tx.Bucket(b.indexEntriesBucket())
+	if bucket == nil || len(indexes) == 0 {
+		return nil
+	}
+
+	id := fmt.Sprintf("%v", doc["_id"])
+	for _, index := range indexes {
+		values, _, err := getIndexValues(index, doc)
+		if err != nil {
+			return err
+		}
+		if err := bucket.Delete(append(getIndexEntryPrefix(col, index.Name, values), []byte(id)...)); err != nil {
+			return helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Unable to delete entry of index (%s) from bbolt db", index.Name), err, nil)
+		}
+	}
+	return nil
+}
+
+// findIndexedIDs returns the ids of the documents matching the find clause if an index covers
+// the find clause. The returned boolean is false if no index could be used
+func (b *Bolt) findIndexedIDs(tx *bbolt.Tx, col string, find map[string]interface{}) ([]string, bool, error) {
+	bucket := tx.Bucket(b.indexEntriesBucket())
+	if bucket == nil || len(find) == 0 {
+		return nil, false, nil
+	}
+
+	indexes, err := b.getIndexes(tx, col)
+	if err != nil {
+		return nil, false, err
+	}
+
+	for _, index := range indexes {
+		// The index can only be used if the find clause checks all its fields for equality
+		doc := make(map[string]interface{}, len(index.Fields))
+		isCovered := true
+		for _, field := range index.Fields {
+			value, ok := find[field]
+			if !ok || !isScalar(value) {
+				isCovered = false
+				break
+			}
+			doc[field] = value
+		}
+		if !isCovered {
+			continue
+		}
+
+		values, _, err := getIndexValues(index, doc)
+		if err != nil {
+			return nil, false, err
+		}
+
+		ids := make([]string, 0)
+		prefix := getIndexEntryPrefix(col, index.Name, values)
+		cursor := bucket.Cursor()
+		for k, _ := cursor.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, _ = cursor.Next() {
+			ids = append(ids, string(k[len(prefix):]))
+		}
+		return ids, true, nil
+	}
+	return nil, false, nil
+}
+
+// getIndexValues returns the values of the indexed fields of a document encoded as json. Values are passed
+// through a json round trip first so that an integer and a float with the same value result in the same entry
+func getIndexValues(index *model.EmbeddedIndex, doc map[string]interface{}) (string, bool, error) {
+	values := make([]interface{}, len(index.Fields))
+	isNull := false
+	for i, field := range index.Fields {
+		value, _ := loadField(field, doc)
+		if value == nil {
+			isNull = true
+		}
+		values[i] = value
+	}
+
+	data, err := json.Marshal(values)
+	if err != nil {
+		return "", false, err
+	}
+	var normalised interface{}
+	if err := json.Unmarshal(data, &normalised); err != nil {
+		return "", false, err
+	}
+	data, err = json.Marshal(normalised)
+	if err != nil {
+		return "", false, err
+	}
+	return string(data), isNull, nil
+}
+
+func getIndexEntryPrefix(col, name, values string) []byte {
+	return []byte(fmt.Sprintf("%s/%s/%s/", col, name, values))
+}
+
+func isSameIndex(a, b *model.EmbeddedIndex) bool {
+	if a.IsUnique != b.IsUnique || len(a.Fields) != len(b.Fields) {
+		return false
+	}
+	for i := range a.Fields {
+		if a.Fields[i] != b.Fields[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func isScalar(value interface{}) bool {
+	switch value.(type) {
+	case string, bool, int, int32, int64, float32, float64:
+		return true
+	}
+	return false
+}
+
+func deleteKeysWithPrefix(bucket *bbolt.Bucket, prefix []byte) error {
+	if bucket == nil {
+		return nil
+	}
+
+	// Collect the keys first since deleting keys while iterating over a cursor skips keys
+	keys := make([][]byte, 0)
+	cursor := bucket.Cursor()
+	for k, _ := cursor.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, _ = cursor.Next() {
+		keys = append(keys, append([]byte{}, k...))
+	}
+	for _, k := range keys {
+		if err := bucket.Delete(k); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package bolt
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"reflect"
+	"testing"
+
+	"go.etcd.io/bbolt"
+
+	"github.com/spaceuptech/space-cloud/gateway/model"
+	"github.com/spaceuptech/space-cloud/gateway/utils"
+)
+
+func TestBolt_Indexes(t *testing.T) {
+	b, err := Init(true, "index.db", "bucketName")
+	if err != nil {
+		t.Fatal("error initializing database")
+	}
+	defer func() {
+		utils.CloseTheCloser(b)
+		if err := os.Remove("index.db"); err != nil {
+			t.Error("error removing database file")
+		}
+	}()
+
+	ctx := context.Background()
+	if err := createDatabaseWithTestData(b); err != nil {
+		t.Fatal("error test data cannot be created for executing index test", err)
+	}
+
+	syncIndexes := func(indexes ...*model.EmbeddedIndex) error {
+		data, _ := json.Marshal(&model.EmbeddedIndexes{Table: "project_details", Indexes: indexes})
+		return b.RawBatch(ctx, []string{string(data)})
+	}
+	getIDs := func(find map[string]interface{}) ([]string, bool) {
+		var ids []string
+		var isIndexed bool
+		_ = b.client.View(func(tx *bbolt.Tx) error {
+			ids, isIndexed, err = b.findIndexedIDs(tx, "project_details", find)
+			return err
+		})
+		return ids, isIndexed
+	}
+
+	// A unique index can't be created if the existing documents violate it
+	if err := syncIndexes(&model.EmbeddedIndex{Name: "index__project_details__team", IsUnique: true, Fields: []string{"team"}}); err == nil {
+		t.Error("RawBatch() expected an error when existing documents violate the unique index")
+	}
+
+	if err := syncIndexes(
+		&model.EmbeddedIndex{Name: "index__project_details__name", IsUnique: true, Fields: []string{"name"}},
+		&model.EmbeddedIndex{Name: "index__project_details__team", Fields: []string{"team", "isPrimary"}},
+	); err != nil {
+		t.Fatalf("RawBatch() error = %v", err)
+	}
+
+	// Existing documents must be indexed
+	if ids, isIndexed := getIDs(map[string]interface{}{"team": "admin", "isPrimary": true}); !isIndexed || !reflect.DeepEqual(ids, []string{"2", "3", "4"}) {
+		t.Errorf("findIndexedIDs() got = %v %v, want [2 3 4] true", ids, isIndexed)
+	}
+	if _, isIndexed := getIDs(map[string]interface{}{"team": "admin"}); isIndexed {
+		t.Error("findIndexedIDs() index must not be used when the find clause doesn't cover all its fields")
+	}
+
+	// Unique indexes must be enforced on create and update
+	if _, err := b.Create(ctx, "project_details", &model.CreateRequest{Operation: utils.One, Document: map[string]interface{}{"_id": "5", "name": "sharad"}}); err == nil {
+		t.Error("Create() expected an error on violating the unique index")
+	}
+	if _, err := b.Update(ctx, "project_details", &model.UpdateRequest{Operation: utils.All, Find: map[string]interface{}{"_id": "2"}, Update: map[string]interface{}{"$set": map[string]interface{}{"name": "ali"}}}); err == nil {
+		t.Error("Update() expected an error on violating the unique index")
+	}
+
+	// Index entries must follow the updates and deletes of documents
+	if _, err := b.Update(ctx, "project_details", &model.UpdateRequest{Operation: utils.All, Find: map[string]interface{}{"_id": "2"}, Update: map[string]interface{}{"$set": map[string]interface{}{"name": "jayesh2"}}}); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if ids, _ := getIDs(map[string]interface{}{"name": "jayesh2"}); !reflect.DeepEqual(ids, []string{"2"}) {
+		t.Errorf("findIndexedIDs() got = %v, want [2]", ids)
+	}
+	if ids, _ := getIDs(map[string]interface{}{"name": "jayesh"}); len(ids) != 0 {
+		t.Errorf("findIndexedIDs() got = %v, want no ids", ids)
+	}
+	if _, err := b.Delete(ctx, "project_details", &model.DeleteRequest{Operation: utils.All, Find: map[string]interface{}{"name": "jayesh2"}}); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if ids, _ := getIDs(map[string]interface{}{"team": "admin", "isPrimary": true}); !reflect.DeepEqual(ids, []string{"3", "4"}) {
+		t.Errorf("findIndexedIDs() got = %v, want [3 4]", ids)
+	}
+
+	// Reads must return the same result with an index
+	_, got, _, _, err := b.Read(ctx, "project_details", &model.ReadRequest{Operation: utils.One, Find: map[string]interface{}{"name": "ali"}, Options: &model.ReadOptions{Select: map[string]int32{"_id": 1}}})
+	if err != nil || !reflect.DeepEqual(got, map[string]interface{}{"_id": "4"}) {
+		t.Errorf("Read() got = %v, error = %v", got, err)
+	}
+
+	// Dropping an index removes its entries
+	if err := syncIndexes(&model.EmbeddedIndex{Name: "index__project_details__name", IsUnique: true, Fields: []string{"name"}}); err != nil {
+		t.Fatalf("RawBatch() error = %v", err)
+	}
+	if _, isIndexed := getIDs(map[string]interface{}{"team": "admin", "isPrimary": true}); isIndexed {
+		t.Error("findIndexedIDs() dropped index must not be used")
+	}
+}
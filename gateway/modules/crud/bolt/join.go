@@ -0,0 +1,181 @@
+package bolt
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spaceuptech/helpers"
+	"go.etcd.io/bbolt"
+
+	"github.com/spaceuptech/space-cloud/gateway/model"
+	"github.com/spaceuptech/space-cloud/gateway/utils"
+)
+
+// joiner performs joins between the collections of the embedded database. The rows of the joint
+// tables get nested inside the parent document just like the result of a join in sql databases
+type joiner struct {
+	bolt       *Bolt
+	tx         *bbolt.Tx
+	cache      map[string][]map[string]interface{}
+	wheres     map[string][]map[string]interface{}
+	sort       map[string][]string
+	selections map[string]map[string]int32
+}
+
+// join attaches the matching rows of the joint tables to each document. Documents without
+// a matching row are dropped in case of an inner join
+func (j *joiner) join(ctx context.Context, table string, docs []map[string]interface{}, joins []*model.JoinOption) ([]map[string]interface{}, error) {
+	return j.joinWithParents(ctx, table, docs, joins, map[string]map[string]interface{}{})
+}
+
+func (j *joiner) joinWithParents(ctx context.Context, table string, docs []map[string]interface{}, joins []*model.JoinOption, parents map[string]map[string]interface{}) ([]map[string]interface{}, error) {
+	result := make([]map[string]interface{}, 0, len(docs))
+	for _, doc := range docs {
+		// Copy the document so that the cached rows of the joint tables don't get modified
+		newDoc := make(map[string]interface{}, len(doc)+len(joins))
+		for k, v := range doc {
+			newDoc[k] = v
+		}
+
+		rowContext := make(map[string]map[string]interface{}, len(parents)+1)
+		for k, v := range parents {
+			rowContext[k] = v
+		}
+		rowContext[table] = doc
+
+		isDropped := false
+		for _, joinOption := range joins {
+			switch strings.ToUpper(joinOption.Type) {
+			case "", "LEFT", "INNER":
+			default:
+				return nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Join type (%s) is not supported by embedded database", joinOption.Type), nil, nil)
+			}
+
+			matches, err := j.getMatchingRows(ctx, joinOption, rowContext)
+			if err != nil {
+				return nil, err
+			}
+			if len(matches) == 0 {
+				if strings.ToUpper(joinOption.Type) == "INNER" {
+					isDropped = true
+					break
+				}
+				continue
+			}
+
+			if joinOption.Op == utils.One {
+				newDoc[getJoinName(joinOption)] = matches[0]
+				continue
+			}
+			newDoc[getJoinName(joinOption)] = toInterfaceArray(matches)
+		}
+		if !isDropped {
+			result = append(result, newDoc)
+		}
+	}
+	return result, nil
+}
+
+// getMatchingRows returns the rows of the joint table which satisfy the on clause of the join
+func (j *joiner) getMatchingRows(ctx context.Context, joinOption *model.JoinOption, rowContext map[string]map[string]interface{}) ([]map[string]interface{}, error) {
+	rows, ok := j.cache[joinOption.Table]
+	if !ok {
+		docs, err := j.bolt.scanCollection(j.tx, joinOption.Table)
+		if err != nil {
+			return nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Unable to read joint table (%s) from bbolt db", joinOption.Table), err, nil)
+		}
+		for _, where := range j.wheres[joinOption.Table] {
+			docs = filterDocs(docs, where)
+		}
+		rows = docs
+		j.cache[joinOption.Table] = rows
+	}
+
+	matches := make([]map[string]interface{}, 0)
+	for _, row := range rows {
+		if j.isMatch(joinOption, rowContext, row) {
+			matches = append(matches, row)
+		}
+	}
+
+	if len(joinOption.Join) > 0 {
+		var err error
+		matches, err = j.joinWithParents(ctx, joinOption.Table, matches, joinOption.Join, rowContext)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	sortDocs(matches, j.sort[joinOption.Table])
+	for i, match := range matches {
+		matches[i] = projectDocWithJoins(match, j.selections[joinOption.Table], joinOption.Join)
+	}
+	return matches, nil
+}
+
+// isMatch checks if a row of the joint table satisfies the on clause. The value of each key of the on clause
+// can either refer to a column of the form `table.column`, an object of operators or a constant
+func (j *joiner) isMatch(joinOption *model.JoinOption, rowContext map[string]map[string]interface{}, row map[string]interface{}) bool {
+	for k, v := range joinOption.On {
+		lhs, ok := j.resolveField(joinOption.Table, k, rowContext, row)
+		if !ok {
+			return false
+		}
+
+		conditions, ok := v.(map[string]interface{})
+		if !ok {
+			conditions = map[string]interface{}{"$eq": v}
+		}
+		for op, value := range conditions {
+			if field, ok := value.(string); ok {
+				if rhs, ok := j.resolveField(joinOption.Table, field, rowContext, row); ok {
+					value = rhs
+				}
+			}
+			if !compareWithOperator(op, lhs, value) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// resolveField returns the value of a field of the form `table.column` from the current row of that table
+func (j *joiner) resolveField(joinTable, field string, rowContext map[string]map[string]interface{}, row map[string]interface{}) (interface{}, bool) {
+	arr := strings.SplitN(field, ".", 2)
+	if len(arr) != 2 {
+		return nil, false
+	}
+	if arr[0] == joinTable {
+		return loadField(arr[1], row)
+	}
+	doc, ok := rowContext[arr[0]]
+	if !ok {
+		return nil, false
+	}
+	return loadField(arr[1], doc)
+}
+
+// compareWithOperator compares two values with a comparison operator. Null values never match
+func compareWithOperator(op string, v1, v2 interface{}) bool {
+	if v1 == nil || v2 == nil {
+		return false
+	}
+	c := compareValues(v1, v2)
+	switch op {
+	case "$eq":
+		return c == 0
+	case "$ne":
+		return c != 0
+	case "$gt":
+		return c > 0
+	case "$gte":
+		return c >= 0
+	case "$lt":
+		return c < 0
+	case "$lte":
+		return c <= 0
+	}
+	return false
+}
@@ -2,9 +2,11 @@ package bolt
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 
 	"github.com/spaceuptech/helpers"
+	"go.etcd.io/bbolt"
 
 	"github.com/spaceuptech/space-cloud/gateway/model"
 )
@@ -19,10 +21,22 @@ func (b *Bolt) CreateDatabaseIfNotExist(ctx context.Context, project string) err
 	return helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to create database operation cannot be performed over selected database", nil, nil)
 }
 
-// RawBatch performs a batch operation for schema creation
+// RawBatch performs a batch operation for schema creation. Each query is a json encoded
+// model.EmbeddedIndexes object describing the secondary indexes of a collection
 // NOTE: not to be exposed externally
 func (b *Bolt) RawBatch(ctx context.Context, batchedQueries []string) error {
-	return helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to create raw batch operation cannot be performed over selected database", nil, nil)
+	return b.client.Update(func(tx *bbolt.Tx) error {
+		for _, query := range batchedQueries {
+			req := new(model.EmbeddedIndexes)
+			if err := json.Unmarshal([]byte(query), req); err != nil {
+				return helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to parse raw batch query for embedded database", err, nil)
+			}
+			if err := b.syncIndexes(ctx, tx, req); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
 }
 
 // GetConnectionState : function to check connection state
@@ -1,10 +1,10 @@
 package bolt
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/spaceuptech/helpers"
@@ -14,6 +14,7 @@ import (
 	"github.com/spaceuptech/space-cloud/gateway/utils"
 )
 
+// Read queries document(s) from the database
 func (b *Bolt) Read(ctx context.Context, col string, req *model.ReadRequest) (int64, interface{}, map[string]map[string]string, *model.SQLMetaData, error) {
 	if req.Options == nil {
 		req.Options = &model.ReadOptions{}
@@ -22,68 +23,310 @@ func (b *Bolt) Read(ctx context.Context, col string, req *model.ReadRequest) (in
 		req.Options.Limit = b.queryFetchLimit
 		req.Options.HasOptions = true
 	}
-	switch req.Operation {
-	case utils.All, utils.One:
-		var count int64
-		results := []interface{}{}
-		if err := b.client.View(func(tx *bbolt.Tx) error {
-			// Assume bucket exists and has keys
-			bucket := tx.Bucket([]byte(b.bucketName))
-			if bucket == nil {
-				return nil
+
+	var count int64
+	var result interface{}
+	if err := b.client.View(func(tx *bbolt.Tx) error {
+		joinTables := getJoinTables(req.Options.Join)
+
+		// Separate the where clauses of the joint tables from the where clause of the collection
+		find, joinWheres := splitWhereClause(col, joinTables, req.Find)
+		docs, err := b.findDocs(tx, col, find)
+		if err != nil {
+			return helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to read documents from bbolt db", err, nil)
+		}
+		for _, where := range req.MatchWhere {
+			matchFind, matchJoinWheres := splitWhereClause(col, joinTables, where)
+			docs = filterDocs(docs, matchFind)
+			for table, wheres := range matchJoinWheres {
+				joinWheres[table] = append(joinWheres[table], wheres...)
+			}
+		}
+
+		switch req.Operation {
+		case utils.Count:
+			count = int64(len(docs))
+			result = count
+			return nil
+
+		case utils.Distinct:
+			if req.Options.Distinct == nil {
+				return utils.ErrInvalidParams
 			}
+			distinctDocs := getDistinctDocs(*req.Options.Distinct, docs)
+			sortDocs(distinctDocs, req.Options.Sort)
+			distinctDocs = paginate(distinctDocs, req.Options.Skip, req.Options.Limit)
+			count = int64(len(distinctDocs))
+			result = toInterfaceArray(distinctDocs)
+			return nil
 
-			cursor := bucket.Cursor()
-			prefix := []byte(col + "/")
-			for k, v := cursor.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, v = cursor.Next() {
-				result := map[string]interface{}{}
-				if err := json.Unmarshal(v, &result); err != nil {
-					return helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to unmarshal while reading from bbolt db", err, nil)
+		case utils.All, utils.One:
+			if len(req.Aggregate) > 0 || len(req.GroupBy) > 0 {
+				if len(req.Options.Join) > 0 {
+					return helpers.Logger.LogError(helpers.GetRequestID(ctx), "Aggregations cannot be performed along with joins in embedded database", nil, nil)
 				}
-				if utils.Validate(string(model.EmbeddedDB), req.Find, result) {
-					if req.Options.Debug {
-						result["_dbFetchTs"] = time.Now().Format(time.RFC3339Nano)
-					}
+				docs, err = aggregateDocs(ctx, docs, req.GroupBy, req.Aggregate)
+				if err != nil {
+					return err
+				}
+			}
 
-					results = append(results, result)
-					count++
-					if req.Operation == utils.One {
-						break
-					}
+			// Sort the documents of the collection. Sort fields of the joint tables are applied while joining
+			sortFields, joinSortFields := splitFields(col, joinTables, req.Options.Sort)
+			if len(req.Aggregate) > 0 {
+				sortFields = getAggregateSortFields(sortFields, req.GroupBy, req.Aggregate)
+			}
+			selection, joinSelections := splitSelection(col, joinTables, req.Options.Select)
+			if len(req.Options.Join) > 0 {
+				j := &joiner{bolt: b, tx: tx, cache: map[string][]map[string]interface{}{}, wheres: joinWheres, sort: joinSortFields, selections: joinSelections}
+				docs, err = j.join(ctx, col, docs, req.Options.Join)
+				if err != nil {
+					return err
 				}
 			}
-			return nil
-		}); err != nil {
-			return 0, nil, nil, nil, err
-		}
-		if req.Operation == utils.One {
-			if count == 0 {
-				return 0, nil, nil, nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), "No match found for specified find clause", nil, nil)
+
+			sortDocs(docs, sortFields)
+			docs = paginate(docs, req.Options.Skip, req.Options.Limit)
+
+			for i, doc := range docs {
+				docs[i] = projectDocWithJoins(doc, selection, req.Options.Join)
 			}
-			if count == 1 {
-				return count, results[0], nil, nil, nil
+			if req.Options.ReturnType == "table" && len(req.Options.Join) > 0 {
+				docs = flattenJoins(col, docs, req.Options.Join)
 			}
-		}
 
-		return count, results, nil, nil, nil
-	case utils.Count:
-		var count int64
-		err := b.client.View(func(tx *bbolt.Tx) error {
-			// Assume bucket exists and has keys
-			bucket := tx.Bucket([]byte(b.bucketName))
-			if bucket == nil {
-				return nil
+			if req.Options.Debug {
+				for _, doc := range docs {
+					doc["_dbFetchTs"] = time.Now().Format(time.RFC3339Nano)
+				}
 			}
-			// not nil means value exists
-			if bucket.Get([]byte(fmt.Sprintf("%s/%s", col, req.Find["_id"]))) != nil {
+
+			if req.Operation == utils.One {
+				if len(docs) == 0 {
+					return helpers.Logger.LogError(helpers.GetRequestID(ctx), "No match found for specified find clause", nil, nil)
+				}
 				count = 1
+				result = docs[0]
+				return nil
 			}
 
+			count = int64(len(docs))
+			result = toInterfaceArray(docs)
 			return nil
-		})
-		return count, nil, nil, nil, err
 
-	default:
-		return 0, nil, nil, nil, utils.ErrInvalidParams
+		default:
+			return utils.ErrInvalidParams
+		}
+	}); err != nil {
+		return 0, nil, nil, nil, err
+	}
+
+	return count, result, nil, nil, nil
+}
+
+// getDistinctDocs returns the unique values of a field in the same format as the other databases
+func getDistinctDocs(field string, docs []map[string]interface{}) []map[string]interface{} {
+	seen := map[string]bool{}
+	distinctDocs := make([]map[string]interface{}, 0)
+	for _, doc := range docs {
+		value, ok := loadField(field, doc)
+		if !ok {
+			continue
+		}
+		data, _ := json.Marshal(value)
+		if seen[string(data)] {
+			continue
+		}
+		seen[string(data)] = true
+		distinctDocs = append(distinctDocs, map[string]interface{}{field: value})
+	}
+	return distinctDocs
+}
+
+// getJoinTables returns the names of all the tables used in the joins
+func getJoinTables(joins []*model.JoinOption) map[string]bool {
+	tables := map[string]bool{}
+	for _, j := range joins {
+		tables[j.Table] = true
+		for table := range getJoinTables(j.Join) {
+			tables[table] = true
+		}
+	}
+	return tables
+}
+
+// splitTableField splits a field of the form `table.column`. The table is empty if the field doesn't belong to any of the tables
+func splitTableField(col string, joinTables map[string]bool, field string) (string, string) {
+	arr := strings.SplitN(field, ".", 2)
+	if len(arr) == 2 && (arr[0] == col || joinTables[arr[0]]) {
+		return arr[0], arr[1]
+	}
+	return "", field
+}
+
+// splitWhereClause separates the where clauses of the joint tables from the where clause of the collection
+func splitWhereClause(col string, joinTables map[string]bool, find map[string]interface{}) (map[string]interface{}, map[string][]map[string]interface{}) {
+	colFind := make(map[string]interface{}, len(find))
+	joinWheres := map[string][]map[string]interface{}{}
+	for k, v := range find {
+		if strings.HasPrefix(k, "$or") {
+			colFind[k] = stripTablePrefix(col, v)
+			continue
+		}
+
+		table, field := splitTableField(col, joinTables, k)
+		if table == "" || table == col {
+			colFind[field] = v
+			continue
+		}
+		joinWheres[table] = append(joinWheres[table], map[string]interface{}{field: v})
+	}
+	return colFind, joinWheres
+}
+
+// stripTablePrefix removes the name of the collection from the fields used inside an or clause
+func stripTablePrefix(col string, value interface{}) interface{} {
+	clauses, ok := value.([]interface{})
+	if !ok {
+		return value
+	}
+	stripped := make([]interface{}, len(clauses))
+	for i, clause := range clauses {
+		obj, ok := clause.(map[string]interface{})
+		if !ok {
+			stripped[i] = clause
+			continue
+		}
+		newObj := make(map[string]interface{}, len(obj))
+		for k, v := range obj {
+			if strings.HasPrefix(k, "$or") {
+				newObj[k] = stripTablePrefix(col, v)
+				continue
+			}
+			newObj[strings.TrimPrefix(k, col+".")] = v
+		}
+		stripped[i] = newObj
+	}
+	return stripped
+}
+
+// splitFields separates the sort fields of the joint tables from the ones of the collection
+func splitFields(col string, joinTables map[string]bool, fields []string) ([]string, map[string][]string) {
+	colFields := make([]string, 0, len(fields))
+	joinFields := map[string][]string{}
+	for _, f := range fields {
+		prefix := ""
+		if strings.HasPrefix(f, "-") {
+			prefix = "-"
+		}
+		table, field := splitTableField(col, joinTables, strings.TrimPrefix(f, "-"))
+		if table == "" || table == col {
+			colFields = append(colFields, prefix+field)
+			continue
+		}
+		joinFields[table] = append(joinFields[table], prefix+field)
+	}
+	return colFields, joinFields
+}
+
+// splitSelection separates the selected fields of the joint tables from the ones of the collection
+func splitSelection(col string, joinTables map[string]bool, selection map[string]int32) (map[string]int32, map[string]map[string]int32) {
+	colSelection := make(map[string]int32, len(selection))
+	joinSelections := map[string]map[string]int32{}
+	for k, v := range selection {
+		if k == "_dbFetchTs" {
+			continue
+		}
+		table, field := splitTableField(col, joinTables, k)
+		if table == "" || table == col {
+			colSelection[field] = v
+			continue
+		}
+		if _, ok := joinSelections[table]; !ok {
+			joinSelections[table] = map[string]int32{}
+		}
+		joinSelections[table][field] = v
+	}
+	return colSelection, joinSelections
+}
+
+// projectDocWithJoins projects a document while retaining the results of the joins
+func projectDocWithJoins(doc map[string]interface{}, selection map[string]int32, joins []*model.JoinOption) map[string]interface{} {
+	projected := projectDoc(doc, selection)
+	if len(selection) == 0 {
+		return projected
+	}
+	for _, j := range joins {
+		name := getJoinName(j)
+		if value, ok := doc[name]; ok {
+			projected[name] = value
+		}
+	}
+	return projected
+}
+
+func getJoinName(j *model.JoinOption) string {
+	if j.As != "" {
+		return j.As
+	}
+	return j.Table
+}
+
+// flattenJoins converts the nested result of the joins into flat rows with fields of the form `table__column`
+func flattenJoins(col string, docs []map[string]interface{}, joins []*model.JoinOption) []map[string]interface{} {
+	rows := make([]map[string]interface{}, 0, len(docs))
+	for _, doc := range docs {
+		rows = append(rows, flattenDoc(col, doc, joins)...)
+	}
+	return rows
+}
+
+func flattenDoc(table string, doc map[string]interface{}, joins []*model.JoinOption) []map[string]interface{} {
+	base := map[string]interface{}{}
+	joinNames := map[string]bool{}
+	for _, j := range joins {
+		joinNames[getJoinName(j)] = true
+	}
+	for k, v := range doc {
+		if !joinNames[k] {
+			base[fmt.Sprintf("%s__%s", table, k)] = v
+		}
+	}
+
+	rows := []map[string]interface{}{base}
+	for _, j := range joins {
+		var children []map[string]interface{}
+		switch v := doc[getJoinName(j)].(type) {
+		case map[string]interface{}:
+			children = []map[string]interface{}{v}
+		case []interface{}:
+			for _, child := range v {
+				if obj, ok := child.(map[string]interface{}); ok {
+					children = append(children, obj)
+				}
+			}
+		}
+		if len(children) == 0 {
+			continue
+		}
+
+		// Generate a row for every combination of the current rows and the rows of the joint table
+		newRows := make([]map[string]interface{}, 0, len(rows)*len(children))
+		for _, row := range rows {
+			for _, child := range children {
+				for _, childRow := range flattenDoc(j.Table, child, j.Join) {
+					newRow := make(map[string]interface{}, len(row)+len(childRow))
+					for k, v := range row {
+						newRow[k] = v
+					}
+					for k, v := range childRow {
+						newRow[k] = v
+					}
+					newRows = append(newRows, newRow)
+				}
+			}
+		}
+		rows = newRows
 	}
+	return rows
 }
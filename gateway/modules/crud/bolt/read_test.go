@@ -154,3 +154,178 @@ func removeTs(result interface{}) {
 		delete(obj, "_dbFetchTs")
 	}
 }
+
+func TestBolt_ReadOptions(t *testing.T) {
+	limit, skip := int64(2), int64(1)
+	distinct := "team"
+	tests := []struct {
+		name    string
+		col     string
+		req     *model.ReadRequest
+		want    int64
+		want1   interface{}
+		wantErr bool
+	}{
+		{
+			name: "read with sort, skip, limit and select",
+			col:  "project_details",
+			req: &model.ReadRequest{
+				Find:      map[string]interface{}{"isPrimary": true},
+				Operation: utils.All,
+				Options: &model.ReadOptions{
+					Sort:   []string{"-project_count"},
+					Skip:   &skip,
+					Limit:  &limit,
+					Select: map[string]int32{"name": 1, "project_count": 1},
+				},
+			},
+			want: 2,
+			want1: []interface{}{
+				map[string]interface{}{"name": "noorain", "project_count": float64(52)},
+				map[string]interface{}{"name": "jayesh", "project_count": float64(10)},
+			},
+		},
+		{
+			name: "read with an or clause along with other fields",
+			col:  "project_details",
+			req: &model.ReadRequest{
+				Find: map[string]interface{}{
+					"team": "admin",
+					"$or":  []interface{}{map[string]interface{}{"name": "sharad"}, map[string]interface{}{"name": "ali"}},
+				},
+				Operation: utils.All,
+				Options:   &model.ReadOptions{Sort: []string{"name"}, Select: map[string]int32{"name": 1}},
+			},
+			want: 2,
+			want1: []interface{}{
+				map[string]interface{}{"name": "ali"},
+				map[string]interface{}{"name": "sharad"},
+			},
+		},
+		{
+			name: "read distinct values",
+			col:  "project_details",
+			req: &model.ReadRequest{
+				Find:      map[string]interface{}{},
+				Operation: utils.Distinct,
+				Options:   &model.ReadOptions{Distinct: &distinct},
+			},
+			want:  1,
+			want1: []interface{}{map[string]interface{}{"team": "admin"}},
+		},
+		{
+			name: "count documents",
+			col:  "project_details",
+			req: &model.ReadRequest{
+				Find:      map[string]interface{}{"project_count": map[string]interface{}{"$gte": 50}},
+				Operation: utils.Count,
+			},
+			want:  2,
+			want1: int64(2),
+		},
+		{
+			name: "group by along with aggregate functions sorted on an aggregated column",
+			col:  "project_details",
+			req: &model.ReadRequest{
+				Find:      map[string]interface{}{},
+				Operation: utils.All,
+				GroupBy:   []interface{}{"isPrimary"},
+				Aggregate: map[string][]string{
+					"sum":   {"project_count:project_count"},
+					"count": {"count:"},
+				},
+				Options: &model.ReadOptions{Sort: []string{"-project_count"}},
+			},
+			want: 2,
+			want1: []interface{}{
+				map[string]interface{}{"isPrimary": true, "aggregate": map[string]interface{}{"sum": map[string]interface{}{"project_count": float64(162)}, "count": int64(3)}},
+				map[string]interface{}{"isPrimary": false, "aggregate": map[string]interface{}{"sum": map[string]interface{}{"project_count": float64(15)}, "count": int64(1)}},
+			},
+		},
+		{
+			name: "inner join with a nested result",
+			col:  "project_details",
+			req: &model.ReadRequest{
+				Find:      map[string]interface{}{"project_details.project_count": map[string]interface{}{"$lt": 50}, "teams.region": "asia"},
+				Operation: utils.All,
+				Options: &model.ReadOptions{
+					Sort:   []string{"project_details.name"},
+					Select: map[string]int32{"project_details.name": 1, "teams.region": 1},
+					Join: []*model.JoinOption{
+						{Type: "INNER", Table: "teams", Op: utils.One, On: map[string]interface{}{"teams.name": "project_details.team"}},
+					},
+				},
+			},
+			want: 2,
+			want1: []interface{}{
+				map[string]interface{}{"name": "jayesh", "teams": map[string]interface{}{"region": "asia"}},
+				map[string]interface{}{"name": "sharad", "teams": map[string]interface{}{"region": "asia"}},
+			},
+		},
+		{
+			name: "left join with a flat result",
+			col:  "project_details",
+			req: &model.ReadRequest{
+				Find:      map[string]interface{}{"_id": "4"},
+				Operation: utils.All,
+				Options: &model.ReadOptions{
+					ReturnType: "table",
+					Select:     map[string]int32{"project_details.name": 1, "teams.region": 1},
+					Join: []*model.JoinOption{
+						{Type: "LEFT", Table: "teams", On: map[string]interface{}{"teams.name": "project_details.team"}},
+					},
+				},
+			},
+			want: 1,
+			want1: []interface{}{
+				map[string]interface{}{"project_details__name": "ali", "teams__region": "asia"},
+			},
+		},
+		{
+			name: "unsupported join type",
+			col:  "project_details",
+			req: &model.ReadRequest{
+				Find:      map[string]interface{}{},
+				Operation: utils.All,
+				Options: &model.ReadOptions{
+					Join: []*model.JoinOption{
+						{Type: "RIGHT", Table: "teams", On: map[string]interface{}{"teams.name": "project_details.team"}},
+					},
+				},
+			},
+			wantErr: true,
+		},
+	}
+
+	b, err := Init(true, "read_options.db", "bucketName")
+	if err != nil {
+		t.Fatal("error initializing database")
+	}
+
+	if err := createDatabaseWithTestData(b); err != nil {
+		t.Fatal("error test data cannot be created for executing read test", err)
+	}
+	if _, err := b.Create(context.Background(), "teams", &model.CreateRequest{Operation: utils.One, Document: map[string]interface{}{"_id": "1", "name": "admin", "region": "asia"}}); err != nil {
+		t.Fatal("error test data cannot be created for executing read test", err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, got1, _, _, err := b.Read(context.Background(), tt.col, tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Read() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Read() got = %v, want %v", got, tt.want)
+			}
+			if !reflect.DeepEqual(got1, tt.want1) {
+				t.Errorf("Read() got1 = %v, want %v", got1, tt.want1)
+			}
+		})
+	}
+	utils.CloseTheCloser(b)
+	if err := os.Remove("read_options.db"); err != nil {
+		t.Error("error removing database file")
+	}
+}
@@ -1,7 +1,6 @@
 package bolt
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -21,40 +20,55 @@ func (b *Bolt) Update(ctx context.Context, col string, req *model.UpdateRequest)
 		if err := b.client.Update(func(tx *bbolt.Tx) error {
 			// Assume bucket exists and has keys
 			bucket := tx.Bucket([]byte(b.bucketName))
-			c := bucket.Cursor()
+			if bucket == nil {
+				return nil
+			}
+
+			indexes, err := b.getIndexes(tx, col)
+			if err != nil {
+				return helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to load indexes from bbolt db", err, nil)
+			}
 
-			// get all keys matching the prefix
-			prefix := []byte(col + "/")
-			for k, v := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, v = c.Next() {
-				currentObj := map[string]interface{}{}
-				if err := json.Unmarshal(v, &currentObj); err != nil {
-					return helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to unmarshal data read from bbbolt db", err, nil)
+			docs, err := b.findDocs(tx, col, req.Find)
+			if err != nil {
+				return helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to unmarshal data read from bbbolt db", err, nil)
+			}
+			for _, currentObj := range docs {
+				objToSet, ok := req.Update["$set"].(map[string]interface{})
+				if !ok {
+					return helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to update in bbolt - $set db operator not found or the operator value is not map", nil, nil)
 				}
-				// if valid then update
-				if utils.Validate(string(model.EmbeddedDB), req.Find, currentObj) {
-					objToSet, ok := req.Update["$set"].(map[string]interface{})
-					if !ok {
-						return helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to update in bbolt - $set db operator not found or the operator value is not map", nil, nil)
-					}
 
-					for objToSetKey, objToSetValue := range objToSet {
-						currentObj[objToSetKey] = objToSetValue
-					}
-					value, err := json.Marshal(&currentObj)
-					if err != nil {
-						return helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to unmarshal data updated from bbbolt db", err, nil)
-					}
+				if err := b.removeIndexEntries(ctx, tx, col, indexes, currentObj); err != nil {
+					return err
+				}
 
-					// over ride the data
-					if err = bucket.Put(k, value); err != nil {
-						return err
-					}
-					count++
+				for objToSetKey, objToSetValue := range objToSet {
+					currentObj[objToSetKey] = objToSetValue
+				}
+				value, err := json.Marshal(&currentObj)
+				if err != nil {
+					return helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to unmarshal data updated from bbbolt db", err, nil)
+				}
+
+				// update the secondary indexes using the stored form of the document
+				storedDoc := map[string]interface{}{}
+				if err := json.Unmarshal(value, &storedDoc); err != nil {
+					return helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to unmarshal data updated from bbbolt db", err, nil)
+				}
+				if err := b.addIndexEntries(ctx, tx, col, indexes, storedDoc); err != nil {
+					return err
+				}
+
+				// over ride the data
+				if err = bucket.Put([]byte(fmt.Sprintf("%s/%s", col, currentObj["_id"])), value); err != nil {
+					return err
+				}
+				count++
 
-					if req.Operation == utils.One {
-						// exit the loop
-						break
-					}
+				if req.Operation == utils.One {
+					// exit the loop
+					break
 				}
 			}
 			return nil
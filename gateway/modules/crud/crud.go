@@ -3,6 +3,7 @@ package crud
 import (
 	"context"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
@@ -85,7 +86,7 @@ func (m *Module) initBlock(dbType model.DBType, enabled bool, connection, dbName
 		return mgo.Init(enabled, connection, dbName, driverConf)
 	case model.EmbeddedDB:
 		return bolt.Init(enabled, connection, dbName)
-	case model.MySQL, model.Postgres, model.SQLServer:
+	case model.MySQL, model.Postgres, model.SQLServer, model.SQLite:
 		c, err := sql.Init(dbType, enabled, connection, dbName, driverConf)
 		if err == nil && enabled {
 			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -103,6 +104,62 @@ func (m *Module) initBlock(dbType model.DBType, enabled bool, connection, dbName
 	}
 }
 
+// CheckConnection checks if a connection can be established with the provided database config.
+// Unlike SetConfig, the database is neither created nor added to the module
+func (m *Module) CheckConnection(ctx context.Context, dbConfig *config.DatabaseConfig) error {
+	if !dbConfig.Enabled {
+		return nil
+	}
+
+	// The lock isn't held while fetching secrets and dialing the database since both can take a while
+	m.RLock()
+	project, getSecrets := m.project, m.getSecrets
+	m.RUnlock()
+
+	connectionString := dbConfig.Conn
+	if secretName, isSecretExists := splitConnectionString(dbConfig.Conn); isSecretExists {
+		if getSecrets == nil {
+			return helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to fetch connection string secret from runner", nil, map[string]interface{}{"project": project})
+		}
+		var err error
+		connectionString, err = getSecrets(project, secretName, "CONN")
+		if err != nil {
+			return helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to fetch connection string secret from runner", err, map[string]interface{}{"project": project})
+		}
+	}
+
+	dbName := dbConfig.DBName
+	if dbName == "" {
+		dbName = project
+	}
+
+	dbType := dbConfig.Type
+	if dbType == "" {
+		dbType = dbConfig.DbAlias
+	}
+
+	var c Crud
+	var err error
+	switch dbType := model.DBType(strings.TrimPrefix(dbType, "sql-")); dbType {
+	case model.Mongo:
+		c, err = mgo.Init(true, connectionString, dbName, dbConfig.DriverConf)
+	case model.MySQL, model.Postgres, model.SQLServer:
+		c, err = sql.Init(dbType, true, connectionString, dbName, dbConfig.DriverConf)
+	case model.EmbeddedDB, model.SQLite:
+		// File based databases get created on the first connection, so we only check if a path is provided
+		if connectionString == "" {
+			return helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Connection string not provided for database (%s)", dbType), nil, nil)
+		}
+		return nil
+	default:
+		return helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Unsupported database (%s) provided", dbType), nil, nil)
+	}
+	if err != nil {
+		return helpers.Logger.LogError(helpers.GetRequestID(ctx), "Cannot connect to database", err, map[string]interface{}{"dbAlias": dbConfig.DbAlias, "dbType": dbConfig.Type})
+	}
+	return c.Close()
+}
+
 // GetDBType returns the type of the db for the alias provided
 func (m *Module) GetDBType(dbAlias string) (string, error) {
 	m.RLock()
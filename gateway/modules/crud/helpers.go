@@ -3,11 +3,15 @@ package crud
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/spaceuptech/helpers"
 
 	"github.com/spaceuptech/space-cloud/gateway/config"
+	"github.com/spaceuptech/space-cloud/gateway/model"
+	"github.com/spaceuptech/space-cloud/gateway/utils"
+	"github.com/spaceuptech/space-cloud/gateway/utils/tracing"
 )
 
 func (m *Module) createBatch(ctx context.Context, project, dbAlias, col string, doc interface{}) (int64, error) {
@@ -80,3 +84,141 @@ func (m *Module) getDBType(dbAlias string) (string, error) {
 
 	return string(block.GetDBType()), nil
 }
+
+// addSortTiebreaker appends the primary key of the table to the sort of a read request so that the documents having
+// the same values for the sort keys are always returned in the same order. Cursors are generated from the resulting
+// sort, hence the primary key gets added to the select clause as well. The options of the request are modified in place
+// so that the caller generates the cursors from the same sort
+func (m *Module) addSortTiebreaker(dbAlias, dbType, col string, req *model.ReadRequest) {
+	if req.Options == nil || len(req.Options.Sort) == 0 || req.Operation != utils.All {
+		return
+	}
+	if req.Options.Distinct != nil || len(req.Aggregate) > 0 || len(req.GroupBy) > 0 {
+		return
+	}
+
+	sortFields := make(map[string]struct{}, len(req.Options.Sort))
+	for _, field := range req.Options.Sort {
+		sortFields[strings.TrimPrefix(field, "-")] = struct{}{}
+	}
+
+	sortOrder := append([]string{}, req.Options.Sort...)
+	isJoin := len(req.Options.Join) > 0 && model.DBType(dbType) != model.Mongo
+	for _, key := range m.getPrimaryKeys(dbAlias, dbType, col) {
+		_, p1 := sortFields[key]
+		_, p2 := sortFields[col+"."+key]
+		if p1 || p2 {
+			continue
+		}
+
+		if isJoin {
+			key = col + "." + key
+		}
+		sortOrder = append(sortOrder, key)
+		if len(req.Options.Select) > 0 {
+			req.Options.Select[key] = 1
+		}
+	}
+	req.Options.Sort = sortOrder
+}
+
+// getPrimaryKeys returns the fields making up the primary key of a table in the order they appear in the primary key
+func (m *Module) getPrimaryKeys(dbAlias, dbType, col string) []string {
+	if model.DBType(dbType) == model.Mongo {
+		return []string{"_id"}
+	}
+
+	fields := m.schemaDoc[dbAlias][col]
+	keys := make([]string, 0)
+	for name, field := range fields {
+		if field.IsPrimary {
+			keys = append(keys, name)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := fields[keys[i]].PrimaryKeyInfo, fields[keys[j]].PrimaryKeyInfo
+		if a != nil && b != nil && a.Order != b.Order {
+			return a.Order < b.Order
+		}
+		return keys[i] < keys[j]
+	})
+	return keys
+}
+
+// applyCursor converts the cursor provided in the read options into a where clause on the sort keys. A copy of the
+// request is returned so that the request of the caller remains untouched. The returned boolean is true if the
+// results are read in the reverse order and need to be reversed before returning them
+func applyCursor(ctx context.Context, req *model.ReadRequest) (*model.ReadRequest, bool, error) {
+	if req.Options == nil || (req.Options.After == nil && req.Options.Before == nil) {
+		return req, false, nil
+	}
+
+	switch {
+	case req.Options.After != nil && req.Options.Before != nil:
+		return nil, false, helpers.Logger.LogError(helpers.GetRequestID(ctx), "Cannot provide both after and before cursors in the same read request", nil, nil)
+	case req.Operation != utils.All:
+		return nil, false, helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Cursors can only be used with read operation (%s)", utils.All), nil, nil)
+	case len(req.Options.Sort) == 0:
+		return nil, false, helpers.Logger.LogError(helpers.GetRequestID(ctx), "Sort needs to be provided when using cursors", nil, nil)
+	case req.Options.Skip != nil:
+		return nil, false, helpers.Logger.LogError(helpers.GetRequestID(ctx), "Skip cannot be used along with cursors", nil, nil)
+	case req.Options.Distinct != nil || len(req.Aggregate) > 0 || len(req.GroupBy) > 0:
+		return nil, false, helpers.Logger.LogError(helpers.GetRequestID(ctx), "Cursors cannot be used along with distinct, aggregate or group by", nil, nil)
+	}
+
+	isBefore := req.Options.Before != nil
+	value := req.Options.After
+	if isBefore {
+		value = req.Options.Before
+	}
+	values, err := utils.DecodeCursor(req.Options.Sort, *value)
+	if err != nil {
+		return nil, false, helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to decode cursor provided in read request", err, nil)
+	}
+
+	find := make(map[string]interface{}, len(req.Find)+1)
+	for k, v := range req.Find {
+		find[k] = v
+	}
+	find[utils.CursorClauseKey] = utils.GenerateCursorClause(req.Options.Sort, values, isBefore)
+
+	// Documents before the cursor are read in the reverse order so that the limit picks the closest ones
+	options := *req.Options
+	options.After, options.Before = nil, nil
+	if isBefore {
+		options.Sort = utils.ReverseSort(options.Sort)
+	}
+
+	newReq := *req
+	newReq.Find = find
+	newReq.Options = &options
+	return &newReq, isBefore, nil
+}
+
+// reverseResult returns the documents returned by a read operation in the reverse order. A new array is
+// returned since the original one might be referred to by the cache
+func reverseResult(result interface{}) interface{} {
+	docs, ok := result.([]interface{})
+	if !ok {
+		return result
+	}
+	reversed := make([]interface{}, len(docs))
+	for i, doc := range docs {
+		reversed[len(docs)-1-i] = doc
+	}
+	return reversed
+}
+
+// startDBSpan starts a span for an operation performed on a table of the database
+func (m *Module) startDBSpan(ctx context.Context, dbAlias, col string, op model.OperationType) (context.Context, *tracing.Span) {
+	dbType, _ := m.getDBType(dbAlias)
+	return tracing.StartSpan(ctx, fmt.Sprintf("db.%s %s", op, col), tracing.SpanKindClient,
+		tracing.String("db.system", dbType), tracing.String("db.name", dbAlias), tracing.String("db.sql.table", col),
+		tracing.String("db.operation", string(op)), tracing.String("db.project", m.project))
+}
+
+func endDBSpan(span *tracing.Span, count int64, err error) {
+	span.SetAttributes(tracing.Int64("db.documents", count))
+	span.RecordError(err)
+	span.End()
+}
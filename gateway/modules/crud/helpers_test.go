@@ -0,0 +1,201 @@
+package crud
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/spaceuptech/space-cloud/gateway/model"
+	"github.com/spaceuptech/space-cloud/gateway/utils"
+)
+
+func Test_applyCursor(t *testing.T) {
+	cursor, err := utils.EncodeCursor([]string{"-age", "id"}, map[string]interface{}{"id": "1", "age": 20})
+	if err != nil {
+		t.Fatalf("EncodeCursor() error = %v", err)
+	}
+	invalidCursor := "invalid"
+	skip := int64(10)
+
+	tests := []struct {
+		name         string
+		req          *model.ReadRequest
+		want         *model.ReadRequest
+		wantReversed bool
+		wantErr      bool
+	}{
+		{
+			name: "no cursor",
+			req:  &model.ReadRequest{Operation: utils.All, Find: map[string]interface{}{"name": "john"}, Options: &model.ReadOptions{Sort: []string{"id"}}},
+			want: &model.ReadRequest{Operation: utils.All, Find: map[string]interface{}{"name": "john"}, Options: &model.ReadOptions{Sort: []string{"id"}}},
+		},
+		{
+			name: "after cursor",
+			req:  &model.ReadRequest{Operation: utils.All, Find: map[string]interface{}{"name": "john"}, Options: &model.ReadOptions{Sort: []string{"-age", "id"}, After: &cursor}},
+			want: &model.ReadRequest{Operation: utils.All, Find: map[string]interface{}{
+				"name": "john",
+				utils.CursorClauseKey: []interface{}{
+					map[string]interface{}{"age": map[string]interface{}{"$lt": int64(20)}},
+					map[string]interface{}{"age": int64(20), "id": map[string]interface{}{"$gt": "1"}},
+				},
+			}, Options: &model.ReadOptions{Sort: []string{"-age", "id"}}},
+		},
+		{
+			name: "before cursor",
+			req:  &model.ReadRequest{Operation: utils.All, Options: &model.ReadOptions{Sort: []string{"-age", "id"}, Before: &cursor}},
+			want: &model.ReadRequest{Operation: utils.All, Find: map[string]interface{}{
+				utils.CursorClauseKey: []interface{}{
+					map[string]interface{}{"age": map[string]interface{}{"$gt": int64(20)}},
+					map[string]interface{}{"age": int64(20), "id": map[string]interface{}{"$lt": "1"}},
+				},
+			}, Options: &model.ReadOptions{Sort: []string{"age", "-id"}}},
+			wantReversed: true,
+		},
+		{
+			name:    "both cursors",
+			req:     &model.ReadRequest{Operation: utils.All, Options: &model.ReadOptions{Sort: []string{"-age", "id"}, After: &cursor, Before: &cursor}},
+			wantErr: true,
+		},
+		{
+			name:    "cursor without sort",
+			req:     &model.ReadRequest{Operation: utils.All, Options: &model.ReadOptions{After: &cursor}},
+			wantErr: true,
+		},
+		{
+			name:    "cursor with a different sort",
+			req:     &model.ReadRequest{Operation: utils.All, Options: &model.ReadOptions{Sort: []string{"id"}, After: &cursor}},
+			wantErr: true,
+		},
+		{
+			name:    "cursor with skip",
+			req:     &model.ReadRequest{Operation: utils.All, Options: &model.ReadOptions{Sort: []string{"-age", "id"}, After: &cursor, Skip: &skip}},
+			wantErr: true,
+		},
+		{
+			name:    "cursor with read one",
+			req:     &model.ReadRequest{Operation: utils.One, Options: &model.ReadOptions{Sort: []string{"-age", "id"}, After: &cursor}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid cursor",
+			req:     &model.ReadRequest{Operation: utils.All, Options: &model.ReadOptions{Sort: []string{"-age", "id"}, After: &invalidCursor}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, isReversed, err := applyCursor(context.Background(), tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("applyCursor() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if isReversed != tt.wantReversed {
+				t.Errorf("applyCursor() isReversed = %v, want %v", isReversed, tt.wantReversed)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("applyCursor() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_reverseResult(t *testing.T) {
+	docs := []interface{}{1, 2, 3}
+	if got, want := reverseResult(docs), []interface{}{3, 2, 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("reverseResult() = %v, want %v", got, want)
+	}
+	if want := []interface{}{1, 2, 3}; !reflect.DeepEqual(docs, want) {
+		t.Errorf("reverseResult() modified the original result = %v", docs)
+	}
+}
+
+func TestModule_addSortTiebreaker(t *testing.T) {
+	schemaDoc := model.Type{"db": model.Collection{
+		"books":  model.Fields{"id": &model.FieldType{FieldName: "id", IsPrimary: true}, "title": &model.FieldType{FieldName: "title"}},
+		"orders": model.Fields{"b": &model.FieldType{FieldName: "b", IsPrimary: true, PrimaryKeyInfo: &model.TableProperties{Order: 1}}, "a": &model.FieldType{FieldName: "a", IsPrimary: true, PrimaryKeyInfo: &model.TableProperties{Order: 2}}},
+	}}
+	distinct := "title"
+
+	tests := []struct {
+		name   string
+		dbType string
+		col    string
+		req    *model.ReadRequest
+		want   *model.ReadOptions
+	}{
+		{
+			name:   "primary key is appended to sort and select",
+			dbType: string(model.Postgres),
+			col:    "books",
+			req:    &model.ReadRequest{Operation: utils.All, Options: &model.ReadOptions{Sort: []string{"-title"}, Select: map[string]int32{"title": 1}}},
+			want:   &model.ReadOptions{Sort: []string{"-title", "id"}, Select: map[string]int32{"title": 1, "id": 1}},
+		},
+		{
+			name:   "primary key already present in sort",
+			dbType: string(model.Postgres),
+			col:    "books",
+			req:    &model.ReadRequest{Operation: utils.All, Options: &model.ReadOptions{Sort: []string{"-id", "title"}}},
+			want:   &model.ReadOptions{Sort: []string{"-id", "title"}},
+		},
+		{
+			name:   "composite primary key is appended in order",
+			dbType: string(model.MySQL),
+			col:    "orders",
+			req:    &model.ReadRequest{Operation: utils.All, Options: &model.ReadOptions{Sort: []string{"a"}}},
+			want:   &model.ReadOptions{Sort: []string{"a", "b"}},
+		},
+		{
+			name:   "primary key is prefixed with the table when using joins",
+			dbType: string(model.Postgres),
+			col:    "books",
+			req:    &model.ReadRequest{Operation: utils.All, Options: &model.ReadOptions{Sort: []string{"books.title"}, Join: []*model.JoinOption{{Table: "authors"}}}},
+			want:   &model.ReadOptions{Sort: []string{"books.title", "books.id"}, Join: []*model.JoinOption{{Table: "authors"}}},
+		},
+		{
+			name:   "id is used for mongo",
+			dbType: string(model.Mongo),
+			col:    "books",
+			req:    &model.ReadRequest{Operation: utils.All, Options: &model.ReadOptions{Sort: []string{"title"}}},
+			want:   &model.ReadOptions{Sort: []string{"title", "_id"}},
+		},
+		{
+			name:   "table without a schema",
+			dbType: string(model.Postgres),
+			col:    "authors",
+			req:    &model.ReadRequest{Operation: utils.All, Options: &model.ReadOptions{Sort: []string{"title"}}},
+			want:   &model.ReadOptions{Sort: []string{"title"}},
+		},
+		{
+			name:   "no sort",
+			dbType: string(model.Postgres),
+			col:    "books",
+			req:    &model.ReadRequest{Operation: utils.All, Options: &model.ReadOptions{}},
+			want:   &model.ReadOptions{},
+		},
+		{
+			name:   "distinct",
+			dbType: string(model.Postgres),
+			col:    "books",
+			req:    &model.ReadRequest{Operation: utils.Distinct, Options: &model.ReadOptions{Sort: []string{"title"}, Distinct: &distinct}},
+			want:   &model.ReadOptions{Sort: []string{"title"}, Distinct: &distinct},
+		},
+		{
+			name:   "group by",
+			dbType: string(model.Postgres),
+			col:    "books",
+			req:    &model.ReadRequest{Operation: utils.All, GroupBy: []interface{}{"title"}, Options: &model.ReadOptions{Sort: []string{"title"}}},
+			want:   &model.ReadOptions{Sort: []string{"title"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &Module{schemaDoc: schemaDoc}
+			m.addSortTiebreaker("db", tt.dbType, tt.col, tt.req)
+			if !reflect.DeepEqual(tt.req.Options, tt.want) {
+				t.Errorf("addSortTiebreaker() = %v, want %v", tt.req.Options, tt.want)
+			}
+		})
+	}
+}
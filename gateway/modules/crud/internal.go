@@ -2,6 +2,7 @@ package crud
 
 import (
 	"context"
+	"time"
 
 	"github.com/spaceuptech/space-cloud/gateway/model"
 	"github.com/spaceuptech/space-cloud/gateway/modules/schema/helpers"
@@ -32,16 +33,19 @@ func (m *Module) InternalCreate(ctx context.Context, dbAlias, project, col strin
 	}
 
 	var n int64
+	ctx, span := m.startDBSpan(ctx, dbAlias, col, model.Create)
+	start := time.Now()
 	// Perform the create operation
 	if req.IsBatch {
 		n, err = m.createBatch(ctx, project, dbAlias, col, req.Document)
 	} else {
 		n, err = crud.Create(ctx, col, req)
 	}
+	endDBSpan(span, n, err)
 
-	// Invoke the metric hook if the operation was successful
-	if err == nil && !isIgnoreMetrics {
-		m.metricHook(m.project, dbAlias, col, n, model.Create)
+	// Invoke the metric hook with the result of the operation
+	if !isIgnoreMetrics {
+		m.metricHook(m.project, dbAlias, col, n, model.Create, time.Since(start), err)
 	}
 
 	return err
@@ -77,12 +81,13 @@ func (m *Module) InternalUpdate(ctx context.Context, dbAlias, project, col strin
 	}
 
 	// Perform the update operation
+	ctx, span := m.startDBSpan(ctx, dbAlias, col, model.Update)
+	start := time.Now()
 	n, err := crud.Update(ctx, col, req)
+	endDBSpan(span, n, err)
 
-	// Invoke the metric hook if the operation was successful
-	if err == nil {
-		m.metricHook(m.project, dbAlias, col, n, model.Update)
-	}
+	// Invoke the metric hook with the result of the operation
+	m.metricHook(m.project, dbAlias, col, n, model.Update, time.Since(start), err)
 
 	return err
 }
@@ -112,12 +117,13 @@ func (m *Module) InternalDelete(ctx context.Context, dbAlias, project, col strin
 	}
 
 	// Perform the delete operation
+	ctx, span := m.startDBSpan(ctx, dbAlias, col, model.Delete)
+	start := time.Now()
 	n, err := crud.Delete(ctx, col, req)
+	endDBSpan(span, n, err)
 
-	// Invoke the metric hook if the operation was successful
-	if err == nil {
-		m.metricHook(m.project, dbAlias, col, n, model.Update)
-	}
+	// Invoke the metric hook with the result of the operation
+	m.metricHook(m.project, dbAlias, col, n, model.Delete, time.Since(start), err)
 
 	return err
 }
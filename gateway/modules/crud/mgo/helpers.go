@@ -2,18 +2,24 @@ package mgo
 
 import (
 	"context"
+	"sort"
 	"strings"
 )
 
 func sanitizeWhereClause(ctx context.Context, col string, find map[string]interface{}) map[string]interface{} {
+	// Keys like `$or:cursor` are used to combine multiple or clauses with an and
+	extraOrKeys := make([]string, 0)
 	for key, value := range find {
 		arr := strings.Split(key, ".")
 		if len(arr) > 1 && arr[0] == col {
 			delete(find, key)
 			find[strings.Join(arr[1:], ".")] = value
 		}
-		switch key {
-		case "$or":
+		if key != "$or" && strings.HasPrefix(key, "$or") {
+			extraOrKeys = append(extraOrKeys, key)
+		}
+		switch {
+		case strings.HasPrefix(key, "$or"):
 			objArr, ok := value.([]interface{})
 			if ok {
 				for _, obj := range objArr {
@@ -30,5 +36,16 @@ func sanitizeWhereClause(ctx context.Context, col string, find map[string]interf
 			}
 		}
 	}
+
+	// Mongo doesn't understand multiple or clauses in the same object. Hence we need to move them in an and clause
+	if len(extraOrKeys) > 0 {
+		sort.Strings(extraOrKeys)
+		andClauses, _ := find["$and"].([]interface{})
+		for _, key := range extraOrKeys {
+			andClauses = append(andClauses, map[string]interface{}{"$or": find[key]})
+			delete(find, key)
+		}
+		find["$and"] = andClauses
+	}
 	return find
 }
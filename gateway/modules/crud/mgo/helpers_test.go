@@ -76,6 +76,39 @@ func Test_sanitizeWhereClause(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Where clause with multiple or clauses",
+			args: args{
+				ctx: context.Background(),
+				col: "users",
+				find: map[string]interface{}{
+					"users.name": "same",
+					"$or": []interface{}{
+						map[string]interface{}{"users.age": 10},
+						map[string]interface{}{"users.age": 20},
+					},
+					"$or:cursor": []interface{}{
+						map[string]interface{}{"users.id": map[string]interface{}{"$gt": 5}},
+						map[string]interface{}{"users.id": 5, "users.height": map[string]interface{}{"$gt": 5.5}},
+					},
+				},
+			},
+			want: map[string]interface{}{
+				"name": "same",
+				"$or": []interface{}{
+					map[string]interface{}{"age": 10},
+					map[string]interface{}{"age": 20},
+				},
+				"$and": []interface{}{
+					map[string]interface{}{
+						"$or": []interface{}{
+							map[string]interface{}{"id": map[string]interface{}{"$gt": 5}},
+							map[string]interface{}{"id": 5, "height": map[string]interface{}{"$gt": 5.5}},
+						},
+					},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -0,0 +1,73 @@
+// +build integration
+
+package mgo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spaceuptech/space-cloud/gateway/config"
+	"github.com/spaceuptech/space-cloud/gateway/model"
+	"github.com/spaceuptech/space-cloud/gateway/utils"
+)
+
+func TestMongo_ReadWithCursor(t *testing.T) {
+	db, err := Init(true, *connection, "myproject", config.DriverConfig{})
+	if err != nil {
+		t.Fatal("Read() Couldn't establishing connection with database", dbType)
+	}
+
+	// ensure that the table is empty
+	coll := db.client.Database("myproject").Collection("cursor_orders")
+	if err := coll.Drop(context.Background()); err != nil {
+		t.Log("Read() Couldn't truncate table", err)
+	}
+
+	// The documents don't have an _id, so mongo generates object ids for them
+	docs := make([]interface{}, 5)
+	for i := range docs {
+		docs[i] = map[string]interface{}{"amount": 10}
+	}
+	if _, err := db.Create(context.Background(), "cursor_orders", &model.CreateRequest{Document: docs, Operation: utils.All}); err != nil {
+		t.Fatalf("Read() couldn't insert data %v", err)
+	}
+
+	// Read all the documents two at a time by sorting them on the amount and the generated object ids
+	sort := []string{"amount", "_id"}
+	limit := int64(2)
+	seen := map[interface{}]bool{}
+	var cursor string
+	for page := 0; page < 3; page++ {
+		find := map[string]interface{}{}
+		if cursor != "" {
+			values, err := utils.DecodeCursor(sort, cursor)
+			if err != nil {
+				t.Fatalf("Read() couldn't decode cursor %v", err)
+			}
+			find[utils.CursorClauseKey] = utils.GenerateCursorClause(sort, values, false)
+		}
+
+		req := &model.ReadRequest{Find: find, Operation: utils.All, Options: &model.ReadOptions{Sort: sort, Limit: &limit}}
+		_, result, _, _, err := db.Read(context.Background(), "cursor_orders", req)
+		if err != nil {
+			t.Fatalf("Read() error = %v", err)
+		}
+
+		results := result.([]interface{})
+		if want := []int{2, 2, 1}[page]; len(results) != want {
+			t.Fatalf("Read() page %d returned %d documents, want %d", page, len(results), want)
+		}
+		for _, doc := range results {
+			id := doc.(map[string]interface{})["_id"]
+			if seen[id] {
+				t.Errorf("Read() document (%v) returned twice", id)
+			}
+			seen[id] = true
+		}
+
+		cursor, err = utils.EncodeCursor(sort, results[len(results)-1])
+		if err != nil {
+			t.Fatalf("Read() couldn't encode cursor %v", err)
+		}
+	}
+}
@@ -3,12 +3,15 @@ package crud
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/spaceuptech/helpers"
 
+	"github.com/spaceuptech/space-cloud/gateway/config"
 	"github.com/spaceuptech/space-cloud/gateway/model"
 	schemaHelpers "github.com/spaceuptech/space-cloud/gateway/modules/schema/helpers"
 	"github.com/spaceuptech/space-cloud/gateway/utils"
+	"github.com/spaceuptech/space-cloud/gateway/utils/tracing"
 )
 
 // Create inserts a documents (or multiple when op is "all") into the database based on dbType
@@ -46,6 +49,8 @@ func (m *Module) Create(ctx context.Context, dbAlias, col string, req *model.Cre
 	}
 
 	var n int64
+	ctx, span := m.startDBSpan(ctx, dbAlias, col, model.Create)
+	start := time.Now()
 	if req.IsBatch {
 		// add the request for batch operation
 		n, err = m.createBatch(ctx, m.project, dbAlias, col, req.Document)
@@ -54,15 +59,15 @@ func (m *Module) Create(ctx context.Context, dbAlias, col string, req *model.Cre
 		n, err = crud.Create(ctx, col, req)
 	}
 
-	// Invoke the metric hook if the operation was successful
-	if err == nil {
-		m.metricHook(m.project, dbAlias, col, n, model.Create)
-	}
+	// Invoke the metric hook with the result of the operation
+	m.metricHook(m.project, dbAlias, col, n, model.Create, time.Since(start), err)
+	endDBSpan(span, n, err)
 
 	return err
 }
 
-// Read returns the documents(s) which match a query from the database based on dbType
+// Read returns the documents(s) which match a query from the database based on dbType. The primary key of the table
+// gets appended to the sort provided in the options of the request
 func (m *Module) Read(ctx context.Context, dbAlias, col string, req *model.ReadRequest, params model.RequestParams) (interface{}, *model.SQLMetaData, error) {
 	m.RLock()
 	defer m.RUnlock()
@@ -72,6 +77,14 @@ func (m *Module) Read(ctx context.Context, dbAlias, col string, req *model.ReadR
 	if err != nil {
 		return nil, nil, err
 	}
+
+	// Use the primary key as a tiebreaker for the sort and convert the cursor into a where clause on the sort keys
+	m.addSortTiebreaker(dbAlias, dbType, col, req)
+	req, isReversed, err := applyCursor(ctx, req)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	if err := schemaHelpers.AdjustWhereClause(ctx, dbAlias, model.DBType(dbType), col, m.schemaDoc, req.Find); err != nil {
 		return nil, nil, err
 	}
@@ -112,6 +125,9 @@ func (m *Module) Read(ctx context.Context, dbAlias, col string, req *model.ReadR
 			return nil, nil, err
 		}
 		res := data.(queryResult)
+		if isReversed {
+			res.doc = reverseResult(res.doc)
+		}
 		if res.metaData != nil {
 			res.metaData.DbAlias = dbAlias
 			res.metaData.Col = col
@@ -119,11 +135,14 @@ func (m *Module) Read(ctx context.Context, dbAlias, col string, req *model.ReadR
 		return res.doc, res.metaData, err
 	}
 
+	ctx, span := m.startDBSpan(ctx, dbAlias, col, model.Read)
+	start := time.Now()
 	dbCacheOptions, err := m.caching.GetDatabaseKey(ctx, m.project, dbAlias, col, req)
 	if err != nil {
+		endDBSpan(span, 0, err)
 		return nil, nil, err
 	}
-	// TODO: Add metric hook for cache
+	span.SetAttributes(tracing.Bool("cache.hit", dbCacheOptions.IsCacheHit()))
 
 	// See if result is present in cache
 	var metaData *model.SQLMetaData
@@ -134,21 +153,25 @@ func (m *Module) Read(ctx context.Context, dbAlias, col string, req *model.ReadR
 		var cacheJoinInfo map[string]map[string]string
 		n, result, cacheJoinInfo, metaData, err = crud.Read(ctx, col, req)
 
-		// Set result in cache & invoke the metric hook if the operation was successful
+		// Set result in cache if the operation was successful
 		if err == nil {
 			if err := m.caching.SetDatabaseKey(ctx, m.project, dbAlias, col, &model.CacheDatabaseResult{MetricCount: n, Result: result}, dbCacheOptions, req.Cache, cacheJoinInfo); err != nil {
+				endDBSpan(span, n, err)
 				return nil, nil, err
 			}
-
-			m.metricHook(m.project, dbAlias, col, n, model.Read)
 		}
+
+		// Invoke the metric hook with the result of the operation
+		m.metricHook(m.project, dbAlias, col, n, model.Read, time.Since(start), err)
+		endDBSpan(span, n, err)
 	} else {
 		// Make a metadata object for cached results
 		metaData = &model.SQLMetaData{QueryTime: "0s", SQL: "fetched from cache"}
 
 		cacheResult := dbCacheOptions.GetDatabaseResult()
 		result = cacheResult.Result
-		m.metricHook(m.project, dbAlias, col, cacheResult.MetricCount, model.Read)
+		m.metricHook(m.project, dbAlias, col, cacheResult.MetricCount, model.Read, time.Since(start), nil)
+		endDBSpan(span, cacheResult.MetricCount, nil)
 	}
 
 	// Process the response
@@ -156,6 +179,10 @@ func (m *Module) Read(ctx context.Context, dbAlias, col string, req *model.ReadR
 		return nil, nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("error executing read request in crud module unable to perform schema post process for un marshalling json for project (%s) col (%s)", m.project, col), err, nil)
 	}
 
+	if isReversed {
+		result = reverseResult(result)
+	}
+
 	if metaData != nil {
 		metaData.DbAlias = dbAlias
 		metaData.Col = col
@@ -204,12 +231,13 @@ func (m *Module) Update(ctx context.Context, dbAlias, col string, req *model.Upd
 	}
 
 	// Perform the update operation
+	ctx, span := m.startDBSpan(ctx, dbAlias, col, model.Update)
+	start := time.Now()
 	n, err := crud.Update(ctx, col, req)
 
-	// Invoke the metric hook if the operation was successful
-	if err == nil {
-		m.metricHook(m.project, dbAlias, col, n, model.Update)
-	}
+	// Invoke the metric hook with the result of the operation
+	m.metricHook(m.project, dbAlias, col, n, model.Update, time.Since(start), err)
+	endDBSpan(span, n, err)
 
 	return err
 }
@@ -250,12 +278,13 @@ func (m *Module) Delete(ctx context.Context, dbAlias, col string, req *model.Del
 	}
 
 	// Perform the delete operation
+	ctx, span := m.startDBSpan(ctx, dbAlias, col, model.Delete)
+	start := time.Now()
 	n, err := crud.Delete(ctx, col, req)
 
-	// Invoke the metric hook if the operation was successful
-	if err == nil {
-		m.metricHook(m.project, dbAlias, col, n, model.Delete)
-	}
+	// Invoke the metric hook with the result of the operation
+	m.metricHook(m.project, dbAlias, col, n, model.Delete, time.Since(start), err)
+	endDBSpan(span, n, err)
 
 	return err
 }
@@ -303,7 +332,9 @@ func (m *Module) ExecPreparedQuery(ctx context.Context, dbAlias, id string, req
 	}
 
 	// Fire the query and return the result
-	_, b, metaData, err := crud.RawQuery(ctx, preparedQuery.SQL, req.Debug, args)
+	ctx, span := m.startDBSpan(ctx, dbAlias, id, model.Read)
+	n, b, metaData, err := crud.RawQuery(ctx, preparedQuery.SQL, req.Debug, args)
+	endDBSpan(span, n, err)
 	if metaData != nil {
 		metaData.DbAlias = dbAlias
 		metaData.Col = id
@@ -337,7 +368,10 @@ func (m *Module) Aggregate(ctx context.Context, dbAlias, col string, req *model.
 		return nil, err
 	}
 
-	return crud.Aggregate(ctx, col, req)
+	ctx, span := m.startDBSpan(ctx, dbAlias, col, model.Aggregation)
+	result, err := crud.Aggregate(ctx, col, req)
+	endDBSpan(span, 0, err)
+	return result, err
 }
 
 // Batch performs a batch operation on the database
@@ -387,13 +421,21 @@ func (m *Module) Batch(ctx context.Context, dbAlias string, req *model.BatchRequ
 	}
 
 	// Perform the batch operation
+	ctx, span := tracing.StartSpan(ctx, "db.batch", tracing.SpanKindClient, tracing.String("db.name", dbAlias), tracing.String("db.system", dbType),
+		tracing.String("db.operation", string(model.Batch)), tracing.String("db.project", m.project), tracing.Int64("db.batch.size", int64(len(req.Requests))))
+	start := time.Now()
 	counts, err := crud.Batch(ctx, req)
+	span.RecordError(err)
+	span.End()
 
-	// Invoke the metric hook if the operation was successful
-	if err == nil {
-		for i, r := range req.Requests {
-			m.metricHook(m.project, dbAlias, r.Col, counts[i], model.OperationType(r.Type))
+	// Invoke the metric hook with the result of the operation
+	latency := time.Since(start)
+	for i, r := range req.Requests {
+		var n int64
+		if err == nil && i < len(counts) {
+			n = counts[i]
 		}
+		m.metricHook(m.project, dbAlias, r.Col, n, model.OperationType(r.Type), latency, err)
 	}
 
 	return err
@@ -492,6 +534,18 @@ func (m *Module) IsPreparedQueryPresent(dbAlias, id string) bool {
 	return p
 }
 
+// GetPreparedQueries returns the prepared queries of all databases
+func (m *Module) GetPreparedQueries() config.DatabasePreparedQueries {
+	m.RLock()
+	defer m.RUnlock()
+
+	queries := make(config.DatabasePreparedQueries, len(m.queries))
+	for k, v := range m.queries {
+		queries[k] = v
+	}
+	return queries
+}
+
 // GetSchema function gets schema
 func (m *Module) GetSchema(dbAlias, col string) (model.Fields, bool) {
 	m.RLock()
@@ -17,6 +17,8 @@ func (s *SQL) Batch(ctx context.Context, req *model.BatchRequest) ([]int64, erro
 	if err != nil {
 		return counts, err
 	}
+	// Rollback the transaction if any of the operations fail. This is a no-op once the transaction is committed
+	defer func() { _ = tx.Rollback() }()
 
 	for i, req := range req.Requests {
 		switch req.Type {
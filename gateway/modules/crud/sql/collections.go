@@ -8,13 +8,18 @@ import (
 	"github.com/doug-martin/goqu/v8"
 	"github.com/spaceuptech/helpers"
 
+	"github.com/spaceuptech/space-cloud/gateway/model"
 	"github.com/spaceuptech/space-cloud/gateway/utils"
 )
 
 // GetCollections returns collection / tables name of specified database
 func (s *SQL) GetCollections(ctx context.Context) ([]utils.DatabaseCollections, error) {
-	dialect := goqu.Dialect(s.dbType)
+	dialect := getDialect(s.dbType)
 	query := dialect.From("information_schema.tables").Prepared(true).Select("table_name").Where(goqu.Ex{"table_schema": s.name})
+	if model.DBType(s.dbType) == model.SQLite {
+		// Sqlite doesn't have an information schema. The tables are listed in the schema table instead
+		query = dialect.From("sqlite_master").Prepared(true).Select("name").Where(goqu.Ex{"type": "table"}, goqu.I("name").NotLike("sqlite_%"))
+	}
 
 	sqlString, args, err := query.ToSQL()
 	if err != nil {
@@ -4,7 +4,6 @@ import (
 	"context"
 	"strings"
 
-	"github.com/doug-martin/goqu/v8"
 	"github.com/spaceuptech/helpers"
 
 	_ "github.com/denisenkom/go-mssqldb"                // Import for MsSQL
@@ -39,7 +38,7 @@ func (s *SQL) generateCreateQuery(col string, req *model.CreateRequest) (string,
 		dbType = string(model.Postgres)
 	}
 
-	dialect := goqu.Dialect(dbType)
+	dialect := getDialect(dbType)
 	query := dialect.From(s.getColName(col)).Prepared(true)
 
 	var insert []interface{}
@@ -4,8 +4,6 @@ import (
 	"context"
 	"strings"
 
-	"github.com/doug-martin/goqu/v8"
-
 	_ "github.com/denisenkom/go-mssqldb"                // Import for MsSQL
 	_ "github.com/doug-martin/goqu/v8/dialect/postgres" // Dialect for postgres
 	_ "github.com/go-sql-driver/mysql"                  // Import for MySQL
@@ -37,12 +35,16 @@ func (s *SQL) generateDeleteQuery(ctx context.Context, req *model.DeleteRequest,
 		dbType = string(model.Postgres)
 	}
 
-	dialect := goqu.Dialect(dbType)
+	dialect := getDialect(dbType)
 	query := dialect.From(s.getColName(col)).Prepared(true)
 
 	if req.Find != nil {
 		// Get the where clause from query object
-		query = s.generateWhereClause(ctx, query, req.Find, nil)
+		var err error
+		query, err = s.generateWhereClause(ctx, query, req.Find, nil)
+		if err != nil {
+			return "", nil, err
+		}
 	}
 
 	// Generate SQL string and arguments
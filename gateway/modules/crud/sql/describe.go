@@ -170,6 +170,35 @@ where c.table_name = @p1 and c.table_schema = @p2
 order by c.ordinal_position;
 `
 		args = append(args, col, project)
+	case model.SQLite:
+		// Sqlite doesn't store the names of foreign key constraints. We generate the name the same way the schema module does
+		queryString = `
+select '' AS "TABLE_SCHEMA",
+       m.name AS "TABLE_NAME",
+
+       c.name AS "COLUMN_NAME",
+       lower(c.type) AS "DATA_TYPE",
+       case when c."notnull" = 1 or c.pk > 0 then 'NO' else 'YES' end AS "IS_NULLABLE",
+       c.cid + 1 AS "ORDINAL_POSITION",
+       trim(coalesce(c.dflt_value, ''), '''') AS "DEFAULT",
+       case when c.pk = 1 and lower(c.type) = 'integer' and upper(m.sql) like '%AUTOINCREMENT%' then 'true' else 'false' end AS "AUTO_INCREMENT",
+       0 AS "CHARACTER_MAXIMUM_LENGTH",
+       0 AS "NUMERIC_PRECISION",
+       0 AS "NUMERIC_SCALE",
+       0 AS "DATETIME_PRECISION",
+
+       case when f.id is null then '' else 'c_' || m.name || '_' || c.name end AS "CONSTRAINT_NAME",
+       coalesce(f.on_delete, '') AS "DELETE_RULE",
+       '' AS "REFERENCED_TABLE_SCHEMA",
+       coalesce(f."table", '') AS "REFERENCED_TABLE_NAME",
+       coalesce(f."to", '') AS "REFERENCED_COLUMN_NAME"
+from sqlite_master m
+         join pragma_table_info(m.name) c
+         left join pragma_foreign_key_list(m.name) f on f."from" = c.name
+where m.type = 'table' and m.name = ?
+order by c.cid;
+`
+		args = append(args, col)
 	}
 	rows, err := s.getClient().QueryxContext(ctx, queryString, args...)
 	if err != nil {
@@ -186,6 +215,9 @@ order by c.ordinal_position;
 		if err := rows.StructScan(fieldType); err != nil {
 			return nil, err
 		}
+		if model.DBType(s.dbType) == model.SQLite {
+			parseSQLiteColumnType(fieldType)
+		}
 
 		result = append(result, *fieldType)
 	}
@@ -197,6 +229,7 @@ order by c.ordinal_position;
 
 func (s *SQL) getIndexDetails(ctx context.Context, project, col string) ([]model.IndexType, error) {
 	queryString := ""
+	args := []interface{}{project, col}
 	switch model.DBType(s.dbType) {
 
 	case model.MySQL:
@@ -254,8 +287,36 @@ where t.is_ms_shipped <> 1
   and schema_name(t.schema_id) = @p1
   and t.[name] = @p2
 order by i.index_id;`
+	case model.SQLite:
+		// The primary key of a table isn't always backed by an index in sqlite. Hence we get it from the table info
+		queryString = `
+select '' AS "TABLE_SCHEMA",
+       m.name AS "TABLE_NAME",
+       c.name AS "COLUMN_NAME",
+       'PRIMARY' AS "INDEX_NAME",
+       c.pk AS "SEQ_IN_INDEX",
+       'asc' AS "SORT",
+       1 AS "IS_UNIQUE",
+       1 AS "IS_PRIMARY"
+from sqlite_master m
+         join pragma_table_info(m.name) c
+where m.type = 'table' and m.name = ? and c.pk > 0
+union all
+select '' AS "TABLE_SCHEMA",
+       m.name AS "TABLE_NAME",
+       i.name AS "COLUMN_NAME",
+       l.name AS "INDEX_NAME",
+       i.seqno + 1 AS "SEQ_IN_INDEX",
+       case when i."desc" = 1 then 'desc' else 'asc' end AS "SORT",
+       l."unique" AS "IS_UNIQUE",
+       0 AS "IS_PRIMARY"
+from sqlite_master m
+         join pragma_index_list(m.name) l
+         join pragma_index_xinfo(l.name) i
+where m.type = 'table' and m.name = ? and l.origin <> 'pk' and i.key = 1;`
+		args = []interface{}{col, col}
 	}
-	rows, err := s.getClient().QueryxContext(ctx, queryString, []interface{}{project, col}...)
+	rows, err := s.getClient().QueryxContext(ctx, queryString, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -20,7 +20,7 @@ import (
 	"github.com/spaceuptech/space-cloud/gateway/utils"
 )
 
-func (s *SQL) generator(ctx context.Context, find map[string]interface{}, isJoin bool) goqu.Expression {
+func (s *SQL) generator(ctx context.Context, find map[string]interface{}, isJoin bool) (goqu.Expression, error) {
 	array := []goqu.Expression{}
 	for k, v := range find {
 		if strings.HasPrefix(k, "$or") {
@@ -36,7 +36,10 @@ func (s *SQL) generator(ctx context.Context, find map[string]interface{}, isJoin
 					continue
 				}
 
-				exp := s.generator(ctx, f2, isJoin)
+				exp, err := s.generator(ctx, f2, isJoin)
+				if err != nil {
+					return nil, err
+				}
 				orFinalArray = append(orFinalArray, exp)
 			}
 
@@ -57,6 +60,10 @@ func (s *SQL) generator(ctx context.Context, find map[string]interface{}, isJoin
 						array = append(array, goqu.L(fmt.Sprintf("(%s ~ ?)", k), v2))
 					case "mysql":
 						array = append(array, goqu.L(fmt.Sprintf("(%s REGEXP ?)", k), v2))
+					default:
+						// Sqlite and sql server can't evaluate regular expressions. The filter used to be dropped, which
+						// widened the query to more rows than were asked for, hence the request is rejected instead
+						return nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("_regex not supported for database (%s)", s.dbType), nil, nil)
 					}
 
 				case "$like":
@@ -68,8 +75,7 @@ func (s *SQL) generator(ctx context.Context, find map[string]interface{}, isJoin
 				case "$contains":
 					data, err := json.Marshal(v2)
 					if err != nil {
-						_ = helpers.Logger.LogError(helpers.GetRequestID(ctx), "error marshalling data $contains data", err, nil)
-						break
+						return nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), "error marshalling data $contains data", err, nil)
 					}
 					switch s.dbType {
 					case string(model.MySQL):
@@ -77,7 +83,8 @@ func (s *SQL) generator(ctx context.Context, find map[string]interface{}, isJoin
 					case string(model.Postgres):
 						array = append(array, goqu.L(fmt.Sprintf("%s @> ?", k), string(data)))
 					default:
-						_ = helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("_contains not supported for database (%s)", s.dbType), nil, nil)
+						// Just like _regex, an unsupported _contains filter is rejected instead of being dropped
+						return nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("_contains not supported for database (%s)", s.dbType), nil, nil)
 					}
 				case "$gt":
 					array = append(array, goqu.I(k).Gt(v2))
@@ -106,19 +113,26 @@ func (s *SQL) generator(ctx context.Context, find map[string]interface{}, isJoin
 		}
 	}
 
-	return goqu.And(array...)
+	return goqu.And(array...), nil
 }
 
-func (s *SQL) generateWhereClause(ctx context.Context, q *goqu.SelectDataset, find map[string]interface{}, matchWhere []map[string]interface{}) (query *goqu.SelectDataset) {
-	query = q
+func (s *SQL) generateWhereClause(ctx context.Context, q *goqu.SelectDataset, find map[string]interface{}, matchWhere []map[string]interface{}) (*goqu.SelectDataset, error) {
+	query := q
 
 	exps := make([]goqu.Expression, len(matchWhere))
 	for i, f := range matchWhere {
-		exps[i] = s.generator(ctx, f, false)
+		exp, err := s.generator(ctx, f, false)
+		if err != nil {
+			return nil, err
+		}
+		exps[i] = exp
 	}
 
 	if len(find) > 0 {
-		exp := s.generator(ctx, find, false)
+		exp, err := s.generator(ctx, find, false)
+		if err != nil {
+			return nil, err
+		}
 		exps = append(exps, exp)
 	}
 
@@ -126,7 +140,15 @@ func (s *SQL) generateWhereClause(ctx context.Context, q *goqu.SelectDataset, fi
 		query = query.Where(goqu.And(exps...))
 	}
 
-	return query
+	return query, nil
+}
+
+// getDialect returns the goqu dialect used to generate the queries of the provided database type
+func getDialect(dbType string) goqu.DialectWrapper {
+	if model.DBType(dbType) == model.SQLite {
+		return goqu.Dialect(sqliteDialect)
+	}
+	return goqu.Dialect(dbType)
 }
 
 func generateRecord(temp interface{}) (goqu.Record, error) {
@@ -225,8 +247,9 @@ func mysqlTypeCheck(ctx context.Context, dbType model.DBType, types []*sql.Colum
 				mapping[colType.Name()] = string(v)
 			}
 		case int64:
-			if typeName == "TINYINT" {
+			if typeName == "TINYINT" || typeName == "BOOLEAN" {
 				// this case occurs for mysql database with column type tinyint during the upsert operation
+				// and for sqlite which stores booleans as integers
 				if v == int64(1) {
 					mapping[colType.Name()] = true
 				} else {
@@ -252,7 +275,10 @@ func mysqlTypeCheck(ctx context.Context, dbType model.DBType, types []*sql.Colum
 
 func (s *SQL) processJoins(ctx context.Context, query *goqu.SelectDataset, join []*model.JoinOption, sel map[string]int32, isAggregate bool) (*goqu.SelectDataset, error) {
 	for _, j := range join {
-		on := s.generator(ctx, j.On, true)
+		on, err := s.generator(ctx, j.On, true)
+		if err != nil {
+			return nil, err
+		}
 		switch j.Type {
 		case "", "LEFT":
 			query = query.LeftJoin(goqu.T(s.getColName(j.Table)), goqu.On(on))
@@ -76,6 +76,9 @@ func (s *SQL) CreateDatabaseIfNotExist(ctx context.Context, name string) error {
 					BEGIN
     					EXEC ('CREATE SCHEMA [` + name + `]')
 					END`
+	case model.SQLite:
+		// The sqlite database file gets created when we connect to it
+		return nil
 	default:
 		return helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to create logical database", fmt.Errorf("invalid database (%s) provided", s.dbType), nil)
 	}
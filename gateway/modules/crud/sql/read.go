@@ -37,11 +37,14 @@ func (s *SQL) generateReadQuery(ctx context.Context, col string, req *model.Read
 		req.Options.HasOptions = true
 	}
 
-	dialect := goqu.Dialect(dbType)
+	dialect := getDialect(dbType)
 	query := dialect.From(s.getColName(col)).Prepared(true)
 
 	// Get the where clause from query object
-	query = s.generateWhereClause(ctx, query, req.Find, req.MatchWhere)
+	query, err := s.generateWhereClause(ctx, query, req.Find, req.MatchWhere)
+	if err != nil {
+		return "", nil, err
+	}
 
 	selArray := make([]interface{}, 0)
 	if req.Options != nil {
@@ -218,8 +221,13 @@ func (s *SQL) readExec(ctx context.Context, col, sqlString string, args []interf
 	operation := req.Operation
 	isAggregate := len(req.Aggregate) > 0
 	metaData := new(model.SQLMetaData)
+
+	ctx, span := startQuerySpan(ctx, sqlString)
+	defer span.End()
+
 	stmt, err := executor.PreparexContext(ctx, sqlString)
 	if err != nil {
+		span.RecordError(err)
 		return 0, nil, nil, nil, err
 	}
 	defer func() { _ = stmt.Close() }()
@@ -227,6 +235,7 @@ func (s *SQL) readExec(ctx context.Context, col, sqlString string, args []interf
 	start := time.Now()
 	rows, err := stmt.QueryxContext(ctx, args...)
 	if err != nil {
+		span.RecordError(err)
 		return 0, nil, nil, nil, err
 	}
 	defer func() { _ = rows.Close() }()
@@ -238,7 +247,7 @@ func (s *SQL) readExec(ctx context.Context, col, sqlString string, args []interf
 	var rowTypes []*sql.ColumnType
 
 	switch s.GetDBType() {
-	case model.MySQL, model.Postgres, model.SQLServer:
+	case model.MySQL, model.Postgres, model.SQLServer, model.SQLite:
 		rowTypes, _ = rows.ColumnTypes()
 	}
 
@@ -255,7 +264,7 @@ func (s *SQL) readExec(ctx context.Context, col, sqlString string, args []interf
 		}
 
 		switch s.GetDBType() {
-		case model.MySQL, model.Postgres, model.SQLServer:
+		case model.MySQL, model.Postgres, model.SQLServer, model.SQLite:
 			mysqlTypeCheck(ctx, s.GetDBType(), rowTypes, mapping)
 		}
 
@@ -282,7 +291,7 @@ func (s *SQL) readExec(ctx context.Context, col, sqlString string, args []interf
 			}
 
 			switch s.GetDBType() {
-			case model.MySQL, model.Postgres, model.SQLServer:
+			case model.MySQL, model.Postgres, model.SQLServer, model.SQLite:
 				mysqlTypeCheck(ctx, s.GetDBType(), rowTypes, row)
 			}
 
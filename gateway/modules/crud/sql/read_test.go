@@ -442,6 +442,22 @@ func TestSQL_generateReadQuery(t *testing.T) {
 			want1:   []interface{}{int64(1)},
 			wantErr: false,
 		},
+		{
+			name:    "regex isn't supported",
+			fields:  fields{dbType: "sqlserver"},
+			args:    args{project: "test", col: "table", req: &model.ReadRequest{Find: map[string]interface{}{"fieldName": map[string]interface{}{"$regex": "ss"}}}},
+			want:    []string{""},
+			want1:   nil,
+			wantErr: true,
+		},
+		{
+			name:    "contains isn't supported",
+			fields:  fields{dbType: "sqlserver"},
+			args:    args{project: "test", col: "table", req: &model.ReadRequest{Find: map[string]interface{}{"Obj1": map[string]interface{}{"$contains": map[string]interface{}{"obj1": "value1"}}}}},
+			want:    []string{""},
+			want1:   nil,
+			wantErr: true,
+		},
 		{
 			name:    "String1 > ?",
 			fields:  fields{dbType: "sqlserver"},
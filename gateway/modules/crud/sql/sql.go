@@ -18,6 +18,7 @@ import (
 	"github.com/spaceuptech/space-cloud/gateway/config"
 	"github.com/spaceuptech/space-cloud/gateway/model"
 	"github.com/spaceuptech/space-cloud/gateway/utils"
+	"github.com/spaceuptech/space-cloud/gateway/utils/tracing"
 )
 
 // SQL holds the sql db object
@@ -50,6 +51,9 @@ func Init(dbType model.DBType, enabled bool, connection string, dbName string, d
 	case model.SQLServer:
 		s.dbType = "sqlserver"
 
+	case model.SQLite:
+		s.dbType = "sqlite"
+
 	default:
 		err = utils.ErrUnsupportedDatabase
 		return
@@ -113,6 +117,8 @@ func (s *SQL) GetDBType() model.DBType {
 		return model.MySQL
 	case "sqlserver":
 		return model.SQLServer
+	case "sqlite":
+		return model.SQLite
 	}
 
 	return model.MySQL
@@ -139,9 +145,15 @@ func (s *SQL) connect() error {
 	ctx, cancel := context.WithTimeout(context.Background(), timeOut)
 	defer cancel()
 
-	sql, err := sqlx.Open(s.dbType, s.connection)
-	if err != nil {
-		return err
+	var sql *sqlx.DB
+	if model.DBType(s.dbType) == model.SQLite {
+		sql = openSQLite(s.connection)
+	} else {
+		var err error
+		sql, err = sqlx.Open(s.dbType, s.connection)
+		if err != nil {
+			return err
+		}
 	}
 
 	s.setClient(sql)
@@ -161,6 +173,17 @@ func (s *SQL) connect() error {
 		maxIdleTimeout = 60 * 5 * 1000
 	}
 
+	if model.DBType(s.dbType) == model.SQLite {
+		// Sqlite only allows a single writer at a time and every connection to an in memory
+		// database opens a new database. Hence we use a single connection which is never closed
+		// unless told otherwise
+		if s.driverConf.MaxConn == 0 {
+			maxConn = 1
+		}
+		maxIdleConn = maxConn
+		maxIdleTimeout = 0
+	}
+
 	s.getClient().SetMaxOpenConns(maxConn)
 	s.getClient().SetMaxIdleConns(maxIdleConn)
 	duration := time.Duration(maxIdleTimeout) * time.Millisecond
@@ -173,6 +196,14 @@ type executor interface {
 }
 
 func doExecContext(ctx context.Context, query string, args []interface{}, executor executor) (sql.Result, error) {
+	ctx, span := startQuerySpan(ctx, query)
+	res, err := execContext(ctx, query, args, executor)
+	span.RecordError(err)
+	span.End()
+	return res, err
+}
+
+func execContext(ctx context.Context, query string, args []interface{}, executor executor) (sql.Result, error) {
 	stmt, err := executor.PreparexContext(ctx, query)
 	if err != nil {
 		return nil, err
@@ -182,6 +213,11 @@ func doExecContext(ctx context.Context, query string, args []interface{}, execut
 	return stmt.ExecContext(ctx, args...)
 }
 
+// startQuerySpan starts a span for a single statement fired on the database
+func startQuerySpan(ctx context.Context, query string) (context.Context, *tracing.Span) {
+	return tracing.StartSpan(ctx, "db.query", tracing.SpanKindClient, tracing.String("db.statement", query))
+}
+
 // SetQueryFetchLimit sets data fetch limit
 func (s *SQL) SetQueryFetchLimit(limit int64) {
 	s.queryFetchLimit = &limit
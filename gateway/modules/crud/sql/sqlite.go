@@ -0,0 +1,283 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/doug-martin/goqu/v8"
+	"github.com/doug-martin/goqu/v8/dialect/sqlite3"
+	"github.com/jmoiron/sqlx"
+	"modernc.org/sqlite"
+
+	"github.com/spaceuptech/space-cloud/gateway/model"
+)
+
+// sqliteDialect is the name of the goqu dialect used to generate queries for sqlite
+const sqliteDialect = "sqlite3"
+
+func init() {
+	// The sqlite3 dialect quotes identifiers with backticks. The quotes in the generated queries get stripped
+	// the same way it's done for the other databases, hence the dialect is registered with double quotes instead
+	opts := sqlite3.DialectOptions()
+	opts.QuoteRune = '"'
+	goqu.RegisterDialect(sqliteDialect, opts)
+}
+
+// sqlitePragmas are executed on every new sqlite connection. Sqlite doesn't enforce foreign key
+// constraints unless told to and fails immediately when the database is locked by another connection
+var sqlitePragmas = []string{
+	"PRAGMA foreign_keys = ON",
+	"PRAGMA busy_timeout = 5000",
+}
+
+// sqliteConnector opens connections to a sqlite database
+type sqliteConnector struct {
+	dsn    string
+	driver *sqlite.Driver
+}
+
+// Connect opens a new connection and configures it with the required pragmas
+func (c *sqliteConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.driver.Open(c.dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	sqliteConn, ok := conn.(sqliteDriverConn)
+	if !ok {
+		_ = conn.Close()
+		return nil, errors.New("sqlite connection doesn't support contexts")
+	}
+	for _, pragma := range sqlitePragmas {
+		if _, err := sqliteConn.ExecContext(context.Background(), pragma, nil); err != nil {
+			_ = conn.Close()
+			return nil, err
+		}
+	}
+	return &sqliteContextConn{sqliteDriverConn: sqliteConn}, nil
+}
+
+// Driver returns the underlying sqlite driver
+func (c *sqliteConnector) Driver() driver.Driver {
+	return c.driver
+}
+
+// sqliteDriverConn is the set of interfaces implemented by a connection of the sqlite driver
+type sqliteDriverConn interface {
+	driver.Conn
+	driver.ConnBeginTx
+	driver.ConnPrepareContext
+	driver.ExecerContext
+	driver.QueryerContext
+	driver.Pinger
+}
+
+// sqliteDriverStmt is the set of interfaces implemented by a statement of the sqlite driver
+type sqliteDriverStmt interface {
+	driver.Stmt
+	driver.StmtExecContext
+	driver.StmtQueryContext
+}
+
+// sqliteContextConn relays the cancellation of a request to the sqlite driver. The driver interrupts the connection
+// with sqlite3_interrupt once the context it was passed is done, but it may do so after the statement has already
+// completed and would then abort whichever statement runs next on the reused connection. Hence the driver gets a
+// context which is only cancelled while the statement is running, and a connection which got interrupted is
+// discarded instead of being reused.
+type sqliteContextConn struct {
+	sqliteDriverConn
+
+	lock        sync.Mutex
+	interrupted bool
+}
+
+// BeginTx starts a transaction
+func (c *sqliteContextConn) BeginTx(ctx context.Context, opts driver.TxOptions) (tx driver.Tx, err error) {
+	err = c.run(ctx, func(ctx context.Context) error {
+		tx, err = c.sqliteDriverConn.BeginTx(ctx, opts)
+		return err
+	})
+	return tx, err
+}
+
+// PrepareContext prepares a statement
+func (c *sqliteContextConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	var stmt driver.Stmt
+	err := c.run(ctx, func(ctx context.Context) (err error) {
+		stmt, err = c.sqliteDriverConn.PrepareContext(ctx, query)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	sqliteStmt, ok := stmt.(sqliteDriverStmt)
+	if !ok {
+		_ = stmt.Close()
+		return nil, errors.New("sqlite statement doesn't support contexts")
+	}
+	return &sqliteContextStmt{sqliteStmt, c}, nil
+}
+
+// ExecContext executes a query which doesn't return any rows
+func (c *sqliteContextConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (res driver.Result, err error) {
+	err = c.run(ctx, func(ctx context.Context) error {
+		res, err = c.sqliteDriverConn.ExecContext(ctx, query, args)
+		return err
+	})
+	return res, err
+}
+
+// QueryContext executes a query which returns rows
+func (c *sqliteContextConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (rows driver.Rows, err error) {
+	err = c.run(ctx, func(ctx context.Context) error {
+		rows, err = c.sqliteDriverConn.QueryContext(ctx, query, args)
+		return err
+	})
+	return rows, err
+}
+
+// Ping checks if the connection is alive
+func (c *sqliteContextConn) Ping(ctx context.Context) error {
+	return c.run(ctx, c.sqliteDriverConn.Ping)
+}
+
+// ResetSession discards the connection if a statement running on it got interrupted
+func (c *sqliteContextConn) ResetSession(ctx context.Context) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.interrupted {
+		return driver.ErrBadConn
+	}
+	return nil
+}
+
+// run invokes the driver with a context which is cancelled only if the context of the request is done before the
+// driver returns. The error of the request context is returned for an interrupted statement
+func (c *sqliteContextConn) run(ctx context.Context, fn func(ctx context.Context) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if ctx.Done() == nil {
+		return fn(context.Background())
+	}
+
+	driverCtx := &sqliteDriverContext{Context: context.Background(), done: make(chan struct{})}
+	stop := make(chan struct{})
+	go func() {
+		select {
+		case <-ctx.Done():
+			c.lock.Lock()
+			select {
+			case <-stop:
+			default:
+				c.interrupted = true
+				driverCtx.cancel()
+			}
+			c.lock.Unlock()
+		case <-stop:
+		}
+	}()
+
+	err := fn(driverCtx)
+
+	c.lock.Lock()
+	close(stop)
+	interrupted := driverCtx.Err() != nil
+	c.lock.Unlock()
+
+	if err != nil && interrupted {
+		return ctx.Err()
+	}
+	return err
+}
+
+// sqliteDriverContext is the context passed to the sqlite driver. Unlike a context created with context.WithCancel,
+// it doesn't get cancelled once the statement completes
+type sqliteDriverContext struct {
+	lock sync.Mutex
+	context.Context
+	done chan struct{}
+	err  error
+}
+
+// Done returns a channel which is closed when the statement gets interrupted
+func (c *sqliteDriverContext) Done() <-chan struct{} {
+	return c.done
+}
+
+// Err returns context.Canceled if the statement got interrupted
+func (c *sqliteDriverContext) Err() error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.err
+}
+
+func (c *sqliteDriverContext) cancel() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.err = context.Canceled
+	close(c.done)
+}
+
+// sqliteContextStmt relays the cancellation of a request to a sqlite statement. Check sqliteContextConn for details
+type sqliteContextStmt struct {
+	sqliteDriverStmt
+	conn *sqliteContextConn
+}
+
+// ExecContext executes the statement
+func (s *sqliteContextStmt) ExecContext(ctx context.Context, args []driver.NamedValue) (res driver.Result, err error) {
+	err = s.conn.run(ctx, func(ctx context.Context) error {
+		res, err = s.sqliteDriverStmt.ExecContext(ctx, args)
+		return err
+	})
+	return res, err
+}
+
+// QueryContext executes the statement and returns the resulting rows
+func (s *sqliteContextStmt) QueryContext(ctx context.Context, args []driver.NamedValue) (rows driver.Rows, err error) {
+	err = s.conn.run(ctx, func(ctx context.Context) error {
+		rows, err = s.sqliteDriverStmt.QueryContext(ctx, args)
+		return err
+	})
+	return rows, err
+}
+
+// openSQLite opens a sqlite database. The connection string is the path of the database
+// file or `:memory:` for an in memory database
+func openSQLite(dsn string) *sqlx.DB {
+	// Sqlx uses the driver name to determine the bind type. The sqlite driver uses `?` for bind vars
+	return sqlx.NewDb(sql.OpenDB(&sqliteConnector{dsn: dsn, driver: &sqlite.Driver{}}), "sqlite3")
+}
+
+// parseSQLiteColumnType splits the declared type of a sqlite column into its name and arguments since
+// sqlite doesn't store the size, precision and scale of a column separately. Eg. `varchar(50)` or `decimal(10,2)`
+func parseSQLiteColumnType(field *model.InspectorFieldType) {
+	start := strings.Index(field.FieldType, "(")
+	if start == -1 || !strings.HasSuffix(field.FieldType, ")") {
+		return
+	}
+
+	args := strings.Split(field.FieldType[start+1:len(field.FieldType)-1], ",")
+	field.FieldType = strings.TrimSpace(field.FieldType[:start])
+	for i, arg := range args {
+		args[i] = strings.TrimSpace(arg)
+	}
+
+	switch field.FieldType {
+	case "varchar", "char":
+		field.VarcharSize, _ = strconv.Atoi(args[0])
+	case "decimal", "numeric":
+		field.NumericPrecision, _ = strconv.Atoi(args[0])
+		if len(args) > 1 {
+			field.NumericScale, _ = strconv.Atoi(args[1])
+		}
+	case "time", "datetime", "timestamp":
+		field.DateTimePrecision, _ = strconv.Atoi(args[0])
+	}
+}
@@ -0,0 +1,445 @@
+package sql
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/spaceuptech/space-cloud/gateway/config"
+	"github.com/spaceuptech/space-cloud/gateway/model"
+	"github.com/spaceuptech/space-cloud/gateway/utils"
+	"github.com/spaceuptech/space-cloud/gateway/utils/tracing"
+)
+
+// newSQLiteTestDB returns a sqlite database seeded with authors and the books they have written
+func newSQLiteTestDB(t *testing.T) *SQL {
+	s, err := Init(model.SQLite, true, filepath.Join(t.TempDir(), "test.db"), "", config.DriverConfig{})
+	if err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+	t.Cleanup(func() { _ = s.Close() })
+
+	ctx := context.Background()
+	if err := s.RawBatch(ctx, []string{
+		"CREATE TABLE authors (id varchar(50) NOT NULL, name text, age integer, score double, active boolean DEFAULT true, joined datetime, meta json, PRIMARY KEY (id))",
+		"CREATE TABLE books (id integer NOT NULL PRIMARY KEY AUTOINCREMENT, author_id varchar(50) CONSTRAINT c_books_author_id REFERENCES authors (id) ON DELETE CASCADE, title varchar(100) NOT NULL, price decimal(10,2))",
+		"CREATE UNIQUE INDEX index__books__title ON books (title asc)",
+	}); err != nil {
+		t.Fatalf("RawBatch() error = %v", err)
+	}
+
+	if _, err := s.Create(ctx, "authors", &model.CreateRequest{Operation: utils.All, Document: []interface{}{
+		map[string]interface{}{"id": "1", "name": "John", "age": 20, "score": 1.5, "active": true, "joined": "2020-01-02T03:04:05.123Z", "meta": `{"city":"Mumbai"}`},
+		map[string]interface{}{"id": "2", "name": "Jane", "age": 30, "score": 2.5, "active": false, "joined": "2021-01-02T03:04:05Z", "meta": `{}`},
+	}}); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if _, err := s.Create(ctx, "books", &model.CreateRequest{Operation: utils.All, Document: []interface{}{
+		map[string]interface{}{"author_id": "1", "title": "a", "price": 10.5},
+		map[string]interface{}{"author_id": "1", "title": "b", "price": 20},
+		map[string]interface{}{"author_id": "2", "title": "c", "price": 5},
+	}}); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	return s
+}
+
+func TestSQLite_Read(t *testing.T) {
+	s := newSQLiteTestDB(t)
+	distinct := "author_id"
+	skip, limit := int64(1), int64(1)
+
+	tests := []struct {
+		name      string
+		col       string
+		req       *model.ReadRequest
+		wantCount int64
+		want      interface{}
+		wantErr   bool
+	}{
+		{
+			name:      "read one",
+			col:       "authors",
+			req:       &model.ReadRequest{Operation: utils.One, Find: map[string]interface{}{"id": "1"}},
+			wantCount: 1,
+			want:      map[string]interface{}{"id": "1", "name": "John", "age": int64(20), "score": 1.5, "active": true, "joined": "2020-01-02T03:04:05.123Z", "meta": map[string]interface{}{"city": "Mumbai"}},
+		},
+		{
+			name:    "read one which doesn't exist",
+			col:     "authors",
+			req:     &model.ReadRequest{Operation: utils.One, Find: map[string]interface{}{"id": "3"}},
+			wantErr: true,
+		},
+		{
+			name:    "regex isn't supported",
+			col:     "authors",
+			req:     &model.ReadRequest{Operation: utils.All, Find: map[string]interface{}{"name": map[string]interface{}{"$regex": "^J"}}},
+			wantErr: true,
+		},
+		{
+			name:    "contains isn't supported",
+			col:     "authors",
+			req:     &model.ReadRequest{Operation: utils.All, Find: map[string]interface{}{"meta": map[string]interface{}{"$contains": map[string]interface{}{"city": "Mumbai"}}}},
+			wantErr: true,
+		},
+		{
+			name:      "read all with an empty or clause and like",
+			col:       "authors",
+			req:       &model.ReadRequest{Operation: utils.All, Find: map[string]interface{}{"$or": []interface{}{map[string]interface{}{}}, "name": map[string]interface{}{"$like": "Ja%"}}, Options: &model.ReadOptions{Select: map[string]int32{"id": 1}}},
+			wantCount: 1,
+			want:      []interface{}{map[string]interface{}{"id": "2"}},
+		},
+		{
+			name:      "read all with filter, sort, skip and limit",
+			col:       "books",
+			req:       &model.ReadRequest{Operation: utils.All, Find: map[string]interface{}{"price": map[string]interface{}{"$gte": 5}}, Options: &model.ReadOptions{Select: map[string]int32{"title": 1}, Sort: []string{"-price"}, Skip: &skip, Limit: &limit}},
+			wantCount: 1,
+			want:      []interface{}{map[string]interface{}{"title": "a"}},
+		},
+		{
+			name: "read all after a cursor along with an or clause",
+			col:  "books",
+			req: &model.ReadRequest{Operation: utils.All,
+				Find: map[string]interface{}{
+					"$or":                 []interface{}{map[string]interface{}{"author_id": "1"}, map[string]interface{}{"author_id": "2"}},
+					utils.CursorClauseKey: utils.GenerateCursorClause([]string{"author_id", "-price"}, []interface{}{"1", int64(20)}, false),
+				},
+				Options: &model.ReadOptions{Select: map[string]int32{"title": 1}, Sort: []string{"author_id", "-price"}},
+			},
+			wantCount: 2,
+			want:      []interface{}{map[string]interface{}{"title": "a"}, map[string]interface{}{"title": "c"}},
+		},
+		{
+			name:      "count",
+			col:       "books",
+			req:       &model.ReadRequest{Operation: utils.Count, Find: map[string]interface{}{"author_id": "1"}},
+			wantCount: 2,
+			want:      int64(2),
+		},
+		{
+			name:      "distinct",
+			col:       "books",
+			req:       &model.ReadRequest{Operation: utils.Distinct, Find: map[string]interface{}{}, Options: &model.ReadOptions{Distinct: &distinct, Sort: []string{"author_id"}}},
+			wantCount: 2,
+			want:      []interface{}{map[string]interface{}{"author_id": "1"}, map[string]interface{}{"author_id": "2"}},
+		},
+		{
+			name: "join",
+			col:  "authors",
+			req: &model.ReadRequest{Operation: utils.All, Find: map[string]interface{}{"authors.id": "1"}, Options: &model.ReadOptions{
+				Select: map[string]int32{"authors.name": 1, "books.title": 1},
+				Sort:   []string{"books.title"},
+				Join:   []*model.JoinOption{{Table: "books", Type: "LEFT", Op: utils.All, On: map[string]interface{}{"authors.id": "books.author_id"}}},
+			}},
+			wantCount: 2,
+			want: []interface{}{map[string]interface{}{"name": "John", "books": []interface{}{
+				map[string]interface{}{"author_id": "1", "title": "a"},
+				map[string]interface{}{"author_id": "1", "title": "b"},
+			}}},
+		},
+		{
+			name: "aggregate with group by",
+			col:  "books",
+			req: &model.ReadRequest{Operation: utils.All, Find: map[string]interface{}{}, GroupBy: []interface{}{"author_id"}, Options: &model.ReadOptions{Sort: []string{"author_id"}},
+				Aggregate: map[string][]string{"sum": {"books:price"}, "count": {"books:id"}}},
+			wantCount: 2,
+			want: []interface{}{
+				map[string]interface{}{"aggregate": map[string]interface{}{"sum": map[string]interface{}{"price": 30.5}, "count": map[string]interface{}{"id": int64(2)}}},
+				map[string]interface{}{"aggregate": map[string]interface{}{"sum": map[string]interface{}{"price": int64(5)}, "count": map[string]interface{}{"id": int64(1)}}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			count, got, _, _, err := s.Read(context.Background(), tt.col, tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Read() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if count != tt.wantCount {
+				t.Errorf("Read() count = %v, want %v", count, tt.wantCount)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Read() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSQLite_Update(t *testing.T) {
+	tests := []struct {
+		name      string
+		req       *model.UpdateRequest
+		find      map[string]interface{}
+		wantCount int64
+		want      map[string]interface{}
+		wantErr   bool
+	}{
+		{
+			name:      "set",
+			req:       &model.UpdateRequest{Operation: utils.All, Find: map[string]interface{}{"id": "1"}, Update: map[string]interface{}{"$set": map[string]interface{}{"name": "Johnny"}}},
+			find:      map[string]interface{}{"id": "1"},
+			wantCount: 1,
+			want:      map[string]interface{}{"name": "Johnny", "age": int64(20), "score": 1.5},
+		},
+		{
+			name:      "inc and mul",
+			req:       &model.UpdateRequest{Operation: utils.All, Find: map[string]interface{}{"id": "1"}, Update: map[string]interface{}{"$inc": map[string]interface{}{"age": 2}, "$mul": map[string]interface{}{"score": 2}}},
+			find:      map[string]interface{}{"id": "1"},
+			wantCount: 2,
+			want:      map[string]interface{}{"name": "John", "age": int64(22), "score": float64(3)},
+		},
+		{
+			name:      "max",
+			req:       &model.UpdateRequest{Operation: utils.All, Find: map[string]interface{}{"id": "1"}, Update: map[string]interface{}{"$max": map[string]interface{}{"age": 25, "score": 1}}},
+			find:      map[string]interface{}{"id": "1"},
+			wantCount: 1,
+			want:      map[string]interface{}{"name": "John", "age": int64(25), "score": 1.5},
+		},
+		{
+			name:      "min",
+			req:       &model.UpdateRequest{Operation: utils.All, Find: map[string]interface{}{"id": "2"}, Update: map[string]interface{}{"$min": map[string]interface{}{"age": 25, "score": 5}}},
+			find:      map[string]interface{}{"id": "2"},
+			wantCount: 1,
+			want:      map[string]interface{}{"name": "Jane", "age": int64(25), "score": 2.5},
+		},
+		{
+			name:      "upsert which inserts",
+			req:       &model.UpdateRequest{Operation: utils.Upsert, Find: map[string]interface{}{"id": "3"}, Update: map[string]interface{}{"$set": map[string]interface{}{"name": "Jack", "age": 40}}},
+			find:      map[string]interface{}{"id": "3"},
+			wantCount: 1,
+			want:      map[string]interface{}{"name": "Jack", "age": int64(40), "score": nil},
+		},
+		{
+			name:      "upsert which updates",
+			req:       &model.UpdateRequest{Operation: utils.Upsert, Find: map[string]interface{}{"id": "2"}, Update: map[string]interface{}{"$set": map[string]interface{}{"age": 40}}},
+			find:      map[string]interface{}{"id": "2"},
+			wantCount: 1,
+			want:      map[string]interface{}{"name": "Jane", "age": int64(40), "score": 2.5},
+		},
+		{
+			name:    "invalid operator",
+			req:     &model.UpdateRequest{Operation: utils.All, Find: map[string]interface{}{"id": "1"}, Update: map[string]interface{}{"$push": map[string]interface{}{"age": 1}}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newSQLiteTestDB(t)
+			ctx := context.Background()
+
+			count, err := s.Update(ctx, "authors", tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Update() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if count != tt.wantCount {
+				t.Errorf("Update() count = %v, want %v", count, tt.wantCount)
+			}
+
+			_, got, _, _, err := s.Read(ctx, "authors", &model.ReadRequest{Operation: utils.One, Find: tt.find, Options: &model.ReadOptions{Select: map[string]int32{"name": 1, "age": 1, "score": 1}}})
+			if err != nil {
+				t.Fatalf("Read() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Update() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSQLite_CurrentDate(t *testing.T) {
+	s := newSQLiteTestDB(t)
+	ctx := context.Background()
+
+	req := &model.UpdateRequest{Operation: utils.All, Find: map[string]interface{}{"id": "1"}, Update: map[string]interface{}{"$currentDate": map[string]interface{}{"joined": map[string]interface{}{"$type": "timestamp"}}}}
+	if _, err := s.Update(ctx, "authors", req); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	_, got, _, _, err := s.Read(ctx, "authors", &model.ReadRequest{Operation: utils.One, Find: map[string]interface{}{"id": "1"}})
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if joined := got.(map[string]interface{})["joined"]; joined == "2020-01-02T03:04:05.123Z" {
+		t.Errorf("Update() joined = %v, want the current time", joined)
+	}
+}
+
+func TestSQLite_DeleteCascade(t *testing.T) {
+	s := newSQLiteTestDB(t)
+	ctx := context.Background()
+
+	count, err := s.Delete(ctx, "authors", &model.DeleteRequest{Operation: utils.All, Find: map[string]interface{}{"id": "1"}})
+	if err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if count != 1 {
+		t.Errorf("Delete() count = %v, want 1", count)
+	}
+
+	// The books of the author must have been deleted by the foreign key constraint
+	count, _, _, _, err = s.Read(ctx, "books", &model.ReadRequest{Operation: utils.Count, Find: map[string]interface{}{}})
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if count != 1 {
+		t.Errorf("Delete() books left = %v, want 1", count)
+	}
+
+	// Foreign keys must be enforced on inserts as well
+	if _, err := s.Create(ctx, "books", &model.CreateRequest{Operation: utils.One, Document: map[string]interface{}{"author_id": "1", "title": "d"}}); err == nil {
+		t.Errorf("Create() expected error for book with invalid author")
+	}
+}
+
+func TestSQLite_Timeout(t *testing.T) {
+	s := newSQLiteTestDB(t)
+	client := s.getClient()
+	client.SetMaxOpenConns(1)
+
+	// A query which runs for much longer than the deadline must get interrupted
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	var count int64
+	err := client.QueryRowContext(ctx, "WITH RECURSIVE c(x) AS (SELECT 1 UNION ALL SELECT x+1 FROM c LIMIT 1000000000) SELECT count(*) FROM c").Scan(&count)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("QueryRowContext() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	// The interrupted connection must not affect the queries which follow
+	for i := 0; i < 3; i++ {
+		if err := client.QueryRowContext(context.Background(), "SELECT count(*) FROM authors").Scan(&count); err != nil {
+			t.Fatalf("QueryRowContext() error = %v", err)
+		}
+	}
+}
+
+func TestSQLite_Batch(t *testing.T) {
+	s := newSQLiteTestDB(t)
+	ctx := context.Background()
+
+	counts, err := s.Batch(ctx, &model.BatchRequest{Requests: []*model.AllRequest{
+		{Type: string(model.Create), Col: "authors", Operation: utils.One, Document: map[string]interface{}{"id": "3", "name": "Jack"}},
+		{Type: string(model.Update), Col: "books", Operation: utils.All, Find: map[string]interface{}{"author_id": "1"}, Update: map[string]interface{}{"$set": map[string]interface{}{"author_id": "3"}}},
+		{Type: string(model.Delete), Col: "authors", Operation: utils.All, Find: map[string]interface{}{"id": "1"}},
+	}})
+	if err != nil {
+		t.Fatalf("Batch() error = %v", err)
+	}
+	if want := []int64{1, 2, 1}; !reflect.DeepEqual(counts, want) {
+		t.Errorf("Batch() = %v, want %v", counts, want)
+	}
+
+	// A failing request must roll back the whole batch
+	if _, err := s.Batch(ctx, &model.BatchRequest{Requests: []*model.AllRequest{
+		{Type: string(model.Delete), Col: "books", Operation: utils.All, Find: map[string]interface{}{}},
+		{Type: string(model.Create), Col: "authors", Operation: utils.One, Document: map[string]interface{}{"id": "2", "name": "Duplicate"}},
+	}}); err == nil {
+		t.Fatalf("Batch() expected error for duplicate primary key")
+	}
+	count, _, _, _, err := s.Read(ctx, "books", &model.ReadRequest{Operation: utils.Count, Find: map[string]interface{}{}})
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if count != 3 {
+		t.Errorf("Batch() books after rollback = %v, want 3", count)
+	}
+}
+
+func TestSQLite_DescribeTable(t *testing.T) {
+	s := newSQLiteTestDB(t)
+
+	fields, indexes, err := s.DescribeTable(context.Background(), "books")
+	if err != nil {
+		t.Fatalf("DescribeTable() error = %v", err)
+	}
+	wantFields := []model.InspectorFieldType{
+		{TableName: "books", ColumnName: "id", FieldType: "integer", FieldNull: "NO", OrdinalPosition: "1", AutoIncrement: "true"},
+		{TableName: "books", ColumnName: "author_id", FieldType: "varchar", FieldNull: "YES", OrdinalPosition: "2", AutoIncrement: "false", VarcharSize: 50, ConstraintName: "c_books_author_id", DeleteRule: "CASCADE", RefTableName: "authors", RefColumnName: "id"},
+		{TableName: "books", ColumnName: "title", FieldType: "varchar", FieldNull: "NO", OrdinalPosition: "3", AutoIncrement: "false", VarcharSize: 100},
+		{TableName: "books", ColumnName: "price", FieldType: "decimal", FieldNull: "YES", OrdinalPosition: "4", AutoIncrement: "false", NumericPrecision: 10, NumericScale: 2},
+	}
+	if !reflect.DeepEqual(fields, wantFields) {
+		t.Errorf("DescribeTable() fields = %+v, want %+v", fields, wantFields)
+	}
+	wantIndexes := []model.IndexType{
+		{TableName: "books", ColumnName: "id", IndexName: "PRIMARY", Order: 1, Sort: "asc", IsUnique: true, IsPrimary: true},
+		{TableName: "books", ColumnName: "title", IndexName: "index__books__title", Order: 1, Sort: "asc", IsUnique: true},
+	}
+	if !reflect.DeepEqual(indexes, wantIndexes) {
+		t.Errorf("DescribeTable() indexes = %+v, want %+v", indexes, wantIndexes)
+	}
+
+	fields, _, err = s.DescribeTable(context.Background(), "authors")
+	if err != nil {
+		t.Fatalf("DescribeTable() error = %v", err)
+	}
+	if fields[4].ColumnName != "active" || fields[4].FieldDefault != "true" {
+		t.Errorf("DescribeTable() default = %+v, want true for column active", fields[4])
+	}
+
+	if _, _, err := s.DescribeTable(context.Background(), "unknown"); err == nil {
+		t.Errorf("DescribeTable() expected error for unknown table")
+	}
+}
+
+func TestSQLite_Collections(t *testing.T) {
+	s := newSQLiteTestDB(t)
+	ctx := context.Background()
+
+	got, err := s.GetCollections(ctx)
+	if err != nil {
+		t.Fatalf("GetCollections() error = %v", err)
+	}
+	if want := []utils.DatabaseCollections{{TableName: "authors"}, {TableName: "books"}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("GetCollections() = %v, want %v", got, want)
+	}
+
+	if err := s.DeleteCollection(ctx, "books"); err != nil {
+		t.Fatalf("DeleteCollection() error = %v", err)
+	}
+	got, err = s.GetCollections(ctx)
+	if err != nil {
+		t.Fatalf("GetCollections() error = %v", err)
+	}
+	if want := []utils.DatabaseCollections{{TableName: "authors"}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("GetCollections() after delete = %v, want %v", got, want)
+	}
+}
+
+func TestSQLite_tracing(t *testing.T) {
+	s := newSQLiteTestDB(t)
+
+	exporter := tracing.NewInMemoryExporter()
+	tracing.SetTracer(tracing.New(exporter, 1))
+	defer tracing.SetTracer(nil)
+
+	ctx, parent := tracing.StartSpan(context.Background(), "db.read authors", tracing.SpanKindClient)
+	if _, _, _, _, err := s.Read(ctx, "authors", &model.ReadRequest{Operation: utils.All, Find: map[string]interface{}{"id": "1"}, Options: &model.ReadOptions{}}); err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if _, err := s.Delete(ctx, "books", &model.DeleteRequest{Operation: utils.All, Find: map[string]interface{}{"title": "a"}}); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	parent.End()
+
+	spans := exporter.GetSpansByName("db.query")
+	if len(spans) != 2 {
+		t.Fatalf("exported query spans = %d, want 2", len(spans))
+	}
+	for i, want := range []string{"SELECT * FROM authors WHERE (id = ?)", "DELETE FROM books WHERE (title = ?)"} {
+		if got, _ := spans[i].Attribute("db.statement"); got != want {
+			t.Errorf("query span (%d) statement = %v, want %v", i, got, want)
+		}
+		if spans[i].ParentSpanID != parent.SpanContext().SpanID {
+			t.Errorf("query span (%d) is not a child of the operation span", i)
+		}
+	}
+}
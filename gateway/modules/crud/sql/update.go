@@ -8,7 +8,6 @@ import (
 	"strconv"
 	"strings"
 
-	"github.com/doug-martin/goqu/v8"
 	"github.com/spaceuptech/helpers"
 
 	_ "github.com/denisenkom/go-mssqldb"                // Import for MsSQL
@@ -28,6 +27,7 @@ func (s *SQL) Update(ctx context.Context, col string, req *model.UpdateRequest)
 	}
 	count, err := s.update(ctx, col, req, tx)
 	if err != nil {
+		_ = tx.Rollback()
 		return 0, err
 	}
 	return count, tx.Commit() // commit the Batch
@@ -141,12 +141,16 @@ func (s *SQL) generateUpdateQuery(ctx context.Context, col string, req *model.Up
 	if dbType == string(model.SQLServer) {
 		dbType = string(model.Postgres)
 	}
-	dialect := goqu.Dialect(dbType)
+	dialect := getDialect(dbType)
 	query := dialect.From(s.getColName(col)).Prepared(true)
 
 	if req.Find != nil {
 		// Get the where clause from query object
-		query = s.generateWhereClause(ctx, query, req.Find, nil)
+		var err error
+		query, err = s.generateWhereClause(ctx, query, req.Find, nil)
+		if err != nil {
+			return "", nil, err
+		}
 	}
 
 	if req.Update == nil {
@@ -193,7 +197,7 @@ func (s *SQL) generateUpdateQuery(ctx context.Context, col string, req *model.Up
 			if err != nil {
 				return "", nil, err
 			}
-			if s.dbType == string(model.MySQL) {
+			if s.dbType == string(model.MySQL) || s.dbType == string(model.SQLite) {
 				sqlString = strings.Replace(sqlString, k+"=?", k+"="+k+"+?", -1)
 			}
 			if dbType == string(model.Postgres) {
@@ -208,7 +212,7 @@ func (s *SQL) generateUpdateQuery(ctx context.Context, col string, req *model.Up
 			if err != nil {
 				return "", nil, err
 			}
-			if dbType == string(model.MySQL) {
+			if dbType == string(model.MySQL) || dbType == string(model.SQLite) {
 				sqlString = strings.Replace(sqlString, k+"=?", k+"="+k+"*?", -1)
 			}
 			if dbType == string(model.Postgres) {
@@ -226,6 +230,10 @@ func (s *SQL) generateUpdateQuery(ctx context.Context, col string, req *model.Up
 			if s.dbType == string(model.MySQL) {
 				sqlString = strings.Replace(sqlString, k+"=?", k+"=GREATEST("+k+","+"?"+")", -1)
 			}
+			if s.dbType == string(model.SQLite) {
+				// Sqlite uses the multi-argument max function instead of greatest
+				sqlString = strings.Replace(sqlString, k+"=?", k+"=MAX("+k+","+"?"+")", -1)
+			}
 			if dbType == string(model.Postgres) {
 				sqlString = strings.Replace(sqlString, k+"=$", k+"=GREATEST("+k+","+"$"+"", -1)
 			}
@@ -241,6 +249,10 @@ func (s *SQL) generateUpdateQuery(ctx context.Context, col string, req *model.Up
 			if dbType == string(model.MySQL) {
 				sqlString = strings.Replace(sqlString, k+"=?", k+"=LEAST("+k+","+"?"+")", -1)
 			}
+			if dbType == string(model.SQLite) {
+				// Sqlite uses the multi-argument min function instead of least
+				sqlString = strings.Replace(sqlString, k+"=?", k+"=MIN("+k+","+"?"+")", -1)
+			}
 			if dbType == string(model.Postgres) {
 				sqlString = strings.Replace(sqlString, k+"=$", k+"=LEAST("+k+","+"$", -1)
 			}
@@ -253,7 +265,7 @@ func (s *SQL) generateUpdateQuery(ctx context.Context, col string, req *model.Up
 			if !ok {
 				return "", nil, utils.ErrInvalidParams
 			}
-			if dbType == string(model.MySQL) {
+			if dbType == string(model.MySQL) || dbType == string(model.SQLite) {
 				sqlString = strings.Replace(sqlString, k+"=?", k+"="+val, -1)
 			}
 			if dbType == string(model.Postgres) {
@@ -0,0 +1,217 @@
+package eventing
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronSchedule is a parsed cron expression. Each field is stored as a bit set of the allowed values
+type cronSchedule struct {
+	minute, hour, dom, month, dow uint64
+	location                      *time.Location
+}
+
+type cronField struct {
+	name     string
+	min, max int
+	names    map[string]int
+}
+
+var (
+	cronMinute = cronField{name: "minute", min: 0, max: 59}
+	cronHour   = cronField{name: "hour", min: 0, max: 23}
+	cronDom    = cronField{name: "day of month", min: 1, max: 31}
+	cronMonth  = cronField{name: "month", min: 1, max: 12, names: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	// Both 0 and 7 represent sunday
+	cronDow = cronField{name: "day of week", min: 0, max: 7, names: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+var cronDescriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// parseCronSchedule parses a standard 5 field cron expression (minute hour day-of-month month day-of-week)
+// evaluated in the provided time zone. An empty time zone defaults to UTC
+func parseCronSchedule(expr, timeZone string) (*cronSchedule, error) {
+	location := time.UTC
+	if timeZone != "" {
+		l, err := time.LoadLocation(timeZone)
+		if err != nil {
+			return nil, fmt.Errorf("invalid time zone (%s) provided - %v", timeZone, err)
+		}
+		location = l
+	}
+
+	expr = strings.TrimSpace(expr)
+	if descriptor, ok := cronDescriptors[strings.ToLower(expr)]; ok {
+		expr = descriptor
+	}
+
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("invalid cron schedule (%s) provided - expected 5 fields but got %d", expr, len(fields))
+	}
+
+	s := &cronSchedule{location: location}
+	var err error
+	if s.minute, err = parseCronField(fields[0], cronMinute); err != nil {
+		return nil, err
+	}
+	if s.hour, err = parseCronField(fields[1], cronHour); err != nil {
+		return nil, err
+	}
+	if s.dom, err = parseCronField(fields[2], cronDom); err != nil {
+		return nil, err
+	}
+	if s.month, err = parseCronField(fields[3], cronMonth); err != nil {
+		return nil, err
+	}
+	if s.dow, err = parseCronField(fields[4], cronDow); err != nil {
+		return nil, err
+	}
+
+	// Fold 7 into 0 since both represent sunday
+	if s.dow&(1<<7) != 0 {
+		s.dow = (s.dow | 1) &^ (1 << 7)
+	}
+
+	// A restricted day of month and day of week are OR'ed together as per the cron convention. We
+	// represent an unrestricted field as the empty set to make that check easy while matching
+	if fields[2] == "*" || fields[2] == "?" {
+		s.dom = 0
+	}
+	if fields[4] == "*" || fields[4] == "?" {
+		s.dow = 0
+	}
+
+	return s, nil
+}
+
+func parseCronField(value string, field cronField) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(value, ",") {
+		rangeExpr, step := part, 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			s, err := strconv.Atoi(part[i+1:])
+			if err != nil || s <= 0 {
+				return 0, fmt.Errorf("invalid step (%s) provided in %s field", part[i+1:], field.name)
+			}
+			rangeExpr, step = part[:i], s
+		}
+
+		var start, end int
+		switch {
+		case rangeExpr == "*" || rangeExpr == "?":
+			start, end = field.min, field.max
+		case strings.Contains(rangeExpr, "-"):
+			bounds := strings.SplitN(rangeExpr, "-", 2)
+			var err error
+			if start, err = parseCronValue(bounds[0], field); err != nil {
+				return 0, err
+			}
+			if end, err = parseCronValue(bounds[1], field); err != nil {
+				return 0, err
+			}
+			if start > end {
+				return 0, fmt.Errorf("invalid range (%s) provided in %s field", rangeExpr, field.name)
+			}
+		default:
+			v, err := parseCronValue(rangeExpr, field)
+			if err != nil {
+				return 0, err
+			}
+			start, end = v, v
+			// A step on a single value means "starting from the value"
+			if step > 1 {
+				end = field.max
+			}
+		}
+
+		for i := start; i <= end; i += step {
+			bits |= 1 << uint(i)
+		}
+	}
+	return bits, nil
+}
+
+func parseCronValue(value string, field cronField) (int, error) {
+	if v, ok := field.names[strings.ToLower(value)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(value)
+	if err != nil || v < field.min || v > field.max {
+		return 0, fmt.Errorf("invalid value (%s) provided in %s field - must be between %d and %d", value, field.name, field.min, field.max)
+	}
+	return v, nil
+}
+
+// next returns the first tick of the schedule strictly after the provided time. A zero time is returned if no
+// tick exists within the next five years (for example "0 0 30 2 *")
+func (s *cronSchedule) next(t time.Time) time.Time {
+	t = t.In(s.location).Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+
+	for t.Before(limit) {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = nextCronTime(t, time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, s.location))
+			continue
+		}
+		if !s.matchesDay(t) {
+			t = nextCronTime(t, time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, s.location))
+			continue
+		}
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = nextCronHour(t)
+			continue
+		}
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// nextCronTime guards against time.Date normalising a wall clock time which falls in a daylight saving gap to a
+// time before t. The schedule then moves ahead an hour at a time instead
+func nextCronTime(t, next time.Time) time.Time {
+	if next.After(t) {
+		return next
+	}
+	return nextCronHour(t)
+}
+
+// nextCronHour returns the start of the next hour. Adding the remaining minutes skips hours which don't exist
+// on the wall clock due to daylight saving
+func nextCronHour(t time.Time) time.Time {
+	return t.Add(time.Duration(60-t.Minute()) * time.Minute)
+}
+
+func (s *cronSchedule) matchesDay(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+	switch {
+	case s.dom == 0 && s.dow == 0:
+		return true
+	case s.dom == 0:
+		return dowMatch
+	case s.dow == 0:
+		return domMatch
+	default:
+		return domMatch || dowMatch
+	}
+}
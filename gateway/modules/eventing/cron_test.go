@@ -0,0 +1,134 @@
+package eventing
+
+import (
+	"testing"
+	"time"
+)
+
+func Test_parseCronSchedule(t *testing.T) {
+	tests := []struct {
+		name     string
+		expr     string
+		timeZone string
+		wantErr  bool
+	}{
+		{name: "every minute", expr: "* * * * *"},
+		{name: "ranges, lists and steps", expr: "*/15 9-17 1,15 1-12/2 mon-fri"},
+		{name: "names", expr: "0 0 * jan,jul sun"},
+		{name: "descriptor", expr: "@daily"},
+		{name: "time zone", expr: "0 2 * * *", timeZone: "Asia/Kolkata"},
+		{name: "too few fields", expr: "* * * *", wantErr: true},
+		{name: "value out of range", expr: "60 * * * *", wantErr: true},
+		{name: "invalid step", expr: "*/0 * * * *", wantErr: true},
+		{name: "invalid range", expr: "0 10-5 * * *", wantErr: true},
+		{name: "invalid name", expr: "0 0 * foo *", wantErr: true},
+		{name: "invalid time zone", expr: "0 0 * * *", timeZone: "Mars/Olympus", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseCronSchedule(tt.expr, tt.timeZone)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseCronSchedule() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_cronSchedule_next(t *testing.T) {
+	kolkata, err := time.LoadLocation("Asia/Kolkata")
+	if err != nil {
+		t.Skipf("Unable to load time zone - %v", err)
+	}
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("Unable to load time zone - %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		expr     string
+		timeZone string
+		from     time.Time
+		want     time.Time
+	}{
+		{
+			name: "every minute",
+			expr: "* * * * *",
+			from: time.Date(2020, 1, 1, 10, 0, 30, 0, time.UTC),
+			want: time.Date(2020, 1, 1, 10, 1, 0, 0, time.UTC),
+		},
+		{
+			name: "next tick is strictly after the provided time",
+			expr: "0 * * * *",
+			from: time.Date(2020, 1, 1, 10, 0, 0, 0, time.UTC),
+			want: time.Date(2020, 1, 1, 11, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "steps",
+			expr: "*/15 * * * *",
+			from: time.Date(2020, 1, 1, 10, 16, 0, 0, time.UTC),
+			want: time.Date(2020, 1, 1, 10, 30, 0, 0, time.UTC),
+		},
+		{
+			name: "rolls over to the next year",
+			expr: "@yearly",
+			from: time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC),
+			want: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "day of week",
+			expr: "30 8 * * mon",
+			from: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), // Wednesday
+			want: time.Date(2020, 1, 6, 8, 30, 0, 0, time.UTC),
+		},
+		{
+			name: "seven is sunday",
+			expr: "0 0 * * 7",
+			from: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+			want: time.Date(2020, 1, 5, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "day of month and day of week are or'ed",
+			expr: "0 0 15 * fri",
+			from: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+			want: time.Date(2020, 1, 3, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "leap day",
+			expr: "0 0 29 2 *",
+			from: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			want: time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "time zone",
+			expr:     "0 2 * * *",
+			timeZone: "Asia/Kolkata",
+			from:     time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+			want:     time.Date(2020, 1, 2, 2, 0, 0, 0, kolkata),
+		},
+		{
+			name:     "daylight saving time gap",
+			expr:     "30 2 * * *",
+			timeZone: "America/New_York",
+			from:     time.Date(2020, 3, 8, 0, 0, 0, 0, newYork),
+			want:     time.Date(2020, 3, 9, 2, 30, 0, 0, newYork),
+		},
+		{
+			name: "impossible schedule",
+			expr: "0 0 30 2 *",
+			from: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+			want: time.Time{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := parseCronSchedule(tt.expr, tt.timeZone)
+			if err != nil {
+				t.Fatalf("parseCronSchedule() error = %v", err)
+			}
+			if got := s.next(tt.from); !got.Equal(tt.want) {
+				t.Errorf("next() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
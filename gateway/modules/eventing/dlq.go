@@ -0,0 +1,234 @@
+package eventing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/spaceuptech/helpers"
+
+	"github.com/spaceuptech/space-cloud/gateway/model"
+	"github.com/spaceuptech/space-cloud/gateway/utils"
+)
+
+const defaultDLQLimit int64 = 100
+
+// dlqReplayBatchSize is the number of events of the dead letter queue replayed at a time
+var dlqReplayBatchSize int64 = model.DefaultFetchLimit
+
+// ListDLQEvents lists the events of the dead letter queue matching the filter
+func (m *Module) ListDLQEvents(ctx context.Context, filter *model.DLQFilter) ([]*model.EventDocument, error) {
+	find, err := generateDLQFind(filter, utils.EventStatusFailed)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultDLQLimit
+	}
+	if limit > model.DefaultFetchLimit {
+		limit = model.DefaultFetchLimit
+	}
+	skip := filter.Skip
+
+	return m.readEvents(ctx, &model.ReadRequest{Find: find, Operation: utils.All, Options: &model.ReadOptions{Sort: []string{"-ts"}, Limit: &limit, Skip: &skip}})
+}
+
+// GetDLQEvent returns an event of the dead letter queue along with the history of its invocations
+func (m *Module) GetDLQEvent(ctx context.Context, id string) (*model.EventDocument, []*model.InvocationDocument, error) {
+	find := map[string]interface{}{"_id": id, "status": map[string]interface{}{"$in": []interface{}{utils.EventStatusFailed, utils.EventStatusDiscarded}}}
+	events, err := m.readEvents(ctx, &model.ReadRequest{Find: find, Operation: utils.All})
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(events) == 0 {
+		return nil, nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Event (%s) does not exist", id), nil, nil)
+	}
+
+	m.lock.RLock()
+	dbAlias := m.config.DBAlias
+	m.lock.RUnlock()
+
+	attr := map[string]string{"project": m.project, "db": dbAlias, "col": utils.TableInvocationLogs}
+	reqParams := model.RequestParams{Resource: "db-read", Op: "access", Attributes: attr}
+	readRequest := &model.ReadRequest{Find: map[string]interface{}{"event_id": id}, Operation: utils.All, Options: &model.ReadOptions{Sort: []string{"invocation_time"}}}
+	results, _, err := m.crud.Read(ctx, dbAlias, utils.TableInvocationLogs, readRequest, reqParams)
+	if err != nil {
+		return nil, nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Unable to read invocations of event (%s)", id), err, nil)
+	}
+
+	invocations := make([]*model.InvocationDocument, 0)
+	for _, result := range results.([]interface{}) {
+		invocation := new(model.InvocationDocument)
+		if err := mapstructure.WeakDecode(result, invocation); err != nil {
+			return nil, nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Unable to decode invocation of event (%s)", id), err, nil)
+		}
+		invocations = append(invocations, invocation)
+	}
+	return events[0], invocations, nil
+}
+
+// ReplayDLQEvents stages the failed or discarded events matching the filter again. It returns the number of replayed events
+func (m *Module) ReplayDLQEvents(ctx context.Context, req *model.DLQReplayRequest) (int, error) {
+	if req.Payload != nil && len(req.IDs) != 1 {
+		return 0, helpers.Logger.LogError(helpers.GetRequestID(ctx), "Payload can only be replaced while replaying a single event", nil, nil)
+	}
+
+	find, err := generateDLQFind(&req.DLQFilter, "")
+	if err != nil {
+		return 0, err
+	}
+	if _, ok := find["status"]; !ok {
+		find["status"] = map[string]interface{}{"$in": []interface{}{utils.EventStatusFailed, utils.EventStatusDiscarded}}
+	} else if req.Status != utils.EventStatusFailed && req.Status != utils.EventStatusDiscarded {
+		return 0, helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Events having status (%s) cannot be replayed", req.Status), nil, nil)
+	}
+	if isDLQFilterEmpty(&req.DLQFilter) {
+		return 0, helpers.Logger.LogError(helpers.GetRequestID(ctx), "Filter of events to replay is empty. Set all to true to replay every event of the dead letter queue", nil, nil)
+	}
+
+	// Events are replayed in batches to keep the memory in check. The batches are read after the last replayed event
+	// so that an event which fails again while the replay is in progress doesn't get replayed twice
+	replayed := 0
+	for {
+		events, err := m.readEvents(ctx, &model.ReadRequest{Find: find, Operation: utils.All, Options: &model.ReadOptions{Sort: []string{"ts", "_id"}, Limit: &dlqReplayBatchSize}})
+		if err != nil {
+			return replayed, err
+		}
+		if len(events) == 0 {
+			return replayed, nil
+		}
+
+		if err := m.replayEvents(ctx, events, req.Payload); err != nil {
+			return replayed, err
+		}
+		replayed += len(events)
+
+		if int64(len(events)) < dlqReplayBatchSize {
+			return replayed, nil
+		}
+		last := events[len(events)-1]
+		find[utils.CursorClauseKey] = utils.GenerateCursorClause([]string{"ts", "_id"}, []interface{}{last.Timestamp, last.ID}, false)
+	}
+}
+
+// replayEvents stages the events again and sends them to the nodes responsible for them. The timestamp of the events
+// is retained while the time of the next attempt is set to now
+func (m *Module) replayEvents(ctx context.Context, events []*model.EventDocument, payload interface{}) error {
+	ids := make([]interface{}, len(events))
+	for i, event := range events {
+		ids[i] = event.ID
+	}
+
+	nextAttempt := time.Now().Format(time.RFC3339Nano)
+	set := map[string]interface{}{"status": utils.EventStatusStaged, "next_attempt": nextAttempt, "retries": 0, "remark": "Replayed from dead letter queue"}
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return err
+		}
+		set["payload"] = string(data)
+		events[0].Payload = string(data)
+	}
+
+	updateRequest := &model.UpdateRequest{Find: map[string]interface{}{"_id": map[string]interface{}{"$in": ids}}, Operation: utils.All, Update: map[string]interface{}{"$set": set}}
+	if err := m.crud.InternalUpdate(ctx, m.config.DBAlias, m.project, utils.TableEventingLogs, updateRequest); err != nil {
+		return helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to replay events of dead letter queue", err, nil)
+	}
+
+	// Send the events to the nodes responsible for them so that they get processed right away
+	for _, event := range events {
+		event.Status, event.NextAttempt, event.Retries = utils.EventStatusStaged, nextAttempt, 0
+		m.transmitEvents(event.Token, []*model.EventDocument{event})
+	}
+	return nil
+}
+
+// DiscardDLQEvents marks the failed events matching the filter as discarded
+func (m *Module) DiscardDLQEvents(ctx context.Context, filter *model.DLQFilter) error {
+	if filter.Status != "" && filter.Status != utils.EventStatusFailed {
+		return helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Events having status (%s) cannot be discarded", filter.Status), nil, nil)
+	}
+	if isDLQFilterEmpty(filter) {
+		return helpers.Logger.LogError(helpers.GetRequestID(ctx), "Filter of events to discard is empty. Set all to true to discard every event of the dead letter queue", nil, nil)
+	}
+	find, err := generateDLQFind(filter, utils.EventStatusFailed)
+	if err != nil {
+		return err
+	}
+
+	updateRequest := &model.UpdateRequest{Find: find, Operation: utils.All, Update: map[string]interface{}{"$set": map[string]interface{}{"status": utils.EventStatusDiscarded}}}
+	if err := m.crud.InternalUpdate(ctx, m.config.DBAlias, m.project, utils.TableEventingLogs, updateRequest); err != nil {
+		return helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to discard events of dead letter queue", err, nil)
+	}
+	return nil
+}
+
+func (m *Module) readEvents(ctx context.Context, readRequest *model.ReadRequest) ([]*model.EventDocument, error) {
+	m.lock.RLock()
+	dbAlias := m.config.DBAlias
+	m.lock.RUnlock()
+
+	attr := map[string]string{"project": m.project, "db": dbAlias, "col": utils.TableEventingLogs}
+	reqParams := model.RequestParams{Resource: "db-read", Op: "access", Attributes: attr}
+	results, _, err := m.crud.Read(ctx, dbAlias, utils.TableEventingLogs, readRequest, reqParams)
+	if err != nil {
+		return nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to read events from eventing logs", err, nil)
+	}
+
+	events := make([]*model.EventDocument, 0)
+	for _, result := range results.([]interface{}) {
+		event := new(model.EventDocument)
+		if err := mapstructure.WeakDecode(result, event); err != nil {
+			return nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Could not covert object (%v) as event doc", result), err, nil)
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+// isDLQFilterEmpty tells if the filter selects every event of the dead letter queue without it being asked for explicitly
+func isDLQFilterEmpty(filter *model.DLQFilter) bool {
+	return !filter.All && len(filter.IDs) == 0 && filter.Trigger == "" && filter.From == "" && filter.To == ""
+}
+
+// generateDLQFind generates the find clause for the filter. The default status is used if the filter doesn't have one
+func generateDLQFind(filter *model.DLQFilter, defaultStatus string) (map[string]interface{}, error) {
+	find := map[string]interface{}{}
+	if len(filter.IDs) > 0 {
+		ids := make([]interface{}, len(filter.IDs))
+		for i, id := range filter.IDs {
+			ids[i] = id
+		}
+		find["_id"] = map[string]interface{}{"$in": ids}
+	}
+
+	if filter.Status != "" {
+		find["status"] = filter.Status
+	} else if defaultStatus != "" {
+		find["status"] = defaultStatus
+	}
+
+	if filter.Trigger != "" {
+		find["rule_name"] = filter.Trigger
+	}
+
+	ts := map[string]interface{}{}
+	for op, value := range map[string]string{"$gte": filter.From, "$lte": filter.To} {
+		if value == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timestamp (%s) provided - %v", value, err)
+		}
+		ts[op] = t.UTC().Format(time.RFC3339Nano)
+	}
+	if len(ts) > 0 {
+		find["ts"] = ts
+	}
+	return find, nil
+}
@@ -0,0 +1,213 @@
+package eventing
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/spaceuptech/space-cloud/gateway/config"
+	"github.com/spaceuptech/space-cloud/gateway/model"
+	"github.com/spaceuptech/space-cloud/gateway/utils"
+)
+
+func Test_generateDLQFind(t *testing.T) {
+	tests := []struct {
+		name          string
+		filter        *model.DLQFilter
+		defaultStatus string
+		want          map[string]interface{}
+		wantErr       bool
+	}{
+		{
+			name:          "default status",
+			filter:        &model.DLQFilter{},
+			defaultStatus: utils.EventStatusFailed,
+			want:          map[string]interface{}{"status": utils.EventStatusFailed},
+		},
+		{
+			name:          "all filters",
+			filter:        &model.DLQFilter{IDs: []string{"1", "2"}, Trigger: "trigger", Status: utils.EventStatusDiscarded, From: "2020-10-01T10:00:00+05:30", To: "2020-10-02T00:00:00Z"},
+			defaultStatus: utils.EventStatusFailed,
+			want: map[string]interface{}{
+				"_id":       map[string]interface{}{"$in": []interface{}{"1", "2"}},
+				"status":    utils.EventStatusDiscarded,
+				"rule_name": "trigger",
+				"ts":        map[string]interface{}{"$gte": "2020-10-01T04:30:00Z", "$lte": "2020-10-02T00:00:00Z"},
+			},
+		},
+		{
+			name:    "invalid timestamp",
+			filter:  &model.DLQFilter{From: "yesterday"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := generateDLQFind(tt.filter, tt.defaultStatus)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("generateDLQFind() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("generateDLQFind() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestModule_ReplayDLQEvents(t *testing.T) {
+	readFind := map[string]interface{}{"rule_name": "trigger", "status": map[string]interface{}{"$in": []interface{}{utils.EventStatusFailed, utils.EventStatusDiscarded}}}
+	readLimit := int64(model.DefaultFetchLimit)
+	readRequest := &model.ReadRequest{Find: readFind, Operation: utils.All, Options: &model.ReadOptions{Sort: []string{"ts", "_id"}, Limit: &readLimit}}
+
+	tests := []struct {
+		name      string
+		req       *model.DLQReplayRequest
+		readDocs  []interface{}
+		isUpdated func(req *model.UpdateRequest) bool
+		want      int
+		wantErr   bool
+	}{
+		{
+			name:     "events of trigger are replayed",
+			req:      &model.DLQReplayRequest{DLQFilter: model.DLQFilter{Trigger: "trigger"}},
+			readDocs: []interface{}{map[string]interface{}{"_id": "1", "token": 10, "status": "failed"}, map[string]interface{}{"_id": "2", "token": 20, "status": "discarded"}},
+			isUpdated: func(req *model.UpdateRequest) bool {
+				set := req.Update["$set"].(map[string]interface{})
+				_, hasPayload := set["payload"]
+				_, hasTimestamp := set["ts"]
+				_, hasNextAttempt := set["next_attempt"]
+				return reflect.DeepEqual(req.Find, map[string]interface{}{"_id": map[string]interface{}{"$in": []interface{}{"1", "2"}}}) && set["status"] == utils.EventStatusStaged && set["retries"] == 0 && !hasPayload && !hasTimestamp && hasNextAttempt
+			},
+			want: 2,
+		},
+		{
+			name:     "no events to replay",
+			req:      &model.DLQReplayRequest{DLQFilter: model.DLQFilter{Trigger: "trigger"}},
+			readDocs: []interface{}{},
+		},
+		{
+			name:    "payload with multiple events",
+			req:     &model.DLQReplayRequest{DLQFilter: model.DLQFilter{IDs: []string{"1", "2"}}, Payload: map[string]interface{}{"id": 1}},
+			wantErr: true,
+		},
+		{
+			name:    "processed events cannot be replayed",
+			req:     &model.DLQReplayRequest{DLQFilter: model.DLQFilter{Status: utils.EventStatusProcessed}},
+			wantErr: true,
+		},
+		{
+			name:    "empty filter without all",
+			req:     &model.DLQReplayRequest{DLQFilter: model.DLQFilter{Status: utils.EventStatusFailed}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &Module{project: "abc", config: &config.Eventing{DBAlias: "db"}}
+			mockCrud := mockCrudInterface{}
+			mockSyncman := mockSyncmanEventingInterface{}
+			if tt.readDocs != nil {
+				mockCrud.On("Read", mock.Anything, "db", utils.TableEventingLogs, readRequest, mock.Anything).Return(tt.readDocs, new(model.SQLMetaData), nil)
+			}
+			if tt.isUpdated != nil {
+				mockCrud.On("InternalUpdate", mock.Anything, "db", "abc", utils.TableEventingLogs, mock.MatchedBy(tt.isUpdated)).Return(nil)
+				mockSyncman.On("GetAssignedSpaceCloudID", mock.Anything, "abc", mock.Anything).Return("node", nil)
+			}
+			m.crud = &mockCrud
+			m.syncMan = &mockSyncman
+
+			got, err := m.ReplayDLQEvents(context.Background(), tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ReplayDLQEvents() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ReplayDLQEvents() got = %v, want %v", got, tt.want)
+			}
+			mockCrud.AssertExpectations(t)
+			mockSyncman.AssertExpectations(t)
+		})
+	}
+}
+
+func TestModule_ReplayDLQEvents_batches(t *testing.T) {
+	defer func(size int64) { dlqReplayBatchSize = size }(dlqReplayBatchSize)
+	dlqReplayBatchSize = 2
+
+	status := map[string]interface{}{"$in": []interface{}{utils.EventStatusFailed, utils.EventStatusDiscarded}}
+	firstRead := &model.ReadRequest{Find: map[string]interface{}{"status": status}, Operation: utils.All, Options: &model.ReadOptions{Sort: []string{"ts", "_id"}, Limit: &dlqReplayBatchSize}}
+	secondRead := &model.ReadRequest{Find: map[string]interface{}{
+		"status": status,
+		utils.CursorClauseKey: []interface{}{
+			map[string]interface{}{"ts": map[string]interface{}{"$gt": "2020-10-01T00:00:02Z"}},
+			map[string]interface{}{"ts": "2020-10-01T00:00:02Z", "_id": map[string]interface{}{"$gt": "2"}},
+		},
+	}, Operation: utils.All, Options: &model.ReadOptions{Sort: []string{"ts", "_id"}, Limit: &dlqReplayBatchSize}}
+
+	m := &Module{project: "abc", config: &config.Eventing{DBAlias: "db"}}
+	mockCrud := mockCrudInterface{}
+	mockSyncman := mockSyncmanEventingInterface{}
+	mockCrud.On("Read", mock.Anything, "db", utils.TableEventingLogs, firstRead, mock.Anything).Return([]interface{}{
+		map[string]interface{}{"_id": "1", "ts": "2020-10-01T00:00:01Z", "status": "failed"},
+		map[string]interface{}{"_id": "2", "ts": "2020-10-01T00:00:02Z", "status": "failed"},
+	}, new(model.SQLMetaData), nil).Once()
+	mockCrud.On("Read", mock.Anything, "db", utils.TableEventingLogs, secondRead, mock.Anything).Return([]interface{}{
+		map[string]interface{}{"_id": "3", "ts": "2020-10-01T00:00:03Z", "status": "failed"},
+	}, new(model.SQLMetaData), nil).Once()
+	mockCrud.On("InternalUpdate", mock.Anything, "db", "abc", utils.TableEventingLogs, mock.Anything).Return(nil).Twice()
+	mockSyncman.On("GetAssignedSpaceCloudID", mock.Anything, "abc", mock.Anything).Return("node", nil)
+	m.crud = &mockCrud
+	m.syncMan = &mockSyncman
+
+	got, err := m.ReplayDLQEvents(context.Background(), &model.DLQReplayRequest{DLQFilter: model.DLQFilter{All: true}})
+	if err != nil {
+		t.Fatalf("ReplayDLQEvents() error = %v", err)
+	}
+	if got != 3 {
+		t.Errorf("ReplayDLQEvents() got = %v, want %v", got, 3)
+	}
+	mockCrud.AssertExpectations(t)
+}
+
+func TestModule_GetDLQEvent(t *testing.T) {
+	m := &Module{project: "abc", config: &config.Eventing{DBAlias: "db"}}
+	mockCrud := mockCrudInterface{}
+	eventRead := &model.ReadRequest{Find: map[string]interface{}{"_id": "1", "status": map[string]interface{}{"$in": []interface{}{utils.EventStatusFailed, utils.EventStatusDiscarded}}}, Operation: utils.All}
+	invocationRead := &model.ReadRequest{Find: map[string]interface{}{"event_id": "1"}, Operation: utils.All, Options: &model.ReadOptions{Sort: []string{"invocation_time"}}}
+	mockCrud.On("Read", mock.Anything, "db", utils.TableEventingLogs, eventRead, mock.Anything).Return([]interface{}{map[string]interface{}{"_id": "1", "status": "failed"}}, new(model.SQLMetaData), nil)
+	mockCrud.On("Read", mock.Anything, "db", utils.TableInvocationLogs, invocationRead, mock.Anything).Return([]interface{}{map[string]interface{}{"_id": "i1", "event_id": "1"}}, new(model.SQLMetaData), nil)
+	m.crud = &mockCrud
+
+	event, invocations, err := m.GetDLQEvent(context.Background(), "1")
+	if err != nil {
+		t.Fatalf("GetDLQEvent() error = %v", err)
+	}
+	if event.ID != "1" || len(invocations) != 1 || invocations[0].ID != "i1" {
+		t.Errorf("GetDLQEvent() got event = %v invocations = %v", event, invocations)
+	}
+	mockCrud.AssertExpectations(t)
+}
+
+func TestModule_DiscardDLQEvents(t *testing.T) {
+	m := &Module{project: "abc", config: &config.Eventing{DBAlias: "db"}}
+	mockCrud := mockCrudInterface{}
+	updateRequest := &model.UpdateRequest{
+		Find:      map[string]interface{}{"status": utils.EventStatusFailed, "rule_name": "trigger"},
+		Operation: utils.All,
+		Update:    map[string]interface{}{"$set": map[string]interface{}{"status": utils.EventStatusDiscarded}},
+	}
+	mockCrud.On("InternalUpdate", mock.Anything, "db", "abc", utils.TableEventingLogs, updateRequest).Return(nil)
+	m.crud = &mockCrud
+
+	if err := m.DiscardDLQEvents(context.Background(), &model.DLQFilter{Trigger: "trigger"}); err != nil {
+		t.Errorf("DiscardDLQEvents() error = %v", err)
+	}
+	if err := m.DiscardDLQEvents(context.Background(), &model.DLQFilter{Status: utils.EventStatusStaged}); err == nil {
+		t.Errorf("DiscardDLQEvents() expected error while discarding staged events")
+	}
+	if err := m.DiscardDLQEvents(context.Background(), &model.DLQFilter{}); err == nil {
+		t.Errorf("DiscardDLQEvents() expected error while discarding with an empty filter")
+	}
+	mockCrud.AssertExpectations(t)
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/spaceuptech/space-cloud/gateway/managers/syncman"
 	"github.com/spaceuptech/space-cloud/gateway/model"
 	schemaHelpers "github.com/spaceuptech/space-cloud/gateway/modules/schema/helpers"
+	"github.com/spaceuptech/space-cloud/gateway/utils"
 	"github.com/spaceuptech/space-cloud/gateway/utils/pubsub"
 )
 
@@ -31,6 +32,7 @@ type Module struct {
 
 	// Atomic maps to handle events being processed
 	processingEvents sync.Map
+	pollingIntents   sync.Map // ids of the file intents whose files are being polled
 
 	// Variables defined during initialisation
 	auth model.AuthEventingInterface
@@ -41,10 +43,17 @@ type Module struct {
 
 	schemas    map[string]model.Fields
 	metricHook model.MetricEventingHook
+	// deliveryHook is invoked for every attempt to deliver an event to the webhook of a trigger
+	deliveryHook model.MetricEventingDeliveryHook
 	// stores mapping of batchID w.r.t channel for sending synchronous event response
 	eventChanMap sync.Map // key here is batchID
 	tickerIntent *time.Ticker
 	tickerStaged *time.Ticker
+	tickerCron   *time.Ticker
+
+	// Parsed schedules of cron triggers and the last tick fired for each of them
+	cronSchedules map[string]*cronSchedule
+	cronLastTicks map[cronTickKey]time.Time
 
 	// Templates for body transformation
 	templates map[string]*template.Template
@@ -83,11 +92,15 @@ func New(clusterID, projectID, nodeID string, auth model.AuthEventingInterface,
 		config:       &config.Eventing{Enabled: false, InternalRules: make(config.EventingTriggers)},
 		templates:    map[string]*template.Template{},
 		pubsubClient: pubsubClient,
+
+		cronSchedules: map[string]*cronSchedule{},
+		cronLastTicks: map[cronTickKey]time.Time{},
 	}
 
 	// Start the internal processes
 	go m.routineProcessIntents()
 	go m.routineProcessStaged()
+	go m.routineProcessCron()
 	go m.routineHandleMessages()
 	go m.routineHandleEventResponseMessages()
 	m.createProcessUpdateEventsRoutine()
@@ -160,6 +173,7 @@ func (m *Module) SetTriggerConfig(triggers config.EventingTriggers) error {
 	}
 
 	m.templates = map[string]*template.Template{}
+	m.cronSchedules = map[string]*cronSchedule{}
 	for name, trigger := range m.config.Rules {
 		trigger.ID = name
 
@@ -173,6 +187,18 @@ func (m *Module) SetTriggerConfig(triggers config.EventingTriggers) error {
 			trigger.OpFormat = "yaml"
 		}
 
+		if err := validateRetryPolicy(trigger.RetryPolicy); err != nil {
+			return helpers.Logger.LogError(helpers.GetRequestID(context.TODO()), fmt.Sprintf("Invalid retry policy provided for trigger (%s)", trigger.ID), err, nil)
+		}
+
+		if trigger.Type == utils.EventCron {
+			schedule, err := parseCronSchedule(trigger.Schedule, trigger.TimeZone)
+			if err != nil {
+				return helpers.Logger.LogError(helpers.GetRequestID(context.TODO()), fmt.Sprintf("Invalid schedule provided for cron trigger (%s)", trigger.ID), err, nil)
+			}
+			m.cronSchedules[trigger.ID] = schedule
+		}
+
 		switch trigger.Tmpl {
 		case config.TemplatingEngineGo:
 			if trigger.RequestTemplate != "" {
@@ -192,6 +218,14 @@ func (m *Module) SetTriggerConfig(triggers config.EventingTriggers) error {
 	return nil
 }
 
+// SetDeliveryHook sets the metric hook invoked on every event delivery
+func (m *Module) SetDeliveryHook(hook model.MetricEventingDeliveryHook) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.deliveryHook = hook
+}
+
 // SetSecurityRuleConfig set security rule config of eventing module
 func (m *Module) SetSecurityRuleConfig(rules map[string]*config.Rule) error {
 	m.lock.Lock()
@@ -236,5 +270,6 @@ func (m *Module) CloseConfig() error {
 	}
 	m.tickerIntent.Stop()
 	m.tickerStaged.Stop()
+	m.tickerCron.Stop()
 	return nil
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"math/rand"
+	"time"
 
 	"github.com/spaceuptech/helpers"
 
@@ -13,6 +14,16 @@ import (
 
 // CreateFileIntentHook handles the create file intent request
 func (m *Module) CreateFileIntentHook(ctx context.Context, req *model.CreateFileRequest) (*model.EventIntent, error) {
+	return m.createFileIntent(ctx, req, 0)
+}
+
+// CreateSignedFileIntentHook handles the create file intent of an upload made directly to the file store using a
+// signed url. Since the gateway isn't notified of the upload, the file is polled till the url expires
+func (m *Module) CreateSignedFileIntentHook(ctx context.Context, req *model.CreateFileRequest, expiry time.Duration) (*model.EventIntent, error) {
+	return m.createFileIntent(ctx, req, expiry)
+}
+
+func (m *Module) createFileIntent(ctx context.Context, req *model.CreateFileRequest, delay time.Duration) (*model.EventIntent, error) {
 	m.lock.RLock()
 	defer m.lock.RUnlock()
 
@@ -25,7 +36,8 @@ func (m *Module) CreateFileIntentHook(ctx context.Context, req *model.CreateFile
 	token := rand.Intn(utils.MaxEventTokens)
 	batchID := m.generateBatchID()
 
-	rules := m.getMatchingRules(ctx, &model.QueueEventRequest{Type: utils.EventFileCreate, Options: map[string]string{}})
+	// Triggers can be restricted to a file store with the store option
+	rules := m.getMatchingRules(ctx, &model.QueueEventRequest{Type: utils.EventFileCreate, Options: map[string]string{"store": utils.GetFileStoreAlias(req.Store)}})
 
 	// Process the documents
 	eventDocs := make([]*model.EventDocument, 0)
@@ -35,11 +47,13 @@ func (m *Module) CreateFileIntentHook(ctx context.Context, req *model.CreateFile
 	}
 	for _, rule := range rules {
 		eventDoc := m.generateQueueEventRequest(ctx, token, rule, batchID, utils.EventStatusIntent, &model.QueueEventRequest{
-			Type: utils.EventFileCreate,
+			Type:  utils.EventFileCreate,
+			Delay: delay.Milliseconds(),
 			Payload: &model.FilePayload{
-				Meta: req.Meta,
-				Path: path,
-				Type: req.Type,
+				Store: req.Store,
+				Meta:  req.Meta,
+				Path:  path,
+				Type:  req.Type,
 			},
 		})
 		eventDocs = append(eventDocs, eventDoc)
@@ -59,7 +73,7 @@ func (m *Module) CreateFileIntentHook(ctx context.Context, req *model.CreateFile
 }
 
 // DeleteFileIntentHook handles the delete file intent requests
-func (m *Module) DeleteFileIntentHook(ctx context.Context, path string, meta map[string]interface{}) (*model.EventIntent, error) {
+func (m *Module) DeleteFileIntentHook(ctx context.Context, store, path string, meta map[string]interface{}) (*model.EventIntent, error) {
 	m.lock.RLock()
 	defer m.lock.RUnlock()
 
@@ -72,15 +86,17 @@ func (m *Module) DeleteFileIntentHook(ctx context.Context, path string, meta map
 	batchID := m.generateBatchID()
 	token := rand.Intn(utils.MaxEventTokens)
 
-	rules := m.getMatchingRules(ctx, &model.QueueEventRequest{Type: utils.EventFileDelete, Options: map[string]string{}})
+	// Triggers can be restricted to a file store with the store option
+	rules := m.getMatchingRules(ctx, &model.QueueEventRequest{Type: utils.EventFileDelete, Options: map[string]string{"store": utils.GetFileStoreAlias(store)}})
 	// Process the documents
 	eventDocs := make([]*model.EventDocument, 0)
 	for _, rule := range rules {
 		eventDoc := m.generateQueueEventRequest(ctx, token, rule, batchID, utils.EventStatusIntent, &model.QueueEventRequest{
 			Type: utils.EventFileDelete,
 			Payload: &model.FilePayload{
-				Path: path,
-				Meta: meta,
+				Store: store,
+				Path:  path,
+				Meta:  meta,
 			},
 		})
 		eventDocs = append(eventDocs, eventDoc)
@@ -72,6 +72,25 @@ func TestModule_CreateFileIntentHook(t *testing.T) {
 			},
 			want: &model.EventIntent{Docs: []*model.EventDocument{{Type: utils.EventFileCreate, RuleName: "rule", Timestamp: time.Now().Format(time.RFC3339Nano), Payload: `{"meta":{},"path":"path"}`, Status: "intent"}}},
 		},
+		{
+			name: "rule restricted to another file store",
+			m:    &Module{project: "abc", config: &config.Eventing{DBAlias: "dbtype", Enabled: true, Rules: map[string]*config.EventingTrigger{"rule": {Type: utils.EventFileCreate, ID: "rule", Options: map[string]string{"store": "media"}}}}},
+			args: args{ctx: context.Background(), req: &model.CreateFileRequest{Meta: map[string]interface{}{}, Path: "path"}},
+			want: &model.EventIntent{Invalid: true},
+		},
+		{
+			name: "file intent request of a named file store handled",
+			m:    &Module{project: "abc", config: &config.Eventing{DBAlias: "dbtype", Enabled: true, Rules: map[string]*config.EventingTrigger{"rule": {Type: utils.EventFileCreate, ID: "rule", Options: map[string]string{"store": "media"}}}}},
+			args: args{ctx: context.Background(), req: &model.CreateFileRequest{Meta: map[string]interface{}{}, Path: "path", Store: "media"}},
+			crudMockArgs: []mockArgs{
+				{
+					method:         "InternalCreate",
+					args:           []interface{}{mock.Anything, "dbtype", "abc", utils.TableEventingLogs, mock.Anything, false},
+					paramsReturned: []interface{}{nil},
+				},
+			},
+			want: &model.EventIntent{Docs: []*model.EventDocument{{Type: utils.EventFileCreate, RuleName: "rule", Timestamp: time.Now().Format(time.RFC3339Nano), Payload: `{"meta":{},"path":"path","store":"media"}`, Status: "intent"}}},
+		},
 	}
 
 	for _, tt := range tests {
@@ -139,9 +158,10 @@ func TestModule_DeleteFileIntentHook(t *testing.T) {
 		paramsReturned []interface{}
 	}
 	type args struct {
-		ctx  context.Context
-		path string
-		meta map[string]interface{}
+		ctx   context.Context
+		store string
+		path  string
+		meta  map[string]interface{}
 	}
 	tests := []struct {
 		name            string
@@ -191,6 +211,12 @@ func TestModule_DeleteFileIntentHook(t *testing.T) {
 			},
 			want: &model.EventIntent{Docs: []*model.EventDocument{{Type: utils.EventFileDelete, RuleName: "rule", Timestamp: time.Now().Format(time.RFC3339Nano), Payload: `{"meta":{},"path":"path"}`, Status: "intent"}}},
 		},
+		{
+			name: "rule restricted to another file store",
+			m:    &Module{project: "abc", config: &config.Eventing{DBAlias: "dbtype", Enabled: true, Rules: map[string]*config.EventingTrigger{"rule": {Type: utils.EventFileDelete, ID: "rule", Options: map[string]string{"store": "media"}}}}},
+			args: args{ctx: context.Background(), store: "backups", meta: map[string]interface{}{}, path: "path"},
+			want: &model.EventIntent{Invalid: true},
+		},
 	}
 
 	for _, tt := range tests {
@@ -209,7 +235,7 @@ func TestModule_DeleteFileIntentHook(t *testing.T) {
 			tt.m.syncMan = &mockSyncman
 			tt.m.crud = &mockCrud
 
-			got, err := tt.m.DeleteFileIntentHook(context.Background(), tt.args.path, tt.args.meta)
+			got, err := tt.m.DeleteFileIntentHook(context.Background(), tt.args.store, tt.args.path, tt.args.meta)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Module.DeleteFileIntentHook() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -362,3 +388,31 @@ func TestModule_HookStage(t *testing.T) {
 		})
 	}
 }
+
+func TestModule_CreateSignedFileIntentHook(t *testing.T) {
+	m := &Module{project: "abc", config: &config.Eventing{DBAlias: "dbtype", Enabled: true, Rules: map[string]*config.EventingTrigger{"rule": {Type: utils.EventFileCreate, ID: "rule"}}}}
+	mockCrud := mockCrudInterface{}
+	mockCrud.On("InternalCreate", mock.Anything, "dbtype", "abc", utils.TableEventingLogs, mock.Anything, false).Return(nil)
+	m.crud = &mockCrud
+
+	got, err := m.CreateSignedFileIntentHook(context.Background(), &model.CreateFileRequest{Meta: map[string]interface{}{}, Path: "path", Name: "file", Type: "file"}, time.Hour)
+	if err != nil {
+		t.Fatalf("Module.CreateSignedFileIntentHook() error = %v", err)
+	}
+	if len(got.Docs) != 1 {
+		t.Fatalf("Module.CreateSignedFileIntentHook() got %d docs, want 1", len(got.Docs))
+	}
+
+	// The intent should be scheduled for when the signed url expires
+	ts, err := time.Parse(time.RFC3339Nano, got.Docs[0].Timestamp)
+	if err != nil {
+		t.Fatalf("Module.CreateSignedFileIntentHook() invalid timestamp - %v", err)
+	}
+	if ts.Before(time.Now().Add(59*time.Minute)) || ts.After(time.Now().Add(time.Hour)) {
+		t.Errorf("Module.CreateSignedFileIntentHook() timestamp = %v, want an hour from now", ts)
+	}
+	if got.Docs[0].Status != utils.EventStatusIntent {
+		t.Errorf("Module.CreateSignedFileIntentHook() status = %v, want %v", got.Docs[0].Status, utils.EventStatusIntent)
+	}
+	mockCrud.AssertExpectations(t)
+}
@@ -0,0 +1,193 @@
+package eventing
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash/crc32"
+	"time"
+
+	"github.com/spaceuptech/helpers"
+
+	"github.com/spaceuptech/space-cloud/gateway/config"
+	"github.com/spaceuptech/space-cloud/gateway/model"
+	"github.com/spaceuptech/space-cloud/gateway/utils"
+)
+
+// maxCronCatchUpTicks is the max number of missed ticks of a trigger which get fired while catching up. Only the
+// latest ticks are fired if more were missed
+const maxCronCatchUpTicks = 100
+
+type cronTickKey struct {
+	trigger, schedule, timeZone string
+}
+
+type cronTrigger struct {
+	rule     *config.EventingTrigger
+	schedule *cronSchedule
+}
+
+// processCronTriggers queues an event for every tick of the cron triggers owned by this node. Each trigger is
+// mapped to a single token so only one gateway in the cluster fires it
+func (m *Module) processCronTriggers(t *time.Time) {
+	// Return if module is not enabled
+	if !m.IsEnabled() {
+		return
+	}
+
+	m.lock.RLock()
+	dbAlias, project := m.config.DBAlias, m.project
+	triggers := make(map[string]cronTrigger, len(m.cronSchedules))
+	for id, schedule := range m.cronSchedules {
+		if rule, ok := m.config.Rules[id]; ok {
+			triggers[id] = cronTrigger{rule: rule, schedule: schedule}
+		}
+	}
+	m.lock.RUnlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	start, end := m.syncMan.GetAssignedTokens()
+
+	if m.cronLastTicks == nil {
+		m.cronLastTicks = map[cronTickKey]time.Time{}
+	}
+
+	// Forget the triggers which have been removed
+	for key := range m.cronLastTicks {
+		if _, ok := triggers[key.trigger]; !ok {
+			delete(m.cronLastTicks, key)
+		}
+	}
+
+	for id, trigger := range triggers {
+		key := cronTickKey{trigger: id, schedule: trigger.rule.Schedule, timeZone: trigger.rule.TimeZone}
+
+		// Skip the trigger if its token isn't assigned to this node. The last tick is forgotten so that we don't
+		// fire ticks which were already fired by another node if the token gets assigned back to us
+		token := getCronToken(project, id)
+		if token < start || token > end {
+			delete(m.cronLastTicks, key)
+			continue
+		}
+
+		// The ticks missed while the trigger wasn't tracked by this node are caught up from the last fired tick
+		lastTick, ok := m.cronLastTicks[key]
+		if !ok {
+			tick, err := m.getLastCronTick(ctx, trigger.rule, *t)
+			if err != nil {
+				continue
+			}
+			lastTick = tick
+		}
+
+		fromTick := lastTick
+		var ticks []time.Time
+		for tick := trigger.schedule.next(lastTick); !tick.IsZero() && !tick.After(*t); tick = trigger.schedule.next(tick) {
+			ticks = append(ticks, tick)
+			lastTick = tick
+		}
+		m.cronLastTicks[key] = lastTick
+
+		if len(ticks) > maxCronCatchUpTicks {
+			helpers.Logger.LogWarn(helpers.GetRequestID(ctx), fmt.Sprintf("Skipping %d missed ticks of cron trigger (%s)", len(ticks)-maxCronCatchUpTicks, id), nil)
+			ticks = ticks[len(ticks)-maxCronCatchUpTicks:]
+		}
+		eventDocs := make([]*model.EventDocument, len(ticks))
+		for i, tick := range ticks {
+			eventDocs[i] = m.generateCronEventRequest(ctx, token, trigger.rule, project, tick)
+		}
+
+		// The ticks which couldn't be logged are retried in the next run
+		if queued, err := m.queueCronEvents(ctx, dbAlias, project, token, eventDocs); err != nil {
+			m.cronLastTicks[key] = fromTick
+			if queued > 0 {
+				m.cronLastTicks[key] = ticks[queued-1]
+			}
+		}
+	}
+}
+
+// getLastCronTick returns the tick of the latest event logged for the cron trigger. The provided time is returned
+// if the trigger has never fired with its current schedule
+func (m *Module) getLastCronTick(ctx context.Context, rule *config.EventingTrigger, t time.Time) (time.Time, error) {
+	limit := int64(1)
+	find := map[string]interface{}{"rule_name": rule.ID, "type": utils.EventCron}
+	events, err := m.readEvents(ctx, &model.ReadRequest{Find: find, Operation: utils.All, Options: &model.ReadOptions{Sort: []string{"-ts"}, Limit: &limit}})
+	if err != nil {
+		return time.Time{}, err
+	}
+	if len(events) == 0 {
+		return t, nil
+	}
+
+	payload := map[string]interface{}{}
+	switch v := events[0].Payload.(type) {
+	case string:
+		_ = json.Unmarshal([]byte(v), &payload)
+	case map[string]interface{}:
+		payload = v
+	}
+	if payload["schedule"] != rule.Schedule {
+		return t, nil
+	}
+
+	tick, err := time.Parse(time.RFC3339Nano, events[0].Timestamp)
+	if err != nil {
+		return t, nil
+	}
+	return tick, nil
+}
+
+// queueCronEvents logs and transmits the events of a cron trigger in the order of their ticks. It stops at the first
+// event which couldn't be logged and returns the number of events which were queued before it
+func (m *Module) queueCronEvents(ctx context.Context, dbAlias, project string, token int, eventDocs []*model.EventDocument) (int, error) {
+	created := make([]*model.EventDocument, 0, len(eventDocs))
+	defer func() {
+		if len(created) > 0 {
+			m.transmitEvents(token, created)
+		}
+	}()
+
+	for i, eventDoc := range eventDocs {
+		// The event id is derived from the tick. Hence creating the same event twice (for example while tokens are
+		// being rebalanced) fails on the primary key which guarantees that the tick is fired only once
+		createRequest := &model.CreateRequest{Document: convertToArray([]*model.EventDocument{eventDoc}), Operation: utils.All, IsBatch: true}
+		if err := m.crud.InternalCreate(ctx, dbAlias, project, utils.TableEventingLogs, createRequest, false); err != nil {
+			if utils.IsDuplicateKeyError(err) {
+				helpers.Logger.LogInfo(helpers.GetRequestID(ctx), fmt.Sprintf("Skipping cron event (%s) of trigger (%s) as it has already been logged", eventDoc.ID, eventDoc.RuleName), nil)
+				continue
+			}
+			return i, helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Unable to log cron event (%s) of trigger (%s)", eventDoc.ID, eventDoc.RuleName), err, nil)
+		}
+		created = append(created, eventDoc)
+	}
+	return len(eventDocs), nil
+}
+
+func (m *Module) generateCronEventRequest(ctx context.Context, token int, rule *config.EventingTrigger, project string, tick time.Time) *model.EventDocument {
+	scheduledTime := tick.UTC().Format(time.RFC3339Nano)
+	req := &model.QueueEventRequest{
+		Type:      utils.EventCron,
+		Timestamp: scheduledTime,
+		Payload:   map[string]interface{}{"trigger": rule.ID, "schedule": rule.Schedule, "scheduledTime": scheduledTime},
+	}
+
+	eventDoc := m.generateQueueEventRequestRaw(ctx, token, rule, getCronEventID(project, rule.ID, tick), m.generateBatchID(), utils.EventStatusStaged, req)
+	eventDoc.TriggerType = "external"
+	return eventDoc
+}
+
+// getCronToken returns the token a cron trigger is assigned to
+func getCronToken(project, triggerID string) int {
+	return int(crc32.ChecksumIEEE([]byte(project+"/"+triggerID)) % uint32(utils.MaxEventTokens))
+}
+
+// getCronEventID returns a deterministic id for the event of a single tick of a cron trigger
+func getCronEventID(project, triggerID string, tick time.Time) string {
+	hash := sha256.Sum256([]byte(fmt.Sprintf("%s/%s/%d", project, triggerID, tick.Unix())))
+	return "cron-" + hex.EncodeToString(hash[:])[:32]
+}
@@ -0,0 +1,200 @@
+package eventing
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/spaceuptech/space-cloud/gateway/config"
+	"github.com/spaceuptech/space-cloud/gateway/model"
+	"github.com/spaceuptech/space-cloud/gateway/utils"
+)
+
+func TestModule_processCronTriggers(t *testing.T) {
+	project := "project"
+	rule := &config.EventingTrigger{ID: "nightly", Type: utils.EventCron, Schedule: "*/5 * * * *"}
+	token := getCronToken(project, rule.ID)
+	lastTick := time.Date(2020, 1, 1, 10, 0, 0, 0, time.UTC)
+	key := cronTickKey{trigger: rule.ID, schedule: rule.Schedule}
+
+	tests := []struct {
+		name          string
+		start, end    int
+		lastTicks     map[cronTickKey]time.Time
+		lastEvents    []interface{}
+		readErr       error
+		now           time.Time
+		createErr     error
+		wantEvents    []string
+		wantLastTicks map[cronTickKey]time.Time
+	}{
+		{
+			name:          "first observation of a trigger which never fired does not fire",
+			start:         0,
+			end:           utils.MaxEventTokens - 1,
+			lastTicks:     map[cronTickKey]time.Time{},
+			lastEvents:    []interface{}{},
+			now:           lastTick,
+			wantLastTicks: map[cronTickKey]time.Time{key: lastTick},
+		},
+		{
+			name:          "first observation catches up the ticks missed since the last logged event",
+			start:         0,
+			end:           utils.MaxEventTokens - 1,
+			lastTicks:     map[cronTickKey]time.Time{},
+			lastEvents:    []interface{}{map[string]interface{}{"_id": "1", "ts": lastTick.Format(time.RFC3339Nano), "payload": `{"schedule":"*/5 * * * *"}`}},
+			now:           lastTick.Add(11 * time.Minute),
+			wantEvents:    []string{getCronEventID(project, rule.ID, lastTick.Add(5*time.Minute)), getCronEventID(project, rule.ID, lastTick.Add(10*time.Minute))},
+			wantLastTicks: map[cronTickKey]time.Time{key: lastTick.Add(10 * time.Minute)},
+		},
+		{
+			name:          "first observation ignores events logged with another schedule",
+			start:         0,
+			end:           utils.MaxEventTokens - 1,
+			lastTicks:     map[cronTickKey]time.Time{},
+			lastEvents:    []interface{}{map[string]interface{}{"_id": "1", "ts": lastTick.Format(time.RFC3339Nano), "payload": `{"schedule":"* * * * *"}`}},
+			now:           lastTick.Add(11 * time.Minute),
+			wantLastTicks: map[cronTickKey]time.Time{key: lastTick.Add(11 * time.Minute)},
+		},
+		{
+			name:          "only the latest missed ticks are caught up",
+			start:         0,
+			end:           utils.MaxEventTokens - 1,
+			lastTicks:     map[cronTickKey]time.Time{},
+			lastEvents:    []interface{}{map[string]interface{}{"_id": "1", "ts": lastTick.Add(-5 * maxCronCatchUpTicks * time.Minute).Format(time.RFC3339Nano), "payload": `{"schedule":"*/5 * * * *"}`}},
+			now:           lastTick.Add(10 * time.Minute),
+			wantEvents:    getCronEventIDs(project, rule.ID, lastTick.Add(-5*(maxCronCatchUpTicks-3)*time.Minute), maxCronCatchUpTicks),
+			wantLastTicks: map[cronTickKey]time.Time{key: lastTick.Add(10 * time.Minute)},
+		},
+		{
+			name:          "trigger is skipped if the last logged event cannot be read",
+			start:         0,
+			end:           utils.MaxEventTokens - 1,
+			lastTicks:     map[cronTickKey]time.Time{},
+			readErr:       errors.New("db down"),
+			now:           lastTick,
+			wantLastTicks: map[cronTickKey]time.Time{},
+		},
+		{
+			name:          "no tick since the last run",
+			start:         0,
+			end:           utils.MaxEventTokens - 1,
+			lastTicks:     map[cronTickKey]time.Time{key: lastTick},
+			now:           lastTick.Add(4 * time.Minute),
+			wantLastTicks: map[cronTickKey]time.Time{key: lastTick},
+		},
+		{
+			name:          "fires every tick since the last run",
+			start:         0,
+			end:           utils.MaxEventTokens - 1,
+			lastTicks:     map[cronTickKey]time.Time{key: lastTick},
+			now:           lastTick.Add(11 * time.Minute),
+			wantEvents:    []string{getCronEventID(project, rule.ID, lastTick.Add(5*time.Minute)), getCronEventID(project, rule.ID, lastTick.Add(10*time.Minute))},
+			wantLastTicks: map[cronTickKey]time.Time{key: lastTick.Add(10 * time.Minute)},
+		},
+		{
+			name:          "tick already logged by another node is not transmitted",
+			start:         0,
+			end:           utils.MaxEventTokens - 1,
+			lastTicks:     map[cronTickKey]time.Time{key: lastTick},
+			now:           lastTick.Add(5 * time.Minute),
+			createErr:     errors.New("duplicate key"),
+			wantEvents:    []string{getCronEventID(project, rule.ID, lastTick.Add(5*time.Minute))},
+			wantLastTicks: map[cronTickKey]time.Time{key: lastTick.Add(5 * time.Minute)},
+		},
+		{
+			name:          "ticks which cannot be logged are retried in the next run",
+			start:         0,
+			end:           utils.MaxEventTokens - 1,
+			lastTicks:     map[cronTickKey]time.Time{key: lastTick},
+			now:           lastTick.Add(11 * time.Minute),
+			createErr:     errors.New("db down"),
+			wantEvents:    []string{getCronEventID(project, rule.ID, lastTick.Add(5*time.Minute))},
+			wantLastTicks: map[cronTickKey]time.Time{key: lastTick},
+		},
+		{
+			name:          "token not assigned to this node",
+			start:         token + 1,
+			end:           token + 1,
+			lastTicks:     map[cronTickKey]time.Time{key: lastTick},
+			now:           lastTick.Add(5 * time.Minute),
+			wantLastTicks: map[cronTickKey]time.Time{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := parseCronSchedule(rule.Schedule, rule.TimeZone)
+			if err != nil {
+				t.Fatalf("parseCronSchedule() error = %v", err)
+			}
+
+			mockCrud := new(mockCrudInterface)
+			mockSyncman := new(mockSyncmanEventingInterface)
+			mockSyncman.On("GetAssignedTokens").Return(tt.start, tt.end)
+			if tt.lastEvents != nil || tt.readErr != nil {
+				limit := int64(1)
+				readRequest := &model.ReadRequest{Find: map[string]interface{}{"rule_name": rule.ID, "type": utils.EventCron}, Operation: utils.All, Options: &model.ReadOptions{Sort: []string{"-ts"}, Limit: &limit}}
+				mockCrud.On("Read", mock.Anything, "db", utils.TableEventingLogs, readRequest, mock.Anything).Return(tt.lastEvents, new(model.SQLMetaData), tt.readErr)
+			}
+
+			var created []string
+			for _, id := range tt.wantEvents {
+				id := id
+				mockCrud.On("InternalCreate", mock.Anything, "db", project, utils.TableEventingLogs, mock.MatchedBy(func(req *model.CreateRequest) bool {
+					docs := req.Document.([]interface{})
+					return len(docs) == 1 && docs[0].(map[string]interface{})["_id"] == id
+				}), false).Run(func(args mock.Arguments) { created = append(created, id) }).Return(tt.createErr)
+			}
+			if len(tt.wantEvents) > 0 && tt.createErr == nil {
+				mockSyncman.On("GetAssignedSpaceCloudID", mock.Anything, project, token).Return("node", nil)
+			}
+
+			m := &Module{
+				project:       project,
+				config:        &config.Eventing{Enabled: true, DBAlias: "db", Rules: config.EventingTriggers{rule.ID: rule}},
+				crud:          mockCrud,
+				syncMan:       mockSyncman,
+				cronSchedules: map[string]*cronSchedule{rule.ID: schedule},
+				cronLastTicks: tt.lastTicks,
+			}
+			m.processCronTriggers(&tt.now)
+
+			mockCrud.AssertExpectations(t)
+			mockSyncman.AssertExpectations(t)
+			if len(created) != len(tt.wantEvents) {
+				t.Errorf("processCronTriggers() created = %v, want %v", created, tt.wantEvents)
+			}
+			if len(m.cronLastTicks) != len(tt.wantLastTicks) {
+				t.Errorf("processCronTriggers() last ticks = %v, want %v", m.cronLastTicks, tt.wantLastTicks)
+			}
+			for k, v := range tt.wantLastTicks {
+				if !m.cronLastTicks[k].Equal(v) {
+					t.Errorf("processCronTriggers() last tick of (%v) = %v, want %v", k, m.cronLastTicks[k], v)
+				}
+			}
+		})
+	}
+}
+
+func getCronEventIDs(project, triggerID string, start time.Time, count int) []string {
+	ids := make([]string, count)
+	for i := range ids {
+		ids[i] = getCronEventID(project, triggerID, start.Add(time.Duration(5*i)*time.Minute))
+	}
+	return ids
+}
+
+func Test_getCronEventID(t *testing.T) {
+	tick := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	if getCronEventID("project", "trigger", tick) != getCronEventID("project", "trigger", tick.In(time.Local)) {
+		t.Error("getCronEventID() must be the same for the same tick")
+	}
+	if getCronEventID("project", "trigger", tick) == getCronEventID("project", "trigger", tick.Add(time.Minute)) {
+		t.Error("getCronEventID() must differ across ticks")
+	}
+	if token := getCronToken("project", "trigger"); token < 0 || token >= utils.MaxEventTokens {
+		t.Errorf("getCronToken() = %d, out of range", token)
+	}
+}
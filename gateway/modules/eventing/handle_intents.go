@@ -61,6 +61,21 @@ func (m *Module) processIntents(t *time.Time) {
 			continue
 		}
 
+		// Intents scheduled for later, like the uploads made using signed urls, are processed only once they are due.
+		// Till then the file is polled so that the event gets staged as soon as the upload completes
+		if ts, err := time.Parse(time.RFC3339Nano, eventDoc.Timestamp); err == nil && t.Before(ts) {
+			if eventDoc.Type == utils.EventFileCreate {
+				// Only one poll of an intent runs at a time, so that slow file stores don't pile up polls of the same intent
+				if _, loaded := m.pollingIntents.LoadOrStore(eventDoc.ID, true); !loaded {
+					go func(eventDoc *model.EventDocument) {
+						defer m.pollingIntents.Delete(eventDoc.ID)
+						m.pollFileIntent(eventDoc)
+					}(eventDoc)
+				}
+			}
+			continue
+		}
+
 		if t.After(timestamp.Add(5 * time.Minute)) {
 			go m.processIntent(eventDoc)
 		}
@@ -92,7 +107,7 @@ func (m *Module) processIntent(eventDoc *model.EventDocument) {
 			return
 		}
 
-		if err := m.fileStore.DoesExists(ctx, m.project, token, filePayload.Path); err != nil {
+		if err := m.fileStore.DoesExists(ctx, m.project, token, filePayload.Store, filePayload.Path); err != nil {
 
 			// Mark event as cancelled if it document doesn't exist
 			m.updateEventC <- &queueUpdateEvent{
@@ -125,7 +140,7 @@ func (m *Module) processIntent(eventDoc *model.EventDocument) {
 			return
 		}
 
-		if err := m.fileStore.DoesExists(ctx, m.project, token, filePayload.Path); err == nil {
+		if err := m.fileStore.DoesExists(ctx, m.project, token, filePayload.Store, filePayload.Path); err == nil {
 			// Mark the event as cancelled if the object still exists
 			m.updateEventC <- &queueUpdateEvent{
 				project: m.project,
@@ -145,3 +160,51 @@ func (m *Module) processIntent(eventDoc *model.EventDocument) {
 		}
 	}
 }
+
+// pollFileIntent stages the create file intent of an upload made using a signed url once the file has been written
+// after the intent was logged. The intent is left as is otherwise, so that it gets polled again till the url expires.
+// A file which already existed and hasn't been overwritten yet doesn't stage the intent
+func (m *Module) pollFileIntent(eventDoc *model.EventDocument) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	// Create a context with 5 second timeout
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	filePayload := model.FilePayload{}
+	_ = json.Unmarshal([]byte(eventDoc.Payload.(string)), &filePayload)
+
+	token, err := m.auth.GetInternalAccessToken(ctx)
+	if err != nil {
+		_ = helpers.Logger.LogError(helpers.GetRequestID(ctx), "Eventing: Error generating token in intent polling", err, nil)
+		return
+	}
+
+	_, info, err := m.fileStore.GetFileInfo(ctx, m.project, token, filePayload.Store, filePayload.Path)
+	if err != nil || info.ModifiedAt == nil {
+		return
+	}
+	createdAt, err := time.Parse(time.RFC3339Nano, eventDoc.EventTimestamp)
+	if err != nil {
+		return
+	}
+	// Some file stores report the modification time in seconds
+	if info.ModifiedAt.Before(createdAt.Truncate(time.Second)) {
+		return
+	}
+
+	// The event was scheduled for when the url expires. Hence it needs to be rescheduled to be processed right away
+	req := m.generateStageEventRequest(eventDoc.ID)
+	ts := time.Now().Format(time.RFC3339Nano)
+	req.Update["$set"].(map[string]interface{})["ts"] = ts
+	if err := m.crud.InternalUpdate(ctx, m.config.DBAlias, m.project, utils.TableEventingLogs, req); err != nil {
+		_ = helpers.Logger.LogError(helpers.GetRequestID(ctx), "Eventing: Couldn't update intent to staged", err, nil)
+		return
+	}
+
+	// Broadcast the event so the concerned worker can process it immediately
+	eventDoc.Status = utils.EventStatusStaged
+	eventDoc.Timestamp = ts
+	m.transmitEvents(eventDoc.Token, []*model.EventDocument{eventDoc})
+}
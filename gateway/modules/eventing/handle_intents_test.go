@@ -132,6 +132,54 @@ func TestModule_processIntents(t *testing.T) {
 	}
 }
 
+func TestModule_processIntents_polling(t *testing.T) {
+	now := time.Now()
+	intent := &model.EventDocument{ID: "id", Type: utils.EventFileCreate, Token: 50, Status: utils.EventStatusIntent, Timestamp: now.Add(time.Hour).Format(time.RFC3339Nano), EventTimestamp: now.Format(time.RFC3339Nano), Payload: `{"path": "path"}`}
+
+	mockCrud := mockCrudInterface{}
+	mockAuth := mockAuthEventingInterface{}
+	mockSyncman := mockSyncmanEventingInterface{}
+	mockFileStore := mockFileStoreEventingInterface{}
+
+	mockSyncman.On("GetAssignedTokens").Return(1, 100)
+	mockCrud.On("Read", mock.Anything, "dbtype", utils.TableEventingLogs, mock.Anything, mock.Anything).Return([]interface{}{intent}, new(model.SQLMetaData), nil)
+	mockAuth.On("GetInternalAccessToken").Return("token", nil)
+
+	// The file store is slow to respond to the first poll
+	polled, release := make(chan struct{}, 2), make(chan struct{})
+	mockFileStore.On("GetFileInfo", mock.Anything, "abc", "token", "", "path").Run(func(mock.Arguments) {
+		polled <- struct{}{}
+		<-release
+	}).Return(404, nil, errors.New("file not found"))
+
+	m := &Module{project: "abc", config: &config.Eventing{DBAlias: "dbtype", Enabled: true}, crud: &mockCrud, auth: &mockAuth, syncMan: &mockSyncman, fileStore: &mockFileStore}
+	m.processIntents(&now)
+	<-polled
+
+	// The intent isn't polled again while its previous poll is running
+	m.processIntents(&now)
+	close(release)
+	select {
+	case <-polled:
+		t.Fatalf("processIntents() polled the intent while it was already being polled")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	// The intent is polled again once the previous poll completes
+	for i := 0; i < 100; i++ {
+		if _, ok := m.pollingIntents.Load("id"); !ok {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	m.processIntents(&now)
+	select {
+	case <-polled:
+	case <-time.After(time.Second):
+		t.Fatalf("processIntents() didn't poll the intent after the previous poll completed")
+	}
+}
+
 func TestModule_processIntent(t *testing.T) {
 	type mockArgs struct {
 		method         string
@@ -174,7 +222,7 @@ func TestModule_processIntent(t *testing.T) {
 			filestoreMockArgs: []mockArgs{
 				{
 					method:         "DoesExists",
-					args:           []interface{}{mock.Anything, "abc", "token", "path"},
+					args:           []interface{}{mock.Anything, "abc", "token", "", "path"},
 					paramsReturned: []interface{}{errors.New("some error")},
 				},
 			},
@@ -192,7 +240,7 @@ func TestModule_processIntent(t *testing.T) {
 			filestoreMockArgs: []mockArgs{
 				{
 					method:         "DoesExists",
-					args:           []interface{}{mock.Anything, "abc", "token", "path"},
+					args:           []interface{}{mock.Anything, "abc", "token", "", "path"},
 					paramsReturned: []interface{}{errors.New("some error")},
 				},
 			},
@@ -210,7 +258,7 @@ func TestModule_processIntent(t *testing.T) {
 			filestoreMockArgs: []mockArgs{
 				{
 					method:         "DoesExists",
-					args:           []interface{}{mock.Anything, "abc", "token", "path"},
+					args:           []interface{}{mock.Anything, "abc", "token", "", "path"},
 					paramsReturned: []interface{}{nil},
 				},
 			},
@@ -235,7 +283,7 @@ func TestModule_processIntent(t *testing.T) {
 			filestoreMockArgs: []mockArgs{
 				{
 					method:         "DoesExists",
-					args:           []interface{}{mock.Anything, "abc", "token", "path"},
+					args:           []interface{}{mock.Anything, "abc", "token", "", "path"},
 					paramsReturned: []interface{}{nil},
 				},
 			},
@@ -278,7 +326,7 @@ func TestModule_processIntent(t *testing.T) {
 			filestoreMockArgs: []mockArgs{
 				{
 					method:         "DoesExists",
-					args:           []interface{}{mock.Anything, "abc", "token", "path"},
+					args:           []interface{}{mock.Anything, "abc", "token", "", "path"},
 					paramsReturned: []interface{}{nil},
 				},
 			},
@@ -297,7 +345,7 @@ func TestModule_processIntent(t *testing.T) {
 			filestoreMockArgs: []mockArgs{
 				{
 					method:         "DoesExists",
-					args:           []interface{}{mock.Anything, "abc", "token", "path"},
+					args:           []interface{}{mock.Anything, "abc", "token", "", "path"},
 					paramsReturned: []interface{}{errors.New("some error")},
 				},
 			},
@@ -322,7 +370,7 @@ func TestModule_processIntent(t *testing.T) {
 			filestoreMockArgs: []mockArgs{
 				{
 					method:         "DoesExists",
-					args:           []interface{}{mock.Anything, "abc", "token", "path"},
+					args:           []interface{}{mock.Anything, "abc", "token", "", "path"},
 					paramsReturned: []interface{}{errors.New("some error")},
 				},
 			},
@@ -384,3 +432,69 @@ func TestModule_processIntent(t *testing.T) {
 		})
 	}
 }
+
+func TestModule_pollFileIntent(t *testing.T) {
+	isStagedNow := func(req *model.UpdateRequest) bool {
+		set := req.Update["$set"].(map[string]interface{})
+		ts, err := time.Parse(time.RFC3339Nano, set["ts"].(string))
+		return err == nil && req.Find["_id"] == "id" && set["status"] == utils.EventStatusStaged && time.Since(ts) < time.Minute
+	}
+	createdAt := time.Now().Add(-time.Minute)
+	modifiedBefore, modifiedAfter := createdAt.Add(-time.Hour), createdAt.Add(time.Second)
+	tests := []struct {
+		name      string
+		info      *model.FileInfo
+		infoErr   error
+		updateErr error
+		wantStage bool
+	}{
+		{
+			name:    "intent is left as is if the file doesn't exist yet",
+			infoErr: errors.New("file not found"),
+		},
+		{
+			name: "intent is left as is if the file existed before the intent was logged",
+			info: &model.FileInfo{ModifiedAt: &modifiedBefore},
+		},
+		{
+			name: "intent is left as is if the modification time of the file isn't known",
+			info: &model.FileInfo{},
+		},
+		{
+			name:      "intent is staged right away once the file is written",
+			info:      &model.FileInfo{ModifiedAt: &modifiedAfter},
+			wantStage: true,
+		},
+		{
+			name:      "error while staging the intent",
+			info:      &model.FileInfo{ModifiedAt: &modifiedAfter},
+			updateErr: errors.New("some error"),
+			wantStage: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCrud := mockCrudInterface{}
+			mockAuth := mockAuthEventingInterface{}
+			mockSyncman := mockSyncmanEventingInterface{}
+			mockFileStore := mockFileStoreEventingInterface{}
+
+			mockAuth.On("GetInternalAccessToken").Return("token", nil)
+			mockFileStore.On("GetFileInfo", mock.Anything, "abc", "token", "", "path").Return(200, tt.info, tt.infoErr)
+			if tt.wantStage {
+				mockCrud.On("InternalUpdate", mock.Anything, "dbtype", "abc", utils.TableEventingLogs, mock.MatchedBy(isStagedNow)).Return(tt.updateErr)
+			}
+			if tt.wantStage && tt.updateErr == nil {
+				mockSyncman.On("GetAssignedSpaceCloudID", mock.Anything, "abc", 50).Return("url", nil)
+			}
+
+			m := &Module{project: "abc", config: &config.Eventing{DBAlias: "dbtype"}, crud: &mockCrud, auth: &mockAuth, syncMan: &mockSyncman, fileStore: &mockFileStore}
+			m.pollFileIntent(&model.EventDocument{ID: "id", Type: utils.EventFileCreate, Token: 50, Timestamp: time.Now().Add(time.Hour).Format(time.RFC3339Nano), EventTimestamp: createdAt.Format(time.RFC3339Nano), Payload: `{"path": "path"}`})
+
+			mockCrud.AssertExpectations(t)
+			mockSyncman.AssertExpectations(t)
+			mockAuth.AssertExpectations(t)
+			mockFileStore.AssertExpectations(t)
+		})
+	}
+}
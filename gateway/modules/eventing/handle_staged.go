@@ -15,6 +15,7 @@ import (
 	"github.com/spaceuptech/space-cloud/gateway/config"
 	"github.com/spaceuptech/space-cloud/gateway/model"
 	"github.com/spaceuptech/space-cloud/gateway/utils"
+	"github.com/spaceuptech/space-cloud/gateway/utils/tracing"
 )
 
 func (m *Module) processStagedEvents(t *time.Time) {
@@ -57,20 +58,38 @@ func (m *Module) processStagedEvents(t *time.Time) {
 			continue
 		}
 
-		timestamp, err := time.Parse(time.RFC3339Nano, eventDoc.Timestamp) // We are using event timestamp since intent are processed wrt the time the event was created
+		timestamp, err := getStagedEventTime(eventDoc)
 		if err != nil {
-			_ = helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Could not parse time (%s) in staged event doc (%s) as time", eventDoc.Timestamp, eventDoc.ID), err, nil)
+			_ = helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Could not parse time in staged event doc (%s) as time", eventDoc.ID), err, nil)
 			continue
 		}
 
-		timestamp = timestamp.Add(15 * time.Second)
-
 		if t.After(timestamp) || t.Equal(timestamp) {
 			go m.processStagedEvent(eventDoc)
 		}
 	}
 }
 
+// getStagedEventTime returns the time at which a staged event should be processed. Events which have been rescheduled
+// are processed at the time of their next attempt, the rest at the time of the event
+func getStagedEventTime(eventDoc *model.EventDocument) (time.Time, error) {
+	value := eventDoc.Timestamp
+	if eventDoc.NextAttempt != "" {
+		value = eventDoc.NextAttempt
+	}
+	timestamp, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	// Give the node the event was transmitted to a chance to process it first. Retries were rescheduled by this
+	// routine itself so they can be processed right away
+	if eventDoc.Retries == 0 {
+		timestamp = timestamp.Add(15 * time.Second)
+	}
+	return timestamp, nil
+}
+
 func (m *Module) processStagedEvent(eventDoc *model.EventDocument) {
 	m.lock.RLock()
 	defer m.lock.RUnlock()
@@ -91,26 +110,22 @@ func (m *Module) processStagedEvent(eventDoc *model.EventDocument) {
 		return
 	}
 
-	var maxRetries int
-	if rule.Retries > 0 {
-		maxRetries = rule.Retries
-	} else {
-		maxRetries = 3
+	maxRetries := rule.Retries
+	if maxRetries <= 0 {
+		maxRetries = defaultMaxRetries
 	}
 
+	// Work on a copy of the rule since the defaults mustn't be written to the shared config
+	r := *rule
+	rule = &r
 	if rule.Timeout == 0 {
 		rule.Timeout = 5000
 	}
 
-	// Call the function to process the event
-	timeoutLocal := time.Duration(5000*maxRetries*rule.Timeout) * time.Millisecond
-
-	ctx, cancel := context.WithTimeout(context.Background(), timeoutLocal)
+	// Every invocation is a single attempt. Leave some time after the invocation times out for persisting the result
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(rule.Timeout)*time.Millisecond+10*time.Second)
 	defer cancel()
 
-	// Create a variable to track retries
-	retries := 0
-
 	// Payload will be of type json. Unmarshal it before sending
 	var doc interface{}
 	_ = json.Unmarshal([]byte(eventDoc.Payload.(string)), &doc)
@@ -155,25 +170,26 @@ func (m *Module) processStagedEvent(eventDoc *model.EventDocument) {
 		return
 	}
 
-	for {
-		if err := m.invokeWebhook(ctx, token, &http.Client{}, rule, eventDoc, newDoc); err != nil {
-			_ = helpers.Logger.LogError(helpers.GetRequestID(ctx), "Eventing staged event handler could not get response from service", err, nil)
-
-			// Increment the retries. Exit the loop if max retries reached.
-			retries++
-			if retries >= maxRetries+1 {
-				// Mark event as failed
-				break
-			}
-
-			// Sleep for 5 seconds
-			time.Sleep(5 * time.Second)
-			continue
-		}
-
+	start := time.Now()
+	err = m.invokeWebhook(ctx, token, &http.Client{}, rule, eventDoc, newDoc)
+	if m.deliveryHook != nil {
+		m.deliveryHook(m.project, triggerName, time.Since(start), err)
+	}
+	if err == nil {
 		// Reaching here means the event was successfully processed. Let's simply return
 		return
 	}
+	_ = helpers.Logger.LogError(helpers.GetRequestID(ctx), "Eventing staged event handler could not get response from service", err, nil)
+
+	remark := "Max retires limit reached"
+	policy := getRetryPolicy(rule)
+	if !isRetryable(policy, err) {
+		remark = "Service responded with a non retryable status code"
+	} else if eventDoc.Retries < maxRetries {
+		// The event stays staged if it couldn't be rescheduled. It will simply get retried on the next tick in that case
+		_ = m.rescheduleEvent(ctx, eventDoc, getRetryDelay(policy, eventDoc.Retries, randomFloat))
+		return
+	}
 
 	if err := m.triggerDLQEvent(ctx, eventDoc); err != nil {
 		_ = helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Couldn't create DLQ event for event id %v", eventDoc.ID), err, nil)
@@ -183,12 +199,20 @@ func (m *Module) processStagedEvent(eventDoc *model.EventDocument) {
 		project: m.project,
 		db:      m.config.DBAlias,
 		col:     utils.TableEventingLogs,
-		req:     m.generateFailedEventRequest(eventDoc.ID, "Max retires limit reached"),
+		req:     m.generateFailedEventRequest(eventDoc.ID, remark),
 		err:     "Eventing staged event handler could not update event doc",
 	}
 }
 
-func (m *Module) invokeWebhook(ctx context.Context, token string, client model.HTTPEventingInterface, rule *config.EventingTrigger, eventDoc *model.EventDocument, params interface{}) error {
+func (m *Module) invokeWebhook(ctx context.Context, token string, client model.HTTPEventingInterface, rule *config.EventingTrigger, eventDoc *model.EventDocument, params interface{}) (err error) {
+	ctx, span := tracing.StartSpan(ctx, "eventing.deliver "+rule.ID, tracing.SpanKindClient,
+		tracing.String("eventing.project", m.project), tracing.String("eventing.trigger", rule.ID), tracing.String("eventing.event.id", eventDoc.ID),
+		tracing.String("eventing.event.type", eventDoc.Type), tracing.Int64("eventing.event.retries", int64(eventDoc.Retries)), tracing.String("http.url", rule.URL))
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
 	ctxLocal, cancel := context.WithTimeout(ctx, time.Duration(rule.Timeout)*time.Millisecond)
 	defer cancel()
 
@@ -199,7 +223,10 @@ func (m *Module) invokeWebhook(ctx context.Context, token string, client model.H
 
 	var eventResponse model.EventResponse
 	if err := m.MakeInvocationHTTPRequest(ctxLocal, client, http.MethodPost, rule.URL, eventDoc.ID, token, scToken, params, &eventResponse); err != nil {
-		return helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("error invoking web hook in eventing unable to send http request to url %s", rule.URL), err, nil)
+		_ = helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("error invoking web hook in eventing unable to send http request to url %s", rule.URL), err, nil)
+
+		// The error is returned as is so that the status code of the response isn't lost
+		return err
 	}
 
 	// Check if response contains an error
@@ -114,6 +114,50 @@ func TestModule_processStagedEvents(t *testing.T) {
 	}
 }
 
+func Test_getStagedEventTime(t *testing.T) {
+	ts := "2020-10-01T10:00:00Z"
+	nextAttempt := "2020-10-01T10:05:00Z"
+
+	tests := []struct {
+		name     string
+		eventDoc *model.EventDocument
+		want     string
+		wantErr  bool
+	}{
+		{
+			name:     "new event is given some time to be processed by the node it was transmitted to",
+			eventDoc: &model.EventDocument{Timestamp: ts},
+			want:     "2020-10-01T10:00:15Z",
+		},
+		{
+			name:     "rescheduled event is processed at the time of its next attempt",
+			eventDoc: &model.EventDocument{Timestamp: ts, NextAttempt: nextAttempt, Retries: 1},
+			want:     nextAttempt,
+		},
+		{
+			name:     "replayed event is given some time to be processed by the node it was transmitted to",
+			eventDoc: &model.EventDocument{Timestamp: ts, NextAttempt: nextAttempt},
+			want:     "2020-10-01T10:05:15Z",
+		},
+		{
+			name:     "invalid next attempt",
+			eventDoc: &model.EventDocument{Timestamp: ts, NextAttempt: "invalid", Retries: 1},
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := getStagedEventTime(tt.eventDoc)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("getStagedEventTime() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && got.Format(time.RFC3339) != tt.want {
+				t.Errorf("getStagedEventTime() = %v, want %v", got.Format(time.RFC3339), tt.want)
+			}
+		})
+	}
+}
+
 func TestModule_invokeWebhook(t *testing.T) {
 	type mockArgs struct {
 		method         string
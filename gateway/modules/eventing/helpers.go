@@ -208,8 +208,8 @@ func (m *Module) getMatchingRules(ctx context.Context, req *model.QueueEventRequ
 	rules := make([]*config.EventingTrigger, 0)
 
 	for _, rule := range m.config.Rules {
-		// Skip trigger if its event type does not match incoming request
-		if rule.Type != req.Type {
+		// Skip trigger if its event type does not match incoming request. Cron triggers are only fired by their schedule
+		if rule.Type != req.Type || rule.Type == utils.EventCron {
 			continue
 		}
 
@@ -13,6 +13,7 @@ import (
 
 	"github.com/spaceuptech/space-cloud/gateway/model"
 	"github.com/spaceuptech/space-cloud/gateway/utils"
+	"github.com/spaceuptech/space-cloud/gateway/utils/tracing"
 )
 
 func (m *Module) logInvocation(ctx context.Context, eventID string, payload []byte, responseStatusCode int, responseBody, errorMsg string) error {
@@ -58,6 +59,9 @@ func (m *Module) MakeInvocationHTTPRequest(ctx context.Context, client model.HTT
 	req.Header.Add("Content-Type", "application/json")
 	req.Header.Add("x-sc-token", "Bearer "+scToken)
 
+	// Propagate the trace context to the webhook
+	tracing.Inject(ctx, req.Header)
+
 	req = req.WithContext(ctx)
 	resp, err := client.Do(req)
 	if err != nil {
@@ -79,14 +83,14 @@ func (m *Module) MakeInvocationHTTPRequest(ctx context.Context, client model.HTT
 		if err := m.logInvocation(ctx, eventID, data, resp.StatusCode, string(responseBody), err.Error()); err != nil {
 			return helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to log invocation request", err, nil)
 		}
-		return err
+		return newInvocationStatusError(resp.StatusCode, err)
 	}
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
 		if err := m.logInvocation(ctx, eventID, data, resp.StatusCode, string(responseBody), errors.New("invalid status code received").Error()); err != nil {
 			return helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to log invocation request", err, nil)
 		}
-		return helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Invocation service responded with status code - %v", resp.StatusCode), nil, nil)
+		return newInvocationStatusError(resp.StatusCode, helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Invocation service responded with status code - %v", resp.StatusCode), nil, nil))
 	}
 
 	if err := m.logInvocation(ctx, eventID, data, resp.StatusCode, string(responseBody), ""); err != nil {
@@ -95,3 +99,20 @@ func (m *Module) MakeInvocationHTTPRequest(ctx context.Context, client model.HTT
 
 	return nil
 }
+
+// invocationStatusError is returned when the invoked service responds with a non 2xx status code
+type invocationStatusError struct {
+	statusCode int
+	err        error
+}
+
+func newInvocationStatusError(statusCode int, err error) error {
+	if statusCode >= 200 && statusCode < 300 {
+		return err
+	}
+	return &invocationStatusError{statusCode: statusCode, err: err}
+}
+
+func (e *invocationStatusError) Error() string {
+	return e.err.Error()
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/spaceuptech/space-cloud/gateway/config"
 	"github.com/spaceuptech/space-cloud/gateway/model"
 	"github.com/spaceuptech/space-cloud/gateway/utils"
+	"github.com/spaceuptech/space-cloud/gateway/utils/tracing"
 )
 
 func TestModule_logInvocation(t *testing.T) {
@@ -280,3 +281,29 @@ func TestModule_MakeInvocationHTTPRequest(t *testing.T) {
 }
 
 // TODO: Write test cases for error in ReadAll and for statusCode not <200 or >300
+
+func TestModule_MakeInvocationHTTPRequest_traceContext(t *testing.T) {
+	exporter := tracing.NewInMemoryExporter()
+	tracing.SetTracer(tracing.New(exporter, 1))
+	defer tracing.SetTracer(nil)
+
+	ctx, span := tracing.StartSpan(context.Background(), "eventing.deliver trigger", tracing.SpanKindClient)
+	defer span.End()
+
+	mockHTTP := mockHTTPInterface{}
+	mockHTTP.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+		return req.Header.Get(tracing.HeaderTraceParent) == span.SpanContext().TraceParent()
+	})).Return(nil, nil)
+
+	mockCrud := mockCrudInterface{}
+	mockCrud.On("InternalCreate", mock.Anything, "db", "project", utils.TableInvocationLogs, mock.Anything, false).Return(nil)
+
+	m := &Module{project: "project", config: &config.Eventing{DBAlias: "db"}, crud: &mockCrud}
+	var eventResponse model.EventResponse
+	if err := m.MakeInvocationHTTPRequest(ctx, &mockHTTP, http.MethodPost, "http://webhook", "id", "token", "scToken", "payload", &eventResponse); err != nil {
+		t.Fatalf("Module.MakeInvocationHTTPRequest() error = %v", err)
+	}
+
+	mockHTTP.AssertExpectations(t)
+	mockCrud.AssertExpectations(t)
+}
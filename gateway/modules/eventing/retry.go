@@ -0,0 +1,118 @@
+package eventing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/spaceuptech/helpers"
+
+	"github.com/spaceuptech/space-cloud/gateway/config"
+	"github.com/spaceuptech/space-cloud/gateway/model"
+	"github.com/spaceuptech/space-cloud/gateway/utils"
+)
+
+const (
+	defaultMaxRetries      = 3
+	defaultInitialInterval = 5000
+	defaultMaxInterval     = 5 * 60 * 1000
+)
+
+// getRetryPolicy returns the retry policy of the trigger with the defaults applied. Triggers without a
+// retry policy are retried every 5 seconds
+func getRetryPolicy(rule *config.EventingTrigger) *config.RetryPolicy {
+	if rule.RetryPolicy == nil {
+		return &config.RetryPolicy{Backoff: config.BackoffFixed, InitialInterval: defaultInitialInterval, MaxInterval: defaultMaxInterval}
+	}
+
+	policy := *rule.RetryPolicy
+	if policy.Backoff == "" {
+		policy.Backoff = config.BackoffExponential
+	}
+	if policy.InitialInterval <= 0 {
+		policy.InitialInterval = defaultInitialInterval
+	}
+	if policy.MaxInterval <= 0 {
+		policy.MaxInterval = defaultMaxInterval
+	}
+	return &policy
+}
+
+func validateRetryPolicy(policy *config.RetryPolicy) error {
+	if policy == nil {
+		return nil
+	}
+	switch policy.Backoff {
+	case "", config.BackoffFixed, config.BackoffLinear, config.BackoffExponential:
+	default:
+		return fmt.Errorf("invalid backoff type (%s) provided in retry policy", policy.Backoff)
+	}
+	if policy.InitialInterval < 0 || policy.MaxInterval < 0 {
+		return errors.New("intervals of retry policy cannot be negative")
+	}
+	if policy.MaxInterval > 0 && policy.InitialInterval > policy.MaxInterval {
+		return errors.New("initial interval of retry policy cannot be greater than the max interval")
+	}
+	if policy.Jitter < 0 || policy.Jitter > 1 {
+		return errors.New("jitter of retry policy must be between 0 and 1")
+	}
+	return nil
+}
+
+// getRetryDelay returns the time to wait before the next retry. The retries are the number of retries already made
+func getRetryDelay(policy *config.RetryPolicy, retries int, random func() float64) time.Duration {
+	initial, max := float64(policy.InitialInterval), float64(policy.MaxInterval)
+
+	delay := initial
+	switch policy.Backoff {
+	case config.BackoffLinear:
+		delay = initial * float64(retries+1)
+	case config.BackoffExponential:
+		delay = initial * math.Pow(2, float64(retries))
+	}
+	delay = math.Min(delay, max)
+
+	// Randomly vary the delay by the jitter fraction in either direction
+	if policy.Jitter > 0 {
+		delay += delay * policy.Jitter * (2*random() - 1)
+		delay = math.Min(delay, max)
+	}
+	return time.Duration(delay) * time.Millisecond
+}
+
+// isRetryable checks if an invocation which failed with the provided error should be retried
+func isRetryable(policy *config.RetryPolicy, err error) bool {
+	var statusErr *invocationStatusError
+	if !errors.As(err, &statusErr) {
+		return true
+	}
+	for _, code := range policy.NonRetryableStatusCodes {
+		if code == statusErr.statusCode {
+			return false
+		}
+	}
+	return true
+}
+
+// rescheduleEvent persists the time of the next retry in the event doc. The staged events routine picks it up once the time
+// has come. The timestamp of the event is left untouched since it is sent to the webhook as the time of the event
+func (m *Module) rescheduleEvent(ctx context.Context, eventDoc *model.EventDocument, delay time.Duration) error {
+	updateRequest := &model.UpdateRequest{
+		Find:      map[string]interface{}{"_id": eventDoc.ID},
+		Operation: utils.All,
+		Update: map[string]interface{}{
+			"$set": map[string]interface{}{"next_attempt": time.Now().Add(delay).Format(time.RFC3339Nano), "retries": eventDoc.Retries + 1},
+		},
+	}
+	if err := m.crud.InternalUpdate(ctx, m.config.DBAlias, m.project, utils.TableEventingLogs, updateRequest); err != nil {
+		return helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Unable to reschedule event (%s)", eventDoc.ID), err, nil)
+	}
+	return nil
+}
+
+func randomFloat() float64 {
+	return rand.Float64()
+}
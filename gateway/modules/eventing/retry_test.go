@@ -0,0 +1,136 @@
+package eventing
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/spaceuptech/space-cloud/gateway/config"
+	"github.com/spaceuptech/space-cloud/gateway/model"
+	"github.com/spaceuptech/space-cloud/gateway/utils"
+)
+
+func Test_getRetryDelay(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  *config.RetryPolicy
+		retries int
+		random  float64
+		want    time.Duration
+	}{
+		{
+			name:    "default policy",
+			policy:  getRetryPolicy(&config.EventingTrigger{}),
+			retries: 2,
+			want:    5 * time.Second,
+		},
+		{
+			name:    "linear backoff",
+			policy:  &config.RetryPolicy{Backoff: config.BackoffLinear, InitialInterval: 1000, MaxInterval: 10000},
+			retries: 2,
+			want:    3 * time.Second,
+		},
+		{
+			name:    "exponential backoff",
+			policy:  getRetryPolicy(&config.EventingTrigger{RetryPolicy: &config.RetryPolicy{InitialInterval: 1000}}),
+			retries: 3,
+			want:    8 * time.Second,
+		},
+		{
+			name:    "exponential backoff is capped by the max interval",
+			policy:  &config.RetryPolicy{Backoff: config.BackoffExponential, InitialInterval: 1000, MaxInterval: 5000},
+			retries: 10,
+			want:    5 * time.Second,
+		},
+		{
+			name:   "jitter reduces the delay",
+			policy: &config.RetryPolicy{Backoff: config.BackoffFixed, InitialInterval: 1000, MaxInterval: 5000, Jitter: 0.5},
+			random: 0,
+			want:   500 * time.Millisecond,
+		},
+		{
+			name:   "jitter increases the delay",
+			policy: &config.RetryPolicy{Backoff: config.BackoffFixed, InitialInterval: 1000, MaxInterval: 5000, Jitter: 0.5},
+			random: 1,
+			want:   1500 * time.Millisecond,
+		},
+		{
+			name:   "jitter doesn't exceed the max interval",
+			policy: &config.RetryPolicy{Backoff: config.BackoffFixed, InitialInterval: 5000, MaxInterval: 5000, Jitter: 0.5},
+			random: 1,
+			want:   5 * time.Second,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := getRetryDelay(tt.policy, tt.retries, func() float64 { return tt.random }); got != tt.want {
+				t.Errorf("getRetryDelay() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_validateRetryPolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  *config.RetryPolicy
+		wantErr bool
+	}{
+		{name: "no policy"},
+		{name: "valid policy", policy: &config.RetryPolicy{Backoff: config.BackoffLinear, InitialInterval: 100, MaxInterval: 1000, Jitter: 0.2}},
+		{name: "invalid backoff", policy: &config.RetryPolicy{Backoff: "random"}, wantErr: true},
+		{name: "negative interval", policy: &config.RetryPolicy{InitialInterval: -1}, wantErr: true},
+		{name: "initial interval greater than max interval", policy: &config.RetryPolicy{InitialInterval: 1000, MaxInterval: 100}, wantErr: true},
+		{name: "invalid jitter", policy: &config.RetryPolicy{Jitter: 1.5}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateRetryPolicy(tt.policy); (err != nil) != tt.wantErr {
+				t.Errorf("validateRetryPolicy() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_isRetryable(t *testing.T) {
+	policy := &config.RetryPolicy{NonRetryableStatusCodes: []int{400, 404}}
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "network error", err: errors.New("connection refused"), want: true},
+		{name: "retryable status code", err: newInvocationStatusError(503, errors.New("unavailable")), want: true},
+		{name: "non retryable status code", err: newInvocationStatusError(404, errors.New("not found")), want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isRetryable(policy, tt.err); got != tt.want {
+				t.Errorf("isRetryable() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestModule_rescheduleEvent(t *testing.T) {
+	m := &Module{project: "abc", config: &config.Eventing{DBAlias: "db"}}
+	mockCrud := mockCrudInterface{}
+	start := time.Now()
+
+	isRescheduled := func(req *model.UpdateRequest) bool {
+		set := req.Update["$set"].(map[string]interface{})
+		_, hasTimestamp := set["ts"]
+		nextAttempt, err := time.Parse(time.RFC3339Nano, set["next_attempt"].(string))
+		return err == nil && !hasTimestamp && req.Find["_id"] == "eventID" && set["retries"] == 2 && !nextAttempt.Before(start.Add(time.Minute))
+	}
+	mockCrud.On("InternalUpdate", mock.Anything, "db", "abc", utils.TableEventingLogs, mock.MatchedBy(isRescheduled)).Return(nil)
+	m.crud = &mockCrud
+
+	if err := m.rescheduleEvent(context.Background(), &model.EventDocument{ID: "eventID", Retries: 1}, time.Minute); err != nil {
+		t.Errorf("rescheduleEvent() error = %v", err)
+	}
+	mockCrud.AssertExpectations(t)
+}
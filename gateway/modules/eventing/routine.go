@@ -37,6 +37,13 @@ func (m *Module) routineProcessStaged() {
 	}
 }
 
+func (m *Module) routineProcessCron() {
+	m.tickerCron = time.NewTicker(10 * time.Second)
+	for t := range m.tickerCron.C {
+		m.processCronTriggers(&t)
+	}
+}
+
 func (m *Module) routineHandleMessages() {
 	ch, err := m.pubsubClient.Subscribe(context.Background(), getEventingTopic(m.nodeID))
 	if err != nil {
@@ -137,7 +137,15 @@ type mockFileStoreEventingInterface struct {
 	mock.Mock
 }
 
-func (m *mockFileStoreEventingInterface) DoesExists(ctx context.Context, project, token, path string) error {
-	c := m.Called(ctx, project, token, path)
+func (m *mockFileStoreEventingInterface) DoesExists(ctx context.Context, project, token, store, path string) error {
+	c := m.Called(ctx, project, token, store, path)
 	return c.Error(0)
 }
+
+func (m *mockFileStoreEventingInterface) GetFileInfo(ctx context.Context, project, token, store, path string) (int, *model.FileInfo, error) {
+	c := m.Called(ctx, project, token, store, path)
+	if info := c.Get(1); info != nil {
+		return c.Int(0), info.(*model.FileInfo), c.Error(2)
+	}
+	return c.Int(0), nil, c.Error(2)
+}
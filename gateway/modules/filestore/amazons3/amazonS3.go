@@ -1,6 +1,9 @@
 package amazons3
 
 import (
+	"sync"
+	"time"
+
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/session"
 
@@ -11,6 +14,11 @@ import (
 type AmazonS3 struct {
 	client *session.Session
 	bucket string
+
+	// uploads are the multipart uploads created by the gateway which haven't been completed yet. The multipart uploads
+	// of the bucket created by anyone else are left untouched while cleaning up the abandoned uploads
+	lock    sync.Mutex
+	uploads map[multipartUpload]time.Time
 }
 
 // Init initializes an amazon s3 driver
@@ -24,7 +32,7 @@ func Init(region, endpoint, bucket string, disableSSL, forcePathStyle *bool) (*A
 		awsConf.Endpoint = aws.String(endpoint)
 	}
 	session, err := session.NewSession(awsConf)
-	return &AmazonS3{client: session, bucket: bucket}, err
+	return &AmazonS3{client: session, bucket: bucket, uploads: map[multipartUpload]time.Time{}}, err
 }
 
 // GetStoreType returns the file store type
@@ -3,7 +3,9 @@ package amazons3
 import (
 	"bufio"
 	"context"
+	"fmt"
 	"io/ioutil"
+	"mime"
 	"os"
 	"path/filepath"
 	"strings"
@@ -45,6 +47,12 @@ func (a *AmazonS3) ListDir(ctx context.Context, req *model.ListFilesRequest) ([]
 
 	for _, key := range resp.Contents {
 		t := &model.ListFilesResponse{Name: filepath.Base(*key.Key), Type: "file"}
+		t.FileInfo = model.FileInfo{
+			Size:        aws.Int64Value(key.Size),
+			ModifiedAt:  key.LastModified,
+			ContentType: mime.TypeByExtension(filepath.Ext(*key.Key)),
+			ETag:        aws.StringValue(key.ETag),
+		}
 		if req.Type == "all" || req.Type == t.Type {
 			result = append(result, t)
 		}
@@ -59,8 +67,13 @@ func (a *AmazonS3) ListDir(ctx context.Context, req *model.ListFilesRequest) ([]
 	return result, nil
 }
 
-// ReadFile reads a file from S3
-func (a *AmazonS3) ReadFile(ctx context.Context, path string) (*model.File, error) {
+// ReadFile reads a file from S3. Only the provided range of the file is read if the range isn't nil
+func (a *AmazonS3) ReadFile(ctx context.Context, path string, byteRange *model.FileRange) (*model.File, error) {
+	info, err := a.StatFile(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
 	u2 := uuid.NewV4()
 
 	tmpfile, err := ioutil.TempFile("", u2.String())
@@ -70,11 +83,15 @@ func (a *AmazonS3) ReadFile(ctx context.Context, path string) (*model.File, erro
 
 	downloader := s3manager.NewDownloader(a.client)
 
-	_, err = downloader.Download(tmpfile,
-		&s3.GetObjectInput{
-			Bucket: aws.String(a.bucket),
-			Key:    aws.String(path),
-		})
+	input := &s3.GetObjectInput{
+		Bucket: aws.String(a.bucket),
+		Key:    aws.String(path),
+	}
+	if byteRange != nil {
+		// The downloader makes a single request for the range provided
+		input.Range = aws.String(fmt.Sprintf("bytes=%d-%d", byteRange.Start, byteRange.End))
+	}
+	_, err = downloader.Download(tmpfile, input)
 	if err != nil {
 		_ = tmpfile.Close()
 		_ = os.Remove(tmpfile.Name())
@@ -83,5 +100,24 @@ func (a *AmazonS3) ReadFile(ctx context.Context, path string) (*model.File, erro
 	return &model.File{File: bufio.NewReader(tmpfile), Close: func() error {
 		defer func() { _ = os.Remove(tmpfile.Name()) }()
 		return tmpfile.Close()
-	}}, nil
+	}, Info: info, Range: byteRange}, nil
+}
+
+// StatFile returns the stat information of the file at the path provided
+func (a *AmazonS3) StatFile(ctx context.Context, path string) (*model.FileInfo, error) {
+	svc := s3.New(a.client)
+	resp, err := svc.HeadObjectWithContext(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(a.bucket),
+		Key:    aws.String(strings.TrimPrefix(path, "/")),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.FileInfo{
+		Size:        aws.Int64Value(resp.ContentLength),
+		ModifiedAt:  resp.LastModified,
+		ContentType: aws.StringValue(resp.ContentType),
+		ETag:        aws.StringValue(resp.ETag),
+	}, nil
 }
@@ -0,0 +1,41 @@
+package amazons3
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/s3"
+
+	"github.com/spaceuptech/space-cloud/gateway/model"
+	"github.com/spaceuptech/space-cloud/gateway/utils"
+)
+
+// GetSignedURL creates a presigned url to read or create a file in S3
+func (a *AmazonS3) GetSignedURL(ctx context.Context, req *model.SignedURLRequest) (string, string, error) {
+	svc := s3.New(a.client)
+
+	var r *request.Request
+	switch req.Op {
+	case model.FileRead:
+		r, _ = svc.GetObjectRequest(&s3.GetObjectInput{
+			Bucket: aws.String(a.bucket),
+			Key:    aws.String(req.Path),
+		})
+	case model.FileCreate:
+		r, _ = svc.PutObjectRequest(&s3.PutObjectInput{
+			Bucket: aws.String(a.bucket),
+			Key:    aws.String(utils.JoinLeading(req.Path, req.Name, "/")),
+		})
+	default:
+		return "", "", fmt.Errorf("invalid op (%s) provided for signed url", req.Op)
+	}
+
+	url, err := r.Presign(time.Duration(req.Expiry) * time.Second)
+	if err != nil {
+		return "", "", err
+	}
+	return url, r.HTTPRequest.Method, nil
+}
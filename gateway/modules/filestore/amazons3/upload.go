@@ -0,0 +1,173 @@
+package amazons3
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"os"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+
+	"github.com/spaceuptech/space-cloud/gateway/model"
+	"github.com/spaceuptech/space-cloud/gateway/utils"
+)
+
+// CreateUpload creates a native multipart upload in S3
+func (a *AmazonS3) CreateUpload(ctx context.Context, req *model.CreateFileRequest) (string, error) {
+	svc := s3.New(a.client)
+	res, err := svc.CreateMultipartUploadWithContext(ctx, &s3.CreateMultipartUploadInput{
+		Bucket: aws.String(a.bucket),
+		Key:    aws.String(utils.JoinLeading(req.Path, req.Name, "/")),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	id := aws.StringValue(res.UploadId)
+	a.recordUpload(multipartUpload{key: utils.JoinLeading(req.Path, req.Name, "/"), id: id})
+	return id, nil
+}
+
+// UploadPart uploads a part of a multipart upload. Every part except the last must be at least 5 MB in size
+func (a *AmazonS3) UploadPart(ctx context.Context, upload *model.FileUpload, number int, reader io.Reader) (*model.FileUploadPart, error) {
+	// S3 requires the body of a part to be seekable. Hence the part is buffered in a temporary file
+	f, err := ioutil.TempFile("", "upload-part-")
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = os.Remove(f.Name()) }()
+	defer utils.CloseTheCloser(f)
+
+	size, err := io.Copy(f, reader)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	svc := s3.New(a.client)
+	res, err := svc.UploadPartWithContext(ctx, &s3.UploadPartInput{
+		Bucket:     aws.String(a.bucket),
+		Key:        aws.String(getUploadKey(upload)),
+		UploadId:   aws.String(upload.ID),
+		PartNumber: aws.Int64(int64(number)),
+		Body:       f,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &model.FileUploadPart{Number: number, Size: size, ETag: aws.StringValue(res.ETag)}, nil
+}
+
+// ListParts lists the parts of a multipart upload uploaded so far
+func (a *AmazonS3) ListParts(ctx context.Context, upload *model.FileUpload) ([]*model.FileUploadPart, error) {
+	svc := s3.New(a.client)
+
+	parts := []*model.FileUploadPart{}
+	err := svc.ListPartsPagesWithContext(ctx, &s3.ListPartsInput{
+		Bucket:   aws.String(a.bucket),
+		Key:      aws.String(getUploadKey(upload)),
+		UploadId: aws.String(upload.ID),
+	}, func(page *s3.ListPartsOutput, lastPage bool) bool {
+		for _, part := range page.Parts {
+			parts = append(parts, &model.FileUploadPart{Number: int(aws.Int64Value(part.PartNumber)), Size: aws.Int64Value(part.Size), ETag: aws.StringValue(part.ETag)})
+		}
+		return true
+	})
+	return parts, err
+}
+
+// CompleteUpload completes a multipart upload. S3 assembles the parts into the object atomically
+func (a *AmazonS3) CompleteUpload(ctx context.Context, upload *model.FileUpload, parts []*model.FileUploadPart) error {
+	completed := make([]*s3.CompletedPart, len(parts))
+	for i, part := range parts {
+		completed[i] = &s3.CompletedPart{PartNumber: aws.Int64(int64(part.Number)), ETag: aws.String(part.ETag)}
+	}
+
+	svc := s3.New(a.client)
+	_, err := svc.CompleteMultipartUploadWithContext(ctx, &s3.CompleteMultipartUploadInput{
+		Bucket:          aws.String(a.bucket),
+		Key:             aws.String(getUploadKey(upload)),
+		UploadId:        aws.String(upload.ID),
+		MultipartUpload: &s3.CompletedMultipartUpload{Parts: completed},
+	})
+	if err != nil {
+		return err
+	}
+
+	a.removeUpload(multipartUpload{key: getUploadKey(upload), id: upload.ID})
+	return nil
+}
+
+// AbortUpload aborts a multipart upload and deletes the parts uploaded so far
+func (a *AmazonS3) AbortUpload(ctx context.Context, upload *model.FileUpload) error {
+	u := multipartUpload{key: getUploadKey(upload), id: upload.ID}
+	if err := a.abortUpload(ctx, u); err != nil {
+		return err
+	}
+
+	a.removeUpload(u)
+	return nil
+}
+
+// CleanupUploads aborts the multipart uploads created by the gateway before the provided time. The uploads created
+// before the gateway was restarted aren't known to it and need to be cleaned up by a lifecycle rule of the bucket
+func (a *AmazonS3) CleanupUploads(ctx context.Context, before time.Time) error {
+	a.lock.Lock()
+	uploads := []multipartUpload{}
+	for u, createdAt := range a.uploads {
+		if createdAt.Before(before) {
+			uploads = append(uploads, u)
+		}
+	}
+	a.lock.Unlock()
+
+	for _, u := range uploads {
+		// The upload might have been completed or aborted through another gateway
+		if err := a.abortUpload(ctx, u); err != nil && !isNoSuchUpload(err) {
+			return err
+		}
+		a.removeUpload(u)
+	}
+	return nil
+}
+
+// multipartUpload identifies a multipart upload of the bucket
+type multipartUpload struct {
+	key, id string
+}
+
+func (a *AmazonS3) abortUpload(ctx context.Context, u multipartUpload) error {
+	svc := s3.New(a.client)
+	_, err := svc.AbortMultipartUploadWithContext(ctx, &s3.AbortMultipartUploadInput{
+		Bucket:   aws.String(a.bucket),
+		Key:      aws.String(u.key),
+		UploadId: aws.String(u.id),
+	})
+	return err
+}
+
+func (a *AmazonS3) recordUpload(u multipartUpload) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	a.uploads[u] = time.Now()
+}
+
+func (a *AmazonS3) removeUpload(u multipartUpload) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	delete(a.uploads, u)
+}
+
+func isNoSuchUpload(err error) bool {
+	aerr, ok := err.(awserr.Error)
+	return ok && aerr.Code() == s3.ErrCodeNoSuchUpload
+}
+
+func getUploadKey(upload *model.FileUpload) string {
+	return utils.JoinLeading(upload.Path, upload.Name, "/")
+}
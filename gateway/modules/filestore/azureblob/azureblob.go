@@ -0,0 +1,117 @@
+package azureblob
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/Azure/azure-storage-blob-go/azblob"
+
+	"github.com/spaceuptech/space-cloud/gateway/utils"
+)
+
+// devStorageConnection is the connection string of the azurite storage emulator
+const devStorageConnection = "DefaultEndpointsProtocol=http;AccountName=devstoreaccount1;AccountKey=Eby8vdM02xNOcqFlqUwJPLlmEtlCDXJ1OUzFT50uSRZ6IFsuFq2UVErCz4I6tq/K1SZFPTOtr/KBHBeksoGMGw==;BlobEndpoint=http://127.0.0.1:10000/devstoreaccount1;"
+
+// AzureBlob holds the azure blob storage container client
+type AzureBlob struct {
+	container azblob.ContainerURL
+}
+
+// Init initialises an azure blob storage driver. The connection is either a connection string of the storage account
+// or the SAS url of the blob service. The endpoint, if provided, overrides the blob endpoint of the connection
+func Init(conn, endpoint, container string) (*AzureBlob, error) {
+	serviceURL, credential, err := parseConnection(conn, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	service := azblob.NewServiceURL(*serviceURL, azblob.NewPipeline(credential, azblob.PipelineOptions{}))
+	return &AzureBlob{container: service.NewContainerURL(container)}, nil
+}
+
+// GetStoreType returns the file store type
+func (a *AzureBlob) GetStoreType() utils.FileStoreType {
+	return utils.AzureBlob
+}
+
+// Close gracefully closes the azure blob storage module
+func (a *AzureBlob) Close() error {
+	return nil
+}
+
+// parseConnection returns the url of the blob service along with the credential to be used
+func parseConnection(conn, endpoint string) (*url.URL, azblob.Credential, error) {
+	// The SAS url of the blob service carries the credential in its query
+	if strings.HasPrefix(conn, "http://") || strings.HasPrefix(conn, "https://") {
+		u, err := url.Parse(conn)
+		if err != nil {
+			return nil, nil, err
+		}
+		if endpoint != "" {
+			query := u.RawQuery
+			if u, err = url.Parse(endpoint); err != nil {
+				return nil, nil, err
+			}
+			u.RawQuery = query
+		}
+		return u, azblob.NewAnonymousCredential(), nil
+	}
+
+	values := map[string]string{}
+	for _, pair := range strings.Split(conn, ";") {
+		if pair = strings.TrimSpace(pair); pair == "" {
+			continue
+		}
+		arr := strings.SplitN(pair, "=", 2)
+		if len(arr) != 2 {
+			return nil, nil, fmt.Errorf("invalid key value pair (%s) provided in azure connection string", arr[0])
+		}
+		values[arr[0]] = arr[1]
+	}
+	if values["UseDevelopmentStorage"] == "true" {
+		return parseConnection(devStorageConnection, endpoint)
+	}
+
+	if endpoint == "" {
+		endpoint = values["BlobEndpoint"]
+	}
+	if endpoint == "" {
+		if values["AccountName"] == "" {
+			return nil, nil, errors.New("account name or blob endpoint is required in azure connection string")
+		}
+		protocol, suffix := values["DefaultEndpointsProtocol"], values["EndpointSuffix"]
+		if protocol == "" {
+			protocol = "https"
+		}
+		if suffix == "" {
+			suffix = "core.windows.net"
+		}
+		endpoint = fmt.Sprintf("%s://%s.blob.%s", protocol, values["AccountName"], suffix)
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if sas := values["SharedAccessSignature"]; sas != "" {
+		u.RawQuery = strings.TrimPrefix(sas, "?")
+		return u, azblob.NewAnonymousCredential(), nil
+	}
+
+	if values["AccountName"] == "" || values["AccountKey"] == "" {
+		return nil, nil, errors.New("account name and key or shared access signature is required in azure connection string")
+	}
+	credential, err := azblob.NewSharedKeyCredential(values["AccountName"], values["AccountKey"])
+	if err != nil {
+		return nil, nil, err
+	}
+	return u, credential, nil
+}
+
+// isNotFound checks if the error returned by azure is because the blob doesn't exist
+func isNotFound(err error) bool {
+	var storageErr azblob.StorageError
+	return errors.As(err, &storageErr) && storageErr.Response() != nil && storageErr.Response().StatusCode == 404
+}
@@ -0,0 +1,102 @@
+package azureblob
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/azure-storage-blob-go/azblob"
+)
+
+func Test_parseConnection(t *testing.T) {
+	tests := []struct {
+		name       string
+		conn       string
+		endpoint   string
+		wantURL    string
+		wantShared bool
+		wantErr    bool
+	}{
+		{
+			name:       "connection string with account key",
+			conn:       "DefaultEndpointsProtocol=https;AccountName=myaccount;AccountKey=bXlrZXk=;EndpointSuffix=core.windows.net",
+			wantURL:    "https://myaccount.blob.core.windows.net",
+			wantShared: true,
+		},
+		{
+			name:       "connection string with default protocol and suffix",
+			conn:       "AccountName=myaccount;AccountKey=bXlrZXk=",
+			wantURL:    "https://myaccount.blob.core.windows.net",
+			wantShared: true,
+		},
+		{
+			name:       "connection string with blob endpoint",
+			conn:       "AccountName=devstoreaccount1;AccountKey=bXlrZXk=;BlobEndpoint=http://127.0.0.1:10000/devstoreaccount1;",
+			wantURL:    "http://127.0.0.1:10000/devstoreaccount1",
+			wantShared: true,
+		},
+		{
+			name:       "endpoint overrides the blob endpoint",
+			conn:       "AccountName=devstoreaccount1;AccountKey=bXlrZXk=;BlobEndpoint=http://127.0.0.1:10000/devstoreaccount1;",
+			endpoint:   "http://azurite:10000/devstoreaccount1",
+			wantURL:    "http://azurite:10000/devstoreaccount1",
+			wantShared: true,
+		},
+		{
+			name:       "development storage",
+			conn:       "UseDevelopmentStorage=true",
+			wantURL:    "http://127.0.0.1:10000/devstoreaccount1",
+			wantShared: true,
+		},
+		{
+			name:    "connection string with shared access signature",
+			conn:    "BlobEndpoint=https://myaccount.blob.core.windows.net;SharedAccessSignature=sv=2019-12-12&sig=abc%3D",
+			wantURL: "https://myaccount.blob.core.windows.net?sv=2019-12-12&sig=abc%3D",
+		},
+		{
+			name:    "sas url",
+			conn:    "https://myaccount.blob.core.windows.net/?sv=2019-12-12&sig=abc%3D",
+			wantURL: "https://myaccount.blob.core.windows.net/?sv=2019-12-12&sig=abc%3D",
+		},
+		{
+			name:    "connection string without credentials",
+			conn:    "AccountName=myaccount",
+			wantErr: true,
+		},
+		{
+			name:    "connection string without account",
+			conn:    "AccountKey=bXlrZXk=",
+			wantErr: true,
+		},
+		{
+			name:    "malformed connection string",
+			conn:    "AccountName",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, credential, err := parseConnection(tt.conn, tt.endpoint)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseConnection() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if u.String() != tt.wantURL {
+				t.Errorf("parseConnection() url = %v, want %v", u.String(), tt.wantURL)
+			}
+			if _, ok := credential.(*azblob.SharedKeyCredential); ok != tt.wantShared {
+				t.Errorf("parseConnection() credential = %T, want shared key credential %v", credential, tt.wantShared)
+			}
+		})
+	}
+}
+
+func TestAzureBlob_DeleteDir_root(t *testing.T) {
+	for _, path := range []string{"", "/", "//"} {
+		if err := (&AzureBlob{}).DeleteDir(context.Background(), path); err == nil {
+			t.Errorf("DeleteDir() error = nil for root path (%s)", path)
+		}
+	}
+}
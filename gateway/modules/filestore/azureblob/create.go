@@ -0,0 +1,33 @@
+package azureblob
+
+import (
+	"context"
+	"io"
+	"mime"
+	"path/filepath"
+	"strings"
+
+	"github.com/Azure/azure-storage-blob-go/azblob"
+
+	"github.com/spaceuptech/space-cloud/gateway/model"
+	"github.com/spaceuptech/space-cloud/gateway/utils"
+)
+
+// CreateFile creates a file in azure blob storage
+func (a *AzureBlob) CreateFile(ctx context.Context, req *model.CreateFileRequest, file io.Reader) error {
+	path := strings.TrimPrefix(utils.JoinLeading(req.Path, req.Name, "/"), "/")
+	_, err := azblob.UploadStreamToBlockBlob(ctx, file, a.container.NewBlockBlobURL(path), azblob.UploadStreamToBlockBlobOptions{
+		BufferSize:      4 * 1024 * 1024,
+		MaxBuffers:      4,
+		BlobHTTPHeaders: azblob.BlobHTTPHeaders{ContentType: mime.TypeByExtension(filepath.Ext(req.Name))},
+	})
+	return err
+}
+
+// CreateDir creates a directory in azure blob storage. Blob storage has a flat namespace, hence an empty blob whose
+// name ends with a slash marks the directory
+func (a *AzureBlob) CreateDir(ctx context.Context, req *model.CreateFileRequest) error {
+	path := strings.TrimPrefix(utils.JoinLeadingTrailing(req.Path, req.Name, "/"), "/")
+	_, err := a.container.NewBlockBlobURL(path).Upload(ctx, strings.NewReader(""), azblob.BlobHTTPHeaders{}, azblob.Metadata{}, azblob.BlobAccessConditions{}, azblob.DefaultAccessTier, nil, azblob.ClientProvidedKeyOptions{})
+	return err
+}
@@ -0,0 +1,40 @@
+package azureblob
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/Azure/azure-storage-blob-go/azblob"
+)
+
+// DeleteFile deletes a file from azure blob storage
+func (a *AzureBlob) DeleteFile(ctx context.Context, path string) error {
+	path = strings.Trim(path, "/")
+	_, err := a.container.NewBlobURL(path).Delete(ctx, azblob.DeleteSnapshotsOptionInclude, azblob.BlobAccessConditions{})
+	return err
+}
+
+// DeleteDir deletes a directory in azure blob storage along with all the blobs inside it. The root directory can't be
+// deleted since that would empty the entire container
+func (a *AzureBlob) DeleteDir(ctx context.Context, path string) error {
+	prefix := strings.Trim(path, "/") + "/"
+	if prefix == "/" {
+		return errors.New("root directory of the container can't be deleted")
+	}
+
+	for marker := (azblob.Marker{}); marker.NotDone(); {
+		res, err := a.container.ListBlobsFlatSegment(ctx, marker, azblob.ListBlobsSegmentOptions{Prefix: prefix})
+		if err != nil {
+			return err
+		}
+		marker = res.NextMarker
+
+		for _, blob := range res.Segment.BlobItems {
+			if _, err := a.container.NewBlobURL(blob.Name).Delete(ctx, azblob.DeleteSnapshotsOptionInclude, azblob.BlobAccessConditions{}); err != nil && !isNotFound(err) {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,230 @@
+// +build file_integration
+
+package azureblob
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/Azure/azure-storage-blob-go/azblob"
+	"github.com/segmentio/ksuid"
+
+	"github.com/spaceuptech/space-cloud/gateway/model"
+)
+
+// The tests run against the azurite storage emulator by default
+var connection = flag.String("conn", "UseDevelopmentStorage=true", "connection string of the azure storage account")
+
+// initTestStore creates a new container for a test and deletes it once the test completes
+func initTestStore(t *testing.T) *AzureBlob {
+	ctx := context.Background()
+	a, err := Init(*connection, "", "space-cloud-"+strings.ToLower(ksuid.New().String()[:16]))
+	if err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+	if _, err := a.container.Create(ctx, azblob.Metadata{}, azblob.PublicAccessNone); err != nil {
+		t.Fatalf("Unable to create container - %v", err)
+	}
+	t.Cleanup(func() { _, _ = a.container.Delete(ctx, azblob.ContainerAccessConditions{}) })
+	return a
+}
+
+func createTestFiles(t *testing.T, a *AzureBlob, files map[string]string) {
+	for name, data := range files {
+		dir, file := "/", name
+		if i := strings.LastIndex(name, "/"); i >= 0 {
+			dir, file = name[:i], name[i+1:]
+		}
+		if err := a.CreateFile(context.Background(), &model.CreateFileRequest{Path: dir, Name: file, Type: "file", MakeAll: true}, bytes.NewBufferString(data)); err != nil {
+			t.Fatalf("CreateFile() error = %v", err)
+		}
+	}
+}
+
+func TestMain(m *testing.M) {
+	flag.Parse()
+	os.Exit(m.Run())
+}
+
+func TestAzureBlob_GetState(t *testing.T) {
+	a := initTestStore(t)
+	if err := a.GetState(context.Background()); err != nil {
+		t.Errorf("GetState() error = %v", err)
+	}
+
+	missing, _ := Init(*connection, "", "missing-container")
+	if err := missing.GetState(context.Background()); err == nil {
+		t.Errorf("GetState() error = nil, want an error for a missing container")
+	}
+}
+
+func TestAzureBlob_CreateAndReadFile(t *testing.T) {
+	ctx := context.Background()
+	a := initTestStore(t)
+
+	tests := []struct {
+		name      string
+		req       *model.CreateFileRequest
+		data      string
+		path      string
+		byteRange *model.FileRange
+		want      string
+	}{
+		{
+			name: "file at root level where path doesn't start with slash(/)",
+			req:  &model.CreateFileRequest{Path: "", Type: "file", Name: "creds.txt"},
+			data: "Die always like a fantastic lieutenant commander.",
+			path: "/creds.txt",
+			want: "Die always like a fantastic lieutenant commander.",
+		},
+		{
+			name: "file at root level",
+			req:  &model.CreateFileRequest{Path: "/", Type: "file", Name: "creds.txt"},
+			data: "Die always like a fantastic lieutenant commander.",
+			path: "creds.txt",
+			want: "Die always like a fantastic lieutenant commander.",
+		},
+		{
+			name: "file in a nested folder",
+			req:  &model.CreateFileRequest{Path: "/websites/assets/", Type: "file", Name: "creds.txt", MakeAll: true},
+			data: "Die always like a fantastic lieutenant commander.",
+			path: "/websites/assets/creds.txt",
+			want: "Die always like a fantastic lieutenant commander.",
+		},
+		{
+			name:      "range of a file",
+			req:       &model.CreateFileRequest{Path: "/", Type: "file", Name: "range.txt"},
+			data:      "0123456789",
+			path:      "/range.txt",
+			byteRange: &model.FileRange{Start: 2, End: 5},
+			want:      "2345",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := a.CreateFile(ctx, tt.req, bytes.NewBufferString(tt.data)); err != nil {
+				t.Fatalf("CreateFile() error = %v", err)
+			}
+
+			file, err := a.ReadFile(ctx, tt.path, tt.byteRange)
+			if err != nil {
+				t.Fatalf("ReadFile() error = %v", err)
+			}
+			defer func() { _ = file.Close() }()
+
+			data, _ := ioutil.ReadAll(file.File)
+			if string(data) != tt.want {
+				t.Errorf("ReadFile() data = %s, want %s", data, tt.want)
+			}
+			if file.Info.Size != int64(len(tt.data)) || file.Info.ETag == "" || file.Info.ModifiedAt == nil || file.Info.ContentType != "text/plain; charset=utf-8" {
+				t.Errorf("ReadFile() info = %+v, want the stat information of the file", file.Info)
+			}
+		})
+	}
+
+	if _, err := a.ReadFile(ctx, "/missing.txt", nil); err == nil {
+		t.Errorf("ReadFile() error = nil, want an error for a missing file")
+	}
+	if _, err := a.StatFile(ctx, "/missing.txt"); err == nil {
+		t.Errorf("StatFile() error = nil, want an error for a missing file")
+	}
+}
+
+func TestAzureBlob_ListDir(t *testing.T) {
+	ctx := context.Background()
+	a := initTestStore(t)
+	createTestFiles(t, a, map[string]string{
+		"root.txt":                "root",
+		"websites/index.html":     "index",
+		"websites/assets/app.css": "css",
+	})
+	if err := a.CreateDir(ctx, &model.CreateFileRequest{Path: "/websites", Name: "empty", Type: "dir"}); err != nil {
+		t.Fatalf("CreateDir() error = %v", err)
+	}
+
+	tests := []struct {
+		name string
+		req  *model.ListFilesRequest
+		want []string
+	}{
+		{name: "list all at root", req: &model.ListFilesRequest{Path: "/", Type: "all"}, want: []string{"dir:websites", "file:root.txt"}},
+		{name: "list all in folder", req: &model.ListFilesRequest{Path: "/websites", Type: "all"}, want: []string{"dir:assets", "dir:empty", "file:index.html"}},
+		{name: "list files in folder", req: &model.ListFilesRequest{Path: "websites/", Type: "file"}, want: []string{"file:index.html"}},
+		{name: "list dirs in folder", req: &model.ListFilesRequest{Path: "/websites/", Type: "dir"}, want: []string{"dir:assets", "dir:empty"}},
+		{name: "list empty folder", req: &model.ListFilesRequest{Path: "/websites/empty", Type: "all"}, want: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := a.ListDir(ctx, tt.req)
+			if err != nil {
+				t.Fatalf("ListDir() error = %v", err)
+			}
+
+			got := []string{}
+			for _, f := range res {
+				got = append(got, f.Type+":"+f.Name)
+				if f.Type == "file" && f.Size == 0 {
+					t.Errorf("ListDir() size of file (%s) = 0", f.Name)
+				}
+			}
+			sort.Strings(got)
+			if !equal(got, tt.want) {
+				t.Errorf("ListDir() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAzureBlob_Delete(t *testing.T) {
+	ctx := context.Background()
+	a := initTestStore(t)
+	createTestFiles(t, a, map[string]string{
+		"root.txt":                "root",
+		"websites/index.html":     "index",
+		"websites/assets/app.css": "css",
+	})
+
+	if err := a.DoesExists(ctx, "/websites"); err != nil {
+		t.Errorf("DoesExists() error = %v for a folder", err)
+	}
+
+	if err := a.DeleteFile(ctx, "/root.txt"); err != nil {
+		t.Fatalf("DeleteFile() error = %v", err)
+	}
+	if err := a.DoesExists(ctx, "/root.txt"); err == nil {
+		t.Errorf("DoesExists() error = nil for a deleted file")
+	}
+	if err := a.DeleteFile(ctx, "/root.txt"); err == nil {
+		t.Errorf("DeleteFile() error = nil for a missing file")
+	}
+
+	if err := a.DeleteDir(ctx, "/websites/"); err != nil {
+		t.Fatalf("DeleteDir() error = %v", err)
+	}
+	if err := a.DeleteDir(ctx, "/"); err == nil {
+		t.Errorf("DeleteDir() deleted the root directory")
+	}
+	for _, path := range []string{"/websites", "/websites/index.html", "/websites/assets/app.css"} {
+		if err := a.DoesExists(ctx, path); err == nil {
+			t.Errorf("DoesExists() error = nil for (%s) after deleting the folder", path)
+		}
+	}
+}
+
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,40 @@
+package azureblob
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/Azure/azure-storage-blob-go/azblob"
+	"github.com/spaceuptech/helpers"
+)
+
+// DoesExists checks if the path exists. A path is a directory if any blob exists inside it
+func (a *AzureBlob) DoesExists(ctx context.Context, path string) error {
+	path = strings.Trim(path, "/")
+
+	_, err := a.container.NewBlobURL(path).GetProperties(ctx, azblob.BlobAccessConditions{}, azblob.ClientProvidedKeyOptions{})
+	if err == nil {
+		return nil
+	}
+	if !isNotFound(err) {
+		return err
+	}
+
+	res, err := a.container.ListBlobsFlatSegment(ctx, azblob.Marker{}, azblob.ListBlobsSegmentOptions{Prefix: path + "/", MaxResults: 1})
+	if err != nil {
+		return err
+	}
+	if len(res.Segment.BlobItems) == 0 {
+		return errors.New("provided file / dir path not found")
+	}
+	return nil
+}
+
+// GetState checks if sc is able to query the azure blob storage container
+func (a *AzureBlob) GetState(ctx context.Context) error {
+	if _, err := a.container.GetProperties(ctx, azblob.LeaseAccessConditions{}); err != nil {
+		return helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to connect to azure blob storage", err, nil)
+	}
+	return nil
+}
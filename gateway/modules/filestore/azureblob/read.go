@@ -0,0 +1,102 @@
+package azureblob
+
+import (
+	"bufio"
+	"context"
+	"strings"
+
+	"github.com/Azure/azure-storage-blob-go/azblob"
+
+	"github.com/spaceuptech/space-cloud/gateway/model"
+)
+
+// ListDir lists a directory in azure blob storage
+func (a *AzureBlob) ListDir(ctx context.Context, req *model.ListFilesRequest) ([]*model.ListFilesResponse, error) {
+	// path should not start with a slash but should end with one
+	prefix := strings.Trim(req.Path, "/") + "/"
+	if prefix == "/" {
+		prefix = ""
+	}
+
+	result := []*model.ListFilesResponse{}
+	for marker := (azblob.Marker{}); marker.NotDone(); {
+		res, err := a.container.ListBlobsHierarchySegment(ctx, marker, "/", azblob.ListBlobsSegmentOptions{Prefix: prefix})
+		if err != nil {
+			return nil, err
+		}
+		marker = res.NextMarker
+
+		for _, p := range res.Segment.BlobPrefixes {
+			t := &model.ListFilesResponse{Name: strings.TrimSuffix(strings.TrimPrefix(p.Name, prefix), "/"), Type: "dir"}
+			if req.Type == "all" || req.Type == t.Type {
+				result = append(result, t)
+			}
+		}
+		for _, blob := range res.Segment.BlobItems {
+			// Skip the blob marking the directory being listed
+			if blob.Name == prefix {
+				continue
+			}
+
+			t := &model.ListFilesResponse{Name: strings.TrimPrefix(blob.Name, prefix), Type: "file", FileInfo: *getFileInfo(blob.Properties)}
+			if req.Type == "all" || req.Type == t.Type {
+				result = append(result, t)
+			}
+		}
+	}
+	return result, nil
+}
+
+// ReadFile reads a file from azure blob storage. Only the provided range of the file is read if the range isn't nil
+func (a *AzureBlob) ReadFile(ctx context.Context, path string, byteRange *model.FileRange) (*model.File, error) {
+	info, err := a.StatFile(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	// A count of 0 reads till the end of the blob
+	var offset, count int64 = 0, azblob.CountToEnd
+	if byteRange != nil {
+		offset, count = byteRange.Start, byteRange.Length()
+	}
+
+	// The blob is only read if it hasn't changed since it was stat'ed
+	ac := azblob.BlobAccessConditions{ModifiedAccessConditions: azblob.ModifiedAccessConditions{IfMatch: azblob.ETag(info.ETag)}}
+	res, err := a.getBlobURL(path).Download(ctx, offset, count, ac, false, azblob.ClientProvidedKeyOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	body := res.Body(azblob.RetryReaderOptions{MaxRetryRequests: 3})
+	return &model.File{File: bufio.NewReader(body), Close: func() error { return body.Close() }, Info: info, Range: byteRange}, nil
+}
+
+// StatFile returns the stat information of the file at the path provided
+func (a *AzureBlob) StatFile(ctx context.Context, path string) (*model.FileInfo, error) {
+	res, err := a.getBlobURL(path).GetProperties(ctx, azblob.BlobAccessConditions{}, azblob.ClientProvidedKeyOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	modifiedAt := res.LastModified()
+	return &model.FileInfo{Size: res.ContentLength(), ModifiedAt: &modifiedAt, ContentType: res.ContentType(), ETag: string(res.ETag())}, nil
+}
+
+func (a *AzureBlob) getBlobURL(path string) azblob.BlobURL {
+	return a.container.NewBlobURL(strings.TrimPrefix(path, "/"))
+}
+
+func getFileInfo(props azblob.BlobProperties) *model.FileInfo {
+	info := &model.FileInfo{ETag: string(props.Etag)}
+	if props.ContentLength != nil {
+		info.Size = *props.ContentLength
+	}
+	if props.ContentType != nil {
+		info.ContentType = *props.ContentType
+	}
+	if !props.LastModified.IsZero() {
+		modifiedAt := props.LastModified
+		info.ModifiedAt = &modifiedAt
+	}
+	return info
+}
@@ -10,18 +10,19 @@ import (
 
 // GCPStorage holds the GCPStorage client
 type GCPStorage struct {
-	client *storage.Client
-	bucket string
+	client        *storage.Client
+	bucket        string
+	uploadsBucket string // The bucket where the parts of resumable uploads are staged
 }
 
 // Init initializes a GCPStorage client
-func Init(bucket string) (*GCPStorage, error) {
+func Init(bucket, uploadsBucket string) (*GCPStorage, error) {
 	ctx := context.TODO()
 	client, err := storage.NewClient(ctx)
 	if err != nil {
 		return nil, err
 	}
-	return &GCPStorage{client, bucket}, nil
+	return &GCPStorage{client: client, bucket: bucket, uploadsBucket: uploadsBucket}, nil
 }
 
 // GetStoreType returns the file store type
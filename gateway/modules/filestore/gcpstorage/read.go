@@ -42,7 +42,7 @@ func (g *GCPStorage) ListDir(ctx context.Context, req *model.ListFilesRequest) (
 		} else {
 			name := strings.TrimPrefix(attrs.Name, req.Path)
 			name = strings.TrimLeft(name, "/")
-			t := &model.ListFilesResponse{Name: name, Type: "file"}
+			t := &model.ListFilesResponse{Name: name, Type: "file", FileInfo: *getFileInfo(attrs)}
 			if req.Type == "all" || req.Type == t.Type {
 				result = append(result, t)
 			}
@@ -51,14 +51,38 @@ func (g *GCPStorage) ListDir(ctx context.Context, req *model.ListFilesRequest) (
 	return result, nil
 }
 
-// ReadFile reads a file from GCPStorage
-func (g *GCPStorage) ReadFile(ctx context.Context, path string) (*model.File, error) {
+// ReadFile reads a file from GCPStorage. Only the provided range of the file is read if the range isn't nil
+func (g *GCPStorage) ReadFile(ctx context.Context, path string, byteRange *model.FileRange) (*model.File, error) {
+	info, err := g.StatFile(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	var offset, length int64 = 0, -1
+	if byteRange != nil {
+		offset, length = byteRange.Start, byteRange.Length()
+	}
+
+	rc, err := g.client.Bucket(g.bucket).Object(strings.TrimPrefix(path, "/")).NewRangeReader(ctx, offset, length)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.File{File: bufio.NewReader(rc), Close: func() error { return rc.Close() }, Info: info, Range: byteRange}, nil
+}
+
+// StatFile returns the stat information of the file at the path provided
+func (g *GCPStorage) StatFile(ctx context.Context, path string) (*model.FileInfo, error) {
 	path = strings.TrimPrefix(path, "/")
 
-	rc, err := g.client.Bucket(g.bucket).Object(path).NewReader(ctx)
+	attrs, err := g.client.Bucket(g.bucket).Object(path).Attrs(ctx)
 	if err != nil {
 		return nil, err
 	}
+	return getFileInfo(attrs), nil
+}
 
-	return &model.File{File: bufio.NewReader(rc), Close: func() error { return rc.Close() }}, nil
+func getFileInfo(attrs *storage.ObjectAttrs) *model.FileInfo {
+	modifiedAt := attrs.Updated
+	return &model.FileInfo{Size: attrs.Size, ModifiedAt: &modifiedAt, ContentType: attrs.ContentType, ETag: attrs.Etag}
 }
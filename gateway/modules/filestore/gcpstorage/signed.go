@@ -0,0 +1,54 @@
+package gcpstorage
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"golang.org/x/oauth2/google"
+
+	"github.com/spaceuptech/space-cloud/gateway/model"
+)
+
+// GetSignedURL creates a presigned url to read or create a file in GCPStorage. The url is signed using the service
+// account of the default credentials
+func (g *GCPStorage) GetSignedURL(ctx context.Context, req *model.SignedURLRequest) (string, string, error) {
+	var path, method string
+	switch req.Op {
+	case model.FileRead:
+		path, method = strings.TrimPrefix(req.Path, "/"), http.MethodGet
+	case model.FileCreate:
+		path, method = strings.TrimPrefix(req.Path, "/"), http.MethodPut
+		if len(path) == 0 {
+			path = req.Name
+		} else {
+			path = path + "/" + req.Name
+		}
+	default:
+		return "", "", fmt.Errorf("invalid op (%s) provided for signed url", req.Op)
+	}
+
+	creds, err := google.FindDefaultCredentials(ctx, storage.ScopeReadWrite)
+	if err != nil {
+		return "", "", err
+	}
+	conf, err := google.JWTConfigFromJSON(creds.JSON)
+	if err != nil {
+		return "", "", fmt.Errorf("signed urls require service account credentials - %v", err)
+	}
+
+	url, err := storage.SignedURL(g.bucket, path, &storage.SignedURLOptions{
+		GoogleAccessID: conf.Email,
+		PrivateKey:     conf.PrivateKey,
+		Method:         method,
+		Expires:        time.Now().Add(time.Duration(req.Expiry) * time.Second),
+		Scheme:         storage.SigningSchemeV4,
+	})
+	if err != nil {
+		return "", "", err
+	}
+	return url, method, nil
+}
@@ -0,0 +1,174 @@
+package gcpstorage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/segmentio/ksuid"
+	"google.golang.org/api/iterator"
+
+	"github.com/spaceuptech/space-cloud/gateway/model"
+)
+
+const (
+	// uploadsDir is the prefix in the uploads bucket where the parts of resumable uploads are staged
+	uploadsDir = "uploads"
+
+	// maxComposeSources is the max number of objects which can be composed in a single request
+	maxComposeSources = 32
+)
+
+// CreateUpload creates a resumable upload. The parts are staged as objects in the uploads bucket, so that they can't be
+// accessed through the file store, and are composed once the upload completes
+func (g *GCPStorage) CreateUpload(ctx context.Context, req *model.CreateFileRequest) (string, error) {
+	if g.uploadsBucket == "" || g.uploadsBucket == g.bucket {
+		return "", errors.New("separate uploads bucket is required for resumable uploads")
+	}
+	return ksuid.New().String(), nil
+}
+
+// UploadPart writes a part of a resumable upload as a staged object
+func (g *GCPStorage) UploadPart(ctx context.Context, upload *model.FileUpload, number int, reader io.Reader) (*model.FileUploadPart, error) {
+	wc := g.client.Bucket(g.uploadsBucket).Object(getPartName(upload.ID, number)).NewWriter(ctx)
+	size, err := io.Copy(wc, reader)
+	if err != nil {
+		_ = wc.Close()
+		return nil, err
+	}
+	if err := wc.Close(); err != nil {
+		return nil, err
+	}
+	return &model.FileUploadPart{Number: number, Size: size, ETag: wc.Attrs().Etag}, nil
+}
+
+// ListParts lists the parts of a resumable upload staged so far
+func (g *GCPStorage) ListParts(ctx context.Context, upload *model.FileUpload) ([]*model.FileUploadPart, error) {
+	prefix := getUploadPrefix(upload.ID) + "part-"
+	it := g.client.Bucket(g.uploadsBucket).Objects(ctx, &storage.Query{Prefix: prefix})
+
+	parts := []*model.FileUploadPart{}
+	for {
+		attrs, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		number, err := strconv.Atoi(strings.TrimPrefix(attrs.Name, prefix))
+		if err != nil {
+			continue
+		}
+		parts = append(parts, &model.FileUploadPart{Number: number, Size: attrs.Size, ETag: attrs.Etag})
+	}
+	return parts, nil
+}
+
+// CompleteUpload composes the staged parts into a single object which is then copied to the file. The parts are
+// composed in batches since a compose request is limited to 32 objects. The file is only replaced by the copy
+func (g *GCPStorage) CompleteUpload(ctx context.Context, upload *model.FileUpload, parts []*model.FileUploadPart) error {
+	bucket := g.client.Bucket(g.uploadsBucket)
+
+	names := make([]string, len(parts))
+	for i, part := range parts {
+		names[i] = getPartName(upload.ID, part.Number)
+	}
+
+	for round := 0; len(names) > 1; round++ {
+		composed := []string{}
+		for i := 0; i < len(names); i += maxComposeSources {
+			end := i + maxComposeSources
+			if end > len(names) {
+				end = len(names)
+			}
+			name := fmt.Sprintf("%scompose-%d-%d", getUploadPrefix(upload.ID), round, i/maxComposeSources)
+			if _, err := bucket.Object(name).ComposerFrom(getObjects(bucket, names[i:end])...).Run(ctx); err != nil {
+				return err
+			}
+			composed = append(composed, name)
+		}
+		names = composed
+	}
+
+	path := strings.Trim(upload.Path, "/") + "/" + upload.Name
+	if strings.Trim(upload.Path, "/") == "" {
+		path = upload.Name
+	}
+
+	// Compose requests can't write to another bucket
+	if _, err := g.client.Bucket(g.bucket).Object(path).CopierFrom(bucket.Object(names[0])).Run(ctx); err != nil {
+		return err
+	}
+
+	return g.AbortUpload(ctx, upload)
+}
+
+// AbortUpload deletes the staged objects of a resumable upload
+func (g *GCPStorage) AbortUpload(ctx context.Context, upload *model.FileUpload) error {
+	return g.deletePrefix(ctx, getUploadPrefix(upload.ID))
+}
+
+// CleanupUploads deletes the staged objects of the uploads created before the provided time
+func (g *GCPStorage) CleanupUploads(ctx context.Context, before time.Time) error {
+	if g.uploadsBucket == "" || g.uploadsBucket == g.bucket {
+		return nil
+	}
+
+	it := g.client.Bucket(g.uploadsBucket).Objects(ctx, &storage.Query{Prefix: uploadsDir + "/", Delimiter: "/"})
+	for {
+		attrs, err := it.Next()
+		if err == iterator.Done {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		id, err := ksuid.Parse(strings.Trim(strings.TrimPrefix(attrs.Prefix, uploadsDir+"/"), "/"))
+		if err != nil || !id.Time().Before(before) {
+			continue
+		}
+		if err := g.deletePrefix(ctx, attrs.Prefix); err != nil {
+			return err
+		}
+	}
+}
+
+func (g *GCPStorage) deletePrefix(ctx context.Context, prefix string) error {
+	bucket := g.client.Bucket(g.uploadsBucket)
+	it := bucket.Objects(ctx, &storage.Query{Prefix: prefix})
+	for {
+		attrs, err := it.Next()
+		if err == iterator.Done {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := bucket.Object(attrs.Name).Delete(ctx); err != nil && err != storage.ErrObjectNotExist {
+			return err
+		}
+	}
+}
+
+func getObjects(bucket *storage.BucketHandle, names []string) []*storage.ObjectHandle {
+	objects := make([]*storage.ObjectHandle, len(names))
+	for i, name := range names {
+		objects[i] = bucket.Object(name)
+	}
+	return objects
+}
+
+func getUploadPrefix(id string) string {
+	return uploadsDir + "/" + id + "/"
+}
+
+func getPartName(id string, number int) string {
+	return fmt.Sprintf("%spart-%05d", getUploadPrefix(id), number)
+}
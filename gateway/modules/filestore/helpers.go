@@ -0,0 +1,97 @@
+package filestore
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spaceuptech/space-cloud/gateway/model"
+)
+
+var errRangeNotSatisfiable = errors.New("requested range is not satisfiable")
+
+// readFile reads the file from the store. Only the requested range of the file is read if the byte range is valid.
+// It must be called with a lock held
+func (m *Module) readFile(ctx context.Context, project string, store FileStore, path, byteRange string) (int, *model.File, error) {
+	var r *model.FileRange
+	if byteRange != "" {
+		info, err := store.StatFile(ctx, path)
+		if err != nil {
+			return http.StatusNotFound, nil, err
+		}
+
+		r, err = parseRange(byteRange, info.Size)
+		if err != nil {
+			return http.StatusRequestedRangeNotSatisfiable, &model.File{Info: info}, err
+		}
+	}
+
+	// Read the file from file storage
+	start := time.Now()
+	file, err := store.ReadFile(ctx, path, r)
+	m.metricsHook(project, string(store.GetStoreType()), model.Read, time.Since(start), err)
+	if err != nil {
+		return http.StatusInternalServerError, nil, err
+	}
+
+	if r != nil {
+		return http.StatusPartialContent, file, nil
+	}
+	return http.StatusOK, file, nil
+}
+
+// parseRange parses the value of a http Range header for a file of the provided size. Headers which are malformed or
+// request multiple ranges are ignored and a nil range is returned, in which case the entire file must be served
+func parseRange(header string, size int64) (*model.FileRange, error) {
+	if !strings.HasPrefix(header, "bytes=") {
+		return nil, nil
+	}
+	spec := strings.TrimSpace(strings.TrimPrefix(header, "bytes="))
+	if strings.Contains(spec, ",") {
+		return nil, nil
+	}
+
+	i := strings.Index(spec, "-")
+	if i < 0 {
+		return nil, nil
+	}
+	startStr, endStr := strings.TrimSpace(spec[:i]), strings.TrimSpace(spec[i+1:])
+
+	// Suffix ranges request the last n bytes of the file
+	if startStr == "" {
+		n, err := strconv.ParseInt(endStr, 10, 64)
+		if err != nil || n < 0 {
+			return nil, nil
+		}
+		if n == 0 || size == 0 {
+			return nil, errRangeNotSatisfiable
+		}
+		if n > size {
+			n = size
+		}
+		return &model.FileRange{Start: size - n, End: size - 1}, nil
+	}
+
+	start, err := strconv.ParseInt(startStr, 10, 64)
+	if err != nil || start < 0 {
+		return nil, nil
+	}
+	if start >= size {
+		return nil, errRangeNotSatisfiable
+	}
+
+	end := size - 1
+	if endStr != "" {
+		e, err := strconv.ParseInt(endStr, 10, 64)
+		if err != nil || e < start {
+			return nil, nil
+		}
+		if e < end {
+			end = e
+		}
+	}
+	return &model.FileRange{Start: start, End: end}, nil
+}
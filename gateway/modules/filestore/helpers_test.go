@@ -0,0 +1,105 @@
+package filestore
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/spaceuptech/space-cloud/gateway/config"
+	"github.com/spaceuptech/space-cloud/gateway/model"
+	"github.com/spaceuptech/space-cloud/gateway/utils"
+)
+
+func Test_parseRange(t *testing.T) {
+	tests := []struct {
+		name    string
+		header  string
+		size    int64
+		want    *model.FileRange
+		wantErr bool
+	}{
+		{name: "closed range", header: "bytes=0-4", size: 10, want: &model.FileRange{Start: 0, End: 4}},
+		{name: "closed range exceeding the size", header: "bytes=5-100", size: 10, want: &model.FileRange{Start: 5, End: 9}},
+		{name: "open ended range", header: "bytes=3-", size: 10, want: &model.FileRange{Start: 3, End: 9}},
+		{name: "suffix range", header: "bytes=-4", size: 10, want: &model.FileRange{Start: 6, End: 9}},
+		{name: "suffix range exceeding the size", header: "bytes=-40", size: 10, want: &model.FileRange{Start: 0, End: 9}},
+		{name: "start beyond the size", header: "bytes=10-", size: 10, wantErr: true},
+		{name: "empty suffix range", header: "bytes=-0", size: 10, wantErr: true},
+		{name: "range of an empty file", header: "bytes=0-", size: 0, wantErr: true},
+		{name: "multiple ranges are ignored", header: "bytes=0-1,4-5", size: 10},
+		{name: "unknown unit is ignored", header: "items=0-1", size: 10},
+		{name: "malformed range is ignored", header: "bytes=a-b", size: 10},
+		{name: "end before start is ignored", header: "bytes=5-2", size: 10},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseRange(tt.header, tt.size)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseRange() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseRange() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestModule_DownloadFile(t *testing.T) {
+	root := t.TempDir()
+	if err := ioutil.WriteFile(filepath.Join(root, "file.txt"), []byte("0123456789"), 0644); err != nil {
+		t.Fatalf("Unable to create file - %v", err)
+	}
+
+	auth := new(mockAuthFilestoreInterface)
+	auth.On("IsFileOpAuthorised", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	m := Init(auth, func(project, storeType string, op model.OperationType, latency time.Duration, err error) {})
+	if err := m.SetConfig("myproject", &config.FileStoreConfig{Enabled: true, StoreType: string(utils.Local), Conn: root}); err != nil {
+		t.Fatalf("SetConfig() error = %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		byteRange  string
+		wantStatus int
+		wantData   string
+		wantRange  *model.FileRange
+		wantErr    bool
+	}{
+		{name: "entire file", wantStatus: http.StatusOK, wantData: "0123456789"},
+		{name: "range of the file", byteRange: "bytes=2-5", wantStatus: http.StatusPartialContent, wantData: "2345", wantRange: &model.FileRange{Start: 2, End: 5}},
+		{name: "suffix range of the file", byteRange: "bytes=-3", wantStatus: http.StatusPartialContent, wantData: "789", wantRange: &model.FileRange{Start: 7, End: 9}},
+		{name: "multiple ranges serve the entire file", byteRange: "bytes=0-1,3-4", wantStatus: http.StatusOK, wantData: "0123456789"},
+		{name: "unsatisfiable range", byteRange: "bytes=20-", wantStatus: http.StatusRequestedRangeNotSatisfiable, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, file, err := m.DownloadFile(context.Background(), "myproject", "token", "", "/file.txt", tt.byteRange)
+			if (err != nil) != tt.wantErr || status != tt.wantStatus {
+				t.Errorf("DownloadFile() status = %v, error = %v, want status %v wantErr %v", status, err, tt.wantStatus, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			defer func() { _ = file.Close() }()
+
+			data, _ := ioutil.ReadAll(file.File)
+			if string(data) != tt.wantData {
+				t.Errorf("DownloadFile() data = %s, want %s", data, tt.wantData)
+			}
+			if !reflect.DeepEqual(file.Range, tt.wantRange) {
+				t.Errorf("DownloadFile() range = %v, want %v", file.Range, tt.wantRange)
+			}
+			if file.Info == nil || file.Info.Size != 10 || file.Info.ETag == "" || file.Info.ContentType != "text/plain; charset=utf-8" {
+				t.Errorf("DownloadFile() info = %+v, want the stat information of the file", file.Info)
+			}
+		})
+	}
+}
@@ -8,12 +8,13 @@ import (
 
 // Local is the file store driver for the local filesystem
 type Local struct {
-	rootPath string
+	rootPath    string
+	uploadsPath string // The directory where the parts of resumable uploads are staged. It lies outside the root path
 }
 
 // Init initialises the local filestore driver
 func Init(path string) (*Local, error) {
-	return &Local{path}, os.MkdirAll(path, os.ModePerm)
+	return &Local{rootPath: path, uploadsPath: getUploadsPath(path)}, os.MkdirAll(path, os.ModePerm)
 }
 
 // GetStoreType returns the file store type
@@ -3,8 +3,12 @@ package local
 import (
 	"bufio"
 	"context"
+	"fmt"
+	"io"
 	"io/ioutil"
+	"mime"
 	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/spaceuptech/space-cloud/gateway/model"
@@ -24,6 +28,8 @@ func (l *Local) ListDir(ctx context.Context, req *model.ListFilesRequest) ([]*mo
 		t := &model.ListFilesResponse{Name: f.Name(), Type: "file"}
 		if f.IsDir() {
 			t.Type = "dir"
+		} else {
+			t.FileInfo = *getFileInfo(f)
 		}
 
 		if req.Type == "all" || req.Type == t.Type {
@@ -34,8 +40,8 @@ func (l *Local) ListDir(ctx context.Context, req *model.ListFilesRequest) ([]*mo
 	return result, nil
 }
 
-// ReadFile reads a file from the path provided
-func (l *Local) ReadFile(ctx context.Context, path string) (*model.File, error) {
+// ReadFile reads a file from the path provided. Only the provided range of the file is read if the range isn't nil
+func (l *Local) ReadFile(ctx context.Context, path string, byteRange *model.FileRange) (*model.File, error) {
 	ps := string(os.PathSeparator)
 	p := strings.TrimRight(l.rootPath, ps) + ps + strings.TrimLeft(path, ps)
 	f, err := os.Open(p)
@@ -43,5 +49,45 @@ func (l *Local) ReadFile(ctx context.Context, path string) (*model.File, error)
 		return nil, err
 	}
 
-	return &model.File{File: bufio.NewReader(f), Close: func() error { return f.Close() }}, nil
+	stat, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+
+	var reader io.Reader = f
+	if byteRange != nil {
+		if _, err := f.Seek(byteRange.Start, io.SeekStart); err != nil {
+			_ = f.Close()
+			return nil, err
+		}
+		reader = io.LimitReader(f, byteRange.Length())
+	}
+
+	return &model.File{File: bufio.NewReader(reader), Close: func() error { return f.Close() }, Info: getFileInfo(stat), Range: byteRange}, nil
+}
+
+// StatFile returns the stat information of the file at the path provided
+func (l *Local) StatFile(ctx context.Context, path string) (*model.FileInfo, error) {
+	ps := string(os.PathSeparator)
+	p := strings.TrimRight(l.rootPath, ps) + ps + strings.TrimLeft(path, ps)
+	stat, err := os.Stat(p)
+	if err != nil {
+		return nil, err
+	}
+	if stat.IsDir() {
+		return nil, fmt.Errorf("path (%s) is a directory", path)
+	}
+
+	return getFileInfo(stat), nil
+}
+
+func getFileInfo(stat os.FileInfo) *model.FileInfo {
+	modifiedAt := stat.ModTime().UTC()
+	return &model.FileInfo{
+		Size:        stat.Size(),
+		ModifiedAt:  &modifiedAt,
+		ContentType: mime.TypeByExtension(filepath.Ext(stat.Name())),
+		ETag:        fmt.Sprintf(`"%x-%x"`, modifiedAt.UnixNano(), stat.Size()),
+	}
 }
@@ -0,0 +1,152 @@
+package local
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/segmentio/ksuid"
+
+	"github.com/spaceuptech/space-cloud/gateway/model"
+	"github.com/spaceuptech/space-cloud/gateway/utils"
+)
+
+// CreateUpload creates the staging directory of a resumable upload
+func (l *Local) CreateUpload(ctx context.Context, req *model.CreateFileRequest) (string, error) {
+	id := ksuid.New().String()
+	return id, os.MkdirAll(l.getUploadPath(id), os.ModePerm)
+}
+
+// UploadPart writes a part of a resumable upload to the staging directory
+func (l *Local) UploadPart(ctx context.Context, upload *model.FileUpload, number int, reader io.Reader) (*model.FileUploadPart, error) {
+	path := l.getUploadPath(upload.ID)
+	if !isPathDir(path) {
+		return nil, fmt.Errorf("upload (%s) doesn't exist", upload.ID)
+	}
+
+	// The part is written to a temporary file first so that a failed request doesn't leave a partial part behind
+	f, err := ioutil.TempFile(path, "tmp-")
+	if err != nil {
+		return nil, err
+	}
+	size, err := io.Copy(f, reader)
+	utils.CloseTheCloser(f)
+	if err != nil {
+		_ = os.Remove(f.Name())
+		return nil, err
+	}
+
+	if err := os.Rename(f.Name(), filepath.Join(path, fmt.Sprintf("part-%05d", number))); err != nil {
+		_ = os.Remove(f.Name())
+		return nil, err
+	}
+	return &model.FileUploadPart{Number: number, Size: size}, nil
+}
+
+// ListParts lists the parts of a resumable upload staged so far
+func (l *Local) ListParts(ctx context.Context, upload *model.FileUpload) ([]*model.FileUploadPart, error) {
+	files, err := ioutil.ReadDir(l.getUploadPath(upload.ID))
+	if err != nil {
+		return nil, err
+	}
+
+	parts := []*model.FileUploadPart{}
+	for _, f := range files {
+		if !strings.HasPrefix(f.Name(), "part-") {
+			continue
+		}
+		number, err := strconv.Atoi(strings.TrimPrefix(f.Name(), "part-"))
+		if err != nil {
+			continue
+		}
+		parts = append(parts, &model.FileUploadPart{Number: number, Size: f.Size()})
+	}
+	return parts, nil
+}
+
+// CompleteUpload concatenates the staged parts into the file. The file is replaced atomically once all the parts are written
+func (l *Local) CompleteUpload(ctx context.Context, upload *model.FileUpload, parts []*model.FileUploadPart) error {
+	ps := string(os.PathSeparator)
+	dir := strings.TrimRight(l.rootPath, ps) + ps + strings.TrimLeft(upload.Path, ps)
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return err
+	}
+
+	f, err := ioutil.TempFile(dir, "."+upload.Name+".")
+	if err != nil {
+		return err
+	}
+	if err := l.concatParts(f, upload, parts); err != nil {
+		utils.CloseTheCloser(f)
+		_ = os.Remove(f.Name())
+		return err
+	}
+	if err := f.Close(); err != nil {
+		_ = os.Remove(f.Name())
+		return err
+	}
+
+	if err := os.Rename(f.Name(), filepath.Join(dir, upload.Name)); err != nil {
+		_ = os.Remove(f.Name())
+		return err
+	}
+	return os.RemoveAll(l.getUploadPath(upload.ID))
+}
+
+// AbortUpload removes the staging directory of a resumable upload
+func (l *Local) AbortUpload(ctx context.Context, upload *model.FileUpload) error {
+	return os.RemoveAll(l.getUploadPath(upload.ID))
+}
+
+// CleanupUploads removes the staging directories of the uploads created before the provided time
+func (l *Local) CleanupUploads(ctx context.Context, before time.Time) error {
+	files, err := ioutil.ReadDir(l.uploadsPath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, f := range files {
+		id, err := ksuid.Parse(f.Name())
+		if err != nil || !id.Time().Before(before) {
+			continue
+		}
+		if err := os.RemoveAll(l.getUploadPath(f.Name())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (l *Local) concatParts(w io.Writer, upload *model.FileUpload, parts []*model.FileUploadPart) error {
+	for _, part := range parts {
+		f, err := os.Open(filepath.Join(l.getUploadPath(upload.ID), fmt.Sprintf("part-%05d", part.Number)))
+		if err != nil {
+			return err
+		}
+		_, err = io.Copy(w, f)
+		utils.CloseTheCloser(f)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (l *Local) getUploadPath(id string) string {
+	return filepath.Join(l.uploadsPath, filepath.Base(id))
+}
+
+// getUploadsPath returns the directory next to the root path where the parts of resumable uploads are staged, so
+// that they can't be accessed through the file store
+func getUploadsPath(rootPath string) string {
+	return filepath.Clean(rootPath) + ".uploads"
+}
@@ -2,25 +2,33 @@ package filestore
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"time"
 
 	"github.com/spaceuptech/helpers"
 
 	"github.com/spaceuptech/space-cloud/gateway/model"
+	"github.com/spaceuptech/space-cloud/gateway/utils"
 )
 
 // CreateDir creates a directory at the provided path
 func (m *Module) CreateDir(ctx context.Context, project, token string, req *model.CreateFileRequest, meta map[string]interface{}) (int, error) {
-	// Exit if file storage is not enabled
-	if !m.IsEnabled() {
-		return http.StatusNotFound, errors.New("This feature isn't enabled")
+	req.Store = utils.GetFileStoreAlias(req.Store)
+
+	if isUploadsDir(req.Path, req.Name) {
+		return http.StatusBadRequest, errUploadsDir
+	}
+
+	// Exit if the file store is not enabled
+	status, err := m.checkStore(req.Store)
+	if err != nil {
+		return status, err
 	}
 
 	// Check if the user is authorised to make this request
-	_, err := m.auth.IsFileOpAuthorised(ctx, project, token, req.Path, model.FileCreate, map[string]interface{}{})
+	_, err = m.auth.IsFileOpAuthorised(ctx, project, token, req.Store, req.Path, model.FileCreate, map[string]interface{}{})
 	if err != nil {
 		return http.StatusForbidden, err
 	}
@@ -28,60 +36,81 @@ func (m *Module) CreateDir(ctx context.Context, project, token string, req *mode
 	m.RLock()
 	defer m.RUnlock()
 
+	store, status, err := m.getStore(req.Store)
+	if err != nil {
+		return status, err
+	}
+
 	intent, err := m.eventing.CreateFileIntentHook(ctx, req)
 	if err != nil {
 		return http.StatusInternalServerError, err
 	}
 
-	if err = m.store.CreateDir(ctx, req); err != nil {
+	start := time.Now()
+	err = store.CreateDir(ctx, req)
+	m.metricsHook(project, string(store.GetStoreType()), model.Create, time.Since(start), err)
+	if err != nil {
 		m.eventing.HookStage(ctx, intent, err)
 		return http.StatusInternalServerError, nil
 	}
 
 	m.eventing.HookStage(ctx, intent, nil)
-	m.metricsHook(project, string(m.store.GetStoreType()), model.Create)
 	return http.StatusOK, err
 }
 
 // DeleteDir deletes a directory at the provided path
-func (m *Module) DeleteDir(ctx context.Context, project, token string, path string, meta map[string]interface{}) (int, error) {
-	// Exit if file storage is not enabled
-	if !m.IsEnabled() {
-		return http.StatusNotFound, errors.New("This feature isn't enabled")
+func (m *Module) DeleteDir(ctx context.Context, project, token, storeAlias, path string, meta map[string]interface{}) (int, error) {
+	storeAlias = utils.GetFileStoreAlias(storeAlias)
+
+	// Exit if the file store is not enabled
+	status, err := m.checkStore(storeAlias)
+	if err != nil {
+		return status, err
 	}
+
 	// Check if the user is authorised to make this request
-	_, err := m.auth.IsFileOpAuthorised(ctx, project, token, path, model.FileDelete, map[string]interface{}{})
+	_, err = m.auth.IsFileOpAuthorised(ctx, project, token, storeAlias, path, model.FileDelete, map[string]interface{}{})
 	if err != nil {
-		return http.StatusForbidden, errors.New("You are not authorized to make this request")
+		return http.StatusForbidden, fmt.Errorf("You are not authorized to make this request")
 	}
 
 	m.RLock()
 	defer m.RUnlock()
 
-	intent, err := m.eventing.DeleteFileIntentHook(ctx, path, meta)
+	store, status, err := m.getStore(storeAlias)
+	if err != nil {
+		return status, err
+	}
+
+	intent, err := m.eventing.DeleteFileIntentHook(ctx, storeAlias, path, meta)
 	if err != nil {
 		return http.StatusInternalServerError, err
 	}
 
-	if err = m.store.DeleteDir(ctx, path); err != nil {
+	start := time.Now()
+	err = store.DeleteDir(ctx, path)
+	m.metricsHook(project, string(store.GetStoreType()), model.Delete, time.Since(start), err)
+	if err != nil {
 		m.eventing.HookStage(ctx, intent, err)
 		return http.StatusInternalServerError, err
 	}
 
 	m.eventing.HookStage(ctx, intent, nil)
-	m.metricsHook(project, string(m.store.GetStoreType()), model.Delete)
 	return http.StatusOK, err
 }
 
 // DeleteFile deletes a file at the provided path
-func (m *Module) DeleteFile(ctx context.Context, project, token string, path string, meta map[string]interface{}) (int, error) {
-	// Exit if file storage is not enabled
-	if !m.IsEnabled() {
-		return http.StatusNotFound, errors.New("This feature isn't enabled")
+func (m *Module) DeleteFile(ctx context.Context, project, token, storeAlias, path string, meta map[string]interface{}) (int, error) {
+	storeAlias = utils.GetFileStoreAlias(storeAlias)
+
+	// Exit if the file store is not enabled
+	status, err := m.checkStore(storeAlias)
+	if err != nil {
+		return status, err
 	}
 
 	// Check if the user is authorised to make this request
-	_, err := m.auth.IsFileOpAuthorised(ctx, project, token, path, model.FileDelete, map[string]interface{}{})
+	_, err = m.auth.IsFileOpAuthorised(ctx, project, token, storeAlias, path, model.FileDelete, map[string]interface{}{})
 	if err != nil {
 		return http.StatusForbidden, err
 	}
@@ -89,30 +118,40 @@ func (m *Module) DeleteFile(ctx context.Context, project, token string, path str
 	m.RLock()
 	defer m.RUnlock()
 
-	intent, err := m.eventing.DeleteFileIntentHook(ctx, path, meta)
+	store, status, err := m.getStore(storeAlias)
+	if err != nil {
+		return status, err
+	}
+
+	intent, err := m.eventing.DeleteFileIntentHook(ctx, storeAlias, path, meta)
 	if err != nil {
 		return http.StatusInternalServerError, err
 	}
 
-	if err = m.store.DeleteFile(ctx, path); err != nil {
+	start := time.Now()
+	err = store.DeleteFile(ctx, path)
+	m.metricsHook(project, string(store.GetStoreType()), model.Delete, time.Since(start), err)
+	if err != nil {
 		m.eventing.HookStage(ctx, intent, err)
 		return http.StatusInternalServerError, err
 	}
 
 	m.eventing.HookStage(ctx, intent, nil)
-	m.metricsHook(project, string(m.store.GetStoreType()), model.Delete)
 	return http.StatusOK, err
 }
 
 // ListFiles lists all the files in the provided path
 func (m *Module) ListFiles(ctx context.Context, project, token string, req *model.ListFilesRequest) (int, []*model.ListFilesResponse, error) {
-	// Exit if file storage is not enabled
-	if !m.IsEnabled() {
-		return http.StatusNotFound, nil, errors.New("This feature isn't enabled")
+	req.Store = utils.GetFileStoreAlias(req.Store)
+
+	// Exit if the file store is not enabled
+	status, err := m.checkStore(req.Store)
+	if err != nil {
+		return status, nil, err
 	}
 
 	// Check if the user is authorised to make this request
-	_, err := m.auth.IsFileOpAuthorised(ctx, project, token, req.Path, model.FileRead, map[string]interface{}{})
+	_, err = m.auth.IsFileOpAuthorised(ctx, project, token, req.Store, req.Path, model.FileRead, map[string]interface{}{})
 	if err != nil {
 		return http.StatusForbidden, nil, err
 	}
@@ -120,24 +159,37 @@ func (m *Module) ListFiles(ctx context.Context, project, token string, req *mode
 	m.RLock()
 	defer m.RUnlock()
 
-	res, err := m.store.ListDir(ctx, req)
+	store, status, err := m.getStore(req.Store)
+	if err != nil {
+		return status, nil, err
+	}
+
+	start := time.Now()
+	res, err := store.ListDir(ctx, req)
+	m.metricsHook(project, string(store.GetStoreType()), model.List, time.Since(start), err)
 	if err != nil {
 		return http.StatusInternalServerError, nil, err
 	}
 
-	m.metricsHook(project, string(m.store.GetStoreType()), model.List)
 	return http.StatusOK, res, nil
 }
 
 // UploadFile uploads a file to the provided path
 func (m *Module) UploadFile(ctx context.Context, project, token string, req *model.CreateFileRequest, reader io.Reader) (int, error) {
-	// Exit if file storage is not enabled
-	if !m.IsEnabled() {
-		return http.StatusNotFound, errors.New("This feature isn't enabled")
+	req.Store = utils.GetFileStoreAlias(req.Store)
+
+	if isUploadsDir(req.Path, req.Name) {
+		return http.StatusBadRequest, errUploadsDir
+	}
+
+	// Exit if the file store is not enabled
+	status, err := m.checkStore(req.Store)
+	if err != nil {
+		return status, err
 	}
 
 	// Check if the user is authorised to make this request
-	_, err := m.auth.IsFileOpAuthorised(ctx, project, token, req.Path, model.FileCreate, map[string]interface{}{"meta": req.Meta})
+	_, err = m.auth.IsFileOpAuthorised(ctx, project, token, req.Store, req.Path, model.FileCreate, map[string]interface{}{"meta": req.Meta})
 	if err != nil {
 		return http.StatusForbidden, err
 	}
@@ -145,30 +197,68 @@ func (m *Module) UploadFile(ctx context.Context, project, token string, req *mod
 	m.RLock()
 	defer m.RUnlock()
 
+	store, status, err := m.getStore(req.Store)
+	if err != nil {
+		return status, err
+	}
+
 	intent, err := m.eventing.CreateFileIntentHook(ctx, req)
 	if err != nil {
 		return 500, err
 	}
 
-	if err = m.store.CreateFile(ctx, req, reader); err != nil {
+	start := time.Now()
+	err = store.CreateFile(ctx, req, reader)
+	m.metricsHook(project, string(store.GetStoreType()), model.Create, time.Since(start), err)
+	if err != nil {
 		m.eventing.HookStage(ctx, intent, err)
 		return http.StatusInternalServerError, helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Unable to create file (%s)", req.Name), err, nil)
 	}
 
 	m.eventing.HookStage(ctx, intent, nil)
-	m.metricsHook(project, string(m.store.GetStoreType()), model.Create)
 	return http.StatusOK, nil
 }
 
-// DownloadFile downloads a file from the provided path
-func (m *Module) DownloadFile(ctx context.Context, project, token, path string) (int, *model.File, error) {
-	// Exit if file storage is not enabled
-	if !m.IsEnabled() {
-		return http.StatusNotFound, nil, errors.New("This feature isn't enabled")
+// DownloadFile downloads a file from the provided path. The byte range is the value of the http Range header. Only the
+// requested range of the file is read if it is a valid single range
+func (m *Module) DownloadFile(ctx context.Context, project, token, storeAlias, path, byteRange string) (int, *model.File, error) {
+	storeAlias = utils.GetFileStoreAlias(storeAlias)
+
+	// Exit if the file store is not enabled
+	status, err := m.checkStore(storeAlias)
+	if err != nil {
+		return status, nil, err
+	}
+
+	// Check if the user is authorised to make this request
+	_, err = m.auth.IsFileOpAuthorised(ctx, project, token, storeAlias, path, model.FileRead, map[string]interface{}{})
+	if err != nil {
+		return http.StatusForbidden, nil, err
+	}
+
+	m.RLock()
+	defer m.RUnlock()
+
+	store, status, err := m.getStore(storeAlias)
+	if err != nil {
+		return status, nil, err
+	}
+
+	return m.readFile(ctx, project, store, path, byteRange)
+}
+
+// GetFileInfo returns the stat information of the file at the provided path
+func (m *Module) GetFileInfo(ctx context.Context, project, token, storeAlias, path string) (int, *model.FileInfo, error) {
+	storeAlias = utils.GetFileStoreAlias(storeAlias)
+
+	// Exit if the file store is not enabled
+	status, err := m.checkStore(storeAlias)
+	if err != nil {
+		return status, nil, err
 	}
 
 	// Check if the user is authorised to make this request
-	_, err := m.auth.IsFileOpAuthorised(ctx, project, token, path, model.FileRead, map[string]interface{}{})
+	_, err = m.auth.IsFileOpAuthorised(ctx, project, token, storeAlias, path, model.FileRead, map[string]interface{}{})
 	if err != nil {
 		return http.StatusForbidden, nil, err
 	}
@@ -176,25 +266,30 @@ func (m *Module) DownloadFile(ctx context.Context, project, token, path string)
 	m.RLock()
 	defer m.RUnlock()
 
-	// Read the file from file storage
-	file, err := m.store.ReadFile(ctx, path)
+	store, status, err := m.getStore(storeAlias)
 	if err != nil {
-		return http.StatusInternalServerError, nil, err
+		return status, nil, err
 	}
 
-	m.metricsHook(project, string(m.store.GetStoreType()), model.Read)
-	return http.StatusOK, file, nil
+	info, err := store.StatFile(ctx, path)
+	if err != nil {
+		return http.StatusNotFound, nil, err
+	}
+	return http.StatusOK, info, nil
 }
 
 // DoesExists checks if the provided path exists
-func (m *Module) DoesExists(ctx context.Context, project, token, path string) error {
-	// Exit if file storage is not enabled
-	if !m.IsEnabled() {
-		return errors.New("This feature isn't enabled")
+func (m *Module) DoesExists(ctx context.Context, project, token, storeAlias, path string) error {
+	storeAlias = utils.GetFileStoreAlias(storeAlias)
+
+	// Exit if the file store is not enabled
+	_, err := m.checkStore(storeAlias)
+	if err != nil {
+		return err
 	}
 
 	// Check if the user is authorised to make this request
-	_, err := m.auth.IsFileOpAuthorised(ctx, project, token, path, model.FileRead, map[string]interface{}{})
+	_, err = m.auth.IsFileOpAuthorised(ctx, project, token, storeAlias, path, model.FileRead, map[string]interface{}{})
 	if err != nil {
 		return err
 	}
@@ -202,18 +297,26 @@ func (m *Module) DoesExists(ctx context.Context, project, token, path string) er
 	m.RLock()
 	defer m.RUnlock()
 
+	store, _, err := m.getStore(storeAlias)
+	if err != nil {
+		return err
+	}
+
 	// Read the file from file storage
-	return m.store.DoesExists(ctx, path)
+	return store.DoesExists(ctx, path)
 }
 
 // GetState checks if selected storage is active
-func (m *Module) GetState(ctx context.Context) error {
-	// Exit if file storage is not enabled
-	if !m.IsEnabled() {
-		return errors.New("This feature isn't enabled")
-	}
+func (m *Module) GetState(ctx context.Context, storeAlias string) error {
 	m.RLock()
 	defer m.RUnlock()
+
+	// Exit if the file store is not enabled
+	store, _, err := m.getStore(storeAlias)
+	if err != nil {
+		return err
+	}
+
 	// Read the state from file storage
-	return m.store.GetState(ctx)
+	return store.GetState(ctx)
 }
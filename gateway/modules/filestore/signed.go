@@ -0,0 +1,269 @@
+package filestore
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spaceuptech/helpers"
+
+	"github.com/spaceuptech/space-cloud/gateway/model"
+	"github.com/spaceuptech/space-cloud/gateway/utils"
+)
+
+const (
+	// defaultSignedURLExpiry is the validity of a signed url in seconds when the expiry isn't provided
+	defaultSignedURLExpiry int64 = 15 * 60
+
+	// maxSignedURLExpiry is the max validity of a signed url in seconds. This is the limit imposed by S3 and GCS
+	maxSignedURLExpiry int64 = 7 * 24 * 60 * 60
+
+	// The labels separate the keys used to sign urls and uploads from each other and from the other uses of the aes key
+	signedURLKeyLabel = "space-cloud-signed-url"
+	uploadKeyLabel    = "space-cloud-upload"
+)
+
+// urlSigner is implemented by the file stores which can natively create presigned urls. The urls of the other
+// stores are signed by the gateway
+type urlSigner interface {
+	GetSignedURL(ctx context.Context, req *model.SignedURLRequest) (string, string, error)
+}
+
+// CreateSignedURL creates a short lived url which can be used to read or create a file without the token of the caller
+func (m *Module) CreateSignedURL(ctx context.Context, project, token string, req *model.SignedURLRequest) (int, *model.SignedURLResponse, error) {
+	req.Store = utils.GetFileStoreAlias(req.Store)
+
+	if req.Op != model.FileRead && req.Op != model.FileCreate {
+		return http.StatusBadRequest, nil, fmt.Errorf("invalid op (%s) provided for signed url, only read and create are supported", req.Op)
+	}
+	if strings.Contains(req.Path, "..") {
+		return http.StatusBadRequest, nil, errors.New("valid path of the file is required to create a signed url")
+	}
+	if req.Op == model.FileCreate && (req.Name == "" || strings.Contains(req.Name, "..")) {
+		return http.StatusBadRequest, nil, errors.New("valid name of the file is required to create a signed url for upload")
+	}
+	if req.Op == model.FileCreate && isUploadsDir(req.Path, req.Name) {
+		return http.StatusBadRequest, nil, errUploadsDir
+	}
+	if req.Expiry == 0 {
+		req.Expiry = defaultSignedURLExpiry
+	}
+	if req.Expiry < 0 || req.Expiry > maxSignedURLExpiry {
+		return http.StatusBadRequest, nil, fmt.Errorf("expiry of signed url should be between 1 and %d seconds", maxSignedURLExpiry)
+	}
+
+	// Exit if the file store is not enabled
+	status, err := m.checkStore(req.Store)
+	if err != nil {
+		return status, nil, err
+	}
+
+	// Check if the user is authorised to make this request
+	args := map[string]interface{}{}
+	if req.Op == model.FileCreate {
+		args["meta"] = req.Meta
+	}
+	if _, err := m.auth.IsFileOpAuthorised(ctx, project, token, req.Store, req.Path, req.Op, args); err != nil {
+		return http.StatusForbidden, nil, err
+	}
+
+	m.RLock()
+	defer m.RUnlock()
+
+	store, status, err := m.getStore(req.Store)
+	if err != nil {
+		return status, nil, err
+	}
+
+	expiry := time.Duration(req.Expiry) * time.Second
+	res := &model.SignedURLResponse{ExpiresAt: time.Now().Add(expiry).Format(time.RFC3339)}
+	signer, isNative := store.(urlSigner)
+	if isNative {
+		res.URL, res.Method, err = signer.GetSignedURL(ctx, req)
+	} else {
+		res.URL, res.Method, err = m.createGatewaySignedURL(project, req)
+	}
+	if err != nil {
+		return http.StatusInternalServerError, nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to create signed url", err, nil)
+	}
+
+	// The gateway isn't notified when an upload made directly to the file store completes. Hence we log an intent
+	// which the eventing module polls till the url expires. Uploads through the gateway log their own event
+	if isNative && req.Op == model.FileCreate {
+		createReq := &model.CreateFileRequest{Store: req.Store, Meta: req.Meta, Path: req.Path, Name: req.Name, Type: "file"}
+		if _, err := m.eventing.CreateSignedFileIntentHook(ctx, createReq, expiry); err != nil {
+			return http.StatusInternalServerError, nil, err
+		}
+	}
+
+	return http.StatusOK, res, nil
+}
+
+// DownloadSignedFile downloads a file using a url signed by the gateway. The byte range is the value of the http Range header
+func (m *Module) DownloadSignedFile(ctx context.Context, project string, req *model.SignedFileRequest, byteRange string) (int, *model.File, error) {
+	req.Store = utils.GetFileStoreAlias(req.Store)
+
+	if req.Op != model.FileRead {
+		return http.StatusForbidden, nil, errors.New("signed url is not valid for reading files")
+	}
+	if err := m.verifySignature(project, req); err != nil {
+		return http.StatusForbidden, nil, err
+	}
+
+	m.RLock()
+	defer m.RUnlock()
+
+	store, status, err := m.getStore(req.Store)
+	if err != nil {
+		return status, nil, err
+	}
+
+	return m.readFile(ctx, project, store, req.Path, byteRange)
+}
+
+// UploadSignedFile uploads a file using a url signed by the gateway
+func (m *Module) UploadSignedFile(ctx context.Context, project string, req *model.SignedFileRequest, reader io.Reader) (int, error) {
+	req.Store = utils.GetFileStoreAlias(req.Store)
+
+	if req.Op != model.FileCreate {
+		return http.StatusForbidden, errors.New("signed url is not valid for creating files")
+	}
+	if err := m.verifySignature(project, req); err != nil {
+		return http.StatusForbidden, err
+	}
+	if isUploadsDir(req.Path, req.Name) {
+		return http.StatusBadRequest, errUploadsDir
+	}
+
+	m.RLock()
+	defer m.RUnlock()
+
+	store, status, err := m.getStore(req.Store)
+	if err != nil {
+		return status, err
+	}
+
+	// The meta was signed in its encoded form so that it gets verified byte for byte
+	createReq := &model.CreateFileRequest{Store: req.Store, Path: req.Path, Name: req.Name, Type: "file", MakeAll: true}
+	if req.Meta != "" {
+		if err := json.Unmarshal([]byte(req.Meta), &createReq.Meta); err != nil {
+			return http.StatusBadRequest, errors.New("invalid meta provided in signed url")
+		}
+	}
+
+	intent, err := m.eventing.CreateFileIntentHook(ctx, createReq)
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	start := time.Now()
+	err = store.CreateFile(ctx, createReq, reader)
+	m.metricsHook(project, string(store.GetStoreType()), model.Create, time.Since(start), err)
+	if err != nil {
+		m.eventing.HookStage(ctx, intent, err)
+		return http.StatusInternalServerError, helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Unable to create file (%s)", req.Name), err, nil)
+	}
+
+	m.eventing.HookStage(ctx, intent, nil)
+	return http.StatusOK, nil
+}
+
+// createGatewaySignedURL creates a url served by the gateway which carries an hmac signature of the request
+func (m *Module) createGatewaySignedURL(project string, req *model.SignedURLRequest) (string, string, error) {
+	signedReq := &model.SignedFileRequest{Store: req.Store, Op: req.Op, Path: req.Path, Name: req.Name, Expires: time.Now().Add(time.Duration(req.Expiry) * time.Second).Unix()}
+	if req.Op == model.FileCreate && len(req.Meta) > 0 {
+		meta, err := json.Marshal(req.Meta)
+		if err != nil {
+			return "", "", err
+		}
+		signedReq.Meta = string(meta)
+	}
+	signature, err := m.sign(project, signedReq)
+	if err != nil {
+		return "", "", err
+	}
+
+	query := url.Values{}
+	query.Set("store", signedReq.Store)
+	query.Set("op", string(signedReq.Op))
+	query.Set("path", signedReq.Path)
+	if signedReq.Name != "" {
+		query.Set("name", signedReq.Name)
+	}
+	if signedReq.Meta != "" {
+		query.Set("meta", signedReq.Meta)
+	}
+	query.Set("expires", strconv.FormatInt(signedReq.Expires, 10))
+	query.Set("signature", signature)
+
+	method := http.MethodGet
+	if req.Op == model.FileCreate {
+		method = http.MethodPut
+	}
+	return fmt.Sprintf("/v1/api/%s/signed-files?%s", project, query.Encode()), method, nil
+}
+
+// verifySignature checks if the signed request is valid and hasn't expired
+func (m *Module) verifySignature(project string, req *model.SignedFileRequest) error {
+	if time.Now().Unix() > req.Expires {
+		return errors.New("signed url has expired")
+	}
+
+	ok, err := m.verifyMessage(signedURLKeyLabel, getSignedURLMessage(project, req), req.Signature)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errors.New("invalid signature provided in signed url")
+	}
+	return nil
+}
+
+// sign generates the hmac signature of the signed request
+func (m *Module) sign(project string, req *model.SignedFileRequest) (string, error) {
+	return m.signMessage(signedURLKeyLabel, getSignedURLMessage(project, req))
+}
+
+func getSignedURLMessage(project string, req *model.SignedFileRequest) string {
+	return strings.Join([]string{project, req.Store, string(req.Op), req.Path, req.Name, req.Meta, strconv.FormatInt(req.Expires, 10)}, "\n")
+}
+
+// signMessage generates the hmac signature of the message using the key derived from the aes key of the project for
+// the purpose described by the label
+func (m *Module) signMessage(label, message string) (string, error) {
+	aesKey := m.auth.GetAESKey()
+	if len(aesKey) == 0 {
+		return "", errors.New("aes key of the project is required to sign file requests")
+	}
+
+	mac := hmac.New(sha256.New, getSigningKey(aesKey, label))
+	_, _ = mac.Write([]byte(message))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil)), nil
+}
+
+// verifyMessage checks if the signature was generated by signMessage for the same label and message
+func (m *Module) verifyMessage(label, message, signature string) (bool, error) {
+	expected, err := m.signMessage(label, message)
+	if err != nil {
+		return false, err
+	}
+	return hmac.Equal([]byte(expected), []byte(signature)), nil
+}
+
+// getSigningKey derives the key used for the purpose described by the label from the aes key, so that the aes key
+// itself is only used for encryption
+func getSigningKey(aesKey []byte, label string) []byte {
+	mac := hmac.New(sha256.New, aesKey)
+	_, _ = mac.Write([]byte(label))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,293 @@
+package filestore
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/spaceuptech/space-cloud/gateway/config"
+	"github.com/spaceuptech/space-cloud/gateway/model"
+	"github.com/spaceuptech/space-cloud/gateway/utils"
+)
+
+func newSignedTestModule(t *testing.T, auth *mockAuthFilestoreInterface, eventing *mockEventingModule) *Module {
+	m := Init(auth, func(project, storeType string, op model.OperationType, latency time.Duration, err error) {})
+	m.SetEventingModule(eventing)
+	if err := m.SetConfig("myproject", &config.FileStoreConfig{Enabled: true, StoreType: string(utils.Local), Conn: t.TempDir()}); err != nil {
+		t.Fatalf("SetConfig() error = %v", err)
+	}
+	return m
+}
+
+// mockURLSigner is a file store which natively creates presigned urls
+type mockURLSigner struct {
+	FileStore
+}
+
+func (m *mockURLSigner) GetSignedURL(ctx context.Context, req *model.SignedURLRequest) (string, string, error) {
+	return "https://bucket.storage/signed", http.MethodPut, nil
+}
+
+// parseSignedURL converts a url signed by the gateway to the request received by the gateway
+func parseSignedURL(t *testing.T, signedURL string) *model.SignedFileRequest {
+	u, err := url.Parse(signedURL)
+	if err != nil {
+		t.Fatalf("Unable to parse signed url - %v", err)
+	}
+	query := u.Query()
+	expires, _ := strconv.ParseInt(query.Get("expires"), 10, 64)
+	return &model.SignedFileRequest{
+		Store:     query.Get("store"),
+		Op:        model.FileOpType(query.Get("op")),
+		Path:      query.Get("path"),
+		Name:      query.Get("name"),
+		Meta:      query.Get("meta"),
+		Expires:   expires,
+		Signature: query.Get("signature"),
+	}
+}
+
+func TestModule_CreateSignedURL(t *testing.T) {
+	key := []byte("some-aes-key-of-32-characters!!!")
+
+	tests := []struct {
+		name       string
+		req        *model.SignedURLRequest
+		key        []byte
+		native     bool
+		authErr    error
+		wantIntent time.Duration
+		wantMethod string
+		wantStatus int
+		wantErr    bool
+	}{
+		{
+			name:       "signed url to read a file",
+			req:        &model.SignedURLRequest{Op: model.FileRead, Path: "/folder/file.txt"},
+			key:        key,
+			wantMethod: http.MethodGet,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "signed url to create a file through the gateway doesn't log an intent",
+			req:        &model.SignedURLRequest{Op: model.FileCreate, Path: "/folder", Name: "file.txt", Expiry: 60},
+			key:        key,
+			wantMethod: http.MethodPut,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "native signed url to create a file logs an intent till the url expires",
+			req:        &model.SignedURLRequest{Op: model.FileCreate, Path: "/folder", Name: "file.txt", Expiry: 60},
+			native:     true,
+			wantIntent: time.Minute,
+			wantMethod: http.MethodPut,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "path of the file is outside the store",
+			req:        &model.SignedURLRequest{Op: model.FileCreate, Path: "/folder/../..", Name: "file.txt"},
+			key:        key,
+			wantStatus: http.StatusBadRequest,
+			wantErr:    true,
+		},
+		{
+			name:       "path of the file is reserved for uploads",
+			req:        &model.SignedURLRequest{Op: model.FileCreate, Path: "/uploads/folder", Name: "file.txt"},
+			key:        key,
+			wantStatus: http.StatusBadRequest,
+			wantErr:    true,
+		},
+		{
+			name:       "invalid op provided",
+			req:        &model.SignedURLRequest{Op: model.FileDelete, Path: "/folder/file.txt"},
+			key:        key,
+			wantStatus: http.StatusBadRequest,
+			wantErr:    true,
+		},
+		{
+			name:       "name not provided to create a file",
+			req:        &model.SignedURLRequest{Op: model.FileCreate, Path: "/folder"},
+			key:        key,
+			wantStatus: http.StatusBadRequest,
+			wantErr:    true,
+		},
+		{
+			name:       "expiry greater than 7 days",
+			req:        &model.SignedURLRequest{Op: model.FileRead, Path: "/folder/file.txt", Expiry: maxSignedURLExpiry + 1},
+			key:        key,
+			wantStatus: http.StatusBadRequest,
+			wantErr:    true,
+		},
+		{
+			name:       "unknown file store provided",
+			req:        &model.SignedURLRequest{Store: "media", Op: model.FileRead, Path: "/folder/file.txt"},
+			key:        key,
+			wantStatus: http.StatusNotFound,
+			wantErr:    true,
+		},
+		{
+			name:       "caller is not authorised",
+			req:        &model.SignedURLRequest{Op: model.FileRead, Path: "/folder/file.txt"},
+			key:        key,
+			authErr:    errors.New("access denied"),
+			wantStatus: http.StatusForbidden,
+			wantErr:    true,
+		},
+		{
+			name:       "aes key of the project is not set",
+			req:        &model.SignedURLRequest{Op: model.FileRead, Path: "/folder/file.txt"},
+			key:        []byte{},
+			wantStatus: http.StatusInternalServerError,
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			auth := new(mockAuthFilestoreInterface)
+			auth.On("IsFileOpAuthorised", mock.Anything, "myproject", "token", utils.DefaultFileStore, tt.req.Path, tt.req.Op, mock.Anything).Return(tt.authErr)
+			auth.On("GetAESKey").Return(tt.key)
+			eventing := new(mockEventingModule)
+			if tt.wantIntent != 0 {
+				eventing.On("CreateSignedFileIntentHook", mock.Anything, mock.MatchedBy(func(req *model.CreateFileRequest) bool {
+					return req.Path == tt.req.Path && req.Name == tt.req.Name && req.Type == "file"
+				}), tt.wantIntent).Return(&model.EventIntent{}, nil)
+			}
+
+			m := newSignedTestModule(t, auth, eventing)
+			if tt.native {
+				s := m.stores[utils.DefaultFileStore]
+				s.store = &mockURLSigner{FileStore: s.store}
+			}
+			status, res, err := m.CreateSignedURL(context.Background(), "myproject", "token", tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CreateSignedURL() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if status != tt.wantStatus {
+				t.Errorf("CreateSignedURL() status = %v, want %v", status, tt.wantStatus)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if res.Method != tt.wantMethod {
+				t.Errorf("CreateSignedURL() method = %v, want %v", res.Method, tt.wantMethod)
+			}
+			if tt.native {
+				if res.URL != "https://bucket.storage/signed" {
+					t.Errorf("CreateSignedURL() url = %v, want the url signed by the file store", res.URL)
+				}
+			} else if !strings.HasPrefix(res.URL, "/v1/api/myproject/signed-files?") {
+				t.Errorf("CreateSignedURL() url = %v, want a url signed by the gateway", res.URL)
+			}
+			eventing.AssertExpectations(t)
+		})
+	}
+}
+
+func TestModule_SignedFile(t *testing.T) {
+	auth := new(mockAuthFilestoreInterface)
+	auth.On("IsFileOpAuthorised", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	auth.On("GetAESKey").Return([]byte("some-aes-key-of-32-characters!!!"))
+	intent := &model.EventIntent{BatchID: "batch"}
+	eventing := new(mockEventingModule)
+	eventing.On("CreateFileIntentHook", mock.Anything, mock.MatchedBy(func(req *model.CreateFileRequest) bool {
+		return req.Path == "/folder" && req.Name == "file.txt" && reflect.DeepEqual(req.Meta, map[string]interface{}{"owner": "user1"})
+	})).Return(intent, nil).Once()
+	eventing.On("HookStage", mock.Anything, intent, nil).Return().Once()
+	m := newSignedTestModule(t, auth, eventing)
+	ctx := context.Background()
+
+	// Upload a file using a signed url. The event is staged once the upload completes
+	_, res, err := m.CreateSignedURL(ctx, "myproject", "token", &model.SignedURLRequest{Op: model.FileCreate, Path: "/folder", Name: "file.txt", Meta: map[string]interface{}{"owner": "user1"}})
+	if err != nil {
+		t.Fatalf("CreateSignedURL() error = %v", err)
+	}
+	uploadReq := parseSignedURL(t, res.URL)
+	if status, err := m.UploadSignedFile(ctx, "myproject", uploadReq, bytes.NewBufferString("some data")); err != nil {
+		t.Fatalf("UploadSignedFile() status = %v, error = %v", status, err)
+	}
+	eventing.AssertExpectations(t)
+
+	// Download the uploaded file using a signed url
+	_, res, err = m.CreateSignedURL(ctx, "myproject", "token", &model.SignedURLRequest{Op: model.FileRead, Path: "/folder/file.txt"})
+	if err != nil {
+		t.Fatalf("CreateSignedURL() error = %v", err)
+	}
+	downloadReq := parseSignedURL(t, res.URL)
+	_, file, err := m.DownloadSignedFile(ctx, "myproject", downloadReq, "")
+	if err != nil {
+		t.Fatalf("DownloadSignedFile() error = %v", err)
+	}
+	data, _ := ioutil.ReadAll(file.File)
+	_ = file.Close()
+	if string(data) != "some data" {
+		t.Errorf("DownloadSignedFile() got = %s, want %s", data, "some data")
+	}
+
+	tests := []struct {
+		name   string
+		modify func(req model.SignedFileRequest) *model.SignedFileRequest
+	}{
+		{
+			name: "signature of another path",
+			modify: func(req model.SignedFileRequest) *model.SignedFileRequest {
+				req.Path = "/folder/another.txt"
+				return &req
+			},
+		},
+		{
+			name: "invalid signature",
+			modify: func(req model.SignedFileRequest) *model.SignedFileRequest {
+				req.Signature = "invalid"
+				return &req
+			},
+		},
+		{
+			name: "expiry is extended",
+			modify: func(req model.SignedFileRequest) *model.SignedFileRequest {
+				req.Expires += 60
+				return &req
+			},
+		},
+		{
+			name: "url has expired",
+			modify: func(req model.SignedFileRequest) *model.SignedFileRequest {
+				req.Expires = time.Now().Add(-time.Minute).Unix()
+				return &req
+			},
+		},
+		{
+			name: "url to read a file is used to create it",
+			modify: func(req model.SignedFileRequest) *model.SignedFileRequest {
+				req.Op = model.FileCreate
+				return &req
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if status, _, err := m.DownloadSignedFile(ctx, "myproject", tt.modify(*downloadReq), ""); err == nil || status != http.StatusForbidden {
+				t.Errorf("DownloadSignedFile() status = %v, error = %v, want a forbidden error", status, err)
+			}
+		})
+	}
+
+	if status, err := m.UploadSignedFile(ctx, "another-project", uploadReq, bytes.NewBufferString("some data")); err == nil || status != http.StatusForbidden {
+		t.Errorf("UploadSignedFile() status = %v, error = %v, want a forbidden error for another project", status, err)
+	}
+	tamperedReq := *uploadReq
+	tamperedReq.Meta = `{"owner":"user2"}`
+	if status, err := m.UploadSignedFile(ctx, "myproject", &tamperedReq, bytes.NewBufferString("some data")); err == nil || status != http.StatusForbidden {
+		t.Errorf("UploadSignedFile() status = %v, error = %v, want a forbidden error for modified meta", status, err)
+	}
+}
@@ -2,12 +2,16 @@ package filestore
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
+	"net/http"
 	"os"
+	"reflect"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/spaceuptech/helpers"
 
@@ -16,6 +20,7 @@ import (
 	"github.com/spaceuptech/space-cloud/gateway/utils"
 
 	"github.com/spaceuptech/space-cloud/gateway/modules/filestore/amazons3"
+	"github.com/spaceuptech/space-cloud/gateway/modules/filestore/azureblob"
 	"github.com/spaceuptech/space-cloud/gateway/modules/filestore/gcpstorage"
 	"github.com/spaceuptech/space-cloud/gateway/modules/filestore/local"
 )
@@ -23,19 +28,35 @@ import (
 // Module is responsible for managing the file storage module
 type Module struct {
 	sync.RWMutex
-	store       FileStore
-	enabled     bool
+	stores      map[string]*fileStore // key is the alias of the store
 	auth        model.AuthFilestoreInterface
 	eventing    model.EventingModule
 	metricsHook model.MetricFileHook
 
+	// ticker to cleanup the abandoned resumable uploads
+	tickerCleanup *time.Ticker
+
 	// function to get secrets from runner
 	getSecrets utils.GetSecrets
 }
 
+// fileStore holds a file store along with the config it was created from
+type fileStore struct {
+	config *config.FileStoreConfig
+	store  FileStore
+}
+
 // Init creates a new instance of the file store object
 func Init(auth model.AuthFilestoreInterface, hook model.MetricFileHook) *Module {
-	return &Module{enabled: false, store: nil, auth: auth, metricsHook: hook}
+	m := &Module{stores: map[string]*fileStore{}, auth: auth, metricsHook: hook, tickerCleanup: time.NewTicker(time.Hour)}
+	go m.routineCleanupUploads()
+	return m
+}
+
+func (m *Module) routineCleanupUploads() {
+	for t := range m.tickerCleanup.C {
+		m.cleanupUploads(&t)
+	}
 }
 
 // SetEventingModule sets the eventing module
@@ -49,7 +70,8 @@ type FileStore interface {
 	CreateDir(ctx context.Context, req *model.CreateFileRequest) error
 
 	ListDir(ctx context.Context, req *model.ListFilesRequest) ([]*model.ListFilesResponse, error)
-	ReadFile(ctx context.Context, path string) (*model.File, error)
+	ReadFile(ctx context.Context, path string, byteRange *model.FileRange) (*model.File, error)
+	StatFile(ctx context.Context, path string) (*model.FileInfo, error)
 
 	DeleteDir(ctx context.Context, path string) error
 	DeleteFile(ctx context.Context, path string) error
@@ -61,60 +83,103 @@ type FileStore interface {
 	Close() error
 }
 
-// SetConfig set the rules and secret key required by the filestore block
+// SetConfig set the rules and secret key required by the default file store
 func (m *Module) SetConfig(project string, conf *config.FileStoreConfig) error {
 	m.Lock()
 	defer m.Unlock()
 
+	return m.setStore(project, utils.DefaultFileStore, conf)
+}
+
+// SetFileStores sets the named file stores of the project. Stores whose config hasn't changed are left untouched
+func (m *Module) SetFileStores(project string, stores config.FileStores) error {
+	m.Lock()
+	defer m.Unlock()
+
+	configs := map[string]*config.FileStoreConfig{}
+	for _, conf := range stores {
+		if conf.ID == utils.DefaultFileStore {
+			return helpers.Logger.LogError(helpers.GetRequestID(context.TODO()), fmt.Sprintf("Alias (%s) is reserved for the default file store", conf.ID), nil, nil)
+		}
+		configs[conf.ID] = conf
+	}
+
+	// Remove the stores which no longer exist
+	for alias := range m.stores {
+		if _, ok := configs[alias]; !ok && alias != utils.DefaultFileStore {
+			if err := m.setStore(project, alias, nil); err != nil {
+				return err
+			}
+		}
+	}
+
+	for alias, conf := range configs {
+		if err := m.setStore(project, alias, conf); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setStore creates the file store with the provided alias. The store is removed if it isn't enabled
+func (m *Module) setStore(project, alias string, conf *config.FileStoreConfig) error {
+	existing, ok := m.stores[alias]
+	if ok && conf != nil && reflect.DeepEqual(existing.config, conf) {
+		return nil
+	}
+
 	// Close the previous store connections
-	if m.store != nil {
-		err := m.store.Close()
-		if err != nil {
+	if ok {
+		if err := existing.store.Close(); err != nil {
 			return err
 		}
+		delete(m.stores, alias)
 	}
 
-	// Disable the module if file store is not enabled
+	// Disable the store if it is not enabled
 	if conf == nil || !conf.Enabled {
-		m.enabled = false
-
-		// Clear the store object
-		m.store = nil
 		return nil
 	}
 
 	// create aws and gcp file secret
+	conn := conf.Conn
 	secretName, secretKey, isSecretExists := splitConnectionString(conf.Secret)
 	if isSecretExists {
 		value, err := m.getSecrets(project, secretName, secretKey)
 		if err != nil {
 			return helpers.Logger.LogError(helpers.GetRequestID(context.TODO()), "Unable to fetch secret from runner", err, nil)
 		}
-		if err := setFileSecret(utils.FileStoreType(conf.StoreType), secretKey, value); err != nil {
+
+		// The secret of azure blob storage is the connection string itself
+		if utils.FileStoreType(conf.StoreType) == utils.AzureBlob {
+			conn = value
+		} else if err := setFileSecret(utils.FileStoreType(conf.StoreType), secretKey, value); err != nil {
 			return helpers.Logger.LogError(helpers.GetRequestID(context.TODO()), "Unable to create credential file in gateway", err, nil)
 		}
 	}
 
 	// Create a new crud blocks
-	s, err := initBlock(conf)
+	s, err := initBlock(conf, conn)
 	if err != nil {
 		return err
 	}
-	m.store = s
-	m.enabled = true
+	m.stores[alias] = &fileStore{config: conf, store: s}
 	return nil
 }
 
-// CloseConfig closes file store
+// CloseConfig closes all the file stores
 func (m *Module) CloseConfig() error {
 	m.Lock()
 	defer m.Unlock()
 
-	if m.store == nil {
-		return nil
+	for alias, s := range m.stores {
+		if err := s.store.Close(); err != nil {
+			return err
+		}
+		delete(m.stores, alias)
 	}
-
-	return m.store.Close()
+	m.tickerCleanup.Stop()
+	return nil
 }
 
 func setFileSecret(fileStoreType utils.FileStoreType, key, value string) error {
@@ -147,21 +212,45 @@ func splitConnectionString(connection string) (string, string, bool) {
 	return "", "", false
 }
 
-// IsEnabled checks if the file store module is enabled
+// IsEnabled checks if the default file store is enabled
 func (m *Module) IsEnabled() bool {
 	m.RLock()
 	defer m.RUnlock()
-	return m.enabled
+	_, ok := m.stores[utils.DefaultFileStore]
+	return ok
+}
+
+// checkStore checks if the file store with the provided alias is enabled
+func (m *Module) checkStore(alias string) (int, error) {
+	m.RLock()
+	defer m.RUnlock()
+	_, status, err := m.getStore(alias)
+	return status, err
+}
+
+// getStore returns the file store with the provided alias. It must be called with a lock held
+func (m *Module) getStore(alias string) (FileStore, int, error) {
+	alias = utils.GetFileStoreAlias(alias)
+	s, ok := m.stores[alias]
+	if !ok {
+		if alias == utils.DefaultFileStore {
+			return nil, http.StatusNotFound, errors.New("This feature isn't enabled")
+		}
+		return nil, http.StatusNotFound, fmt.Errorf("file store (%s) isn't enabled", alias)
+	}
+	return s.store, http.StatusOK, nil
 }
 
-func initBlock(conf *config.FileStoreConfig) (FileStore, error) {
+func initBlock(conf *config.FileStoreConfig, conn string) (FileStore, error) {
 	switch utils.FileStoreType(conf.StoreType) {
 	case utils.Local:
-		return local.Init(conf.Conn)
+		return local.Init(conn)
 	case utils.AmazonS3:
-		return amazons3.Init(conf.Conn, conf.Endpoint, conf.Bucket, conf.DisableSSL, conf.ForcePathStyle) // connection is the aws region code
+		return amazons3.Init(conn, conf.Endpoint, conf.Bucket, conf.DisableSSL, conf.ForcePathStyle) // connection is the aws region code
 	case utils.GCPStorage:
-		return gcpstorage.Init(conf.Bucket)
+		return gcpstorage.Init(conf.Bucket, conf.UploadsBucket)
+	case utils.AzureBlob:
+		return azureblob.Init(conn, conf.Endpoint, conf.Bucket) // connection is the connection string or SAS url and bucket is the container
 	default:
 		return nil, utils.ErrInvalidParams
 	}
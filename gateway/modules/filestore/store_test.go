@@ -0,0 +1,120 @@
+package filestore
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/spaceuptech/space-cloud/gateway/config"
+	"github.com/spaceuptech/space-cloud/gateway/utils"
+)
+
+func TestModule_SetFileStores(t *testing.T) {
+	dir := t.TempDir()
+	media := &config.FileStoreConfig{ID: "media", Enabled: true, StoreType: string(utils.Local), Conn: dir + "/media"}
+	scratch := &config.FileStoreConfig{ID: "scratch", Enabled: true, StoreType: string(utils.Local), Conn: dir + "/scratch"}
+	disabled := &config.FileStoreConfig{ID: "exports", Enabled: false, StoreType: string(utils.Local), Conn: dir + "/exports"}
+
+	tests := []struct {
+		name       string
+		existing   config.FileStores
+		stores     config.FileStores
+		wantStores []string
+		wantErr    bool
+	}{
+		{
+			name:       "named file stores are added along side the default store",
+			stores:     config.FileStores{"media": media, "scratch": scratch},
+			wantStores: []string{utils.DefaultFileStore, "media", "scratch"},
+		},
+		{
+			name:       "disabled file stores are not added",
+			stores:     config.FileStores{"media": media, "exports": disabled},
+			wantStores: []string{utils.DefaultFileStore, "media"},
+		},
+		{
+			name:       "file stores which no longer exist are removed",
+			existing:   config.FileStores{"media": media, "scratch": scratch},
+			stores:     config.FileStores{"scratch": scratch},
+			wantStores: []string{utils.DefaultFileStore, "scratch"},
+		},
+		{
+			name:    "alias of the default file store cannot be used",
+			stores:  config.FileStores{"default": &config.FileStoreConfig{ID: utils.DefaultFileStore, Enabled: true, StoreType: string(utils.Local), Conn: dir}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := Init(nil, nil)
+			if err := m.SetConfig("myproject", &config.FileStoreConfig{Enabled: true, StoreType: string(utils.Local), Conn: dir + "/default"}); err != nil {
+				t.Fatalf("SetConfig() error = %v", err)
+			}
+			if err := m.SetFileStores("myproject", tt.existing); err != nil {
+				t.Fatalf("SetFileStores() error = %v", err)
+			}
+
+			err := m.SetFileStores("myproject", tt.stores)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SetFileStores() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if len(m.stores) != len(tt.wantStores) {
+				t.Errorf("SetFileStores() got %d stores, want %d", len(m.stores), len(tt.wantStores))
+			}
+			for _, alias := range tt.wantStores {
+				if _, ok := m.stores[alias]; !ok {
+					t.Errorf("SetFileStores() store (%s) not found", alias)
+				}
+			}
+		})
+	}
+}
+
+func TestModule_getStore(t *testing.T) {
+	dir := t.TempDir()
+	m := Init(nil, nil)
+	if err := m.SetFileStores("myproject", config.FileStores{"media": &config.FileStoreConfig{ID: "media", Enabled: true, StoreType: string(utils.Local), Conn: dir}}); err != nil {
+		t.Fatalf("SetFileStores() error = %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		alias      string
+		wantStatus int
+		wantErr    bool
+	}{
+		{
+			name:       "named file store is returned",
+			alias:      "media",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "default file store is not enabled",
+			wantStatus: http.StatusNotFound,
+			wantErr:    true,
+		},
+		{
+			name:       "unknown file store provided",
+			alias:      "exports",
+			wantStatus: http.StatusNotFound,
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, status, err := m.getStore(tt.alias)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("getStore() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if status != tt.wantStatus {
+				t.Errorf("getStore() status = %v, want %v", status, tt.wantStatus)
+			}
+			if !tt.wantErr && store == nil {
+				t.Errorf("getStore() store is nil")
+			}
+		})
+	}
+}
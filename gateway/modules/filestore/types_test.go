@@ -0,0 +1,46 @@
+package filestore
+
+import (
+	"context"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/spaceuptech/space-cloud/gateway/model"
+)
+
+type mockAuthFilestoreInterface struct {
+	mock.Mock
+}
+
+func (m *mockAuthFilestoreInterface) IsFileOpAuthorised(ctx context.Context, project, token, store, path string, op model.FileOpType, args map[string]interface{}) (*model.PostProcess, error) {
+	c := m.Called(ctx, project, token, store, path, op, args)
+	return nil, c.Error(0)
+}
+
+func (m *mockAuthFilestoreInterface) GetAESKey() []byte {
+	return m.Called().Get(0).([]byte)
+}
+
+type mockEventingModule struct {
+	mock.Mock
+}
+
+func (m *mockEventingModule) CreateFileIntentHook(ctx context.Context, req *model.CreateFileRequest) (*model.EventIntent, error) {
+	c := m.Called(ctx, req)
+	return c.Get(0).(*model.EventIntent), c.Error(1)
+}
+
+func (m *mockEventingModule) CreateSignedFileIntentHook(ctx context.Context, req *model.CreateFileRequest, expiry time.Duration) (*model.EventIntent, error) {
+	c := m.Called(ctx, req, expiry)
+	return c.Get(0).(*model.EventIntent), c.Error(1)
+}
+
+func (m *mockEventingModule) DeleteFileIntentHook(ctx context.Context, store, path string, meta map[string]interface{}) (*model.EventIntent, error) {
+	c := m.Called(ctx, store, path, meta)
+	return c.Get(0).(*model.EventIntent), c.Error(1)
+}
+
+func (m *mockEventingModule) HookStage(ctx context.Context, intent *model.EventIntent, err error) {
+	m.Called(ctx, intent, err)
+}
@@ -0,0 +1,313 @@
+package filestore
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spaceuptech/helpers"
+
+	"github.com/spaceuptech/space-cloud/gateway/model"
+	"github.com/spaceuptech/space-cloud/gateway/utils"
+)
+
+const (
+	// uploadTTL is the duration after which an incomplete resumable upload is considered abandoned
+	uploadTTL = 24 * time.Hour
+
+	// maxUploadParts is the max number of parts of a resumable upload. This is the limit imposed by S3
+	maxUploadParts = 10000
+
+	// uploadsDir is reserved for the routes of resumable uploads, which would otherwise shadow the files stored in it
+	uploadsDir = "/uploads"
+)
+
+var errUploadsDir = fmt.Errorf("path (%s) is reserved for resumable uploads", uploadsDir)
+
+// resumableUploader is implemented by the file stores which support resumable uploads. A file is uploaded in parts
+// which are committed to the file atomically once the upload completes
+type resumableUploader interface {
+	CreateUpload(ctx context.Context, req *model.CreateFileRequest) (string, error)
+	UploadPart(ctx context.Context, upload *model.FileUpload, number int, reader io.Reader) (*model.FileUploadPart, error)
+	ListParts(ctx context.Context, upload *model.FileUpload) ([]*model.FileUploadPart, error)
+	CompleteUpload(ctx context.Context, upload *model.FileUpload, parts []*model.FileUploadPart) error
+	AbortUpload(ctx context.Context, upload *model.FileUpload) error
+
+	// CleanupUploads aborts the incomplete uploads created before the provided time
+	CleanupUploads(ctx context.Context, before time.Time) error
+}
+
+// CreateUpload starts a resumable upload of a file. The returned id carries the authorisation of the caller, hence
+// the parts of the upload can be sent without the token
+func (m *Module) CreateUpload(ctx context.Context, project, token string, req *model.CreateFileRequest) (int, *model.FileUploadResponse, error) {
+	req.Store = utils.GetFileStoreAlias(req.Store)
+
+	if strings.Contains(req.Path, "..") {
+		return http.StatusBadRequest, nil, errors.New("valid path of the file is required to create an upload")
+	}
+	if req.Name == "" || strings.Contains(req.Name, "..") {
+		return http.StatusBadRequest, nil, errors.New("valid name of the file is required to create an upload")
+	}
+	if isUploadsDir(req.Path, req.Name) {
+		return http.StatusBadRequest, nil, errUploadsDir
+	}
+
+	// Exit if the file store is not enabled
+	status, err := m.checkStore(req.Store)
+	if err != nil {
+		return status, nil, err
+	}
+
+	// Check if the user is authorised to make this request
+	_, err = m.auth.IsFileOpAuthorised(ctx, project, token, req.Store, req.Path, model.FileCreate, map[string]interface{}{"meta": req.Meta})
+	if err != nil {
+		return http.StatusForbidden, nil, err
+	}
+
+	m.RLock()
+	defer m.RUnlock()
+
+	uploader, status, err := m.getUploader(req.Store)
+	if err != nil {
+		return status, nil, err
+	}
+
+	id, err := uploader.CreateUpload(ctx, req)
+	if err != nil {
+		return http.StatusInternalServerError, nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Unable to create upload of file (%s)", req.Name), err, nil)
+	}
+
+	upload := &model.FileUpload{ID: id, Store: req.Store, Path: req.Path, Name: req.Name, Meta: req.Meta, CreatedAt: time.Now().Unix()}
+	uploadID, err := m.encodeUpload(project, upload)
+	if err != nil {
+		return http.StatusInternalServerError, nil, err
+	}
+	return http.StatusOK, &model.FileUploadResponse{ID: uploadID, ExpiresAt: getUploadExpiry(upload)}, nil
+}
+
+// GetUpload returns the parts of a resumable upload which have been uploaded so far
+func (m *Module) GetUpload(ctx context.Context, project, uploadID string) (int, *model.FileUploadResponse, error) {
+	upload, err := m.decodeUpload(project, uploadID)
+	if err != nil {
+		return http.StatusForbidden, nil, err
+	}
+
+	m.RLock()
+	defer m.RUnlock()
+
+	uploader, status, err := m.getUploader(upload.Store)
+	if err != nil {
+		return status, nil, err
+	}
+
+	parts, err := uploader.ListParts(ctx, upload)
+	if err != nil {
+		return http.StatusNotFound, nil, err
+	}
+	return http.StatusOK, &model.FileUploadResponse{ID: uploadID, ExpiresAt: getUploadExpiry(upload), Parts: parts}, nil
+}
+
+// UploadPart uploads a part of a resumable upload. A part which has already been uploaded is overwritten
+func (m *Module) UploadPart(ctx context.Context, project, uploadID string, number int, reader io.Reader) (int, *model.FileUploadPart, error) {
+	upload, err := m.decodeUpload(project, uploadID)
+	if err != nil {
+		return http.StatusForbidden, nil, err
+	}
+	if number < 1 || number > maxUploadParts {
+		return http.StatusBadRequest, nil, fmt.Errorf("part number should be between 1 and %d", maxUploadParts)
+	}
+
+	m.RLock()
+	defer m.RUnlock()
+
+	uploader, status, err := m.getUploader(upload.Store)
+	if err != nil {
+		return status, nil, err
+	}
+
+	part, err := uploader.UploadPart(ctx, upload, number, reader)
+	if err != nil {
+		return http.StatusInternalServerError, nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Unable to upload part (%d) of file (%s)", number, upload.Name), err, nil)
+	}
+	return http.StatusOK, part, nil
+}
+
+// CompleteUpload commits the uploaded parts to the file. The parts must be numbered consecutively starting from 1
+func (m *Module) CompleteUpload(ctx context.Context, project, uploadID string) (int, error) {
+	upload, err := m.decodeUpload(project, uploadID)
+	if err != nil {
+		return http.StatusForbidden, err
+	}
+
+	m.RLock()
+	defer m.RUnlock()
+
+	store, status, err := m.getStore(upload.Store)
+	if err != nil {
+		return status, err
+	}
+	uploader, status, err := m.getUploader(upload.Store)
+	if err != nil {
+		return status, err
+	}
+
+	parts, err := uploader.ListParts(ctx, upload)
+	if err != nil {
+		return http.StatusNotFound, err
+	}
+	if len(parts) == 0 {
+		return http.StatusBadRequest, errors.New("no parts have been uploaded")
+	}
+	sort.Slice(parts, func(i, j int) bool { return parts[i].Number < parts[j].Number })
+	for i, part := range parts {
+		if part.Number != i+1 {
+			return http.StatusBadRequest, fmt.Errorf("part (%d) hasn't been uploaded", i+1)
+		}
+	}
+
+	req := &model.CreateFileRequest{Store: upload.Store, Meta: upload.Meta, Path: upload.Path, Name: upload.Name, Type: "file", MakeAll: true}
+	intent, err := m.eventing.CreateFileIntentHook(ctx, req)
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	start := time.Now()
+	err = uploader.CompleteUpload(ctx, upload, parts)
+	m.metricsHook(project, string(store.GetStoreType()), model.Create, time.Since(start), err)
+	if err != nil {
+		m.eventing.HookStage(ctx, intent, err)
+		return http.StatusInternalServerError, helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Unable to complete upload of file (%s)", upload.Name), err, nil)
+	}
+
+	m.eventing.HookStage(ctx, intent, nil)
+	return http.StatusOK, nil
+}
+
+// AbortUpload aborts a resumable upload and removes the parts uploaded so far
+func (m *Module) AbortUpload(ctx context.Context, project, uploadID string) (int, error) {
+	upload, err := m.decodeUpload(project, uploadID)
+	if err != nil {
+		return http.StatusForbidden, err
+	}
+
+	m.RLock()
+	defer m.RUnlock()
+
+	uploader, status, err := m.getUploader(upload.Store)
+	if err != nil {
+		return status, err
+	}
+
+	if err := uploader.AbortUpload(ctx, upload); err != nil {
+		return http.StatusInternalServerError, helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Unable to abort upload of file (%s)", upload.Name), err, nil)
+	}
+	return http.StatusOK, nil
+}
+
+// IsUploadID checks if the provided id is in the format of the id of a resumable upload. It doesn't verify the id
+func IsUploadID(id string) bool {
+	arr := strings.Split(id, ".")
+	if len(arr) != 2 {
+		return false
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(arr[0])
+	return err == nil && json.Valid(payload) && strings.HasPrefix(string(payload), "{")
+}
+
+// cleanupUploads aborts the uploads of all the file stores which have been abandoned
+func (m *Module) cleanupUploads(t *time.Time) {
+	m.RLock()
+	defer m.RUnlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	for alias, s := range m.stores {
+		uploader, ok := s.store.(resumableUploader)
+		if !ok {
+			continue
+		}
+		if err := uploader.CleanupUploads(ctx, t.Add(-uploadTTL)); err != nil {
+			_ = helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Unable to cleanup abandoned uploads of file store (%s)", alias), err, nil)
+		}
+	}
+}
+
+// getUploader returns the file store with the provided alias if it supports resumable uploads. It must be called with a lock held
+func (m *Module) getUploader(alias string) (resumableUploader, int, error) {
+	store, status, err := m.getStore(alias)
+	if err != nil {
+		return nil, status, err
+	}
+	uploader, ok := store.(resumableUploader)
+	if !ok {
+		return nil, http.StatusNotImplemented, fmt.Errorf("file store (%s) doesn't support resumable uploads", utils.GetFileStoreAlias(alias))
+	}
+	return uploader, http.StatusOK, nil
+}
+
+// encodeUpload encodes the upload along with its hmac signature to be used as the id of the upload
+func (m *Module) encodeUpload(project string, upload *model.FileUpload) (string, error) {
+	data, err := json.Marshal(upload)
+	if err != nil {
+		return "", err
+	}
+	payload := base64.RawURLEncoding.EncodeToString(data)
+
+	signature, err := m.signMessage(uploadKeyLabel, getUploadMessage(project, payload))
+	if err != nil {
+		return "", err
+	}
+	return payload + "." + signature, nil
+}
+
+// decodeUpload verifies the signature of the id of an upload and returns the upload if it hasn't expired
+func (m *Module) decodeUpload(project, uploadID string) (*model.FileUpload, error) {
+	arr := strings.Split(uploadID, ".")
+	if len(arr) != 2 {
+		return nil, errors.New("invalid upload id provided")
+	}
+
+	ok, err := m.verifyMessage(uploadKeyLabel, getUploadMessage(project, arr[0]), arr[1])
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, errors.New("invalid signature provided in upload id")
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(arr[0])
+	if err != nil {
+		return nil, err
+	}
+	upload := new(model.FileUpload)
+	if err := json.Unmarshal(data, upload); err != nil {
+		return nil, err
+	}
+	if time.Now().After(time.Unix(upload.CreatedAt, 0).Add(uploadTTL)) {
+		return nil, errors.New("upload has expired")
+	}
+	return upload, nil
+}
+
+func getUploadMessage(project, payload string) string {
+	return project + "\n" + payload
+}
+
+func getUploadExpiry(upload *model.FileUpload) string {
+	return time.Unix(upload.CreatedAt, 0).Add(uploadTTL).Format(time.RFC3339)
+}
+
+// isUploadsDir checks if the file or directory to be created lies in the directory reserved for resumable uploads
+func isUploadsDir(dir, name string) bool {
+	p := path.Join("/", dir, name)
+	return p == uploadsDir || strings.HasPrefix(p, uploadsDir+"/")
+}
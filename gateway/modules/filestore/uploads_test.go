@@ -0,0 +1,187 @@
+package filestore
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/spaceuptech/space-cloud/gateway/config"
+	"github.com/spaceuptech/space-cloud/gateway/model"
+	"github.com/spaceuptech/space-cloud/gateway/modules/filestore/amazons3"
+	"github.com/spaceuptech/space-cloud/gateway/modules/filestore/gcpstorage"
+	"github.com/spaceuptech/space-cloud/gateway/modules/filestore/local"
+	"github.com/spaceuptech/space-cloud/gateway/utils"
+)
+
+var (
+	_ resumableUploader = (*local.Local)(nil)
+	_ resumableUploader = (*amazons3.AmazonS3)(nil)
+	_ resumableUploader = (*gcpstorage.GCPStorage)(nil)
+)
+
+func newUploadTestModule(t *testing.T, root string) (*Module, *mockEventingModule) {
+	auth := new(mockAuthFilestoreInterface)
+	auth.On("IsFileOpAuthorised", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	auth.On("GetAESKey").Return([]byte("some-aes-key-of-32-characters!!!"))
+	eventing := new(mockEventingModule)
+	m := Init(auth, func(project, storeType string, op model.OperationType, latency time.Duration, err error) {})
+	m.SetEventingModule(eventing)
+	if err := m.SetConfig("myproject", &config.FileStoreConfig{Enabled: true, StoreType: string(utils.Local), Conn: root}); err != nil {
+		t.Fatalf("SetConfig() error = %v", err)
+	}
+	return m, eventing
+}
+
+func TestModule_Upload(t *testing.T) {
+	root := t.TempDir()
+	m, eventing := newUploadTestModule(t, root)
+	intent := &model.EventIntent{}
+	eventing.On("CreateFileIntentHook", mock.Anything, &model.CreateFileRequest{Store: "default", Meta: map[string]interface{}{"key": "value"}, Path: "/folder", Name: "file.txt", Type: "file", MakeAll: true}).Return(intent, nil)
+	eventing.On("HookStage", mock.Anything, intent, nil).Return()
+	ctx := context.Background()
+
+	status, res, err := m.CreateUpload(ctx, "myproject", "token", &model.CreateFileRequest{Path: "/folder", Name: "file.txt", Meta: map[string]interface{}{"key": "value"}})
+	if err != nil {
+		t.Fatalf("CreateUpload() status = %v, error = %v", status, err)
+	}
+	if !IsUploadID(res.ID) {
+		t.Errorf("IsUploadID() = false for upload id (%s)", res.ID)
+	}
+
+	// Parts can be uploaded in any order and retried
+	for number, data := range map[int]string{2: "world", 1: "hello "} {
+		if status, _, err := m.UploadPart(ctx, "myproject", res.ID, number, bytes.NewBufferString(data)); err != nil {
+			t.Fatalf("UploadPart() status = %v, error = %v", status, err)
+		}
+	}
+	if status, _, err := m.UploadPart(ctx, "myproject", res.ID, 1, bytes.NewBufferString("hello ")); err != nil {
+		t.Fatalf("UploadPart() status = %v, error = %v for a retried part", status, err)
+	}
+
+	// The staged parts aren't part of the file store
+	if files, _ := ioutil.ReadDir(root); len(files) != 0 {
+		t.Errorf("UploadPart() staged %d files in the root path", len(files))
+	}
+
+	_, upload, err := m.GetUpload(ctx, "myproject", res.ID)
+	if err != nil {
+		t.Fatalf("GetUpload() error = %v", err)
+	}
+	if len(upload.Parts) != 2 {
+		t.Errorf("GetUpload() parts = %v, want 2 parts", len(upload.Parts))
+	}
+
+	if _, err := os.Stat(filepath.Join(root, "folder", "file.txt")); !os.IsNotExist(err) {
+		t.Errorf("file exists before the upload completes")
+	}
+	if status, err := m.CompleteUpload(ctx, "myproject", res.ID); err != nil {
+		t.Fatalf("CompleteUpload() status = %v, error = %v", status, err)
+	}
+	data, _ := ioutil.ReadFile(filepath.Join(root, "folder", "file.txt"))
+	if string(data) != "hello world" {
+		t.Errorf("CompleteUpload() file = %s, want %s", data, "hello world")
+	}
+	eventing.AssertExpectations(t)
+
+	// The upload doesn't exist once it is completed
+	if status, _, err := m.GetUpload(ctx, "myproject", res.ID); err == nil || status != http.StatusNotFound {
+		t.Errorf("GetUpload() status = %v, error = %v, want not found after completion", status, err)
+	}
+}
+
+func TestModule_UploadErrors(t *testing.T) {
+	m, _ := newUploadTestModule(t, t.TempDir())
+	ctx := context.Background()
+
+	if status, _, err := m.CreateUpload(ctx, "myproject", "token", &model.CreateFileRequest{Path: "/folder"}); err == nil || status != http.StatusBadRequest {
+		t.Errorf("CreateUpload() status = %v, error = %v, want a bad request without name", status, err)
+	}
+	if status, _, err := m.CreateUpload(ctx, "myproject", "token", &model.CreateFileRequest{Path: "/folder/../..", Name: "file.txt"}); err == nil || status != http.StatusBadRequest {
+		t.Errorf("CreateUpload() status = %v, error = %v, want a bad request for a path outside the store", status, err)
+	}
+	if status, _, err := m.CreateUpload(ctx, "myproject", "token", &model.CreateFileRequest{Path: "/uploads", Name: "file.txt"}); err == nil || status != http.StatusBadRequest {
+		t.Errorf("CreateUpload() status = %v, error = %v, want a bad request for a path reserved for uploads", status, err)
+	}
+
+	_, res, err := m.CreateUpload(ctx, "myproject", "token", &model.CreateFileRequest{Path: "/folder", Name: "file.txt"})
+	if err != nil {
+		t.Fatalf("CreateUpload() error = %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		project    string
+		uploadID   string
+		number     int
+		wantStatus int
+	}{
+		{name: "upload id of another project", project: "another-project", uploadID: res.ID, number: 1, wantStatus: http.StatusForbidden},
+		{name: "tampered upload id", project: "myproject", uploadID: res.ID + "a", number: 1, wantStatus: http.StatusForbidden},
+		{name: "invalid upload id", project: "myproject", uploadID: "file.txt", number: 1, wantStatus: http.StatusForbidden},
+		{name: "invalid part number", project: "myproject", uploadID: res.ID, number: 0, wantStatus: http.StatusBadRequest},
+		{name: "part number exceeding the limit", project: "myproject", uploadID: res.ID, number: maxUploadParts + 1, wantStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if status, _, err := m.UploadPart(ctx, tt.project, tt.uploadID, tt.number, bytes.NewBufferString("data")); err == nil || status != tt.wantStatus {
+				t.Errorf("UploadPart() status = %v, error = %v, want status %v", status, err, tt.wantStatus)
+			}
+		})
+	}
+
+	// Parts must be consecutive
+	if _, _, err := m.UploadPart(ctx, "myproject", res.ID, 2, bytes.NewBufferString("data")); err != nil {
+		t.Fatalf("UploadPart() error = %v", err)
+	}
+	if status, err := m.CompleteUpload(ctx, "myproject", res.ID); err == nil || status != http.StatusBadRequest {
+		t.Errorf("CompleteUpload() status = %v, error = %v, want a bad request for a missing part", status, err)
+	}
+
+	if _, err := m.AbortUpload(ctx, "myproject", res.ID); err != nil {
+		t.Fatalf("AbortUpload() error = %v", err)
+	}
+	if status, _, err := m.UploadPart(ctx, "myproject", res.ID, 1, bytes.NewBufferString("data")); err == nil || status != http.StatusInternalServerError {
+		t.Errorf("UploadPart() status = %v, error = %v, want an error for an aborted upload", status, err)
+	}
+
+	// The signature of an upload id must not be valid for a signed url
+	arr := strings.Split(res.ID, ".")
+	if ok, err := m.verifyMessage(signedURLKeyLabel, getUploadMessage("myproject", arr[0]), arr[1]); err != nil || ok {
+		t.Errorf("verifyMessage() = %v, error = %v, want the signature of an upload to be invalid for a signed url", ok, err)
+	}
+}
+
+func TestModule_cleanupUploads(t *testing.T) {
+	root := t.TempDir()
+	m, _ := newUploadTestModule(t, root)
+	ctx := context.Background()
+
+	_, res, err := m.CreateUpload(ctx, "myproject", "token", &model.CreateFileRequest{Path: "/", Name: "file.txt"})
+	if err != nil {
+		t.Fatalf("CreateUpload() error = %v", err)
+	}
+
+	// Uploads which haven't crossed the ttl are retained
+	now := time.Now()
+	m.cleanupUploads(&now)
+	if status, _, err := m.GetUpload(ctx, "myproject", res.ID); err != nil {
+		t.Fatalf("GetUpload() status = %v, error = %v, want the upload to be retained", status, err)
+	}
+	if files, _ := ioutil.ReadDir(root + ".uploads"); len(files) != 1 {
+		t.Fatalf("CreateUpload() staged %d uploads next to the root path, want 1", len(files))
+	}
+
+	later := now.Add(uploadTTL + time.Minute)
+	m.cleanupUploads(&later)
+	if files, _ := ioutil.ReadDir(root + ".uploads"); len(files) != 0 {
+		t.Errorf("cleanupUploads() left %d abandoned uploads behind", len(files))
+	}
+}
@@ -99,6 +99,18 @@ func (m *Module) SetConfig(project string, c config.Services) error {
 	return nil
 }
 
+// GetServices returns the remote services of the project
+func (m *Module) GetServices() config.Services {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	services := make(config.Services, len(m.config))
+	for k, v := range m.config {
+		services[k] = v
+	}
+	return services
+}
+
 // SetCachingModule sets caching module
 func (m *Module) SetCachingModule(c cachingInterface) {
 	m.caching = c
@@ -20,6 +20,7 @@ import (
 
 	"github.com/spaceuptech/space-cloud/gateway/config"
 	"github.com/spaceuptech/space-cloud/gateway/utils"
+	"github.com/spaceuptech/space-cloud/gateway/utils/tracing"
 )
 
 func (m *Module) handleCall(ctx context.Context, serviceID, endpointID, token string, auth, params interface{}, cacheInfo *config.ReadCacheOptions) (int, interface{}, error) {
@@ -128,6 +129,8 @@ func (m *Module) handleCall(ctx context.Context, serviceID, endpointID, token st
 	// Prepare the state object
 	state := map[string]interface{}{"args": params, "auth": auth, "token": ogToken}
 
+	tracing.SpanFromContext(ctx).SetAttributes(tracing.String("http.method", method), tracing.String("http.url", url))
+
 	var res interface{}
 	req := &utils.HTTPRequest{
 		Params: newParams,
@@ -3,11 +3,13 @@ package functions
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/spaceuptech/helpers"
 
 	"github.com/spaceuptech/space-cloud/gateway/config"
 	"github.com/spaceuptech/space-cloud/gateway/model"
+	"github.com/spaceuptech/space-cloud/gateway/utils/tracing"
 )
 
 // CallWithContext invokes function on a service. The response from the function is returned back along with
@@ -29,13 +31,18 @@ func (m *Module) CallWithContext(ctx context.Context, service, function, token s
 		return hookResponse.Status(), hookResponse.Result(), nil
 	}
 
-	// TODO: Add metric hook for cache
+	ctx, span := tracing.StartSpan(ctx, fmt.Sprintf("remote_service.call %s/%s", service, function), tracing.SpanKindClient,
+		tracing.String("remote_service.project", m.project), tracing.String("remote_service.name", service), tracing.String("remote_service.endpoint", function))
+	start := time.Now()
 	status, result, err := m.handleCall(ctx, service, function, token, reqParams.Claims, req.Params, req.Cache)
+	m.metricHook(m.project, service, function, time.Since(start), err)
+	span.SetAttributes(tracing.Int64("http.status_code", int64(status)))
+	span.RecordError(err)
+	span.End()
 	if err != nil {
 		return status, result, err
 	}
 
-	m.metricHook(m.project, service, function)
 	return status, result, nil
 }
 
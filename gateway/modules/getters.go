@@ -8,7 +8,10 @@ import (
 	"github.com/spaceuptech/space-cloud/gateway/modules/filestore"
 	"github.com/spaceuptech/space-cloud/gateway/modules/functions"
 	"github.com/spaceuptech/space-cloud/gateway/modules/global/caching"
+	"github.com/spaceuptech/space-cloud/gateway/modules/global/cors"
 	"github.com/spaceuptech/space-cloud/gateway/modules/global/letsencrypt"
+	"github.com/spaceuptech/space-cloud/gateway/modules/global/metrics"
+	"github.com/spaceuptech/space-cloud/gateway/modules/global/ratelimit"
 	"github.com/spaceuptech/space-cloud/gateway/modules/global/routing"
 	"github.com/spaceuptech/space-cloud/gateway/modules/schema"
 	"github.com/spaceuptech/space-cloud/gateway/modules/userman"
@@ -104,6 +107,24 @@ func (m *Modules) GetSchemaModuleForSyncMan(projectID string) (model.SchemaEvent
 	return module.schema, nil
 }
 
+// GetCrudModuleForSyncMan returns crud module for sync manager
+func (m *Modules) GetCrudModuleForSyncMan(projectID string) (model.CrudSyncManInterface, error) {
+	module, err := m.loadModule(projectID)
+	if err != nil {
+		return nil, err
+	}
+	return module.db, nil
+}
+
+// GetCrudModuleForAudit returns crud module for the audit manager
+func (m *Modules) GetCrudModuleForAudit(projectID string) (model.CrudAuditInterface, error) {
+	module, err := m.loadModule(projectID)
+	if err != nil {
+		return nil, err
+	}
+	return module.db, nil
+}
+
 // GetAuthModuleForSyncMan returns auth module for sync manager
 func (m *Modules) GetAuthModuleForSyncMan(projectID string) (model.AuthSyncManInterface, error) {
 	module, err := m.loadModule(projectID)
@@ -126,4 +147,19 @@ func (m *Modules) Routing() *routing.Routing {
 // Caching returns the caching module
 func (m *Modules) Caching() *caching.Cache {
 	return m.GlobalMods.Caching()
-}
\ No newline at end of file
+}
+
+// Metrics returns the metrics module
+func (m *Modules) Metrics() *metrics.Module {
+	return m.GlobalMods.Metrics()
+}
+
+// RateLimiter returns the rate limiter module
+func (m *Modules) RateLimiter() *ratelimit.RateLimiter {
+	return m.GlobalMods.RateLimiter()
+}
+
+// Cors returns the cors module
+func (m *Modules) Cors() *cors.Cors {
+	return m.GlobalMods.Cors()
+}
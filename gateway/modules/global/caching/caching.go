@@ -9,6 +9,7 @@ import (
 
 	"github.com/spaceuptech/space-cloud/gateway/config"
 	"github.com/spaceuptech/space-cloud/gateway/managers/admin"
+	"github.com/spaceuptech/space-cloud/gateway/model"
 	"github.com/spaceuptech/space-cloud/gateway/utils"
 )
 
@@ -21,6 +22,8 @@ type Cache struct {
 
 	admin *admin.Manager
 
+	metricHook model.MetricCacheHook
+
 	config      *config.CacheConfig
 	dbRules     map[string]config.DatabaseRules // key is the project id
 	redisClient *redis.Client
@@ -81,6 +84,20 @@ func (c *Cache) AddDBRules(projectID string, dbRules config.DatabaseRules) {
 	c.dbRules[projectID] = dbRules
 }
 
+// SetMetricHook sets the hook invoked on every cache lookup
+func (c *Cache) SetMetricHook(hook model.MetricCacheHook) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.metricHook = hook
+}
+
+func (c *Cache) invokeMetricHook(projectID, resource string, isHit bool) {
+	if c.metricHook != nil {
+		c.metricHook(projectID, resource, isHit)
+	}
+}
+
 // SetAdminModule sets admin module
 func (c *Cache) SetAdminModule(admin *admin.Manager) {
 	c.lock.Lock()
@@ -54,6 +54,7 @@ func (c *Cache) GetDatabaseKey(ctx context.Context, projectID, dbAlias, tableNam
 	cacheResult.redisKey = key
 	cacheResult.isCacheHit = isCacheHit
 	cacheResult.isCacheEnabled = true
+	c.invokeMetricHook(projectID, metricResourceDatabase, isCacheHit)
 
 	if !isCacheHit {
 		return cacheResult, nil
@@ -20,6 +20,9 @@ const (
 
 	keyTypeTTL        = "ttl"
 	keyTypeInvalidate = "invalidate"
+
+	metricResourceDatabase      = "db"
+	metricResourceRemoteService = "remote-service"
 )
 
 func removeTablePrefixInWhereClauseFields(rootTableName string, whereClause map[string]interface{}) bool {
@@ -47,6 +47,7 @@ func (c *Cache) GetRemoteService(ctx context.Context, projectID, serviceID, endp
 	cacheResult.redisKey = key
 	cacheResult.isCacheHit = isCacheHit
 	cacheResult.isCacheEnabled = true
+	c.invokeMetricHook(projectID, metricResourceRemoteService, isCacheHit)
 
 	if !isCacheHit {
 		return cacheResult, err
@@ -0,0 +1,74 @@
+package caching
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/spaceuptech/helpers"
+)
+
+// tokenBucketScript atomically refills the bucket based on the time elapsed since the last request and consumes a
+// single token if available. The time of the redis server is used so that all gateway replicas share the same clock.
+// It returns whether the request is allowed along with the seconds to wait before a token becomes available
+var tokenBucketScript = redis.NewScript(`
+redis.replicate_commands()
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local t = redis.call('TIME')
+local now = tonumber(t[1]) + tonumber(t[2]) / 1000000
+
+local bucket = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(bucket[1])
+local ts = tonumber(bucket[2])
+if tokens == nil or ts == nil then
+	tokens = burst
+	ts = now
+end
+tokens = math.min(burst, tokens + math.max(0, now - ts) * rate)
+
+local allowed = 0
+local wait = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+else
+	wait = (1 - tokens) / rate
+end
+
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'ts', tostring(now))
+redis.call('PEXPIRE', KEYS[1], math.ceil(burst / rate * 1000) + 1000)
+return {allowed, tostring(wait)}
+`)
+
+// TakeRateLimitToken consumes a token from the bucket stored in redis. It returns whether the request is allowed, the
+// time to wait before retrying and whether the request was handled. The last boolean is false if caching
+// is disabled, in which case the caller is expected to fall back to an in memory bucket
+func (c *Cache) TakeRateLimitToken(ctx context.Context, key string, rate float64, burst int64) (bool, time.Duration, bool, error) {
+	c.lock.RLock()
+	redisClient := c.redisClient
+	isEnabled := c.config.Enabled
+	c.lock.RUnlock()
+
+	if !isEnabled || redisClient == nil {
+		return false, 0, false, nil
+	}
+
+	result, err := tokenBucketScript.Run(ctx, redisClient, []string{c.generateRateLimitKey(key)}, rate, burst).Result()
+	if err != nil {
+		return false, 0, true, helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to take rate limit token from redis", err, map[string]interface{}{"key": key})
+	}
+
+	arr, ok := result.([]interface{})
+	if !ok || len(arr) != 2 {
+		return false, 0, true, helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Invalid response (%v) received from redis for rate limit token", result), nil, map[string]interface{}{"key": key})
+	}
+	wait, _ := strconv.ParseFloat(fmt.Sprintf("%v", arr[1]), 64)
+	return arr[0] == int64(1), time.Duration(wait * float64(time.Second)), true, nil
+}
+
+func (c *Cache) generateRateLimitKey(key string) string {
+	return fmt.Sprintf("%s::rate-limit::%s", c.clusterID, key)
+}
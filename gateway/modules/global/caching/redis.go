@@ -8,14 +8,20 @@ import (
 	"github.com/spaceuptech/helpers"
 
 	"github.com/spaceuptech/space-cloud/gateway/config"
+	"github.com/spaceuptech/space-cloud/gateway/utils/tracing"
 )
 
 func (c *Cache) get(ctx context.Context, redisKey string) (string, bool, []byte, error) {
+	ctx, span := tracing.StartSpan(ctx, "cache.get", tracing.SpanKindClient, tracing.String("db.system", "redis"))
+	defer span.End()
+
 	result, err := c.redisClient.Get(ctx, redisKey).Result()
+	span.SetAttributes(tracing.Bool("cache.hit", err == nil))
 	if err == redis.Nil { // key not present
 		helpers.Logger.LogDebug(helpers.GetRequestID(ctx), "Key not present in redis, it's a cache miss", map[string]interface{}{"key": redisKey})
 		return redisKey, false, nil, nil
 	} else if err != nil {
+		span.RecordError(err)
 		return "", false, nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to get key from redis", err, map[string]interface{}{"key": redisKey})
 	} else {
 		helpers.Logger.LogDebug(helpers.GetRequestID(ctx), "It's a cache hit", map[string]interface{}{"key": redisKey})
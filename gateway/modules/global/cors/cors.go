@@ -0,0 +1,45 @@
+package cors
+
+import (
+	"net/http"
+	"sync"
+
+	rscors "github.com/rs/cors"
+
+	"github.com/spaceuptech/space-cloud/gateway/config"
+	"github.com/spaceuptech/space-cloud/gateway/utils"
+)
+
+// Cors enforces the cors policies of all projects and ingress routes. Requests which don't belong to a project
+// with a cors policy get the default policy which allows all origins
+type Cors struct {
+	lock sync.RWMutex
+
+	projects      map[string]*rscors.Cors            // key is the project id
+	routes        map[string]map[string]*routePolicy // key is the project id and then the route id
+	defaultPolicy *rscors.Cors
+	router        routerInterface
+}
+
+// routePolicy holds the cors handler built for the cors config of an ingress route
+type routePolicy struct {
+	config *config.Cors
+	cors   *rscors.Cors
+}
+
+type routerInterface interface {
+	MatchRoute(request *http.Request) (*config.Route, bool)
+}
+
+// New creates a new instance of the cors module
+func New() *Cors {
+	return &Cors{projects: map[string]*rscors.Cors{}, routes: map[string]map[string]*routePolicy{}, defaultPolicy: utils.CreateCorsObject()}
+}
+
+// SetRoutingModule sets the routing module used to find the ingress route of a request
+func (c *Cors) SetRoutingModule(r routerInterface) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.router = r
+}
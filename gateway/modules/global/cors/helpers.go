@@ -0,0 +1,73 @@
+package cors
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	rscors "github.com/rs/cors"
+
+	"github.com/spaceuptech/space-cloud/gateway/config"
+)
+
+const apiPrefix = "/v1/api/"
+
+// ValidatePolicy checks if the cors policy of a project can be enforced. A missing policy allows all origins
+func ValidatePolicy(policy *config.Cors) error {
+	if policy == nil {
+		return nil
+	}
+	return validatePolicy(policy)
+}
+
+func validatePolicy(policy *config.Cors) error {
+	if len(policy.AllowedOrigins) == 0 {
+		return errors.New("at least one allowed origin must be provided")
+	}
+	for _, origin := range policy.AllowedOrigins {
+		if origin == "*" {
+			if policy.AllowCredentials {
+				return errors.New("credentials cannot be allowed for all origins")
+			}
+			continue
+		}
+		if strings.Count(origin, "*") > 1 {
+			return fmt.Errorf("origin (%s) can contain only a single wildcard", origin)
+		}
+		if !strings.HasPrefix(origin, "http://") && !strings.HasPrefix(origin, "https://") {
+			return fmt.Errorf("origin (%s) must start with http:// or https://", origin)
+		}
+	}
+	for _, method := range policy.AllowedMethods {
+		switch strings.ToUpper(method) {
+		case http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete, http.MethodOptions:
+		default:
+			return fmt.Errorf("invalid method (%s) provided", method)
+		}
+	}
+	if policy.MaxAge < 0 {
+		return errors.New("max age cannot be negative")
+	}
+	return nil
+}
+
+func newPolicy(policy *config.Cors) *rscors.Cors {
+	return rscors.New(rscors.Options{
+		AllowedOrigins:   policy.AllowedOrigins,
+		AllowedMethods:   policy.AllowedMethods,
+		AllowedHeaders:   policy.AllowedHeaders,
+		ExposedHeaders:   policy.ExposedHeaders,
+		AllowCredentials: policy.AllowCredentials,
+		MaxAge:           policy.MaxAge,
+	})
+}
+
+// getProjectFromPath returns the project of a request made to the apis of a project
+func getProjectFromPath(path string) (string, bool) {
+	if !strings.HasPrefix(path, apiPrefix) {
+		return "", false
+	}
+	project := strings.SplitN(strings.TrimPrefix(path, apiPrefix), "/", 2)[0]
+	return project, project != ""
+}
@@ -0,0 +1,54 @@
+package cors
+
+import (
+	"testing"
+
+	"github.com/spaceuptech/space-cloud/gateway/config"
+)
+
+func Test_validatePolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  *config.Cors
+		wantErr bool
+	}{
+		{name: "valid policy", policy: &config.Cors{AllowedOrigins: []string{"https://example.com", "https://*.example.com"}, AllowedMethods: []string{"get", "POST"}, AllowCredentials: true, MaxAge: 600}},
+		{name: "all origins allowed", policy: &config.Cors{AllowedOrigins: []string{"*"}}},
+		{name: "origins not provided", policy: &config.Cors{}, wantErr: true},
+		{name: "credentials allowed for all origins", policy: &config.Cors{AllowedOrigins: []string{"*"}, AllowCredentials: true}, wantErr: true},
+		{name: "origin with multiple wildcards", policy: &config.Cors{AllowedOrigins: []string{"https://*.*.example.com"}}, wantErr: true},
+		{name: "origin without scheme", policy: &config.Cors{AllowedOrigins: []string{"example.com"}}, wantErr: true},
+		{name: "invalid method", policy: &config.Cors{AllowedOrigins: []string{"https://example.com"}, AllowedMethods: []string{"FETCH"}}, wantErr: true},
+		{name: "negative max age", policy: &config.Cors{AllowedOrigins: []string{"https://example.com"}, MaxAge: -1}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validatePolicy(tt.policy); (err != nil) != tt.wantErr {
+				t.Errorf("validatePolicy() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_getProjectFromPath(t *testing.T) {
+	tests := []struct {
+		name        string
+		path        string
+		wantProject string
+		wantOk      bool
+	}{
+		{name: "crud endpoint", path: "/v1/api/myproject/crud/db/users/read", wantProject: "myproject", wantOk: true},
+		{name: "project root", path: "/v1/api/myproject", wantProject: "myproject", wantOk: true},
+		{name: "config endpoint", path: "/v1/config/projects/myproject"},
+		{name: "ingress route", path: "/api/users"},
+		{name: "project not provided", path: "/v1/api/"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			project, ok := getProjectFromPath(tt.path)
+			if project != tt.wantProject || ok != tt.wantOk {
+				t.Errorf("getProjectFromPath() = (%v, %v), want (%v, %v)", project, ok, tt.wantProject, tt.wantOk)
+			}
+		})
+	}
+}
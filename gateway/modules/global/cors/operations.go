@@ -0,0 +1,121 @@
+package cors
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	rscors "github.com/rs/cors"
+	"github.com/spaceuptech/helpers"
+
+	"github.com/spaceuptech/space-cloud/gateway/config"
+)
+
+// SetProjectPolicy sets the cors policy of a project. The default policy is used if the policy is nil
+func (c *Cors) SetProjectPolicy(projectID string, policy *config.Cors) error {
+	if err := ValidatePolicy(policy); err != nil {
+		return helpers.Logger.LogError("", fmt.Sprintf("Invalid cors policy provided for project (%s)", projectID), err, nil)
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if policy == nil {
+		delete(c.projects, projectID)
+		return nil
+	}
+	c.projects[projectID] = newPolicy(policy)
+	return nil
+}
+
+// DeleteProjectPolicy deletes the cors policy of a project along with the policies of its ingress routes
+func (c *Cors) DeleteProjectPolicy(projectID string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	delete(c.projects, projectID)
+	delete(c.routes, projectID)
+}
+
+// SetProjectRoutes sets the cors policies of the ingress routes of a project. The policies of routes which
+// no longer exist are removed. Routes without a cors config get the policy of the project
+func (c *Cors) SetProjectRoutes(projectID string, routes config.Routes) error {
+	policies := map[string]*routePolicy{}
+	for _, route := range routes {
+		if route.Cors == nil {
+			continue
+		}
+		if err := validatePolicy(route.Cors); err != nil {
+			return helpers.Logger.LogError("", fmt.Sprintf("Invalid cors policy provided for route (%s)", route.ID), err, nil)
+		}
+		policies[route.ID] = &routePolicy{config: route.Cors, cors: newPolicy(route.Cors)}
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if len(policies) == 0 {
+		delete(c.routes, projectID)
+		return nil
+	}
+	c.routes[projectID] = policies
+	return nil
+}
+
+// Handler applies the cors policy of the project or ingress route the request is meant for. Preflight
+// requests are answered right away
+func (c *Cors) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.getPolicy(r).ServeHTTP(w, r, next.ServeHTTP)
+	})
+}
+
+func (c *Cors) getPolicy(r *http.Request) *rscors.Cors {
+	// Requests made to the apis of a project get the policy of that project
+	if projectID, ok := getProjectFromPath(r.URL.Path); ok {
+		return c.getProjectPolicy(projectID)
+	}
+
+	// The remaining endpoints of space cloud aren't served by ingress routes
+	if strings.HasPrefix(r.URL.Path, "/v1/") || strings.HasPrefix(r.URL.Path, "/mission-control") {
+		return c.defaultPolicy
+	}
+
+	c.lock.RLock()
+	router := c.router
+	c.lock.RUnlock()
+	if router == nil {
+		return c.defaultPolicy
+	}
+
+	route, ok := router.MatchRoute(r)
+	if !ok {
+		return c.defaultPolicy
+	}
+	if route.Cors == nil {
+		return c.getProjectPolicy(route.Project)
+	}
+	return c.getRoutePolicy(route)
+}
+
+func (c *Cors) getProjectPolicy(projectID string) *rscors.Cors {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	if policy, ok := c.projects[projectID]; ok {
+		return policy
+	}
+	return c.defaultPolicy
+}
+
+// getRoutePolicy returns the cors policy of an ingress route. The policy of the project is used if the
+// policy of the route hasn't been set yet
+func (c *Cors) getRoutePolicy(route *config.Route) *rscors.Cors {
+	c.lock.RLock()
+	policy, ok := c.routes[route.Project][route.ID]
+	c.lock.RUnlock()
+	if ok && policy.config == route.Cors {
+		return policy.cors
+	}
+	return c.getProjectPolicy(route.Project)
+}
@@ -0,0 +1,219 @@
+package cors
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/spaceuptech/space-cloud/gateway/config"
+)
+
+type mockRouter struct {
+	routes map[string]*config.Route // key is the url of the route
+}
+
+func (m *mockRouter) MatchRoute(request *http.Request) (*config.Route, bool) {
+	route, ok := m.routes[request.URL.Path]
+	return route, ok
+}
+
+func TestCors_Handler(t *testing.T) {
+	projectPolicy := &config.Cors{
+		AllowedOrigins:   []string{"https://*.example.com"},
+		AllowedMethods:   []string{http.MethodGet, http.MethodPost},
+		AllowedHeaders:   []string{"Authorization", "Content-Type"},
+		AllowCredentials: true,
+		MaxAge:           600,
+	}
+	router := &mockRouter{routes: map[string]*config.Route{
+		"/api/users":  {ID: "users", Project: "myproject"},
+		"/api/public": {ID: "public", Project: "myproject", Cors: &config.Cors{AllowedOrigins: []string{"https://partner.com"}, AllowedMethods: []string{http.MethodGet}}},
+		"/api/other":  {ID: "other", Project: "otherproject"},
+	}}
+
+	tests := []struct {
+		name        string
+		method      string
+		path        string
+		headers     map[string]string
+		wantStatus  int
+		wantHeaders map[string]string
+		wantNext    bool
+	}{
+		{
+			name:        "preflight from a subdomain is answered from the project policy",
+			method:      http.MethodOptions,
+			path:        "/v1/api/myproject/crud/db/users/read",
+			headers:     map[string]string{"Origin": "https://app.example.com", "Access-Control-Request-Method": http.MethodPost, "Access-Control-Request-Headers": "Authorization"},
+			wantStatus:  http.StatusOK,
+			wantHeaders: map[string]string{"Access-Control-Allow-Origin": "https://app.example.com", "Access-Control-Allow-Methods": http.MethodPost, "Access-Control-Allow-Credentials": "true", "Access-Control-Max-Age": "600"},
+		},
+		{
+			name:        "preflight from an unknown origin is not allowed by the project policy",
+			method:      http.MethodOptions,
+			path:        "/v1/api/myproject/graphql",
+			headers:     map[string]string{"Origin": "https://evil.com", "Access-Control-Request-Method": http.MethodPost},
+			wantStatus:  http.StatusOK,
+			wantHeaders: map[string]string{"Access-Control-Allow-Origin": ""},
+		},
+		{
+			name:        "preflight for a method not allowed by the project policy",
+			method:      http.MethodOptions,
+			path:        "/v1/api/myproject/files",
+			headers:     map[string]string{"Origin": "https://app.example.com", "Access-Control-Request-Method": http.MethodDelete},
+			wantStatus:  http.StatusOK,
+			wantHeaders: map[string]string{"Access-Control-Allow-Origin": ""},
+		},
+		{
+			name:        "actual request gets the headers of the project policy",
+			method:      http.MethodPost,
+			path:        "/v1/api/myproject/graphql",
+			headers:     map[string]string{"Origin": "https://app.example.com"},
+			wantStatus:  http.StatusNoContent,
+			wantHeaders: map[string]string{"Access-Control-Allow-Origin": "https://app.example.com", "Access-Control-Allow-Credentials": "true"},
+			wantNext:    true,
+		},
+		{
+			name:        "project without a policy allows all origins",
+			method:      http.MethodPost,
+			path:        "/v1/api/otherproject/graphql",
+			headers:     map[string]string{"Origin": "https://evil.com"},
+			wantStatus:  http.StatusNoContent,
+			wantHeaders: map[string]string{"Access-Control-Allow-Origin": "https://evil.com"},
+			wantNext:    true,
+		},
+		{
+			name:        "config endpoints get the default policy",
+			method:      http.MethodGet,
+			path:        "/v1/config/projects/myproject",
+			headers:     map[string]string{"Origin": "https://evil.com"},
+			wantStatus:  http.StatusNoContent,
+			wantHeaders: map[string]string{"Access-Control-Allow-Origin": "https://evil.com"},
+			wantNext:    true,
+		},
+		{
+			name:        "ingress route without a policy gets the policy of its project",
+			method:      http.MethodOptions,
+			path:        "/api/users",
+			headers:     map[string]string{"Origin": "https://evil.com", "Access-Control-Request-Method": http.MethodGet},
+			wantStatus:  http.StatusOK,
+			wantHeaders: map[string]string{"Access-Control-Allow-Origin": ""},
+		},
+		{
+			name:        "policy of the ingress route overrides the policy of its project",
+			method:      http.MethodOptions,
+			path:        "/api/public",
+			headers:     map[string]string{"Origin": "https://partner.com", "Access-Control-Request-Method": http.MethodGet},
+			wantStatus:  http.StatusOK,
+			wantHeaders: map[string]string{"Access-Control-Allow-Origin": "https://partner.com", "Access-Control-Allow-Credentials": ""},
+		},
+		{
+			name:        "ingress route of a project without a policy allows all origins",
+			method:      http.MethodGet,
+			path:        "/api/other",
+			headers:     map[string]string{"Origin": "https://evil.com"},
+			wantStatus:  http.StatusNoContent,
+			wantHeaders: map[string]string{"Access-Control-Allow-Origin": "https://evil.com"},
+			wantNext:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := New()
+			c.SetRoutingModule(router)
+			if err := c.SetProjectPolicy("myproject", projectPolicy); err != nil {
+				t.Fatalf("SetProjectPolicy() error = %v", err)
+			}
+			for _, route := range router.routes {
+				if err := c.SetProjectRoutes(route.Project, config.Routes{route}); err != nil {
+					t.Fatalf("SetProjectRoutes() error = %v", err)
+				}
+			}
+
+			var calledNext bool
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calledNext = true
+				w.WriteHeader(http.StatusNoContent)
+			})
+
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+			w := httptest.NewRecorder()
+			c.Handler(next).ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("Handler() status = %v, want %v", w.Code, tt.wantStatus)
+			}
+			if calledNext != tt.wantNext {
+				t.Errorf("Handler() called next = %v, want %v", calledNext, tt.wantNext)
+			}
+			for k, v := range tt.wantHeaders {
+				if got := w.Header().Get(k); got != v {
+					t.Errorf("Handler() header %s = %v, want %v", k, got, v)
+				}
+			}
+		})
+	}
+}
+
+func TestCors_SetProjectPolicy(t *testing.T) {
+	c := New()
+	if err := c.SetProjectPolicy("myproject", &config.Cors{}); err == nil {
+		t.Error("SetProjectPolicy() expected error for policy without origins")
+	}
+
+	if err := c.SetProjectPolicy("myproject", &config.Cors{AllowedOrigins: []string{"https://example.com"}}); err != nil {
+		t.Fatalf("SetProjectPolicy() error = %v", err)
+	}
+	if c.getProjectPolicy("myproject") == c.defaultPolicy {
+		t.Error("SetProjectPolicy() project policy was not set")
+	}
+
+	if err := c.SetProjectPolicy("myproject", nil); err != nil {
+		t.Fatalf("SetProjectPolicy() error = %v", err)
+	}
+	if c.getProjectPolicy("myproject") != c.defaultPolicy {
+		t.Error("SetProjectPolicy() project policy was not removed")
+	}
+}
+
+func TestCors_SetProjectRoutes(t *testing.T) {
+	public := &config.Route{ID: "public", Project: "myproject", Cors: &config.Cors{AllowedOrigins: []string{"https://partner.com"}}}
+	users := &config.Route{ID: "users", Project: "myproject"}
+
+	c := New()
+	if err := c.SetProjectRoutes("myproject", config.Routes{public, {ID: "invalid", Cors: &config.Cors{}}}); err == nil {
+		t.Error("SetProjectRoutes() expected error for route policy without origins")
+	}
+	if c.getRoutePolicy(public) != c.defaultPolicy {
+		t.Error("SetProjectRoutes() route policies were set even though a route policy is invalid")
+	}
+
+	if err := c.SetProjectRoutes("myproject", config.Routes{public, users}); err != nil {
+		t.Fatalf("SetProjectRoutes() error = %v", err)
+	}
+	if c.getRoutePolicy(public) == c.defaultPolicy {
+		t.Error("SetProjectRoutes() route policy was not set")
+	}
+	if _, ok := c.routes["myproject"]["users"]; ok {
+		t.Error("SetProjectRoutes() route policy was set for a route without a cors config")
+	}
+
+	// Routes which have been removed lose their policy
+	if err := c.SetProjectRoutes("myproject", config.Routes{users}); err != nil {
+		t.Fatalf("SetProjectRoutes() error = %v", err)
+	}
+	if _, ok := c.routes["myproject"]; ok {
+		t.Error("SetProjectRoutes() policy of a removed route was not pruned")
+	}
+
+	if err := c.SetProjectRoutes("myproject", config.Routes{public}); err != nil {
+		t.Fatalf("SetProjectRoutes() error = %v", err)
+	}
+	c.DeleteProjectPolicy("myproject")
+	if _, ok := c.routes["myproject"]; ok {
+		t.Error("DeleteProjectPolicy() route policies of the project were not pruned")
+	}
+}
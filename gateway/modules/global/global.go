@@ -3,8 +3,10 @@ package global
 import (
 	"github.com/spaceuptech/space-cloud/gateway/managers"
 	"github.com/spaceuptech/space-cloud/gateway/modules/global/caching"
+	"github.com/spaceuptech/space-cloud/gateway/modules/global/cors"
 	"github.com/spaceuptech/space-cloud/gateway/modules/global/letsencrypt"
 	"github.com/spaceuptech/space-cloud/gateway/modules/global/metrics"
+	"github.com/spaceuptech/space-cloud/gateway/modules/global/ratelimit"
 	"github.com/spaceuptech/space-cloud/gateway/modules/global/routing"
 )
 
@@ -14,6 +16,8 @@ type Global struct {
 	metrics     *metrics.Module
 	routing     *routing.Routing
 	caching     *caching.Cache
+	rateLimiter *ratelimit.RateLimiter
+	cors        *cors.Cors
 }
 
 // New creates a new global object
@@ -36,9 +40,21 @@ func New(clusterID, nodeID string, isDev bool, managers *managers.Managers) (*Gl
 	// Initialise the caching module
 	c := caching.Init(clusterID, nodeID)
 	c.SetAdminModule(managers.Admin())
+	c.SetMetricHook(m.AddCacheOperation)
 	r.SetCachingModule(c)
 
-	return &Global{letsencrypt: le, metrics: m, routing: r, caching: c}, nil
+	// Initialise the rate limiter which stores its buckets in redis when caching is enabled
+	rl := ratelimit.New()
+	rl.SetCachingModule(c)
+	r.SetRateLimiter(rl)
+
+	// Initialise the cors module which looks up the ingress route of a request to find its policy. The routing
+	// module hands it the cors policies of the routes when they are set
+	co := cors.New()
+	co.SetRoutingModule(r)
+	r.SetCorsModule(co)
+
+	return &Global{letsencrypt: le, metrics: m, routing: r, caching: c, rateLimiter: rl, cors: co}, nil
 }
 
 // LetsEncrypt returns the letsencrypt module
@@ -60,3 +76,13 @@ func (g *Global) Routing() *routing.Routing {
 func (g *Global) Caching() *caching.Cache {
 	return g.caching
 }
+
+// RateLimiter returns the rate limiter module
+func (g *Global) RateLimiter() *ratelimit.RateLimiter {
+	return g.rateLimiter
+}
+
+// Cors returns the cors module
+func (g *Global) Cors() *cors.Cors {
+	return g.cors
+}
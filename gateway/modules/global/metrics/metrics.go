@@ -23,6 +23,8 @@ type Module struct {
 	isMetricDisabled bool
 	// Variables to interact with the sink
 	sink *db.DB
+	// Collectors exposed on the prometheus endpoint
+	prometheus *prometheusMetrics
 
 	// Global modules
 	adminMan *admin.Manager
@@ -40,7 +42,7 @@ func New(clusterID, nodeID string, isMetricDisabled bool, adminMan *admin.Manage
 	conn := api.New("spacecloud", "api.spaceuptech.com", true).DB("db")
 
 	// Create a new metrics module
-	m := &Module{nodeID: nodeID, clusterID: clusterID, sink: conn, isMetricDisabled: isMetricDisabled, adminMan: adminMan, syncMan: syncMan, isProd: isProd}
+	m := &Module{nodeID: nodeID, clusterID: clusterID, sink: conn, prometheus: newPrometheusMetrics(), isMetricDisabled: isMetricDisabled, adminMan: adminMan, syncMan: syncMan, isProd: isProd}
 	m.isMetricDisabled = true
 	// Start routine to flush metrics to the sink
 	go m.routineFlushMetricsToSink()
@@ -9,6 +9,10 @@ import (
 
 // AddEventingType counts the number of time a particular event type is called
 func (m *Module) AddEventingType(project, eventingType string) {
+	if m.prometheus != nil {
+		m.prometheus.eventsQueued.WithLabelValues(project, eventingType).Inc()
+	}
+
 	m.lock.RLock()
 	defer m.lock.RUnlock()
 	// Return if the metrics module is disabled
@@ -21,7 +25,16 @@ func (m *Module) AddEventingType(project, eventingType string) {
 }
 
 // AddFunctionOperation counts the number of time a particular function gets invoked
-func (m *Module) AddFunctionOperation(project, service, function string) {
+func (m *Module) AddFunctionOperation(project, service, function string, latency time.Duration, err error) {
+	if m.prometheus != nil {
+		m.prometheus.addFunctionOperation(project, service, function, latency, err)
+	}
+
+	// Only successful invocations are flushed to the sink
+	if err != nil {
+		return
+	}
+
 	m.lock.RLock()
 	defer m.lock.RUnlock()
 
@@ -36,7 +49,16 @@ func (m *Module) AddFunctionOperation(project, service, function string) {
 }
 
 // AddDBOperation adds a operation to the database
-func (m *Module) AddDBOperation(project, dbType, col string, count int64, op model.OperationType) {
+func (m *Module) AddDBOperation(project, dbType, col string, count int64, op model.OperationType, latency time.Duration, err error) {
+	if m.prometheus != nil {
+		m.prometheus.addDBOperation(project, dbType, col, count, op, latency, err)
+	}
+
+	// Only successful operations are flushed to the sink
+	if err != nil {
+		return
+	}
+
 	m.lock.RLock()
 	defer m.lock.RUnlock()
 	// Return if the metrics module is disabled
@@ -63,7 +85,16 @@ func (m *Module) AddDBOperation(project, dbType, col string, count int64, op mod
 }
 
 // AddFileOperation adds a operation to the database
-func (m *Module) AddFileOperation(project, storeType string, op model.OperationType) {
+func (m *Module) AddFileOperation(project, storeType string, op model.OperationType, latency time.Duration, err error) {
+	if m.prometheus != nil {
+		m.prometheus.addFileOperation(project, storeType, op, latency, err)
+	}
+
+	// Only successful operations are flushed to the sink
+	if err != nil {
+		return
+	}
+
 	m.lock.RLock()
 	defer m.lock.RUnlock()
 
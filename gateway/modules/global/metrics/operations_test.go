@@ -5,6 +5,7 @@ import (
 	"sync"
 	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/spaceuptech/space-cloud/gateway/model"
 )
@@ -159,7 +160,7 @@ func TestModule_AddDBOperation(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			tt.fields.AddDBOperation(tt.args.project, tt.args.dbAlias, tt.args.col, tt.args.count, tt.args.op)
+			tt.fields.AddDBOperation(tt.args.project, tt.args.dbAlias, tt.args.col, tt.args.count, tt.args.op, time.Millisecond, nil)
 			tt.want.Range(func(wantKey, wantValue interface{}) bool {
 				gotValue, ok := tt.fields.projects.Load(wantKey)
 				if !ok {
@@ -283,7 +284,7 @@ func TestModule_AddFileOperation(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			tt.fields.AddFileOperation(tt.args.project, tt.args.storeType, tt.args.op)
+			tt.fields.AddFileOperation(tt.args.project, tt.args.storeType, tt.args.op, time.Millisecond, nil)
 			tt.want.Range(func(wantKey, wantValue interface{}) bool {
 				gotValue, ok := tt.fields.projects.Load(wantKey)
 				if !ok {
@@ -329,7 +330,7 @@ func TestModule_AddFunctionOperation(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			tt.fields.AddFunctionOperation(tt.args.project, tt.args.service, tt.args.function)
+			tt.fields.AddFunctionOperation(tt.args.project, tt.args.service, tt.args.function, time.Millisecond, nil)
 			tt.want.Range(func(wantKey, wantValue interface{}) bool {
 				gotValue, ok := tt.fields.projects.Load(wantKey)
 				if !ok {
@@ -0,0 +1,208 @@
+package metrics
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/spaceuptech/space-cloud/gateway/model"
+)
+
+const (
+	prometheusNamespace = "space_cloud"
+
+	statusSuccess = "success"
+	statusError   = "error"
+
+	cacheHit  = "hit"
+	cacheMiss = "miss"
+)
+
+// prometheusMetrics holds the collectors exposed on the prometheus endpoint of the gateway. Unlike the metrics flushed
+// to the sink, these are never reset
+type prometheusMetrics struct {
+	registry *prometheus.Registry
+
+	crudOperations *prometheus.CounterVec
+	crudDocuments  *prometheus.CounterVec
+	crudLatency    *prometheus.HistogramVec
+
+	graphqlRequests *prometheus.CounterVec
+	graphqlLatency  *prometheus.HistogramVec
+
+	functionCalls   *prometheus.CounterVec
+	functionLatency *prometheus.HistogramVec
+
+	eventsQueued       *prometheus.CounterVec
+	eventingDeliveries *prometheus.CounterVec
+	eventingLatency    *prometheus.HistogramVec
+
+	fileOperations *prometheus.CounterVec
+	fileLatency    *prometheus.HistogramVec
+
+	cacheRequests *prometheus.CounterVec
+
+	websocketConnections *prometheus.GaugeVec
+}
+
+func newPrometheusMetrics() *prometheusMetrics {
+	p := &prometheusMetrics{
+		registry: prometheus.NewRegistry(),
+
+		crudOperations: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: prometheusNamespace, Subsystem: "crud", Name: "operations_total",
+			Help: "Number of database operations performed",
+		}, []string{"project", "db", "table", "operation", "status"}),
+		crudDocuments: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: prometheusNamespace, Subsystem: "crud", Name: "documents_total",
+			Help: "Number of documents affected by successful database operations",
+		}, []string{"project", "db", "table", "operation"}),
+		crudLatency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: prometheusNamespace, Subsystem: "crud", Name: "operation_duration_seconds",
+			Help: "Latency of database operations", Buckets: prometheus.DefBuckets,
+		}, []string{"project", "db", "table", "operation"}),
+
+		graphqlRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: prometheusNamespace, Subsystem: "graphql", Name: "requests_total",
+			Help: "Number of graphql requests served",
+		}, []string{"project", "status"}),
+		graphqlLatency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: prometheusNamespace, Subsystem: "graphql", Name: "request_duration_seconds",
+			Help: "Latency of graphql requests", Buckets: prometheus.DefBuckets,
+		}, []string{"project"}),
+
+		functionCalls: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: prometheusNamespace, Subsystem: "remote_service", Name: "calls_total",
+			Help: "Number of remote service endpoint invocations",
+		}, []string{"project", "service", "endpoint", "status"}),
+		functionLatency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: prometheusNamespace, Subsystem: "remote_service", Name: "call_duration_seconds",
+			Help: "Latency of remote service endpoint invocations", Buckets: prometheus.DefBuckets,
+		}, []string{"project", "service", "endpoint"}),
+
+		eventsQueued: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: prometheusNamespace, Subsystem: "eventing", Name: "events_queued_total",
+			Help: "Number of events queued",
+		}, []string{"project", "type"}),
+		eventingDeliveries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: prometheusNamespace, Subsystem: "eventing", Name: "deliveries_total",
+			Help: "Number of event deliveries attempted to the webhooks of eventing triggers",
+		}, []string{"project", "trigger", "status"}),
+		eventingLatency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: prometheusNamespace, Subsystem: "eventing", Name: "delivery_duration_seconds",
+			Help: "Latency of event deliveries", Buckets: prometheus.DefBuckets,
+		}, []string{"project", "trigger"}),
+
+		fileOperations: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: prometheusNamespace, Subsystem: "file", Name: "operations_total",
+			Help: "Number of file store operations performed",
+		}, []string{"project", "store", "operation", "status"}),
+		fileLatency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: prometheusNamespace, Subsystem: "file", Name: "operation_duration_seconds",
+			Help: "Latency of file store operations", Buckets: prometheus.DefBuckets,
+		}, []string{"project", "store", "operation"}),
+
+		cacheRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: prometheusNamespace, Subsystem: "cache", Name: "requests_total",
+			Help: "Number of cache lookups",
+		}, []string{"project", "resource", "result"}),
+
+		websocketConnections: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: prometheusNamespace, Subsystem: "websocket", Name: "connections",
+			Help: "Number of open websocket connections",
+		}, []string{"project", "protocol"}),
+	}
+
+	p.registry.MustRegister(
+		prometheus.NewGoCollector(),
+		prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}),
+		p.crudOperations, p.crudDocuments, p.crudLatency,
+		p.graphqlRequests, p.graphqlLatency,
+		p.functionCalls, p.functionLatency,
+		p.eventsQueued, p.eventingDeliveries, p.eventingLatency,
+		p.fileOperations, p.fileLatency,
+		p.cacheRequests,
+		p.websocketConnections,
+	)
+	return p
+}
+
+// PrometheusHandler returns the http handler which serves the metrics in the prometheus exposition format
+func (m *Module) PrometheusHandler() http.Handler {
+	if m.prometheus == nil {
+		return http.NotFoundHandler()
+	}
+	return promhttp.HandlerFor(m.prometheus.registry, promhttp.HandlerOpts{})
+}
+
+// AddGraphQLOperation records a graphql request
+func (m *Module) AddGraphQLOperation(project string, latency time.Duration, err error) {
+	if m.prometheus == nil {
+		return
+	}
+	m.prometheus.graphqlRequests.WithLabelValues(project, getStatus(err)).Inc()
+	m.prometheus.graphqlLatency.WithLabelValues(project).Observe(latency.Seconds())
+}
+
+// AddEventingDelivery records the delivery of an event to the webhook of an eventing trigger
+func (m *Module) AddEventingDelivery(project, trigger string, latency time.Duration, err error) {
+	if m.prometheus == nil {
+		return
+	}
+	m.prometheus.eventingDeliveries.WithLabelValues(project, trigger, getStatus(err)).Inc()
+	m.prometheus.eventingLatency.WithLabelValues(project, trigger).Observe(latency.Seconds())
+}
+
+// AddCacheOperation records a cache lookup for a resource
+func (m *Module) AddCacheOperation(project, resource string, isHit bool) {
+	if m.prometheus == nil {
+		return
+	}
+	result := cacheMiss
+	if isHit {
+		result = cacheHit
+	}
+	m.prometheus.cacheRequests.WithLabelValues(project, resource, result).Inc()
+}
+
+// AddWebsocketConnection records a websocket connection being opened or closed
+func (m *Module) AddWebsocketConnection(project, protocol string, isConnected bool) {
+	if m.prometheus == nil {
+		return
+	}
+	if isConnected {
+		m.prometheus.websocketConnections.WithLabelValues(project, protocol).Inc()
+		return
+	}
+	m.prometheus.websocketConnections.WithLabelValues(project, protocol).Dec()
+}
+
+func (p *prometheusMetrics) addDBOperation(project, dbAlias, col string, count int64, op model.OperationType, latency time.Duration, err error) {
+	p.crudOperations.WithLabelValues(project, dbAlias, col, string(op), getStatus(err)).Inc()
+	// Operations which didn't hit the database (like realtime feeds) don't report a latency
+	if latency > 0 {
+		p.crudLatency.WithLabelValues(project, dbAlias, col, string(op)).Observe(latency.Seconds())
+	}
+	if err == nil && count > 0 {
+		p.crudDocuments.WithLabelValues(project, dbAlias, col, string(op)).Add(float64(count))
+	}
+}
+
+func (p *prometheusMetrics) addFileOperation(project, storeType string, op model.OperationType, latency time.Duration, err error) {
+	p.fileOperations.WithLabelValues(project, storeType, string(op), getStatus(err)).Inc()
+	p.fileLatency.WithLabelValues(project, storeType, string(op)).Observe(latency.Seconds())
+}
+
+func (p *prometheusMetrics) addFunctionOperation(project, service, function string, latency time.Duration, err error) {
+	p.functionCalls.WithLabelValues(project, service, function, getStatus(err)).Inc()
+	p.functionLatency.WithLabelValues(project, service, function).Observe(latency.Seconds())
+}
+
+func getStatus(err error) string {
+	if err != nil {
+		return statusError
+	}
+	return statusSuccess
+}
@@ -0,0 +1,89 @@
+package metrics
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/spaceuptech/space-cloud/gateway/model"
+)
+
+func TestModule_prometheusMetrics(t *testing.T) {
+	m := &Module{prometheus: newPrometheusMetrics()}
+
+	m.AddDBOperation("project", "db", "users", 5, model.Read, 10*time.Millisecond, nil)
+	m.AddDBOperation("project", "db", "users", 0, model.Read, 10*time.Millisecond, errors.New("connection refused"))
+	m.AddFileOperation("project", "local", model.Create, time.Millisecond, nil)
+	m.AddFunctionOperation("project", "service", "endpoint", time.Millisecond, errors.New("timeout"))
+	m.AddEventingType("project", "DB_INSERT")
+	m.AddEventingDelivery("project", "trigger", time.Millisecond, nil)
+	m.AddGraphQLOperation("project", time.Millisecond, nil)
+	m.AddCacheOperation("project", "db", true)
+	m.AddCacheOperation("project", "db", false)
+	m.AddCacheOperation("project", "db", false)
+	m.AddWebsocketConnection("project", "json", true)
+	m.AddWebsocketConnection("project", "json", true)
+	m.AddWebsocketConnection("project", "json", false)
+
+	p := m.prometheus
+	tests := []struct {
+		name string
+		got  float64
+		want float64
+	}{
+		{name: "successful crud operations", got: testutil.ToFloat64(p.crudOperations.WithLabelValues("project", "db", "users", "read", statusSuccess)), want: 1},
+		{name: "failed crud operations", got: testutil.ToFloat64(p.crudOperations.WithLabelValues("project", "db", "users", "read", statusError)), want: 1},
+		{name: "documents read", got: testutil.ToFloat64(p.crudDocuments.WithLabelValues("project", "db", "users", "read")), want: 5},
+		{name: "file operations", got: testutil.ToFloat64(p.fileOperations.WithLabelValues("project", "local", "create", statusSuccess)), want: 1},
+		{name: "remote service calls", got: testutil.ToFloat64(p.functionCalls.WithLabelValues("project", "service", "endpoint", statusError)), want: 1},
+		{name: "events queued", got: testutil.ToFloat64(p.eventsQueued.WithLabelValues("project", "DB_INSERT")), want: 1},
+		{name: "event deliveries", got: testutil.ToFloat64(p.eventingDeliveries.WithLabelValues("project", "trigger", statusSuccess)), want: 1},
+		{name: "graphql requests", got: testutil.ToFloat64(p.graphqlRequests.WithLabelValues("project", statusSuccess)), want: 1},
+		{name: "cache hits", got: testutil.ToFloat64(p.cacheRequests.WithLabelValues("project", "db", cacheHit)), want: 1},
+		{name: "cache misses", got: testutil.ToFloat64(p.cacheRequests.WithLabelValues("project", "db", cacheMiss)), want: 2},
+		{name: "websocket connections", got: testutil.ToFloat64(p.websocketConnections.WithLabelValues("project", "json")), want: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("got = %v, want %v", tt.got, tt.want)
+			}
+		})
+	}
+
+	// Failed operations must not be flushed to the sink
+	value, ok := m.projects.Load(generateDatabaseKey("project", "db", "users"))
+	if !ok {
+		t.Fatal("AddDBOperation() key doesn't exist in the sink metrics")
+	}
+	if got := value.(*metrics).crud.read; got != 5 {
+		t.Errorf("AddDBOperation() sink read count = %v, want %v", got, 5)
+	}
+	if _, ok := m.projects.Load(generateFunctionKey("project", "service", "endpoint")); ok {
+		t.Error("AddFunctionOperation() failed invocation was added to the sink metrics")
+	}
+}
+
+func TestModule_PrometheusHandler(t *testing.T) {
+	m := &Module{prometheus: newPrometheusMetrics()}
+	m.AddDBOperation("project", "db", "users", 1, model.Create, time.Millisecond, nil)
+
+	w := httptest.NewRecorder()
+	m.PrometheusHandler().ServeHTTP(w, httptest.NewRequest("GET", "/v1/api/metrics", nil))
+
+	data, _ := ioutil.ReadAll(w.Body)
+	for _, want := range []string{
+		`space_cloud_crud_operations_total{db="db",operation="create",project="project",status="success",table="users"} 1`,
+		`space_cloud_crud_operation_duration_seconds_count{db="db",operation="create",project="project",table="users"} 1`,
+		`go_goroutines`,
+	} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("PrometheusHandler() response doesn't contain %s", want)
+		}
+	}
+}
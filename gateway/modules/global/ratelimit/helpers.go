@@ -0,0 +1,192 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/spaceuptech/helpers"
+
+	"github.com/spaceuptech/space-cloud/gateway/config"
+	"github.com/spaceuptech/space-cloud/gateway/utils"
+)
+
+// ValidatePolicies checks if the rate limit policies of a project can be enforced
+func ValidatePolicies(policies []*config.RateLimit) error {
+	for _, policy := range policies {
+		if err := validatePolicy(policy); err != nil {
+			return fmt.Errorf("invalid rate limit policy (%s): %v", policy.ID, err)
+		}
+	}
+	return nil
+}
+
+func validatePolicy(policy *config.RateLimit) error {
+	if policy.Requests <= 0 || policy.Interval <= 0 {
+		return errors.New("requests and interval must be greater than zero")
+	}
+	if policy.Burst < 0 {
+		return errors.New("burst cannot be negative")
+	}
+	for _, endpoint := range policy.Endpoints {
+		switch endpoint {
+		case EndpointCrud, EndpointGraphQL, EndpointServices, EndpointFiles, EndpointIngress:
+		default:
+			return fmt.Errorf("invalid endpoint (%s) provided", endpoint)
+		}
+	}
+	switch policy.KeyType {
+	case KeyTypeIP, KeyTypeAPIKey:
+	case KeyTypeClaim:
+		if policy.Claim == "" {
+			return errors.New("claim must be provided for key type claim")
+		}
+	default:
+		return fmt.Errorf("invalid key type (%s) provided", policy.KeyType)
+	}
+	if _, err := parseTrustedProxies(policy.TrustedProxies); err != nil {
+		return err
+	}
+	return nil
+}
+
+// parseTrustedProxies parses the cidrs of the trusted proxies. A plain ip address is treated as a cidr containing only that address
+func parseTrustedProxies(proxies []string) ([]*net.IPNet, error) {
+	networks := make([]*net.IPNet, len(proxies))
+	for i, proxy := range proxies {
+		if !strings.Contains(proxy, "/") {
+			ip := net.ParseIP(proxy)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid trusted proxy (%s) provided", proxy)
+			}
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 8*net.IPv4len
+			}
+			networks[i] = &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}
+			continue
+		}
+
+		_, network, err := net.ParseCIDR(proxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy (%s) provided", proxy)
+		}
+		networks[i] = network
+	}
+	return networks, nil
+}
+
+func isEndpointMatched(policy *config.RateLimit, endpoint string) bool {
+	if len(policy.Endpoints) == 0 {
+		return true
+	}
+	for _, e := range policy.Endpoints {
+		if e == endpoint {
+			return true
+		}
+	}
+	return false
+}
+
+// getRateAndBurst returns the number of tokens added to the bucket every second and the capacity of the bucket
+func getRateAndBurst(policy *config.RateLimit) (float64, int64) {
+	burst := policy.Burst
+	if burst == 0 {
+		burst = policy.Requests
+	}
+	return float64(policy.Requests) / float64(policy.Interval), burst
+}
+
+// getClientKey returns the value which identifies the client of a request. Requests without an api key
+// or a valid token are identified by their ip address
+func getClientKey(ctx context.Context, policy *config.RateLimit, req *http.Request, claims *lazyClaims) string {
+	switch policy.KeyType {
+	case KeyTypeAPIKey:
+		header := policy.Header
+		if header == "" {
+			header = defaultAPIKeyHeader
+		}
+		if key := req.Header.Get(header); key != "" {
+			return fmt.Sprintf("%s::%s", KeyTypeAPIKey, key)
+		}
+
+	case KeyTypeClaim:
+		if obj, err := claims.get(); err == nil {
+			if value, err := utils.LoadValue("auth."+policy.Claim, map[string]interface{}{"auth": obj}); err == nil && value != nil {
+				return fmt.Sprintf("%s::%v", KeyTypeClaim, value)
+			}
+		} else {
+			helpers.Logger.LogDebug(helpers.GetRequestID(ctx), "Unable to get claims of request for rate limit, using ip address instead", map[string]interface{}{"error": err.Error()})
+		}
+	}
+	// The policy has been validated already
+	trustedProxies, _ := parseTrustedProxies(policy.TrustedProxies)
+	return fmt.Sprintf("%s::%s", KeyTypeIP, getClientIP(req, trustedProxies))
+}
+
+// getClientIP returns the ip address of the client. The X-Forwarded-For and X-Real-IP headers are used only if the request
+// was made by a trusted proxy, since they can be set by any client otherwise. The addresses in the X-Forwarded-For header
+// are walked from the right and the first one which isn't a trusted proxy is the address of the client
+func getClientIP(req *http.Request, trustedProxies []*net.IPNet) string {
+	host, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		host = req.RemoteAddr
+	}
+	if !isTrustedProxy(host, trustedProxies) {
+		return host
+	}
+
+	if forwardedFor := req.Header.Values("X-Forwarded-For"); len(forwardedFor) > 0 {
+		addresses := strings.Split(strings.Join(forwardedFor, ","), ",")
+		for i := len(addresses) - 1; i >= 0; i-- {
+			address := strings.TrimSpace(addresses[i])
+			if i == 0 || !isTrustedProxy(address, trustedProxies) {
+				return address
+			}
+		}
+	}
+	if realIP := req.Header.Get("X-Real-IP"); realIP != "" {
+		return realIP
+	}
+	return host
+}
+
+func isTrustedProxy(address string, trustedProxies []*net.IPNet) bool {
+	ip := net.ParseIP(address)
+	if ip == nil {
+		return false
+	}
+	for _, network := range trustedProxies {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// lazyClaims parses the token of a request only if a policy needs the claims and at most once
+type lazyClaims struct {
+	getClaims ClaimsFunc
+	isLoaded  bool
+	claims    map[string]interface{}
+	err       error
+}
+
+func newLazyClaims(getClaims ClaimsFunc) *lazyClaims {
+	return &lazyClaims{getClaims: getClaims}
+}
+
+func (c *lazyClaims) get() (map[string]interface{}, error) {
+	if !c.isLoaded {
+		c.isLoaded = true
+		if c.getClaims == nil {
+			c.err = errors.New("claims are not available")
+		} else {
+			c.claims, c.err = c.getClaims()
+		}
+	}
+	return c.claims, c.err
+}
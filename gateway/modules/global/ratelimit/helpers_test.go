@@ -0,0 +1,91 @@
+package ratelimit
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/spaceuptech/space-cloud/gateway/config"
+)
+
+func Test_validatePolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  *config.RateLimit
+		wantErr bool
+	}{
+		{name: "valid ip policy", policy: &config.RateLimit{KeyType: KeyTypeIP, Requests: 10, Interval: 1, Endpoints: []string{EndpointCrud, EndpointIngress}}},
+		{name: "valid claim policy", policy: &config.RateLimit{KeyType: KeyTypeClaim, Claim: "id", Requests: 10, Interval: 1}},
+		{name: "claim not provided", policy: &config.RateLimit{KeyType: KeyTypeClaim, Requests: 10, Interval: 1}, wantErr: true},
+		{name: "invalid key type", policy: &config.RateLimit{KeyType: "cookie", Requests: 10, Interval: 1}, wantErr: true},
+		{name: "invalid endpoint", policy: &config.RateLimit{KeyType: KeyTypeIP, Requests: 10, Interval: 1, Endpoints: []string{"realtime"}}, wantErr: true},
+		{name: "zero requests", policy: &config.RateLimit{KeyType: KeyTypeIP, Interval: 1}, wantErr: true},
+		{name: "zero interval", policy: &config.RateLimit{KeyType: KeyTypeIP, Requests: 1}, wantErr: true},
+		{name: "negative burst", policy: &config.RateLimit{KeyType: KeyTypeIP, Requests: 1, Interval: 1, Burst: -1}, wantErr: true},
+		{name: "valid trusted proxies", policy: &config.RateLimit{KeyType: KeyTypeIP, Requests: 1, Interval: 1, TrustedProxies: []string{"10.0.0.0/8", "::1", "1.2.3.4"}}},
+		{name: "invalid trusted proxy", policy: &config.RateLimit{KeyType: KeyTypeIP, Requests: 1, Interval: 1, TrustedProxies: []string{"10.0.0.0/33"}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validatePolicy(tt.policy); (err != nil) != tt.wantErr {
+				t.Errorf("validatePolicy() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_getClientIP(t *testing.T) {
+	trustedProxies, err := parseTrustedProxies([]string{"10.0.0.0/8", "192.168.1.1"})
+	if err != nil {
+		t.Fatalf("parseTrustedProxies() error = %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		headers    map[string]string
+		want       string
+	}{
+		{name: "remote address", remoteAddr: "1.1.1.1:1234", want: "1.1.1.1"},
+		{name: "forwarded for", remoteAddr: "10.0.0.1:1234", headers: map[string]string{"X-Forwarded-For": "2.2.2.2, 10.0.0.2"}, want: "2.2.2.2"},
+		{name: "forwarded for with a spoofed address", remoteAddr: "192.168.1.1:1234", headers: map[string]string{"X-Forwarded-For": "9.9.9.9, 2.2.2.2, 10.0.0.2"}, want: "2.2.2.2"},
+		{name: "forwarded for with only trusted proxies", remoteAddr: "10.0.0.1:1234", headers: map[string]string{"X-Forwarded-For": "10.0.0.3, 10.0.0.2"}, want: "10.0.0.3"},
+		{name: "real ip", remoteAddr: "10.0.0.1:1234", headers: map[string]string{"X-Real-IP": "3.3.3.3"}, want: "3.3.3.3"},
+		{name: "forwarded for from an untrusted client", remoteAddr: "1.1.1.1:1234", headers: map[string]string{"X-Forwarded-For": "2.2.2.2"}, want: "1.1.1.1"},
+		{name: "real ip from an untrusted client", remoteAddr: "1.1.1.1:1234", headers: map[string]string{"X-Real-IP": "3.3.3.3"}, want: "1.1.1.1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.RemoteAddr = tt.remoteAddr
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+			if got := getClientIP(req, trustedProxies); got != tt.want {
+				t.Errorf("getClientIP() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetRetryAfterHeader(t *testing.T) {
+	tests := []struct {
+		name       string
+		retryAfter time.Duration
+		want       string
+	}{
+		{name: "rounded up", retryAfter: 1500 * time.Millisecond, want: "2"},
+		{name: "at least a second", retryAfter: time.Millisecond, want: "1"},
+		{name: "exact seconds", retryAfter: 60 * time.Second, want: "60"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			SetRetryAfterHeader(w, tt.retryAfter)
+			if got := w.Header().Get("Retry-After"); got != tt.want {
+				t.Errorf("SetRetryAfterHeader() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
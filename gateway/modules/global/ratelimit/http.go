@@ -0,0 +1,21 @@
+package ratelimit
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// ErrRateLimitExceeded is returned when a client has exhausted the tokens of its bucket
+var ErrRateLimitExceeded = errors.New("rate limit exceeded, too many requests")
+
+// SetRetryAfterHeader sets the Retry-After header in seconds. The value is rounded up so that clients never retry too early
+func SetRetryAfterHeader(w http.ResponseWriter, retryAfter time.Duration) {
+	seconds := int64(math.Ceil(retryAfter.Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+	w.Header().Set("Retry-After", strconv.FormatInt(seconds, 10))
+}
@@ -0,0 +1,78 @@
+package ratelimit
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	// pruneInterval is the interval after which the buckets which have been refilled completely are removed
+	pruneInterval = time.Minute
+
+	// maxIdleTime is the duration after which an unused bucket is removed even if it hasn't been refilled. This bounds
+	// the memory used by clients which are seen only once, at the cost of resetting the buckets of slow policies
+	maxIdleTime = time.Hour
+)
+
+// memoryStore stores the token buckets in memory. It is used for single node setups where caching is disabled
+type memoryStore struct {
+	lock      sync.Mutex
+	buckets   map[string]*bucket
+	lastPrune time.Time
+}
+
+type bucket struct {
+	tokens float64
+	ts     time.Time
+	// expiry is the time at which the bucket gets refilled completely. Such buckets are the same as a new bucket
+	expiry time.Time
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{buckets: map[string]*bucket{}, lastPrune: time.Now()}
+}
+
+// take refills the bucket based on the time elapsed since the last request and consumes a single token if available.
+// It returns whether the request is allowed along with the time to wait before a token becomes available
+func (s *memoryStore) take(key string, rate float64, burst int64, now time.Time) (bool, time.Duration) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if now.Sub(s.lastPrune) >= pruneInterval {
+		s.prune(now)
+	}
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(burst), ts: now}
+		s.buckets[key] = b
+	}
+
+	if elapsed := now.Sub(b.ts).Seconds(); elapsed > 0 {
+		b.tokens += elapsed * rate
+	}
+	if b.tokens > float64(burst) {
+		b.tokens = float64(burst)
+	}
+	b.ts = now
+
+	allowed := false
+	var wait time.Duration
+	if b.tokens >= 1 {
+		b.tokens--
+		allowed = true
+	} else {
+		wait = time.Duration((1 - b.tokens) / rate * float64(time.Second))
+	}
+	b.expiry = now.Add(time.Duration((float64(burst) - b.tokens) / rate * float64(time.Second)))
+	return allowed, wait
+}
+
+func (s *memoryStore) prune(now time.Time) {
+	for key, b := range s.buckets {
+		if !now.Before(b.expiry) || now.Sub(b.ts) >= maxIdleTime {
+			delete(s.buckets, key)
+		}
+	}
+	s.lastPrune = now
+}
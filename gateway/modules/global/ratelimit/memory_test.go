@@ -0,0 +1,84 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+)
+
+func Test_memoryStore_take(t *testing.T) {
+	start := time.Unix(1600000000, 0)
+	type step struct {
+		offset      time.Duration
+		wantAllowed bool
+		wantWait    time.Duration
+	}
+	tests := []struct {
+		name  string
+		rate  float64
+		burst int64
+		steps []step
+	}{
+		{
+			name:  "burst is allowed and then requests are rejected until a token is refilled",
+			rate:  1,
+			burst: 2,
+			steps: []step{
+				{offset: 0, wantAllowed: true},
+				{offset: 0, wantAllowed: true},
+				{offset: 0, wantAllowed: false, wantWait: time.Second},
+				{offset: 500 * time.Millisecond, wantAllowed: false, wantWait: 500 * time.Millisecond},
+				{offset: time.Second, wantAllowed: true},
+				{offset: time.Second, wantAllowed: false, wantWait: time.Second},
+			},
+		},
+		{
+			name:  "bucket never holds more than the burst",
+			rate:  10,
+			burst: 1,
+			steps: []step{
+				{offset: 0, wantAllowed: true},
+				{offset: time.Hour, wantAllowed: true},
+				{offset: time.Hour, wantAllowed: false, wantWait: 100 * time.Millisecond},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newMemoryStore()
+			for i, st := range tt.steps {
+				allowed, wait := s.take("key", tt.rate, tt.burst, start.Add(st.offset))
+				if allowed != st.wantAllowed {
+					t.Errorf("take() step %d allowed = %v, want %v", i, allowed, st.wantAllowed)
+				}
+				if wait != st.wantWait {
+					t.Errorf("take() step %d wait = %v, want %v", i, wait, st.wantWait)
+				}
+			}
+		})
+	}
+}
+
+func Test_memoryStore_prune(t *testing.T) {
+	start := time.Unix(1600000000, 0)
+	s := newMemoryStore()
+	s.lastPrune = start
+
+	s.take("full", 1, 1, start)
+	s.take("empty", 1.0/120, 1, start)
+
+	// The first bucket gets refilled in a second while the second one needs two minutes
+	s.take("other", 1, 1, start.Add(pruneInterval))
+	if _, ok := s.buckets["full"]; ok {
+		t.Error("prune() refilled bucket must be removed")
+	}
+	if _, ok := s.buckets["empty"]; !ok {
+		t.Error("prune() bucket which isn't refilled must be retained")
+	}
+
+	// Buckets which haven't been used for a long time are removed even if they aren't refilled
+	s.take("slow", 1.0/86400, 1, start.Add(pruneInterval))
+	s.take("other", 1, 1, start.Add(pruneInterval+maxIdleTime))
+	if _, ok := s.buckets["slow"]; ok {
+		t.Error("prune() idle bucket must be removed")
+	}
+}
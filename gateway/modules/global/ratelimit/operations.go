@@ -0,0 +1,102 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/spaceuptech/helpers"
+
+	"github.com/spaceuptech/space-cloud/gateway/config"
+)
+
+// SetProjectPolicies sets the rate limit policies of a project
+func (l *RateLimiter) SetProjectPolicies(projectID string, policies []*config.RateLimit) error {
+	if err := ValidatePolicies(policies); err != nil {
+		return helpers.Logger.LogError("", fmt.Sprintf("Invalid rate limit policies provided for project (%s)", projectID), err, nil)
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if len(policies) == 0 {
+		delete(l.policies, projectID)
+		return nil
+	}
+	l.policies[projectID] = policies
+	return nil
+}
+
+// DeleteProjectPolicies deletes the rate limit policies of a project
+func (l *RateLimiter) DeleteProjectPolicies(projectID string) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	delete(l.policies, projectID)
+}
+
+// Allow checks if a request to an endpoint of a project is allowed by the rate limit policies of the project.
+// It returns the time after which the request can be retried if it isn't allowed
+func (l *RateLimiter) Allow(ctx context.Context, projectID, endpoint string, req *http.Request, getClaims ClaimsFunc) (bool, time.Duration) {
+	l.lock.RLock()
+	policies := make([]*config.RateLimit, 0)
+	for _, policy := range l.policies[projectID] {
+		if isEndpointMatched(policy, endpoint) {
+			policies = append(policies, policy)
+		}
+	}
+	l.lock.RUnlock()
+
+	return l.check(ctx, projectID, policies, req, getClaims)
+}
+
+// AllowRoute checks if a request to an ingress route is allowed. The rate limit of the route overrides
+// the ingress rate limit policies of the project
+func (l *RateLimiter) AllowRoute(ctx context.Context, route *config.Route, req *http.Request, getClaims ClaimsFunc) (bool, time.Duration) {
+	if route.RateLimit == nil {
+		return l.Allow(ctx, route.Project, EndpointIngress, req, getClaims)
+	}
+
+	policy := *route.RateLimit
+	if err := validatePolicy(&policy); err != nil {
+		_ = helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Invalid rate limit provided for route (%s)", route.ID), err, nil)
+		return true, 0
+	}
+	// Each route gets its own buckets
+	policy.ID = fmt.Sprintf("route-%s", route.ID)
+	return l.check(ctx, route.Project, []*config.RateLimit{&policy}, req, getClaims)
+}
+
+func (l *RateLimiter) check(ctx context.Context, projectID string, policies []*config.RateLimit, req *http.Request, getClaims ClaimsFunc) (bool, time.Duration) {
+	if len(policies) == 0 {
+		return true, 0
+	}
+
+	claims := newLazyClaims(getClaims)
+	for _, policy := range policies {
+		key := fmt.Sprintf("%s::%s::%s", projectID, policy.ID, getClientKey(ctx, policy, req, claims))
+		if allowed, retryAfter := l.take(ctx, key, policy); !allowed {
+			helpers.Logger.LogDebug(helpers.GetRequestID(ctx), "Request rejected by rate limit policy", map[string]interface{}{"project": projectID, "policy": policy.ID, "retryAfter": retryAfter.String()})
+			return false, retryAfter
+		}
+	}
+	return true, 0
+}
+
+// take consumes a token from the bucket of the client. The bucket in memory is used if redis isn't available
+func (l *RateLimiter) take(ctx context.Context, key string, policy *config.RateLimit) (bool, time.Duration) {
+	rate, burst := getRateAndBurst(policy)
+
+	l.lock.RLock()
+	caching := l.caching
+	l.lock.RUnlock()
+
+	if caching != nil {
+		allowed, retryAfter, isHandled, err := caching.TakeRateLimitToken(ctx, key, rate, burst)
+		if isHandled && err == nil {
+			return allowed, retryAfter
+		}
+	}
+	return l.memory.take(key, rate, burst, time.Now())
+}
@@ -0,0 +1,201 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/spaceuptech/space-cloud/gateway/config"
+)
+
+type mockCaching struct {
+	isEnabled bool
+	err       error
+	keys      []string
+}
+
+func (m *mockCaching) TakeRateLimitToken(ctx context.Context, key string, rate float64, burst int64) (bool, time.Duration, bool, error) {
+	if !m.isEnabled {
+		return false, 0, false, nil
+	}
+	m.keys = append(m.keys, key)
+	if m.err != nil {
+		return false, 0, true, m.err
+	}
+	return false, 2 * time.Second, true, nil
+}
+
+func newRequest(ip, apiKey string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/v1/api/project/crud/db/col/read", nil)
+	req.RemoteAddr = ip + ":1234"
+	if apiKey != "" {
+		req.Header.Set(defaultAPIKeyHeader, apiKey)
+	}
+	return req
+}
+
+func TestRateLimiter_Allow(t *testing.T) {
+	claims := func(id string) ClaimsFunc {
+		return func() (map[string]interface{}, error) {
+			if id == "" {
+				return nil, errors.New("invalid token")
+			}
+			return map[string]interface{}{"id": id}, nil
+		}
+	}
+	type request struct {
+		endpoint  string
+		req       *http.Request
+		getClaims ClaimsFunc
+		want      bool
+	}
+	tests := []struct {
+		name     string
+		policies []*config.RateLimit
+		requests []request
+	}{
+		{
+			name: "no policies",
+			requests: []request{
+				{endpoint: EndpointCrud, req: newRequest("1.1.1.1", ""), want: true},
+				{endpoint: EndpointCrud, req: newRequest("1.1.1.1", ""), want: true},
+			},
+		},
+		{
+			name:     "clients are limited by ip for the matching endpoints only",
+			policies: []*config.RateLimit{{ID: "p1", Endpoints: []string{EndpointCrud}, KeyType: KeyTypeIP, Requests: 1, Interval: 60}},
+			requests: []request{
+				{endpoint: EndpointCrud, req: newRequest("1.1.1.1", ""), want: true},
+				{endpoint: EndpointCrud, req: newRequest("1.1.1.1", ""), want: false},
+				{endpoint: EndpointCrud, req: newRequest("2.2.2.2", ""), want: true},
+				{endpoint: EndpointGraphQL, req: newRequest("1.1.1.1", ""), want: true},
+			},
+		},
+		{
+			name:     "clients are limited by claim and fall back to ip without a valid token",
+			policies: []*config.RateLimit{{ID: "p1", KeyType: KeyTypeClaim, Claim: "id", Requests: 1, Interval: 60}},
+			requests: []request{
+				{endpoint: EndpointGraphQL, req: newRequest("1.1.1.1", ""), getClaims: claims("user1"), want: true},
+				{endpoint: EndpointFiles, req: newRequest("2.2.2.2", ""), getClaims: claims("user1"), want: false},
+				{endpoint: EndpointFiles, req: newRequest("1.1.1.1", ""), getClaims: claims("user2"), want: true},
+				{endpoint: EndpointFiles, req: newRequest("1.1.1.1", ""), getClaims: claims(""), want: true},
+				{endpoint: EndpointFiles, req: newRequest("1.1.1.1", ""), getClaims: claims(""), want: false},
+			},
+		},
+		{
+			name:     "clients are limited by api key",
+			policies: []*config.RateLimit{{ID: "p1", KeyType: KeyTypeAPIKey, Requests: 1, Interval: 60, Burst: 2}},
+			requests: []request{
+				{endpoint: EndpointServices, req: newRequest("1.1.1.1", "key1"), want: true},
+				{endpoint: EndpointServices, req: newRequest("2.2.2.2", "key1"), want: true},
+				{endpoint: EndpointServices, req: newRequest("3.3.3.3", "key1"), want: false},
+				{endpoint: EndpointServices, req: newRequest("1.1.1.1", "key2"), want: true},
+			},
+		},
+		{
+			name: "request is rejected if any of the policies is exhausted",
+			policies: []*config.RateLimit{
+				{ID: "p1", KeyType: KeyTypeIP, Requests: 10, Interval: 1},
+				{ID: "p2", Endpoints: []string{EndpointCrud}, KeyType: KeyTypeIP, Requests: 1, Interval: 60},
+			},
+			requests: []request{
+				{endpoint: EndpointCrud, req: newRequest("1.1.1.1", ""), want: true},
+				{endpoint: EndpointCrud, req: newRequest("1.1.1.1", ""), want: false},
+				{endpoint: EndpointGraphQL, req: newRequest("1.1.1.1", ""), want: true},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := New()
+			if err := l.SetProjectPolicies("project", tt.policies); err != nil {
+				t.Fatalf("SetProjectPolicies() error = %v", err)
+			}
+			for i, r := range tt.requests {
+				got, retryAfter := l.Allow(context.Background(), "project", r.endpoint, r.req, r.getClaims)
+				if got != r.want {
+					t.Errorf("Allow() request %d got = %v, want %v", i, got, r.want)
+				}
+				if !got && retryAfter <= 0 {
+					t.Errorf("Allow() request %d retry after must be positive for a rejected request", i)
+				}
+			}
+		})
+	}
+}
+
+func TestRateLimiter_AllowRoute(t *testing.T) {
+	l := New()
+	if err := l.SetProjectPolicies("project", []*config.RateLimit{{ID: "p1", Endpoints: []string{EndpointIngress}, KeyType: KeyTypeIP, Requests: 1, Interval: 60}}); err != nil {
+		t.Fatalf("SetProjectPolicies() error = %v", err)
+	}
+
+	route1 := &config.Route{ID: "route1", Project: "project"}
+	route2 := &config.Route{ID: "route2", Project: "project", RateLimit: &config.RateLimit{KeyType: KeyTypeIP, Requests: 2, Interval: 60}}
+
+	ctx := context.Background()
+	if got, _ := l.AllowRoute(ctx, route1, newRequest("1.1.1.1", ""), nil); !got {
+		t.Error("AllowRoute() first request of project policy must be allowed")
+	}
+	if got, _ := l.AllowRoute(ctx, route1, newRequest("1.1.1.1", ""), nil); got {
+		t.Error("AllowRoute() second request of project policy must be rejected")
+	}
+	// The rate limit of the route overrides the policies of the project
+	for i := 0; i < 2; i++ {
+		if got, _ := l.AllowRoute(ctx, route2, newRequest("1.1.1.1", ""), nil); !got {
+			t.Errorf("AllowRoute() request %d of route policy must be allowed", i)
+		}
+	}
+	if got, _ := l.AllowRoute(ctx, route2, newRequest("1.1.1.1", ""), nil); got {
+		t.Error("AllowRoute() third request of route policy must be rejected")
+	}
+}
+
+func TestRateLimiter_caching(t *testing.T) {
+	policies := []*config.RateLimit{{ID: "p1", KeyType: KeyTypeIP, Requests: 1, Interval: 60}}
+	tests := []struct {
+		name           string
+		caching        *mockCaching
+		want           bool
+		wantRetryAfter time.Duration
+		wantKeys       []string
+	}{
+		{
+			name:           "buckets are stored in redis when caching is enabled",
+			caching:        &mockCaching{isEnabled: true},
+			want:           false,
+			wantRetryAfter: 2 * time.Second,
+			wantKeys:       []string{"project::p1::ip::1.1.1.1"},
+		},
+		{
+			name:     "memory is used when caching is disabled",
+			caching:  &mockCaching{},
+			want:     true,
+			wantKeys: nil,
+		},
+		{
+			name:     "memory is used when redis returns an error",
+			caching:  &mockCaching{isEnabled: true, err: errors.New("connection refused")},
+			want:     true,
+			wantKeys: []string{"project::p1::ip::1.1.1.1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := New()
+			l.SetCachingModule(tt.caching)
+			_ = l.SetProjectPolicies("project", policies)
+
+			got, retryAfter := l.Allow(context.Background(), "project", EndpointCrud, newRequest("1.1.1.1", ""), nil)
+			if got != tt.want || retryAfter != tt.wantRetryAfter {
+				t.Errorf("Allow() got = %v %v, want %v %v", got, retryAfter, tt.want, tt.wantRetryAfter)
+			}
+			if len(tt.caching.keys) != len(tt.wantKeys) || (len(tt.wantKeys) > 0 && tt.caching.keys[0] != tt.wantKeys[0]) {
+				t.Errorf("Allow() redis keys = %v, want %v", tt.caching.keys, tt.wantKeys)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/spaceuptech/space-cloud/gateway/config"
+)
+
+const (
+	// EndpointCrud is the endpoint of the crud apis
+	EndpointCrud = "crud"
+	// EndpointGraphQL is the endpoint of the graphql api
+	EndpointGraphQL = "graphql"
+	// EndpointServices is the endpoint of the remote services api
+	EndpointServices = "services"
+	// EndpointFiles is the endpoint of the file storage apis
+	EndpointFiles = "files"
+	// EndpointIngress is the endpoint of the ingress routes
+	EndpointIngress = "ingress"
+
+	// KeyTypeIP identifies the client by its ip address
+	KeyTypeIP = "ip"
+	// KeyTypeClaim identifies the client by a field of its jwt claims
+	KeyTypeClaim = "claim"
+	// KeyTypeAPIKey identifies the client by its api key
+	KeyTypeAPIKey = "apiKey"
+
+	defaultAPIKeyHeader = "X-API-Key"
+)
+
+// RateLimiter enforces the rate limit policies of all projects. Buckets are stored in redis if caching
+// is enabled so that they are shared across gateway replicas, else they are stored in memory
+type RateLimiter struct {
+	lock sync.RWMutex
+
+	policies map[string][]*config.RateLimit // key is the project id
+	caching  cachingInterface
+	memory   *memoryStore
+}
+
+type cachingInterface interface {
+	TakeRateLimitToken(ctx context.Context, key string, rate float64, burst int64) (bool, time.Duration, bool, error)
+}
+
+// ClaimsFunc returns the claims of the token of a request
+type ClaimsFunc func() (map[string]interface{}, error)
+
+// New creates a new instance of the rate limiter
+func New() *RateLimiter {
+	return &RateLimiter{policies: map[string][]*config.RateLimit{}, memory: newMemoryStore()}
+}
+
+// SetCachingModule sets the caching module
+func (l *RateLimiter) SetCachingModule(c cachingInterface) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	l.caching = c
+}
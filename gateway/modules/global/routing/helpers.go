@@ -3,6 +3,8 @@ package routing
 import (
 	"context"
 	"fmt"
+	"net/url"
+	"strings"
 	"text/template"
 
 	"github.com/spaceuptech/helpers"
@@ -30,14 +32,15 @@ func getGoTemplateKey(kind, project, id string) string {
 	return fmt.Sprintf("%s---%s---%s", project, id, kind)
 }
 
-func (r *Routing) adjustBody(ctx context.Context, kind, project, token string, route *config.Route, auth, params interface{}) (interface{}, error) {
+func (r *Routing) adjustBody(ctx context.Context, kind, project, token string, route *config.Route, auth, params interface{}, pathParams map[string]interface{}) (interface{}, error) {
 	var req interface{}
 	var err error
 
 	switch route.Modify.Tmpl {
 	case config.TemplatingEngineGo:
 		if tmpl, p := r.goTemplates[getGoTemplateKey(kind, project, route.ID)]; p {
-			req, err = tmpl2.GoTemplate(ctx, tmpl, route.Modify.OpFormat, token, auth, params)
+			object := map[string]interface{}{"args": params, "auth": auth, "token": token, "pathParams": pathParams}
+			req, err = tmpl2.GoTemplateWithObject(ctx, tmpl, route.Modify.OpFormat, object)
 			if err != nil {
 				return nil, err
 			}
@@ -52,3 +55,25 @@ func (r *Routing) adjustBody(ctx context.Context, kind, project, token string, r
 	}
 	return req, nil
 }
+
+// escapePathParam escapes a path parameter substituted in the rewrite url. Slashes matched by wildcard
+// and regex parameters are kept as they separate the segments of the path
+func escapePathParam(value string) string {
+	segments := strings.Split(value, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+// setURLPath sets the path of the url. The escaped form of the path is kept as the raw path
+// so that the escaped path parameters are forwarded as they are
+func setURLPath(u *url.URL, path string, isEscaped bool) {
+	u.Path, u.RawPath = path, ""
+	if !isEscaped {
+		return
+	}
+	if unescaped, err := url.PathUnescape(path); err == nil && unescaped != path {
+		u.Path, u.RawPath = unescaped, path
+	}
+}
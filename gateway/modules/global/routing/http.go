@@ -16,7 +16,9 @@ import (
 	"github.com/spaceuptech/space-cloud/gateway/config"
 	"github.com/spaceuptech/space-cloud/gateway/model"
 	"github.com/spaceuptech/space-cloud/gateway/modules/auth"
+	"github.com/spaceuptech/space-cloud/gateway/modules/global/ratelimit"
 	"github.com/spaceuptech/space-cloud/gateway/utils"
+	"github.com/spaceuptech/space-cloud/gateway/utils/tracing"
 )
 
 type modulesInterface interface {
@@ -39,14 +41,31 @@ func (r *Routing) HandleRoutes(modules modulesInterface) http.HandlerFunc {
 		host, url := getHostAndURL(request)
 
 		// Select a route based on host and url
-		route, err := r.selectRoute(request.Context(), host, request.Method, url)
+		route, pathParams, err := r.selectRoute(request.Context(), host, request.Method, url)
 		if err != nil {
 			writer.WriteHeader(http.StatusBadRequest)
 			_ = json.NewEncoder(writer).Encode(map[string]string{"error": err.Error()})
 			return
 		}
 
-		token, claims, status, err := r.modifyRequest(request.Context(), modules, route, request)
+		// Reject the request if the client has exhausted the rate limit of the route
+		if r.rateLimiter != nil {
+			allowed, retryAfter := r.rateLimiter.AllowRoute(request.Context(), route, request, func() (map[string]interface{}, error) {
+				auth, err := modules.Auth(route.Project)
+				if err != nil {
+					return nil, err
+				}
+				return auth.ParseToken(request.Context(), utils.GetTokenFromHeader(request))
+			})
+			if !allowed {
+				ratelimit.SetRetryAfterHeader(writer, retryAfter)
+				writer.WriteHeader(http.StatusTooManyRequests)
+				_ = json.NewEncoder(writer).Encode(map[string]string{"error": ratelimit.ErrRateLimitExceeded.Error()})
+				return
+			}
+		}
+
+		token, claims, status, err := r.modifyRequest(request.Context(), modules, route, request, pathParams)
 		if err != nil {
 			writer.WriteHeader(status)
 			_ = json.NewEncoder(writer).Encode(map[string]string{"error": err.Error()})
@@ -57,7 +76,7 @@ func (r *Routing) HandleRoutes(modules modulesInterface) http.HandlerFunc {
 
 		// Apply the rewrite url if provided. It is the users responsibility to make sure both url
 		// and rewrite url starts with a '/'
-		url = rewriteURL(url, route)
+		url = rewriteURL(url, route, pathParams)
 
 		// Proxy the request
 
@@ -100,8 +119,19 @@ func (r *Routing) HandleRoutes(modules modulesInterface) http.HandlerFunc {
 			redisKey = key
 		}
 
+		// Trace the call to the target and propagate the trace context to it
+		ctx, span := tracing.StartSpan(request.Context(), "ingress.proxy "+route.ID, tracing.SpanKindClient,
+			tracing.String("ingress.route.id", route.ID), tracing.String("ingress.project", route.Project),
+			tracing.String("http.method", request.Method), tracing.String("http.url", request.URL.String()))
+		tracing.Inject(ctx, request.Header)
+
 		// TODO: Use http2 client if that was the incoming request protocol
 		response, err := httpClient.Do(request)
+		if response != nil {
+			span.SetAttributes(tracing.Int64("http.status_code", int64(response.StatusCode)))
+		}
+		span.RecordError(err)
+		span.End()
 		if err != nil {
 			writer.WriteHeader(http.StatusInternalServerError)
 			_ = json.NewEncoder(writer).Encode(map[string]string{"error": err.Error()})
@@ -110,7 +140,7 @@ func (r *Routing) HandleRoutes(modules modulesInterface) http.HandlerFunc {
 		}
 		defer utils.CloseTheCloser(response.Body)
 
-		if err := r.modifyResponse(request.Context(), response, route, token, claims); err != nil {
+		if err := r.modifyResponse(request.Context(), response, route, token, claims, pathParams); err != nil {
 			writer.WriteHeader(http.StatusInternalServerError)
 			_ = json.NewEncoder(writer).Encode(map[string]string{"error": err.Error()})
 			return
@@ -169,8 +199,20 @@ func getHostAndURL(request *http.Request) (string, string) {
 	return strings.Split(request.Host, ":")[0], request.URL.Path
 }
 
-func rewriteURL(url string, route *config.Route) string {
+func rewriteURL(url string, route *config.Route, pathParams map[string]interface{}) string {
 	if route.Source.RewriteURL != "" {
+		// Path and regex routes always match the complete url. Hence the rewrite url
+		// replaces the entire url after substituting the path parameters
+		if route.Source.Type == config.RoutePath || route.Source.Type == config.RouteRegex {
+			return pathParamRegex.ReplaceAllStringFunc(route.Source.RewriteURL, func(s string) string {
+				name := pathParamRegex.FindStringSubmatch(s)[1]
+				if value, p := pathParams[name]; p {
+					return escapePathParam(fmt.Sprintf("%v", value))
+				}
+				return s
+			})
+		}
+
 		// First strip away the url provided
 		url = strings.TrimPrefix(url, route.Source.URL)
 
@@ -193,7 +235,9 @@ func setRequest(ctx context.Context, request *http.Request, route *config.Route,
 
 	request.Host = target.Host
 	request.URL.Host = fmt.Sprintf("%s:%d", target.Host, target.Port)
-	request.URL.Path = url
+	// The path parameters substituted in the rewrite url of path and regex routes are escaped
+	isEscaped := route.Source.RewriteURL != "" && (route.Source.Type == config.RoutePath || route.Source.Type == config.RouteRegex)
+	setURLPath(request.URL, url, isEscaped)
 
 	// Set the url scheme to http
 	if target.Scheme == "" {
@@ -224,3 +268,19 @@ func prepareHeaders(headers config.Headers, state map[string]interface{}) config
 	}
 	return out
 }
+
+// MatchRoute returns the ingress route which would serve the request. The method requested by a cors preflight
+// request is used to select the route in place of OPTIONS
+func (r *Routing) MatchRoute(request *http.Request) (*config.Route, bool) {
+	method := request.Method
+	if m := request.Header.Get("Access-Control-Request-Method"); method == http.MethodOptions && m != "" {
+		method = m
+	}
+
+	host, url := getHostAndURL(request)
+	route, _, err := r.selectRoute(request.Context(), host, method, url)
+	if err != nil {
+		return nil, false
+	}
+	return route, true
+}
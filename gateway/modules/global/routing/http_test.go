@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"log"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"reflect"
 	"testing"
@@ -52,8 +53,9 @@ func Test_getHostAndURL(t *testing.T) {
 
 func Test_rewriteURL(t *testing.T) {
 	type args struct {
-		url   string
-		route *config.Route
+		url        string
+		route      *config.Route
+		pathParams map[string]interface{}
 	}
 	tests := []struct {
 		name string
@@ -74,10 +76,58 @@ func Test_rewriteURL(t *testing.T) {
 			},
 			want: "/v1/abc/xyz",
 		},
+		{
+			name: "rewrite url with path parameters",
+			args: args{
+				url: "/users/1/orders/abc",
+				route: &config.Route{
+					ID: "1234",
+					Source: config.RouteSource{
+						URL:        "/users/{id}/orders/{orderId}",
+						RewriteURL: "/v1/orders/{orderId}?user={id}&missing={missing}",
+						Type:       config.RoutePath,
+					},
+				},
+				pathParams: map[string]interface{}{"id": "1", "orderId": "abc"},
+			},
+			want: "/v1/orders/abc?user=1&missing={missing}",
+		},
+		{
+			name: "rewrite url with regex parameters",
+			args: args{
+				url: "/v2/items/abc",
+				route: &config.Route{
+					ID: "1234",
+					Source: config.RouteSource{
+						URL:        `/v(?P<version>\d+)/(?P<rest>.*)`,
+						RewriteURL: "/api/{rest}",
+						Type:       config.RouteRegex,
+					},
+				},
+				pathParams: map[string]interface{}{"version": "2", "rest": "items/abc"},
+			},
+			want: "/api/items/abc",
+		},
+		{
+			name: "path parameters are escaped when substituted",
+			args: args{
+				url: "/files/my docs/a?b#c%d",
+				route: &config.Route{
+					ID: "1234",
+					Source: config.RouteSource{
+						URL:        "/files/{dir}/{rest*}",
+						RewriteURL: "/v1/{dir}/{rest}",
+						Type:       config.RoutePath,
+					},
+				},
+				pathParams: map[string]interface{}{"dir": "my docs", "rest": "a?b/c#d%e"},
+			},
+			want: "/v1/my%20docs/a%3Fb/c%23d%25e",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := rewriteURL(tt.args.url, tt.args.route); got != tt.want {
+			if got := rewriteURL(tt.args.url, tt.args.route, tt.args.pathParams); got != tt.want {
 				t.Errorf("rewriteURL() = %v, want %v", got, tt.want)
 			}
 		})
@@ -119,6 +169,36 @@ func Test_setRequest(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "set request with escaped path parameters",
+			args: args{
+				url: "/v1/my%20docs/a%3Fb",
+				route: &config.Route{
+					Source: config.RouteSource{
+						URL:        "/files/{dir}/{rest*}",
+						RewriteURL: "/v1/{dir}/{rest}",
+						Type:       config.RoutePath,
+					},
+					Targets: []config.RouteTarget{{
+						Host:   "spacecloud.com",
+						Port:   8080,
+						Weight: 100,
+					}},
+				},
+				request: &http.Request{
+					URL: &url.URL{},
+				},
+			},
+			want: &http.Request{
+				Host: "spacecloud.com",
+				URL: &url.URL{
+					Host:    "spacecloud.com:8080",
+					Path:    "/v1/my docs/a?b",
+					RawPath: "/v1/my%20docs/a%3Fb",
+					Scheme:  "http",
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -136,3 +216,35 @@ func Test_setRequest(t *testing.T) {
 		})
 	}
 }
+
+func TestRouting_MatchRoute(t *testing.T) {
+	route := &config.Route{ID: "1", Project: "myproject", Source: config.RouteSource{Hosts: []string{"*"}, Methods: []string{http.MethodPut}, URL: "/api", Type: config.RoutePrefix}}
+
+	tests := []struct {
+		name    string
+		method  string
+		headers map[string]string
+		url     string
+		want    *config.Route
+	}{
+		{name: "request matches the route", method: http.MethodPut, url: "/api/users", want: route},
+		{name: "method of the request doesn't match", method: http.MethodGet, url: "/api/users"},
+		{name: "preflight request matches on the requested method", method: http.MethodOptions, headers: map[string]string{"Access-Control-Request-Method": http.MethodPut}, url: "/api/users", want: route},
+		{name: "url doesn't match", method: http.MethodPut, url: "/users"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := New()
+			r.routes = config.Routes{route}
+
+			req := httptest.NewRequest(tt.method, "http://localhost"+tt.url, nil)
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+			got, ok := r.MatchRoute(req)
+			if ok != (tt.want != nil) || got != tt.want {
+				t.Errorf("MatchRoute() = (%v, %v), want %v", got, ok, tt.want)
+			}
+		})
+	}
+}
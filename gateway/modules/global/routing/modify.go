@@ -15,7 +15,7 @@ import (
 	"github.com/spaceuptech/space-cloud/gateway/utils"
 )
 
-func (r *Routing) modifyRequest(ctx context.Context, modules modulesInterface, route *config.Route, req *http.Request) (string, interface{}, int, error) {
+func (r *Routing) modifyRequest(ctx context.Context, modules modulesInterface, route *config.Route, req *http.Request, pathParams map[string]interface{}) (string, interface{}, int, error) {
 	// Extract the token
 	token := utils.GetTokenFromHeader(req)
 
@@ -41,21 +41,21 @@ func (r *Routing) modifyRequest(ctx context.Context, modules modulesInterface, r
 		return "", nil, http.StatusBadRequest, err
 	}
 
-	args := map[string]interface{}{"params": params, "query": makeQueryArguments(req)}
+	args := map[string]interface{}{"params": params, "query": makeQueryArguments(req), "pathParams": pathParams}
 	auth, err := a.AuthorizeRequest(ctx, route.Rule, route.Project, token, args)
 	if err != nil {
 		return "", nil, http.StatusForbidden, err
 	}
 
 	// Set the headers
-	state := map[string]interface{}{"args": params, "auth": auth}
+	state := map[string]interface{}{"args": params, "auth": auth, "pathParams": pathParams}
 	headers := append(r.globalConfig.RequestHeaders, route.Modify.RequestHeaders...)
 	prepareHeaders(headers, state).UpdateHeader(req.Header)
 
 	// Don't forget to reset the body
 	if params != nil {
 		// Generate new request body if template was provided
-		newParams, err := r.adjustBody(ctx, "request", route.Project, token, route, auth, params)
+		newParams, err := r.adjustBody(ctx, "request", route.Project, token, route, auth, params, pathParams)
 		if err != nil {
 			return "", nil, http.StatusBadRequest, err
 		}
@@ -70,7 +70,7 @@ func (r *Routing) modifyRequest(ctx context.Context, modules modulesInterface, r
 	return token, auth, http.StatusOK, err
 }
 
-func (r *Routing) modifyResponse(ctx context.Context, res *http.Response, route *config.Route, token string, auth interface{}) error {
+func (r *Routing) modifyResponse(ctx context.Context, res *http.Response, route *config.Route, token string, auth interface{}, pathParams map[string]interface{}) error {
 	// Extract the params only if content-type is `application/json` and a response template is provided
 	var params interface{}
 	var data []byte
@@ -89,13 +89,13 @@ func (r *Routing) modifyResponse(ctx context.Context, res *http.Response, route
 	}
 
 	// Set the headers
-	state := map[string]interface{}{"args": params, "auth": auth}
+	state := map[string]interface{}{"args": params, "auth": auth, "pathParams": pathParams}
 	headers := append(r.globalConfig.ResponseHeaders, route.Modify.ResponseHeaders...)
 	prepareHeaders(headers, state).UpdateHeader(res.Header)
 
 	// If params is not nil we need to template the response
 	if params != nil {
-		newParams, err := r.adjustBody(ctx, "response", route.Project, token, route, auth, params)
+		newParams, err := r.adjustBody(ctx, "response", route.Project, token, route, auth, params, pathParams)
 		if err != nil {
 			return err
 		}
@@ -21,6 +21,7 @@ func TestRouting_modifyResponse(t *testing.T) {
 		globalHeaders config.Headers
 		routeHeaders  config.Headers
 		auth          interface{}
+		pathParams    map[string]interface{}
 	}
 	tests := []struct {
 		name        string
@@ -79,6 +80,28 @@ func TestRouting_modifyResponse(t *testing.T) {
 			want:        `{"res":{"abc":"xyz"}}`,
 			wantHeaders: map[string][]string{"Content-Type": {"application/json"}, "Content-Length": {"21"}},
 		},
+		{
+			name: "mutate body with path params",
+			args: args{
+				res:        `{"abc":"xyz"}`,
+				headers:    map[string][]string{"Content-Type": {"application/json"}},
+				resTmpl:    `{"id": "{{.pathParams.id}}", "res": {{marshalJSON .args}}}`,
+				pathParams: map[string]interface{}{"id": "1"},
+			},
+			want:        `{"id":"1","res":{"abc":"xyz"}}`,
+			wantHeaders: map[string][]string{"Content-Type": {"application/json"}, "Content-Length": {"30"}},
+		},
+		{
+			name: "set headers from path params",
+			args: args{
+				res:          `{"abc": "xyz"}`,
+				headers:      map[string][]string{},
+				routeHeaders: config.Headers{{Key: "id", Value: "pathParams.id"}},
+				pathParams:   map[string]interface{}{"id": "1"},
+			},
+			want:        `{"abc": "xyz"}`,
+			wantHeaders: map[string][]string{"Id": {"1"}},
+		},
 		{
 			name: "mutate body - invalid res payload",
 			args: args{
@@ -126,7 +149,7 @@ func TestRouting_modifyResponse(t *testing.T) {
 			// Make an instance of the response object
 			res := &http.Response{Body: ioutil.NopCloser(bytes.NewBuffer([]byte(tt.args.res))), Header: tt.args.headers}
 
-			if err := r.modifyResponse(context.Background(), res, route, "", tt.args.auth); (err != nil) != tt.wantErr {
+			if err := r.modifyResponse(context.Background(), res, route, "", tt.args.auth, tt.args.pathParams); (err != nil) != tt.wantErr {
 				t.Errorf("modifyResponse() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
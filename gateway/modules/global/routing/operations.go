@@ -1,8 +1,13 @@
 package routing
 
 import (
+	"context"
+	"fmt"
+	"regexp"
 	"strings"
 
+	"github.com/spaceuptech/helpers"
+
 	"github.com/spaceuptech/space-cloud/gateway/config"
 )
 
@@ -22,11 +27,21 @@ func (r *Routing) SetProjectRoutes(project string, routesConfig config.IngressRo
 		}
 	}
 
-	// Add projects to the routes object and generate go templates
+	// Add projects to the routes object, compile the urls and generate go templates
+	compiledURLs := map[string]*regexp.Regexp{}
 	for _, route := range routes {
 		route.Project = project
 		route.Modify.Tmpl = config.TemplatingEngineGo
 
+		// Compile the url of path and regex routes
+		if route.Source.Type == config.RoutePath || route.Source.Type == config.RouteRegex {
+			re, err := compileRouteURL(route.Source.Type, route.Source.URL)
+			if err != nil {
+				return helpers.Logger.LogError(helpers.GetRequestID(context.TODO()), fmt.Sprintf("Invalid url (%s) provided for route (%s)", route.Source.URL, route.ID), err, nil)
+			}
+			compiledURLs[route.ID] = re
+		}
+
 		// Parse request template
 		if route.Modify.ReqTmpl != "" {
 			if err := r.createGoTemplate("request", project, route.ID, route.Modify.ReqTmpl); err != nil {
@@ -42,7 +57,15 @@ func (r *Routing) SetProjectRoutes(project string, routesConfig config.IngressRo
 		}
 	}
 
+	// Update the cors policies of the routes
+	if r.cors != nil {
+		if err := r.cors.SetProjectRoutes(project, routes); err != nil {
+			return err
+		}
+	}
+
 	r.addProjectRoutes(project, routes)
+	r.compiledURLs[project] = compiledURLs
 	return nil
 }
 
@@ -52,6 +75,7 @@ func (r *Routing) DeleteProjectRoutes(project string) {
 	defer r.lock.Unlock()
 
 	r.deleteProjectRoutes(project)
+	delete(r.compiledURLs, project)
 }
 
 // SetGlobalConfig sets the project level config of the routing module
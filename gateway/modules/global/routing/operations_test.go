@@ -3,11 +3,14 @@ package routing
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"log"
 	"reflect"
+	"regexp"
 	"testing"
 
 	"github.com/spaceuptech/helpers"
+	"github.com/stretchr/testify/mock"
 
 	"github.com/spaceuptech/space-cloud/gateway/config"
 )
@@ -113,7 +116,8 @@ func TestRouting_SetProjectRoutes(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			r := &Routing{
-				routes: tt.fields.routes,
+				routes:       tt.fields.routes,
+				compiledURLs: map[string]map[string]*regexp.Regexp{},
 			}
 			_ = r.SetProjectRoutes(tt.args.project, tt.args.routes)
 			if !reflect.DeepEqual(tt.fields.routes, tt.want) {
@@ -128,3 +132,75 @@ func TestRouting_SetProjectRoutes(t *testing.T) {
 		})
 	}
 }
+
+type mockCors struct {
+	mock.Mock
+}
+
+func (m *mockCors) SetProjectRoutes(projectID string, routes config.Routes) error {
+	return m.Called(projectID, routes).Error(0)
+}
+
+func TestRouting_SetProjectRoutes_cors(t *testing.T) {
+	route := &config.Route{ID: "12345", Source: config.RouteSource{URL: "/api", Type: config.RoutePrefix}, Cors: &config.Cors{}}
+	tests := []struct {
+		name       string
+		corsErr    error
+		wantErr    bool
+		wantRoutes int
+	}{
+		{
+			name:       "routes are added once the cors policies of the routes are set",
+			wantRoutes: 1,
+		},
+		{
+			name:       "routes with an invalid cors policy are rejected",
+			corsErr:    errors.New("at least one allowed origin must be provided"),
+			wantErr:    true,
+			wantRoutes: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := new(mockCors)
+			c.On("SetProjectRoutes", "myproject", config.Routes{route}).Return(tt.corsErr)
+
+			r := New()
+			r.SetCorsModule(c)
+			if err := r.SetProjectRoutes("myproject", config.IngressRoutes{"12345": route}); (err != nil) != tt.wantErr {
+				t.Errorf("SetProjectRoutes() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(r.routes) != tt.wantRoutes {
+				t.Errorf("SetProjectRoutes() routes = %d, want %d", len(r.routes), tt.wantRoutes)
+			}
+			c.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRouting_SetProjectRoutes_compiledURLs(t *testing.T) {
+	users := &config.Route{ID: "users", Source: config.RouteSource{URL: "/users/{id}", Type: config.RoutePath}}
+	orders := &config.Route{ID: "orders", Source: config.RouteSource{URL: "/orders/{id}", Type: config.RoutePath}}
+	prefix := &config.Route{ID: "prefix", Source: config.RouteSource{URL: "/api", Type: config.RoutePrefix}}
+
+	r := New()
+	if err := r.SetProjectRoutes("myproject", config.IngressRoutes{"users": users, "prefix": prefix}); err != nil {
+		t.Fatalf("SetProjectRoutes() error = %v", err)
+	}
+	if got := r.compiledURLs["myproject"]; len(got) != 1 || got["users"] == nil {
+		t.Errorf("SetProjectRoutes() compiled urls = %v, want only the url of the path route", got)
+	}
+
+	// The urls of routes which have been removed are dropped
+	if err := r.SetProjectRoutes("myproject", config.IngressRoutes{"orders": orders}); err != nil {
+		t.Fatalf("SetProjectRoutes() error = %v", err)
+	}
+	if got := r.compiledURLs["myproject"]; len(got) != 1 || got["orders"] == nil {
+		t.Errorf("SetProjectRoutes() compiled urls = %v, want only the url of the updated routes", got)
+	}
+
+	r.DeleteProjectRoutes("myproject")
+	if _, ok := r.compiledURLs["myproject"]; ok {
+		t.Error("DeleteProjectRoutes() compiled urls of the project were not dropped")
+	}
+}
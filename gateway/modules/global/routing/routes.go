@@ -3,6 +3,7 @@ package routing
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"sort"
 	"strings"
 
@@ -12,6 +13,9 @@ import (
 	"github.com/spaceuptech/space-cloud/gateway/utils"
 )
 
+// pathParamRegex matches the parameters of a path template like `{id}` or `{rest*}`
+var pathParamRegex = regexp.MustCompile(`\{([a-zA-Z_][a-zA-Z0-9_]*)(\*?)\}`)
+
 func (r *Routing) addProjectRoutes(project string, routes config.Routes) {
 	r.deleteProjectRoutes(project)
 	r.routes = append(r.routes, routes...)
@@ -28,7 +32,7 @@ func (r *Routing) deleteProjectRoutes(project string) {
 	r.routes = newRoutes
 }
 
-func (r *Routing) selectRoute(ctx context.Context, host, method, url string) (*config.Route, error) {
+func (r *Routing) selectRoute(ctx context.Context, host, method, url string) (*config.Route, map[string]interface{}, error) {
 	r.lock.RLock()
 	defer r.lock.RUnlock()
 
@@ -44,20 +48,93 @@ func (r *Routing) selectRoute(ctx context.Context, host, method, url string) (*c
 			continue
 		}
 
-		// TODO: add support for path parameters in routes
 		switch route.Source.Type {
 		case config.RoutePrefix:
 			if strings.HasPrefix(url, route.Source.URL) {
-				return route, nil
+				return route, nil, nil
 			}
 		case config.RouteExact:
 			if url == route.Source.URL {
-				return route, nil
+				return route, nil, nil
+			}
+		case config.RoutePath, config.RouteRegex:
+			re, err := r.getRouteURL(route)
+			if err != nil {
+				return nil, nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Invalid url (%s) provided for route (%s)", route.Source.URL, route.ID), err, nil)
+			}
+			if params, ok := matchRouteURL(re, url); ok {
+				return route, params, nil
 			}
 		default:
-			return nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Invalid type (%s) provided for url matching", route.Source.Type), nil, nil)
+			return nil, nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Invalid type (%s) provided for url matching", route.Source.Type), nil, nil)
+		}
+	}
+
+	return nil, nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Route not found for provided host (%s), method (%s) and url (%s)", host, method, url), nil, nil)
+}
+
+// getRouteURL returns the regular expression compiled for the url of a path or regex route when its project's
+// routes were set. The url is compiled again if the route wasn't set that way
+func (r *Routing) getRouteURL(route *config.Route) (*regexp.Regexp, error) {
+	if re, p := r.compiledURLs[route.Project][route.ID]; p {
+		return re, nil
+	}
+	return compileRouteURL(route.Source.Type, route.Source.URL)
+}
+
+// compileRouteURL generates the regular expression used to match urls for path and regex routes
+func compileRouteURL(urlType config.RouteURLType, url string) (*regexp.Regexp, error) {
+	var pattern string
+	switch urlType {
+	case config.RoutePath:
+		pattern = pathTemplateToRegex(url)
+	case config.RouteRegex:
+		pattern = "^(?:" + url + ")$"
+	default:
+		return nil, fmt.Errorf("url type (%s) does not support parameters", urlType)
+	}
+
+	return regexp.Compile(pattern)
+}
+
+// pathTemplateToRegex converts a path template like `/users/{id}` into a regular expression with named groups
+func pathTemplateToRegex(url string) string {
+	var b strings.Builder
+	b.WriteString("^")
+
+	last := 0
+	for _, match := range pathParamRegex.FindAllStringSubmatchIndex(url, -1) {
+		// Everything between two parameters needs to be matched literally
+		b.WriteString(regexp.QuoteMeta(url[last:match[0]]))
+
+		name := url[match[2]:match[3]]
+		if match[5] > match[4] {
+			// Parameters ending with `*` match the rest of the url
+			b.WriteString("(?P<" + name + ">.*)")
+		} else {
+			b.WriteString("(?P<" + name + ">[^/]+)")
 		}
+		last = match[1]
+	}
+	b.WriteString(regexp.QuoteMeta(url[last:]))
+	b.WriteString("$")
+
+	return b.String()
+}
+
+// matchRouteURL matches the url against the regular expression and returns the named groups as path parameters
+func matchRouteURL(re *regexp.Regexp, url string) (map[string]interface{}, bool) {
+	matches := re.FindStringSubmatch(url)
+	if matches == nil {
+		return nil, false
 	}
 
-	return nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Route not found for provided host (%s), method (%s) and url (%s)", host, method, url), nil, nil)
+	params := make(map[string]interface{}, len(matches))
+	for i, name := range re.SubexpNames() {
+		if i == 0 || name == "" {
+			continue
+		}
+		params[name] = matches[i]
+	}
+	return params, true
 }
@@ -18,11 +18,12 @@ func Test_routeMapping_selectRoute(t *testing.T) {
 		url    string
 	}
 	tests := []struct {
-		name    string
-		r       config.Routes
-		args    args
-		want    *config.Route
-		wantErr bool
+		name       string
+		r          config.Routes
+		args       args
+		want       *config.Route
+		wantParams map[string]interface{}
+		wantErr    bool
 	}{
 		// TODO: Add test cases.
 		{
@@ -344,13 +345,140 @@ func Test_routeMapping_selectRoute(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "path template match",
+			r: config.Routes{
+				&config.Route{
+					ID:      "1234",
+					Project: "test",
+					Source: config.RouteSource{
+						Hosts: []string{"spaceuptech.com"},
+						URL:   "/users/{id}/orders/{orderId}",
+						Type:  config.RoutePath,
+					},
+				},
+			},
+			args: args{
+				host: "spaceuptech.com",
+				url:  "/users/1/orders/abc",
+			},
+			want: &config.Route{
+				ID:      "1234",
+				Project: "test",
+				Source: config.RouteSource{
+					Hosts: []string{"spaceuptech.com"},
+					URL:   "/users/{id}/orders/{orderId}",
+					Type:  config.RoutePath,
+				},
+			},
+			wantParams: map[string]interface{}{"id": "1", "orderId": "abc"},
+			wantErr:    false,
+		},
+		{
+			name: "path template does not match extra segments",
+			r: config.Routes{
+				&config.Route{
+					ID:      "1234",
+					Project: "test",
+					Source: config.RouteSource{
+						Hosts: []string{"spaceuptech.com"},
+						URL:   "/users/{id}",
+						Type:  config.RoutePath,
+					},
+				},
+			},
+			args: args{
+				host: "spaceuptech.com",
+				url:  "/users/1/orders",
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "path template with catch all parameter",
+			r: config.Routes{
+				&config.Route{
+					ID:      "1234",
+					Project: "test",
+					Source: config.RouteSource{
+						Hosts: []string{"spaceuptech.com"},
+						URL:   "/users/{id}/{rest*}",
+						Type:  config.RoutePath,
+					},
+				},
+			},
+			args: args{
+				host: "spaceuptech.com",
+				url:  "/users/1/orders/abc",
+			},
+			want: &config.Route{
+				ID:      "1234",
+				Project: "test",
+				Source: config.RouteSource{
+					Hosts: []string{"spaceuptech.com"},
+					URL:   "/users/{id}/{rest*}",
+					Type:  config.RoutePath,
+				},
+			},
+			wantParams: map[string]interface{}{"id": "1", "rest": "orders/abc"},
+			wantErr:    false,
+		},
+		{
+			name: "regex match with named groups",
+			r: config.Routes{
+				&config.Route{
+					ID:      "1234",
+					Project: "test",
+					Source: config.RouteSource{
+						Hosts: []string{"*"},
+						URL:   `/v(?P<version>\d+)/items/(?P<id>[a-z]+)`,
+						Type:  config.RouteRegex,
+					},
+				},
+			},
+			args: args{
+				host: "spaceuptech.com",
+				url:  "/v2/items/abc",
+			},
+			want: &config.Route{
+				ID:      "1234",
+				Project: "test",
+				Source: config.RouteSource{
+					Hosts: []string{"*"},
+					URL:   `/v(?P<version>\d+)/items/(?P<id>[a-z]+)`,
+					Type:  config.RouteRegex,
+				},
+			},
+			wantParams: map[string]interface{}{"version": "2", "id": "abc"},
+			wantErr:    false,
+		},
+		{
+			name: "regex does not match partially",
+			r: config.Routes{
+				&config.Route{
+					ID:      "1234",
+					Project: "test",
+					Source: config.RouteSource{
+						Hosts: []string{"*"},
+						URL:   `/v(?P<version>\d+)`,
+						Type:  config.RouteRegex,
+					},
+				},
+			},
+			args: args{
+				host: "spaceuptech.com",
+				url:  "/v2/items",
+			},
+			want:    nil,
+			wantErr: true,
+		},
 	}
 	routeObj := New()
 
 	for _, tt := range tests {
 		routeObj.routes = tt.r
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := routeObj.selectRoute(context.Background(), tt.args.host, tt.args.method, tt.args.url)
+			got, gotParams, err := routeObj.selectRoute(context.Background(), tt.args.host, tt.args.method, tt.args.url)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("routeMapping.selectRoute() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -358,6 +486,9 @@ func Test_routeMapping_selectRoute(t *testing.T) {
 			if !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("routeMapping.selectRoute() = %v, want %v", got, tt.want)
 			}
+			if !reflect.DeepEqual(gotParams, tt.wantParams) {
+				t.Errorf("routeMapping.selectRoute() params = %v, want %v", gotParams, tt.wantParams)
+			}
 		})
 	}
 }
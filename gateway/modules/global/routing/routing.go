@@ -2,11 +2,15 @@ package routing
 
 import (
 	"context"
+	"net/http"
+	"regexp"
 	"sync"
 	"text/template"
+	"time"
 
 	"github.com/spaceuptech/space-cloud/gateway/config"
 	"github.com/spaceuptech/space-cloud/gateway/model"
+	"github.com/spaceuptech/space-cloud/gateway/modules/global/ratelimit"
 )
 
 // Routing manages the routing functionality of space cloud
@@ -16,12 +20,15 @@ type Routing struct {
 	routes       config.Routes
 	globalConfig *config.GlobalRoutesConfig
 	caching      cachingInterface
+	rateLimiter  rateLimiterInterface
+	cors         corsInterface
 	goTemplates  map[string]*template.Template
+	compiledURLs map[string]map[string]*regexp.Regexp // key is the project id and then the route id
 }
 
 // New creates a new instance of the routing module
 func New() *Routing {
-	return &Routing{routes: make(config.Routes, 0), goTemplates: map[string]*template.Template{}, globalConfig: new(config.GlobalRoutesConfig)}
+	return &Routing{routes: make(config.Routes, 0), goTemplates: map[string]*template.Template{}, compiledURLs: map[string]map[string]*regexp.Regexp{}, globalConfig: new(config.GlobalRoutesConfig)}
 }
 
 // SetCachingModule sets caching module
@@ -29,6 +36,24 @@ func (r *Routing) SetCachingModule(c cachingInterface) {
 	r.caching = c
 }
 
+// SetRateLimiter sets the rate limiter module
+func (r *Routing) SetRateLimiter(rl rateLimiterInterface) {
+	r.rateLimiter = rl
+}
+
+// SetCorsModule sets the cors module which enforces the cors policies of the routes
+func (r *Routing) SetCorsModule(c corsInterface) {
+	r.cors = c
+}
+
+type rateLimiterInterface interface {
+	AllowRoute(ctx context.Context, route *config.Route, req *http.Request, getClaims ratelimit.ClaimsFunc) (bool, time.Duration)
+}
+
+type corsInterface interface {
+	SetProjectRoutes(projectID string, routes config.Routes) error
+}
+
 type cachingInterface interface {
 	SetIngressRouteKey(ctx context.Context, redisKey string, cache *config.ReadCacheOptions, result *model.CacheIngressRoute) error
 	GetIngressRoute(ctx context.Context, routeID string, cacheOptions []interface{}) (string, bool, *model.CacheIngressRoute, error)
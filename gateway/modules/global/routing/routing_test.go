@@ -2,6 +2,7 @@ package routing
 
 import (
 	"reflect"
+	"regexp"
 	"sync"
 	"testing"
 	"text/template"
@@ -21,6 +22,7 @@ func TestNew(t *testing.T) {
 				lock:         sync.RWMutex{},
 				routes:       make(config.Routes, 0),
 				goTemplates:  map[string]*template.Template{},
+				compiledURLs: map[string]map[string]*regexp.Regexp{},
 				globalConfig: new(config.GlobalRoutesConfig),
 			},
 		},
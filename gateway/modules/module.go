@@ -64,6 +64,7 @@ func newModule(projectID, clusterID, nodeID string, managers *managers.Managers,
 	}
 
 	f.SetEventingModule(e)
+	e.SetDeliveryHook(metrics.AddEventingDelivery)
 
 	c.SetHooks(metrics.AddDBOperation)
 
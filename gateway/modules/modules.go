@@ -114,6 +114,15 @@ func (m *Modules) SetFileStoreConfig(ctx context.Context, projectID string, file
 	return module.SetFileStoreConfig(ctx, projectID, fileStore)
 }
 
+// SetFileStoresConfig sets the config of the named file stores of a project
+func (m *Modules) SetFileStoresConfig(ctx context.Context, projectID string, fileStores config.FileStores) error {
+	module, err := m.loadModule(projectID)
+	if err != nil {
+		return err
+	}
+	return module.SetFileStoresConfig(ctx, projectID, fileStores)
+}
+
 // SetFileStoreSecurityRuleConfig sets the config of auth and filestore modules
 func (m *Modules) SetFileStoreSecurityRuleConfig(ctx context.Context, projectID string, fileStoreRules config.FileStoreRules) error {
 	module, err := m.loadModule(projectID)
@@ -205,6 +214,15 @@ func (m *Modules) SetRemoteServiceConfig(ctx context.Context, projectID string,
 	return module.SetRemoteServiceConfig(ctx, projectID, services)
 }
 
+// SetGraphQLPersistedQueryConfig sets the persisted queries of graphql module
+func (m *Modules) SetGraphQLPersistedQueryConfig(ctx context.Context, projectID string, queries config.GraphQLPersistedQueries) error {
+	module, err := m.loadModule(projectID)
+	if err != nil {
+		return err
+	}
+	return module.SetGraphQLPersistedQueryConfig(ctx, projectID, queries)
+}
+
 func (m *Modules) projects() *config.Config {
 	m.lock.RLock()
 	defer m.lock.RUnlock()
@@ -252,6 +270,8 @@ func (m *Modules) Delete(projectID string) {
 	// Remove config from global modules
 	_ = m.LetsEncrypt().DeleteProjectDomains(projectID)
 	m.Routing().DeleteProjectRoutes(projectID)
+	m.RateLimiter().DeleteProjectPolicies(projectID)
+	m.Cors().DeleteProjectPolicy(projectID)
 }
 
 func (m *Modules) loadModule(projectID string) (*Module, error) {
@@ -56,6 +56,9 @@ func (m *Module) SetInitialProjectConfig(ctx context.Context, projects config.Pr
 		if err := m.file.SetConfig(projectID, project.FileStoreConfig); err != nil {
 			_ = helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to set filestore module config", err, nil)
 		}
+		if err := m.SetFileStoresConfig(ctx, projectID, project.FileStores); err != nil {
+			_ = helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to set named file stores", err, nil)
+		}
 
 		helpers.Logger.LogDebug(helpers.GetRequestID(ctx), "Setting config of eventing module", nil)
 		if err := m.eventing.SetConfig(projectID, project.EventingConfig); err != nil {
@@ -77,10 +80,25 @@ func (m *Module) SetInitialProjectConfig(ctx context.Context, projects config.Pr
 
 		helpers.Logger.LogDebug(helpers.GetRequestID(ctx), "Setting config of graphql module", nil)
 		m.graphql.SetConfig(projectID)
+		m.graphql.SetQueryLimits(project.ProjectConfig.GraphQLLimits)
+		m.graphql.SetPersistedQueriesOnly(project.ProjectConfig.PersistedQueriesOnly)
+		m.graphql.SetPersistedQueries(project.GraphQLPersistedQueries)
+		m.graphql.SetIntrospection(project.ProjectConfig.GraphQLIntrospection)
+		m.graphql.ResetSchema()
 		if err := m.graphql.SetProjectAESKey(project.ProjectConfig.AESKey); err != nil {
 			_ = helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to set aes key for graphql module config", err, nil)
 		}
 
+		helpers.Logger.LogDebug(helpers.GetRequestID(ctx), "Setting config of rate limiter module", nil)
+		if err := m.GlobalMods.RateLimiter().SetProjectPolicies(projectID, project.ProjectConfig.RateLimits); err != nil {
+			_ = helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to set rate limiter module config", err, nil)
+		}
+
+		helpers.Logger.LogDebug(helpers.GetRequestID(ctx), "Setting config of cors module", nil)
+		if err := m.GlobalMods.Cors().SetProjectPolicy(projectID, project.ProjectConfig.Cors); err != nil {
+			_ = helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to set cors module config", err, nil)
+		}
+
 		helpers.Logger.LogDebug(helpers.GetRequestID(ctx), "Setting config of lets encrypt module", nil)
 		if err := m.GlobalMods.LetsEncrypt().SetProjectDomains(projectID, project.LetsEncrypt); err != nil {
 			_ = helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to set letsencypt module config", err, nil)
@@ -108,7 +126,13 @@ func (m *Module) SetProjectConfig(ctx context.Context, p *config.ProjectConfig)
 	_ = m.user.SetProjectAESKey(p.AESKey)
 	_ = m.graphql.SetProjectAESKey(p.AESKey)
 	m.graphql.SetConfig(p.ID)
-	return nil
+	m.graphql.SetQueryLimits(p.GraphQLLimits)
+	m.graphql.SetPersistedQueriesOnly(p.PersistedQueriesOnly)
+	m.graphql.SetIntrospection(p.GraphQLIntrospection)
+	if err := m.GlobalMods.RateLimiter().SetProjectPolicies(p.ID, p.RateLimits); err != nil {
+		return err
+	}
+	return m.GlobalMods.Cors().SetProjectPolicy(p.ID, p.Cors)
 }
 
 // SetDatabaseConfig sets the config of db, auth, schema and realtime modules
@@ -154,6 +178,7 @@ func (m *Module) SetDatabaseSchemaConfig(ctx context.Context, projectID string,
 		return err
 	}
 	m.realtime.SetDatabaseSchemas(schemaConfigs)
+	m.graphql.ResetSchema()
 	return nil
 }
 
@@ -174,6 +199,7 @@ func (m *Module) SetDatabasePreparedQueryConfig(ctx context.Context, prepConfigs
 		return helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to set db prepared query in db module", err, nil)
 	}
 	m.auth.SetDatabasePreparedQueryRules(prepConfigs)
+	m.graphql.ResetSchema()
 	return nil
 }
 
@@ -187,6 +213,16 @@ func (m *Module) SetFileStoreConfig(ctx context.Context, projectID string, fileS
 	return nil
 }
 
+// SetFileStoresConfig sets the config of the named file stores in auth and filestore modules
+func (m *Module) SetFileStoresConfig(ctx context.Context, projectID string, fileStores config.FileStores) error {
+	helpers.Logger.LogDebug(helpers.GetRequestID(ctx), "Setting config of named file stores", nil)
+	if err := m.file.SetFileStores(projectID, fileStores); err != nil {
+		return helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to set named file stores of filestore module", err, nil)
+	}
+	m.auth.SetFileStoreTypes(fileStores)
+	return nil
+}
+
 // SetFileStoreSecurityRuleConfig sets the config of auth and filestore modules
 func (m *Module) SetFileStoreSecurityRuleConfig(ctx context.Context, _ string, fileStoreRules config.FileStoreRules) {
 	helpers.Logger.LogDebug(helpers.GetRequestID(ctx), "Setting config of file store rules in auth module", nil)
@@ -271,5 +307,16 @@ func (m *Module) SetRemoteServiceConfig(ctx context.Context, projectID string, s
 	m.auth.SetRemoteServiceConfig(services)
 
 	helpers.Logger.LogDebug(helpers.GetRequestID(ctx), "Setting config of remote service module", nil)
-	return m.functions.SetConfig(projectID, services)
+	if err := m.functions.SetConfig(projectID, services); err != nil {
+		return err
+	}
+	m.graphql.ResetSchema()
+	return nil
+}
+
+// SetGraphQLPersistedQueryConfig sets the persisted queries of graphql module
+func (m *Module) SetGraphQLPersistedQueryConfig(ctx context.Context, projectID string, queries config.GraphQLPersistedQueries) error {
+	helpers.Logger.LogDebug(helpers.GetRequestID(ctx), "Setting persisted queries of graphql module", nil)
+	m.graphql.SetPersistedQueries(queries)
+	return nil
 }
@@ -37,13 +37,13 @@ func (m *Module) helperSendFeed(ctx context.Context, data *model.FeedData) {
 			case utils.RealtimeDelete:
 				_ = authHelpers.PostProcessMethod(ctx, m.aesKey, query.actions, dataPoint.Payload)
 				query.sendFeed(dataPoint)
-				m.metrics.AddDBOperation(m.project, data.DBType, data.Group, 1, model.Read)
+				m.metrics.AddDBOperation(m.project, data.DBType, data.Group, 1, model.Read, 0, nil)
 
 			case utils.RealtimeInsert, utils.RealtimeUpdate:
 				if utils.Validate(model.DefaultValidate, query.whereObj, data.Payload) {
 					_ = authHelpers.PostProcessMethod(ctx, m.aesKey, query.actions, dataPoint.Payload)
 					query.sendFeed(dataPoint)
-					m.metrics.AddDBOperation(m.project, data.DBType, data.Group, 1, model.Read)
+					m.metrics.AddDBOperation(m.project, data.DBType, data.Group, 1, model.Read, 0, nil)
 				}
 
 			default:
@@ -2,8 +2,10 @@ package schema
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
 
 	"github.com/go-test/deep"
 	"github.com/spaceuptech/helpers"
@@ -29,20 +31,40 @@ func (s *Schema) SchemaCreation(ctx context.Context, dbAlias, tableName, logical
 	}
 
 	// Return gracefully if db type is mongo
-	if dbType == string(model.Mongo) || dbType == string(model.EmbeddedDB) {
+	if dbType == string(model.Mongo) {
 		return nil
 	}
 
-	currentSchema, err := s.Inspector(ctx, dbAlias, dbType, logicalDBName, tableName, parsedSchema[dbAlias])
+	queries, err := s.schemaCreationQueries(ctx, dbAlias, tableName, logicalDBName, parsedSchema)
 	if err != nil {
-		helpers.Logger.LogDebug(helpers.GetRequestID(ctx), "Schema Inspector Error", map[string]interface{}{"error": err.Error()})
+		return err
 	}
+	return s.crud.RawBatch(ctx, dbAlias, queries)
+}
 
-	queries, err := s.generateCreationQueries(ctx, dbAlias, tableName, logicalDBName, parsedSchema, currentSchema)
+// schemaCreationQueries returns the queries required to create or alter a table without running them
+func (s *Schema) schemaCreationQueries(ctx context.Context, dbAlias, tableName, logicalDBName string, parsedSchema model.Type) ([]string, error) {
+	dbType, err := s.crud.GetDBType(dbAlias)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	return s.crud.RawBatch(ctx, dbAlias, queries)
+
+	// Return gracefully if db type is mongo
+	if dbType == string(model.Mongo) {
+		return nil, nil
+	}
+
+	// The embedded database only needs to know about the indexes of the collection
+	if dbType == string(model.EmbeddedDB) {
+		return generateEmbeddedIndexQueries(ctx, tableName, parsedSchema[dbAlias])
+	}
+
+	currentSchema, err := s.Inspector(ctx, dbAlias, dbType, logicalDBName, tableName, parsedSchema[dbAlias])
+	if err != nil {
+		helpers.Logger.LogDebug(helpers.GetRequestID(ctx), "Schema Inspector Error", map[string]interface{}{"error": err.Error()})
+	}
+
+	return s.generateCreationQueries(ctx, dbAlias, tableName, logicalDBName, parsedSchema, currentSchema)
 }
 
 func (s *Schema) generateCreationQueries(ctx context.Context, dbAlias, tableName, logicalDBName string, parsedSchema model.Type, currentSchema model.Collection) ([]string, error) {
@@ -89,6 +111,13 @@ func (s *Schema) generateCreationQueries(ctx context.Context, dbAlias, tableName
 				IsPrimary:           realColumnInfo.IsPrimary,
 				NestedObject:        realColumnInfo.NestedObject,
 			}
+			// The foreign key and default constraints of sqlite get created along with the table
+			if model.DBType(dbType) == model.SQLite {
+				temp.IsForeign = realColumnInfo.IsForeign
+				temp.JointTable = realColumnInfo.JointTable
+				temp.IsDefault = realColumnInfo.IsDefault
+				temp.Default = realColumnInfo.Default
+			}
 			currentTableInfo[realColumnName] = &temp
 		}
 	}
@@ -194,6 +223,11 @@ func (s *Schema) generateCreationQueries(ctx context.Context, dbAlias, tableName
 				if c.currentColumnInfo.IsPrimary && (!c.realColumnInfo.IsPrimary || c.realColumnInfo.IsForeign || !c.realColumnInfo.IsFieldTypeRequired || c.realColumnInfo.IsDefault) {
 					return nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf(`Mutation is not allowed on field ("%s") with primary key, Delete the table to change primary key`, c.ColumnName), nil, nil)
 				}
+				if model.DBType(dbType) == model.SQLite {
+					if err := c.checkSQLiteModification(ctx); err != nil {
+						return nil, err
+					}
+				}
 				// make changes according to the changes in directives
 				queries := c.modifyColumn(dbType)
 				batchedQueries = append(batchedQueries, queries...)
@@ -221,6 +255,18 @@ func (s *Schema) generateCreationQueries(ctx context.Context, dbAlias, tableName
 	return batchedQueries, nil
 }
 
+// checkSQLiteModification returns an error if the constraints of an existing column have changed since sqlite cannot alter a column
+func (c *creationModule) checkSQLiteModification(ctx context.Context) error {
+	isForeignChanged := c.realColumnInfo.IsForeign != c.currentColumnInfo.IsForeign
+	if c.realColumnInfo.IsForeign && c.currentColumnInfo.IsForeign && c.currentColumnInfo.JointTable.OnDelete != c.realColumnInfo.JointTable.OnDelete {
+		isForeignChanged = true
+	}
+	if isForeignChanged || c.realColumnInfo.IsFieldTypeRequired != c.currentColumnInfo.IsFieldTypeRequired || c.realColumnInfo.IsDefault != c.currentColumnInfo.IsDefault {
+		return helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf(`Cannot change the constraints of field ("%s") in sqlite, Delete the field or the table to change its constraints`, c.ColumnName), nil, nil)
+	}
+	return nil
+}
+
 func cleanIndexMap(v []*model.TableProperties) []*model.TableProperties {
 	for _, indexInfo := range v {
 		indexInfo.ConstraintName = ""
@@ -247,3 +293,66 @@ func (s *Schema) SchemaModifyAll(ctx context.Context, dbAlias, logicalDBName str
 	}
 	return nil
 }
+
+// GetSchemaModifyAllQueries returns the queries SchemaModifyAll would run for the provided schemas, keyed by table name
+func (s *Schema) GetSchemaModifyAllQueries(ctx context.Context, dbAlias, logicalDBName string, dbSchemas config.DatabaseSchemas) (map[string][]string, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	parsedSchema, err := schemaHelpers.Parser(dbSchemas)
+	if err != nil {
+		return nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable parse provided schema SDL", err, nil)
+	}
+	queries := make(map[string][]string, len(dbSchemas))
+	for _, dbSchema := range dbSchemas {
+		if dbSchema.Schema == "" {
+			continue
+		}
+		tableQueries, err := s.schemaCreationQueries(ctx, dbAlias, dbSchema.Table, logicalDBName, parsedSchema)
+		if err != nil {
+			return nil, err
+		}
+		queries[dbSchema.Table] = tableQueries
+	}
+	return queries, nil
+}
+
+// generateEmbeddedIndexQueries generates the json encoded indexes of a collection for the embedded database
+func generateEmbeddedIndexQueries(ctx context.Context, tableName string, realSchema model.Collection) ([]string, error) {
+	realTableInfo, ok := realSchema[tableName]
+	if !ok {
+		return nil, nil
+	}
+
+	indexMap, err := getIndexMap(ctx, realTableInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	// Sort the index groups so that the generated query is deterministic
+	groups := make([]string, 0, len(indexMap))
+	for group := range indexMap {
+		groups = append(groups, group)
+	}
+	sort.Strings(groups)
+
+	req := &model.EmbeddedIndexes{Table: tableName, Indexes: []*model.EmbeddedIndex{}}
+	for _, group := range groups {
+		indexInfo := indexMap[group]
+		index := &model.EmbeddedIndex{Name: indexInfo.IndexName, IsUnique: indexInfo.IsIndexUnique, Fields: []string{}, Sort: []string{}}
+		if index.Name == "" {
+			index.Name = getIndexName(tableName, group)
+		}
+		for _, column := range indexInfo.IndexTableProperties {
+			index.Fields = append(index.Fields, column.Field)
+			index.Sort = append(index.Sort, column.Sort)
+		}
+		req.Indexes = append(req.Indexes, index)
+	}
+
+	data, err := json.Marshal(req)
+	if err != nil {
+		return nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Unable to marshal indexes of collection (%s) for embedded database", tableName), err, nil)
+	}
+	return []string{string(data)}, nil
+}
@@ -2,6 +2,9 @@ package schema
 
 import (
 	"context"
+	"path/filepath"
+	"reflect"
+	"sort"
 	"testing"
 
 	"github.com/spaceuptech/space-cloud/gateway/config"
@@ -57,6 +60,13 @@ func TestSchema_generateCreationQueries(t *testing.T) {
 		t.Fatal("unable to initialize sql server", err)
 	}
 
+	crudSQLite := crud.Init()
+	crudSQLite.SetAdminManager(adminMan)
+	err = crudSQLite.SetConfig("test", config.DatabaseConfigs{config.GenerateResourceID("chicago", "myproject", config.ResourceDatabaseConfig, "sqlite"): &config.DatabaseConfig{DbAlias: "sqlite", Type: "sql-sqlite", Enabled: false}})
+	if err != nil {
+		t.Fatal("unable to initialize sqlite", err)
+	}
+
 	var noQueriesGeneratedTestCases = []testGenerateCreationQueries{
 		// Mysql
 		{
@@ -3886,6 +3896,129 @@ func TestSchema_generateCreationQueries(t *testing.T) {
 		},
 	}
 
+	var sqliteTestCases = []testGenerateCreationQueries{
+		{
+			name: "Sqlite creating a table with a default value",
+			args: args{
+				dbAlias:       "sqlite",
+				tableName:     "table1",
+				project:       "test",
+				parsedSchema:  model.Type{"sqlite": model.Collection{"table1": model.Fields{"col1": &model.FieldType{FieldName: "col1", Kind: model.TypeID, TypeIDSize: model.DefaultCharacterSize, IsPrimary: true, PrimaryKeyInfo: &model.TableProperties{}, IsFieldTypeRequired: true}, "col2": &model.FieldType{FieldName: "col2", Kind: model.TypeString, IsDefault: true, Default: "abc"}}}},
+				currentSchema: model.Collection{},
+			},
+			fields: fields{crud: crudSQLite, project: "test"},
+			want:   []string{"CREATE TABLE table1 (col1 varchar(100) NOT NULL , col2 text DEFAULT 'abc' ,PRIMARY KEY (col1));"},
+		},
+		{
+			name: "Sqlite creating a table with a foreign key",
+			args: args{
+				dbAlias:       "sqlite",
+				tableName:     "table1",
+				project:       "test",
+				parsedSchema:  model.Type{"sqlite": model.Collection{"table2": model.Fields{"id": &model.FieldType{FieldName: "id", Kind: model.TypeID, TypeIDSize: model.DefaultCharacterSize, IsFieldTypeRequired: true, IsPrimary: true}}, "table1": model.Fields{"col1": &model.FieldType{FieldName: "col1", Kind: model.TypeID, TypeIDSize: model.DefaultCharacterSize, IsPrimary: true, PrimaryKeyInfo: &model.TableProperties{}, IsFieldTypeRequired: true}, "col2": &model.FieldType{FieldName: "col2", Kind: model.TypeID, TypeIDSize: model.DefaultCharacterSize, IsForeign: true, JointTable: &model.TableProperties{Table: "table2", To: "id", ConstraintName: GetConstraintName("table1", "col2"), OnDelete: "CASCADE"}}}}},
+				currentSchema: model.Collection{"table2": model.Fields{"id": &model.FieldType{FieldName: "id", Kind: model.TypeID, TypeIDSize: model.DefaultCharacterSize, IsFieldTypeRequired: true, IsPrimary: true}}},
+			},
+			fields: fields{crud: crudSQLite, project: "test"},
+			want:   []string{"CREATE TABLE table1 (col1 varchar(100) NOT NULL , col2 varchar(100) CONSTRAINT c_table1_col2 REFERENCES table2 (id) ON DELETE CASCADE ,PRIMARY KEY (col1));"},
+		},
+		{
+			name: "Sqlite creating a table with an auto increment primary key",
+			args: args{
+				dbAlias:       "sqlite",
+				tableName:     "table1",
+				project:       "test",
+				parsedSchema:  model.Type{"sqlite": model.Collection{"table1": model.Fields{"col1": &model.FieldType{FieldName: "col1", Kind: model.TypeInteger, IsPrimary: true, IsAutoIncrement: true, PrimaryKeyInfo: &model.TableProperties{}, IsFieldTypeRequired: true}, "col2": &model.FieldType{FieldName: "col2", Kind: model.TypeBoolean}}}},
+				currentSchema: model.Collection{},
+			},
+			fields: fields{crud: crudSQLite, project: "test"},
+			want:   []string{"CREATE TABLE table1 (col1 integer NOT NULL PRIMARY KEY AUTOINCREMENT, col2 boolean);"},
+		},
+		{
+			name: "Sqlite auto increment on a primary key which isn't of type integer",
+			args: args{
+				dbAlias:       "sqlite",
+				tableName:     "table1",
+				project:       "test",
+				parsedSchema:  model.Type{"sqlite": model.Collection{"table1": model.Fields{"col1": &model.FieldType{FieldName: "col1", Kind: model.TypeBigInteger, IsPrimary: true, IsAutoIncrement: true, PrimaryKeyInfo: &model.TableProperties{}, IsFieldTypeRequired: true}}}},
+				currentSchema: model.Collection{},
+			},
+			fields:  fields{crud: crudSQLite, project: "test"},
+			wantErr: true,
+		},
+		{
+			name: "Sqlite adding a column with constraints",
+			args: args{
+				dbAlias:       "sqlite",
+				tableName:     "table1",
+				project:       "test",
+				parsedSchema:  model.Type{"sqlite": model.Collection{"table2": model.Fields{"id": &model.FieldType{FieldName: "id", Kind: model.TypeID, TypeIDSize: model.DefaultCharacterSize, IsFieldTypeRequired: true, IsPrimary: true}}, "table1": model.Fields{"col1": &model.FieldType{FieldName: "col1", Kind: model.TypeID, TypeIDSize: model.DefaultCharacterSize, IsPrimary: true, PrimaryKeyInfo: &model.TableProperties{}, IsFieldTypeRequired: true}, "col2": &model.FieldType{FieldName: "col2", Kind: model.TypeID, TypeIDSize: model.DefaultCharacterSize, IsFieldTypeRequired: true, IsForeign: true, JointTable: &model.TableProperties{Table: "table2", To: "id", ConstraintName: GetConstraintName("table1", "col2"), OnDelete: "CASCADE"}}}}},
+				currentSchema: model.Collection{"table2": model.Fields{"id": &model.FieldType{FieldName: "id", Kind: model.TypeID, TypeIDSize: model.DefaultCharacterSize, IsFieldTypeRequired: true, IsPrimary: true}}, "table1": model.Fields{"col1": &model.FieldType{FieldName: "col1", Kind: model.TypeID, TypeIDSize: model.DefaultCharacterSize, IsPrimary: true, PrimaryKeyInfo: &model.TableProperties{}, IsFieldTypeRequired: true}}},
+			},
+			fields: fields{crud: crudSQLite, project: "test"},
+			want:   []string{"ALTER TABLE table1 ADD COLUMN col2 varchar(100) NOT NULL CONSTRAINT c_table1_col2 REFERENCES table2 (id) ON DELETE CASCADE"},
+		},
+		{
+			name: "Sqlite changing the type of a column with a default value",
+			args: args{
+				dbAlias:       "sqlite",
+				tableName:     "table1",
+				project:       "test",
+				parsedSchema:  model.Type{"sqlite": model.Collection{"table1": model.Fields{"col1": &model.FieldType{FieldName: "col1", Kind: model.TypeID, TypeIDSize: model.DefaultCharacterSize, IsPrimary: true, PrimaryKeyInfo: &model.TableProperties{}, IsFieldTypeRequired: true}, "col2": &model.FieldType{FieldName: "col2", Kind: model.TypeInteger, IsDefault: true, Default: 10}}}},
+				currentSchema: model.Collection{"table1": model.Fields{"col1": &model.FieldType{FieldName: "col1", Kind: model.TypeID, TypeIDSize: model.DefaultCharacterSize, IsPrimary: true, PrimaryKeyInfo: &model.TableProperties{}, IsFieldTypeRequired: true}, "col2": &model.FieldType{FieldName: "col2", Kind: model.TypeString, IsDefault: true, Default: "10"}}},
+			},
+			fields: fields{crud: crudSQLite, project: "test"},
+			want:   []string{"ALTER TABLE table1 DROP COLUMN col2", "ALTER TABLE table1 ADD COLUMN col2 integer DEFAULT 10"},
+		},
+		{
+			name: "Sqlite changing the not null constraint of an existing column",
+			args: args{
+				dbAlias:       "sqlite",
+				tableName:     "table1",
+				project:       "test",
+				parsedSchema:  model.Type{"sqlite": model.Collection{"table1": model.Fields{"col1": &model.FieldType{FieldName: "col1", Kind: model.TypeID, TypeIDSize: model.DefaultCharacterSize, IsPrimary: true, PrimaryKeyInfo: &model.TableProperties{}, IsFieldTypeRequired: true}, "col2": &model.FieldType{FieldName: "col2", Kind: model.TypeString, IsFieldTypeRequired: true}}}},
+				currentSchema: model.Collection{"table1": model.Fields{"col1": &model.FieldType{FieldName: "col1", Kind: model.TypeID, TypeIDSize: model.DefaultCharacterSize, IsPrimary: true, PrimaryKeyInfo: &model.TableProperties{}, IsFieldTypeRequired: true}, "col2": &model.FieldType{FieldName: "col2", Kind: model.TypeString}}},
+			},
+			fields:  fields{crud: crudSQLite, project: "test"},
+			wantErr: true,
+		},
+		{
+			name: "Sqlite removing the foreign key of an existing column",
+			args: args{
+				dbAlias:       "sqlite",
+				tableName:     "table1",
+				project:       "test",
+				parsedSchema:  model.Type{"sqlite": model.Collection{"table2": model.Fields{"id": &model.FieldType{FieldName: "id", Kind: model.TypeID, TypeIDSize: model.DefaultCharacterSize, IsFieldTypeRequired: true, IsPrimary: true}}, "table1": model.Fields{"col1": &model.FieldType{FieldName: "col1", Kind: model.TypeID, TypeIDSize: model.DefaultCharacterSize, IsPrimary: true, PrimaryKeyInfo: &model.TableProperties{}, IsFieldTypeRequired: true}, "col2": &model.FieldType{FieldName: "col2", Kind: model.TypeID, TypeIDSize: model.DefaultCharacterSize}}}},
+				currentSchema: model.Collection{"table2": model.Fields{"id": &model.FieldType{FieldName: "id", Kind: model.TypeID, TypeIDSize: model.DefaultCharacterSize, IsFieldTypeRequired: true, IsPrimary: true}}, "table1": model.Fields{"col1": &model.FieldType{FieldName: "col1", Kind: model.TypeID, TypeIDSize: model.DefaultCharacterSize, IsPrimary: true, PrimaryKeyInfo: &model.TableProperties{}, IsFieldTypeRequired: true}, "col2": &model.FieldType{FieldName: "col2", Kind: model.TypeID, TypeIDSize: model.DefaultCharacterSize, IsForeign: true, JointTable: &model.TableProperties{Table: "table2", To: "id", ConstraintName: GetConstraintName("table1", "col2"), OnDelete: "CASCADE"}}}},
+			},
+			fields:  fields{crud: crudSQLite, project: "test"},
+			wantErr: true,
+		},
+		{
+			name: "Sqlite dropping a column with a foreign key",
+			args: args{
+				dbAlias:       "sqlite",
+				tableName:     "table1",
+				project:       "test",
+				parsedSchema:  model.Type{"sqlite": model.Collection{"table2": model.Fields{"id": &model.FieldType{FieldName: "id", Kind: model.TypeID, TypeIDSize: model.DefaultCharacterSize, IsFieldTypeRequired: true, IsPrimary: true}}, "table1": model.Fields{"col1": &model.FieldType{FieldName: "col1", Kind: model.TypeID, TypeIDSize: model.DefaultCharacterSize, IsPrimary: true, PrimaryKeyInfo: &model.TableProperties{}, IsFieldTypeRequired: true}}}},
+				currentSchema: model.Collection{"table2": model.Fields{"id": &model.FieldType{FieldName: "id", Kind: model.TypeID, TypeIDSize: model.DefaultCharacterSize, IsFieldTypeRequired: true, IsPrimary: true}}, "table1": model.Fields{"col1": &model.FieldType{FieldName: "col1", Kind: model.TypeID, TypeIDSize: model.DefaultCharacterSize, IsPrimary: true, PrimaryKeyInfo: &model.TableProperties{}, IsFieldTypeRequired: true}, "col2": &model.FieldType{FieldName: "col2", Kind: model.TypeID, TypeIDSize: model.DefaultCharacterSize, IsForeign: true, JointTable: &model.TableProperties{Table: "table2", To: "id", ConstraintName: GetConstraintName("table1", "col2"), OnDelete: "CASCADE"}}}},
+			},
+			fields: fields{crud: crudSQLite, project: "test"},
+			want:   []string{"ALTER TABLE table1 DROP COLUMN col2"},
+		},
+		{
+			name: "Sqlite removing an index",
+			args: args{
+				dbAlias:       "sqlite",
+				tableName:     "table1",
+				project:       "test",
+				parsedSchema:  model.Type{"sqlite": model.Collection{"table1": model.Fields{"col1": &model.FieldType{FieldName: "col1", Kind: model.TypeID, TypeIDSize: model.DefaultCharacterSize, IsPrimary: true, PrimaryKeyInfo: &model.TableProperties{}, IsFieldTypeRequired: true}, "col2": &model.FieldType{FieldName: "col2", Kind: model.TypeString}}}},
+				currentSchema: model.Collection{"table1": model.Fields{"col1": &model.FieldType{FieldName: "col1", Kind: model.TypeID, TypeIDSize: model.DefaultCharacterSize, IsPrimary: true, PrimaryKeyInfo: &model.TableProperties{}, IsFieldTypeRequired: true}, "col2": &model.FieldType{FieldName: "col2", Kind: model.TypeString, IndexInfo: []*model.TableProperties{{IsIndex: true, Group: "group1", Order: 1, Sort: "asc", Field: "col2", ConstraintName: "index__table1__group1"}}}}},
+			},
+			fields: fields{crud: crudSQLite, project: "test"},
+			want:   []string{"DROP INDEX index__table1__group1"},
+		},
+	}
+
 	testCases := make([]testGenerateCreationQueries, 0)
 	testCases = append(testCases, noQueriesGeneratedTestCases...)
 	testCases = append(testCases, createTableTestCases...)
@@ -3898,6 +4031,7 @@ func TestSchema_generateCreationQueries(t *testing.T) {
 	testCases = append(testCases, changingUniqueIndexKeyTestCases...)
 	testCases = append(testCases, changingIndexKeyTestCases...)
 	testCases = append(testCases, miscellaneousTestCases...)
+	testCases = append(testCases, sqliteTestCases...)
 
 	for _, tt := range testCases {
 		t.Run(tt.name, func(t *testing.T) {
@@ -3944,3 +4078,184 @@ func sortArray(a []string) []string {
 	}
 	return a
 }
+
+func TestSchema_SchemaModifyAll_sqlite(t *testing.T) {
+	crudSQLite := crud.Init()
+	crudSQLite.SetAdminManager(&admin.Manager{})
+	dbConfig := &config.DatabaseConfig{DbAlias: "sqlite", Type: "sql-sqlite", Conn: filepath.Join(t.TempDir(), "test.db"), Enabled: true}
+	if err := crudSQLite.SetConfig("test", config.DatabaseConfigs{config.GenerateResourceID("chicago", "myproject", config.ResourceDatabaseConfig, "sqlite"): dbConfig}); err != nil {
+		t.Fatal("unable to initialize sqlite", err)
+	}
+	defer func() { _ = crudSQLite.CloseConfig() }()
+
+	schemas := config.DatabaseSchemas{
+		"authors": &config.DatabaseSchema{Table: "authors", DbAlias: "sqlite", Schema: `type authors {
+			id: ID! @primary
+			name: String! @unique
+			age: Integer @default(value: 18)
+			height: Float
+			is_active: Boolean @default(value: true)
+			joined: DateTime @index
+			meta: JSON
+		}`},
+		"books": &config.DatabaseSchema{Table: "books", DbAlias: "sqlite", Schema: `type books {
+			id: Integer! @primary @autoIncrement
+			author_id: ID @foreign(table: "authors", field: "id", onDelete: "cascade")
+			price: Decimal @args(precision: 10, scale: 2)
+			title: String! @default(value: "untitled")
+		}`},
+	}
+
+	s := Init("chicago", crudSQLite)
+	ctx := context.Background()
+	if err := s.SchemaModifyAll(ctx, "sqlite", "test", schemas); err != nil {
+		t.Fatalf("SchemaModifyAll() error = %v", err)
+	}
+
+	// Inspecting the tables must give back the same schema, hence no further queries should be generated
+	parsedSchema, err := Parser(schemas)
+	if err != nil {
+		t.Fatalf("Parser() error = %v", err)
+	}
+	for _, table := range []string{"authors", "books"} {
+		currentSchema, err := s.Inspector(ctx, "sqlite", string(model.SQLite), "test", table, parsedSchema["sqlite"])
+		if err != nil {
+			t.Fatalf("Inspector() error = %v", err)
+		}
+		queries, err := s.generateCreationQueries(ctx, "sqlite", table, "test", parsedSchema, currentSchema)
+		if err != nil {
+			t.Fatalf("generateCreationQueries() error = %v", err)
+		}
+		if len(queries) != 0 {
+			t.Errorf("generateCreationQueries() for (%s) = %v, want no queries", table, queries)
+		}
+	}
+
+	// Adding and removing columns of an existing table must work
+	schemas["authors"].Schema = `type authors {
+		id: ID! @primary
+		name: String! @unique
+		age: Integer @default(value: 18)
+		height: Float
+		email: String @default(value: "none")
+	}`
+	if err := s.SchemaModifyAll(ctx, "sqlite", "test", schemas); err != nil {
+		t.Fatalf("SchemaModifyAll() error = %v", err)
+	}
+	fields, _, err := crudSQLite.DescribeTable(ctx, "sqlite", "authors")
+	if err != nil {
+		t.Fatalf("DescribeTable() error = %v", err)
+	}
+	var columns []string
+	for _, field := range fields {
+		columns = append(columns, field.ColumnName)
+	}
+	sort.Strings(columns)
+	if want := []string{"age", "email", "height", "id", "name"}; !reflect.DeepEqual(columns, want) {
+		t.Errorf("SchemaModifyAll() columns = %v, want %v", columns, want)
+	}
+}
+
+func TestSchema_GetSchemaModifyAllQueries_sqlite(t *testing.T) {
+	crudSQLite := crud.Init()
+	crudSQLite.SetAdminManager(&admin.Manager{})
+	dbConfig := &config.DatabaseConfig{DbAlias: "sqlite", Type: "sql-sqlite", Conn: filepath.Join(t.TempDir(), "test.db"), Enabled: true}
+	if err := crudSQLite.SetConfig("test", config.DatabaseConfigs{config.GenerateResourceID("chicago", "myproject", config.ResourceDatabaseConfig, "sqlite"): dbConfig}); err != nil {
+		t.Fatal("unable to initialize sqlite", err)
+	}
+	defer func() { _ = crudSQLite.CloseConfig() }()
+
+	schemas := config.DatabaseSchemas{
+		"authors": &config.DatabaseSchema{Table: "authors", DbAlias: "sqlite", Schema: `type authors {
+			id: ID! @primary
+			name: String!
+		}`},
+	}
+
+	s := Init("chicago", crudSQLite)
+	ctx := context.Background()
+	queries, err := s.GetSchemaModifyAllQueries(ctx, "sqlite", "test", schemas)
+	if err != nil {
+		t.Fatalf("GetSchemaModifyAllQueries() error = %v", err)
+	}
+	if len(queries["authors"]) == 0 {
+		t.Fatalf("GetSchemaModifyAllQueries() = %v, want queries to create table (authors)", queries)
+	}
+
+	// The queries must not be run
+	collections, err := crudSQLite.GetCollections(ctx, "sqlite")
+	if err != nil {
+		t.Fatalf("GetCollections() error = %v", err)
+	}
+	if len(collections) != 0 {
+		t.Errorf("GetSchemaModifyAllQueries() created tables %v", collections)
+	}
+
+	// No queries are required once the schema has been applied
+	if err := s.SchemaModifyAll(ctx, "sqlite", "test", schemas); err != nil {
+		t.Fatalf("SchemaModifyAll() error = %v", err)
+	}
+	queries, err = s.GetSchemaModifyAllQueries(ctx, "sqlite", "test", schemas)
+	if err != nil {
+		t.Fatalf("GetSchemaModifyAllQueries() error = %v", err)
+	}
+	if len(queries["authors"]) != 0 {
+		t.Errorf("GetSchemaModifyAllQueries() = %v, want no queries", queries)
+	}
+}
+
+func Test_generateEmbeddedIndexQueries(t *testing.T) {
+	tests := []struct {
+		name       string
+		tableName  string
+		realSchema model.Collection
+		want       []string
+		wantErr    bool
+	}{
+		{
+			name:       "table not present in schema",
+			tableName:  "table1",
+			realSchema: model.Collection{},
+			want:       nil,
+		},
+		{
+			name:      "table without indexes",
+			tableName: "table1",
+			realSchema: model.Collection{"table1": model.Fields{
+				"col1": &model.FieldType{FieldName: "col1", Kind: model.TypeID, IsPrimary: true},
+			}},
+			want: []string{`{"table":"table1","indexes":[]}`},
+		},
+		{
+			name:      "table with a composite index and a named unique index",
+			tableName: "table1",
+			realSchema: model.Collection{"table1": model.Fields{
+				"col1": &model.FieldType{FieldName: "col1", Kind: model.TypeID, IsPrimary: true},
+				"col2": &model.FieldType{FieldName: "col2", Kind: model.TypeString, IndexInfo: []*model.TableProperties{{IsIndex: true, Group: "group1", Field: "col2", Order: 2, Sort: "desc"}}},
+				"col3": &model.FieldType{FieldName: "col3", Kind: model.TypeString, IndexInfo: []*model.TableProperties{{IsIndex: true, Group: "group1", Field: "col3", Order: 1, Sort: "asc"}}},
+				"col4": &model.FieldType{FieldName: "col4", Kind: model.TypeString, IndexInfo: []*model.TableProperties{{IsUnique: true, Group: "group2", Field: "col4", Order: 1, Sort: "asc", ConstraintName: "unique_col4"}}},
+			}},
+			want: []string{`{"table":"table1","indexes":[{"name":"index__table1__group1","isUnique":false,"fields":["col3","col2"],"sort":["asc","desc"]},{"name":"unique_col4","isUnique":true,"fields":["col4"],"sort":["asc"]}]}`},
+		},
+		{
+			name:      "invalid index order",
+			tableName: "table1",
+			realSchema: model.Collection{"table1": model.Fields{
+				"col2": &model.FieldType{FieldName: "col2", Kind: model.TypeString, IndexInfo: []*model.TableProperties{{IsIndex: true, Group: "group1", Field: "col2", Order: 2, Sort: "asc"}}},
+			}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := generateEmbeddedIndexQueries(context.Background(), tt.tableName, tt.realSchema)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("generateEmbeddedIndexQueries() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("generateEmbeddedIndexQueries() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -31,7 +31,7 @@ func getSQLType(ctx context.Context, dbType string, realColumnInfo *model.FieldT
 				return "character", nil
 			}
 			return fmt.Sprintf("character(%d)", realColumnInfo.TypeIDSize), nil
-		case string(model.MySQL):
+		case string(model.MySQL), string(model.SQLite):
 			return fmt.Sprintf("char(%d)", realColumnInfo.TypeIDSize), nil
 		case string(model.SQLServer):
 			return fmt.Sprintf("nchar(%d)", realColumnInfo.TypeIDSize), nil
@@ -47,6 +47,11 @@ func getSQLType(ctx context.Context, dbType string, realColumnInfo *model.FieldT
 			return fmt.Sprintf("varchar(%d)", realColumnInfo.TypeIDSize), nil
 		case string(model.SQLServer):
 			return fmt.Sprintf("nvarchar(%d)", realColumnInfo.TypeIDSize), nil
+		case string(model.SQLite):
+			if realColumnInfo.TypeIDSize == -1 {
+				return "varchar", nil
+			}
+			return fmt.Sprintf("varchar(%d)", realColumnInfo.TypeIDSize), nil
 		}
 	case model.TypeString:
 		switch dbType {
@@ -56,6 +61,8 @@ func getSQLType(ctx context.Context, dbType string, realColumnInfo *model.FieldT
 			return "longtext", nil
 		case string(model.SQLServer):
 			return "nvarchar(max)", nil
+		case string(model.SQLite):
+			return "text", nil
 		}
 	case model.TypeDateTime:
 		switch dbType {
@@ -65,6 +72,10 @@ func getSQLType(ctx context.Context, dbType string, realColumnInfo *model.FieldT
 			return fmt.Sprintf("datetime2(%d)", realColumnInfo.Args.Precision), nil
 		case string(model.Postgres):
 			return fmt.Sprintf("timestamp(%d) without time zone", realColumnInfo.Args.Precision), nil
+		case string(model.SQLite):
+			// Sqlite stores date times as text with the complete precision. The driver only parses
+			// columns declared exactly as datetime or timestamp into a time
+			return "datetime", nil
 		}
 	case model.TypeDateTimeWithZone:
 		switch dbType {
@@ -74,10 +85,12 @@ func getSQLType(ctx context.Context, dbType string, realColumnInfo *model.FieldT
 			return fmt.Sprintf("datetimeoffset(%d)", realColumnInfo.Args.Precision), nil
 		case string(model.Postgres):
 			return fmt.Sprintf("timestamp(%d) with time zone", realColumnInfo.Args.Precision), nil
+		case string(model.SQLite):
+			return "timestamp", nil
 		}
 	case model.TypeBoolean:
 		switch dbType {
-		case string(model.Postgres):
+		case string(model.Postgres), string(model.SQLite):
 			return "boolean", nil
 		case string(model.MySQL):
 			return "tinyint(1)", nil
@@ -90,7 +103,7 @@ func getSQLType(ctx context.Context, dbType string, realColumnInfo *model.FieldT
 		switch dbType {
 		case string(model.Postgres):
 			return "double precision", nil
-		case string(model.MySQL):
+		case string(model.MySQL), string(model.SQLite):
 			return "double", nil
 		case string(model.SQLServer):
 			return "float", nil
@@ -99,7 +112,7 @@ func getSQLType(ctx context.Context, dbType string, realColumnInfo *model.FieldT
 		switch dbType {
 		case string(model.Postgres):
 			return fmt.Sprintf("numeric(%d,%d)", realColumnInfo.Args.Precision, realColumnInfo.Args.Scale), nil
-		case string(model.MySQL), string(model.SQLServer):
+		case string(model.MySQL), string(model.SQLServer), string(model.SQLite):
 			return fmt.Sprintf("decimal(%d,%d)", realColumnInfo.Args.Precision, realColumnInfo.Args.Scale), nil
 		}
 	case model.TypeInteger:
@@ -112,7 +125,7 @@ func getSQLType(ctx context.Context, dbType string, realColumnInfo *model.FieldT
 		switch dbType {
 		case string(model.Postgres):
 			return "jsonb", nil
-		case string(model.MySQL):
+		case string(model.MySQL), string(model.SQLite):
 			return "json", nil
 		case string(model.SQLServer):
 			return "nvarchar(max)", nil
@@ -200,6 +213,24 @@ func (c *creationModule) addNewColumn() string {
 	}
 
 	switch model.DBType(dbType) {
+	case model.SQLite:
+		// Sqlite cannot alter a column once it is created. Hence all the constraints are added along with the column
+		query := "ALTER TABLE " + c.schemaModule.getTableName(dbType, c.logicalDBName, c.TableName) + " ADD COLUMN " + c.ColumnName + " " + c.columnType
+		if c.realColumnInfo.IsFieldTypeRequired {
+			c.currentColumnInfo.IsFieldTypeRequired = true // Mark the field as processed
+			query += " NOT NULL"
+		}
+		if c.realColumnInfo.IsDefault {
+			c.currentColumnInfo.IsDefault = true // Mark the field as processed
+			c.currentColumnInfo.Default = c.realColumnInfo.Default
+			query += " DEFAULT " + c.typeSwitch()
+		}
+		if c.realColumnInfo.IsForeign {
+			c.currentColumnInfo.IsForeign = true // Mark the field as processed
+			c.currentColumnInfo.JointTable = &model.TableProperties{OnDelete: c.realColumnInfo.JointTable.OnDelete}
+			query += " " + sqliteForeignKeyClause(c.realColumnInfo)
+		}
+		return query
 	case model.MySQL:
 		return "ALTER TABLE " + c.schemaModule.getTableName(dbType, c.logicalDBName, c.TableName) + " ADD " + c.ColumnName + " " + c.columnType
 	case model.Postgres:
@@ -278,7 +309,12 @@ func (c *creationModule) typeSwitch() string {
 		return ""
 	}
 
-	switch v := c.realColumnInfo.Default.(type) {
+	return formatDefaultValue(dbType, c.realColumnInfo.Default)
+}
+
+// formatDefaultValue returns the sql literal of the default value of a column
+func formatDefaultValue(dbType string, value interface{}) string {
+	switch v := value.(type) {
 	case string:
 		return "'" + fmt.Sprintf("%v", v) + "'"
 	case bool:
@@ -351,6 +387,7 @@ func (s *Schema) addNewTable(ctx context.Context, logicalDBName, dbType, dbAlias
 
 	var query, primaryKeyQuery string
 	doesPrimaryKeyExists := false
+	isSQLiteAutoIncrement := false
 	compositePrimaryKeys := make(primaryKeyStore, 0)
 	for realFieldKey, realFieldStruct := range realColValue {
 
@@ -393,6 +430,14 @@ func (s *Schema) addNewTable(ctx context.Context, logicalDBName, dbType, dbAlias
 					default:
 						return "", helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Cannot add autoIncrement constraint on non integer column (%s)", realFieldKey), nil, nil)
 					}
+
+				case model.SQLite:
+					// Sqlite only allows auto increment on a column which is the only primary key and has the type integer
+					if realFieldStruct.Kind != model.TypeInteger {
+						return "", helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Cannot add autoIncrement constraint on column (%s), sqlite only supports it on columns of type Integer", realFieldKey), nil, nil)
+					}
+					isSQLiteAutoIncrement = true
+					autoIncrement = "PRIMARY KEY AUTOINCREMENT"
 				}
 			}
 			primaryKeyQuery += fmt.Sprintf("%s %s NOT NULL %s, ", realFieldKey, sqlType, autoIncrement)
@@ -408,9 +453,32 @@ func (s *Schema) addNewTable(ctx context.Context, logicalDBName, dbType, dbAlias
 			query += " NOT NULL"
 		}
 
+		// Sqlite cannot add constraints to an existing column. Hence they are added while creating the table
+		if model.DBType(dbType) == model.SQLite {
+			if realFieldStruct.IsDefault {
+				query += " DEFAULT " + formatDefaultValue(dbType, realFieldStruct.Default)
+			}
+			if realFieldStruct.IsForeign {
+				query += " " + sqliteForeignKeyClause(realFieldStruct)
+			}
+		}
+
 		query += " ,"
 	}
 
+	if isSQLiteAutoIncrement {
+		// The primary key has already been declared along with the auto increment column
+		if len(compositePrimaryKeys) > 1 {
+			return "", helpers.Logger.LogError(helpers.GetRequestID(ctx), "Cannot add autoIncrement constraint on a composite primary key in sqlite", nil, nil)
+		}
+		doesPrimaryKeyExists = false
+		query = strings.TrimSuffix(query, " ,")
+		primaryKeyQuery = strings.TrimSuffix(primaryKeyQuery, ", ")
+		if query != "" {
+			primaryKeyQuery += ", "
+		}
+	}
+
 	if doesPrimaryKeyExists {
 		compositePrimaryKeyQuery, err := getCompositePrimaryKeyQuery(ctx, compositePrimaryKeys)
 		if err != nil {
@@ -422,6 +490,15 @@ func (s *Schema) addNewTable(ctx context.Context, logicalDBName, dbType, dbAlias
 	return `CREATE TABLE ` + s.getTableName(dbType, logicalDBName, realColName) + ` (` + primaryKeyQuery + strings.TrimSuffix(query, " ,") + `);`, nil
 }
 
+// sqliteForeignKeyClause returns the inline foreign key constraint of a sqlite column
+func sqliteForeignKeyClause(realFieldStruct *model.FieldType) string {
+	query := "CONSTRAINT " + realFieldStruct.JointTable.ConstraintName + " REFERENCES " + realFieldStruct.JointTable.Table + " (" + realFieldStruct.JointTable.To + ")"
+	if realFieldStruct.JointTable.OnDelete == "CASCADE" {
+		query += " ON DELETE CASCADE"
+	}
+	return query
+}
+
 func getCompositePrimaryKeyQuery(ctx context.Context, compositePrimaryKeys primaryKeyStore) (string, error) {
 	finalPrimaryKeyQuery := "PRIMARY KEY ("
 	if len(compositePrimaryKeys) > 1 {
@@ -464,6 +541,11 @@ func (c *creationModule) addColumn(dbType string) []string {
 		queries = append(queries, c.addNewColumn())
 	}
 
+	// The constraints of a sqlite column get added along with the column itself
+	if dbType == string(model.SQLite) {
+		return queries
+	}
+
 	if c.realColumnInfo.IsFieldTypeRequired {
 		// make the new column not null
 		if dbType == string(model.SQLServer) && c.columnType == "timestamp" {
@@ -531,6 +613,12 @@ func (c *creationModule) modifyColumn(dbType string) []string {
 func (c *creationModule) removeDirectives(dbType string) []string {
 	var queries []string
 
+	// The foreign key and default constraints of a sqlite column are a part of the column definition itself
+	if dbType == string(model.SQLite) {
+		c.currentColumnInfo.IsForeign = false
+		c.currentColumnInfo.IsDefault = false
+	}
+
 	if c.currentColumnInfo.IsForeign {
 		queries = append(queries, c.removeForeignKey()...)
 		c.currentColumnInfo.IsForeign = false
@@ -596,6 +684,8 @@ func (s *Schema) removeIndex(dbType, dbAlias, logicalDBName, tableName, indexNam
 	case model.Postgres:
 		indexname := indexName
 		return "DROP INDEX " + s.getTableName(dbType, logicalDBName, indexname)
+	case model.SQLite:
+		return "DROP INDEX " + indexName
 	}
 	return ""
 }
@@ -219,7 +219,7 @@ func validateUnsetOperation(ctx context.Context, dbType, col string, doc interfa
 		return nil
 	}
 
-	if dbType == string(model.Postgres) || dbType == string(model.MySQL) || dbType == string(model.SQLServer) {
+	if dbType == string(model.Postgres) || dbType == string(model.MySQL) || dbType == string(model.SQLServer) || dbType == string(model.SQLite) {
 		for fieldName := range v {
 			columnInfo, ok := schemaDoc[strings.Split(fieldName, ".")[0]]
 			if ok {
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"strings"
 	"time"
 
 	"github.com/graphql-go/graphql/language/parser"
@@ -288,6 +289,22 @@ func AdjustWhereClause(ctx context.Context, dbAlias string, dbType model.DBType,
 	}
 
 	for k, v := range find {
+		// Adjust the clauses nested inside an or clause as well
+		if strings.HasPrefix(k, "$or") {
+			clauses, ok := v.([]interface{})
+			if !ok {
+				continue
+			}
+			for _, clause := range clauses {
+				if obj, ok := clause.(map[string]interface{}); ok {
+					if err := AdjustWhereClause(ctx, dbAlias, dbType, col, schemaDoc, obj); err != nil {
+						return err
+					}
+				}
+			}
+			continue
+		}
+
 		field, p := tableInfo[k]
 		if !p {
 			continue
@@ -128,6 +128,28 @@ func TestSchema_AdjustWhereClause(t *testing.T) {
 			want:    map[string]interface{}{"col2": "2014-11-12T11:45:26.371Z"},
 			wantErr: false,
 		},
+		{
+			name: "Using param as string inside or clauses",
+			args: args{
+				dbAlias: "mysql",
+				dbType:  "mongo",
+				col:     "table1",
+				find: map[string]interface{}{
+					"$or:cursor": []interface{}{
+						map[string]interface{}{"col2": map[string]interface{}{"$gt": "2014-11-12T11:45:26.371Z"}},
+						map[string]interface{}{"col2": "2014-11-12T11:45:26.371Z", "col1": map[string]interface{}{"$gt": 1}},
+					},
+				},
+				schemaDoc: model.Type{"mysql": model.Collection{"table1": model.Fields{"col2": &model.FieldType{FieldName: "col2", Kind: model.TypeDateTime}}}},
+			},
+			want: map[string]interface{}{
+				"$or:cursor": []interface{}{
+					map[string]interface{}{"col2": map[string]interface{}{"$gt": returntime("2014-11-12T11:45:26.371Z")}},
+					map[string]interface{}{"col2": returntime("2014-11-12T11:45:26.371Z"), "col1": map[string]interface{}{"$gt": 1}},
+				},
+			},
+			wantErr: false,
+		},
 		{
 			name: "Using param as string",
 			args: args{
@@ -100,6 +100,14 @@ func generateInspection(dbType, col string, fields []model.InspectorFieldType, i
 			if err := inspectionSQLServerCheckFieldType(col, field, &fieldDetails); err != nil {
 				return nil, err
 			}
+		case model.SQLite:
+			if err := inspectionSQLiteCheckFieldType(col, field, &fieldDetails); err != nil {
+				return nil, err
+			}
+		case model.EmbeddedDB:
+			if err := inspectionEmbeddedCheckFieldType(col, field, &fieldDetails); err != nil {
+				return nil, err
+			}
 		}
 
 		// default key
@@ -303,6 +311,78 @@ func inspectionSQLServerCheckFieldType(col string, field model.InspectorFieldTyp
 	return nil
 }
 
+func inspectionSQLiteCheckFieldType(col string, field model.InspectorFieldType, fieldDetails *model.FieldType) error {
+	result := strings.Split(field.FieldType, "(")
+
+	switch result[0] {
+	case "date":
+		fieldDetails.Kind = model.TypeDate
+	case "time":
+		fieldDetails.Kind = model.TypeTime
+		if field.DateTimePrecision > 0 {
+			fieldDetails.Args = &model.FieldArgs{
+				Precision: field.DateTimePrecision,
+			}
+		}
+	case "varchar":
+		fieldDetails.Kind = model.TypeVarChar
+		fieldDetails.TypeIDSize = field.VarcharSize
+		if field.VarcharSize == 0 {
+			fieldDetails.TypeIDSize = -1
+		}
+	case "char":
+		fieldDetails.Kind = model.TypeChar
+		fieldDetails.TypeIDSize = field.VarcharSize
+	case "text", "clob":
+		fieldDetails.Kind = model.TypeString
+	case "smallint":
+		fieldDetails.Kind = model.TypeSmallInteger
+	case "bigint":
+		fieldDetails.Kind = model.TypeBigInteger
+	case "integer", "int":
+		fieldDetails.Kind = model.TypeInteger
+	case "real", "float", "double":
+		fieldDetails.Kind = model.TypeFloat
+	case "numeric", "decimal":
+		fieldDetails.Kind = model.TypeDecimal
+		if field.NumericPrecision > 0 || field.NumericScale > 0 {
+			fieldDetails.Args = &model.FieldArgs{
+				Precision: field.NumericPrecision,
+				Scale:     field.NumericScale,
+			}
+		}
+	case "datetime":
+		fieldDetails.Kind = model.TypeDateTime
+	case "timestamp":
+		fieldDetails.Kind = model.TypeDateTimeWithZone
+	case "boolean":
+		fieldDetails.Kind = model.TypeBoolean
+	case "json":
+		fieldDetails.Kind = model.TypeJSON
+	default:
+		return helpers.Logger.LogError("", fmt.Sprintf("Cannot track/inspect table (%s)", col), fmt.Errorf("table contains a column (%s) with type (%s) which is not supported by space cloud", fieldDetails.FieldName, result), nil)
+	}
+	return nil
+}
+
+func inspectionEmbeddedCheckFieldType(col string, field model.InspectorFieldType, fieldDetails *model.FieldType) error {
+	switch field.FieldType {
+	case "string":
+		fieldDetails.Kind = model.TypeString
+	case "integer":
+		fieldDetails.Kind = model.TypeInteger
+	case "float":
+		fieldDetails.Kind = model.TypeFloat
+	case "boolean":
+		fieldDetails.Kind = model.TypeBoolean
+	case "json":
+		fieldDetails.Kind = model.TypeJSON
+	default:
+		return helpers.Logger.LogError("", fmt.Sprintf("Cannot track/inspect table (%s)", col), fmt.Errorf("table contains a column (%s) with type (%s) which is not supported by space cloud", fieldDetails.FieldName, field.FieldType), nil)
+	}
+	return nil
+}
+
 func inspectionPostgresCheckFieldType(col string, field model.InspectorFieldType, fieldDetails *model.FieldType) error {
 	result := strings.Split(field.FieldType, "(")
 
@@ -1956,6 +1956,108 @@ func Test_generateInspection(t *testing.T) {
 		},
 	}
 
+	var sqliteTestCases = []testGenerateInspection{
+		{
+			name: "SQLite field col1 with type Varchar",
+			args: args{
+				dbType: "sqlite",
+				col:    "table1",
+				fields: []model.InspectorFieldType{{ColumnName: "column1", FieldType: "varchar", FieldNull: "YES", VarcharSize: 20}},
+			},
+			want: model.Collection{"table1": model.Fields{"column1": &model.FieldType{FieldName: "column1", Kind: model.TypeVarChar, TypeIDSize: 20}}},
+		},
+		{
+			name: "SQLite field col1 with type Varchar without size",
+			args: args{
+				dbType: "sqlite",
+				col:    "table1",
+				fields: []model.InspectorFieldType{{ColumnName: "column1", FieldType: "varchar", FieldNull: "YES"}},
+			},
+			want: model.Collection{"table1": model.Fields{"column1": &model.FieldType{FieldName: "column1", Kind: model.TypeVarChar, TypeIDSize: -1}}},
+		},
+		{
+			name: "SQLite field col1 with type String",
+			args: args{
+				dbType: "sqlite",
+				col:    "table1",
+				fields: []model.InspectorFieldType{{ColumnName: "column1", FieldType: "text", FieldNull: "YES"}},
+			},
+			want: model.Collection{"table1": model.Fields{"column1": &model.FieldType{FieldName: "column1", Kind: model.TypeString}}},
+		},
+		{
+			name: "SQLite field col1 with type Boolean",
+			args: args{
+				dbType: "sqlite",
+				col:    "table1",
+				fields: []model.InspectorFieldType{{ColumnName: "column1", FieldType: "boolean", FieldNull: "YES"}},
+			},
+			want: model.Collection{"table1": model.Fields{"column1": &model.FieldType{FieldName: "column1", Kind: model.TypeBoolean}}},
+		},
+		{
+			name: "SQLite field col1 with type Integer",
+			args: args{
+				dbType: "sqlite",
+				col:    "table1",
+				fields: []model.InspectorFieldType{{ColumnName: "column1", FieldType: "integer", FieldNull: "YES"}},
+			},
+			want: model.Collection{"table1": model.Fields{"column1": &model.FieldType{FieldName: "column1", Kind: model.TypeInteger}}},
+		},
+		{
+			name: "SQLite field col1 with type Float",
+			args: args{
+				dbType: "sqlite",
+				col:    "table1",
+				fields: []model.InspectorFieldType{{ColumnName: "column1", FieldType: "double", FieldNull: "YES"}},
+			},
+			want: model.Collection{"table1": model.Fields{"column1": &model.FieldType{FieldName: "column1", Kind: model.TypeFloat}}},
+		},
+		{
+			name: "SQLite field col1 with type Decimal",
+			args: args{
+				dbType: "sqlite",
+				col:    "table1",
+				fields: []model.InspectorFieldType{{ColumnName: "column1", FieldType: "decimal", FieldNull: "YES", NumericPrecision: 10, NumericScale: 2}},
+			},
+			want: model.Collection{"table1": model.Fields{"column1": &model.FieldType{FieldName: "column1", Kind: model.TypeDecimal, Args: &model.FieldArgs{Precision: 10, Scale: 2}}}},
+		},
+		{
+			name: "SQLite field col1 with type DateTime",
+			args: args{
+				dbType: "sqlite",
+				col:    "table1",
+				fields: []model.InspectorFieldType{{ColumnName: "column1", FieldType: "datetime", FieldNull: "YES"}},
+			},
+			want: model.Collection{"table1": model.Fields{"column1": &model.FieldType{FieldName: "column1", Kind: model.TypeDateTime}}},
+		},
+		{
+			name: "SQLite field col1 with type DateTimeWithZone",
+			args: args{
+				dbType: "sqlite",
+				col:    "table1",
+				fields: []model.InspectorFieldType{{ColumnName: "column1", FieldType: "timestamp", FieldNull: "YES"}},
+			},
+			want: model.Collection{"table1": model.Fields{"column1": &model.FieldType{FieldName: "column1", Kind: model.TypeDateTimeWithZone}}},
+		},
+		{
+			name: "SQLite field col1 with type JSON",
+			args: args{
+				dbType: "sqlite",
+				col:    "table1",
+				fields: []model.InspectorFieldType{{ColumnName: "column1", FieldType: "json", FieldNull: "YES"}},
+			},
+			want: model.Collection{"table1": model.Fields{"column1": &model.FieldType{FieldName: "column1", Kind: model.TypeJSON}}},
+		},
+		{
+			name: "SQLite field col1 with type Blob",
+			args: args{
+				dbType: "sqlite",
+				col:    "table1",
+				fields: []model.InspectorFieldType{{ColumnName: "column1", FieldType: "blob", FieldNull: "YES"}},
+			},
+			wantErr: true,
+		},
+	}
+
 	var miscellaneousTestCases = []testGenerateInspection{
 		{
 			name: "identify varchar with any size",
@@ -1991,6 +2093,7 @@ func Test_generateInspection(t *testing.T) {
 	testCases = append(testCases, uniqueKeyTestCases...)
 	testCases = append(testCases, indexKeyTestCases...)
 	testCases = append(testCases, miscellaneousTestCases...)
+	testCases = append(testCases, sqliteTestCases...)
 
 	for _, tt := range testCases {
 		t.Run(tt.name, func(t *testing.T) {
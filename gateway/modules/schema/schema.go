@@ -59,6 +59,22 @@ func (s *Schema) GetSchema(dbAlias, col string) (model.Fields, bool) {
 	return fields, true
 }
 
+// GetSchemas returns the parsed schema of all databases
+func (s *Schema) GetSchemas() model.Type {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	schemas := make(model.Type, len(s.SchemaDoc))
+	for dbAlias, dbSchema := range s.SchemaDoc {
+		cols := make(model.Collection, len(dbSchema))
+		for col, fields := range dbSchema {
+			cols[col] = fields
+		}
+		schemas[dbAlias] = cols
+	}
+	return schemas
+}
+
 // parseSchema Initializes Schema field in Module struct
 func (s *Schema) parseSchema(crud config.DatabaseSchemas) error {
 	schema, err := schemaHelpers.Parser(crud)
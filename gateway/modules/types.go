@@ -23,4 +23,6 @@ type RealtimeInterface interface {
 type GraphQLInterface interface {
 	GetDBAlias(ctx context.Context, field *ast.Field, token string, store utils.M) (string, error)
 	ExecGraphQLQuery(ctx context.Context, req *model.GraphQLRequest, token string, cb model.GraphQLCallback)
+	ResolvePersistedQuery(ctx context.Context, req *model.GraphQLRequest, token string) error
+	GetSchemaSDL() (string, error)
 }
@@ -0,0 +1,149 @@
+package userman
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/spaceuptech/space-cloud/gateway/config"
+)
+
+// oauthState is carried through the provider in the state parameter. It is encrypted so that the gateway
+// doesn't need to store anything between the login and the callback which makes it work across replicas
+type oauthState struct {
+	Provider     string `json:"p"`
+	DBAlias      string `json:"d"`
+	Nonce        string `json:"n"`
+	CodeVerifier string `json:"v"`
+	RedirectURL  string `json:"r,omitempty"`
+	ExpiresAt    int64  `json:"e"`
+}
+
+// generateRandomString returns a url safe random string with the provided number of random bytes
+func generateRandomString(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := io.ReadFull(rand.Reader, b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// generateCodeChallenge returns the S256 code challenge of a pkce code verifier
+func generateCodeChallenge(verifier string) string {
+	hash := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(hash[:])
+}
+
+// getStateKey derives the key used to encrypt the state from the aes key of the project and the client secret
+func getStateKey(aesKey []byte, stub *config.AuthStub) ([]byte, error) {
+	if len(aesKey) == 0 && stub.Secret == "" {
+		return nil, errors.New("either the aes key of the project or the client secret of the provider is required to sign in with oauth")
+	}
+	hash := sha256.New()
+	_, _ = hash.Write(aesKey)
+	_, _ = hash.Write([]byte(stub.ID + "/" + stub.ClientID + "/" + stub.Secret))
+	return hash.Sum(nil), nil
+}
+
+func encryptState(key []byte, state *oauthState) (string, error) {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return "", err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return "", err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(gcm.Seal(nonce, nonce, data, nil)), nil
+}
+
+func decryptState(key []byte, value string) (*oauthState, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, errors.New("invalid state provided")
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < gcm.NonceSize() {
+		return nil, errors.New("invalid state provided")
+	}
+	data, err = gcm.Open(nil, data[:gcm.NonceSize()], data[gcm.NonceSize():], nil)
+	if err != nil {
+		return nil, errors.New("invalid state provided")
+	}
+	state := new(oauthState)
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, errors.New("invalid state provided")
+	}
+	return state, nil
+}
+
+// validateState checks that the state was issued for the same provider and browser and hasn't expired
+func validateState(state *oauthState, provider, dbAlias, cookieNonce string, now time.Time) error {
+	if state.Provider != provider || state.DBAlias != dbAlias {
+		return errors.New("state was issued for a different provider")
+	}
+	if now.Unix() > state.ExpiresAt {
+		return errors.New("sign in request has expired")
+	}
+	if cookieNonce == "" || subtle.ConstantTimeCompare([]byte(state.Nonce), []byte(cookieNonce)) != 1 {
+		return errors.New("state doesn't belong to this browser")
+	}
+	return nil
+}
+
+// isRedirectURLAllowed checks if the app url matches one of the allowed redirect urls. The scheme and host must match
+// exactly. An allowed url ending with `*` matches all the paths having the path of the allowed url as a prefix
+func isRedirectURLAllowed(stub *config.AuthStub, redirectURL string) bool {
+	u, err := url.Parse(redirectURL)
+	if err != nil || u.Scheme == "" || u.Host == "" || u.User != nil {
+		return false
+	}
+	for _, allowed := range stub.AllowedRedirectURLs {
+		if allowed == redirectURL {
+			return true
+		}
+		if !strings.HasSuffix(allowed, "*") {
+			continue
+		}
+
+		a, err := url.Parse(strings.TrimSuffix(allowed, "*"))
+		if err != nil || !strings.EqualFold(a.Scheme, u.Scheme) || !strings.EqualFold(a.Host, u.Host) {
+			continue
+		}
+		if strings.HasPrefix(u.EscapedPath(), a.EscapedPath()) {
+			return true
+		}
+	}
+	return false
+}
+
+func getStringField(obj map[string]interface{}, field string) string {
+	if v, ok := obj[field].(string); ok {
+		return v
+	}
+	return ""
+}
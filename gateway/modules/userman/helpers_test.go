@@ -0,0 +1,85 @@
+package userman
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spaceuptech/space-cloud/gateway/config"
+)
+
+func Test_validateState(t *testing.T) {
+	now := time.Now()
+	state := &oauthState{Provider: "google", DBAlias: "db", Nonce: "nonce", ExpiresAt: now.Add(time.Minute).Unix()}
+
+	tests := []struct {
+		name                     string
+		provider, dbAlias, nonce string
+		now                      time.Time
+		wantErr                  bool
+	}{
+		{name: "valid state", provider: "google", dbAlias: "db", nonce: "nonce", now: now},
+		{name: "different provider", provider: "github", dbAlias: "db", nonce: "nonce", now: now, wantErr: true},
+		{name: "different db", provider: "google", dbAlias: "db2", nonce: "nonce", now: now, wantErr: true},
+		{name: "expired state", provider: "google", dbAlias: "db", nonce: "nonce", now: now.Add(2 * time.Minute), wantErr: true},
+		{name: "missing cookie", provider: "google", dbAlias: "db", now: now, wantErr: true},
+		{name: "different cookie", provider: "google", dbAlias: "db", nonce: "other", now: now, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateState(state, tt.provider, tt.dbAlias, tt.nonce, tt.now); (err != nil) != tt.wantErr {
+				t.Errorf("validateState() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_encryptState(t *testing.T) {
+	key, err := getStateKey([]byte("aes-key"), &config.AuthStub{ID: "google"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherKey, _ := getStateKey([]byte("aes-key"), &config.AuthStub{ID: "google", Secret: "rotated"})
+
+	value, err := encryptState(key, &oauthState{Provider: "google", Nonce: "nonce"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	state, err := decryptState(key, value)
+	if err != nil || state.Provider != "google" || state.Nonce != "nonce" {
+		t.Errorf("decryptState() got = %v, err = %v", state, err)
+	}
+	if _, err := decryptState(otherKey, value); err == nil {
+		t.Errorf("decryptState() decrypted state with a different key")
+	}
+	if _, err := decryptState(key, value[:len(value)-2]); err == nil {
+		t.Errorf("decryptState() decrypted a tampered state")
+	}
+	if _, err := getStateKey(nil, &config.AuthStub{ID: "google"}); err == nil {
+		t.Errorf("getStateKey() expected error when no key material is available")
+	}
+}
+
+func Test_isRedirectURLAllowed(t *testing.T) {
+	stub := &config.AuthStub{AllowedRedirectURLs: []string{"https://app.com/callback", "https://admin.app.com/*", "https://app.example.com*"}}
+	tests := []struct {
+		url  string
+		want bool
+	}{
+		{url: "https://app.com/callback", want: true},
+		{url: "https://app.com/callback/other", want: false},
+		{url: "https://admin.app.com/users?id=1", want: true},
+		{url: "https://admin.app.com.evil.com/", want: false},
+		{url: "/relative", want: false},
+		{url: "https://app.example.com/any/path", want: true},
+		{url: "https://app.example.com.evil.com/", want: false},
+		{url: "https://app.example.com@evil.com/", want: false},
+		{url: "http://app.example.com/", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			if got := isRedirectURLAllowed(stub, tt.url); got != tt.want {
+				t.Errorf("isRedirectURLAllowed() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,360 @@
+package userman
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/spaceuptech/helpers"
+
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/spaceuptech/space-cloud/gateway/config"
+	"github.com/spaceuptech/space-cloud/gateway/model"
+	"github.com/spaceuptech/space-cloud/gateway/utils"
+)
+
+const (
+	// ProviderOAuth2 is used for plain oauth2 providers which expose a user info endpoint
+	ProviderOAuth2 string = "oauth2"
+
+	// ProviderOIDC is used for openid connect providers
+	ProviderOIDC string = "oidc"
+
+	// stateExpiry is the time the user has to complete the sign in at the provider
+	stateExpiry = 10 * time.Minute
+)
+
+// oauthEndpoints holds the resolved endpoints of a provider
+type oauthEndpoints struct {
+	authURL, tokenURL, userInfoURL string
+	oidc                           *oidcProvider
+}
+
+// oauthTokenResponse is the response of the token endpoint of the provider
+type oauthTokenResponse struct {
+	AccessToken      string `json:"access_token"`
+	IDToken          string `json:"id_token"`
+	TokenType        string `json:"token_type"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// OAuthLogin returns the url of the provider the user needs to be redirected to for signing in along with a nonce
+// which must be stored in a cookie of the browser and provided back during the callback
+func (m *Module) OAuthLogin(ctx context.Context, dbAlias, project, provider, appRedirectURL string) (int, string, string, error) {
+	stub, key, err := m.getOAuthProvider(provider)
+	if err != nil {
+		return http.StatusNotFound, "", "", err
+	}
+	if appRedirectURL != "" && !isRedirectURLAllowed(stub, appRedirectURL) {
+		return http.StatusBadRequest, "", "", helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Redirect url (%s) is not allowed for provider (%s)", appRedirectURL, provider), nil, nil)
+	}
+
+	endpoints, err := m.getOAuthEndpoints(ctx, stub)
+	if err != nil {
+		return http.StatusBadGateway, "", "", err
+	}
+
+	nonce, err := generateRandomString(32)
+	if err != nil {
+		return http.StatusInternalServerError, "", "", err
+	}
+	verifier, err := generateRandomString(32)
+	if err != nil {
+		return http.StatusInternalServerError, "", "", err
+	}
+
+	state, err := encryptState(key, &oauthState{Provider: provider, DBAlias: dbAlias, Nonce: nonce, CodeVerifier: verifier, RedirectURL: appRedirectURL, ExpiresAt: time.Now().Add(stateExpiry).Unix()})
+	if err != nil {
+		return http.StatusInternalServerError, "", "", helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to create oauth state", err, nil)
+	}
+
+	authURL, err := url.Parse(endpoints.authURL)
+	if err != nil {
+		return http.StatusInternalServerError, "", "", helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Invalid authorization url provided for provider (%s)", provider), err, nil)
+	}
+	params := authURL.Query()
+	params.Set("response_type", "code")
+	params.Set("client_id", stub.ClientID)
+	params.Set("redirect_uri", stub.RedirectURL)
+	params.Set("scope", strings.Join(getScopes(stub), " "))
+	params.Set("state", state)
+	params.Set("code_challenge", generateCodeChallenge(verifier))
+	params.Set("code_challenge_method", "S256")
+	if stub.Type == ProviderOIDC {
+		params.Set("nonce", nonce)
+	}
+	authURL.RawQuery = params.Encode()
+
+	return http.StatusOK, authURL.String(), nonce, nil
+}
+
+// OAuthCallback exchanges the authorization code for the identity of the user, upserts the user in the users table
+// and returns a JWT token. The app url the user needs to be redirected to is returned if one was provided during login
+func (m *Module) OAuthCallback(ctx context.Context, dbAlias, project, provider, code, state, cookieNonce string) (int, map[string]interface{}, string, error) {
+	stub, key, err := m.getOAuthProvider(provider)
+	if err != nil {
+		return http.StatusNotFound, nil, "", err
+	}
+
+	stateObj, err := decryptState(key, state)
+	if err != nil {
+		return http.StatusBadRequest, nil, "", helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to process oauth callback", err, nil)
+	}
+	if err := validateState(stateObj, provider, dbAlias, cookieNonce, time.Now()); err != nil {
+		return http.StatusBadRequest, nil, "", helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to process oauth callback", err, nil)
+	}
+	if code == "" {
+		return http.StatusBadRequest, nil, "", helpers.Logger.LogError(helpers.GetRequestID(ctx), "Authorization code not provided in oauth callback", nil, nil)
+	}
+
+	endpoints, err := m.getOAuthEndpoints(ctx, stub)
+	if err != nil {
+		return http.StatusBadGateway, nil, "", err
+	}
+
+	tokens, err := m.exchangeCode(ctx, stub, endpoints.tokenURL, code, stateObj.CodeVerifier)
+	if err != nil {
+		return http.StatusUnauthorized, nil, "", err
+	}
+
+	profile, err := m.getOAuthProfile(ctx, stub, endpoints, tokens, stateObj.Nonce)
+	if err != nil {
+		return http.StatusUnauthorized, nil, "", err
+	}
+
+	status, res, err := m.upsertOAuthUser(ctx, stub, dbAlias, project, profile)
+	if err != nil {
+		return status, nil, "", err
+	}
+	return http.StatusOK, res, stateObj.RedirectURL, nil
+}
+
+func (m *Module) getOAuthProvider(provider string) (*config.AuthStub, []byte, error) {
+	m.RLock()
+	defer m.RUnlock()
+
+	stub, ok := m.methods[provider]
+	if !ok || !stub.Enabled || (stub.Type != ProviderOAuth2 && stub.Type != ProviderOIDC) {
+		return nil, nil, fmt.Errorf("Sign in with provider (%s) is not enabled", provider)
+	}
+	key, err := getStateKey(m.aesKey, stub)
+	if err != nil {
+		return nil, nil, err
+	}
+	return stub, key, nil
+}
+
+// getOAuthEndpoints returns the endpoints of the provider. Endpoints of oidc providers are discovered from the
+// issuer unless they are explicitly configured
+func (m *Module) getOAuthEndpoints(ctx context.Context, stub *config.AuthStub) (*oauthEndpoints, error) {
+	endpoints := &oauthEndpoints{authURL: stub.AuthURL, tokenURL: stub.TokenURL, userInfoURL: stub.UserInfoURL}
+	if stub.Type == ProviderOIDC {
+		p, err := m.getOIDCProvider(ctx, stub.Issuer, false)
+		if err != nil {
+			return nil, err
+		}
+		endpoints.oidc = p
+		if endpoints.authURL == "" {
+			endpoints.authURL = p.AuthURL
+		}
+		if endpoints.tokenURL == "" {
+			endpoints.tokenURL = p.TokenURL
+		}
+		if endpoints.userInfoURL == "" {
+			endpoints.userInfoURL = p.UserInfoURL
+		}
+	}
+
+	if endpoints.authURL == "" || endpoints.tokenURL == "" {
+		return nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Authorization and token urls are required for provider (%s)", stub.ID), nil, nil)
+	}
+	if stub.Type == ProviderOAuth2 && endpoints.userInfoURL == "" {
+		return nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("User info url is required for oauth2 provider (%s)", stub.ID), nil, nil)
+	}
+	return endpoints, nil
+}
+
+func (m *Module) exchangeCode(ctx context.Context, stub *config.AuthStub, tokenURL, code, verifier string) (*oauthTokenResponse, error) {
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", stub.RedirectURL)
+	form.Set("client_id", stub.ClientID)
+	form.Set("code_verifier", verifier)
+	if stub.Secret != "" {
+		form.Set("client_secret", stub.Secret)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	res, err := m.httpClient.Do(req)
+	if err != nil {
+		return nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Unable to exchange authorization code with provider (%s)", stub.ID), err, nil)
+	}
+	defer utils.CloseTheCloser(res.Body)
+
+	tokens := new(oauthTokenResponse)
+	if err := json.NewDecoder(res.Body).Decode(tokens); err != nil {
+		return nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Invalid token response received from provider (%s)", stub.ID), err, nil)
+	}
+	if res.StatusCode != http.StatusOK || tokens.Error != "" {
+		return nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Provider (%s) rejected the authorization code - %s %s", stub.ID, tokens.Error, tokens.ErrorDescription), nil, map[string]interface{}{"statusCode": res.StatusCode})
+	}
+	return tokens, nil
+}
+
+// getOAuthProfile returns the claims of the user. The id token is used for oidc providers with the user info
+// endpoint as a fallback in case the id token doesn't contain the email of the user
+func (m *Module) getOAuthProfile(ctx context.Context, stub *config.AuthStub, endpoints *oauthEndpoints, tokens *oauthTokenResponse, nonce string) (map[string]interface{}, error) {
+	profile := map[string]interface{}{}
+	if stub.Type == ProviderOIDC {
+		if tokens.IDToken == "" {
+			return nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Id token not returned by provider (%s)", stub.ID), nil, nil)
+		}
+		claims, err := m.verifyIDToken(ctx, stub, endpoints.oidc, tokens.IDToken, nonce)
+		if err != nil {
+			return nil, err
+		}
+		profile = claims
+	}
+
+	if getStringField(profile, getEmailField(stub)) == "" && endpoints.userInfoURL != "" {
+		if tokens.AccessToken == "" {
+			return nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Access token not returned by provider (%s)", stub.ID), nil, nil)
+		}
+		userInfo := map[string]interface{}{}
+		if err := m.getJSON(ctx, endpoints.userInfoURL, tokens.AccessToken, &userInfo); err != nil {
+			return nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Unable to fetch user info from provider (%s)", stub.ID), err, nil)
+		}
+		if sub, ok := profile["sub"]; ok && userInfo["sub"] != sub {
+			return nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), "Subject of user info doesn't match the id token", nil, nil)
+		}
+		for k, v := range userInfo {
+			profile[k] = v
+		}
+	}
+
+	if getStringField(profile, getEmailField(stub)) == "" {
+		return nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Provider (%s) didn't return the email of the user", stub.ID), nil, nil)
+	}
+	if verified, ok := profile["email_verified"].(bool); ok && !verified {
+		return nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), "Email of the user hasn't been verified by the provider", nil, nil)
+	}
+	return profile, nil
+}
+
+// upsertOAuthUser creates the user if one with the same email doesn't exist yet and returns a JWT token for it
+func (m *Module) upsertOAuthUser(ctx context.Context, stub *config.AuthStub, dbAlias, project string, profile map[string]interface{}) (int, map[string]interface{}, error) {
+	email := getStringField(profile, getEmailField(stub))
+	name := getStringField(profile, getNameField(stub))
+
+	actualDbType, err := m.crud.GetDBType(dbAlias)
+	if err != nil {
+		return http.StatusInternalServerError, nil, err
+	}
+	idField := "id"
+	if actualDbType == string(model.Mongo) || actualDbType == string(model.EmbeddedDB) {
+		idField = "_id"
+	}
+
+	// Create read request. All the matching users are read so that a missing user can be told apart from a failed read
+	limit := int64(1)
+	attr := map[string]string{"project": project, "db": dbAlias, "col": "users"}
+	reqParams := model.RequestParams{Resource: "db-read", Op: "access", Attributes: attr}
+	readReq := &model.ReadRequest{Find: map[string]interface{}{"email": email}, Operation: utils.All, Options: &model.ReadOptions{Limit: &limit}}
+	result, _, err := m.crud.Read(ctx, dbAlias, "users", readReq, reqParams)
+	if err != nil {
+		return http.StatusInternalServerError, nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), "Failed to read user account", err, nil)
+	}
+	users, _ := result.([]interface{})
+
+	var userObj map[string]interface{}
+	if len(users) > 0 {
+		userObj, _ = users[0].(map[string]interface{})
+		if userObj == nil {
+			return http.StatusInternalServerError, nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Invalid user account of type (%T) received", users[0]), nil, nil)
+		}
+
+		// Users are matched by email across providers. Hence an existing account is only handed over if the provider has
+		// verified that the user owns the email
+		if !isEmailVerified(stub, profile) {
+			return http.StatusConflict, nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), "User with provided email already exists. Sign in the way the account was created since the provider hasn't verified the email", nil, nil)
+		}
+
+		if name != "" && userObj["name"] != name {
+			reqParams.Resource = "db-update"
+			updateReq := &model.UpdateRequest{Find: map[string]interface{}{idField: userObj[idField]}, Operation: utils.One, Update: map[string]interface{}{"$set": map[string]interface{}{"name": name}}}
+			if err := m.crud.Update(ctx, dbAlias, "users", updateReq, reqParams); err != nil {
+				return http.StatusInternalServerError, nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), "Failed to update user account", err, nil)
+			}
+			userObj["name"] = name
+		}
+	} else {
+		role := stub.DefaultRole
+		if role == "" {
+			role = "user"
+		}
+		userObj = map[string]interface{}{idField: uuid.NewV1().String(), "email": email, "name": name, "role": role}
+
+		reqParams.Resource = "db-create"
+		createReq := &model.CreateRequest{Operation: utils.One, Document: userObj}
+		if err := m.crud.Create(ctx, dbAlias, "users", createReq, reqParams); err != nil {
+			return http.StatusInternalServerError, nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), "Failed to create user account", err, nil)
+		}
+	}
+
+	// Delete password from user
+	delete(userObj, "pass")
+
+	token, err := m.auth.CreateToken(ctx, map[string]interface{}{"id": userObj[idField], "email": email, "role": userObj["role"]})
+	if err != nil {
+		return http.StatusInternalServerError, nil, errors.New("Failed to create a JWT token")
+	}
+	return http.StatusOK, map[string]interface{}{"user": userObj, "token": token}, nil
+}
+
+// isEmailVerified checks if the provider has explicitly marked the email of the user as verified. Providers which
+// don't return the claim or return it as a string aren't trusted unless the provider is configured to be trusted
+func isEmailVerified(stub *config.AuthStub, profile map[string]interface{}) bool {
+	if stub.TrustEmail {
+		return true
+	}
+	verified, ok := profile["email_verified"].(bool)
+	return ok && verified
+}
+
+func getScopes(stub *config.AuthStub) []string {
+	if len(stub.Scopes) > 0 {
+		return stub.Scopes
+	}
+	if stub.Type == ProviderOIDC {
+		return []string{"openid", "email", "profile"}
+	}
+	return []string{"email"}
+}
+
+func getEmailField(stub *config.AuthStub) string {
+	if stub.EmailField != "" {
+		return stub.EmailField
+	}
+	return "email"
+}
+
+func getNameField(stub *config.AuthStub) string {
+	if stub.NameField != "" {
+		return stub.NameField
+	}
+	return "name"
+}
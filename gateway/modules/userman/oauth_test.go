@@ -0,0 +1,399 @@
+package userman
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/lestrrat-go/jwx/jwk"
+
+	"github.com/spaceuptech/space-cloud/gateway/config"
+	"github.com/spaceuptech/space-cloud/gateway/model"
+	"github.com/spaceuptech/space-cloud/gateway/utils"
+)
+
+type mockCrud struct {
+	users   []map[string]interface{}
+	updates int
+	readErr error
+}
+
+func (c *mockCrud) GetDBType(dbAlias string) (string, error) {
+	return string(model.Postgres), nil
+}
+
+func (c *mockCrud) Read(ctx context.Context, dbAlias, col string, req *model.ReadRequest, params model.RequestParams) (interface{}, *model.SQLMetaData, error) {
+	if c.readErr != nil {
+		return nil, nil, c.readErr
+	}
+	docs := []interface{}{}
+	for _, user := range c.users {
+		if user["email"] == req.Find["email"] {
+			obj := map[string]interface{}{}
+			for k, v := range user {
+				obj[k] = v
+			}
+			docs = append(docs, obj)
+		}
+	}
+	if req.Operation != utils.One {
+		return docs, nil, nil
+	}
+	if len(docs) == 0 {
+		return nil, nil, errors.New("not found")
+	}
+	return docs[0], nil, nil
+}
+
+func (c *mockCrud) Create(ctx context.Context, dbAlias, col string, req *model.CreateRequest, params model.RequestParams) error {
+	c.users = append(c.users, req.Document.(map[string]interface{}))
+	return nil
+}
+
+func (c *mockCrud) Update(ctx context.Context, dbAlias, col string, req *model.UpdateRequest, params model.RequestParams) error {
+	c.updates++
+	for _, user := range c.users {
+		if user["id"] == req.Find["id"] {
+			for k, v := range req.Update["$set"].(map[string]interface{}) {
+				user[k] = v
+			}
+		}
+	}
+	return nil
+}
+
+type mockAuth struct{}
+
+func (a *mockAuth) IsReadOpAuthorised(ctx context.Context, project, dbType, col, token string, req *model.ReadRequest, stub model.ReturnWhereStub) (*model.PostProcess, model.RequestParams, error) {
+	return nil, model.RequestParams{}, nil
+}
+
+func (a *mockAuth) CreateToken(ctx context.Context, tokenClaims model.TokenClaims) (string, error) {
+	data, _ := json.Marshal(tokenClaims)
+	return string(data), nil
+}
+
+func (a *mockAuth) IsUpdateOpAuthorised(ctx context.Context, project, dbType, col, token string, req *model.UpdateRequest) (model.RequestParams, error) {
+	return model.RequestParams{}, nil
+}
+
+// mockIssuer is a minimal oidc provider supporting the authorization code flow with pkce
+type mockIssuer struct {
+	server *httptest.Server
+	key    *rsa.PrivateKey
+
+	// claims of the user which signs in
+	claims   jwt.MapClaims
+	userInfo map[string]interface{}
+
+	// challenges of the issued authorization codes
+	codes map[string]string
+	nonce map[string]string
+}
+
+func newMockIssuer(t *testing.T) *mockIssuer {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	i := &mockIssuer{key: key, codes: map[string]string{}, nonce: map[string]string{}}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"issuer":                 i.server.URL,
+			"authorization_endpoint": i.server.URL + "/authorize",
+			"token_endpoint":         i.server.URL + "/token",
+			"userinfo_endpoint":      i.server.URL + "/userinfo",
+			"jwks_uri":               i.server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		k, err := jwk.New(&key.PublicKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = k.Set(jwk.KeyIDKey, "test-key")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"keys": []interface{}{k}})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		challenge, ok := i.codes[r.PostForm.Get("code")]
+		if !ok || challenge != generateCodeChallenge(r.PostForm.Get("code_verifier")) || r.PostForm.Get("client_secret") != "client-secret" {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"error": "invalid_grant"})
+			return
+		}
+		claims := jwt.MapClaims{"iss": i.server.URL, "aud": "client-id", "exp": time.Now().Add(time.Minute).Unix(), "nonce": i.nonce[r.PostForm.Get("code")]}
+		for k, v := range i.claims {
+			claims[k] = v
+		}
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+		token.Header["kid"] = "test-key"
+		idToken, err := token.SignedString(i.key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"access_token": "access-token", "id_token": idToken, "token_type": "Bearer"})
+	})
+	mux.HandleFunc("/userinfo", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer access-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(i.userInfo)
+	})
+	i.server = httptest.NewServer(mux)
+	return i
+}
+
+// authorize simulates the user signing in at the provider and returns the code and state sent back to the gateway
+func (i *mockIssuer) authorize(t *testing.T, authURL string) (string, string) {
+	u, err := url.Parse(authURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	q := u.Query()
+	if q.Get("code_challenge_method") != "S256" || q.Get("client_id") != "client-id" {
+		t.Fatalf("authorize() got invalid query params - %v", q)
+	}
+	code := "code-" + q.Get("code_challenge")[:8]
+	i.codes[code] = q.Get("code_challenge")
+	i.nonce[code] = q.Get("nonce")
+	return code, q.Get("state")
+}
+
+func TestModule_OAuth(t *testing.T) {
+	type testCase struct {
+		name          string
+		stub          *config.AuthStub
+		users         []map[string]interface{}
+		claims        jwt.MapClaims
+		userInfo      map[string]interface{}
+		appRedirect   string
+		wrongNonce    bool
+		wrongVerifier bool
+		wantLoginErr  bool
+		wantErr       bool
+		readErr       error
+		wantUser      map[string]interface{}
+		wantUpdates   int
+	}
+
+	issuer := newMockIssuer(t)
+	defer issuer.server.Close()
+
+	oidcStub := func() *config.AuthStub {
+		return &config.AuthStub{ID: "mock", Enabled: true, Type: ProviderOIDC, ClientID: "client-id", Secret: "client-secret", Issuer: issuer.server.URL, RedirectURL: "http://localhost:4122/v1/api/project/auth/db/oauth/mock/callback", AllowedRedirectURLs: []string{"http://app.com/*"}}
+	}
+
+	testCases := []testCase{
+		{
+			name:     "oidc sign up of a new user",
+			stub:     oidcStub(),
+			claims:   jwt.MapClaims{"sub": "1", "email": "jane@spaceuptech.com", "email_verified": true, "name": "Jane"},
+			wantUser: map[string]interface{}{"email": "jane@spaceuptech.com", "name": "Jane", "role": "user"},
+		},
+		{
+			name:        "oidc sign in of an existing user updates the name",
+			stub:        oidcStub(),
+			users:       []map[string]interface{}{{"id": "existing", "email": "jane@spaceuptech.com", "name": "Old", "role": "admin"}},
+			claims:      jwt.MapClaims{"sub": "1", "email": "jane@spaceuptech.com", "email_verified": true, "name": "Jane"},
+			appRedirect: "http://app.com/home",
+			wantUser:    map[string]interface{}{"id": "existing", "email": "jane@spaceuptech.com", "name": "Jane", "role": "admin"},
+			wantUpdates: 1,
+		},
+		{
+			name:     "oidc sign in links an existing password account with a verified email",
+			stub:     oidcStub(),
+			users:    []map[string]interface{}{{"id": "existing", "email": "jane@spaceuptech.com", "pass": "hash", "name": "Jane", "role": "user"}},
+			claims:   jwt.MapClaims{"sub": "1", "email": "jane@spaceuptech.com", "email_verified": true, "name": "Jane"},
+			wantUser: map[string]interface{}{"id": "existing", "email": "jane@spaceuptech.com", "name": "Jane", "role": "user"},
+		},
+		{
+			name:    "oidc sign in doesn't link a password account when the email isn't marked as verified",
+			stub:    oidcStub(),
+			users:   []map[string]interface{}{{"id": "existing", "email": "jane@spaceuptech.com", "pass": "hash", "role": "admin"}},
+			claims:  jwt.MapClaims{"sub": "1", "email": "jane@spaceuptech.com"},
+			wantErr: true,
+		},
+		{
+			name:    "oidc sign in doesn't link a passwordless account when the email isn't marked as verified",
+			stub:    oidcStub(),
+			users:   []map[string]interface{}{{"id": "existing", "email": "jane@spaceuptech.com", "role": "admin"}},
+			claims:  jwt.MapClaims{"sub": "1", "email": "jane@spaceuptech.com"},
+			wantErr: true,
+		},
+		{
+			name:     "oauth2 sign in doesn't link an existing account without a verified email",
+			stub:     &config.AuthStub{ID: "mock", Enabled: true, Type: ProviderOAuth2, ClientID: "client-id", Secret: "client-secret", AuthURL: issuer.server.URL + "/authorize", TokenURL: issuer.server.URL + "/token", UserInfoURL: issuer.server.URL + "/userinfo"},
+			users:    []map[string]interface{}{{"id": "existing", "email": "jane@spaceuptech.com", "role": "admin"}},
+			userInfo: map[string]interface{}{"email": "jane@spaceuptech.com"},
+			wantErr:  true,
+		},
+		{
+			name:     "oauth2 sign in links an existing account when the provider is trusted",
+			stub:     &config.AuthStub{ID: "mock", Enabled: true, Type: ProviderOAuth2, ClientID: "client-id", Secret: "client-secret", AuthURL: issuer.server.URL + "/authorize", TokenURL: issuer.server.URL + "/token", UserInfoURL: issuer.server.URL + "/userinfo", TrustEmail: true},
+			users:    []map[string]interface{}{{"id": "existing", "email": "jane@spaceuptech.com", "role": "user"}},
+			userInfo: map[string]interface{}{"email": "jane@spaceuptech.com"},
+			wantUser: map[string]interface{}{"id": "existing", "email": "jane@spaceuptech.com", "role": "user"},
+		},
+		{
+			name:    "oidc sign in doesn't link a password account when email verified is a string",
+			stub:    oidcStub(),
+			users:   []map[string]interface{}{{"id": "existing", "email": "jane@spaceuptech.com", "pass": "hash", "role": "admin"}},
+			claims:  jwt.MapClaims{"sub": "1", "email": "jane@spaceuptech.com", "email_verified": "true"},
+			wantErr: true,
+		},
+		{
+			name:    "user isn't created when the read fails",
+			stub:    oidcStub(),
+			claims:  jwt.MapClaims{"sub": "1", "email": "jane@spaceuptech.com", "email_verified": true},
+			readErr: errors.New("connection refused"),
+			wantErr: true,
+		},
+		{
+			name:     "oidc falls back to user info when the id token has no email",
+			stub:     func() *config.AuthStub { s := oidcStub(); s.DefaultRole = "member"; return s }(),
+			claims:   jwt.MapClaims{"sub": "1"},
+			userInfo: map[string]interface{}{"sub": "1", "email": "jane@spaceuptech.com", "name": "Jane"},
+			wantUser: map[string]interface{}{"email": "jane@spaceuptech.com", "name": "Jane", "role": "member"},
+		},
+		{
+			name:    "oidc rejects unverified emails",
+			stub:    oidcStub(),
+			claims:  jwt.MapClaims{"sub": "1", "email": "jane@spaceuptech.com", "email_verified": false},
+			wantErr: true,
+		},
+		{
+			name:    "oidc rejects id tokens issued for another client",
+			stub:    oidcStub(),
+			claims:  jwt.MapClaims{"sub": "1", "email": "jane@spaceuptech.com", "aud": "another-client"},
+			wantErr: true,
+		},
+		{
+			name:    "oidc rejects id tokens with a different nonce",
+			stub:    oidcStub(),
+			claims:  jwt.MapClaims{"sub": "1", "email": "jane@spaceuptech.com", "nonce": "replayed"},
+			wantErr: true,
+		},
+		{
+			name:       "callback from another browser is rejected",
+			stub:       oidcStub(),
+			claims:     jwt.MapClaims{"sub": "1", "email": "jane@spaceuptech.com"},
+			wrongNonce: true,
+			wantErr:    true,
+		},
+		{
+			name:          "provider rejects a wrong code verifier",
+			stub:          oidcStub(),
+			claims:        jwt.MapClaims{"sub": "1", "email": "jane@spaceuptech.com"},
+			wrongVerifier: true,
+			wantErr:       true,
+		},
+		{
+			name:         "redirect url not in the allowed list",
+			stub:         oidcStub(),
+			appRedirect:  "http://evil.com/home",
+			wantLoginErr: true,
+		},
+		{
+			name:         "disabled provider",
+			stub:         func() *config.AuthStub { s := oidcStub(); s.Enabled = false; return s }(),
+			wantLoginErr: true,
+		},
+		{
+			name:     "oauth2 sign up with custom fields",
+			stub:     &config.AuthStub{ID: "mock", Enabled: true, Type: ProviderOAuth2, ClientID: "client-id", Secret: "client-secret", AuthURL: issuer.server.URL + "/authorize", TokenURL: issuer.server.URL + "/token", UserInfoURL: issuer.server.URL + "/userinfo", EmailField: "mail", NameField: "login"},
+			userInfo: map[string]interface{}{"mail": "jane@spaceuptech.com", "login": "jane"},
+			wantUser: map[string]interface{}{"email": "jane@spaceuptech.com", "name": "jane", "role": "user"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			issuer.claims = tc.claims
+			issuer.userInfo = tc.userInfo
+			crud := &mockCrud{users: tc.users, readErr: tc.readErr}
+			m := Init(crud, &mockAuth{})
+			m.SetConfig(config.Auths{"mock": tc.stub})
+			if err := m.SetProjectAESKey("Olw6AhA/GzSxfhwKLxO7JJsUL6VUwwGEFTgxzoZPy9g="); err != nil {
+				t.Fatal(err)
+			}
+
+			_, authURL, nonce, err := m.OAuthLogin(context.Background(), "db", "project", "mock", tc.appRedirect)
+			if (err != nil) != tc.wantLoginErr {
+				t.Fatalf("OAuthLogin() error = %v, wantErr %v", err, tc.wantLoginErr)
+			}
+			if tc.wantLoginErr {
+				return
+			}
+
+			code, state := issuer.authorize(t, authURL)
+			if tc.wrongNonce {
+				nonce = "another-browser"
+			}
+			if tc.wrongVerifier {
+				issuer.codes[code] = "tampered"
+			}
+
+			_, res, appRedirect, err := m.OAuthCallback(context.Background(), "db", "project", "mock", code, state, nonce)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("OAuthCallback() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if tc.wantErr {
+				return
+			}
+			if appRedirect != tc.appRedirect {
+				t.Errorf("OAuthCallback() got app redirect = %v, want %v", appRedirect, tc.appRedirect)
+			}
+
+			user := res["user"].(map[string]interface{})
+			for k, v := range tc.wantUser {
+				if user[k] != v {
+					t.Errorf("OAuthCallback() got user field %s = %v, want %v", k, user[k], v)
+				}
+			}
+			if user["id"] == nil || len(crud.users) != 1 || crud.updates != tc.wantUpdates {
+				t.Errorf("OAuthCallback() user wasn't upserted - users %v updates %d", crud.users, crud.updates)
+			}
+
+			claims := map[string]interface{}{}
+			if err := json.Unmarshal([]byte(res["token"].(string)), &claims); err != nil {
+				t.Fatal(err)
+			}
+			if claims["id"] != user["id"] || claims["email"] != user["email"] || claims["role"] != user["role"] {
+				t.Errorf("OAuthCallback() got token claims = %v for user %v", claims, user)
+			}
+		})
+	}
+}
+
+func Test_isUnknownKeyID(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{"sub": "1"})
+	token.Header["kid"] = "rotated-key"
+	idToken, err := token.SignedString(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = parseIDToken(&config.AuthStub{}, &oidcProvider{keys: &jwk.Set{}}, idToken)
+	if !isUnknownKeyID(err) {
+		t.Errorf("isUnknownKeyID() = false for error (%v)", err)
+	}
+	if isUnknownKeyID(errors.New("id token was signed with an unknown kid")) {
+		t.Errorf("isUnknownKeyID() = true for an error which only has the same message")
+	}
+}
@@ -0,0 +1,173 @@
+package userman
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/lestrrat-go/jwx/jwk"
+	"github.com/spaceuptech/helpers"
+
+	"github.com/spaceuptech/space-cloud/gateway/config"
+)
+
+// discoveryRefreshInterval is the time after which the discovery document and keys of an issuer are fetched again
+const discoveryRefreshInterval = 1 * time.Hour
+
+// errUnknownKeyID is returned when the id token was signed with a key the issuer hasn't published, which happens when
+// the issuer has rotated its keys
+var errUnknownKeyID = errors.New("id token was signed with an unknown kid")
+
+// oidcProvider holds the discovered endpoints and signing keys of an oidc issuer
+type oidcProvider struct {
+	Issuer      string `json:"issuer"`
+	AuthURL     string `json:"authorization_endpoint"`
+	TokenURL    string `json:"token_endpoint"`
+	UserInfoURL string `json:"userinfo_endpoint"`
+	JwksURL     string `json:"jwks_uri"`
+
+	keys      *jwk.Set
+	refreshAt time.Time
+}
+
+// getOIDCProvider returns the discovery document of the issuer. The document is cached for discoveryRefreshInterval
+func (m *Module) getOIDCProvider(ctx context.Context, issuer string, forceRefresh bool) (*oidcProvider, error) {
+	m.oidcLock.Lock()
+	defer m.oidcLock.Unlock()
+
+	if p, ok := m.oidcProviders[issuer]; ok && !forceRefresh && time.Now().Before(p.refreshAt) {
+		return p, nil
+	}
+
+	p := new(oidcProvider)
+	url := strings.TrimSuffix(issuer, "/") + "/.well-known/openid-configuration"
+	if err := m.getJSON(ctx, url, "", p); err != nil {
+		return nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Unable to discover oidc issuer (%s)", issuer), err, nil)
+	}
+	if p.Issuer != issuer {
+		return nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Issuer (%s) returned by the discovery document doesn't match the configured issuer (%s)", p.Issuer, issuer), nil, nil)
+	}
+
+	if p.JwksURL != "" {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.JwksURL, nil)
+		if err != nil {
+			return nil, err
+		}
+		res, err := m.httpClient.Do(req)
+		if err != nil {
+			return nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Unable to fetch jwks of oidc issuer (%s)", issuer), err, nil)
+		}
+		defer func() { _ = res.Body.Close() }()
+		if res.StatusCode != http.StatusOK {
+			return nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Unable to fetch jwks of oidc issuer (%s), server returned status code (%v)", issuer, res.StatusCode), nil, nil)
+		}
+		p.keys, err = jwk.Parse(res.Body)
+		if err != nil {
+			return nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Unable to parse jwks of oidc issuer (%s)", issuer), err, nil)
+		}
+	}
+
+	p.refreshAt = time.Now().Add(discoveryRefreshInterval)
+	m.oidcProviders[issuer] = p
+	return p, nil
+}
+
+// verifyIDToken verifies the signature and standard claims of the id token returned by an oidc provider
+func (m *Module) verifyIDToken(ctx context.Context, stub *config.AuthStub, provider *oidcProvider, idToken, nonce string) (map[string]interface{}, error) {
+	claims, err := parseIDToken(stub, provider, idToken)
+	if err != nil && provider.keys != nil && isUnknownKeyID(err) {
+		// The provider may have rotated its keys
+		if provider, err = m.getOIDCProvider(ctx, stub.Issuer, true); err != nil {
+			return nil, err
+		}
+		claims, err = parseIDToken(stub, provider, idToken)
+	}
+	if err != nil {
+		return nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to verify id token returned by the provider", err, nil)
+	}
+
+	if iss, _ := claims["iss"].(string); iss != stub.Issuer {
+		return nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Invalid issuer (%s) provided in id token", iss), nil, nil)
+	}
+	if !claims.VerifyAudience(stub.ClientID, true) {
+		return nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), "Id token wasn't issued for this client", nil, nil)
+	}
+	if _, ok := claims["exp"]; !ok {
+		return nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), "Claim (exp) not provided in id token", nil, nil)
+	}
+	if n, _ := claims["nonce"].(string); n != nonce {
+		return nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), "Invalid nonce provided in id token", nil, nil)
+	}
+	return claims, nil
+}
+
+func parseIDToken(stub *config.AuthStub, provider *oidcProvider, idToken string) (jwt.MapClaims, error) {
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(idToken, claims, func(token *jwt.Token) (interface{}, error) {
+		switch token.Method.(type) {
+		case *jwt.SigningMethodRSA, *jwt.SigningMethodECDSA, *jwt.SigningMethodRSAPSS:
+			if provider.keys == nil {
+				return nil, errors.New("issuer doesn't publish any signing keys")
+			}
+			kid, _ := token.Header["kid"].(string)
+			keys := provider.keys.LookupKeyID(kid)
+			if kid == "" && len(provider.keys.Keys) == 1 {
+				keys = provider.keys.Keys
+			}
+			if len(keys) == 0 {
+				return nil, fmt.Errorf("%w (%s)", errUnknownKeyID, kid)
+			}
+			var raw interface{}
+			if err := keys[0].Raw(&raw); err != nil {
+				return nil, err
+			}
+			return raw, nil
+		case *jwt.SigningMethodHMAC:
+			if stub.Secret == "" {
+				return nil, errors.New("client secret is required to verify hmac signed id tokens")
+			}
+			return []byte(stub.Secret), nil
+		default:
+			return nil, fmt.Errorf("invalid id token algorithm (%s) provided", token.Method.Alg())
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+// getJSON performs a get request and decodes the json response in v. The token, if provided, is sent as a bearer token
+func (m *Module) getJSON(ctx context.Context, url, token string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	res, err := m.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = res.Body.Close() }()
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("server returned status code (%v)", res.StatusCode)
+	}
+	return json.NewDecoder(res.Body).Decode(v)
+}
+
+// isUnknownKeyID checks if the id token couldn't be verified since it was signed with an unknown key. The errors
+// returned by the key func are wrapped in a validation error by the jwt library
+func isUnknownKeyID(err error) bool {
+	if vErr, ok := err.(*jwt.ValidationError); ok {
+		err = vErr.Inner
+	}
+	return errors.Is(err, errUnknownKeyID)
+}
@@ -2,7 +2,9 @@ package userman
 
 import (
 	"encoding/base64"
+	"net/http"
 	"sync"
+	"time"
 
 	"github.com/spaceuptech/space-cloud/gateway/config"
 	"github.com/spaceuptech/space-cloud/gateway/model"
@@ -17,11 +19,16 @@ type Module struct {
 
 	// auth module
 	aesKey []byte
+
+	// oauth2 and oidc providers
+	httpClient    *http.Client
+	oidcLock      sync.Mutex
+	oidcProviders map[string]*oidcProvider
 }
 
 // Init creates a new instance of the user management object
 func Init(crud model.CrudUserInterface, auth model.AuthUserInterface) *Module {
-	return &Module{crud: crud, auth: auth}
+	return &Module{crud: crud, auth: auth, httpClient: &http.Client{Timeout: 10 * time.Second}, oidcProviders: map[string]*oidcProvider{}}
 }
 
 // SetConfig sets the config required by the user management module
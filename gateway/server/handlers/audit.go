@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/spaceuptech/helpers"
+
+	"github.com/spaceuptech/space-cloud/gateway/managers/admin"
+	"github.com/spaceuptech/space-cloud/gateway/managers/audit"
+	"github.com/spaceuptech/space-cloud/gateway/model"
+	"github.com/spaceuptech/space-cloud/gateway/utils"
+)
+
+// HandleListAuditLogs is an endpoint handler which lists the entries of the audit log matching the query params
+func HandleListAuditLogs(adminMan *admin.Manager, auditMan *audit.Manager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		// Get the JWT token from header
+		token := utils.GetTokenFromHeader(r)
+		defer utils.CloseTheCloser(r.Body)
+
+		ctx, cancel := context.WithTimeout(r.Context(), time.Duration(utils.DefaultContextTime)*time.Second)
+		defer cancel()
+
+		query := r.URL.Query()
+		filter := &model.AuditLogFilter{
+			Kind:       query.Get("kind"),
+			Actor:      query.Get("actor"),
+			Project:    query.Get("project"),
+			Resource:   query.Get("resource"),
+			ResourceID: query.Get("resourceId"),
+			Verb:       query.Get("verb"),
+			Outcome:    query.Get("outcome"),
+			RequestID:  query.Get("requestId"),
+			From:       query.Get("from"),
+			To:         query.Get("to"),
+		}
+		for key, ptr := range map[string]*int64{"limit": &filter.Limit, "skip": &filter.Skip} {
+			if value := query.Get(key); value != "" {
+				v, err := strconv.ParseInt(value, 10, 64)
+				if err != nil {
+					_ = helpers.Response.SendErrorResponse(ctx, w, http.StatusBadRequest, errors.New("invalid value provided for query param "+key))
+					return
+				}
+				*ptr = v
+			}
+		}
+
+		// Check if the request is authorised
+		if _, err := adminMan.IsTokenValid(ctx, token, "audit-log", "read", map[string]string{"project": filter.Project}); err != nil {
+			_ = helpers.Response.SendErrorResponse(ctx, w, http.StatusUnauthorized, err)
+			return
+		}
+
+		entries, err := auditMan.ListAuditLogs(ctx, filter)
+		if err != nil {
+			_ = helpers.Response.SendErrorResponse(ctx, w, http.StatusBadRequest, err)
+			return
+		}
+		_ = helpers.Response.SendResponse(ctx, w, http.StatusOK, model.Response{Result: entries})
+	}
+}
@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/spaceuptech/helpers"
+
+	"github.com/spaceuptech/space-cloud/gateway/config"
+	"github.com/spaceuptech/space-cloud/gateway/managers/admin"
+	"github.com/spaceuptech/space-cloud/gateway/managers/syncman"
+	"github.com/spaceuptech/space-cloud/gateway/model"
+	"github.com/spaceuptech/space-cloud/gateway/utils"
+)
+
+// HandleSetAdminUser handles the request to add or update an admin user or service account
+func HandleSetAdminUser(adminMan *admin.Manager, syncMan *syncman.Manager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Get the token from the request
+		token := utils.GetTokenFromHeader(r)
+
+		// Get the body of the request
+		req := new(config.AdminUserConfig)
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			_ = helpers.Response.SendErrorResponse(r.Context(), w, http.StatusBadRequest, err)
+			return
+		}
+		defer utils.CloseTheCloser(r.Body)
+
+		ctx, cancel := context.WithTimeout(r.Context(), time.Duration(utils.DefaultContextTime)*time.Second)
+		defer cancel()
+
+		// Validate the token
+		reqParams, err := adminMan.IsTokenValid(ctx, token, "admin-user", "modify", map[string]string{"id": req.ID})
+		if err != nil {
+			_ = helpers.Response.SendErrorResponse(ctx, w, http.StatusForbidden, err)
+			return
+		}
+
+		// The key must never be sent to the integration hooks
+		payload := *req
+		payload.Key = ""
+		reqParams = utils.ExtractRequestParams(r, reqParams, &payload)
+
+		status, err := syncMan.SetAdminUser(ctx, req, reqParams)
+		if err != nil {
+			_ = helpers.Response.SendErrorResponse(ctx, w, status, err)
+			return
+		}
+
+		_ = helpers.Response.SendOkayResponse(ctx, status, w)
+	}
+}
+
+// HandleDeleteAdminUser handles the request to delete an admin user or service account
+func HandleDeleteAdminUser(adminMan *admin.Manager, syncMan *syncman.Manager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Get the token from the request
+		token := utils.GetTokenFromHeader(r)
+		defer utils.CloseTheCloser(r.Body)
+
+		id := mux.Vars(r)["id"]
+
+		ctx, cancel := context.WithTimeout(r.Context(), time.Duration(utils.DefaultContextTime)*time.Second)
+		defer cancel()
+
+		// Validate the token
+		reqParams, err := adminMan.IsTokenValid(ctx, token, "admin-user", "modify", map[string]string{"id": id})
+		if err != nil {
+			_ = helpers.Response.SendErrorResponse(ctx, w, http.StatusForbidden, err)
+			return
+		}
+
+		reqParams = utils.ExtractRequestParams(r, reqParams, nil)
+
+		status, err := syncMan.DeleteAdminUser(ctx, id, reqParams)
+		if err != nil {
+			_ = helpers.Response.SendErrorResponse(ctx, w, status, err)
+			return
+		}
+
+		_ = helpers.Response.SendOkayResponse(ctx, status, w)
+	}
+}
+
+// HandleGetAdminUsers handles the request to get the admin users and service accounts
+func HandleGetAdminUsers(adminMan *admin.Manager, syncMan *syncman.Manager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Get the token from the request
+		token := utils.GetTokenFromHeader(r)
+		defer utils.CloseTheCloser(r.Body)
+
+		id := "*"
+		if v := r.URL.Query().Get("id"); v != "" {
+			id = v
+		}
+
+		ctx, cancel := context.WithTimeout(r.Context(), time.Duration(utils.DefaultContextTime)*time.Second)
+		defer cancel()
+
+		// Validate the token
+		reqParams, err := adminMan.IsTokenValid(ctx, token, "admin-user", "read", map[string]string{"id": id})
+		if err != nil {
+			_ = helpers.Response.SendErrorResponse(ctx, w, http.StatusForbidden, err)
+			return
+		}
+
+		reqParams = utils.ExtractRequestParams(r, reqParams, nil)
+
+		status, users, err := syncMan.GetAdminUsers(ctx, id, reqParams)
+		if err != nil {
+			_ = helpers.Response.SendErrorResponse(ctx, w, status, err)
+			return
+		}
+
+		_ = helpers.Response.SendResponse(ctx, w, status, model.Response{Result: users})
+	}
+}
@@ -9,12 +9,14 @@ import (
 	"github.com/spaceuptech/helpers"
 
 	"github.com/spaceuptech/space-cloud/gateway/managers/admin"
+	"github.com/spaceuptech/space-cloud/gateway/managers/syncman"
 	"github.com/spaceuptech/space-cloud/gateway/model"
 	"github.com/spaceuptech/space-cloud/gateway/utils"
 )
 
-// HandleBatchApplyConfig applies all the config at once
-func HandleBatchApplyConfig(adminMan *admin.Manager) http.HandlerFunc {
+// HandleBatchApplyConfig applies all the config at once. If the dryRun query param is set to true, the changes applying the
+// config would make are returned instead without persisting anything
+func HandleBatchApplyConfig(adminMan *admin.Manager, syncMan *syncman.Manager) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		token := utils.GetTokenFromHeader(r)
 
@@ -25,8 +27,27 @@ func HandleBatchApplyConfig(adminMan *admin.Manager) http.HandlerFunc {
 		ctx, cancel := context.WithTimeout(r.Context(), time.Duration(utils.DefaultContextTime)*time.Second)
 		defer cancel()
 
-		if err := adminMan.CheckIfAdmin(ctx, token); err != nil {
-			_ = helpers.Response.SendErrorResponse(ctx, w, http.StatusUnauthorized, err)
+		// Every spec is authorised the way the endpoint applying it would, so that nothing gets applied or planned
+		// unless the token is allowed to modify all the specs. Specs which aren't a part of the gateway config are
+		// authorised by the endpoint they are applied to
+		for _, specObject := range req.Specs {
+			resource, attr, ok := syncman.GetSpecAuthParams(specObject)
+			if !ok {
+				continue
+			}
+			if _, err := adminMan.IsTokenValid(ctx, token, resource, "modify", attr); err != nil {
+				_ = helpers.Response.SendErrorResponse(ctx, w, http.StatusUnauthorized, err)
+				return
+			}
+		}
+
+		if r.URL.Query().Get("dryRun") == "true" {
+			status, plan, err := syncMan.PlanConfig(ctx, req.Specs)
+			if err != nil {
+				_ = helpers.Response.SendErrorResponse(ctx, w, status, err)
+				return
+			}
+			_ = helpers.Response.SendResponse(ctx, w, status, model.Response{Result: plan})
 			return
 		}
 
@@ -117,7 +117,7 @@ func HandleGetFileState(adminMan *admin.Manager, modules *modules.Modules) http.
 			return
 		}
 
-		if err := file.GetState(ctx); err != nil {
+		if err := file.GetState(ctx, r.URL.Query().Get("store")); err != nil {
 			_ = helpers.Response.SendResponse(ctx, w, http.StatusOK, model.Response{Result: false, Error: err.Error()})
 			return
 		}
@@ -233,3 +233,111 @@ func HandleDeleteFileRule(adminMan *admin.Manager, syncMan *syncman.Manager) htt
 		_ = helpers.Response.SendOkayResponse(ctx, http.StatusOK, w)
 	}
 }
+
+// HandleSetFileStoreAlias sets a named file store of the project
+func HandleSetFileStoreAlias(adminMan *admin.Manager, syncMan *syncman.Manager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		// Get the JWT token from header
+		token := utils.GetTokenFromHeader(r)
+
+		vars := mux.Vars(r)
+		projectID := vars["project"]
+		alias := vars["id"]
+
+		value := new(config.FileStoreConfig)
+		_ = json.NewDecoder(r.Body).Decode(value)
+		defer utils.CloseTheCloser(r.Body)
+
+		ctx, cancel := context.WithTimeout(r.Context(), time.Duration(utils.DefaultContextTime)*time.Second)
+		defer cancel()
+
+		// Check if the request is authorised
+		reqParams, err := adminMan.IsTokenValid(ctx, token, "filestore-config", "modify", map[string]string{"project": projectID, "id": alias})
+		if err != nil {
+			_ = helpers.Response.SendErrorResponse(ctx, w, http.StatusUnauthorized, err)
+			return
+		}
+
+		reqParams = utils.ExtractRequestParams(r, reqParams, value)
+		status, err := syncMan.SetFileStoreAlias(ctx, projectID, alias, value, reqParams)
+		if err != nil {
+			_ = helpers.Response.SendErrorResponse(ctx, w, status, err)
+			return
+		}
+
+		_ = helpers.Response.SendOkayResponse(ctx, status, w)
+	}
+}
+
+// HandleGetFileStoreAliases returns handler to get the named file stores of the project
+func HandleGetFileStoreAliases(adminMan *admin.Manager, syncMan *syncman.Manager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		// Get the JWT token from header
+		token := utils.GetTokenFromHeader(r)
+
+		// get project id and alias
+		vars := mux.Vars(r)
+		projectID := vars["project"]
+		alias := "*"
+		aliasQuery, exists := r.URL.Query()["id"]
+		if exists {
+			alias = aliasQuery[0]
+		}
+
+		ctx, cancel := context.WithTimeout(r.Context(), time.Duration(utils.DefaultContextTime)*time.Second)
+		defer cancel()
+
+		// Check if the request is authorised
+		reqParams, err := adminMan.IsTokenValid(ctx, token, "filestore-config", "read", map[string]string{"project": projectID, "id": alias})
+		if err != nil {
+			_ = helpers.Response.SendErrorResponse(ctx, w, http.StatusUnauthorized, err)
+			return
+		}
+
+		// Create a context of execution
+		reqParams = utils.ExtractRequestParams(r, reqParams, nil)
+
+		status, fileStores, err := syncMan.GetFileStoreAliases(ctx, projectID, alias, reqParams)
+		if err != nil {
+			_ = helpers.Response.SendErrorResponse(ctx, w, status, err)
+			return
+		}
+
+		_ = helpers.Response.SendResponse(ctx, w, status, model.Response{Result: fileStores})
+	}
+}
+
+// HandleDeleteFileStoreAlias deletes a named file store of the project
+func HandleDeleteFileStoreAlias(adminMan *admin.Manager, syncMan *syncman.Manager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		// Get the JWT token from header
+		token := utils.GetTokenFromHeader(r)
+
+		vars := mux.Vars(r)
+		projectID := vars["project"]
+		alias := vars["id"]
+
+		ctx, cancel := context.WithTimeout(r.Context(), time.Duration(utils.DefaultContextTime)*time.Second)
+		defer cancel()
+
+		// Check if the request is authorised
+		reqParams, err := adminMan.IsTokenValid(ctx, token, "filestore-config", "modify", map[string]string{"project": projectID, "id": alias})
+		if err != nil {
+			_ = helpers.Response.SendErrorResponse(ctx, w, http.StatusUnauthorized, err)
+			return
+		}
+
+		// Create a context of execution
+		reqParams = utils.ExtractRequestParams(r, reqParams, nil)
+
+		if status, err := syncMan.DeleteFileStoreAlias(ctx, projectID, alias, reqParams); err != nil {
+			_ = helpers.Response.SendErrorResponse(ctx, w, status, err)
+			return
+		}
+
+		_ = helpers.Response.SendOkayResponse(ctx, http.StatusOK, w)
+	}
+}
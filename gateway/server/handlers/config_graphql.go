@@ -0,0 +1,123 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/spaceuptech/helpers"
+
+	"github.com/spaceuptech/space-cloud/gateway/config"
+	"github.com/spaceuptech/space-cloud/gateway/managers/admin"
+	"github.com/spaceuptech/space-cloud/gateway/managers/syncman"
+	"github.com/spaceuptech/space-cloud/gateway/model"
+	"github.com/spaceuptech/space-cloud/gateway/utils"
+)
+
+// HandleSetGraphQLPersistedQuery is an endpoint handler which registers a persisted graphql query
+func HandleSetGraphQLPersistedQuery(adminMan *admin.Manager, syncMan *syncman.Manager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		// Get the JWT token from header
+		token := utils.GetTokenFromHeader(r)
+
+		vars := mux.Vars(r)
+		id := vars["id"]
+		projectID := vars["project"]
+
+		v := config.GraphQLPersistedQuery{}
+		_ = json.NewDecoder(r.Body).Decode(&v)
+		defer utils.CloseTheCloser(r.Body)
+
+		ctx, cancel := context.WithTimeout(r.Context(), time.Duration(utils.DefaultContextTime)*time.Second)
+		defer cancel()
+
+		// Check if the request is authorised
+		reqParams, err := adminMan.IsTokenValid(ctx, token, string(config.ResourceGraphQLPersistedQuery), "modify", map[string]string{"project": projectID, "id": id})
+		if err != nil {
+			_ = helpers.Response.SendErrorResponse(ctx, w, http.StatusUnauthorized, err)
+			return
+		}
+
+		reqParams = utils.ExtractRequestParams(r, reqParams, v)
+		status, err := syncMan.SetGraphQLPersistedQuery(ctx, projectID, id, &v, reqParams)
+		if err != nil {
+			_ = helpers.Response.SendErrorResponse(ctx, w, status, err)
+			return
+		}
+
+		_ = helpers.Response.SendOkayResponse(ctx, status, w)
+	}
+}
+
+// HandleGetGraphQLPersistedQueries returns handler to get the persisted graphql queries of the project
+func HandleGetGraphQLPersistedQueries(adminMan *admin.Manager, syncMan *syncman.Manager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		// Get the JWT token from header
+		token := utils.GetTokenFromHeader(r)
+
+		vars := mux.Vars(r)
+		projectID := vars["project"]
+		id := "*"
+		idQuery, ok := r.URL.Query()["id"]
+		if ok {
+			id = idQuery[0]
+		}
+
+		ctx, cancel := context.WithTimeout(r.Context(), time.Duration(utils.DefaultContextTime)*time.Second)
+		defer cancel()
+
+		// Check if the request is authorised
+		reqParams, err := adminMan.IsTokenValid(ctx, token, string(config.ResourceGraphQLPersistedQuery), "read", map[string]string{"project": projectID, "id": id})
+		if err != nil {
+			_ = helpers.Response.SendErrorResponse(ctx, w, http.StatusUnauthorized, err)
+			return
+		}
+
+		reqParams = utils.ExtractRequestParams(r, reqParams, nil)
+		status, queries, err := syncMan.GetGraphQLPersistedQueries(ctx, projectID, id, reqParams)
+		if err != nil {
+			_ = helpers.Response.SendErrorResponse(ctx, w, status, err)
+			return
+		}
+
+		_ = helpers.Response.SendResponse(ctx, w, status, model.Response{Result: queries})
+	}
+}
+
+// HandleDeleteGraphQLPersistedQuery is an endpoint handler which deletes a persisted graphql query
+func HandleDeleteGraphQLPersistedQuery(adminMan *admin.Manager, syncMan *syncman.Manager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		// Get the JWT token from header
+		token := utils.GetTokenFromHeader(r)
+
+		vars := mux.Vars(r)
+		id := vars["id"]
+		projectID := vars["project"]
+
+		defer utils.CloseTheCloser(r.Body)
+
+		ctx, cancel := context.WithTimeout(r.Context(), time.Duration(utils.DefaultContextTime)*time.Second)
+		defer cancel()
+
+		// Check if the request is authorised
+		reqParams, err := adminMan.IsTokenValid(ctx, token, string(config.ResourceGraphQLPersistedQuery), "modify", map[string]string{"project": projectID, "id": id})
+		if err != nil {
+			_ = helpers.Response.SendErrorResponse(ctx, w, http.StatusUnauthorized, err)
+			return
+		}
+
+		reqParams = utils.ExtractRequestParams(r, reqParams, nil)
+		status, err := syncMan.DeleteGraphQLPersistedQuery(ctx, projectID, id, reqParams)
+		if err != nil {
+			_ = helpers.Response.SendErrorResponse(ctx, w, status, err)
+			return
+		}
+
+		_ = helpers.Response.SendOkayResponse(ctx, status, w)
+	}
+}
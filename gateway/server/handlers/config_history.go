@@ -0,0 +1,147 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/spaceuptech/helpers"
+
+	"github.com/spaceuptech/space-cloud/gateway/managers/admin"
+	"github.com/spaceuptech/space-cloud/gateway/managers/syncman"
+	"github.com/spaceuptech/space-cloud/gateway/model"
+	"github.com/spaceuptech/space-cloud/gateway/utils"
+)
+
+// HandleGetConfigHistory returns handler to get the config history of the project
+func HandleGetConfigHistory(adminMan *admin.Manager, syncMan *syncman.Manager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		// Get the JWT token from header
+		token := utils.GetTokenFromHeader(r)
+		defer utils.CloseTheCloser(r.Body)
+
+		vars := mux.Vars(r)
+		projectID := vars["project"]
+		resourceID := r.URL.Query().Get("resourceId")
+
+		ctx, cancel := context.WithTimeout(r.Context(), time.Duration(utils.DefaultContextTime)*time.Second)
+		defer cancel()
+
+		// The history carries the config of every resource of the project, hence it requires write access. The secrets
+		// and connection strings are removed unless the token is allowed to read them
+		reqParams, err := adminMan.IsTokenValid(ctx, token, "project", "modify", map[string]string{"project": projectID})
+		if err != nil {
+			_ = helpers.Response.SendErrorResponse(ctx, w, http.StatusUnauthorized, err)
+			return
+		}
+
+		reqParams = utils.ExtractRequestParams(r, reqParams, nil)
+		status, entries, err := syncMan.GetConfigHistory(ctx, projectID, resourceID, reqParams)
+		if err != nil {
+			_ = helpers.Response.SendErrorResponse(ctx, w, status, err)
+			return
+		}
+
+		if !adminMan.CanReadProjectSecrets(ctx, token, projectID) {
+			entries = syncman.RemoveConfigHistorySecrets(entries)
+		}
+
+		_ = helpers.Response.SendResponse(ctx, w, status, model.Response{Result: entries})
+	}
+}
+
+// HandleGetConfigHistoryDiff returns handler to get the differences of a resource between two versions of the project
+func HandleGetConfigHistoryDiff(adminMan *admin.Manager, syncMan *syncman.Manager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		// Get the JWT token from header
+		token := utils.GetTokenFromHeader(r)
+		defer utils.CloseTheCloser(r.Body)
+
+		vars := mux.Vars(r)
+		projectID := vars["project"]
+
+		ctx, cancel := context.WithTimeout(r.Context(), time.Duration(utils.DefaultContextTime)*time.Second)
+		defer cancel()
+
+		query := r.URL.Query()
+		resourceID := query.Get("resourceId")
+		if resourceID == "" {
+			_ = helpers.Response.SendErrorResponse(ctx, w, http.StatusBadRequest, errors.New("query param resourceId is required"))
+			return
+		}
+
+		var from, to int64
+		for key, ptr := range map[string]*int64{"from": &from, "to": &to} {
+			if value := query.Get(key); value != "" {
+				v, err := strconv.ParseInt(value, 10, 64)
+				if err != nil {
+					_ = helpers.Response.SendErrorResponse(ctx, w, http.StatusBadRequest, errors.New("invalid value provided for query param "+key))
+					return
+				}
+				*ptr = v
+			}
+		}
+
+		// The history carries the config of every resource of the project, hence it requires write access. The secrets
+		// and connection strings are removed unless the token is allowed to read them
+		reqParams, err := adminMan.IsTokenValid(ctx, token, "project", "modify", map[string]string{"project": projectID})
+		if err != nil {
+			_ = helpers.Response.SendErrorResponse(ctx, w, http.StatusUnauthorized, err)
+			return
+		}
+
+		reqParams = utils.ExtractRequestParams(r, reqParams, nil)
+		status, diffs, err := syncMan.GetConfigHistoryDiff(ctx, projectID, resourceID, from, to, reqParams)
+		if err != nil {
+			_ = helpers.Response.SendErrorResponse(ctx, w, status, err)
+			return
+		}
+
+		if !adminMan.CanReadProjectSecrets(ctx, token, projectID) {
+			diffs = syncman.RemoveConfigDiffSecrets(ctx, resourceID, diffs)
+		}
+
+		_ = helpers.Response.SendResponse(ctx, w, status, model.Response{Result: diffs})
+	}
+}
+
+// HandleRollbackConfig is an endpoint handler which rolls back a resource or the entire project to a point in time
+func HandleRollbackConfig(adminMan *admin.Manager, syncMan *syncman.Manager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		// Get the JWT token from header
+		token := utils.GetTokenFromHeader(r)
+
+		vars := mux.Vars(r)
+		projectID := vars["project"]
+
+		req := new(model.ConfigRollbackRequest)
+		_ = json.NewDecoder(r.Body).Decode(req)
+		defer utils.CloseTheCloser(r.Body)
+
+		ctx, cancel := context.WithTimeout(r.Context(), time.Duration(utils.DefaultContextTime)*time.Second)
+		defer cancel()
+
+		// Check if the request is authorised
+		reqParams, err := adminMan.IsTokenValid(ctx, token, "project", "modify", map[string]string{"project": projectID})
+		if err != nil {
+			_ = helpers.Response.SendErrorResponse(ctx, w, http.StatusUnauthorized, err)
+			return
+		}
+
+		reqParams = utils.ExtractRequestParams(r, reqParams, req)
+		status, resourceIDs, err := syncMan.RollbackConfig(ctx, projectID, req, reqParams)
+		if err != nil {
+			_ = helpers.Response.SendErrorResponse(ctx, w, status, err)
+			return
+		}
+
+		_ = helpers.Response.SendResponse(ctx, w, status, model.Response{Result: resourceIDs})
+	}
+}
@@ -45,10 +45,29 @@ func HandleGetProjectConfig(adminMan *admin.Manager, syncMan *syncman.Manager) h
 			return
 		}
 
-		_ = helpers.Response.SendResponse(ctx, w, status, model.Response{Result: project})
+		_ = helpers.Response.SendResponse(ctx, w, status, model.Response{Result: removeProjectSecrets(ctx, adminMan, token, project)})
 	}
 }
 
+// removeProjectSecrets returns copies of the project configs without the secrets and aes key if the token isn't
+// allowed to read them. The configs stored by the sync manager are left untouched
+func removeProjectSecrets(ctx context.Context, adminMan *admin.Manager, token string, projects []interface{}) []interface{} {
+	result := make([]interface{}, len(projects))
+	for i, v := range projects {
+		result[i] = v
+		project, ok := v.(*config.ProjectConfig)
+		if !ok || project == nil || adminMan.CanReadProjectSecrets(ctx, token, project.ID) {
+			continue
+		}
+
+		redacted := *project
+		redacted.Secrets = nil
+		redacted.AESKey = ""
+		result[i] = &redacted
+	}
+	return result
+}
+
 // HandleApplyProject is an endpoint handler which adds a project configuration in config
 func HandleApplyProject(adminMan *admin.Manager, syncman *syncman.Manager) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
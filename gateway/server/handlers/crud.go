@@ -198,11 +198,23 @@ func HandleCrudRead(modules *modules.Modules) http.HandlerFunc {
 			return
 		}
 
+		// Generate the cursors of the first and last document before the values of the sort keys get modified by post processing
+		res := map[string]interface{}{}
+		if docs, ok := result.([]interface{}); ok && len(docs) > 0 && len(req.Options.Sort) > 0 {
+			if next, err := utils.EncodeCursor(req.Options.Sort, docs[len(docs)-1]); err == nil {
+				res["next"] = next
+			}
+			if prev, err := utils.EncodeCursor(req.Options.Sort, docs[0]); err == nil {
+				res["prev"] = prev
+			}
+		}
+
 		// function to do postProcessing on result
 		_ = authHelpers.PostProcessMethod(ctx, auth.GetAESKey(), actions, result)
 
-		// Give positive acknowledgement
-		_ = helpers.Response.SendResponse(ctx, w, http.StatusOK, map[string]interface{}{"result": result})
+		// Give positive acknowledgement along with the cursors
+		res["result"] = result
+		_ = helpers.Response.SendResponse(ctx, w, http.StatusOK, res)
 	}
 }
 
@@ -51,10 +51,13 @@ func HandleAdminQueueEvent(adminMan *admin.Manager, modules *modules.Modules) ht
 		ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
 		defer cancel()
 
-		// Get the JWT token from header
-		if err := adminMan.CheckIfAdmin(ctx, utils.GetTokenFromHeader(r)); err != nil {
-			_ = helpers.Response.SendErrorResponse(r.Context(), w, http.StatusForbidden, err)
-			return
+		// Admin events skip the eventing rules, hence the token must be allowed to access the rule of every event type
+		token := utils.GetTokenFromHeader(r)
+		for _, ev := range req.Events {
+			if _, err := adminMan.IsTokenValid(ctx, token, "eventing-rule", "access", map[string]string{"project": projectID, "id": ev.Type}); err != nil {
+				_ = helpers.Response.SendErrorResponse(r.Context(), w, http.StatusForbidden, err)
+				return
+			}
 		}
 
 		// Queue the event
@@ -0,0 +1,192 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/spaceuptech/helpers"
+
+	"github.com/spaceuptech/space-cloud/gateway/managers/admin"
+	"github.com/spaceuptech/space-cloud/gateway/model"
+	"github.com/spaceuptech/space-cloud/gateway/modules"
+	"github.com/spaceuptech/space-cloud/gateway/utils"
+)
+
+// HandleListDLQEvents is an endpoint handler which lists the events of the dead letter queue
+func HandleListDLQEvents(adminMan *admin.Manager, modules *modules.Modules) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		// Get the JWT token from header
+		token := utils.GetTokenFromHeader(r)
+		defer utils.CloseTheCloser(r.Body)
+
+		vars := mux.Vars(r)
+		projectID := vars["project"]
+
+		ctx, cancel := context.WithTimeout(r.Context(), time.Duration(utils.DefaultContextTime)*time.Second)
+		defer cancel()
+
+		query := r.URL.Query()
+		filter := &model.DLQFilter{Trigger: query.Get("trigger"), Status: query.Get("status"), From: query.Get("from"), To: query.Get("to")}
+		for key, ptr := range map[string]*int64{"limit": &filter.Limit, "skip": &filter.Skip} {
+			if value := query.Get(key); value != "" {
+				v, err := strconv.ParseInt(value, 10, 64)
+				if err != nil {
+					_ = helpers.Response.SendErrorResponse(ctx, w, http.StatusBadRequest, errors.New("invalid value provided for query param "+key))
+					return
+				}
+				*ptr = v
+			}
+		}
+
+		// Check if the request is authorised
+		if _, err := adminMan.IsTokenValid(ctx, token, "eventing-trigger", "read", map[string]string{"project": projectID, "id": getDLQTriggerID(filter)}); err != nil {
+			_ = helpers.Response.SendErrorResponse(ctx, w, http.StatusUnauthorized, err)
+			return
+		}
+
+		eventing, err := modules.Eventing(projectID)
+		if err != nil {
+			_ = helpers.Response.SendErrorResponse(ctx, w, http.StatusBadRequest, err)
+			return
+		}
+
+		events, err := eventing.ListDLQEvents(ctx, filter)
+		if err != nil {
+			_ = helpers.Response.SendErrorResponse(ctx, w, http.StatusInternalServerError, err)
+			return
+		}
+		_ = helpers.Response.SendResponse(ctx, w, http.StatusOK, model.Response{Result: events})
+	}
+}
+
+// HandleGetDLQEvent is an endpoint handler which returns an event of the dead letter queue along with its invocations
+func HandleGetDLQEvent(adminMan *admin.Manager, modules *modules.Modules) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		// Get the JWT token from header
+		token := utils.GetTokenFromHeader(r)
+		defer utils.CloseTheCloser(r.Body)
+
+		vars := mux.Vars(r)
+		projectID := vars["project"]
+		id := vars["id"]
+
+		ctx, cancel := context.WithTimeout(r.Context(), time.Duration(utils.DefaultContextTime)*time.Second)
+		defer cancel()
+
+		// Check if the request is authorised
+		if _, err := adminMan.IsTokenValid(ctx, token, "eventing-trigger", "read", map[string]string{"project": projectID, "id": "*"}); err != nil {
+			_ = helpers.Response.SendErrorResponse(ctx, w, http.StatusUnauthorized, err)
+			return
+		}
+
+		eventing, err := modules.Eventing(projectID)
+		if err != nil {
+			_ = helpers.Response.SendErrorResponse(ctx, w, http.StatusBadRequest, err)
+			return
+		}
+
+		event, invocations, err := eventing.GetDLQEvent(ctx, id)
+		if err != nil {
+			_ = helpers.Response.SendErrorResponse(ctx, w, http.StatusNotFound, err)
+			return
+		}
+		_ = helpers.Response.SendResponse(ctx, w, http.StatusOK, model.Response{Result: map[string]interface{}{"event": event, "invocations": invocations}})
+	}
+}
+
+// HandleReplayDLQEvents is an endpoint handler which replays the events of the dead letter queue
+func HandleReplayDLQEvents(adminMan *admin.Manager, modules *modules.Modules) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		// Get the JWT token from header
+		token := utils.GetTokenFromHeader(r)
+
+		vars := mux.Vars(r)
+		projectID := vars["project"]
+
+		// Load the request from the body
+		req := new(model.DLQReplayRequest)
+		defer utils.CloseTheCloser(r.Body)
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			_ = helpers.Response.SendErrorResponse(r.Context(), w, http.StatusBadRequest, err)
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(r.Context(), time.Duration(utils.DefaultContextTime)*time.Second)
+		defer cancel()
+
+		// Check if the request is authorised
+		if _, err := adminMan.IsTokenValid(ctx, token, "eventing-trigger", "modify", map[string]string{"project": projectID, "id": getDLQTriggerID(&req.DLQFilter)}); err != nil {
+			_ = helpers.Response.SendErrorResponse(ctx, w, http.StatusUnauthorized, err)
+			return
+		}
+
+		eventing, err := modules.Eventing(projectID)
+		if err != nil {
+			_ = helpers.Response.SendErrorResponse(ctx, w, http.StatusBadRequest, err)
+			return
+		}
+
+		count, err := eventing.ReplayDLQEvents(ctx, req)
+		if err != nil {
+			_ = helpers.Response.SendErrorResponse(ctx, w, http.StatusBadRequest, err)
+			return
+		}
+		_ = helpers.Response.SendResponse(ctx, w, http.StatusOK, model.Response{Result: map[string]interface{}{"replayed": count}})
+	}
+}
+
+// HandleDiscardDLQEvents is an endpoint handler which discards the events of the dead letter queue
+func HandleDiscardDLQEvents(adminMan *admin.Manager, modules *modules.Modules) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		// Get the JWT token from header
+		token := utils.GetTokenFromHeader(r)
+
+		vars := mux.Vars(r)
+		projectID := vars["project"]
+
+		// Load the request from the body
+		req := new(model.DLQFilter)
+		defer utils.CloseTheCloser(r.Body)
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			_ = helpers.Response.SendErrorResponse(r.Context(), w, http.StatusBadRequest, err)
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(r.Context(), time.Duration(utils.DefaultContextTime)*time.Second)
+		defer cancel()
+
+		// Check if the request is authorised
+		if _, err := adminMan.IsTokenValid(ctx, token, "eventing-trigger", "modify", map[string]string{"project": projectID, "id": getDLQTriggerID(req)}); err != nil {
+			_ = helpers.Response.SendErrorResponse(ctx, w, http.StatusUnauthorized, err)
+			return
+		}
+
+		eventing, err := modules.Eventing(projectID)
+		if err != nil {
+			_ = helpers.Response.SendErrorResponse(ctx, w, http.StatusBadRequest, err)
+			return
+		}
+
+		if err := eventing.DiscardDLQEvents(ctx, req); err != nil {
+			_ = helpers.Response.SendErrorResponse(ctx, w, http.StatusBadRequest, err)
+			return
+		}
+		_ = helpers.Response.SendOkayResponse(ctx, http.StatusOK, w)
+	}
+}
+
+func getDLQTriggerID(filter *model.DLQFilter) string {
+	if filter.Trigger != "" && len(filter.IDs) == 0 {
+		return filter.Trigger
+	}
+	return "*"
+}
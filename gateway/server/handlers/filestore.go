@@ -16,6 +16,7 @@ import (
 
 	"github.com/spaceuptech/space-cloud/gateway/model"
 	"github.com/spaceuptech/space-cloud/gateway/modules"
+	"github.com/spaceuptech/space-cloud/gateway/modules/filestore"
 	"github.com/spaceuptech/space-cloud/gateway/utils"
 )
 
@@ -59,6 +60,7 @@ func HandleCreateFile(modules *modules.Modules) http.HandlerFunc {
 		_ = json.Unmarshal([]byte(r.FormValue("meta")), &v)
 		path := r.FormValue("path")
 		fileType := r.FormValue("fileType")
+		store := r.FormValue("store")
 		var makeAll bool
 
 		makeAllString := r.FormValue("makeAll")
@@ -87,7 +89,7 @@ func HandleCreateFile(modules *modules.Modules) http.HandlerFunc {
 				fileName = tempName
 			}
 
-			status, err := fileStore.UploadFile(ctx, projectID, token, &model.CreateFileRequest{Name: fileName, Path: path, Type: fileType, MakeAll: makeAll, Meta: v}, file)
+			status, err := fileStore.UploadFile(ctx, projectID, token, &model.CreateFileRequest{Name: fileName, Path: path, Type: fileType, MakeAll: makeAll, Meta: v, Store: store}, file)
 			if err != nil {
 				_ = helpers.Response.SendErrorResponse(ctx, w, status, err)
 				return
@@ -95,7 +97,7 @@ func HandleCreateFile(modules *modules.Modules) http.HandlerFunc {
 			_ = helpers.Response.SendResponse(ctx, w, status, map[string]string{})
 		} else {
 			name := r.FormValue("name")
-			status, err := fileStore.CreateDir(ctx, projectID, token, &model.CreateFileRequest{Name: name, Path: path, Type: fileType, MakeAll: makeAll}, v)
+			status, err := fileStore.CreateDir(ctx, projectID, token, &model.CreateFileRequest{Name: name, Path: path, Type: fileType, MakeAll: makeAll, Store: store}, v)
 			if err != nil {
 				_ = helpers.Response.SendErrorResponse(ctx, w, status, err)
 				return
@@ -124,11 +126,12 @@ func HandleRead(modules *modules.Modules) http.HandlerFunc {
 		defer cancel()
 
 		op := r.URL.Query().Get("op")
+		store := r.URL.Query().Get("store")
 
 		// List the specified directory if op type is list
 		if op == "list" {
 			mode := r.URL.Query().Get("mode")
-			status, res, err := fileStore.ListFiles(ctx, projectID, token, &model.ListFilesRequest{Path: path, Type: mode})
+			status, res, err := fileStore.ListFiles(ctx, projectID, token, &model.ListFilesRequest{Path: path, Type: mode, Store: store})
 			if err != nil {
 				_ = helpers.Response.SendErrorResponse(ctx, w, status, err)
 				return
@@ -136,7 +139,7 @@ func HandleRead(modules *modules.Modules) http.HandlerFunc {
 			_ = helpers.Response.SendResponse(ctx, w, status, map[string]interface{}{"result": res})
 			return
 		} else if op == "exist" {
-			if err := fileStore.DoesExists(ctx, projectID, token, path); err != nil {
+			if err := fileStore.DoesExists(ctx, projectID, token, store, path); err != nil {
 				_ = helpers.Response.SendErrorResponse(ctx, w, http.StatusNotFound, err)
 				return
 			}
@@ -145,17 +148,112 @@ func HandleRead(modules *modules.Modules) http.HandlerFunc {
 		}
 
 		// Read the file from file storage
-		status, file, err := fileStore.DownloadFile(ctx, projectID, token, path)
+		status, file, err := fileStore.DownloadFile(ctx, projectID, token, store, path, r.Header.Get("Range"))
+		sendFile(ctx, w, r, status, file, err)
+	}
+}
+
+// HandleFileInfo returns the stat information of a file in the headers of the response
+func HandleFileInfo(modules *modules.Modules) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Extract the path from the url
+		token, projectID, path := getFileStoreMeta(r)
+		defer utils.CloseTheCloser(r.Body)
+
+		ctx, cancel := context.WithTimeout(r.Context(), time.Duration(utils.DefaultContextTime)*time.Second)
+		defer cancel()
+
+		fileStore, err := modules.File(projectID)
 		if err != nil {
-			_ = helpers.Response.SendErrorResponse(ctx, w, status, err)
+			w.WriteHeader(http.StatusBadRequest)
 			return
 		}
-		defer func() { _ = file.Close() }()
+
+		status, info, err := fileStore.GetFileInfo(ctx, projectID, token, r.URL.Query().Get("store"), path)
+		if err != nil {
+			w.WriteHeader(status)
+			return
+		}
+
+		setFileInfoHeaders(w, info)
+		if isFileNotModified(r, info) {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("Content-Length", strconv.FormatInt(info.Size, 10))
 		w.WriteHeader(http.StatusOK)
-		_, _ = io.Copy(w, file.File)
 	}
 }
 
+// sendFile streams the file read from the file store along with its stat information
+func sendFile(ctx context.Context, w http.ResponseWriter, r *http.Request, status int, file *model.File, err error) {
+	if err != nil {
+		if status == http.StatusRequestedRangeNotSatisfiable && file != nil && file.Info != nil {
+			w.Header().Set("Content-Range", fmt.Sprintf("bytes */%d", file.Info.Size))
+		}
+		_ = helpers.Response.SendErrorResponse(ctx, w, status, err)
+		return
+	}
+	defer func() { _ = file.Close() }()
+
+	if file.Info != nil {
+		setFileInfoHeaders(w, file.Info)
+		if isFileNotModified(r, file.Info) {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		length := file.Info.Size
+		if file.Range != nil {
+			length = file.Range.Length()
+			w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", file.Range.Start, file.Range.End, file.Info.Size))
+		}
+		w.Header().Set("Content-Length", strconv.FormatInt(length, 10))
+	}
+
+	w.WriteHeader(status)
+	_, _ = io.Copy(w, file.File)
+}
+
+// setFileInfoHeaders sets the stat information of the file as response headers
+func setFileInfoHeaders(w http.ResponseWriter, info *model.FileInfo) {
+	w.Header().Set("Accept-Ranges", "bytes")
+	if info.ContentType != "" {
+		w.Header().Set("Content-Type", info.ContentType)
+	}
+	if info.ETag != "" {
+		w.Header().Set("ETag", info.ETag)
+	}
+	if info.ModifiedAt != nil {
+		w.Header().Set("Last-Modified", info.ModifiedAt.UTC().Format(http.TimeFormat))
+	}
+}
+
+// isFileNotModified checks the conditional headers of the request. If-None-Match takes precedence over If-Modified-Since
+func isFileNotModified(r *http.Request, info *model.FileInfo) bool {
+	if inm := r.Header.Get("If-None-Match"); inm != "" {
+		if info.ETag == "" {
+			return false
+		}
+		for _, tag := range strings.Split(inm, ",") {
+			tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+			if tag == "*" || tag == strings.TrimPrefix(info.ETag, "W/") {
+				return true
+			}
+		}
+		return false
+	}
+
+	if ims := r.Header.Get("If-Modified-Since"); ims != "" && info.ModifiedAt != nil {
+		t, err := http.ParseTime(ims)
+		if err != nil {
+			return false
+		}
+		return !info.ModifiedAt.Truncate(time.Second).After(t)
+	}
+	return false
+}
+
 // HandleDelete creates read file and list directory endpoint
 func HandleDelete(modules *modules.Modules) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -165,6 +263,7 @@ func HandleDelete(modules *modules.Modules) http.HandlerFunc {
 		if exists {
 			fileType = fileTypeQuery[0]
 		}
+		store := r.URL.Query().Get("store")
 
 		// Extract the path from the url
 		token, projectID, path := getFileStoreMeta(r)
@@ -185,14 +284,14 @@ func HandleDelete(modules *modules.Modules) http.HandlerFunc {
 		defer cancel()
 
 		if fileType == "file" {
-			status, err := fileStore.DeleteFile(ctx, projectID, token, path, v)
+			status, err := fileStore.DeleteFile(ctx, projectID, token, store, path, v)
 			if err != nil {
 				_ = helpers.Response.SendErrorResponse(ctx, w, status, err)
 				return
 			}
 			_ = helpers.Response.SendResponse(ctx, w, status, map[string]string{})
 		} else if fileType == "dir" {
-			status, err := fileStore.DeleteDir(ctx, projectID, token, path, v)
+			status, err := fileStore.DeleteDir(ctx, projectID, token, store, path, v)
 			if err != nil {
 				_ = helpers.Response.SendErrorResponse(ctx, w, status, err)
 				return
@@ -221,3 +320,232 @@ func getFileStoreMeta(r *http.Request) (token string, projectID string, path str
 	}
 	return
 }
+
+// HandleCreateSignedURL creates a presigned url to read or create a file
+func HandleCreateSignedURL(modules *modules.Modules) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		token, projectID, _ := getFileStoreMeta(r)
+
+		req := new(model.SignedURLRequest)
+		_ = json.NewDecoder(r.Body).Decode(req)
+		defer utils.CloseTheCloser(r.Body)
+
+		ctx, cancel := context.WithTimeout(r.Context(), time.Duration(utils.DefaultContextTime)*time.Second)
+		defer cancel()
+
+		fileStore, err := modules.File(projectID)
+		if err != nil {
+			_ = helpers.Response.SendErrorResponse(ctx, w, http.StatusBadRequest, err)
+			return
+		}
+
+		status, res, err := fileStore.CreateSignedURL(ctx, projectID, token, req)
+		if err != nil {
+			_ = helpers.Response.SendErrorResponse(ctx, w, status, err)
+			return
+		}
+
+		// Urls signed by the gateway are relative to the gateway
+		if strings.HasPrefix(res.URL, "/") {
+			scheme := "http"
+			if r.TLS != nil {
+				scheme = "https"
+			}
+			if proto := r.Header.Get("X-Forwarded-Proto"); proto != "" {
+				scheme = proto
+			}
+			res.URL = fmt.Sprintf("%s://%s%s", scheme, r.Host, res.URL)
+		}
+		_ = helpers.Response.SendResponse(ctx, w, status, res)
+	}
+}
+
+// HandleSignedFile reads or creates a file using a url signed by the gateway
+func HandleSignedFile(modules *modules.Modules) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		projectID := vars["project"]
+		defer utils.CloseTheCloser(r.Body)
+
+		ctx, cancel := context.WithTimeout(r.Context(), 30*time.Minute)
+		defer cancel()
+
+		query := r.URL.Query()
+		expires, err := strconv.ParseInt(query.Get("expires"), 10, 64)
+		if err != nil {
+			_ = helpers.Response.SendErrorResponse(ctx, w, http.StatusBadRequest, fmt.Errorf("Incorrect value for expires"))
+			return
+		}
+		req := &model.SignedFileRequest{
+			Store:     query.Get("store"),
+			Op:        model.FileOpType(query.Get("op")),
+			Path:      query.Get("path"),
+			Name:      query.Get("name"),
+			Meta:      query.Get("meta"),
+			Expires:   expires,
+			Signature: query.Get("signature"),
+		}
+
+		fileStore, err := modules.File(projectID)
+		if err != nil {
+			_ = helpers.Response.SendErrorResponse(ctx, w, http.StatusBadRequest, err)
+			return
+		}
+
+		if r.Method == http.MethodPut {
+			status, err := fileStore.UploadSignedFile(ctx, projectID, req, r.Body)
+			if err != nil {
+				_ = helpers.Response.SendErrorResponse(ctx, w, status, err)
+				return
+			}
+			_ = helpers.Response.SendResponse(ctx, w, status, map[string]string{})
+			return
+		}
+
+		status, file, err := fileStore.DownloadSignedFile(ctx, projectID, req, r.Header.Get("Range"))
+		sendFile(ctx, w, r, status, file, err)
+	}
+}
+
+// HandleCreateUpload starts a resumable upload of a file
+func HandleCreateUpload(modules *modules.Modules) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		token, projectID, _ := getFileStoreMeta(r)
+
+		req := new(model.CreateFileRequest)
+		_ = json.NewDecoder(r.Body).Decode(req)
+		defer utils.CloseTheCloser(r.Body)
+
+		ctx, cancel := context.WithTimeout(r.Context(), time.Duration(utils.DefaultContextTime)*time.Second)
+		defer cancel()
+
+		fileStore, err := modules.File(projectID)
+		if err != nil {
+			_ = helpers.Response.SendErrorResponse(ctx, w, http.StatusBadRequest, err)
+			return
+		}
+
+		status, res, err := fileStore.CreateUpload(ctx, projectID, token, req)
+		if err != nil {
+			_ = helpers.Response.SendErrorResponse(ctx, w, status, err)
+			return
+		}
+		_ = helpers.Response.SendResponse(ctx, w, status, res)
+	}
+}
+
+// HandleGetUpload returns the parts of a resumable upload uploaded so far. Requests whose id isn't an upload id
+// are reads of files in the uploads directory
+func HandleGetUpload(modules *modules.Modules) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		projectID, uploadID := vars["project"], vars["id"]
+		if !filestore.IsUploadID(uploadID) {
+			HandleRead(modules)(w, r)
+			return
+		}
+		defer utils.CloseTheCloser(r.Body)
+
+		ctx, cancel := context.WithTimeout(r.Context(), time.Duration(utils.DefaultContextTime)*time.Second)
+		defer cancel()
+
+		fileStore, err := modules.File(projectID)
+		if err != nil {
+			_ = helpers.Response.SendErrorResponse(ctx, w, http.StatusBadRequest, err)
+			return
+		}
+
+		status, res, err := fileStore.GetUpload(ctx, projectID, uploadID)
+		if err != nil {
+			_ = helpers.Response.SendErrorResponse(ctx, w, status, err)
+			return
+		}
+		_ = helpers.Response.SendResponse(ctx, w, status, res)
+	}
+}
+
+// HandleUploadPart uploads a part of a resumable upload
+func HandleUploadPart(modules *modules.Modules) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		projectID, uploadID := vars["project"], vars["id"]
+		defer utils.CloseTheCloser(r.Body)
+
+		ctx, cancel := context.WithTimeout(r.Context(), 30*time.Minute)
+		defer cancel()
+
+		number, err := strconv.Atoi(vars["part"])
+		if err != nil {
+			_ = helpers.Response.SendErrorResponse(ctx, w, http.StatusBadRequest, fmt.Errorf("Incorrect value for part number"))
+			return
+		}
+
+		fileStore, err := modules.File(projectID)
+		if err != nil {
+			_ = helpers.Response.SendErrorResponse(ctx, w, http.StatusBadRequest, err)
+			return
+		}
+
+		status, res, err := fileStore.UploadPart(ctx, projectID, uploadID, number, r.Body)
+		if err != nil {
+			_ = helpers.Response.SendErrorResponse(ctx, w, status, err)
+			return
+		}
+		_ = helpers.Response.SendResponse(ctx, w, status, res)
+	}
+}
+
+// HandleCompleteUpload commits the uploaded parts of a resumable upload to the file
+func HandleCompleteUpload(modules *modules.Modules) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		projectID, uploadID := vars["project"], vars["id"]
+		defer utils.CloseTheCloser(r.Body)
+
+		ctx, cancel := context.WithTimeout(r.Context(), 30*time.Minute)
+		defer cancel()
+
+		fileStore, err := modules.File(projectID)
+		if err != nil {
+			_ = helpers.Response.SendErrorResponse(ctx, w, http.StatusBadRequest, err)
+			return
+		}
+
+		status, err := fileStore.CompleteUpload(ctx, projectID, uploadID)
+		if err != nil {
+			_ = helpers.Response.SendErrorResponse(ctx, w, status, err)
+			return
+		}
+		_ = helpers.Response.SendOkayResponse(ctx, status, w)
+	}
+}
+
+// HandleAbortUpload aborts a resumable upload. Requests whose id isn't an upload id are deletes of files in the
+// uploads directory
+func HandleAbortUpload(modules *modules.Modules) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		projectID, uploadID := vars["project"], vars["id"]
+		if !filestore.IsUploadID(uploadID) {
+			HandleDelete(modules)(w, r)
+			return
+		}
+		defer utils.CloseTheCloser(r.Body)
+
+		ctx, cancel := context.WithTimeout(r.Context(), time.Duration(utils.DefaultContextTime)*time.Second)
+		defer cancel()
+
+		fileStore, err := modules.File(projectID)
+		if err != nil {
+			_ = helpers.Response.SendErrorResponse(ctx, w, http.StatusBadRequest, err)
+			return
+		}
+
+		status, err := fileStore.AbortUpload(ctx, projectID, uploadID)
+		if err != nil {
+			_ = helpers.Response.SendErrorResponse(ctx, w, status, err)
+			return
+		}
+		_ = helpers.Response.SendOkayResponse(ctx, status, w)
+	}
+}
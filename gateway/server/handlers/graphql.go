@@ -3,18 +3,26 @@ package handlers
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/spaceuptech/helpers"
 
+	"github.com/spaceuptech/space-cloud/gateway/managers/admin"
 	"github.com/spaceuptech/space-cloud/gateway/managers/syncman"
 	"github.com/spaceuptech/space-cloud/gateway/model"
 	"github.com/spaceuptech/space-cloud/gateway/modules"
 	"github.com/spaceuptech/space-cloud/gateway/utils"
+	graphqlUtils "github.com/spaceuptech/space-cloud/gateway/utils/graphql"
 )
 
+// graphQLExtensionsError is implemented by the errors of the graphql module which carry a code for the clients
+type graphQLExtensionsError interface {
+	Extensions() map[string]interface{}
+}
+
 // HandleGraphQLRequest executes graphql queries
 func HandleGraphQLRequest(modules *modules.Modules, syncMan *syncman.Manager) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -43,9 +51,15 @@ func HandleGraphQLRequest(modules *modules.Modules, syncMan *syncman.Manager) ht
 			return
 		}
 
-		// Load the request from the body
+		// Load the request from the body. Clients send persisted queries in the query params of GET requests so they can be
+		// cached, hence mutations aren't allowed over GET
 		req := model.GraphQLRequest{}
-		_ = json.NewDecoder(r.Body).Decode(&req)
+		if r.Method == http.MethodGet {
+			loadGraphQLRequestFromQuery(r, &req)
+			ctx = graphqlUtils.WithQueriesOnly(ctx)
+		} else {
+			_ = json.NewDecoder(r.Body).Decode(&req)
+		}
 		defer utils.CloseTheCloser(r.Body)
 
 		// Get the path parameters
@@ -53,14 +67,23 @@ func HandleGraphQLRequest(modules *modules.Modules, syncMan *syncman.Manager) ht
 
 		ch := make(chan struct{}, 1)
 
+		// Collect the cost of the query to report it in the extensions of the response
+		ctx, cost := graphqlUtils.WithQueryCost(ctx)
+
+		start := time.Now()
 		graphql.ExecGraphQLQuery(ctx, &req, token, func(op interface{}, err error) {
 			defer func() { ch <- struct{}{} }()
+			modules.Metrics().AddGraphQLOperation(projectID, time.Since(start), err)
+			extensions := map[string]interface{}{"cost": cost}
 			if err != nil {
 				errMes := map[string]interface{}{"message": err.Error()}
-				_ = helpers.Response.SendResponse(ctx, w, http.StatusOK, map[string]interface{}{"errors": []interface{}{errMes}})
+				if extErr, ok := err.(graphQLExtensionsError); ok {
+					errMes["extensions"] = extErr.Extensions()
+				}
+				_ = helpers.Response.SendResponse(ctx, w, http.StatusOK, map[string]interface{}{"errors": []interface{}{errMes}, "extensions": extensions})
 				return
 			}
-			_ = helpers.Response.SendResponse(ctx, w, http.StatusOK, map[string]interface{}{"data": op})
+			_ = helpers.Response.SendResponse(ctx, w, http.StatusOK, map[string]interface{}{"data": op, "extensions": extensions})
 		})
 
 		select {
@@ -75,3 +98,55 @@ func HandleGraphQLRequest(modules *modules.Modules, syncMan *syncman.Manager) ht
 	}
 
 }
+
+func loadGraphQLRequestFromQuery(r *http.Request, req *model.GraphQLRequest) {
+	params := r.URL.Query()
+	req.Query = params.Get("query")
+	req.OperationName = params.Get("operationName")
+	if variables := params.Get("variables"); variables != "" {
+		_ = json.Unmarshal([]byte(variables), &req.Variables)
+	}
+	if extensions := params.Get("extensions"); extensions != "" {
+		_ = json.Unmarshal([]byte(extensions), &req.Extensions)
+	}
+}
+
+// HandleGetGraphQLSchema returns the generated graphql schema of the project in the schema definition language
+func HandleGetGraphQLSchema(adminMan *admin.Manager, modules *modules.Modules) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		// Get the JWT token from header
+		token := utils.GetTokenFromHeader(r)
+
+		vars := mux.Vars(r)
+		projectID := vars["project"]
+
+		defer utils.CloseTheCloser(r.Body)
+
+		ctx, cancel := context.WithTimeout(r.Context(), time.Duration(utils.DefaultContextTime)*time.Second)
+		defer cancel()
+
+		// Check if the request is authorised
+		if _, err := adminMan.IsTokenValid(ctx, token, "db-schema", "read", map[string]string{"project": projectID, "db": "*", "col": "*"}); err != nil {
+			_ = helpers.Response.SendErrorResponse(ctx, w, http.StatusUnauthorized, err)
+			return
+		}
+
+		graphql, err := modules.GraphQL(projectID)
+		if err != nil {
+			_ = helpers.Response.SendErrorResponse(ctx, w, http.StatusBadRequest, err)
+			return
+		}
+
+		sdl, err := graphql.GetSchemaSDL()
+		if err != nil {
+			_ = helpers.Response.SendErrorResponse(ctx, w, http.StatusInternalServerError, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/graphql; charset=utf-8")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", projectID+".graphql"))
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(sdl))
+	}
+}
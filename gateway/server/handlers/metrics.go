@@ -0,0 +1,36 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/spaceuptech/helpers"
+
+	"github.com/spaceuptech/space-cloud/gateway/managers/admin"
+	"github.com/spaceuptech/space-cloud/gateway/modules/global/metrics"
+	"github.com/spaceuptech/space-cloud/gateway/utils"
+)
+
+// HandlePrometheusMetrics serves the metrics of the gateway in the prometheus exposition format
+func HandlePrometheusMetrics(adminMan *admin.Manager, metrics *metrics.Module) http.HandlerFunc {
+	handler := metrics.PrometheusHandler()
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		// Get the JWT token from header
+		token := utils.GetTokenFromHeader(r)
+
+		defer utils.CloseTheCloser(r.Body)
+
+		ctx, cancel := context.WithTimeout(r.Context(), time.Duration(utils.DefaultContextTime)*time.Second)
+		defer cancel()
+
+		// Check if the request is authorised
+		if _, err := adminMan.IsTokenValid(ctx, token, "metrics", "read", map[string]string{}); err != nil {
+			_ = helpers.Response.SendErrorResponse(ctx, w, http.StatusUnauthorized, err)
+			return
+		}
+
+		handler.ServeHTTP(w, r.WithContext(ctx))
+	}
+}
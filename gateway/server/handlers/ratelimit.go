@@ -0,0 +1,35 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/spaceuptech/helpers"
+
+	"github.com/spaceuptech/space-cloud/gateway/modules"
+	"github.com/spaceuptech/space-cloud/gateway/modules/global/ratelimit"
+	"github.com/spaceuptech/space-cloud/gateway/utils"
+)
+
+// HandleRateLimit rejects the requests of clients which have exhausted the rate limit of the endpoint
+// with a 429 status code before passing the request on to the next handler
+func HandleRateLimit(modules *modules.Modules, endpoint string, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		projectID := mux.Vars(r)["project"]
+
+		allowed, retryAfter := modules.RateLimiter().Allow(r.Context(), projectID, endpoint, r, func() (map[string]interface{}, error) {
+			auth, err := modules.Auth(projectID)
+			if err != nil {
+				return nil, err
+			}
+			return auth.ParseToken(r.Context(), utils.GetTokenFromHeader(r))
+		})
+		if !allowed {
+			ratelimit.SetRetryAfterHeader(w, retryAfter)
+			_ = helpers.Response.SendErrorResponse(r.Context(), w, http.StatusTooManyRequests, ratelimit.ErrRateLimitExceeded)
+			return
+		}
+
+		next(w, r)
+	}
+}
@@ -3,7 +3,9 @@ package handlers
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"net/url"
 	"time"
 
 	"github.com/gorilla/mux"
@@ -189,3 +191,100 @@ func HandleEmailEditProfile(modules *modules.Modules) http.HandlerFunc {
 		_ = helpers.Response.SendResponse(ctx, w, status, result)
 	}
 }
+
+// HandleOAuthLogin returns the handler which redirects the user to the sign in page of an oauth2 or oidc provider
+func HandleOAuthLogin(modules *modules.Modules) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Get the path parameters
+		vars := mux.Vars(r)
+		projectID := vars["project"]
+		dbAlias := vars["dbAlias"]
+		provider := vars["provider"]
+
+		userManagement, err := modules.User(projectID)
+		if err != nil {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+
+		// Create a context of execution
+		ctx, cancel := context.WithTimeout(r.Context(), time.Duration(utils.DefaultContextTime)*time.Second)
+		defer cancel()
+		defer utils.CloseTheCloser(r.Body)
+
+		status, authURL, nonce, err := userManagement.OAuthLogin(ctx, dbAlias, projectID, provider, r.URL.Query().Get("redirect_uri"))
+		if err != nil {
+			_ = helpers.Response.SendErrorResponse(ctx, w, status, err)
+			return
+		}
+
+		// The nonce binds the callback to the browser which started the sign in
+		http.SetCookie(w, &http.Cookie{
+			Name:     getOAuthCookieName(provider),
+			Value:    nonce,
+			Path:     fmt.Sprintf("/v1/api/%s/auth/%s/oauth/%s", projectID, dbAlias, provider),
+			MaxAge:   600,
+			HttpOnly: true,
+			Secure:   r.TLS != nil,
+			SameSite: http.SameSiteLaxMode,
+		})
+		http.Redirect(w, r, authURL, http.StatusFound)
+	}
+}
+
+// HandleOAuthCallback returns the handler for the callback of an oauth2 or oidc provider
+func HandleOAuthCallback(modules *modules.Modules) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Get the path parameters
+		vars := mux.Vars(r)
+		projectID := vars["project"]
+		dbAlias := vars["dbAlias"]
+		provider := vars["provider"]
+
+		userManagement, err := modules.User(projectID)
+		if err != nil {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+
+		// Create a context of execution
+		ctx, cancel := context.WithTimeout(r.Context(), time.Duration(utils.DefaultContextTime)*time.Second)
+		defer cancel()
+		defer utils.CloseTheCloser(r.Body)
+
+		query := r.URL.Query()
+		if e := query.Get("error"); e != "" {
+			_ = helpers.Response.SendErrorResponse(ctx, w, http.StatusUnauthorized, helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Provider (%s) returned an error - %s %s", provider, e, query.Get("error_description")), nil, nil))
+			return
+		}
+
+		var nonce string
+		if cookie, err := r.Cookie(getOAuthCookieName(provider)); err == nil {
+			nonce = cookie.Value
+		}
+
+		status, result, appRedirectURL, err := userManagement.OAuthCallback(ctx, dbAlias, projectID, provider, query.Get("code"), query.Get("state"), nonce)
+		if err != nil {
+			_ = helpers.Response.SendErrorResponse(ctx, w, status, err)
+			return
+		}
+
+		// The nonce can only be used once
+		http.SetCookie(w, &http.Cookie{Name: getOAuthCookieName(provider), Path: fmt.Sprintf("/v1/api/%s/auth/%s/oauth/%s", projectID, dbAlias, provider), MaxAge: -1, HttpOnly: true})
+
+		if appRedirectURL != "" {
+			// The token is sent in the fragment so that it never reaches the server logs of the app
+			http.Redirect(w, r, appRedirectURL+"#token="+url.QueryEscape(result["token"].(string)), http.StatusFound)
+			return
+		}
+		_ = helpers.Response.SendResponse(ctx, w, status, result)
+	}
+}
+
+func getOAuthCookieName(provider string) string {
+	return "sc-oauth-" + provider
+}
@@ -21,6 +21,7 @@ import (
 
 	"github.com/spaceuptech/space-cloud/gateway/model"
 	"github.com/spaceuptech/space-cloud/gateway/modules"
+	"github.com/spaceuptech/space-cloud/gateway/modules/global/metrics"
 	"github.com/spaceuptech/space-cloud/gateway/utils"
 	"github.com/spaceuptech/space-cloud/gateway/utils/client"
 	"github.com/spaceuptech/space-cloud/gateway/utils/graphql"
@@ -30,8 +31,14 @@ import (
 type WebsocketModulesInterface interface {
 	Realtime(projectID string) (modules.RealtimeInterface, error)
 	GraphQL(projectID string) (modules.GraphQLInterface, error)
+	Metrics() *metrics.Module
 }
 
+const (
+	websocketProtocolJSON    = "json"
+	websocketProtocolGraphQL = "graphql-ws"
+)
+
 var upgrader = websocket.Upgrader{
 	CheckOrigin: func(r *http.Request) bool {
 		return true
@@ -66,6 +73,9 @@ func HandleWebsocket(modules WebsocketModulesInterface) http.HandlerFunc {
 
 		defer realtime.RemoveClient(c.ClientID())
 
+		modules.Metrics().AddWebsocketConnection(projectID, websocketProtocolJSON, true)
+		defer modules.Metrics().AddWebsocketConnection(projectID, websocketProtocolJSON, false)
+
 		go c.RoutineWrite(ctx)
 
 		// Get client details
@@ -135,11 +145,13 @@ type graphqlMessage struct {
 }
 
 type payloadObject struct {
-	Query     string                 `json:"query,omitempty"`
-	Token     string                 `json:"authToken"`
-	Variables map[string]interface{} `json:"variables"`
-	Error     []gqlError             `json:"errors,omitempty"`
-	Data      interface{}            `json:"data,omitempty"`
+	Query         string                   `json:"query,omitempty"`
+	OperationName string                   `json:"operationName,omitempty"`
+	Extensions    *model.GraphQLExtensions `json:"extensions,omitempty"`
+	Token         string                   `json:"authToken"`
+	Variables     map[string]interface{}   `json:"variables"`
+	Error         []gqlError               `json:"errors,omitempty"`
+	Data          interface{}              `json:"data,omitempty"`
 }
 
 type gqlError struct {
@@ -180,6 +192,9 @@ func HandleGraphqlSocket(modules WebsocketModulesInterface) http.HandlerFunc {
 		}
 		defer utils.CloseTheCloser(socket)
 
+		modules.Metrics().AddWebsocketConnection(projectID, websocketProtocolGraphQL, true)
+		defer modules.Metrics().AddWebsocketConnection(projectID, websocketProtocolGraphQL, false)
+
 		// Create a new client ID that we will use to make subscriptions
 		clientID := ksuid.New().String()
 		defer realtime.RemoveClient(clientID)
@@ -235,8 +250,15 @@ func HandleGraphqlSocket(modules WebsocketModulesInterface) http.HandlerFunc {
 
 			case utils.GqlStart:
 
+				// Subscriptions are subject to the persisted queries of the project just like queries and mutations
+				req := &model.GraphQLRequest{Query: m.Payload.Query, OperationName: m.Payload.OperationName, Variables: m.Payload.Variables, Extensions: m.Payload.Extensions}
+				if err := graph.ResolvePersistedQuery(ctx, req, m.Payload.Token); err != nil {
+					channel <- &graphqlMessage{ID: m.ID, Type: utils.GqlError, Payload: payloadObject{Error: []gqlError{{Message: err.Error()}}}}
+					continue
+				}
+
 				// parse the source
-				doc, err := parser.Parse(parser.ParseParams{Source: source.NewSource(&source.Source{Body: []byte(m.Payload.Query)})})
+				doc, err := parser.Parse(parser.ParseParams{Source: source.NewSource(&source.Source{Body: []byte(req.Query)})})
 				if err != nil {
 					channel <- &graphqlMessage{ID: m.ID, Type: utils.GqlError, Payload: payloadObject{Error: []gqlError{{Message: err.Error()}}}}
 					continue
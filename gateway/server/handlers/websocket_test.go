@@ -15,6 +15,7 @@ import (
 
 	"github.com/spaceuptech/space-cloud/gateway/model"
 	"github.com/spaceuptech/space-cloud/gateway/modules"
+	"github.com/spaceuptech/space-cloud/gateway/modules/global/metrics"
 	"github.com/spaceuptech/space-cloud/gateway/utils"
 )
 
@@ -490,6 +491,24 @@ subscription {
 				{Type: utils.GqlError, ID: "2"},
 			},
 		},
+		{
+			name: "start rejected by persisted queries",
+			realtimeMockArgs: []mockArg{
+				{
+					method:        "RemoveClient",
+					args:          []interface{}{mock.Anything},
+					paramReturned: []interface{}{},
+				},
+			},
+			graphMockArgs: []mockArg{{method: "ResolvePersistedQuery", args: []interface{}{&model.GraphQLRequest{Query: `subscription { col @db { find } }`}, "abc"}, paramReturned: []interface{}{errors.New("only persisted queries are allowed in this project")}}},
+			push:          []*model.FeedData{},
+			send: []*graphqlMessage{
+				{Type: utils.GqlStart, ID: "2", Payload: payloadObject{Query: `subscription { col @db { find } }`, Token: "abc"}},
+			},
+			rcv: []*graphqlMessage{
+				{Type: utils.GqlError, ID: "2"},
+			},
+		},
 		{
 			name: "invalid query string",
 			realtimeMockArgs: []mockArg{
@@ -578,6 +597,7 @@ subscription {
 			for _, m := range tt.graphMockArgs {
 				graph.On(m.method, m.args...).Return(m.paramReturned...)
 			}
+			graph.On("ResolvePersistedQuery", mock.Anything, mock.Anything).Return(nil).Maybe()
 
 			// Create the mock server
 			s := httptest.NewServer(HandleGraphqlSocket(&mockWebsocketModules{&realtime, &graph}))
@@ -637,6 +657,10 @@ func (m *mockWebsocketModules) GraphQL(projectID string) (modules.GraphQLInterfa
 	return m.graphql, nil
 }
 
+func (m *mockWebsocketModules) Metrics() *metrics.Module {
+	return &metrics.Module{}
+}
+
 // Create all the mock interfaces
 type mockRealtimeModule struct {
 	mock.Mock
@@ -691,3 +715,12 @@ func (m *mockGraphQLModule) GetDBAlias(ctx context.Context, field *ast.Field, to
 func (m *mockGraphQLModule) ExecGraphQLQuery(ctx context.Context, req *model.GraphQLRequest, token string, cb model.GraphQLCallback) {
 	m.Called(ctx, req, token, cb)
 }
+
+func (m *mockGraphQLModule) ResolvePersistedQuery(ctx context.Context, req *model.GraphQLRequest, token string) error {
+	return m.Called(req, token).Error(0)
+}
+
+func (m *mockGraphQLModule) GetSchemaSDL() (string, error) {
+	c := m.Called()
+	return c.String(0), c.Error(1)
+}
@@ -1,12 +1,17 @@
 package server
 
 import (
+	"bufio"
 	"bytes"
+	"errors"
 	"io/ioutil"
+	"net"
 	"net/http"
 
 	"github.com/segmentio/ksuid"
 	"github.com/spaceuptech/helpers"
+
+	"github.com/spaceuptech/space-cloud/gateway/utils/tracing"
 )
 
 func loggerMiddleWare(next http.Handler) http.Handler {
@@ -25,8 +30,43 @@ func loggerMiddleWare(next http.Handler) http.Handler {
 			r.Body = ioutil.NopCloser(bytes.NewBuffer(reqBody))
 		}
 
+		// Continue the trace of the caller if it sent the trace context headers
+		ctx, span := tracing.StartSpan(tracing.Extract(helpers.CreateContext(r), r.Header), "HTTP "+r.Method, tracing.SpanKindServer,
+			tracing.String("http.method", r.Method), tracing.String("http.target", r.URL.Path), tracing.String("http.host", r.Host), tracing.String("request.id", requestID))
+		if span != nil {
+			defer span.End()
+			sw := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+			defer func() { span.SetAttributes(tracing.Int64("http.status_code", int64(sw.status))) }()
+			w = sw
+		}
+
 		helpers.Logger.LogInfo(requestID, "Request", map[string]interface{}{"method": r.Method, "url": r.URL.Path, "queryVars": r.URL.Query(), "body": string(reqBody)})
-		next.ServeHTTP(w, r.WithContext(helpers.CreateContext(r)))
+		next.ServeHTTP(w, r.WithContext(ctx))
 
 	})
 }
+
+// statusRecorder captures the status code of the response while still allowing websocket upgrades and streaming
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (s *statusRecorder) WriteHeader(status int) {
+	s.status = status
+	s.ResponseWriter.WriteHeader(status)
+}
+
+func (s *statusRecorder) Flush() {
+	if f, ok := s.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func (s *statusRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h, ok := s.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("response writer does not support hijacking")
+	}
+	return h.Hijack()
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/gorilla/mux"
 
+	"github.com/spaceuptech/space-cloud/gateway/modules/global/ratelimit"
 	"github.com/spaceuptech/space-cloud/gateway/server/handlers"
 )
 
@@ -21,6 +22,10 @@ func (s *Server) routes(profiler bool, staticPath string, restrictedHosts []stri
 
 	router.Methods(http.MethodPost).Path("/v1/config/generate-token").HandlerFunc(handlers.HandleGenerateAdminToken(s.managers.Admin()))
 
+	router.Methods(http.MethodGet).Path("/v1/config/admin-users").HandlerFunc(handlers.HandleGetAdminUsers(s.managers.Admin(), s.managers.Sync()))
+	router.Methods(http.MethodPost).Path("/v1/config/admin-users").HandlerFunc(handlers.HandleSetAdminUser(s.managers.Admin(), s.managers.Sync()))
+	router.Methods(http.MethodDelete).Path("/v1/config/admin-users/{id}").HandlerFunc(handlers.HandleDeleteAdminUser(s.managers.Admin(), s.managers.Sync()))
+
 	router.Methods(http.MethodPost).Path("/v1/config/integrations").HandlerFunc(handlers.HandlePostIntegration(s.managers.Admin(), s.managers.Sync()))
 	router.Methods(http.MethodGet).Path("/v1/config/integrations").HandlerFunc(handlers.HandleGetIntegrations(s.managers.Admin(), s.managers.Sync()))
 	router.Methods(http.MethodDelete).Path("/v1/config/integrations/{name}").HandlerFunc(handlers.HandleDeleteIntegration(s.managers.Admin(), s.managers.Sync()))
@@ -36,14 +41,22 @@ func (s *Server) routes(profiler bool, staticPath string, restrictedHosts []stri
 	router.Methods(http.MethodGet).Path("/v1/config/projects/{project}").HandlerFunc(handlers.HandleGetProjectConfig(s.managers.Admin(), s.managers.Sync()))
 	router.Methods(http.MethodPost).Path("/v1/config/projects/{project}").HandlerFunc(handlers.HandleApplyProject(s.managers.Admin(), s.managers.Sync()))
 	router.Methods(http.MethodDelete).Path("/v1/config/projects/{project}").HandlerFunc(handlers.HandleDeleteProjectConfig(s.managers.Admin(), s.managers.Sync()))
+	router.Methods(http.MethodGet).Path("/v1/config/projects/{project}/history").HandlerFunc(handlers.HandleGetConfigHistory(s.managers.Admin(), s.managers.Sync()))
+	router.Methods(http.MethodGet).Path("/v1/config/projects/{project}/history/diff").HandlerFunc(handlers.HandleGetConfigHistoryDiff(s.managers.Admin(), s.managers.Sync()))
+	router.Methods(http.MethodPost).Path("/v1/config/projects/{project}/history/rollback").HandlerFunc(handlers.HandleRollbackConfig(s.managers.Admin(), s.managers.Sync()))
 	router.Methods(http.MethodPost).Path("/v1/config/projects/{project}/generate-internal-token").HandlerFunc(handlers.HandleGenerateTokenForMissionControl(s.managers.Admin(), s.managers.Sync()))
 	router.Methods(http.MethodGet).Path("/v1/config/cluster").HandlerFunc(handlers.HandleGetClusterConfig(s.managers.Admin(), s.managers.Sync()))
 	router.Methods(http.MethodPost).Path("/v1/config/cluster").HandlerFunc(handlers.HandleSetClusterConfig(s.managers.Admin(), s.managers.Sync()))
+	router.Methods(http.MethodGet).Path("/v1/config/audit-logs").HandlerFunc(handlers.HandleListAuditLogs(s.managers.Admin(), s.managers.Audit()))
 
 	router.Methods(http.MethodGet).Path("/v1/config/projects/{project}/remote-service/service").HandlerFunc(handlers.HandleGetService(s.managers.Admin(), s.managers.Sync()))
 	router.Methods(http.MethodPost).Path("/v1/config/projects/{project}/remote-service/service/{id}").HandlerFunc(handlers.HandleAddService(s.managers.Admin(), s.managers.Sync()))
 	router.Methods(http.MethodDelete).Path("/v1/config/projects/{project}/remote-service/service/{id}").HandlerFunc(handlers.HandleDeleteService(s.managers.Admin(), s.managers.Sync()))
 
+	router.Methods(http.MethodGet).Path("/v1/config/projects/{project}/graphql/persisted-queries").HandlerFunc(handlers.HandleGetGraphQLPersistedQueries(s.managers.Admin(), s.managers.Sync()))
+	router.Methods(http.MethodPost).Path("/v1/config/projects/{project}/graphql/persisted-queries/{id}").HandlerFunc(handlers.HandleSetGraphQLPersistedQuery(s.managers.Admin(), s.managers.Sync()))
+	router.Methods(http.MethodDelete).Path("/v1/config/projects/{project}/graphql/persisted-queries/{id}").HandlerFunc(handlers.HandleDeleteGraphQLPersistedQuery(s.managers.Admin(), s.managers.Sync()))
+
 	router.Methods(http.MethodGet).Path("/v1/config/projects/{project}/user-management/provider").HandlerFunc(handlers.HandleGetUserManagement(s.managers.Admin(), s.managers.Sync()))
 	router.Methods(http.MethodPost).Path("/v1/config/projects/{project}/user-management/provider/{id}").HandlerFunc(handlers.HandleSetUserManagement(s.managers.Admin(), s.managers.Sync()))
 	router.Methods(http.MethodDelete).Path("/v1/config/projects/{project}/user-management/provider/{id}").HandlerFunc(handlers.HandleDeleteUserManagement(s.managers.Admin(), s.managers.Sync()))
@@ -65,10 +78,17 @@ func (s *Server) routes(profiler bool, staticPath string, restrictedHosts []stri
 	router.Methods(http.MethodGet).Path("/v1/config/projects/{project}/eventing/rules").HandlerFunc(handlers.HandleGetEventingSecurityRules(s.managers.Admin(), s.managers.Sync()))
 	router.Methods(http.MethodPost).Path("/v1/config/projects/{project}/eventing/rules/{id}").HandlerFunc(handlers.HandleAddEventingSecurityRule(s.managers.Admin(), s.managers.Sync()))
 	router.Methods(http.MethodDelete).Path("/v1/config/projects/{project}/eventing/rules/{id}").HandlerFunc(handlers.HandleDeleteEventingSecurityRule(s.managers.Admin(), s.managers.Sync()))
+	router.Methods(http.MethodGet).Path("/v1/external/projects/{project}/eventing/dlq").HandlerFunc(handlers.HandleListDLQEvents(s.managers.Admin(), s.modules))
+	router.Methods(http.MethodGet).Path("/v1/external/projects/{project}/eventing/dlq/{id}").HandlerFunc(handlers.HandleGetDLQEvent(s.managers.Admin(), s.modules))
+	router.Methods(http.MethodPost).Path("/v1/external/projects/{project}/eventing/dlq/replay").HandlerFunc(handlers.HandleReplayDLQEvents(s.managers.Admin(), s.modules))
+	router.Methods(http.MethodPost).Path("/v1/external/projects/{project}/eventing/dlq/discard").HandlerFunc(handlers.HandleDiscardDLQEvents(s.managers.Admin(), s.modules))
 
 	router.Methods(http.MethodGet).Path("/v1/external/projects/{project}/file-storage/connection-state").HandlerFunc(handlers.HandleGetFileState(s.managers.Admin(), s.modules))
 	router.Methods(http.MethodGet).Path("/v1/config/projects/{project}/file-storage/config").HandlerFunc(handlers.HandleGetFileStore(s.managers.Admin(), s.managers.Sync()))
 	router.Methods(http.MethodPost).Path("/v1/config/projects/{project}/file-storage/config/{id}").HandlerFunc(handlers.HandleSetFileStore(s.managers.Admin(), s.managers.Sync()))
+	router.Methods(http.MethodGet).Path("/v1/config/projects/{project}/file-storage/stores").HandlerFunc(handlers.HandleGetFileStoreAliases(s.managers.Admin(), s.managers.Sync()))
+	router.Methods(http.MethodPost).Path("/v1/config/projects/{project}/file-storage/stores/{id}").HandlerFunc(handlers.HandleSetFileStoreAlias(s.managers.Admin(), s.managers.Sync()))
+	router.Methods(http.MethodDelete).Path("/v1/config/projects/{project}/file-storage/stores/{id}").HandlerFunc(handlers.HandleDeleteFileStoreAlias(s.managers.Admin(), s.managers.Sync()))
 	router.Methods(http.MethodGet).Path("/v1/config/projects/{project}/file-storage/rules").HandlerFunc(handlers.HandleGetFileRule(s.managers.Admin(), s.managers.Sync()))
 	router.Methods(http.MethodPost).Path("/v1/config/projects/{project}/file-storage/rules/{id}").HandlerFunc(handlers.HandleSetFileRule(s.managers.Admin(), s.managers.Sync()))
 	router.Methods(http.MethodDelete).Path("/v1/config/projects/{project}/file-storage/rules/{id}").HandlerFunc(handlers.HandleDeleteFileRule(s.managers.Admin(), s.managers.Sync()))
@@ -92,6 +112,7 @@ func (s *Server) routes(profiler bool, staticPath string, restrictedHosts []stri
 	router.Methods(http.MethodPost).Path("/v1/config/projects/{project}/database/{dbAlias}/collections/{col}/schema/track").HandlerFunc(handlers.HandleInspectCollectionSchema(s.managers.Admin(), s.modules, s.managers.Sync()))
 	router.Methods(http.MethodDelete).Path("/v1/config/projects/{project}/database/{dbAlias}/collections/{col}/schema/untrack").HandlerFunc(handlers.HandleUntrackCollectionSchema(s.managers.Admin(), s.modules, s.managers.Sync()))
 	router.Methods(http.MethodGet).Path("/v1/external/projects/{project}/database/{dbAlias}/schema/inspect").HandlerFunc(handlers.HandleInspectTrackedCollectionsSchema(s.managers.Admin(), s.modules))
+	router.Methods(http.MethodGet).Path("/v1/external/projects/{project}/graphql/schema").HandlerFunc(handlers.HandleGetGraphQLSchema(s.managers.Admin(), s.modules))
 
 	router.Methods(http.MethodGet).Path("/v1/config/projects/{project}/letsencrypt/config").HandlerFunc(handlers.HandleGetEncryptWhitelistedDomain(s.managers.Admin(), s.managers.Sync()))
 	router.Methods(http.MethodPost).Path("/v1/config/projects/{project}/letsencrypt/config/{id}").HandlerFunc(handlers.HandleLetsEncryptWhitelistedDomain(s.managers.Admin(), s.managers.Sync()))
@@ -102,13 +123,14 @@ func (s *Server) routes(profiler bool, staticPath string, restrictedHosts []stri
 	router.Methods(http.MethodPost).Path("/v1/config/projects/{project}/routing/ingress/{id}").HandlerFunc(handlers.HandleSetProjectRoute(s.managers.Admin(), s.managers.Sync()))
 	router.Methods(http.MethodDelete).Path("/v1/config/projects/{project}/routing/ingress/{id}").HandlerFunc(handlers.HandleDeleteProjectRoute(s.managers.Admin(), s.managers.Sync()))
 
-	router.Methods(http.MethodPost).Path("/v1/config/batch-apply").HandlerFunc(handlers.HandleBatchApplyConfig(s.managers.Admin()))
+	router.Methods(http.MethodPost).Path("/v1/config/batch-apply").HandlerFunc(handlers.HandleBatchApplyConfig(s.managers.Admin(), s.managers.Sync()))
 
 	// Health check
 	router.Methods(http.MethodGet).Path("/v1/api/health-check").HandlerFunc(handlers.HandleHealthCheck(s.managers.Sync()))
+	router.Methods(http.MethodGet).Path("/v1/api/metrics").HandlerFunc(handlers.HandlePrometheusMetrics(s.managers.Admin(), s.modules.Metrics()))
 
 	// Initialize route for graphql
-	router.Path("/v1/api/{project}/graphql").HandlerFunc(handlers.HandleGraphQLRequest(s.modules, s.managers.Sync()))
+	router.Path("/v1/api/{project}/graphql").HandlerFunc(handlers.HandleRateLimit(s.modules, ratelimit.EndpointGraphQL, handlers.HandleGraphQLRequest(s.modules, s.managers.Sync())))
 
 	// Initialize the route for websocket
 	router.HandleFunc("/v1/api/{project}/socket/json", handlers.HandleWebsocket(s.modules))
@@ -117,7 +139,7 @@ func (s *Server) routes(profiler bool, staticPath string, restrictedHosts []stri
 	router.HandleFunc("/v1/api/{project}/graphql/socket", handlers.HandleGraphqlSocket(s.modules))
 
 	// Initialize the routes for services module
-	router.Methods(http.MethodPost).Path("/v1/api/{project}/services/{service}/{func}").HandlerFunc(handlers.HandleFunctionCall(s.modules))
+	router.Methods(http.MethodPost).Path("/v1/api/{project}/services/{service}/{func}").HandlerFunc(handlers.HandleRateLimit(s.modules, ratelimit.EndpointServices, handlers.HandleFunctionCall(s.modules)))
 
 	// Initialize the routes for realtime service
 	router.Methods(http.MethodPost).Path("/v1/api/{project}/realtime/handle").HandlerFunc(handlers.HandleRealtimeEvent(s.modules))
@@ -127,14 +149,14 @@ func (s *Server) routes(profiler bool, staticPath string, restrictedHosts []stri
 	router.Methods(http.MethodPost).Path("/v1/api/{project}/eventing/admin-queue").HandlerFunc(handlers.HandleAdminQueueEvent(s.managers.Admin(), s.modules))
 
 	// Initialize the routes for the crud operations
-	router.Methods(http.MethodPost).Path("/v1/api/{project}/crud/{dbAlias}/batch").HandlerFunc(handlers.HandleCrudBatch(s.modules))
-	router.Methods(http.MethodPost).Path("/v1/api/{project}/crud/{dbAlias}/prepared-queries/{id}").HandlerFunc(handlers.HandleCrudPreparedQuery(s.modules))
+	router.Methods(http.MethodPost).Path("/v1/api/{project}/crud/{dbAlias}/batch").HandlerFunc(handlers.HandleRateLimit(s.modules, ratelimit.EndpointCrud, handlers.HandleCrudBatch(s.modules)))
+	router.Methods(http.MethodPost).Path("/v1/api/{project}/crud/{dbAlias}/prepared-queries/{id}").HandlerFunc(handlers.HandleRateLimit(s.modules, ratelimit.EndpointCrud, handlers.HandleCrudPreparedQuery(s.modules)))
 	crudRouter := router.Methods(http.MethodPost).PathPrefix("/v1/api/{project}/crud/{dbAlias}/{col}").Subrouter()
-	crudRouter.HandleFunc("/create", handlers.HandleCrudCreate(s.modules))
-	crudRouter.HandleFunc("/read", handlers.HandleCrudRead(s.modules))
-	crudRouter.HandleFunc("/update", handlers.HandleCrudUpdate(s.modules))
-	crudRouter.HandleFunc("/delete", handlers.HandleCrudDelete(s.modules))
-	crudRouter.HandleFunc("/aggr", handlers.HandleCrudAggregate(s.modules))
+	crudRouter.HandleFunc("/create", handlers.HandleRateLimit(s.modules, ratelimit.EndpointCrud, handlers.HandleCrudCreate(s.modules)))
+	crudRouter.HandleFunc("/read", handlers.HandleRateLimit(s.modules, ratelimit.EndpointCrud, handlers.HandleCrudRead(s.modules)))
+	crudRouter.HandleFunc("/update", handlers.HandleRateLimit(s.modules, ratelimit.EndpointCrud, handlers.HandleCrudUpdate(s.modules)))
+	crudRouter.HandleFunc("/delete", handlers.HandleRateLimit(s.modules, ratelimit.EndpointCrud, handlers.HandleCrudDelete(s.modules)))
+	crudRouter.HandleFunc("/aggr", handlers.HandleRateLimit(s.modules, ratelimit.EndpointCrud, handlers.HandleCrudAggregate(s.modules)))
 
 	// Initialize the routes for the user management operations
 	userRouter := router.PathPrefix("/v1/api/{project}/auth/{dbAlias}").Subrouter()
@@ -143,11 +165,22 @@ func (s *Server) routes(profiler bool, staticPath string, restrictedHosts []stri
 	userRouter.Methods(http.MethodGet).Path("/profile/{id}").HandlerFunc(handlers.HandleProfile(s.modules))
 	userRouter.Methods(http.MethodGet).Path("/profiles").HandlerFunc(handlers.HandleProfiles(s.modules))
 	userRouter.Methods(http.MethodPost).Path("/edit_profile/{id}").HandlerFunc(handlers.HandleEmailEditProfile(s.modules))
+	userRouter.Methods(http.MethodGet).Path("/oauth/{provider}/login").HandlerFunc(handlers.HandleOAuthLogin(s.modules))
+	userRouter.Methods(http.MethodGet).Path("/oauth/{provider}/callback").HandlerFunc(handlers.HandleOAuthCallback(s.modules))
 
 	// Initialize the routes for the file management operations
-	router.Methods(http.MethodPost).Path("/v1/api/{project}/files").HandlerFunc(handlers.HandleCreateFile(s.modules))
-	router.Methods(http.MethodGet).PathPrefix("/v1/api/{project}/files").HandlerFunc(handlers.HandleRead(s.modules))
-	router.Methods(http.MethodDelete).PathPrefix("/v1/api/{project}/files").HandlerFunc(handlers.HandleDelete(s.modules))
+	router.Methods(http.MethodPost).Path("/v1/api/{project}/files/signed-url").HandlerFunc(handlers.HandleRateLimit(s.modules, ratelimit.EndpointFiles, handlers.HandleCreateSignedURL(s.modules)))
+	router.Methods(http.MethodGet, http.MethodPut).Path("/v1/api/{project}/signed-files").HandlerFunc(handlers.HandleRateLimit(s.modules, ratelimit.EndpointFiles, handlers.HandleSignedFile(s.modules)))
+	// The uploads directory of the file stores is reserved for the routes of resumable uploads, which would shadow it
+	router.Methods(http.MethodPost).Path("/v1/api/{project}/files/uploads").HandlerFunc(handlers.HandleRateLimit(s.modules, ratelimit.EndpointFiles, handlers.HandleCreateUpload(s.modules)))
+	router.Methods(http.MethodGet).Path("/v1/api/{project}/files/uploads/{id}").HandlerFunc(handlers.HandleRateLimit(s.modules, ratelimit.EndpointFiles, handlers.HandleGetUpload(s.modules)))
+	router.Methods(http.MethodPut).Path("/v1/api/{project}/files/uploads/{id}/parts/{part}").HandlerFunc(handlers.HandleRateLimit(s.modules, ratelimit.EndpointFiles, handlers.HandleUploadPart(s.modules)))
+	router.Methods(http.MethodPost).Path("/v1/api/{project}/files/uploads/{id}/complete").HandlerFunc(handlers.HandleRateLimit(s.modules, ratelimit.EndpointFiles, handlers.HandleCompleteUpload(s.modules)))
+	router.Methods(http.MethodDelete).Path("/v1/api/{project}/files/uploads/{id}").HandlerFunc(handlers.HandleRateLimit(s.modules, ratelimit.EndpointFiles, handlers.HandleAbortUpload(s.modules)))
+	router.Methods(http.MethodPost).Path("/v1/api/{project}/files").HandlerFunc(handlers.HandleRateLimit(s.modules, ratelimit.EndpointFiles, handlers.HandleCreateFile(s.modules)))
+	router.Methods(http.MethodGet).PathPrefix("/v1/api/{project}/files").HandlerFunc(handlers.HandleRateLimit(s.modules, ratelimit.EndpointFiles, handlers.HandleRead(s.modules)))
+	router.Methods(http.MethodHead).PathPrefix("/v1/api/{project}/files").HandlerFunc(handlers.HandleRateLimit(s.modules, ratelimit.EndpointFiles, handlers.HandleFileInfo(s.modules)))
+	router.Methods(http.MethodDelete).PathPrefix("/v1/api/{project}/files").HandlerFunc(handlers.HandleRateLimit(s.modules, ratelimit.EndpointFiles, handlers.HandleDelete(s.modules)))
 
 	// Register pprof handlers if profiler set to true
 	if profiler {
@@ -13,7 +13,6 @@ import (
 	"github.com/spaceuptech/space-cloud/gateway/managers"
 	"github.com/spaceuptech/space-cloud/gateway/modules"
 	"github.com/spaceuptech/space-cloud/gateway/modules/global"
-	"github.com/spaceuptech/space-cloud/gateway/utils"
 )
 
 // Server is the object which sets up the server and handles all server operations
@@ -44,6 +43,7 @@ func New(nodeID, clusterID, storeType, runnerAddr string, isDev bool, adminUserI
 
 	managers.Sync().SetModules(modules)
 	managers.Sync().SetGlobalModules(globalMods.Metrics())
+	managers.Audit().SetModules(modules)
 
 	helpers.Logger.LogInfo(helpers.GetRequestID(context.TODO()), fmt.Sprintf("Creating a new server with id %s", nodeID), nil)
 
@@ -57,8 +57,8 @@ func (s *Server) Start(profiler bool, staticPath string, port int, restrictedHos
 		return err
 	}
 
-	// Allow cors
-	corsObj := utils.CreateCorsObject()
+	// Apply the cors policy of the project or ingress route of each request
+	corsObj := s.modules.Cors()
 
 	if s.ssl != nil && s.ssl.Enabled {
 
@@ -0,0 +1,21 @@
+package utils
+
+const (
+	// TableAuditLogs is a variable for "audit_logs"
+	TableAuditLogs string = "audit_logs"
+	// SchemaAuditLogs is a variable for the audit log schema
+	SchemaAuditLogs string = `type audit_logs {
+		_id: ID! @primary
+		timestamp: DateTime! @index
+		request_id: String
+		node_id: String
+		kind: ID! @size(value: 20)
+		actor: String
+		project: String
+		resource: String
+		resource_id: String
+		verb: String
+		outcome: ID! @size(value: 20)
+		message: String
+	  }`
+)
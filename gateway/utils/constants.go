@@ -47,8 +47,22 @@ const (
 
 	// GCPStorage is the type used for the GCP storage
 	GCPStorage FileStoreType = "gcp-storage"
+
+	// AzureBlob is the type used for the Azure blob storage
+	AzureBlob FileStoreType = "azure-blob"
 )
 
+// DefaultFileStore is the alias of the file store configured by the file store config of a project
+const DefaultFileStore = "default"
+
+// GetFileStoreAlias returns the alias of the file store. The default file store is used if no alias is provided
+func GetFileStoreAlias(alias string) string {
+	if alias == "" {
+		return DefaultFileStore
+	}
+	return alias
+}
+
 const (
 
 	// RealtimeInsert is for create operations
@@ -128,6 +142,9 @@ const GraphQLGroupByArgument = "group"
 // GraphQLAggregate is used by graphql aggregate clause
 const GraphQLAggregate = "aggregate"
 
+// GraphQLCursorField is used by graphql to return the pagination cursor of each document
+const GraphQLCursorField = "_cursor"
+
 // DatabaseCollections stores all callections of sql or postgres or mongo
 type DatabaseCollections struct {
 	TableName string `db:"table_name" json:"tableName"`
@@ -151,6 +168,9 @@ const (
 
 	// EventFileDelete is fired for delete request
 	EventFileDelete string = "FILE_DELETE"
+
+	// EventCron is fired on every tick of the schedule of a cron trigger
+	EventCron string = "CRON"
 )
 
 const (
@@ -168,6 +188,9 @@ const (
 
 	// EventStatusCancelled signifies that the event has been cancelled and should not be processed
 	EventStatusCancelled string = "cancel"
+
+	// EventStatusDiscarded signifies that the failed event has been discarded from the dead letter queue
+	EventStatusDiscarded string = "discarded"
 )
 
 // RequestKind specifies the kind of the request
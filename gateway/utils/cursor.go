@@ -0,0 +1,206 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// CursorClauseKey is the key under which the where clause generated from a cursor gets added to the find clause.
+// Keys prefixed with `$or` are treated as an or clause by all the databases
+const CursorClauseKey = "$or:cursor"
+
+// The types of the values of a cursor which don't survive a round trip through json
+const (
+	cursorTypeDate     = "date"
+	cursorTypeObjectID = "oid"
+)
+
+// cursor is the decoded form of a pagination cursor. The types hold the type of each value which isn't a plain json
+// value, so that it gets compared with the documents as the same type it was read as
+type cursor struct {
+	Sort   []string      `json:"s"`
+	Values []interface{} `json:"v"`
+	Types  []string      `json:"t,omitempty"`
+}
+
+// EncodeCursor generates an opaque cursor pointing to the provided document. The cursor stores the values of
+// the sort keys so that a subsequent read can resume right after (or before) the document
+func EncodeCursor(sort []string, doc interface{}) (string, error) {
+	if len(sort) == 0 {
+		return "", errors.New("sort needs to be provided to generate a cursor")
+	}
+
+	obj, ok := doc.(map[string]interface{})
+	if !ok {
+		return "", fmt.Errorf("cannot generate cursor for document of type (%T)", doc)
+	}
+
+	c := cursor{Sort: sort, Values: make([]interface{}, len(sort))}
+	for i, field := range sort {
+		value, err := loadCursorField(strings.TrimPrefix(field, "-"), obj)
+		if err != nil {
+			return "", err
+		}
+
+		var valueType string
+		switch v := value.(type) {
+		case time.Time:
+			valueType, value = cursorTypeDate, v.UTC().Format(time.RFC3339Nano)
+		case primitive.DateTime:
+			valueType, value = cursorTypeDate, v.Time().UTC().Format(time.RFC3339Nano)
+		case primitive.ObjectID:
+			valueType, value = cursorTypeObjectID, v.Hex()
+		}
+		if valueType != "" {
+			if c.Types == nil {
+				c.Types = make([]string, len(sort))
+			}
+			c.Types[i] = valueType
+		}
+		c.Values[i] = value
+	}
+
+	data, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// DecodeCursor returns the values of the sort keys stored in a cursor. An error is returned
+// if the cursor was generated for a different sort order
+func DecodeCursor(sort []string, value string) ([]interface{}, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, errors.New("invalid cursor provided")
+	}
+
+	// Use json numbers so that we don't lose precision of large integers
+	c := new(cursor)
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(c); err != nil {
+		return nil, errors.New("invalid cursor provided")
+	}
+
+	if len(c.Sort) != len(sort) || len(c.Values) != len(sort) || (c.Types != nil && len(c.Types) != len(sort)) {
+		return nil, errors.New("cursor does not belong to the provided sort order")
+	}
+	for i, field := range sort {
+		if c.Sort[i] != field {
+			return nil, errors.New("cursor does not belong to the provided sort order")
+		}
+	}
+
+	for i, v := range c.Values {
+		if c.Types != nil && c.Types[i] != "" {
+			value, err := decodeCursorValue(c.Types[i], v)
+			if err != nil {
+				return nil, err
+			}
+			c.Values[i] = value
+			continue
+		}
+
+		if number, ok := v.(json.Number); ok {
+			if n, err := number.Int64(); err == nil {
+				c.Values[i] = n
+				continue
+			}
+			n, err := number.Float64()
+			if err != nil {
+				return nil, errors.New("invalid cursor provided")
+			}
+			c.Values[i] = n
+		}
+	}
+	return c.Values, nil
+}
+
+// decodeCursorValue restores the type of a value stored in a cursor
+func decodeCursorValue(valueType string, value interface{}) (interface{}, error) {
+	str, ok := value.(string)
+	if !ok {
+		return nil, errors.New("invalid cursor provided")
+	}
+
+	switch valueType {
+	case cursorTypeDate:
+		t, err := time.Parse(time.RFC3339Nano, str)
+		if err != nil {
+			return nil, errors.New("invalid cursor provided")
+		}
+		return t, nil
+	case cursorTypeObjectID:
+		id, err := primitive.ObjectIDFromHex(str)
+		if err != nil {
+			return nil, errors.New("invalid cursor provided")
+		}
+		return id, nil
+	default:
+		return nil, errors.New("invalid cursor provided")
+	}
+}
+
+// GenerateCursorClause generates the or clauses which select the documents coming after the position described by
+// the values of the sort keys. The documents coming before that position are selected if isBefore is true
+//
+// For a sort of [a, -b] the generated clause is equivalent to `a > v1 || (a == v1 && b < v2)`
+func GenerateCursorClause(sort []string, values []interface{}, isBefore bool) []interface{} {
+	clauses := make([]interface{}, len(sort))
+	for i, field := range sort {
+		clause := make(map[string]interface{}, i+1)
+		for j := 0; j < i; j++ {
+			clause[strings.TrimPrefix(sort[j], "-")] = values[j]
+		}
+
+		// Descending fields need to be compared the other way round
+		op := "$gt"
+		if strings.HasPrefix(field, "-") != isBefore {
+			op = "$lt"
+		}
+		clause[strings.TrimPrefix(field, "-")] = map[string]interface{}{op: values[i]}
+		clauses[i] = clause
+	}
+	return clauses
+}
+
+// ReverseSort reverses the direction of each field in the provided sort
+func ReverseSort(sort []string) []string {
+	reversed := make([]string, len(sort))
+	for i, field := range sort {
+		if strings.HasPrefix(field, "-") {
+			reversed[i] = strings.TrimPrefix(field, "-")
+		} else {
+			reversed[i] = "-" + field
+		}
+	}
+	return reversed
+}
+
+func loadCursorField(field string, obj map[string]interface{}) (interface{}, error) {
+	if value, p := obj[field]; p {
+		return value, nil
+	}
+
+	// The field might be nested
+	var value interface{} = obj
+	for _, key := range strings.Split(field, ".") {
+		nested, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("sort field (%s) not present in document", field)
+		}
+		value, ok = nested[key]
+		if !ok {
+			return nil, fmt.Errorf("sort field (%s) not present in document", field)
+		}
+	}
+	return value, nil
+}
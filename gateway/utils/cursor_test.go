@@ -0,0 +1,166 @@
+package utils
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestEncodeDecodeCursor(t *testing.T) {
+	objectID := primitive.NewObjectID()
+	date := time.Date(2020, 10, 1, 10, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		sort       []string
+		decodeSort []string
+		doc        interface{}
+		want       []interface{}
+		wantErr    bool
+	}{
+		{
+			name:       "single ascending field",
+			sort:       []string{"id"},
+			decodeSort: []string{"id"},
+			doc:        map[string]interface{}{"id": "1", "name": "john"},
+			want:       []interface{}{"1"},
+		},
+		{
+			name:       "multiple fields with numbers",
+			sort:       []string{"-age", "height", "id"},
+			decodeSort: []string{"-age", "height", "id"},
+			doc:        map[string]interface{}{"id": int64(9007199254740993), "age": 25, "height": 5.5},
+			want:       []interface{}{int64(25), 5.5, int64(9007199254740993)},
+		},
+		{
+			name:       "nested field",
+			sort:       []string{"address.city"},
+			decodeSort: []string{"address.city"},
+			doc:        map[string]interface{}{"address": map[string]interface{}{"city": "mumbai"}},
+			want:       []interface{}{"mumbai"},
+		},
+		{
+			name:       "null value",
+			sort:       []string{"name"},
+			decodeSort: []string{"name"},
+			doc:        map[string]interface{}{"name": nil},
+			want:       []interface{}{nil},
+		},
+		{
+			name:       "object id and dates keep their types",
+			sort:       []string{"-createdAt", "updatedAt", "_id"},
+			decodeSort: []string{"-createdAt", "updatedAt", "_id"},
+			doc: map[string]interface{}{
+				"_id":       objectID,
+				"createdAt": primitive.NewDateTimeFromTime(date),
+				"updatedAt": date.In(time.FixedZone("IST", 19800)),
+			},
+			want: []interface{}{date, date, objectID},
+		},
+		{
+			name:       "cursor of a different sort order",
+			sort:       []string{"age"},
+			decodeSort: []string{"-age"},
+			doc:        map[string]interface{}{"age": 25},
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cursor, err := EncodeCursor(tt.sort, tt.doc)
+			if err != nil {
+				t.Errorf("EncodeCursor() error = %v", err)
+				return
+			}
+			got, err := DecodeCursor(tt.decodeSort, cursor)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DecodeCursor() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DecodeCursor() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEncodeCursor_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		sort []string
+		doc  interface{}
+	}{
+		{name: "no sort", doc: map[string]interface{}{"id": "1"}},
+		{name: "field not present", sort: []string{"age"}, doc: map[string]interface{}{"id": "1"}},
+		{name: "invalid document", sort: []string{"id"}, doc: []interface{}{"1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := EncodeCursor(tt.sort, tt.doc); err == nil {
+				t.Errorf("EncodeCursor() expected error")
+			}
+		})
+	}
+}
+
+func TestDecodeCursor_Invalid(t *testing.T) {
+	invalidObjectID := "eyJzIjpbImlkIl0sInYiOlsiMTIzIl0sInQiOlsib2lkIl19" // {"s":["id"],"v":["123"],"t":["oid"]}
+	for _, cursor := range []string{"", "not-a-cursor!", "e30", invalidObjectID} {
+		if _, err := DecodeCursor([]string{"id"}, cursor); err == nil {
+			t.Errorf("DecodeCursor(%q) expected error", cursor)
+		}
+	}
+}
+
+func TestGenerateCursorClause(t *testing.T) {
+	tests := []struct {
+		name     string
+		sort     []string
+		values   []interface{}
+		isBefore bool
+		want     []interface{}
+	}{
+		{
+			name:   "after single field",
+			sort:   []string{"id"},
+			values: []interface{}{"5"},
+			want: []interface{}{
+				map[string]interface{}{"id": map[string]interface{}{"$gt": "5"}},
+			},
+		},
+		{
+			name:   "after multiple fields with descending order",
+			sort:   []string{"-age", "id"},
+			values: []interface{}{int64(25), "5"},
+			want: []interface{}{
+				map[string]interface{}{"age": map[string]interface{}{"$lt": int64(25)}},
+				map[string]interface{}{"age": int64(25), "id": map[string]interface{}{"$gt": "5"}},
+			},
+		},
+		{
+			name:     "before multiple fields with descending order",
+			sort:     []string{"-age", "id"},
+			values:   []interface{}{int64(25), "5"},
+			isBefore: true,
+			want: []interface{}{
+				map[string]interface{}{"age": map[string]interface{}{"$gt": int64(25)}},
+				map[string]interface{}{"age": int64(25), "id": map[string]interface{}{"$lt": "5"}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GenerateCursorClause(tt.sort, tt.values, tt.isBefore); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GenerateCursorClause() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReverseSort(t *testing.T) {
+	if got, want := ReverseSort([]string{"-age", "id"}), []string{"age", "-id"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ReverseSort() = %v, want %v", got, want)
+	}
+}
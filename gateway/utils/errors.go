@@ -1,6 +1,11 @@
 package utils
 
-import "errors"
+import (
+	"errors"
+	"strings"
+
+	"github.com/spaceuptech/helpers"
+)
 
 // ErrInvalidParams is thrown when the input parameters for an operation are invalid
 var ErrInvalidParams = errors.New("Invalid parameter provided")
@@ -13,3 +18,31 @@ var ErrUnsupportedDatabase = errors.New("Unsupported database. Make sure your da
 
 // ErrDatabaseConnection is thrown when SC was unable to connect to the requested database
 var ErrDatabaseConnection = errors.New("Could not connect to database. Make sure it is up and connection string provided to SC is correct")
+
+// duplicateKeyErrors are the messages with which the databases report a violated primary key or unique constraint
+var duplicateKeyErrors = []string{
+	"duplicate key",                       // Mongo, Postgres and SQL Server (unique index)
+	"duplicate entry",                     // MySQL
+	"violation of primary key constraint", // SQL Server
+	"violation of unique key constraint",  // SQL Server
+	"unique constraint failed",            // SQLite
+}
+
+// IsDuplicateKeyError checks if a database rejected a write because it violates a primary key or unique constraint
+func IsDuplicateKeyError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	msg := err.Error()
+	if e, ok := err.(helpers.Error); ok {
+		msg += " " + e.RawError()
+	}
+	msg = strings.ToLower(msg)
+	for _, s := range duplicateKeyErrors {
+		if strings.Contains(msg, s) {
+			return true
+		}
+	}
+	return false
+}
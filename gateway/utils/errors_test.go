@@ -0,0 +1,32 @@
+package utils
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/spaceuptech/helpers"
+)
+
+func TestIsDuplicateKeyError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "no error", err: nil, want: false},
+		{name: "mongo", err: errors.New(`E11000 duplicate key error collection: db.event_logs index: _id_ dup key: { _id: "1" }`), want: true},
+		{name: "postgres", err: errors.New(`pq: duplicate key value violates unique constraint "event_logs_pkey"`), want: true},
+		{name: "mysql", err: errors.New("Error 1062: Duplicate entry '1' for key 'PRIMARY'"), want: true},
+		{name: "sql server", err: errors.New("mssql: Violation of PRIMARY KEY constraint 'PK_event_logs'. Cannot insert duplicate key in object 'dbo.event_logs'."), want: true},
+		{name: "sqlite", err: errors.New("constraint failed: UNIQUE constraint failed: event_logs._id (1555)"), want: true},
+		{name: "logged database error", err: helpers.Logger.LogError("", "Unable to create event", errors.New("Error 1062: Duplicate entry '1' for key 'PRIMARY'"), nil), want: true},
+		{name: "connection error", err: errors.New("dial tcp 127.0.0.1:5432: connect: connection refused"), want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsDuplicateKeyError(tt.err); got != tt.want {
+				t.Errorf("IsDuplicateKeyError() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -29,6 +29,8 @@ const (
 		status: String
 		remark: String
 		trigger_type: ID @size(value: 10)
+		retries: Integer
+		next_attempt: DateTime
 		invocations: [invocation_logs]! @link(table: "invocation_logs", from: "_id", to: "event_id")
 	  }`
 )
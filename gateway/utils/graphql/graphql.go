@@ -7,17 +7,22 @@ import (
 	"fmt"
 	"sync"
 
+	gql "github.com/graphql-go/graphql"
 	"github.com/graphql-go/graphql/language/ast"
 	"github.com/graphql-go/graphql/language/kinds"
 	"github.com/graphql-go/graphql/language/parser"
 	"github.com/graphql-go/graphql/language/source"
 
+	"github.com/spaceuptech/space-cloud/gateway/config"
 	"github.com/spaceuptech/space-cloud/gateway/model"
 	"github.com/spaceuptech/space-cloud/gateway/utils"
+	"github.com/spaceuptech/space-cloud/gateway/utils/tracing"
 )
 
 // Module is the object for the GraphQL module
 type Module struct {
+	lock sync.RWMutex
+
 	project   string
 	auth      AuthInterface
 	crud      CrudInterface
@@ -26,6 +31,18 @@ type Module struct {
 
 	// 	Auth module
 	aesKey []byte
+
+	limits *config.GraphQLLimits
+
+	persistedQueries     map[string]*config.GraphQLPersistedQuery // key is the sha256 hash of the query
+	persistedQueriesOnly bool
+
+	introspection config.GraphQLIntrospection
+
+	// generatedSchema is built on first use and reset whenever the config it is generated from changes. The
+	// version guards against caching a schema built from the config which was replaced while building it
+	generatedSchema *gql.Schema
+	schemaVersion   int
 }
 
 // New creates a new GraphQL module
@@ -55,6 +72,13 @@ func (graph *Module) GetProjectID() string {
 
 // ExecGraphQLQuery executes the provided graphql query
 func (graph *Module) ExecGraphQLQuery(ctx context.Context, req *model.GraphQLRequest, token string, cb model.GraphQLCallback) {
+	ctx, span := tracing.StartSpan(ctx, "graphql.execute", tracing.SpanKindInternal, tracing.String("graphql.project", graph.project), tracing.String("graphql.operation.name", req.OperationName))
+	cb = endSpanOnCallback(span, cb)
+
+	if err := graph.ResolvePersistedQuery(ctx, req, token); err != nil {
+		cb(nil, err)
+		return
+	}
 
 	s := source.NewSource(&source.Source{
 		Body: []byte(req.Query),
@@ -66,8 +90,68 @@ func (graph *Module) ExecGraphQLQuery(ctx context.Context, req *model.GraphQLReq
 		cb(nil, err)
 		return
 	}
+	if isQueriesOnly(ctx) && !isQueryOperation(doc) {
+		cb(nil, errors.New("only queries can be executed with this request"))
+		return
+	}
+
+	op, isIntrospection := introspectionOperation(doc)
+	if isIntrospection {
+		if err := graph.checkIntrospection(ctx, token); err != nil {
+			cb(nil, err)
+			return
+		}
+	}
+
+	store := utils.M{"vars": req.Variables, "path": "", "_query": utils.NewArray(0), "directive": ""}
+	if err := graph.checkQueryLimits(ctx, doc, store); err != nil {
+		cb(nil, err)
+		return
+	}
 
-	graph.execGraphQLDocument(ctx, doc, token, utils.M{"vars": req.Variables, "path": "", "_query": utils.NewArray(0), "directive": ""}, nil, createCallback(cb))
+	// Introspection queries are answered from the generated schema of the project
+	if isIntrospection {
+		graph.execIntrospectionQuery(ctx, op, req, cb)
+		return
+	}
+
+	ctx, cb = graph.withExecutionTimeLimit(ctx, cb)
+	graph.execGraphQLDocument(ctx, doc, token, store, nil, createCallback(cb))
+}
+
+type queriesOnlyKey struct{}
+
+// WithQueriesOnly returns a context which only allows queries to be executed with it. It is used for the requests which
+// must not have side effects, like the ones made with the GET method
+func WithQueriesOnly(ctx context.Context) context.Context {
+	return context.WithValue(ctx, queriesOnlyKey{}, true)
+}
+
+func isQueriesOnly(ctx context.Context) bool {
+	queriesOnly, _ := ctx.Value(queriesOnlyKey{}).(bool)
+	return queriesOnly
+}
+
+// isQueryOperation tells if the operation which gets executed from the document is a query
+func isQueryOperation(doc *ast.Document) bool {
+	if len(doc.Definitions) == 0 {
+		return false
+	}
+	op, ok := doc.Definitions[0].(*ast.OperationDefinition)
+	return ok && op.Operation == ast.OperationTypeQuery
+}
+
+// endSpanOnCallback returns a callback which ends the span with the result of the resolver before invoking the
+// provided callback
+func endSpanOnCallback(span *tracing.Span, cb model.GraphQLCallback) model.GraphQLCallback {
+	if span == nil {
+		return cb
+	}
+	return func(result interface{}, err error) {
+		span.RecordError(err)
+		span.End()
+		cb(result, err)
+	}
 }
 
 type dbCallback func(dbAlias, col string, op interface{}, err error)
@@ -206,6 +290,11 @@ func (graph *Module) execGraphQLDocument(ctx context.Context, node ast.Node, tok
 			}
 
 			kind := graph.getQueryKind(directive, field.Name.Value)
+
+			// Trace the resolver of the field. Nested fields are resolved from the result of the parent and aren't traced
+			ctx, span := tracing.StartSpan(ctx, "graphql.resolve "+field.Name.Value, tracing.SpanKindInternal,
+				tracing.String("graphql.field.name", field.Name.Value), tracing.String("graphql.resolver.kind", kind), tracing.String("graphql.directive", directive))
+			cb = endSpanOnCallback(span, cb)
 			// database query
 			if kind == "read" {
 				graph.execReadRequest(ctx, field, token, store, createDBCallback(func(dbAlias, col string, result interface{}, err error) {
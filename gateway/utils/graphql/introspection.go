@@ -0,0 +1,86 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	gql "github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+
+	"github.com/spaceuptech/space-cloud/gateway/config"
+	"github.com/spaceuptech/space-cloud/gateway/model"
+)
+
+// introspectionOperation returns the operation of the document if it only selects the introspection fields
+// (__schema, __type and __typename). Like the rest of the graphql module, only the first definition is executed
+func introspectionOperation(doc *ast.Document) (*ast.OperationDefinition, bool) {
+	if len(doc.Definitions) == 0 {
+		return nil, false
+	}
+	op, ok := doc.Definitions[0].(*ast.OperationDefinition)
+	if !ok || op.Operation != ast.OperationTypeQuery || op.SelectionSet == nil || len(op.SelectionSet.Selections) == 0 {
+		return nil, false
+	}
+
+	for _, selection := range op.SelectionSet.Selections {
+		field, ok := selection.(*ast.Field)
+		if !ok || !strings.HasPrefix(field.Name.Value, "__") {
+			return nil, false
+		}
+	}
+	return op, true
+}
+
+// SetIntrospection sets who can run introspection queries on the project
+func (graph *Module) SetIntrospection(introspection config.GraphQLIntrospection) {
+	graph.lock.Lock()
+	defer graph.lock.Unlock()
+	graph.introspection = introspection
+}
+
+// checkIntrospection checks if the caller is allowed to run introspection queries. Unknown settings are treated as disabled
+func (graph *Module) checkIntrospection(ctx context.Context, token string) error {
+	graph.lock.RLock()
+	introspection := graph.introspection
+	graph.lock.RUnlock()
+
+	switch introspection {
+	case "", config.GraphQLIntrospectionEnabled:
+		return nil
+	case config.GraphQLIntrospectionToken:
+		if _, err := graph.auth.ParseToken(ctx, token); err != nil {
+			return errors.New("a valid token is required to run introspection queries")
+		}
+		return nil
+	default:
+		return errors.New("introspection queries are disabled for this project")
+	}
+}
+
+// execIntrospectionQuery answers the introspection query using the generated schema of the project
+func (graph *Module) execIntrospectionQuery(ctx context.Context, op *ast.OperationDefinition, req *model.GraphQLRequest, cb model.GraphQLCallback) {
+	schema, err := graph.GenerateSchema()
+	if err != nil {
+		cb(nil, err)
+		return
+	}
+
+	var operationName string
+	if op.Name != nil {
+		operationName = op.Name.Value
+	}
+
+	result := gql.Do(gql.Params{
+		Schema:         *schema,
+		RequestString:  req.Query,
+		VariableValues: req.Variables,
+		OperationName:  operationName,
+		Context:        ctx,
+	})
+	if len(result.Errors) > 0 {
+		cb(nil, errors.New(result.Errors[0].Message))
+		return
+	}
+	cb(result.Data, nil)
+}
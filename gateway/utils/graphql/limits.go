@@ -0,0 +1,279 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/graphql-go/graphql/language/ast"
+
+	"github.com/spaceuptech/space-cloud/gateway/config"
+	"github.com/spaceuptech/space-cloud/gateway/model"
+	"github.com/spaceuptech/space-cloud/gateway/utils"
+)
+
+// defaultListSize is the number of documents a list is assumed to hold while estimating the cost of a query which
+// doesn't specify a limit
+const defaultListSize = 100
+
+// maxCost is the value the cost of a query saturates at instead of overflowing
+const maxCost = int(^uint(0) >> 1)
+
+// Codes of the errors returned when a query exceeds one of the limits of the project
+const (
+	LimitCodeMaxDepth         = "MAX_DEPTH_EXCEEDED"
+	LimitCodeMaxAliases       = "MAX_ALIASES_EXCEEDED"
+	LimitCodeMaxCost          = "MAX_COST_EXCEEDED"
+	LimitCodeMaxExecutionTime = "MAX_EXECUTION_TIME_EXCEEDED"
+)
+
+// QueryCost describes the complexity of a graphql query. It is computed before the query gets executed
+type QueryCost struct {
+	Depth   int `json:"depth"`
+	Aliases int `json:"aliases"`
+	Cost    int `json:"cost"`
+}
+
+// LimitError is returned when a graphql query exceeds one of the limits of the project
+type LimitError struct {
+	Code  string
+	Limit int
+	Value int
+}
+
+func (e *LimitError) Error() string {
+	switch e.Code {
+	case LimitCodeMaxDepth:
+		return fmt.Sprintf("query depth (%d) exceeds the maximum allowed depth (%d)", e.Value, e.Limit)
+	case LimitCodeMaxAliases:
+		return fmt.Sprintf("query has (%d) aliases which exceeds the maximum allowed aliases (%d)", e.Value, e.Limit)
+	case LimitCodeMaxCost:
+		return fmt.Sprintf("query cost (%d) exceeds the maximum allowed cost (%d)", e.Value, e.Limit)
+	default:
+		return fmt.Sprintf("query did not finish within the maximum allowed execution time (%d ms)", e.Limit)
+	}
+}
+
+// Extensions returns the details of the error to be sent in the extensions field of the graphql error
+func (e *LimitError) Extensions() map[string]interface{} {
+	ext := map[string]interface{}{"code": e.Code, "limit": e.Limit}
+	if e.Code != LimitCodeMaxExecutionTime {
+		ext["value"] = e.Value
+	}
+	return ext
+}
+
+type queryCostKey struct{}
+
+// WithQueryCost returns a context which collects the cost of the query executed with it
+func WithQueryCost(ctx context.Context) (context.Context, *QueryCost) {
+	cost := new(QueryCost)
+	return context.WithValue(ctx, queryCostKey{}, cost), cost
+}
+
+// SetQueryLimits sets the limits enforced on the graphql queries of the project
+func (graph *Module) SetQueryLimits(limits *config.GraphQLLimits) {
+	graph.lock.Lock()
+	defer graph.lock.Unlock()
+	graph.limits = limits
+}
+
+func (graph *Module) getQueryLimits() config.GraphQLLimits {
+	graph.lock.RLock()
+	defer graph.lock.RUnlock()
+	if graph.limits == nil {
+		return config.GraphQLLimits{}
+	}
+	return *graph.limits
+}
+
+// checkQueryLimits computes the cost of the operation and checks it against the limits of the project. The cost is
+// only computed if a limit is configured or the caller has asked for it
+func (graph *Module) checkQueryLimits(ctx context.Context, doc *ast.Document, store utils.M) error {
+	limits := graph.getQueryLimits()
+	report, _ := ctx.Value(queryCostKey{}).(*QueryCost)
+	if report == nil && limits.MaxDepth == 0 && limits.MaxAliases == 0 && limits.MaxCost == 0 {
+		return nil
+	}
+	if len(doc.Definitions) == 0 {
+		return nil
+	}
+	op, ok := doc.Definitions[0].(*ast.OperationDefinition)
+	if !ok {
+		return nil
+	}
+
+	a := &costAnalyzer{graph: graph, store: store, fragments: map[string]*ast.FragmentDefinition{}}
+	for _, def := range doc.Definitions {
+		if fragment, ok := def.(*ast.FragmentDefinition); ok {
+			a.fragments[fragment.Name.Value] = fragment
+		}
+	}
+	a.cost.Cost = a.selectionSet(op.SelectionSet, 1, "", "", map[string]bool{})
+	if report != nil {
+		*report = a.cost
+	}
+
+	switch {
+	case limits.MaxDepth > 0 && a.cost.Depth > limits.MaxDepth:
+		return &LimitError{Code: LimitCodeMaxDepth, Limit: limits.MaxDepth, Value: a.cost.Depth}
+	case limits.MaxAliases > 0 && a.cost.Aliases > limits.MaxAliases:
+		return &LimitError{Code: LimitCodeMaxAliases, Limit: limits.MaxAliases, Value: a.cost.Aliases}
+	case limits.MaxCost > 0 && a.cost.Cost > limits.MaxCost:
+		return &LimitError{Code: LimitCodeMaxCost, Limit: limits.MaxCost, Value: a.cost.Cost}
+	}
+	return nil
+}
+
+// withExecutionTimeLimit cancels the context and invokes the callback with an error if the query doesn't finish
+// within the maximum execution time of the project
+func (graph *Module) withExecutionTimeLimit(ctx context.Context, cb model.GraphQLCallback) (context.Context, model.GraphQLCallback) {
+	maxTime := graph.getQueryLimits().MaxExecutionTime
+	if maxTime <= 0 {
+		return ctx, cb
+	}
+
+	parent := ctx
+	ctx, cancel := context.WithTimeout(parent, time.Duration(maxTime)*time.Millisecond)
+	cb = createCallback(cb)
+	go func() {
+		<-ctx.Done()
+		// The query is only reported as too slow if our deadline fired and not the one of the parent context
+		if ctx.Err() == context.DeadlineExceeded && parent.Err() == nil {
+			cb(nil, &LimitError{Code: LimitCodeMaxExecutionTime, Limit: maxTime})
+		}
+	}()
+	return ctx, func(result interface{}, err error) {
+		cancel()
+		cb(result, err)
+	}
+}
+
+// costAnalyzer estimates the number of documents a query fetches. Each field resolved with a directive (database
+// reads, prepared queries and remote services) and each linked field costs one. The cost of the fields nested in a
+// list is multiplied by the limit of the list, or by the default list size if no limit was provided. The cost saturates
+// at maxCost instead of overflowing
+type costAnalyzer struct {
+	graph     *Module
+	store     utils.M
+	fragments map[string]*ast.FragmentDefinition
+	cost      QueryCost
+}
+
+func (a *costAnalyzer) selectionSet(selectionSet *ast.SelectionSet, depth int, dbAlias, col string, visited map[string]bool) int {
+	if selectionSet == nil {
+		return 0
+	}
+
+	cost := 0
+	for _, selection := range selectionSet.Selections {
+		switch v := selection.(type) {
+		case *ast.Field:
+			cost = addCost(cost, a.field(v, depth, dbAlias, col, visited))
+		case *ast.InlineFragment:
+			cost = addCost(cost, a.selectionSet(v.SelectionSet, depth, dbAlias, col, visited))
+		case *ast.FragmentSpread:
+			// Fragment cycles are invalid graphql. We skip them here to avoid recursing endlessly
+			fragment, ok := a.fragments[v.Name.Value]
+			if !ok || visited[v.Name.Value] {
+				continue
+			}
+			visited[v.Name.Value] = true
+			cost = addCost(cost, a.selectionSet(fragment.SelectionSet, depth, dbAlias, col, visited))
+			delete(visited, v.Name.Value)
+		}
+	}
+	return cost
+}
+
+func (a *costAnalyzer) field(field *ast.Field, depth int, dbAlias, col string, visited map[string]bool) int {
+	if depth > a.cost.Depth {
+		a.cost.Depth = depth
+	}
+	if field.Alias != nil {
+		a.cost.Aliases++
+	}
+
+	// Fields with a directive are resolved on their own
+	if len(field.Directives) > 0 && field.Directives[0].Name.Value != utils.GraphQLAggregate {
+		directive := field.Directives[0].Name.Value
+		if _, err := a.graph.crud.GetDBType(directive); err != nil {
+			return addCost(1, a.selectionSet(field.SelectionSet, depth+1, "", "", visited))
+		}
+
+		nestedCol, err := getCollection(field)
+		if err != nil {
+			nestedCol = field.Name.Value
+		}
+		return addCost(1, mulCost(a.listSize(field, true), a.selectionSet(field.SelectionSet, depth+1, directive, nestedCol, visited)))
+	}
+
+	if field.SelectionSet == nil {
+		return 0
+	}
+
+	// Linked fields are fetched separately for every document of the parent
+	if dbAlias != "" {
+		if fields, ok := a.graph.schema.GetSchema(dbAlias, col); ok {
+			if fieldStruct, p := fields[field.Name.Value]; p && fieldStruct.IsLinked && fieldStruct.LinkedTable != nil {
+				return addCost(1, mulCost(a.listSize(field, fieldStruct.IsList), a.selectionSet(field.SelectionSet, depth+1, fieldStruct.LinkedTable.DBType, fieldStruct.LinkedTable.Table, visited)))
+			}
+		}
+	}
+
+	// Nested objects and joint tables are returned along with the parent document
+	return a.selectionSet(field.SelectionSet, depth+1, dbAlias, field.Name.Value, visited)
+}
+
+// listSize returns the number of documents the field is expected to return. Limits above the max number of documents
+// fetched by a read are clamped to it
+func (a *costAnalyzer) listSize(field *ast.Field, isList bool) int {
+	if !isList {
+		return 1
+	}
+
+	size := defaultListSize
+	for _, arg := range field.Arguments {
+		val, err := utils.ParseGraphqlValue(arg.Value, a.store)
+		if err != nil {
+			continue
+		}
+		switch arg.Name.Value {
+		case "op":
+			if val == utils.One {
+				return 1
+			}
+		case "limit":
+			switch v := val.(type) {
+			case int:
+				size = v
+			case float64:
+				size = model.DefaultFetchLimit
+				if v < model.DefaultFetchLimit {
+					size = int(v)
+				}
+			}
+		}
+	}
+	if size < 1 {
+		size = 1
+	}
+	if size > model.DefaultFetchLimit {
+		size = model.DefaultFetchLimit
+	}
+	return size
+}
+
+func addCost(a, b int) int {
+	if a > maxCost-b {
+		return maxCost
+	}
+	return a + b
+}
+
+func mulCost(a, b int) int {
+	if a != 0 && b > maxCost/a {
+		return maxCost
+	}
+	return a * b
+}
@@ -0,0 +1,188 @@
+package graphql_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/spaceuptech/space-cloud/gateway/config"
+	"github.com/spaceuptech/space-cloud/gateway/model"
+	"github.com/spaceuptech/space-cloud/gateway/utils/graphql"
+)
+
+func TestModule_ExecGraphQLQuery_limits(t *testing.T) {
+	authorsSchema := model.Fields{
+		"id":    &model.FieldType{FieldName: "id", Kind: model.TypeID},
+		"books": &model.FieldType{FieldName: "books", Kind: "books", IsList: true, IsLinked: true, LinkedTable: &model.TableProperties{DBType: "db", Table: "books"}},
+	}
+
+	tests := []struct {
+		name     string
+		limits   *config.GraphQLLimits
+		req      *model.GraphQLRequest
+		wantErr  *graphql.LimitError
+		wantCost graphql.QueryCost
+	}{
+		{
+			name:     "depth exceeded",
+			limits:   &config.GraphQLLimits{MaxDepth: 2},
+			req:      &model.GraphQLRequest{Query: `{ authors @db { books { title } } }`},
+			wantErr:  &graphql.LimitError{Code: graphql.LimitCodeMaxDepth, Limit: 2, Value: 3},
+			wantCost: graphql.QueryCost{Depth: 3, Cost: 101},
+		},
+		{
+			name:     "aliases exceeded",
+			limits:   &config.GraphQLLimits{MaxAliases: 1},
+			req:      &model.GraphQLRequest{Query: `{ a: authors(limit: 1) @db { id } b: authors(limit: 1) @db { id } }`},
+			wantErr:  &graphql.LimitError{Code: graphql.LimitCodeMaxAliases, Limit: 1, Value: 2},
+			wantCost: graphql.QueryCost{Depth: 2, Aliases: 2, Cost: 2},
+		},
+		{
+			name:     "cost is computed from the limit arguments",
+			limits:   &config.GraphQLLimits{MaxCost: 10},
+			req:      &model.GraphQLRequest{Query: `{ authors(limit: 10) @db { id books(limit: 5) { title } } }`},
+			wantErr:  &graphql.LimitError{Code: graphql.LimitCodeMaxCost, Limit: 10, Value: 11},
+			wantCost: graphql.QueryCost{Depth: 3, Cost: 11},
+		},
+		{
+			name:     "cost of nested links is multiplied by the size of the parent",
+			limits:   &config.GraphQLLimits{MaxCost: 10},
+			req:      &model.GraphQLRequest{Query: `{ authors(limit: 3) @db { books(limit: 5) { author @db(col: "authors") { id } } } }`},
+			wantErr:  &graphql.LimitError{Code: graphql.LimitCodeMaxCost, Limit: 10, Value: 19},
+			wantCost: graphql.QueryCost{Depth: 4, Cost: 19},
+		},
+		{
+			name:     "lists without a limit use the default size",
+			limits:   &config.GraphQLLimits{MaxCost: 10},
+			req:      &model.GraphQLRequest{Query: `{ authors @db { books { title } } }`},
+			wantErr:  &graphql.LimitError{Code: graphql.LimitCodeMaxCost, Limit: 10, Value: 101},
+			wantCost: graphql.QueryCost{Depth: 3, Cost: 101},
+		},
+		{
+			name:     "limit is read from the variables",
+			limits:   &config.GraphQLLimits{MaxCost: 1},
+			req:      &model.GraphQLRequest{Query: `query($n: Int) { authors(limit: $n) @db { books { title } } }`, Variables: map[string]interface{}{"n": float64(3)}},
+			wantErr:  &graphql.LimitError{Code: graphql.LimitCodeMaxCost, Limit: 1, Value: 4},
+			wantCost: graphql.QueryCost{Depth: 3, Cost: 4},
+		},
+		{
+			name:     "limits above the fetch limit are clamped",
+			limits:   &config.GraphQLLimits{MaxCost: 10},
+			req:      &model.GraphQLRequest{Query: `{ authors(limit: 1000000000) @db { books(limit: 1) { title } } }`},
+			wantErr:  &graphql.LimitError{Code: graphql.LimitCodeMaxCost, Limit: 10, Value: 1001},
+			wantCost: graphql.QueryCost{Depth: 3, Cost: 1001},
+		},
+		{
+			name:     "cost saturates instead of overflowing",
+			limits:   &config.GraphQLLimits{MaxCost: 10},
+			req:      &model.GraphQLRequest{Query: "{ authors @db { " + strings.Repeat(`books { author @db(col: "authors") { `, 8) + "id" + strings.Repeat(" }", 18)},
+			wantErr:  &graphql.LimitError{Code: graphql.LimitCodeMaxCost, Limit: 10, Value: int(^uint(0) >> 1)},
+			wantCost: graphql.QueryCost{Depth: 18, Cost: int(^uint(0) >> 1)},
+		},
+		{
+			name:     "single document reads",
+			limits:   &config.GraphQLLimits{MaxCost: 1},
+			req:      &model.GraphQLRequest{Query: `{ authors(op: one) @db { ...authorFields } } fragment authorFields on authors { books(limit: 2) { title } }`},
+			wantErr:  &graphql.LimitError{Code: graphql.LimitCodeMaxCost, Limit: 1, Value: 2},
+			wantCost: graphql.QueryCost{Depth: 3, Cost: 2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCrud := mockGraphQLCrudInterface{}
+			mockCrud.On("GetDBType", "db").Return("postgres", nil)
+			mockSchema := mockGraphQLSchemaInterface{}
+			mockSchema.On("GetSchema", "db", "authors").Return(authorsSchema, true)
+			mockSchema.On("GetSchema", mock.Anything, mock.Anything).Return(model.Fields{}, false)
+
+			graph := graphql.New(&mockGraphQLAuthInterface{}, &mockCrud, &mockGraphQLFunctionInterface{}, &mockSchema)
+			graph.SetQueryLimits(tt.limits)
+
+			ctx, cost := graphql.WithQueryCost(context.Background())
+			_, err := execGraphQLQueryWithContext(ctx, graph, tt.req, "")
+			var limitErr *graphql.LimitError
+			if !errors.As(err, &limitErr) || !reflect.DeepEqual(limitErr, tt.wantErr) {
+				t.Errorf("ExecGraphQLQuery() error = %v, want %v", err, tt.wantErr)
+			}
+			if *cost != tt.wantCost {
+				t.Errorf("ExecGraphQLQuery() cost = %v, want %v", *cost, tt.wantCost)
+			}
+		})
+	}
+}
+
+func TestModule_ExecGraphQLQuery_executionTimeLimit(t *testing.T) {
+	tests := []struct {
+		name          string
+		limits        *config.GraphQLLimits
+		parentTimeout time.Duration
+		wantResult    interface{}
+		wantErr       error
+	}{
+		{
+			name:       "finished within the limit",
+			limits:     &config.GraphQLLimits{MaxExecutionTime: 5000},
+			wantResult: map[string]interface{}{"adder": map[string]interface{}{"sum": 30}},
+		},
+		{
+			name:    "execution time exceeded",
+			limits:  &config.GraphQLLimits{MaxExecutionTime: 10},
+			wantErr: &graphql.LimitError{Code: graphql.LimitCodeMaxExecutionTime, Limit: 10},
+		},
+		{
+			name:          "deadline of the parent context is not reported as exceeding the limit",
+			limits:        &config.GraphQLLimits{MaxExecutionTime: 5000},
+			parentTimeout: 10 * time.Millisecond,
+			wantResult:    map[string]interface{}{"adder": map[string]interface{}{"sum": 30}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCrud := mockGraphQLCrudInterface{}
+			mockCrud.On("GetDBType", "arithmetic").Return("", errors.New("invalid db alias provided"))
+			mockAuth := mockGraphQLAuthInterface{}
+			mockAuth.On("IsFuncCallAuthorised", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(&model.PostProcess{}, model.RequestParams{}, nil)
+			mockFunction := mockGraphQLFunctionInterface{}
+			mockFunction.On("CallWithContext", mock.Anything, "arithmetic", "adder", "", mock.Anything, mock.Anything).After(100*time.Millisecond).Return(map[string]interface{}{"sum": 30}, nil)
+
+			graph := graphql.New(&mockAuth, &mockCrud, &mockFunction, &mockGraphQLSchemaInterface{})
+			graph.SetQueryLimits(tt.limits)
+
+			ctx := context.Background()
+			if tt.parentTimeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tt.parentTimeout)
+				defer cancel()
+			}
+			ctx, cost := graphql.WithQueryCost(ctx)
+			result, err := execGraphQLQueryWithContext(ctx, graph, &model.GraphQLRequest{Query: `{ adder(num1: 10, num2: 20) @arithmetic { sum } }`}, "")
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Fatalf("ExecGraphQLQuery() error = %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(result, tt.wantResult) {
+				t.Errorf("ExecGraphQLQuery() = %v, want %v", result, tt.wantResult)
+			}
+			if want := (graphql.QueryCost{Depth: 2, Cost: 1}); *cost != want {
+				t.Errorf("ExecGraphQLQuery() cost = %v, want %v", *cost, want)
+			}
+		})
+	}
+}
+
+func execGraphQLQueryWithContext(ctx context.Context, graph *graphql.Module, req *model.GraphQLRequest, token string) (interface{}, error) {
+	type response struct {
+		result interface{}
+		err    error
+	}
+	ch := make(chan response, 1)
+	graph.ExecGraphQLQuery(ctx, req, token, func(result interface{}, err error) {
+		ch <- response{result, err}
+	})
+	r := <-ch
+	return r.result, r.err
+}
@@ -0,0 +1,119 @@
+package graphql
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/spaceuptech/space-cloud/gateway/config"
+	"github.com/spaceuptech/space-cloud/gateway/model"
+)
+
+// Codes of the errors returned while resolving a persisted query
+const (
+	PersistedQueryCodeNotFound     = "PERSISTED_QUERY_NOT_FOUND"
+	PersistedQueryCodeNotAllowed   = "PERSISTED_QUERY_NOT_ALLOWED"
+	PersistedQueryCodeHashMismatch = "PERSISTED_QUERY_HASH_MISMATCH"
+	PersistedQueryCodeBadVersion   = "PERSISTED_QUERY_BAD_VERSION"
+)
+
+// PersistedQueryError is returned when a graphql request can't be resolved to a persisted query
+type PersistedQueryError struct {
+	Code string
+	Hash string
+}
+
+func (e *PersistedQueryError) Error() string {
+	switch e.Code {
+	case PersistedQueryCodeNotFound:
+		// Apollo clients look for this exact message to send the full query
+		return "PersistedQueryNotFound"
+	case PersistedQueryCodeNotAllowed:
+		return "only persisted queries are allowed in this project"
+	case PersistedQueryCodeHashMismatch:
+		return fmt.Sprintf("provided sha256 hash (%s) does not match the query", e.Hash)
+	default:
+		return "unsupported persisted query version provided"
+	}
+}
+
+// Extensions returns the details of the error to be sent in the extensions field of the graphql error
+func (e *PersistedQueryError) Extensions() map[string]interface{} {
+	return map[string]interface{}{"code": e.Code}
+}
+
+// SetPersistedQueries sets the persisted queries of the project
+func (graph *Module) SetPersistedQueries(queries config.GraphQLPersistedQueries) {
+	// Index the queries by their hash since that's what the clients send
+	persistedQueries := make(map[string]*config.GraphQLPersistedQuery, len(queries))
+	for _, query := range queries {
+		persistedQueries[strings.ToLower(query.ID)] = query
+	}
+
+	graph.lock.Lock()
+	defer graph.lock.Unlock()
+	graph.persistedQueries = persistedQueries
+}
+
+// SetPersistedQueriesOnly restricts the module to only execute the persisted queries of the project
+func (graph *Module) SetPersistedQueriesOnly(persistedQueriesOnly bool) {
+	graph.lock.Lock()
+	defer graph.lock.Unlock()
+	graph.persistedQueriesOnly = persistedQueriesOnly
+}
+
+// ResolvePersistedQuery replaces the query of the request with the persisted query it refers to and checks the rule of
+// the persisted query. Requests carrying the entire query without a hash are only looked up when the project is
+// restricted to persisted queries
+func (graph *Module) ResolvePersistedQuery(ctx context.Context, req *model.GraphQLRequest, token string) error {
+	// The map of persisted queries is replaced and never modified, so it can be read after releasing the lock
+	graph.lock.RLock()
+	persistedQueries, persistedQueriesOnly := graph.persistedQueries, graph.persistedQueriesOnly
+	graph.lock.RUnlock()
+
+	var hash string
+	if req.Extensions != nil && req.Extensions.PersistedQuery != nil {
+		if req.Extensions.PersistedQuery.Version != 1 {
+			return &PersistedQueryError{Code: PersistedQueryCodeBadVersion}
+		}
+
+		hash = strings.ToLower(req.Extensions.PersistedQuery.SHA256Hash)
+		if req.Query != "" && hashQuery(req.Query) != hash {
+			return &PersistedQueryError{Code: PersistedQueryCodeHashMismatch, Hash: hash}
+		}
+	}
+
+	if hash == "" {
+		if !persistedQueriesOnly {
+			return nil
+		}
+		hash = hashQuery(req.Query)
+	}
+
+	query, ok := persistedQueries[hash]
+	if !ok {
+		switch {
+		case persistedQueriesOnly:
+			return &PersistedQueryError{Code: PersistedQueryCodeNotAllowed, Hash: hash}
+		case req.Query == "":
+			return &PersistedQueryError{Code: PersistedQueryCodeNotFound, Hash: hash}
+		}
+		return nil
+	}
+
+	req.Query = query.Query
+	if query.Rule != nil {
+		args := map[string]interface{}{"id": query.ID, "name": query.Name, "operationName": req.OperationName, "variables": req.Variables}
+		if _, err := graph.auth.AuthorizeRequest(ctx, query.Rule, graph.project, token, args); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func hashQuery(query string) string {
+	hash := sha256.Sum256([]byte(query))
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,220 @@
+package graphql_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/spaceuptech/space-cloud/gateway/config"
+	"github.com/spaceuptech/space-cloud/gateway/model"
+	"github.com/spaceuptech/space-cloud/gateway/utils/graphql"
+)
+
+func TestModule_ExecGraphQLQuery_persistedQueries(t *testing.T) {
+	const (
+		adderQuery      = `{ adder(num1: 10, num2: 20) @arithmetic { sum } }`
+		unregisteredQry = `{ adder(num1: 1, num2: 2) @arithmetic { sum } }`
+		securedQuery    = `query Secured { adder(num1: 10, num2: 20) @arithmetic { sum } }`
+	)
+	adderHash, unregisteredHash, securedHash := sha256Hex(adderQuery), sha256Hex(unregisteredQry), sha256Hex(securedQuery)
+	securedRule := &config.Rule{Rule: "authenticated"}
+	queries := config.GraphQLPersistedQueries{
+		config.GenerateResourceID("chicago", "myproject", config.ResourceGraphQLPersistedQuery, adderHash):   &config.GraphQLPersistedQuery{ID: adderHash, Query: adderQuery},
+		config.GenerateResourceID("chicago", "myproject", config.ResourceGraphQLPersistedQuery, securedHash): &config.GraphQLPersistedQuery{ID: securedHash, Name: "secured", Query: securedQuery, Rule: securedRule},
+	}
+	persisted := func(hash string) *model.GraphQLExtensions {
+		return &model.GraphQLExtensions{PersistedQuery: &model.PersistedQueryExtension{Version: 1, SHA256Hash: hash}}
+	}
+	sum := map[string]interface{}{"adder": map[string]interface{}{"sum": 30}}
+
+	type mockArgs struct {
+		method         string
+		args           []interface{}
+		paramsReturned []interface{}
+	}
+	tests := []struct {
+		name                 string
+		persistedQueriesOnly bool
+		req                  *model.GraphQLRequest
+		token                string
+		authMockArgs         []mockArgs
+		wantResult           interface{}
+		wantErr              error
+	}{
+		{
+			name:       "query without a hash is executed as is",
+			req:        &model.GraphQLRequest{Query: unregisteredQry},
+			wantResult: sum,
+		},
+		{
+			name:       "registered hash is resolved to the persisted query",
+			req:        &model.GraphQLRequest{Extensions: persisted(adderHash)},
+			wantResult: sum,
+		},
+		{
+			name:       "hash is case insensitive",
+			req:        &model.GraphQLRequest{Extensions: persisted(strings.ToUpper(adderHash))},
+			wantResult: sum,
+		},
+		{
+			name:    "unknown hash without the query",
+			req:     &model.GraphQLRequest{Extensions: persisted(unregisteredHash)},
+			wantErr: &graphql.PersistedQueryError{Code: graphql.PersistedQueryCodeNotFound, Hash: unregisteredHash},
+		},
+		{
+			name:       "unknown hash with the query is executed",
+			req:        &model.GraphQLRequest{Query: unregisteredQry, Extensions: persisted(unregisteredHash)},
+			wantResult: sum,
+		},
+		{
+			name:    "hash doesn't match the query",
+			req:     &model.GraphQLRequest{Query: unregisteredQry, Extensions: persisted(adderHash)},
+			wantErr: &graphql.PersistedQueryError{Code: graphql.PersistedQueryCodeHashMismatch, Hash: adderHash},
+		},
+		{
+			name:    "unsupported version",
+			req:     &model.GraphQLRequest{Extensions: &model.GraphQLExtensions{PersistedQuery: &model.PersistedQueryExtension{Version: 2, SHA256Hash: adderHash}}},
+			wantErr: &graphql.PersistedQueryError{Code: graphql.PersistedQueryCodeBadVersion},
+		},
+		{
+			name:                 "only persisted queries - registered query without a hash",
+			persistedQueriesOnly: true,
+			req:                  &model.GraphQLRequest{Query: adderQuery},
+			wantResult:           sum,
+		},
+		{
+			name:                 "only persisted queries - unregistered query",
+			persistedQueriesOnly: true,
+			req:                  &model.GraphQLRequest{Query: unregisteredQry},
+			wantErr:              &graphql.PersistedQueryError{Code: graphql.PersistedQueryCodeNotAllowed, Hash: unregisteredHash},
+		},
+		{
+			name:                 "only persisted queries - unknown hash with the query",
+			persistedQueriesOnly: true,
+			req:                  &model.GraphQLRequest{Query: unregisteredQry, Extensions: persisted(unregisteredHash)},
+			wantErr:              &graphql.PersistedQueryError{Code: graphql.PersistedQueryCodeNotAllowed, Hash: unregisteredHash},
+		},
+		{
+			name:  "rule of the persisted query is satisfied",
+			req:   &model.GraphQLRequest{OperationName: "Secured", Variables: map[string]interface{}{"id": "1"}, Extensions: persisted(securedHash)},
+			token: "token",
+			authMockArgs: []mockArgs{
+				{
+					method:         "AuthorizeRequest",
+					args:           []interface{}{mock.Anything, securedRule, "myproject", "token", map[string]interface{}{"id": securedHash, "name": "secured", "operationName": "Secured", "variables": map[string]interface{}{"id": "1"}}},
+					paramsReturned: []interface{}{map[string]interface{}{}, nil},
+				},
+			},
+			wantResult: sum,
+		},
+		{
+			name:  "rule of the persisted query is not satisfied",
+			req:   &model.GraphQLRequest{Extensions: persisted(securedHash)},
+			token: "token",
+			authMockArgs: []mockArgs{
+				{
+					method:         "AuthorizeRequest",
+					args:           []interface{}{mock.Anything, securedRule, "myproject", "token", mock.Anything},
+					paramsReturned: []interface{}{map[string]interface{}(nil), errors.New("access denied")},
+				},
+			},
+			wantErr: errors.New("access denied"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCrud := mockGraphQLCrudInterface{}
+			mockCrud.On("GetDBType", "arithmetic").Return("", errors.New("invalid db alias provided"))
+			mockAuth := mockGraphQLAuthInterface{}
+			mockAuth.On("IsFuncCallAuthorised", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(&model.PostProcess{}, model.RequestParams{}, nil).Maybe()
+			for _, m := range tt.authMockArgs {
+				mockAuth.On(m.method, m.args...).Return(m.paramsReturned...)
+			}
+			mockFunction := mockGraphQLFunctionInterface{}
+			mockFunction.On("CallWithContext", mock.Anything, "arithmetic", "adder", tt.token, mock.Anything, mock.Anything).Return(map[string]interface{}{"sum": 30}, nil)
+
+			graph := graphql.New(&mockAuth, &mockCrud, &mockFunction, &mockGraphQLSchemaInterface{})
+			graph.SetConfig("myproject")
+			graph.SetPersistedQueries(queries)
+			graph.SetPersistedQueriesOnly(tt.persistedQueriesOnly)
+
+			result, err := execGraphQLQueryWithContext(context.Background(), graph, tt.req, tt.token)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Fatalf("ExecGraphQLQuery() error = %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(result, tt.wantResult) {
+				t.Errorf("ExecGraphQLQuery() = %v, want %v", result, tt.wantResult)
+			}
+
+			mockAuth.AssertExpectations(t)
+		})
+	}
+}
+
+func TestModule_ExecGraphQLQuery_queriesOnly(t *testing.T) {
+	const (
+		query    = `{ adder(num1: 10, num2: 20) @arithmetic { sum } }`
+		mutation = `mutation { adder(num1: 10, num2: 20) @arithmetic { sum } }`
+	)
+	mutationHash := sha256Hex(mutation)
+	queries := config.GraphQLPersistedQueries{
+		config.GenerateResourceID("chicago", "myproject", config.ResourceGraphQLPersistedQuery, mutationHash): &config.GraphQLPersistedQuery{ID: mutationHash, Query: mutation},
+	}
+
+	tests := []struct {
+		name       string
+		req        *model.GraphQLRequest
+		wantResult interface{}
+		wantErr    bool
+	}{
+		{
+			name:       "query is executed",
+			req:        &model.GraphQLRequest{Query: query},
+			wantResult: map[string]interface{}{"adder": map[string]interface{}{"sum": 30}},
+		},
+		{
+			name:    "mutation is rejected",
+			req:     &model.GraphQLRequest{Query: mutation},
+			wantErr: true,
+		},
+		{
+			name:    "persisted mutation is rejected",
+			req:     &model.GraphQLRequest{Extensions: &model.GraphQLExtensions{PersistedQuery: &model.PersistedQueryExtension{Version: 1, SHA256Hash: mutationHash}}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCrud := mockGraphQLCrudInterface{}
+			mockCrud.On("GetDBType", "arithmetic").Return("", errors.New("invalid db alias provided"))
+			mockAuth := mockGraphQLAuthInterface{}
+			mockAuth.On("IsFuncCallAuthorised", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(&model.PostProcess{}, model.RequestParams{}, nil).Maybe()
+			mockFunction := mockGraphQLFunctionInterface{}
+			mockFunction.On("CallWithContext", mock.Anything, "arithmetic", "adder", mock.Anything, mock.Anything, mock.Anything).Return(map[string]interface{}{"sum": 30}, nil).Maybe()
+
+			graph := graphql.New(&mockAuth, &mockCrud, &mockFunction, &mockGraphQLSchemaInterface{})
+			graph.SetConfig("myproject")
+			graph.SetPersistedQueries(queries)
+
+			result, err := execGraphQLQueryWithContext(graphql.WithQueriesOnly(context.Background()), graph, tt.req, "")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ExecGraphQLQuery() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(result, tt.wantResult) {
+				t.Errorf("ExecGraphQLQuery() = %v, want %v", result, tt.wantResult)
+			}
+			mockFunction.AssertExpectations(t)
+		})
+	}
+}
+
+func sha256Hex(query string) string {
+	hash := sha256.Sum256([]byte(query))
+	return hex.EncodeToString(hash[:])
+}
@@ -69,6 +69,11 @@ func (graph *Module) execLinkedReadRequest(ctx context.Context, field *ast.Field
 			val.(*utils.Array).Append(structs.Map(metaData))
 		}
 
+		// The cursors are generated before the values of the sort keys get modified by post processing
+		if isCursorFieldSelected(field) {
+			addCursors(req.Options.Sort, result)
+		}
+
 		// Post process only if joins were not enabled
 		if isPostProcessingEnabled(req.PostProcess) && len(req.Options.Join) == 0 {
 			_ = authHelpers.PostProcessMethod(ctx, graph.aesKey, req.PostProcess[col], result)
@@ -109,6 +114,18 @@ func (graph *Module) execReadRequest(ctx context.Context, field *ast.Field, toke
 	// Check if read op is authorised
 	dbType, _ := graph.crud.GetDBType(dbAlias)
 
+	// The sort keys are required to generate the cursor of each document
+	isCursorRequested := isCursorFieldSelected(field)
+	if isCursorRequested && len(req.Options.Select) > 0 {
+		for _, sortField := range req.Options.Sort {
+			key := strings.TrimPrefix(sortField, "-")
+			if model.DBType(dbType) != model.Mongo && !strings.Contains(key, ".") {
+				key = col + "." + key
+			}
+			req.Options.Select[key] = 1
+		}
+	}
+
 	returnWhere := model.ReturnWhereStub{Col: col, PrefixColName: len(req.Options.Join) > 0, ReturnWhere: dbType != string(model.Mongo), Where: map[string]interface{}{}}
 	actions, reqParams, err := graph.auth.IsReadOpAuthorised(ctx, graph.project, dbAlias, col, token, req, returnWhere)
 	if err != nil {
@@ -147,6 +164,11 @@ func (graph *Module) execReadRequest(ctx context.Context, field *ast.Field, toke
 			val.(*utils.Array).Append(structs.Map(metaData))
 		}
 
+		// The cursors are generated before the values of the sort keys get modified by post processing
+		if isCursorRequested {
+			addCursors(req.Options.Sort, result)
+		}
+
 		// Post process only if joins were not enabled
 		if isPostProcessingEnabled(req.PostProcess) && len(req.Options.Join) == 0 {
 			_ = authHelpers.PostProcessMethod(ctx, graph.aesKey, req.PostProcess[col], result)
@@ -156,6 +178,37 @@ func (graph *Module) execReadRequest(ctx context.Context, field *ast.Field, toke
 	}()
 }
 
+// isCursorFieldSelected checks if the cursor of each document has been requested in the selection set
+func isCursorFieldSelected(field *ast.Field) bool {
+	if field.SelectionSet == nil {
+		return false
+	}
+	for _, selection := range field.SelectionSet.Selections {
+		if v, ok := selection.(*ast.Field); ok && v.Name.Value == utils.GraphQLCursorField {
+			return true
+		}
+	}
+	return false
+}
+
+// addCursors sets the cursor of each document in the result. The cursor can be passed to the
+// `after` or `before` argument of a query to fetch the documents after or before that document
+func addCursors(sort []string, result interface{}) {
+	docs, ok := result.([]interface{})
+	if !ok || len(sort) == 0 {
+		return
+	}
+	for _, doc := range docs {
+		obj, ok := doc.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if cursor, err := utils.EncodeCursor(sort, obj); err == nil {
+			obj[utils.GraphQLCursorField] = cursor
+		}
+	}
+}
+
 func isDataLoaderDisabled(ctx context.Context, field *ast.Field, store utils.M) (bool, error) {
 	for _, arg := range field.Arguments {
 		switch arg.Name.Value {
@@ -360,8 +413,8 @@ func (graph *Module) extractSelectionSet(ctx context.Context, field *ast.Field,
 	for _, selection := range field.SelectionSet.Selections {
 		v := selection.(*ast.Field)
 
-		// Skip dbFetchTs fields
-		if v.Name.Value == "_dbFetchTs" {
+		// Skip dbFetchTs & cursor fields
+		if v.Name.Value == "_dbFetchTs" || v.Name.Value == utils.GraphQLCursorField {
 			continue
 		}
 
@@ -787,6 +840,24 @@ func generateOptions(ctx context.Context, args []*ast.Argument, store utils.M) (
 			}
 
 			options.Distinct = &tempString
+		case "after", "before":
+			hasOptions = true // Set the flag to true
+
+			temp, err := utils.ParseGraphqlValue(v.Value, store)
+			if err != nil {
+				return nil, hasOptions, err
+			}
+
+			cursor, ok := temp.(string)
+			if !ok {
+				return nil, hasOptions, fmt.Errorf("invalid type provided for %s expecting string got (%s)", v.Name.Value, reflect.TypeOf(temp))
+			}
+
+			if v.Name.Value == "after" {
+				options.After = &cursor
+			} else {
+				options.Before = &cursor
+			}
 		case "debug":
 			hasOptions = true // Set the flag to true
 
@@ -0,0 +1,674 @@
+package graphql
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	gql "github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+
+	"github.com/spaceuptech/space-cloud/gateway/config"
+	"github.com/spaceuptech/space-cloud/gateway/model"
+	"github.com/spaceuptech/space-cloud/gateway/utils"
+)
+
+// graphqlNameRegex matches the names allowed by the graphql spec. Tables, columns, services and prepared queries
+// whose names don't match it cannot be queried over graphql and are left out of the schema
+var graphqlNameRegex = regexp.MustCompile(`^[_A-Za-z][_0-9A-Za-z]*$`)
+
+// Custom scalars used by the generated schema
+var (
+	scalarJSON     = newScalar("JSON", "Arbitrary json value")
+	scalarDate     = newScalar("Date", "Date in the format YYYY-MM-DD")
+	scalarTime     = newScalar("Time", "Time in the format HH:MM:SS")
+	scalarDateTime = newScalar("DateTime", "Date time in the RFC3339 format")
+)
+
+func newScalar(name, description string) *gql.Scalar {
+	identity := func(value interface{}) interface{} { return value }
+	return gql.NewScalar(gql.ScalarConfig{
+		Name:         name,
+		Description:  description,
+		Serialize:    identity,
+		ParseValue:   identity,
+		ParseLiteral: func(value ast.Value) interface{} { return value.GetValue() },
+	})
+}
+
+// GenerateSchema returns the typed graphql schema of the project generated from the database schemas, prepared queries
+// and remote services. The schema describes the queries understood by the gateway and is used to answer introspection
+// queries. It is generated once and reused till ResetSchema gets called
+func (graph *Module) GenerateSchema() (*gql.Schema, error) {
+	graph.lock.RLock()
+	schema, version := graph.generatedSchema, graph.schemaVersion
+	graph.lock.RUnlock()
+	if schema != nil {
+		return schema, nil
+	}
+
+	b := newSchemaBuilder(graph.schema.GetSchemas())
+	b.addTables()
+	b.addPreparedQueries(graph.crud.GetPreparedQueries())
+	b.addServices(graph.functions.GetServices())
+	schema, err := b.build()
+	if err != nil {
+		return nil, err
+	}
+
+	graph.lock.Lock()
+	defer graph.lock.Unlock()
+	if graph.schemaVersion == version {
+		graph.generatedSchema = schema
+	}
+	return schema, nil
+}
+
+// ResetSchema discards the generated schema. It must be called whenever the database schemas, prepared queries or
+// remote services of the project change
+func (graph *Module) ResetSchema() {
+	graph.lock.Lock()
+	defer graph.lock.Unlock()
+	graph.generatedSchema = nil
+	graph.schemaVersion++
+}
+
+// GetSchemaSDL returns the generated graphql schema of the project in the schema definition language
+func (graph *Module) GetSchemaSDL() (string, error) {
+	schema, err := graph.GenerateSchema()
+	if err != nil {
+		return "", err
+	}
+	return printSchema(schema), nil
+}
+
+type schemaBuilder struct {
+	schemas model.Type
+
+	// owners maps a table name to the database whose table gets the un-prefixed name in the schema. Tables with the
+	// same name in other databases are prefixed with the database alias
+	owners map[string]string
+
+	// readFields maps the name of the fields reading a table to the database of the table
+	readFields map[string]string
+
+	objects      map[string]*gql.Object
+	inputObjects map[string]*gql.InputObject
+	scalars      map[string]*gql.Scalar
+
+	query      gql.Fields
+	mutation   gql.Fields
+	directives map[string]gql.FieldConfigArgument
+}
+
+func newSchemaBuilder(schemas model.Type) *schemaBuilder {
+	b := &schemaBuilder{
+		schemas:      schemas,
+		owners:       map[string]string{},
+		readFields:   map[string]string{},
+		objects:      map[string]*gql.Object{},
+		inputObjects: map[string]*gql.InputObject{},
+		scalars:      map[string]*gql.Scalar{},
+		query:        gql.Fields{},
+		mutation:     gql.Fields{},
+		directives:   map[string]gql.FieldConfigArgument{},
+	}
+
+	for _, dbAlias := range sortedKeys(schemas) {
+		if !graphqlNameRegex.MatchString(dbAlias) {
+			continue
+		}
+		for table := range schemas[dbAlias] {
+			if _, p := b.owners[table]; !p {
+				b.owners[table] = dbAlias
+			}
+		}
+	}
+
+	b.addDirective(utils.GraphQLAggregate, gql.FieldConfigArgument{
+		"op":    &gql.ArgumentConfig{Type: gql.NewNonNull(gql.String), Description: "Aggregate function to apply. One of count, sum, avg, min or max"},
+		"field": &gql.ArgumentConfig{Type: gql.String, Description: "Column to aggregate. Defaults to the name of the field"},
+	})
+	b.query["_query"] = &gql.Field{Type: scalarJSON, Description: "Returns the queries executed by the fields which have the debug argument set"}
+	return b
+}
+
+func (b *schemaBuilder) build() (*gql.Schema, error) {
+	c := gql.SchemaConfig{Query: gql.NewObject(gql.ObjectConfig{Name: "Query", Fields: b.query})}
+	if len(b.mutation) > 0 {
+		c.Mutation = gql.NewObject(gql.ObjectConfig{Name: "Mutation", Fields: b.mutation})
+	}
+
+	c.Directives = append(c.Directives, gql.SpecifiedDirectives...)
+	for _, name := range sortedKeys(b.directives) {
+		c.Directives = append(c.Directives, gql.NewDirective(gql.DirectiveConfig{
+			Name:      name,
+			Locations: []string{gql.DirectiveLocationField},
+			Args:      b.directives[name],
+		}))
+	}
+
+	schema, err := gql.NewSchema(c)
+	if err != nil {
+		return nil, fmt.Errorf("unable to generate graphql schema - %v", err)
+	}
+	return &schema, nil
+}
+
+// addDirective declares a directive which can be used on fields. Arguments are merged if the directive has already
+// been declared, which happens when a remote service has the same name as a database
+func (b *schemaBuilder) addDirective(name string, args gql.FieldConfigArgument) {
+	if name == "skip" || name == "include" || name == "deprecated" {
+		return
+	}
+	existing, p := b.directives[name]
+	if !p {
+		existing = gql.FieldConfigArgument{}
+		b.directives[name] = existing
+	}
+	for k, v := range args {
+		existing[k] = v
+	}
+}
+
+func (b *schemaBuilder) addTables() {
+	for _, dbAlias := range sortedKeys(b.schemas) {
+		if !graphqlNameRegex.MatchString(dbAlias) {
+			continue
+		}
+		b.addDirective(dbAlias, gql.FieldConfigArgument{
+			"col":   &gql.ArgumentConfig{Type: gql.String, Description: "Table to query. Defaults to the name of the field"},
+			"cache": &gql.ArgumentConfig{Type: scalarJSON, Description: "Caching options of the query in the form {ttl: Int, instantInvalidate: Boolean}"},
+		})
+
+		for _, table := range sortedKeys(b.schemas[dbAlias]) {
+			if !graphqlNameRegex.MatchString(table) {
+				continue
+			}
+			b.addTable(dbAlias, table)
+		}
+	}
+}
+
+func (b *schemaBuilder) addTable(dbAlias, table string) {
+	typeName := b.tableTypeName(dbAlias, table)
+	object := b.tableObject(dbAlias, table)
+	where := b.whereInput(typeName, b.schemas[dbAlias][table])
+
+	directive := fmt.Sprintf("@%s", dbAlias)
+	if typeName != table {
+		directive = fmt.Sprintf("@%s(col: %q)", dbAlias, table)
+	}
+
+	b.readFields[typeName] = dbAlias
+	b.query[typeName] = &gql.Field{
+		Type:        gql.NewList(object),
+		Description: fmt.Sprintf("Reads the documents of the table (%s) in the database (%s). Use the %s directive on this field", table, dbAlias, directive),
+		Args: gql.FieldConfigArgument{
+			"where":      &gql.ArgumentConfig{Type: where, Description: "Filters the documents to read"},
+			"sort":       &gql.ArgumentConfig{Type: gql.NewList(gql.NewNonNull(b.sortScalar(typeName, b.schemas[dbAlias][table])))},
+			"skip":       &gql.ArgumentConfig{Type: gql.Int},
+			"limit":      &gql.ArgumentConfig{Type: gql.Int},
+			"distinct":   &gql.ArgumentConfig{Type: gql.String, Description: "Returns the distinct values of this column"},
+			"group":      &gql.ArgumentConfig{Type: gql.NewList(gql.NewNonNull(gql.String)), Description: "Columns to group by when aggregating"},
+			"after":      &gql.ArgumentConfig{Type: gql.String, Description: "Returns the documents after the provided cursor"},
+			"before":     &gql.ArgumentConfig{Type: gql.String, Description: "Returns the documents before the provided cursor"},
+			"op":         &gql.ArgumentConfig{Type: gql.String, Description: "Set to one to read a single document. Defaults to all"},
+			"join":       &gql.ArgumentConfig{Type: scalarJSON, Description: "Tables to join with"},
+			"returnType": &gql.ArgumentConfig{Type: gql.String, Description: "Set to table to return the joined documents in a flat structure"},
+			"debug":      &gql.ArgumentConfig{Type: gql.Boolean, Description: "Returns the executed query in the _query field"},
+		},
+	}
+
+	// Mutations derive the table from the field name, which is why they can only be generated for tables which aren't
+	// prefixed with the database alias
+	if typeName != table {
+		return
+	}
+
+	fields := b.schemas[dbAlias][table]
+	response := gql.NewObject(gql.ObjectConfig{
+		Name: typeName + "_mutation_response",
+		Fields: gql.Fields{
+			"status":    &gql.Field{Type: gql.Int},
+			"error":     &gql.Field{Type: gql.String},
+			"returning": &gql.Field{Type: gql.NewList(object), Description: "Documents inserted by the mutation"},
+		},
+	})
+	setInput := b.fieldsInput(typeName+"_set_input", typeName, fields, false)
+
+	b.mutation["insert_"+table] = &gql.Field{
+		Type:        response,
+		Description: fmt.Sprintf("Inserts documents in the table (%s) in the database (%s). Use the @%s directive on this field", table, dbAlias, dbAlias),
+		Args: gql.FieldConfigArgument{
+			"docs": &gql.ArgumentConfig{Type: gql.NewNonNull(gql.NewList(gql.NewNonNull(b.fieldsInput(typeName+"_insert_input", typeName, fields, true))))},
+		},
+	}
+
+	updateArgs := gql.FieldConfigArgument{
+		"where": &gql.ArgumentConfig{Type: where, Description: "Filters the documents to update"},
+		"op":    &gql.ArgumentConfig{Type: gql.String, Description: "Set to upsert to insert the document if it doesn't exist"},
+		"set":   &gql.ArgumentConfig{Type: setInput},
+		"min":   &gql.ArgumentConfig{Type: setInput},
+		"max":   &gql.ArgumentConfig{Type: setInput},
+	}
+	if numeric := b.numericFields(typeName+"_inc_input", fields, true); numeric != nil {
+		updateArgs["inc"] = &gql.ArgumentConfig{Type: numeric.(gql.Input)}
+		updateArgs["mul"] = &gql.ArgumentConfig{Type: numeric.(gql.Input)}
+	}
+	for _, op := range []string{"push", "rename", "unset", "currentDate", "currentTimestamp"} {
+		updateArgs[op] = &gql.ArgumentConfig{Type: scalarJSON}
+	}
+	b.mutation["update_"+table] = &gql.Field{
+		Type:        response,
+		Description: fmt.Sprintf("Updates documents in the table (%s) in the database (%s). Use the @%s directive on this field", table, dbAlias, dbAlias),
+		Args:        updateArgs,
+	}
+
+	b.mutation["delete_"+table] = &gql.Field{
+		Type:        response,
+		Description: fmt.Sprintf("Deletes documents from the table (%s) in the database (%s). Use the @%s directive on this field", table, dbAlias, dbAlias),
+		Args: gql.FieldConfigArgument{
+			"where": &gql.ArgumentConfig{Type: where, Description: "Filters the documents to delete"},
+		},
+	}
+}
+
+func (b *schemaBuilder) tableTypeName(dbAlias, table string) string {
+	if b.owners[table] == dbAlias {
+		return table
+	}
+	return dbAlias + "_" + table
+}
+
+// tableObject returns the object type of a table. Fields are resolved lazily since linked tables may reference each other
+func (b *schemaBuilder) tableObject(dbAlias, table string) *gql.Object {
+	typeName := b.tableTypeName(dbAlias, table)
+	if object, p := b.objects[typeName]; p {
+		return object
+	}
+
+	fields := b.schemas[dbAlias][table]
+	object := gql.NewObject(gql.ObjectConfig{
+		Name:        typeName,
+		Description: fmt.Sprintf("Document of the table (%s) in the database (%s)", table, dbAlias),
+		Fields: gql.FieldsThunk(func() gql.Fields {
+			objectFields := b.objectFields(typeName, fields)
+			objectFields[utils.GraphQLCursorField] = &gql.Field{Type: gql.String, Description: "Cursor of the document. Pass it to the after or before argument to paginate"}
+			objectFields[utils.GraphQLAggregate] = &gql.Field{Type: b.aggregateObject(typeName, fields), Description: "Aggregations of the documents"}
+			return objectFields
+		}),
+	})
+	b.objects[typeName] = object
+	return object
+}
+
+func (b *schemaBuilder) objectFields(typeName string, fields model.Fields) gql.Fields {
+	objectFields := gql.Fields{}
+	for _, name := range sortedKeys(fields) {
+		if !graphqlNameRegex.MatchString(name) {
+			continue
+		}
+		objectFields[name] = &gql.Field{Type: b.outputType(typeName, fields[name])}
+	}
+	return objectFields
+}
+
+func (b *schemaBuilder) outputType(typeName string, field *model.FieldType) gql.Output {
+	var t gql.Output
+	switch {
+	case field.IsLinked:
+		t = b.linkedType(field)
+	case field.Kind == model.TypeObject && len(field.NestedObject) > 0:
+		name := typeName + "_" + field.FieldName
+		object, p := b.objects[name]
+		if !p {
+			object = gql.NewObject(gql.ObjectConfig{Name: name, Fields: b.objectFields(name, field.NestedObject)})
+			b.objects[name] = object
+		}
+		t = object
+	default:
+		t = scalarType(field.Kind)
+	}
+
+	if field.IsList {
+		t = gql.NewList(t)
+	}
+	if field.IsFieldTypeRequired && !field.IsLinked {
+		t = gql.NewNonNull(t)
+	}
+	return t
+}
+
+func (b *schemaBuilder) linkedType(field *model.FieldType) gql.Output {
+	if field.LinkedTable == nil || field.LinkedTable.Field != "" {
+		return scalarType(field.Kind)
+	}
+	dbAlias, table := field.LinkedTable.DBType, field.LinkedTable.Table
+	if _, p := b.schemas[dbAlias][table]; !p || !graphqlNameRegex.MatchString(dbAlias) || !graphqlNameRegex.MatchString(table) {
+		return scalarJSON
+	}
+	return b.tableObject(dbAlias, table)
+}
+
+// fieldsInput returns the input type used to insert or set the columns of a table
+func (b *schemaBuilder) fieldsInput(name, typeName string, fields model.Fields, isInsert bool) *gql.InputObject {
+	if input, p := b.inputObjects[name]; p {
+		return input
+	}
+
+	inputFields := gql.InputObjectConfigFieldMap{}
+	for _, fieldName := range sortedKeys(fields) {
+		field := fields[fieldName]
+		if !graphqlNameRegex.MatchString(fieldName) {
+			continue
+		}
+
+		var t gql.Input
+		switch {
+		case field.IsLinked:
+			// Linked documents are inserted along with the parent document
+			t = scalarJSON
+		case field.Kind == model.TypeObject && len(field.NestedObject) > 0:
+			suffix := "_set_input"
+			if isInsert {
+				suffix = "_insert_input"
+			}
+			nestedTypeName := typeName + "_" + fieldName
+			t = b.fieldsInput(nestedTypeName+suffix, nestedTypeName, field.NestedObject, isInsert)
+		default:
+			t = scalarType(field.Kind)
+		}
+
+		if field.IsList {
+			t = gql.NewList(t)
+		}
+		if isInsert && field.IsFieldTypeRequired && !field.IsLinked && !field.IsDefault && !field.IsAutoIncrement && !field.IsCreatedAt && !field.IsUpdatedAt {
+			t = gql.NewNonNull(t)
+		}
+		inputFields[fieldName] = &gql.InputObjectFieldConfig{Type: t}
+	}
+
+	input := gql.NewInputObject(gql.InputObjectConfig{Name: name, Fields: inputFields})
+	b.inputObjects[name] = input
+	return input
+}
+
+// whereInput returns the input type used to filter the documents of a table
+func (b *schemaBuilder) whereInput(typeName string, fields model.Fields) *gql.InputObject {
+	name := typeName + "_where"
+	if input, p := b.inputObjects[name]; p {
+		return input
+	}
+
+	var input *gql.InputObject
+	input = gql.NewInputObject(gql.InputObjectConfig{
+		Name:        name,
+		Description: fmt.Sprintf("Filters the documents of %s. Conditions on multiple columns are combined with an and", typeName),
+		Fields: gql.InputObjectConfigFieldMapThunk(func() gql.InputObjectConfigFieldMap {
+			inputFields := gql.InputObjectConfigFieldMap{
+				"_or": &gql.InputObjectFieldConfig{Type: gql.NewList(gql.NewNonNull(input)), Description: "Matches the documents which satisfy any of the conditions"},
+			}
+			for _, fieldName := range sortedKeys(fields) {
+				field := fields[fieldName]
+				if field.IsLinked || !graphqlNameRegex.MatchString(fieldName) {
+					continue
+				}
+
+				s := scalarType(field.Kind)
+				if field.IsList || field.Kind == model.TypeObject {
+					s = scalarJSON
+				}
+				inputFields[fieldName] = &gql.InputObjectFieldConfig{Type: b.comparisonInput(s)}
+			}
+			return inputFields
+		}),
+	})
+	b.inputObjects[name] = input
+	return input
+}
+
+// comparisonInput returns the input type holding the operators which can be used to filter on a column of the provided type
+func (b *schemaBuilder) comparisonInput(s *gql.Scalar) *gql.InputObject {
+	name := s.Name() + "_comparison_exp"
+	if input, p := b.inputObjects[name]; p {
+		return input
+	}
+
+	fields := gql.InputObjectConfigFieldMap{
+		"_eq": &gql.InputObjectFieldConfig{Type: s},
+		"_ne": &gql.InputObjectFieldConfig{Type: s},
+	}
+	switch s {
+	case gql.Boolean:
+	case scalarJSON:
+		fields["_contains"] = &gql.InputObjectFieldConfig{Type: scalarJSON}
+	default:
+		for _, op := range []string{"_gt", "_gte", "_lt", "_lte"} {
+			fields[op] = &gql.InputObjectFieldConfig{Type: s}
+		}
+		fields["_in"] = &gql.InputObjectFieldConfig{Type: gql.NewList(gql.NewNonNull(s))}
+		fields["_nin"] = &gql.InputObjectFieldConfig{Type: gql.NewList(gql.NewNonNull(s))}
+		if s == gql.String {
+			fields["_like"] = &gql.InputObjectFieldConfig{Type: gql.String}
+			fields["_regex"] = &gql.InputObjectFieldConfig{Type: gql.String}
+		}
+	}
+
+	input := gql.NewInputObject(gql.InputObjectConfig{Name: name, Fields: fields})
+	b.inputObjects[name] = input
+	return input
+}
+
+// sortScalar returns the scalar accepted by the sort argument of a table. Columns are sorted in descending order
+// when prefixed with a minus which is why an enum cannot be used here
+func (b *schemaBuilder) sortScalar(typeName string, fields model.Fields) *gql.Scalar {
+	name := typeName + "_sort"
+	if s, p := b.scalars[name]; p {
+		return s
+	}
+
+	columns := make([]string, 0, len(fields))
+	for _, fieldName := range sortedKeys(fields) {
+		if field := fields[fieldName]; !field.IsLinked && field.Kind != model.TypeObject {
+			columns = append(columns, fieldName)
+		}
+	}
+	s := newScalar(name, fmt.Sprintf("Column of %s to sort by, prefixed with - to sort in descending order. One of: %s", typeName, strings.Join(columns, ", ")))
+	b.scalars[name] = s
+	return s
+}
+
+// aggregateObject returns the type of the aggregate field of a table
+func (b *schemaBuilder) aggregateObject(typeName string, fields model.Fields) *gql.Object {
+	name := typeName + "_aggregate"
+	if object, p := b.objects[name]; p {
+		return object
+	}
+
+	aggregateFields := gql.Fields{"count": &gql.Field{Type: gql.Int}}
+	if numeric := b.numericFields(typeName+"_numeric_fields", fields, false); numeric != nil {
+		aggregateFields["sum"] = &gql.Field{Type: numeric.(gql.Output)}
+		aggregateFields["avg"] = &gql.Field{Type: numeric.(gql.Output)}
+	}
+
+	comparable := gql.Fields{}
+	for _, fieldName := range sortedKeys(fields) {
+		field := fields[fieldName]
+		if s := scalarType(field.Kind); !field.IsLinked && !field.IsList && s != scalarJSON && s != gql.Boolean && graphqlNameRegex.MatchString(fieldName) {
+			comparable[fieldName] = &gql.Field{Type: s}
+		}
+	}
+	if len(comparable) > 0 {
+		minMax := gql.NewObject(gql.ObjectConfig{Name: typeName + "_aggregate_fields", Fields: comparable})
+		aggregateFields["min"] = &gql.Field{Type: minMax}
+		aggregateFields["max"] = &gql.Field{Type: minMax}
+	}
+
+	object := gql.NewObject(gql.ObjectConfig{Name: name, Fields: aggregateFields})
+	b.objects[name] = object
+	return object
+}
+
+// numericFields returns an object (or input object) holding the numeric columns of a table. Nil is returned if the
+// table doesn't have any numeric columns
+func (b *schemaBuilder) numericFields(name string, fields model.Fields, isInput bool) gql.Type {
+	if isInput {
+		if input, p := b.inputObjects[name]; p {
+			return input
+		}
+	} else if object, p := b.objects[name]; p {
+		return object
+	}
+
+	objectFields := gql.Fields{}
+	inputFields := gql.InputObjectConfigFieldMap{}
+	for _, fieldName := range sortedKeys(fields) {
+		field := fields[fieldName]
+		if s := scalarType(field.Kind); !field.IsLinked && !field.IsList && (s == gql.Int || s == gql.Float) && graphqlNameRegex.MatchString(fieldName) {
+			objectFields[fieldName] = &gql.Field{Type: s}
+			inputFields[fieldName] = &gql.InputObjectFieldConfig{Type: s}
+		}
+	}
+	if len(objectFields) == 0 {
+		return nil
+	}
+
+	if isInput {
+		input := gql.NewInputObject(gql.InputObjectConfig{Name: name, Fields: inputFields})
+		b.inputObjects[name] = input
+		return input
+	}
+	object := gql.NewObject(gql.ObjectConfig{Name: name, Fields: objectFields})
+	b.objects[name] = object
+	return object
+}
+
+func (b *schemaBuilder) addPreparedQueries(queries config.DatabasePreparedQueries) {
+	for _, key := range sortedKeys(queries) {
+		q := queries[key]
+		if !graphqlNameRegex.MatchString(q.ID) || !graphqlNameRegex.MatchString(q.DbAlias) {
+			continue
+		}
+
+		// Prepared queries take precedence over the tables of the same database
+		if _, p := b.query[q.ID]; p && b.readFields[q.ID] != q.DbAlias {
+			continue
+		}
+
+		args := gql.FieldConfigArgument{
+			"debug": &gql.ArgumentConfig{Type: gql.Boolean, Description: "Returns the executed query in the _query field"},
+		}
+		for _, arg := range q.Arguments {
+			if !strings.HasPrefix(arg, "args.") {
+				continue
+			}
+			name := strings.Split(strings.TrimPrefix(arg, "args."), ".")[0]
+			if graphqlNameRegex.MatchString(name) {
+				args[name] = &gql.ArgumentConfig{Type: scalarJSON}
+			}
+		}
+
+		b.addDirective(q.DbAlias, gql.FieldConfigArgument{})
+		b.query[q.ID] = &gql.Field{
+			Type:        scalarJSON,
+			Args:        args,
+			Description: fmt.Sprintf("Executes the prepared query (%s) on the database (%s). Use the @%s directive on this field", q.ID, q.DbAlias, q.DbAlias),
+		}
+	}
+}
+
+func (b *schemaBuilder) addServices(services config.Services) {
+	for _, key := range sortedKeys(services) {
+		service := services[key]
+		if !graphqlNameRegex.MatchString(service.ID) {
+			continue
+		}
+		b.addDirective(service.ID, gql.FieldConfigArgument{
+			"func":    &gql.ArgumentConfig{Type: gql.String, Description: "Endpoint to invoke. Defaults to the name of the field"},
+			"timeout": &gql.ArgumentConfig{Type: gql.Int, Description: "Timeout of the request in seconds"},
+		})
+
+		for _, endpoint := range sortedKeys(service.Endpoints) {
+			if !graphqlNameRegex.MatchString(endpoint) {
+				continue
+			}
+
+			// Endpoints which collide with another field are prefixed with the service name and use the func argument
+			// of the directive to specify the endpoint
+			name, directive := endpoint, "@"+service.ID
+			if _, p := b.query[name]; p {
+				name, directive = service.ID+"_"+endpoint, fmt.Sprintf("@%s(func: %q)", service.ID, endpoint)
+				if _, p := b.query[name]; p {
+					continue
+				}
+			}
+
+			b.query[name] = &gql.Field{
+				Type:        scalarJSON,
+				Description: fmt.Sprintf("Invokes the endpoint (%s) of the remote service (%s). Use the %s directive on this field. The arguments of the field are forwarded to the endpoint", endpoint, service.ID, directive),
+			}
+		}
+	}
+}
+
+// scalarType maps the kind of a column to the graphql scalar representing it
+func scalarType(kind string) *gql.Scalar {
+	switch kind {
+	case model.TypeID:
+		return gql.ID
+	case model.TypeString, model.TypeChar, model.TypeVarChar, model.TypeUUID:
+		return gql.String
+	case model.TypeInteger, model.TypeSmallInteger, model.TypeBigInteger:
+		return gql.Int
+	case model.TypeFloat, model.TypeDecimal:
+		return gql.Float
+	case model.TypeBoolean:
+		return gql.Boolean
+	case model.TypeDate:
+		return scalarDate
+	case model.TypeTime:
+		return scalarTime
+	case model.TypeDateTime, model.TypeDateTimeWithZone:
+		return scalarDateTime
+	default:
+		return scalarJSON
+	}
+}
+
+func sortedKeys(m interface{}) []string {
+	var keys []string
+	switch v := m.(type) {
+	case model.Type:
+		for k := range v {
+			keys = append(keys, k)
+		}
+	case model.Collection:
+		for k := range v {
+			keys = append(keys, k)
+		}
+	case model.Fields:
+		for k := range v {
+			keys = append(keys, k)
+		}
+	case config.DatabasePreparedQueries:
+		for k := range v {
+			keys = append(keys, k)
+		}
+	case config.Services:
+		for k := range v {
+			keys = append(keys, k)
+		}
+	case map[string]*config.Endpoint:
+		for k := range v {
+			keys = append(keys, k)
+		}
+	case map[string]gql.FieldConfigArgument:
+		for k := range v {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
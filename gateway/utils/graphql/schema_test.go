@@ -0,0 +1,220 @@
+package graphql_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/graphql-go/graphql/testutil"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/spaceuptech/space-cloud/gateway/config"
+	"github.com/spaceuptech/space-cloud/gateway/model"
+	schemaHelpers "github.com/spaceuptech/space-cloud/gateway/modules/schema/helpers"
+	"github.com/spaceuptech/space-cloud/gateway/utils/graphql"
+)
+
+func newSchemaTestModule(t *testing.T) *graphql.Module {
+	graph, _ := newSchemaTestModuleWithAuth(t, &mockGraphQLAuthInterface{})
+	return graph
+}
+
+func newSchemaTestModuleWithAuth(t *testing.T, mockAuth *mockGraphQLAuthInterface) (*graphql.Module, *mockGraphQLSchemaInterface) {
+	schemas, err := schemaHelpers.Parser(config.DatabaseSchemas{
+		"authors": &config.DatabaseSchema{Table: "authors", DbAlias: "db", Schema: `type authors {
+			id: ID! @primary
+			name: String!
+			age: Integer
+			rating: Float
+			created_at: DateTime @createdAt
+			address: address
+			books: [books] @link(table: "books", from: "id", to: "author_id")
+		}
+		type address {
+			city: String
+		}`},
+		"books": &config.DatabaseSchema{Table: "books", DbAlias: "db", Schema: `type books {
+			id: ID! @primary
+			author_id: ID
+			title: String
+		}`},
+		"legacy-books": &config.DatabaseSchema{Table: "books", DbAlias: "legacy", Schema: `type books {
+			id: ID! @primary
+			title: String
+		}`},
+	})
+	if err != nil {
+		t.Fatalf("Parser() error = %v", err)
+	}
+
+	mockSchema := mockGraphQLSchemaInterface{}
+	mockSchema.On("GetSchemas").Return(schemas)
+	mockCrud := mockGraphQLCrudInterface{}
+	mockCrud.On("GetPreparedQueries").Return(config.DatabasePreparedQueries{
+		"top-authors":  &config.DatbasePreparedQuery{ID: "top_authors", DbAlias: "db", Arguments: []string{"args.limit", "auth.id"}},
+		"invalid-name": &config.DatbasePreparedQuery{ID: "top-books", DbAlias: "db"},
+	})
+	mockFunction := mockGraphQLFunctionInterface{}
+	mockFunction.On("GetServices").Return(config.Services{
+		"payments": &config.Service{ID: "payments", Endpoints: map[string]*config.Endpoint{"charge": {}, "books": {}}},
+	})
+
+	return graphql.New(mockAuth, &mockCrud, &mockFunction, &mockSchema), &mockSchema
+}
+
+func TestModule_GetSchemaSDL(t *testing.T) {
+	sdl, err := newSchemaTestModule(t).GetSchemaSDL()
+	if err != nil {
+		t.Fatalf("GetSchemaSDL() error = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		contains string
+		want     bool
+	}{
+		{name: "database directive", contains: "directive @db(cache: JSON, col: String) on FIELD", want: true},
+		{name: "remote service directive", contains: "directive @payments(func: String, timeout: Int) on FIELD", want: true},
+		{name: "aggregate directive", contains: "directive @aggregate(field: String, op: String!) on FIELD", want: true},
+		{name: "read field", contains: "  authors(after: String, before: String, debug: Boolean, distinct: String, group: [String!], join: JSON, limit: Int, op: String, returnType: String, skip: Int, sort: [authors_sort!], where: authors_where): [authors]", want: true},
+		{name: "table of another database is prefixed", contains: "  legacy_books(", want: true},
+		{name: "prefixed table describes the col argument", contains: `Use the @legacy(col: \"books\") directive on this field`, want: true},
+		{name: "prefixed table has no mutations", contains: "insert_legacy_books", want: false},
+		{name: "table type", contains: "type authors {\n  \"Cursor of the document. Pass it to the after or before argument to paginate\"\n  _cursor: String\n  address: authors_address\n  age: Int\n  \"Aggregations of the documents\"\n  aggregate: authors_aggregate\n  books: [books]\n  created_at: DateTime\n  id: ID!\n  name: String!\n  rating: Float\n}", want: true},
+		{name: "nested object type", contains: "type authors_address {\n  city: String\n}", want: true},
+		{name: "where input", contains: "input authors_where {\n  \"Matches the documents which satisfy any of the conditions\"\n  _or: [authors_where!]\n  address: JSON_comparison_exp\n  age: Int_comparison_exp\n  created_at: DateTime_comparison_exp\n  id: ID_comparison_exp\n  name: String_comparison_exp\n  rating: Float_comparison_exp\n}", want: true},
+		{name: "comparison input", contains: "input String_comparison_exp {\n  _eq: String\n  _gt: String\n  _gte: String\n  _in: [String!]\n  _like: String\n  _lt: String\n  _lte: String\n  _ne: String\n  _nin: [String!]\n  _regex: String\n}", want: true},
+		{name: "sort scalar", contains: `"Column of authors to sort by, prefixed with - to sort in descending order. One of: age, created_at, id, name, rating"` + "\nscalar authors_sort", want: true},
+		{name: "aggregate type", contains: "type authors_aggregate {\n  avg: authors_numeric_fields\n  count: Int\n  max: authors_aggregate_fields\n  min: authors_aggregate_fields\n  sum: authors_numeric_fields\n}", want: true},
+		{name: "numeric fields", contains: "type authors_numeric_fields {\n  age: Int\n  rating: Float\n}", want: true},
+		{name: "insert input", contains: "input authors_insert_input {\n  address: authors_address_insert_input\n  age: Int\n  books: [JSON]\n  created_at: DateTime\n  id: ID!\n  name: String!\n  rating: Float\n}", want: true},
+		{name: "insert mutation", contains: "  insert_authors(docs: [authors_insert_input!]!): authors_mutation_response", want: true},
+		{name: "update mutation", contains: "  update_authors(currentDate: JSON, currentTimestamp: JSON, inc: authors_inc_input, max: authors_set_input, min: authors_set_input, mul: authors_inc_input, op: String, push: JSON, rename: JSON, set: authors_set_input, unset: JSON, where: authors_where): authors_mutation_response", want: true},
+		{name: "delete mutation", contains: "  delete_books(where: books_where): books_mutation_response", want: true},
+		{name: "prepared query", contains: "  top_authors(debug: Boolean, limit: JSON): JSON", want: true},
+		{name: "prepared query with invalid name", contains: "top-books", want: false},
+		{name: "remote service endpoint", contains: "  charge: JSON", want: true},
+		{name: "remote service endpoint colliding with a table", contains: `  payments_books: JSON`, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := strings.Contains(sdl, tt.contains); got != tt.want {
+				t.Errorf("GetSchemaSDL() contains (%s) = %v, want %v\n%s", tt.contains, got, tt.want, sdl)
+			}
+		})
+	}
+}
+
+func TestModule_ExecGraphQLQuery_introspection(t *testing.T) {
+	tests := []struct {
+		name       string
+		req        *model.GraphQLRequest
+		wantResult interface{}
+		wantErr    bool
+	}{
+		{
+			name:       "root types",
+			req:        &model.GraphQLRequest{Query: `{ __schema { queryType { name } mutationType { name } } }`},
+			wantResult: map[string]interface{}{"__schema": map[string]interface{}{"queryType": map[string]interface{}{"name": "Query"}, "mutationType": map[string]interface{}{"name": "Mutation"}}},
+		},
+		{
+			name:       "type with variables",
+			req:        &model.GraphQLRequest{Query: `query Book($name: String!) { __type(name: $name) { name kind } __typename }`, OperationName: "Book", Variables: map[string]interface{}{"name": "books_where"}},
+			wantResult: map[string]interface{}{"__type": map[string]interface{}{"name": "books_where", "kind": "INPUT_OBJECT"}, "__typename": "Query"},
+		},
+		{
+			name:    "invalid introspection query",
+			req:     &model.GraphQLRequest{Query: `{ __schema { unknownField } }`},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := execGraphQLQuery(newSchemaTestModule(t), tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ExecGraphQLQuery() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(result, tt.wantResult) {
+				t.Errorf("ExecGraphQLQuery() = %v, want %v", result, tt.wantResult)
+			}
+		})
+	}
+
+	t.Run("standard introspection query", func(t *testing.T) {
+		result, err := execGraphQLQuery(newSchemaTestModule(t), &model.GraphQLRequest{Query: testutil.IntrospectionQuery, OperationName: "IntrospectionQuery"})
+		if err != nil {
+			t.Fatalf("ExecGraphQLQuery() error = %v", err)
+		}
+		types := result.(map[string]interface{})["__schema"].(map[string]interface{})["types"].([]interface{})
+		names := map[string]bool{}
+		for _, v := range types {
+			names[v.(map[string]interface{})["name"].(string)] = true
+		}
+		for _, name := range []string{"authors", "books", "legacy_books", "authors_where", "authors_sort", "authors_aggregate", "JSON"} {
+			if !names[name] {
+				t.Errorf("ExecGraphQLQuery() introspection result doesn't contain the type (%s)", name)
+			}
+		}
+	})
+}
+
+func TestModule_ExecGraphQLQuery_introspectionSettings(t *testing.T) {
+	query := `{ __schema { queryType { name } } }`
+	tests := []struct {
+		name          string
+		introspection config.GraphQLIntrospection
+		limits        *config.GraphQLLimits
+		query         string
+		token         string
+		tokenErr      error
+		wantErr       bool
+	}{
+		{name: "enabled by default", query: query},
+		{name: "disabled", introspection: config.GraphQLIntrospectionDisabled, query: query, wantErr: true},
+		{name: "unknown setting is treated as disabled", introspection: "public", query: query, wantErr: true},
+		{name: "token required and provided", introspection: config.GraphQLIntrospectionToken, query: query, token: "valid"},
+		{name: "token required and invalid", introspection: config.GraphQLIntrospectionToken, query: query, token: "invalid", tokenErr: errors.New("invalid token"), wantErr: true},
+		{name: "depth limit applies", limits: &config.GraphQLLimits{MaxDepth: 3}, query: `{ __schema { types { fields { type { name } } } } }`, wantErr: true},
+		{name: "alias limit applies", limits: &config.GraphQLLimits{MaxAliases: 1}, query: `{ a: __typename b: __typename }`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAuth := &mockGraphQLAuthInterface{}
+			if tt.introspection == config.GraphQLIntrospectionToken {
+				mockAuth.On("ParseToken", mock.Anything, tt.token).Return(map[string]interface{}{}, tt.tokenErr)
+			}
+			graph, _ := newSchemaTestModuleWithAuth(t, mockAuth)
+			graph.SetIntrospection(tt.introspection)
+			graph.SetQueryLimits(tt.limits)
+
+			_, err := execGraphQLQueryWithContext(context.Background(), graph, &model.GraphQLRequest{Query: tt.query}, tt.token)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ExecGraphQLQuery() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			mockAuth.AssertExpectations(t)
+		})
+	}
+}
+
+func TestModule_GenerateSchema_cache(t *testing.T) {
+	graph, mockSchema := newSchemaTestModuleWithAuth(t, &mockGraphQLAuthInterface{})
+
+	for i := 0; i < 2; i++ {
+		if _, err := graph.GenerateSchema(); err != nil {
+			t.Fatalf("GenerateSchema() error = %v", err)
+		}
+	}
+	mockSchema.AssertNumberOfCalls(t, "GetSchemas", 1)
+
+	graph.ResetSchema()
+	if _, err := graph.GenerateSchema(); err != nil {
+		t.Fatalf("GenerateSchema() error = %v", err)
+	}
+	mockSchema.AssertNumberOfCalls(t, "GetSchemas", 2)
+}
+
+func execGraphQLQuery(graph *graphql.Module, req *model.GraphQLRequest) (interface{}, error) {
+	return execGraphQLQueryWithContext(context.Background(), graph, req, "")
+}
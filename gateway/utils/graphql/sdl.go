@@ -0,0 +1,136 @@
+package graphql
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	gql "github.com/graphql-go/graphql"
+)
+
+// printSchema prints the schema in the graphql schema definition language. Introspection types, the built in scalars
+// and the directives defined by the spec are left out
+func printSchema(schema *gql.Schema) string {
+	blocks := make([]string, 0)
+
+	directives := make([]*gql.Directive, 0)
+	for _, d := range schema.Directives() {
+		if !isSpecifiedDirective(d) {
+			directives = append(directives, d)
+		}
+	}
+	sort.Slice(directives, func(i, j int) bool { return directives[i].Name < directives[j].Name })
+	for _, d := range directives {
+		blocks = append(blocks, fmt.Sprintf("%sdirective @%s%s on %s", printDescription(d.Description, ""), d.Name, printArgs(d.Args), strings.Join(d.Locations, " | ")))
+	}
+
+	// The root types are printed before the remaining types
+	names := make([]string, 0)
+	for name := range schema.TypeMap() {
+		if strings.HasPrefix(name, "__") || isBuiltInScalar(name) || name == "Query" || name == "Mutation" {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	if schema.MutationType() != nil {
+		names = append([]string{"Mutation"}, names...)
+	}
+	names = append([]string{"Query"}, names...)
+
+	for _, name := range names {
+		if block := printType(schema.Type(name)); block != "" {
+			blocks = append(blocks, block)
+		}
+	}
+
+	return strings.Join(blocks, "\n\n") + "\n"
+}
+
+func printType(t gql.Type) string {
+	switch v := t.(type) {
+	case *gql.Scalar:
+		return fmt.Sprintf("%sscalar %s", printDescription(v.Description(), ""), v.Name())
+
+	case *gql.Enum:
+		values := make([]string, 0, len(v.Values()))
+		for _, value := range v.Values() {
+			values = append(values, printDescription(value.Description, "  ")+"  "+value.Name)
+		}
+		return fmt.Sprintf("%senum %s {\n%s\n}", printDescription(v.Description(), ""), v.Name(), strings.Join(values, "\n"))
+
+	case *gql.Object:
+		fieldMap := v.Fields()
+		fields := make([]string, 0, len(fieldMap))
+		for _, name := range sortedFieldNames(fieldMap) {
+			f := fieldMap[name]
+			fields = append(fields, fmt.Sprintf("%s  %s%s: %s", printDescription(f.Description, "  "), f.Name, printArgs(f.Args), f.Type.String()))
+		}
+		return fmt.Sprintf("%stype %s {\n%s\n}", printDescription(v.Description(), ""), v.Name(), strings.Join(fields, "\n"))
+
+	case *gql.InputObject:
+		fieldMap := v.Fields()
+		names := make([]string, 0, len(fieldMap))
+		for name := range fieldMap {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		fields := make([]string, 0, len(fieldMap))
+		for _, name := range names {
+			f := fieldMap[name]
+			fields = append(fields, fmt.Sprintf("%s  %s: %s", printDescription(f.Description(), "  "), f.Name(), f.Type.String()))
+		}
+		return fmt.Sprintf("%sinput %s {\n%s\n}", printDescription(v.Description(), ""), v.Name(), strings.Join(fields, "\n"))
+	}
+	return ""
+}
+
+func printArgs(args []*gql.Argument) string {
+	if len(args) == 0 {
+		return ""
+	}
+
+	sorted := make([]*gql.Argument, len(args))
+	copy(sorted, args)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name() < sorted[j].Name() })
+
+	arr := make([]string, len(sorted))
+	for i, arg := range sorted {
+		arr[i] = fmt.Sprintf("%s: %s", arg.Name(), arg.Type.String())
+	}
+	return "(" + strings.Join(arr, ", ") + ")"
+}
+
+func printDescription(description, indent string) string {
+	if description == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s%q\n", indent, description)
+}
+
+func sortedFieldNames(fields gql.FieldDefinitionMap) []string {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func isSpecifiedDirective(d *gql.Directive) bool {
+	for _, specified := range gql.SpecifiedDirectives {
+		if specified.Name == d.Name {
+			return true
+		}
+	}
+	return false
+}
+
+func isBuiltInScalar(name string) bool {
+	switch name {
+	case "String", "Int", "Float", "Boolean", "ID":
+		return true
+	}
+	return false
+}
@@ -3,6 +3,7 @@ package graphql
 import (
 	"context"
 
+	"github.com/spaceuptech/space-cloud/gateway/config"
 	"github.com/spaceuptech/space-cloud/gateway/model"
 )
 
@@ -16,6 +17,7 @@ type CrudInterface interface {
 	GetDBType(dbAlias string) (string, error)
 	IsPreparedQueryPresent(directive, fieldName string) bool
 	ExecPreparedQuery(ctx context.Context, dbAlias, id string, req *model.PreparedQueryRequest, params model.RequestParams) (interface{}, *model.SQLMetaData, error)
+	GetPreparedQueries() config.DatabasePreparedQueries
 }
 
 // AuthInterface is an interface consisting of functions of auth module used by graphql module
@@ -27,14 +29,17 @@ type AuthInterface interface {
 	IsDeleteOpAuthorised(ctx context.Context, project, dbAlias, col, token string, req *model.DeleteRequest) (model.RequestParams, error)
 	IsFuncCallAuthorised(ctx context.Context, project, service, function, token string, params interface{}) (*model.PostProcess, model.RequestParams, error)
 	IsPreparedQueryAuthorised(ctx context.Context, project, dbAlias, id, token string, req *model.PreparedQueryRequest) (*model.PostProcess, model.RequestParams, error)
+	AuthorizeRequest(ctx context.Context, rule *config.Rule, project, token string, args map[string]interface{}) (map[string]interface{}, error)
 }
 
 // FunctionInterface is an interface consisting of functions of function module used by graphql module
 type FunctionInterface interface {
 	CallWithContext(ctx context.Context, service, function, token string, reqParams model.RequestParams, req *model.FunctionsRequest) (int, interface{}, error)
+	GetServices() config.Services
 }
 
 // SchemaInterface is an interface consisting of functions of schema module used by graphql module
 type SchemaInterface interface {
 	GetSchema(dbAlias, col string) (model.Fields, bool)
+	GetSchemas() model.Type
 }
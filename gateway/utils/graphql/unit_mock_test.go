@@ -5,6 +5,7 @@ import (
 
 	"github.com/stretchr/testify/mock"
 
+	"github.com/spaceuptech/space-cloud/gateway/config"
 	"github.com/spaceuptech/space-cloud/gateway/model"
 )
 
@@ -45,6 +46,11 @@ func (m *mockGraphQLCrudInterface) ExecPreparedQuery(ctx context.Context, dbAlia
 	return args.Get(0), args.Get(1).(*model.SQLMetaData), args.Error(2)
 }
 
+func (m *mockGraphQLCrudInterface) GetPreparedQueries() config.DatabasePreparedQueries {
+	args := m.Called()
+	return args.Get(0).(config.DatabasePreparedQueries)
+}
+
 type mockGraphQLAuthInterface struct {
 	mock.Mock
 }
@@ -83,6 +89,11 @@ func (m *mockGraphQLAuthInterface) IsPreparedQueryAuthorised(ctx context.Context
 	return args.Get(0).(*model.PostProcess), args.Get(1).(model.RequestParams), args.Error(2)
 }
 
+func (m *mockGraphQLAuthInterface) AuthorizeRequest(ctx context.Context, rule *config.Rule, project, token string, args map[string]interface{}) (map[string]interface{}, error) {
+	c := m.Called(ctx, rule, project, token, args)
+	return c.Get(0).(map[string]interface{}), c.Error(1)
+}
+
 type mockGraphQLFunctionInterface struct {
 	mock.Mock
 }
@@ -92,6 +103,11 @@ func (m *mockGraphQLFunctionInterface) CallWithContext(ctx context.Context, serv
 	return 0, args.Get(0).(interface{}), args.Error(1)
 }
 
+func (m *mockGraphQLFunctionInterface) GetServices() config.Services {
+	args := m.Called()
+	return args.Get(0).(config.Services)
+}
+
 type mockGraphQLSchemaInterface struct {
 	mock.Mock
 }
@@ -100,3 +116,8 @@ func (m *mockGraphQLSchemaInterface) GetSchema(dbAlias, col string) (model.Field
 	args := m.Called(dbAlias, col)
 	return args.Get(0).(model.Fields), args.Bool(1)
 }
+
+func (m *mockGraphQLSchemaInterface) GetSchemas() model.Type {
+	args := m.Called()
+	return args.Get(0).(model.Type)
+}
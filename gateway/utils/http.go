@@ -10,6 +10,8 @@ import (
 
 	"github.com/rs/cors"
 	"github.com/spaceuptech/helpers"
+
+	"github.com/spaceuptech/space-cloud/gateway/utils/tracing"
 )
 
 // HTTPRequest describes the request object
@@ -47,6 +49,9 @@ func MakeHTTPRequest(ctx context.Context, request *HTTPRequest, vPtr interface{}
 		request.Headers.UpdateHeader(req.Header)
 	}
 
+	// Propagate the trace context to the remote service
+	tracing.Inject(ctx, req.Header)
+
 	// Create a http client and fire the request
 	client := &http.Client{}
 
@@ -31,6 +31,11 @@ func ExecTemplate(ctx context.Context, tmpl *template.Template, object map[strin
 func GoTemplate(ctx context.Context, tmpl *template.Template, format, token string, claims, params interface{}) (interface{}, error) {
 	// Prepare the object
 	object := map[string]interface{}{"args": params, "auth": claims, "token": token}
+	return GoTemplateWithObject(ctx, tmpl, format, object)
+}
+
+// GoTemplateWithObject executes a go template against the provided object
+func GoTemplateWithObject(ctx context.Context, tmpl *template.Template, format string, object map[string]interface{}) (interface{}, error) {
 	s, err := ExecTemplate(ctx, tmpl, object)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,57 @@
+package tracing
+
+import (
+	"context"
+	"sync"
+)
+
+// InMemoryExporter stores the exported spans in memory. It is meant to be used in tests
+type InMemoryExporter struct {
+	lock  sync.Mutex
+	spans []*SpanData
+}
+
+// NewInMemoryExporter creates a new in memory exporter
+func NewInMemoryExporter() *InMemoryExporter {
+	return &InMemoryExporter{}
+}
+
+// ExportSpans stores the provided spans
+func (e *InMemoryExporter) ExportSpans(_ context.Context, spans []*SpanData) error {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	e.spans = append(e.spans, spans...)
+	return nil
+}
+
+// Shutdown is a no-op for the in memory exporter
+func (e *InMemoryExporter) Shutdown(_ context.Context) error {
+	return nil
+}
+
+// GetSpans returns the spans exported so far in the order they ended
+func (e *InMemoryExporter) GetSpans() []*SpanData {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	return append([]*SpanData{}, e.spans...)
+}
+
+// GetSpansByName returns the exported spans with the provided name
+func (e *InMemoryExporter) GetSpansByName(name string) []*SpanData {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	var spans []*SpanData
+	for _, span := range e.spans {
+		if span.Name == name {
+			spans = append(spans, span)
+		}
+	}
+	return spans
+}
+
+// Reset removes all the stored spans
+func (e *InMemoryExporter) Reset() {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	e.spans = nil
+}
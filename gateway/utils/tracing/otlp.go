@@ -0,0 +1,298 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spaceuptech/helpers"
+)
+
+const (
+	otlpTracesPath    = "/v1/traces"
+	otlpQueueSize     = 2048
+	otlpMaxBatchSize  = 512
+	otlpFlushInterval = 5 * time.Second
+	otlpScopeName     = "github.com/spaceuptech/space-cloud/gateway"
+)
+
+// OTLPExporter batches the finished spans and exports them to an OpenTelemetry collector using OTLP over http
+// with the json encoding
+type OTLPExporter struct {
+	url      string
+	headers  map[string]string
+	resource []Attribute
+	client   *http.Client
+
+	queue    chan *SpanData
+	flushC   chan chan struct{}
+	closeC   chan struct{}
+	doneC    chan struct{}
+	shutdown sync.Once
+}
+
+// NewOTLPExporter creates an exporter which sends spans to the collector at the provided endpoint (for example
+// http://localhost:4318). The resource attributes identify the gateway emitting the spans
+func NewOTLPExporter(endpoint string, headers map[string]string, resource ...Attribute) *OTLPExporter {
+	e := &OTLPExporter{
+		url:      strings.TrimSuffix(endpoint, "/") + otlpTracesPath,
+		headers:  headers,
+		resource: resource,
+		client:   &http.Client{Timeout: 10 * time.Second},
+		queue:    make(chan *SpanData, otlpQueueSize),
+		flushC:   make(chan chan struct{}),
+		closeC:   make(chan struct{}),
+		doneC:    make(chan struct{}),
+	}
+	go e.routineExport()
+	return e
+}
+
+// ExportSpans queues the spans to be exported in the next batch. Spans are dropped if the queue is full
+func (e *OTLPExporter) ExportSpans(_ context.Context, spans []*SpanData) error {
+	for _, span := range spans {
+		select {
+		case e.queue <- span:
+		default:
+			return fmt.Errorf("otlp export queue is full - dropping span (%s)", span.Name)
+		}
+	}
+	return nil
+}
+
+// ForceFlush exports all the queued spans
+func (e *OTLPExporter) ForceFlush(ctx context.Context) error {
+	done := make(chan struct{})
+	select {
+	case e.flushC <- done:
+	case <-e.doneC:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Shutdown exports the queued spans and stops the exporter
+func (e *OTLPExporter) Shutdown(ctx context.Context) error {
+	e.shutdown.Do(func() { close(e.closeC) })
+	select {
+	case <-e.doneC:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (e *OTLPExporter) routineExport() {
+	defer close(e.doneC)
+
+	ticker := time.NewTicker(otlpFlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]*SpanData, 0, otlpMaxBatchSize)
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		if err := e.send(batch); err != nil {
+			_ = helpers.Logger.LogError(helpers.GetRequestID(context.TODO()), fmt.Sprintf("Unable to export (%d) spans to otlp endpoint (%s)", len(batch), e.url), err, nil)
+		}
+		batch = make([]*SpanData, 0, otlpMaxBatchSize)
+	}
+	drain := func() {
+		for {
+			select {
+			case span := <-e.queue:
+				batch = append(batch, span)
+				if len(batch) >= otlpMaxBatchSize {
+					flush()
+				}
+			default:
+				flush()
+				return
+			}
+		}
+	}
+
+	for {
+		select {
+		case span := <-e.queue:
+			batch = append(batch, span)
+			if len(batch) >= otlpMaxBatchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		case done := <-e.flushC:
+			drain()
+			close(done)
+		case <-e.closeC:
+			drain()
+			return
+		}
+	}
+}
+
+func (e *OTLPExporter) send(spans []*SpanData) error {
+	data, err := json.Marshal(newOTLPRequest(e.resource, spans))
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, e.url, bytes.NewBuffer(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range e.headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("otlp endpoint responded with status code (%d)", resp.StatusCode)
+	}
+	return nil
+}
+
+// The following types model the json encoding of the OTLP ExportTraceServiceRequest
+
+type otlpRequest struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpKeyValue `json:"attributes"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name string `json:"name"`
+}
+
+type otlpSpan struct {
+	TraceID           string         `json:"traceId"`
+	SpanID            string         `json:"spanId"`
+	TraceState        string         `json:"traceState,omitempty"`
+	ParentSpanID      string         `json:"parentSpanId,omitempty"`
+	Name              string         `json:"name"`
+	Kind              SpanKind       `json:"kind"`
+	StartTimeUnixNano string         `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string         `json:"endTimeUnixNano"`
+	Attributes        []otlpKeyValue `json:"attributes,omitempty"`
+	Status            otlpStatus     `json:"status"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+type otlpKeyValue struct {
+	Key   string                 `json:"key"`
+	Value map[string]interface{} `json:"value"`
+}
+
+const (
+	otlpStatusUnset = 0
+	otlpStatusError = 2
+)
+
+func newOTLPRequest(resource []Attribute, spans []*SpanData) *otlpRequest {
+	otlpSpans := make([]otlpSpan, len(spans))
+	for i, span := range spans {
+		s := otlpSpan{
+			TraceID:           span.SpanContext.TraceID.String(),
+			SpanID:            span.SpanContext.SpanID.String(),
+			TraceState:        span.SpanContext.TraceState,
+			Name:              span.Name,
+			Kind:              span.Kind,
+			StartTimeUnixNano: strconv.FormatInt(span.StartTime.UnixNano(), 10),
+			EndTimeUnixNano:   strconv.FormatInt(span.EndTime.UnixNano(), 10),
+			Attributes:        newOTLPAttributes(span.Attributes),
+			Status:            otlpStatus{Code: otlpStatusUnset},
+		}
+		if span.ParentSpanID != (SpanID{}) {
+			s.ParentSpanID = span.ParentSpanID.String()
+		}
+		if span.IsError {
+			s.Status = otlpStatus{Code: otlpStatusError, Message: span.ErrorMessage}
+		}
+		otlpSpans[i] = s
+	}
+
+	return &otlpRequest{ResourceSpans: []otlpResourceSpans{{
+		Resource:   otlpResource{Attributes: newOTLPAttributes(resource)},
+		ScopeSpans: []otlpScopeSpans{{Scope: otlpScope{Name: otlpScopeName}, Spans: otlpSpans}},
+	}}}
+}
+
+func newOTLPAttributes(attrs []Attribute) []otlpKeyValue {
+	kvs := make([]otlpKeyValue, 0, len(attrs))
+	for _, attr := range attrs {
+		var value map[string]interface{}
+		switch v := attr.Value.(type) {
+		case string:
+			value = map[string]interface{}{"stringValue": v}
+		case bool:
+			value = map[string]interface{}{"boolValue": v}
+		case int64:
+			// 64 bit integers are encoded as strings in the json encoding of OTLP
+			value = map[string]interface{}{"intValue": strconv.FormatInt(v, 10)}
+		case int:
+			value = map[string]interface{}{"intValue": strconv.Itoa(v)}
+		case float64:
+			value = map[string]interface{}{"doubleValue": v}
+		default:
+			value = map[string]interface{}{"stringValue": fmt.Sprintf("%v", v)}
+		}
+		kvs = append(kvs, otlpKeyValue{Key: attr.Key, Value: value})
+	}
+	return kvs
+}
+
+// ParseHeaders parses the comma separated key=value pairs used to configure the headers of the otlp exporter
+func ParseHeaders(value string) (map[string]string, error) {
+	headers := map[string]string{}
+	for _, pair := range strings.Split(value, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		arr := strings.SplitN(pair, "=", 2)
+		if len(arr) != 2 || strings.TrimSpace(arr[0]) == "" {
+			return nil, fmt.Errorf("invalid otlp header (%s) provided - expected key=value", pair)
+		}
+		headers[strings.TrimSpace(arr[0])] = strings.TrimSpace(arr[1])
+	}
+	return headers, nil
+}
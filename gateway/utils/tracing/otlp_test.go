@@ -0,0 +1,117 @@
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestOTLPExporter(t *testing.T) {
+	var lock sync.Mutex
+	var requests []map[string]interface{}
+	var headers []http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/traces" || r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		body := map[string]interface{}{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+
+		lock.Lock()
+		defer lock.Unlock()
+		requests = append(requests, body)
+		headers = append(headers, r.Header)
+	}))
+	defer server.Close()
+
+	exporter := NewOTLPExporter(server.URL+"/", map[string]string{"api-key": "secret"}, String("service.name", "gateway"))
+	tracer := New(exporter, 1)
+
+	ctx, parent := tracer.StartSpan(context.Background(), "parent", SpanKindServer)
+	_, child := tracer.StartSpan(ctx, "child", SpanKindClient, String("db.statement", "SELECT 1"), Int64("db.documents", 3), Bool("cache.hit", false))
+	child.RecordError(errors.New("some error"))
+	child.End()
+	parent.End()
+
+	if err := exporter.ForceFlush(context.Background()); err != nil {
+		t.Fatalf("ForceFlush() error = %v", err)
+	}
+	if err := tracer.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+
+	lock.Lock()
+	defer lock.Unlock()
+	if len(requests) != 1 {
+		t.Fatalf("OTLPExporter sent %d requests, want 1", len(requests))
+	}
+	if got := headers[0].Get("api-key"); got != "secret" {
+		t.Errorf("OTLPExporter api-key header = %s, want secret", got)
+	}
+	if got := headers[0].Get("Content-Type"); got != "application/json" {
+		t.Errorf("OTLPExporter content type = %s, want application/json", got)
+	}
+
+	resourceSpans := requests[0]["resourceSpans"].([]interface{})[0].(map[string]interface{})
+	wantResource := map[string]interface{}{"attributes": []interface{}{map[string]interface{}{"key": "service.name", "value": map[string]interface{}{"stringValue": "gateway"}}}}
+	if !reflect.DeepEqual(resourceSpans["resource"], wantResource) {
+		t.Errorf("OTLPExporter resource = %v, want %v", resourceSpans["resource"], wantResource)
+	}
+
+	spans := resourceSpans["scopeSpans"].([]interface{})[0].(map[string]interface{})["spans"].([]interface{})
+	if len(spans) != 2 {
+		t.Fatalf("OTLPExporter exported %d spans, want 2", len(spans))
+	}
+	gotChild := spans[0].(map[string]interface{})
+	wantChild := map[string]interface{}{
+		"traceId":      child.SpanContext().TraceID.String(),
+		"spanId":       child.SpanContext().SpanID.String(),
+		"parentSpanId": parent.SpanContext().SpanID.String(),
+		"name":         "child",
+		"kind":         float64(SpanKindClient),
+		"attributes": []interface{}{
+			map[string]interface{}{"key": "db.statement", "value": map[string]interface{}{"stringValue": "SELECT 1"}},
+			map[string]interface{}{"key": "db.documents", "value": map[string]interface{}{"intValue": "3"}},
+			map[string]interface{}{"key": "cache.hit", "value": map[string]interface{}{"boolValue": false}},
+		},
+		"status": map[string]interface{}{"code": float64(otlpStatusError), "message": "some error"},
+	}
+	for k, want := range wantChild {
+		if !reflect.DeepEqual(gotChild[k], want) {
+			t.Errorf("OTLPExporter span field (%s) = %v, want %v", k, gotChild[k], want)
+		}
+	}
+	if _, ok := spans[1].(map[string]interface{})["parentSpanId"]; ok {
+		t.Error("OTLPExporter root span must not have a parent span id")
+	}
+}
+
+func TestParseHeaders(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    map[string]string
+		wantErr bool
+	}{
+		{name: "empty", value: "", want: map[string]string{}},
+		{name: "multiple headers", value: "api-key=secret, tenant = a=b", want: map[string]string{"api-key": "secret", "tenant": "a=b"}},
+		{name: "missing value", value: "api-key", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseHeaders(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseHeaders() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseHeaders() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
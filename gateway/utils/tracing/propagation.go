@@ -0,0 +1,151 @@
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+)
+
+const (
+	// HeaderTraceParent is the W3C trace context header which identifies the incoming request in a trace
+	HeaderTraceParent = "traceparent"
+
+	// HeaderTraceState is the W3C trace context header which carries vendor specific trace information
+	HeaderTraceState = "tracestate"
+
+	traceParentVersion = "00"
+	flagSampled        = 0x01
+)
+
+// TraceID uniquely identifies a trace
+type TraceID [16]byte
+
+// String returns the hex encoded trace id
+func (t TraceID) String() string {
+	return hex.EncodeToString(t[:])
+}
+
+// SpanID uniquely identifies a span within a trace
+type SpanID [8]byte
+
+// String returns the hex encoded span id
+func (s SpanID) String() string {
+	return hex.EncodeToString(s[:])
+}
+
+// SpanContext holds the part of a span which gets propagated across services
+type SpanContext struct {
+	TraceID    TraceID
+	SpanID     SpanID
+	Sampled    bool
+	TraceState string
+}
+
+// IsValid returns true if both the trace id and the span id are set
+func (sc SpanContext) IsValid() bool {
+	return sc.TraceID != TraceID{} && sc.SpanID != SpanID{}
+}
+
+// TraceParent returns the value of the traceparent header for the span context
+func (sc SpanContext) TraceParent() string {
+	var flags byte
+	if sc.Sampled {
+		flags |= flagSampled
+	}
+	return fmt.Sprintf("%s-%s-%s-%02x", traceParentVersion, sc.TraceID, sc.SpanID, flags)
+}
+
+// Inject adds the trace context headers of the active span to the provided headers
+func Inject(ctx context.Context, header http.Header) {
+	sc := SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return
+	}
+	header.Set(HeaderTraceParent, sc.TraceParent())
+	if sc.TraceState != "" {
+		header.Set(HeaderTraceState, sc.TraceState)
+	}
+}
+
+// Extract returns a context which holds the span context present in the trace context headers. The provided context
+// is returned as is if the headers are missing or invalid
+func Extract(ctx context.Context, header http.Header) context.Context {
+	sc, err := ParseTraceParent(header.Get(HeaderTraceParent))
+	if err != nil {
+		return ctx
+	}
+	sc.TraceState = header.Get(HeaderTraceState)
+	return ContextWithRemoteSpanContext(ctx, sc)
+}
+
+// ParseTraceParent parses the value of a traceparent header
+func ParseTraceParent(value string) (SpanContext, error) {
+	parts := strings.Split(strings.TrimSpace(value), "-")
+	if len(parts) < 4 {
+		return SpanContext{}, fmt.Errorf("invalid traceparent (%s) provided", value)
+	}
+
+	// Future versions may append fields, but the version itself must be valid and the first version must have exactly
+	// four fields
+	version, traceID, spanID, flags := parts[0], parts[1], parts[2], parts[3]
+	if len(version) != 2 || version == "ff" || !isLowerHex(version) || (version == traceParentVersion && len(parts) != 4) {
+		return SpanContext{}, fmt.Errorf("invalid traceparent version (%s) provided", version)
+	}
+	if len(traceID) != 32 || !isLowerHex(traceID) || len(spanID) != 16 || !isLowerHex(spanID) || len(flags) != 2 || !isLowerHex(flags) {
+		return SpanContext{}, fmt.Errorf("invalid traceparent (%s) provided", value)
+	}
+
+	var sc SpanContext
+	_, _ = hex.Decode(sc.TraceID[:], []byte(traceID))
+	_, _ = hex.Decode(sc.SpanID[:], []byte(spanID))
+	if !sc.IsValid() {
+		return SpanContext{}, fmt.Errorf("traceparent (%s) cannot have an all zero trace id or span id", value)
+	}
+
+	var f [1]byte
+	_, _ = hex.Decode(f[:], []byte(flags))
+	sc.Sampled = f[0]&flagSampled == flagSampled
+	return sc, nil
+}
+
+func isLowerHex(s string) bool {
+	for _, c := range s {
+		if !(c >= '0' && c <= '9') && !(c >= 'a' && c <= 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+func newTraceID() TraceID {
+	var id TraceID
+	for id == (TraceID{}) {
+		_, _ = rand.Read(id[:])
+	}
+	return id
+}
+
+func newSpanID() SpanID {
+	var id SpanID
+	for id == (SpanID{}) {
+		_, _ = rand.Read(id[:])
+	}
+	return id
+}
+
+// shouldSample makes the sampling decision of a root span based on the lower bits of its trace id, so that the
+// decision is consistent for a trace
+func shouldSample(id TraceID, ratio float64) bool {
+	if ratio >= 1 {
+		return true
+	}
+	if ratio <= 0 {
+		return false
+	}
+	return binary.BigEndian.Uint64(id[8:])>>1 < uint64(ratio*math.MaxInt64)
+}
@@ -0,0 +1,54 @@
+package tracing
+
+import (
+	"testing"
+)
+
+func TestParseTraceParent(t *testing.T) {
+	tests := []struct {
+		name        string
+		value       string
+		wantSampled bool
+		wantErr     bool
+	}{
+		{name: "sampled", value: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", wantSampled: true},
+		{name: "not sampled", value: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00"},
+		{name: "unknown flags are ignored", value: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-09", wantSampled: true},
+		{name: "future version with extra fields", value: "01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra", wantSampled: true},
+		{name: "empty", value: "", wantErr: true},
+		{name: "invalid version", value: "ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", wantErr: true},
+		{name: "extra fields in version 00", value: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra", wantErr: true},
+		{name: "upper case hex", value: "00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01", wantErr: true},
+		{name: "short trace id", value: "00-4bf92f3577b34da6a3ce929d0e0e47-00f067aa0ba902b7-01", wantErr: true},
+		{name: "all zero trace id", value: "00-00000000000000000000000000000000-00f067aa0ba902b7-01", wantErr: true},
+		{name: "all zero span id", value: "00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseTraceParent(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseTraceParent() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.TraceID.String() != "4bf92f3577b34da6a3ce929d0e0e4736" || got.SpanID.String() != "00f067aa0ba902b7" {
+				t.Errorf("ParseTraceParent() = %s, %s", got.TraceID, got.SpanID)
+			}
+			if got.Sampled != tt.wantSampled {
+				t.Errorf("ParseTraceParent() sampled = %v, want %v", got.Sampled, tt.wantSampled)
+			}
+		})
+	}
+}
+
+func TestSpanContext_TraceParent(t *testing.T) {
+	value := "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	sc, err := ParseTraceParent(value)
+	if err != nil {
+		t.Fatalf("ParseTraceParent() error = %v", err)
+	}
+	if got := sc.TraceParent(); got != value {
+		t.Errorf("TraceParent() = %s, want %s", got, value)
+	}
+}
@@ -0,0 +1,258 @@
+// Package tracing implements the subset of OpenTelemetry tracing used by the gateway. Spans follow the OpenTelemetry
+// data model, are correlated across services with the W3C trace context headers and can be exported to any OTLP
+// compatible collector
+package tracing
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// SpanKind describes the relationship between a span and its parent
+type SpanKind int
+
+const (
+	// SpanKindInternal is used for operations which do not cross a process boundary
+	SpanKindInternal SpanKind = iota + 1
+
+	// SpanKindServer is used for spans which handle an incoming request
+	SpanKindServer
+
+	// SpanKindClient is used for spans which make a request to a remote service or a database
+	SpanKindClient
+)
+
+// Exporter exports finished spans to a tracing backend
+type Exporter interface {
+	ExportSpans(ctx context.Context, spans []*SpanData) error
+	Shutdown(ctx context.Context) error
+}
+
+// Tracer creates spans and hands them over to the exporter once they end
+type Tracer struct {
+	exporter    Exporter
+	sampleRatio float64
+}
+
+// New creates a new tracer. Root spans are sampled with the provided ratio while child spans follow the sampling
+// decision of their parent
+func New(exporter Exporter, sampleRatio float64) *Tracer {
+	return &Tracer{exporter: exporter, sampleRatio: sampleRatio}
+}
+
+// Shutdown flushes the pending spans and shuts down the exporter
+func (t *Tracer) Shutdown(ctx context.Context) error {
+	return t.exporter.Shutdown(ctx)
+}
+
+// StartSpan starts a new span which is a child of the span present in the context. The returned context holds the
+// newly created span
+func (t *Tracer) StartSpan(ctx context.Context, name string, kind SpanKind, attrs ...Attribute) (context.Context, *Span) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	parent := SpanContextFromContext(ctx)
+
+	sc := SpanContext{SpanID: newSpanID(), TraceState: parent.TraceState}
+	if parent.IsValid() {
+		sc.TraceID = parent.TraceID
+		sc.Sampled = parent.Sampled
+	} else {
+		sc.TraceID = newTraceID()
+		sc.Sampled = shouldSample(sc.TraceID, t.sampleRatio)
+	}
+
+	span := &Span{
+		tracer:     t,
+		name:       name,
+		kind:       kind,
+		sc:         sc,
+		parent:     parent.SpanID,
+		start:      time.Now(),
+		attributes: attrs,
+	}
+	return context.WithValue(ctx, spanKey, span), span
+}
+
+// Span represents a single operation within a trace. All methods are safe to be called on a nil span
+type Span struct {
+	lock sync.Mutex
+
+	tracer     *Tracer
+	name       string
+	kind       SpanKind
+	sc         SpanContext
+	parent     SpanID
+	start      time.Time
+	attributes []Attribute
+	errMessage string
+	isError    bool
+	isEnded    bool
+}
+
+// SpanContext returns the span context of the span
+func (s *Span) SpanContext() SpanContext {
+	if s == nil {
+		return SpanContext{}
+	}
+	return s.sc
+}
+
+// SetAttributes adds attributes to the span
+func (s *Span) SetAttributes(attrs ...Attribute) {
+	if s == nil {
+		return
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.attributes = append(s.attributes, attrs...)
+}
+
+// RecordError marks the span as failed if the provided error isn't nil
+func (s *Span) RecordError(err error) {
+	if s == nil || err == nil {
+		return
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.isError = true
+	s.errMessage = err.Error()
+}
+
+// End completes the span and exports it if it was sampled. Calling end more than once has no effect
+func (s *Span) End() {
+	if s == nil {
+		return
+	}
+
+	s.lock.Lock()
+	if s.isEnded {
+		s.lock.Unlock()
+		return
+	}
+	s.isEnded = true
+	data := &SpanData{
+		Name:         s.name,
+		Kind:         s.kind,
+		SpanContext:  s.sc,
+		ParentSpanID: s.parent,
+		StartTime:    s.start,
+		EndTime:      time.Now(),
+		Attributes:   append([]Attribute{}, s.attributes...),
+		IsError:      s.isError,
+		ErrorMessage: s.errMessage,
+	}
+	s.lock.Unlock()
+
+	if !s.sc.Sampled {
+		return
+	}
+	_ = s.tracer.exporter.ExportSpans(context.Background(), []*SpanData{data})
+}
+
+// SpanData is the read only snapshot of a finished span
+type SpanData struct {
+	Name         string
+	Kind         SpanKind
+	SpanContext  SpanContext
+	ParentSpanID SpanID
+	StartTime    time.Time
+	EndTime      time.Time
+	Attributes   []Attribute
+	IsError      bool
+	ErrorMessage string
+}
+
+// Attribute returns the value of the attribute with the provided key
+func (s *SpanData) Attribute(key string) (interface{}, bool) {
+	for i := len(s.Attributes) - 1; i >= 0; i-- {
+		if s.Attributes[i].Key == key {
+			return s.Attributes[i].Value, true
+		}
+	}
+	return nil, false
+}
+
+// Attribute is a key value pair describing a span
+type Attribute struct {
+	Key   string
+	Value interface{}
+}
+
+// String creates a string attribute
+func String(key, value string) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// Int64 creates an integer attribute
+func Int64(key string, value int64) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// Bool creates a boolean attribute
+func Bool(key string, value bool) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+type contextKey int
+
+const (
+	spanKey contextKey = iota
+	remoteSpanContextKey
+)
+
+// SpanFromContext returns the span stored in the context. It returns nil if the context doesn't hold a span
+func SpanFromContext(ctx context.Context) *Span {
+	if ctx == nil {
+		return nil
+	}
+	span, _ := ctx.Value(spanKey).(*Span)
+	return span
+}
+
+// SpanContextFromContext returns the span context of the active span. The span context extracted from an incoming
+// request is returned if no span has been started locally
+func SpanContextFromContext(ctx context.Context) SpanContext {
+	if span := SpanFromContext(ctx); span != nil {
+		return span.SpanContext()
+	}
+	if ctx == nil {
+		return SpanContext{}
+	}
+	sc, _ := ctx.Value(remoteSpanContextKey).(SpanContext)
+	return sc
+}
+
+// ContextWithRemoteSpanContext returns a context which holds the span context received from a remote service
+func ContextWithRemoteSpanContext(ctx context.Context, sc SpanContext) context.Context {
+	return context.WithValue(ctx, remoteSpanContextKey, sc)
+}
+
+var globalTracer struct {
+	sync.RWMutex
+	tracer *Tracer
+}
+
+// SetTracer sets the tracer used by the gateway. Tracing is disabled if the tracer is nil
+func SetTracer(t *Tracer) {
+	globalTracer.Lock()
+	defer globalTracer.Unlock()
+	globalTracer.tracer = t
+}
+
+// GetTracer returns the tracer used by the gateway
+func GetTracer() *Tracer {
+	globalTracer.RLock()
+	defer globalTracer.RUnlock()
+	return globalTracer.tracer
+}
+
+// StartSpan starts a span using the tracer of the gateway. A nil span is returned if tracing is disabled
+func StartSpan(ctx context.Context, name string, kind SpanKind, attrs ...Attribute) (context.Context, *Span) {
+	t := GetTracer()
+	if t == nil {
+		return ctx, nil
+	}
+	return t.StartSpan(ctx, name, kind, attrs...)
+}
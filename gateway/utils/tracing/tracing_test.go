@@ -0,0 +1,136 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestTracer_StartSpan(t *testing.T) {
+	exporter := NewInMemoryExporter()
+	tracer := New(exporter, 1)
+
+	ctx, root := tracer.StartSpan(context.Background(), "root", SpanKindServer, String("key", "value"))
+	_, child := tracer.StartSpan(ctx, "child", SpanKindClient)
+	child.SetAttributes(Int64("count", 2))
+	child.RecordError(errors.New("some error"))
+	child.End()
+	child.End()
+	root.End()
+
+	spans := exporter.GetSpans()
+	if len(spans) != 2 {
+		t.Fatalf("StartSpan() exported spans = %d, want 2", len(spans))
+	}
+	gotChild, gotRoot := spans[0], spans[1]
+
+	if gotRoot.ParentSpanID != (SpanID{}) {
+		t.Errorf("StartSpan() root span has parent (%s)", gotRoot.ParentSpanID)
+	}
+	if gotChild.SpanContext.TraceID != gotRoot.SpanContext.TraceID {
+		t.Errorf("StartSpan() child trace id = %s, want %s", gotChild.SpanContext.TraceID, gotRoot.SpanContext.TraceID)
+	}
+	if gotChild.ParentSpanID != gotRoot.SpanContext.SpanID {
+		t.Errorf("StartSpan() child parent id = %s, want %s", gotChild.ParentSpanID, gotRoot.SpanContext.SpanID)
+	}
+	if v, _ := gotRoot.Attribute("key"); v != "value" {
+		t.Errorf("StartSpan() root attribute = %v, want value", v)
+	}
+	if v, _ := gotChild.Attribute("count"); v != int64(2) {
+		t.Errorf("SetAttributes() child attribute = %v, want 2", v)
+	}
+	if !gotChild.IsError || gotChild.ErrorMessage != "some error" {
+		t.Errorf("RecordError() child status = (%v, %s), want (true, some error)", gotChild.IsError, gotChild.ErrorMessage)
+	}
+	if gotRoot.IsError {
+		t.Error("StartSpan() root span must not be marked as failed")
+	}
+}
+
+func TestTracer_StartSpan_remoteParent(t *testing.T) {
+	tests := []struct {
+		name        string
+		traceParent string
+		wantSampled bool
+		wantExports int
+	}{
+		{name: "sampled parent", traceParent: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", wantSampled: true, wantExports: 1},
+		{name: "parent not sampled", traceParent: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00", wantSampled: false, wantExports: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exporter := NewInMemoryExporter()
+			header := http.Header{}
+			header.Set(HeaderTraceParent, tt.traceParent)
+			header.Set(HeaderTraceState, "vendor=value")
+
+			ctx, span := New(exporter, 1).StartSpan(Extract(context.Background(), header), "span", SpanKindServer)
+			span.End()
+
+			sc := span.SpanContext()
+			if sc.TraceID.String() != "4bf92f3577b34da6a3ce929d0e0e4736" {
+				t.Errorf("StartSpan() trace id = %s, want the trace id of the remote parent", sc.TraceID)
+			}
+			if sc.Sampled != tt.wantSampled {
+				t.Errorf("StartSpan() sampled = %v, want %v", sc.Sampled, tt.wantSampled)
+			}
+			if got := len(exporter.GetSpans()); got != tt.wantExports {
+				t.Errorf("End() exported spans = %d, want %d", got, tt.wantExports)
+			}
+
+			// The outgoing headers must identify the new span as the parent
+			out := http.Header{}
+			Inject(ctx, out)
+			if got := out.Get(HeaderTraceParent); got != sc.TraceParent() {
+				t.Errorf("Inject() traceparent = %s, want %s", got, sc.TraceParent())
+			}
+			if got := out.Get(HeaderTraceState); got != "vendor=value" {
+				t.Errorf("Inject() tracestate = %s, want vendor=value", got)
+			}
+		})
+	}
+}
+
+func TestStartSpan_disabled(t *testing.T) {
+	SetTracer(nil)
+
+	ctx := context.Background()
+	gotCtx, span := StartSpan(ctx, "span", SpanKindInternal)
+	if span != nil || gotCtx != ctx {
+		t.Fatal("StartSpan() must be a no-op when tracing is disabled")
+	}
+
+	// All methods must be safe to call on the nil span
+	span.SetAttributes(String("key", "value"))
+	span.RecordError(errors.New("some error"))
+	span.End()
+
+	header := http.Header{}
+	Inject(gotCtx, header)
+	if len(header) != 0 {
+		t.Errorf("Inject() headers = %v, want none", header)
+	}
+}
+
+func Test_shouldSample(t *testing.T) {
+	exporter := NewInMemoryExporter()
+	tracer := New(exporter, 0)
+	for i := 0; i < 10; i++ {
+		_, span := tracer.StartSpan(context.Background(), "span", SpanKindInternal)
+		span.End()
+	}
+	if got := len(exporter.GetSpans()); got != 0 {
+		t.Errorf("StartSpan() exported spans with a sample ratio of zero = %d, want 0", got)
+	}
+
+	var sampled int
+	for i := 0; i < 1000; i++ {
+		if shouldSample(newTraceID(), 0.5) {
+			sampled++
+		}
+	}
+	if sampled < 350 || sampled > 650 {
+		t.Errorf("shouldSample() sampled %d of 1000 traces with a ratio of 0.5", sampled)
+	}
+}
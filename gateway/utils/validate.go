@@ -108,13 +108,18 @@ func Validate(dbType string, where map[string]interface{}, obj interface{}) bool
 				if !ok {
 					return false
 				}
+				isMatched := false
 				for _, val := range array {
 					value := val.(map[string]interface{})
 					if Validate(dbType, value, res) {
-						return true
+						isMatched = true
+						break
 					}
 				}
-				return false
+				if !isMatched {
+					return false
+				}
+				continue
 			}
 
 			val, p := res[k]
@@ -242,7 +247,9 @@ func Validate(dbType string, where map[string]interface{}, obj interface{}) bool
 						_ = helpers.Logger.LogError(helpers.GetRequestID(context.TODO()), fmt.Sprintf("Invalid value provided for $in clause (%v)", v2), nil, nil)
 						return false
 					}
-					return ArrayContains(array, val)
+					if !ArrayContains(array, val) {
+						return false
+					}
 
 				case "$nin":
 					array, ok := v2.([]interface{})
@@ -250,7 +257,9 @@ func Validate(dbType string, where map[string]interface{}, obj interface{}) bool
 						_ = helpers.Logger.LogError(helpers.GetRequestID(context.TODO()), fmt.Sprintf("Invalid value provided for $nin clause (%v)", v2), nil, nil)
 						return false
 					}
-					return !ArrayContains(array, val)
+					if ArrayContains(array, val) {
+						return false
+					}
 
 				case "$contains":
 					switch v := v2.(type) {
@@ -260,7 +269,9 @@ func Validate(dbType string, where map[string]interface{}, obj interface{}) bool
 						if !ok {
 							return false
 						}
-						return checkIfObjContainsWhereObj(result, v, false)
+						if !checkIfObjContainsWhereObj(result, v, false) {
+							return false
+						}
 					default:
 						return false
 					}
@@ -272,7 +283,9 @@ func Validate(dbType string, where map[string]interface{}, obj interface{}) bool
 						_ = helpers.Logger.LogError(helpers.GetRequestID(context.TODO()), fmt.Sprintf("Couldn't compile regex (%s)", regex), nil, nil)
 						return false
 					}
-					return r.MatchString(vString)
+					if !r.MatchString(vString) {
+						return false
+					}
 				default:
 					log.Printf("Invalid operator (%s) provided\n", k2)
 					return false
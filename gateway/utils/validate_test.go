@@ -62,6 +62,24 @@ func TestValidate(t *testing.T) {
 			},
 			want: false,
 		},
+		{
+			name: "$or along with another field which doesn't match",
+			args: args{
+				dbType: string(model.EmbeddedDB),
+				where:  map[string]interface{}{"op1": 2, "$or": []interface{}{map[string]interface{}{"op2": 1}}},
+				obj:    map[string]interface{}{"op1": 1, "op2": 1},
+			},
+			want: false,
+		},
+		{
+			name: "$in along with another field which doesn't match",
+			args: args{
+				dbType: string(model.EmbeddedDB),
+				where:  map[string]interface{}{"op1": map[string]interface{}{"$in": []interface{}{1, 2}}, "op2": 2},
+				obj:    map[string]interface{}{"op1": 1, "op2": 1},
+			},
+			want: false,
+		},
 		{
 			name: "test4",
 			args: args{
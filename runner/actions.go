@@ -29,6 +29,7 @@ func actionRunner(c *cli.Context) error {
 	// Get driver config
 	driverType := c.String("driver")
 	driverConfig := c.String("driver-config")
+	statePath := c.String("state-path")
 	outsideCluster := c.Bool("outside-cluster")
 
 	isDev := c.Bool("dev")
@@ -60,6 +61,7 @@ func actionRunner(c *cli.Context) error {
 			IsInCluster:    !outsideCluster,
 			PrometheusAddr: prometheusAddr,
 			ClusterName:    clusterName,
+			StatePath:      statePath,
 		},
 	})
 	if err != nil {
@@ -4,6 +4,8 @@ go 1.15
 
 require (
 	github.com/AlecAivazis/survey/v2 v2.0.7
+	github.com/docker/docker v17.12.0-ce-rc1.0.20200618181300-9dc6525e6118+incompatible
+	github.com/docker/go-connections v0.4.0
 	github.com/ghodss/yaml v1.0.0
 	github.com/go-redis/redis/v8 v8.3.3
 	github.com/go-test/deep v1.0.4
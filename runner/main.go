@@ -102,6 +102,11 @@ func main() {
 					EnvVar: "DRIVER_CONFIG",
 					Usage:  "Driver config file path",
 				},
+				cli.StringFlag{
+					Name:   "state-path",
+					EnvVar: "STATE_PATH",
+					Usage:  "The directory used by the docker driver to store its state. It defaults to ~/.space-cloud/<cluster-name>",
+				},
 				cli.StringFlag{
 					Name:   "prometheus-addr",
 					EnvVar: "PROMETHEUS_ADDR",
@@ -0,0 +1,285 @@
+package docker
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/client"
+	"github.com/docker/go-connections/nat"
+	"github.com/spaceuptech/helpers"
+
+	"github.com/spaceuptech/space-cloud/runner/model"
+	"github.com/spaceuptech/space-cloud/runner/utils"
+)
+
+// ApplyService deploys the service on docker. Every replica of a service runs one container per task. The
+// first task joins the cluster network while the remaining tasks share its network namespace
+func (d *Docker) ApplyService(ctx context.Context, service *model.Service) error {
+	if len(service.Tasks) == 0 {
+		return helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Service (%s) must have at least one task", service.ID), nil, nil)
+	}
+
+	if err := d.createNetworkIfNotExist(ctx); err != nil {
+		return err
+	}
+
+	// Get the list of secrets required for this service
+	listOfSecrets, err := d.getSecrets(ctx, service)
+	if err != nil {
+		return err
+	}
+
+	// Make sure the images of all tasks are available locally
+	for _, task := range service.Tasks {
+		if err := d.pullImageIfRequired(ctx, task, listOfSecrets); err != nil {
+			return err
+		}
+	}
+
+	// Remove the containers of the previous deployment of this version
+	if err := d.DeleteService(ctx, service.ProjectID, service.ID, service.Version); err != nil {
+		return err
+	}
+
+	// The general service domain is assigned to the version receiving the highest weight
+	routes, err := d.getOrCreateServiceRoutes(service)
+	if err != nil {
+		return helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Unable to get routes of service (%s)", service.ID), err, nil)
+	}
+	aliases := getServiceAliases(service.ProjectID, service.ID, service.Version, getActiveVersion(routes))
+
+	spec, err := json.Marshal(service)
+	if err != nil {
+		return err
+	}
+
+	replicas := getReplicaCount(service)
+	for replica := 0; replica < max(replicas, 1); replica++ {
+		var primaryContainerID string
+		for i, task := range service.Tasks {
+			containerConfig, hostConfig, err := d.generateContainerConfig(service, task, replica, listOfSecrets)
+			if err != nil {
+				return err
+			}
+			containerConfig.Labels["spec"] = string(spec)
+
+			networkConfig := &network.NetworkingConfig{}
+			if i == 0 {
+				containerConfig.Labels["primary"] = "true"
+				hostConfig.NetworkMode = container.NetworkMode(getNetworkName(d.config.ClusterName))
+				networkConfig.EndpointsConfig = map[string]*network.EndpointSettings{getNetworkName(d.config.ClusterName): {Aliases: aliases}}
+			} else {
+				containerConfig.Labels["primary"] = "false"
+				hostConfig.NetworkMode = container.NetworkMode("container:" + primaryContainerID)
+			}
+
+			name := getContainerName(d.config.ClusterName, service.ProjectID, service.ID, service.Version, task.ID, replica)
+			helpers.Logger.LogDebug(helpers.GetRequestID(ctx), fmt.Sprintf("Creating container (%s)", name), nil)
+			res, err := d.client.ContainerCreate(ctx, containerConfig, hostConfig, networkConfig, name)
+			if err != nil {
+				return helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Unable to create container (%s)", name), err, nil)
+			}
+			if i == 0 {
+				primaryContainerID = res.ID
+			}
+
+			// Containers of services scaled down to zero are only started when the service is scaled up
+			if replicas == 0 {
+				continue
+			}
+			if err := d.client.ContainerStart(ctx, res.ID, types.ContainerStartOptions{}); err != nil {
+				return helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Unable to start container (%s)", name), err, nil)
+			}
+		}
+	}
+
+	helpers.Logger.LogInfo(helpers.GetRequestID(ctx), fmt.Sprintf("Service (%s:%s) applied successfully", service.ProjectID, service.ID), nil)
+	return nil
+}
+
+// ApplyServiceRole is not supported by the docker driver since docker has no notion of roles
+func (d *Docker) ApplyServiceRole(ctx context.Context, role *model.Role) error {
+	return helpers.Logger.LogError(helpers.GetRequestID(ctx), "Service roles are not supported by the docker driver", nil, nil)
+}
+
+func (d *Docker) generateContainerConfig(service *model.Service, task model.Task, replica int, listOfSecrets map[string]*model.Secret) (*container.Config, *container.HostConfig, error) {
+	// Prepare the environment variables. Env secrets are exposed as environment variables as well
+	env := []string{
+		"SC_PROJECT_ID=" + service.ProjectID,
+		"SC_SERVICE_ID=" + service.ID,
+		"SC_VERSION=" + service.Version,
+	}
+	for k, v := range task.Env {
+		env = append(env, fmt.Sprintf("%s=%s", k, v))
+	}
+
+	var mounts []mount.Mount
+	for _, secretName := range task.Secrets {
+		secret := listOfSecrets[secretName]
+		switch secret.Type {
+		case model.EnvType:
+			for k, v := range secret.Data {
+				env = append(env, fmt.Sprintf("%s=%s", k, v))
+			}
+
+		case model.FileType:
+			// File secrets are written to a temporary directory which gets mounted in the container
+			if err := validateNames("service name", service.ProjectID, service.ID, service.Version); err != nil {
+				return nil, nil, helpers.Logger.LogError(helpers.GetRequestID(context.TODO()), fmt.Sprintf("Unable to write file secret (%s)", secret.ID), err, nil)
+			}
+			dir := getTempSecretsDir(d.config.StatePath, service.ProjectID, service.ID, service.Version, secret.ID)
+			if err := writeFileSecret(dir, secret); err != nil {
+				return nil, nil, helpers.Logger.LogError(helpers.GetRequestID(context.TODO()), fmt.Sprintf("Unable to write file secret (%s)", secret.ID), err, nil)
+			}
+
+			rootPath := secret.RootPath
+			if rootPath == "" {
+				rootPath = "/secrets/" + secret.ID
+			}
+			mounts = append(mounts, mount.Mount{Type: mount.TypeBind, Source: dir, Target: rootPath, ReadOnly: true})
+		}
+	}
+
+	exposedPorts := nat.PortSet{}
+	for _, port := range task.Ports {
+		exposedPorts[nat.Port(fmt.Sprintf("%d/tcp", port.Port))] = struct{}{}
+	}
+
+	containerConfig := &container.Config{
+		Image:        task.Docker.Image,
+		Cmd:          task.Docker.Cmd,
+		Env:          env,
+		ExposedPorts: exposedPorts,
+		Labels: map[string]string{
+			"app":     "service",
+			"cluster": d.config.ClusterName,
+			"project": service.ProjectID,
+			"service": service.ID,
+			"version": service.Version,
+			"task":    task.ID,
+			"replica": strconv.Itoa(replica),
+		},
+	}
+
+	// Resources are treated as reservations just like the requests made by the istio driver. The
+	// cpu is provided in milli cores and the memory in mega bytes
+	resources := task.Resources
+	if resources.Memory == 0 || resources.CPU == 0 {
+		resources.Memory = 512
+		resources.CPU = 250
+	}
+	hostConfig := &container.HostConfig{
+		Mounts:        mounts,
+		RestartPolicy: container.RestartPolicy{Name: "unless-stopped"},
+		Resources: container.Resources{
+			CPUShares:         resources.CPU * 1024 / 1000,
+			MemoryReservation: resources.Memory * 1024 * 1024,
+		},
+	}
+
+	return containerConfig, hostConfig, nil
+}
+
+func (d *Docker) pullImageIfRequired(ctx context.Context, task model.Task, listOfSecrets map[string]*model.Secret) error {
+	if task.Docker.ImagePullPolicy != model.PullAlways {
+		if _, _, err := d.client.ImageInspectWithRaw(ctx, task.Docker.Image); err == nil {
+			return nil
+		} else if !client.IsErrNotFound(err) {
+			return helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Unable to inspect image (%s)", task.Docker.Image), err, nil)
+		}
+	}
+
+	options := types.ImagePullOptions{}
+	if task.Docker.Secret != "" {
+		secret := listOfSecrets[task.Docker.Secret]
+		if secret.Type != model.DockerType {
+			return helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Secret (%s) used to pull image (%s) must be of type docker", secret.ID, task.Docker.Image), nil, nil)
+		}
+		auth, err := json.Marshal(types.AuthConfig{Username: secret.Data["username"], Password: secret.Data["password"], ServerAddress: secret.Data["url"]})
+		if err != nil {
+			return err
+		}
+		options.RegistryAuth = base64.URLEncoding.EncodeToString(auth)
+	}
+
+	helpers.Logger.LogDebug(helpers.GetRequestID(ctx), fmt.Sprintf("Pulling image (%s)", task.Docker.Image), nil)
+	out, err := d.client.ImagePull(ctx, task.Docker.Image, options)
+	if err != nil {
+		return helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Unable to pull image (%s)", task.Docker.Image), err, nil)
+	}
+	defer utils.CloseTheCloser(out)
+
+	// The image is pulled only once the entire response has been read
+	_, err = io.Copy(ioutil.Discard, out)
+	return err
+}
+
+func (d *Docker) removeContainers(ctx context.Context, args ...filters.KeyValuePair) error {
+	containers, err := d.listContainers(ctx, args...)
+	if err != nil {
+		return err
+	}
+
+	// Remove the containers sharing the network of the primary container first
+	for _, primary := range []string{"false", "true"} {
+		for _, c := range containers {
+			if c.Labels["primary"] != primary {
+				continue
+			}
+			if err := d.client.ContainerRemove(ctx, c.ID, types.ContainerRemoveOptions{Force: true}); err != nil && !client.IsErrNotFound(err) {
+				return helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Unable to remove container (%s)", c.ID), err, nil)
+			}
+		}
+	}
+	return nil
+}
+
+func writeFileSecret(dir string, secret *model.Secret) error {
+	if err := os.RemoveAll(dir); err != nil {
+		return err
+	}
+	// The files need to be readable by the user the container runs as
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	for k, v := range secret.Data {
+		if err := validateNames("file secret key", k); err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(filepath.Join(dir, k), []byte(v), 0444); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func getReplicaCount(service *model.Service) int {
+	if service.Scale == nil {
+		return 1
+	}
+
+	replicas := service.Scale.Replicas
+	if replicas < service.Scale.MinReplicas {
+		replicas = service.Scale.MinReplicas
+	}
+	return int(replicas)
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
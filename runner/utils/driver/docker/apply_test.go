@@ -0,0 +1,315 @@
+package docker
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/docker/docker/api/types/mount"
+
+	"github.com/spaceuptech/space-cloud/runner/model"
+)
+
+func getTestService(version string) *model.Service {
+	return &model.Service{
+		ID:        "greeter",
+		ProjectID: "myproject",
+		Version:   version,
+		Scale:     &model.ScaleConfig{Replicas: 2},
+		Tasks: []model.Task{
+			{
+				ID:      "app",
+				Ports:   []model.Port{{Name: "http", Protocol: model.HTTP, Port: 8080}},
+				Docker:  model.Docker{Image: "greeter:" + version, Cmd: []string{"./app"}},
+				Env:     map[string]string{"MODE": "test"},
+				Secrets: []string{"env-secret", "file-secret"},
+			},
+			{
+				ID:     "sidecar",
+				Docker: model.Docker{Image: "sidecar:latest"},
+			},
+		},
+	}
+}
+
+func TestDocker_ApplyService(t *testing.T) {
+	d, fake := newTestDriver(t)
+	ctx := context.Background()
+
+	if err := d.CreateSecret(ctx, "myproject", &model.Secret{ID: "env-secret", Type: model.EnvType, Data: map[string]string{"PASSWORD": "pass"}}); err != nil {
+		t.Fatalf("CreateSecret() error = %v", err)
+	}
+	if err := d.CreateSecret(ctx, "myproject", &model.Secret{ID: "file-secret", Type: model.FileType, RootPath: "/etc/config", Data: map[string]string{"config.json": "{}"}}); err != nil {
+		t.Fatalf("CreateSecret() error = %v", err)
+	}
+
+	service := getTestService("v1")
+	if err := d.ApplyService(ctx, service); err != nil {
+		t.Fatalf("ApplyService() error = %v", err)
+	}
+
+	// Images should have been pulled
+	if want := []string{"greeter:v1", "sidecar:latest"}; !reflect.DeepEqual(fake.pulls, want) {
+		t.Errorf("ApplyService() pulled images = %v, want %v", fake.pulls, want)
+	}
+	if !fake.networks["space-cloud"] {
+		t.Errorf("ApplyService() did not create the network")
+	}
+
+	containers := fake.getContainersByName()
+	if len(containers) != 4 {
+		t.Fatalf("ApplyService() created %d containers, want 4", len(containers))
+	}
+
+	// Check the primary container
+	primary, p := containers["space-cloud--myproject--greeter--v1--app--0"]
+	if !p {
+		t.Fatalf("ApplyService() primary container not found in %v", containers)
+	}
+	if primary.State != "running" {
+		t.Errorf("ApplyService() primary container state = %s, want running", primary.State)
+	}
+	env := primary.Config.Env
+	sort.Strings(env)
+	if want := []string{"MODE=test", "PASSWORD=pass", "SC_PROJECT_ID=myproject", "SC_SERVICE_ID=greeter", "SC_VERSION=v1"}; !reflect.DeepEqual(env, want) {
+		t.Errorf("ApplyService() env = %v, want %v", env, want)
+	}
+	if len(primary.HostConfig.Mounts) != 1 || primary.HostConfig.Mounts[0].Target != "/etc/config" || primary.HostConfig.Mounts[0].Type != mount.TypeBind {
+		t.Errorf("ApplyService() mounts = %v, want file secret mounted at /etc/config", primary.HostConfig.Mounts)
+	} else if data, err := ioutil.ReadFile(primary.HostConfig.Mounts[0].Source + "/config.json"); err != nil || string(data) != "{}" {
+		t.Errorf("ApplyService() file secret content = %s (%v), want {}", string(data), err)
+	}
+	aliases := primary.Networks["space-cloud"].Aliases
+	if want := []string{"greeter.myproject-v1.svc.cluster.local", "greeter.myproject.svc.cluster.local"}; !reflect.DeepEqual(aliases, want) {
+		t.Errorf("ApplyService() aliases = %v, want %v", aliases, want)
+	}
+
+	// Check the sidecar container shares the network of the primary container
+	sidecar := containers["space-cloud--myproject--greeter--v1--sidecar--0"]
+	if want := "container:" + primary.ID; string(sidecar.HostConfig.NetworkMode) != want {
+		t.Errorf("ApplyService() sidecar network mode = %s, want %s", sidecar.HostConfig.NetworkMode, want)
+	}
+
+	// Services should be returned as they were applied
+	services, err := d.GetServices(ctx, "myproject")
+	if err != nil {
+		t.Fatalf("GetServices() error = %v", err)
+	}
+	if len(services) != 1 || !reflect.DeepEqual(services[0], service) {
+		t.Errorf("GetServices() = %v, want %v", services, []*model.Service{service})
+	}
+
+	// Check the status of the service
+	status, err := d.GetServiceStatus(ctx, "myproject")
+	if err != nil {
+		t.Fatalf("GetServiceStatus() error = %v", err)
+	}
+	replicas := int32(2)
+	wantStatus := []*model.ServiceStatus{{
+		ServiceID:       "greeter",
+		Version:         "v1",
+		DesiredReplicas: &replicas,
+		Replicas: []*model.ReplicaInfo{
+			{ID: "space-cloud--myproject--greeter--v1--app--0", Status: "RUNNING"},
+			{ID: "space-cloud--myproject--greeter--v1--app--1", Status: "RUNNING"},
+		},
+	}}
+	if !reflect.DeepEqual(status, wantStatus) {
+		t.Errorf("GetServiceStatus() = %v, want %v", status, wantStatus)
+	}
+
+	// Applying the service again should replace the existing containers
+	if err := d.ApplyService(ctx, service); err != nil {
+		t.Fatalf("ApplyService() error = %v", err)
+	}
+	if got := len(fake.getContainersByName()); got != 4 {
+		t.Errorf("ApplyService() containers after re-apply = %d, want 4", got)
+	}
+
+	// Delete the service
+	if err := d.DeleteService(ctx, "myproject", "greeter", "v1"); err != nil {
+		t.Fatalf("DeleteService() error = %v", err)
+	}
+	if got := len(fake.getContainersByName()); got != 0 {
+		t.Errorf("DeleteService() containers left = %d, want 0", got)
+	}
+}
+
+func TestDocker_DeleteProject(t *testing.T) {
+	d, fake := newTestDriver(t)
+	ctx := context.Background()
+
+	if err := d.CreateSecret(ctx, "myproject", &model.Secret{ID: "env-secret", Type: model.EnvType, Data: map[string]string{"PASSWORD": "pass"}}); err != nil {
+		t.Fatalf("CreateSecret() error = %v", err)
+	}
+	if err := d.CreateSecret(ctx, "myproject", &model.Secret{ID: "file-secret", Type: model.FileType, RootPath: "/etc/config", Data: map[string]string{"config.json": "{}"}}); err != nil {
+		t.Fatalf("CreateSecret() error = %v", err)
+	}
+	if err := d.ApplyService(ctx, getTestService("v1")); err != nil {
+		t.Fatalf("ApplyService() error = %v", err)
+	}
+	mounts := fake.getContainersByName()["space-cloud--myproject--greeter--v1--app--0"].HostConfig.Mounts
+	if len(mounts) != 1 {
+		t.Fatalf("ApplyService() mounts = %v, want the file secret to be mounted", mounts)
+	}
+	source := mounts[0].Source
+
+	// A project whose id matches the directory of the file secrets mustn't affect the other projects
+	if err := d.DeleteProject(ctx, "temp-secrets"); err != nil {
+		t.Fatalf("DeleteProject() error = %v", err)
+	}
+	if _, err := os.Stat(source); err != nil {
+		t.Errorf("DeleteProject() removed the file secrets of another project - %v", err)
+	}
+
+	if err := d.DeleteProject(ctx, "myproject"); err != nil {
+		t.Fatalf("DeleteProject() error = %v", err)
+	}
+	if got := len(fake.getContainersByName()); got != 0 {
+		t.Errorf("DeleteProject() containers left = %d, want 0", got)
+	}
+	if _, err := os.Stat(source); !os.IsNotExist(err) {
+		t.Errorf("DeleteProject() didn't remove the file secrets mounted in the services - %v", err)
+	}
+	if secrets, err := d.ListSecrets(ctx, "myproject"); err != nil || len(secrets) != 0 {
+		t.Errorf("DeleteProject() secrets left = %v (%v), want none", secrets, err)
+	}
+}
+
+func TestDocker_ApplyService_missingSecret(t *testing.T) {
+	d, fake := newTestDriver(t)
+
+	if err := d.ApplyService(context.Background(), getTestService("v1")); err == nil {
+		t.Errorf("ApplyService() expected error for missing secrets")
+	}
+	if got := len(fake.getContainersByName()); got != 0 {
+		t.Errorf("ApplyService() created %d containers, want 0", got)
+	}
+}
+
+func TestDocker_ScaleUp(t *testing.T) {
+	d, fake := newTestDriver(t)
+	ctx := context.Background()
+
+	service := getTestService("v1")
+	service.Scale = &model.ScaleConfig{Replicas: 0}
+	service.Tasks[0].Secrets = nil
+	if err := d.ApplyService(ctx, service); err != nil {
+		t.Fatalf("ApplyService() error = %v", err)
+	}
+
+	for name, c := range fake.getContainersByName() {
+		if c.State != "created" {
+			t.Errorf("ApplyService() container (%s) state = %s, want created", name, c.State)
+		}
+	}
+
+	if err := d.ScaleUp(ctx, "myproject", "greeter", "v1"); err != nil {
+		t.Fatalf("ScaleUp() error = %v", err)
+	}
+	if err := d.WaitForService(ctx, service); err != nil {
+		t.Fatalf("WaitForService() error = %v", err)
+	}
+	for name, c := range fake.getContainersByName() {
+		if c.State != "running" {
+			t.Errorf("ScaleUp() container (%s) state = %s, want running", name, c.State)
+		}
+	}
+}
+
+func TestDocker_ApplyServiceRoutes(t *testing.T) {
+	d, fake := newTestDriver(t)
+	ctx := context.Background()
+
+	for _, version := range []string{"v1", "v2"} {
+		service := getTestService(version)
+		service.Scale = &model.ScaleConfig{Replicas: 1}
+		service.Tasks[0].Secrets = nil
+		if err := d.ApplyService(ctx, service); err != nil {
+			t.Fatalf("ApplyService() error = %v", err)
+		}
+	}
+
+	// The first version receives all the traffic by default
+	routes, err := d.GetServiceRoutes(ctx, "myproject")
+	if err != nil {
+		t.Fatalf("GetServiceRoutes() error = %v", err)
+	}
+	if got := getActiveVersion(routes["greeter"]); got != "v1" {
+		t.Errorf("GetServiceRoutes() active version = %s, want v1", got)
+	}
+
+	newRoutes := model.Routes{{
+		ID:     "greeter-8080",
+		Source: model.RouteSource{Port: 8080},
+		Targets: []model.RouteTarget{
+			{Type: model.RouteTargetVersion, Version: "v1", Port: 8080, Weight: 20},
+			{Type: model.RouteTargetVersion, Version: "v2", Port: 8080, Weight: 80},
+		},
+	}}
+	if err := d.ApplyServiceRoutes(ctx, "myproject", "greeter", newRoutes); err != nil {
+		t.Fatalf("ApplyServiceRoutes() error = %v", err)
+	}
+
+	containers := fake.getContainersByName()
+	if got, want := containers["space-cloud--myproject--greeter--v1--app--0"].Networks["space-cloud"].Aliases, []string{"greeter.myproject-v1.svc.cluster.local"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ApplyServiceRoutes() v1 aliases = %v, want %v", got, want)
+	}
+	if got, want := containers["space-cloud--myproject--greeter--v2--app--0"].Networks["space-cloud"].Aliases, []string{"greeter.myproject-v2.svc.cluster.local", "greeter.myproject.svc.cluster.local"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ApplyServiceRoutes() v2 aliases = %v, want %v", got, want)
+	}
+
+	routes, err = d.GetServiceRoutes(ctx, "myproject")
+	if err != nil {
+		t.Fatalf("GetServiceRoutes() error = %v", err)
+	}
+	if !reflect.DeepEqual(routes, map[string]model.Routes{"greeter": newRoutes}) {
+		t.Errorf("GetServiceRoutes() = %v, want %v", routes, newRoutes)
+	}
+
+	// Routes without a port must be rejected
+	if err := d.ApplyServiceRoutes(ctx, "myproject", "greeter", model.Routes{{ID: "invalid", Targets: newRoutes[0].Targets}}); err == nil {
+		t.Errorf("ApplyServiceRoutes() expected error for route without port")
+	}
+}
+
+func TestDocker_GetLogs(t *testing.T) {
+	d, fake := newTestDriver(t)
+	ctx := context.Background()
+
+	service := getTestService("v1")
+	service.Tasks[0].Secrets = nil
+	if err := d.ApplyService(ctx, service); err != nil {
+		t.Fatalf("ApplyService() error = %v", err)
+	}
+	fake.logs = "line 1\nline 2\n"
+
+	tests := []struct {
+		name    string
+		info    *model.LogRequest
+		wantErr bool
+	}{
+		{name: "primary task", info: &model.LogRequest{ReplicaID: "space-cloud--myproject--greeter--v1--app--1"}},
+		{name: "other task", info: &model.LogRequest{ReplicaID: "space-cloud--myproject--greeter--v1--app--1", TaskID: "sidecar"}},
+		{name: "unknown replica", info: &model.LogRequest{ReplicaID: "space-cloud--myproject--greeter--v1--app--5"}, wantErr: true},
+		{name: "unknown task", info: &model.LogRequest{ReplicaID: "space-cloud--myproject--greeter--v1--app--1", TaskID: "unknown"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := d.GetLogs(ctx, "myproject", tt.info)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetLogs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			data, _ := ioutil.ReadAll(r)
+			if string(data) != fake.logs {
+				t.Errorf("GetLogs() = %q, want %q", string(data), fake.logs)
+			}
+		})
+	}
+}
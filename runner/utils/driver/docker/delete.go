@@ -0,0 +1,20 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/docker/docker/api/types/filters"
+	"github.com/spaceuptech/helpers"
+)
+
+// DeleteService removes every container of the provided version of a service
+func (d *Docker) DeleteService(ctx context.Context, projectID, serviceID, version string) error {
+	helpers.Logger.LogDebug(helpers.GetRequestID(ctx), fmt.Sprintf("Removing containers of service (%s:%s:%s)", projectID, serviceID, version), nil)
+	return d.removeContainers(ctx, filters.Arg("label", "project="+projectID), filters.Arg("label", "service="+serviceID), filters.Arg("label", "version="+version))
+}
+
+// DeleteServiceRole is not supported by the docker driver since docker has no notion of roles
+func (d *Docker) DeleteServiceRole(ctx context.Context, projectID, serviceID, id string) error {
+	return helpers.Logger.LogError(helpers.GetRequestID(ctx), "Service roles are not supported by the docker driver", nil, nil)
+}
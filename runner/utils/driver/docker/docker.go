@@ -0,0 +1,145 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/client"
+	"github.com/spaceuptech/helpers"
+
+	"github.com/spaceuptech/space-cloud/runner/model"
+	"github.com/spaceuptech/space-cloud/runner/utils/auth"
+)
+
+// Config describes the configuration required by the docker driver
+type Config struct {
+	// ClusterName is used to uniquely identify the containers and network of a docker cluster
+	ClusterName string
+
+	// StatePath is the directory used to persist secrets and service routes. It defaults to `~/.space-cloud/<cluster>`.
+	// File secrets are bind mounted from this directory, so the docker daemon must be able to access it at the same path
+	StatePath string
+
+	// Host is the address of the docker daemon. The environment (DOCKER_HOST) is used when left empty
+	Host string
+}
+
+// Docker manages the docker deployment target
+type Docker struct {
+	lock sync.Mutex
+
+	// For internal use
+	auth   *auth.Module
+	config *Config
+
+	// Client to talk to the docker daemon
+	client *client.Client
+}
+
+// NewDockerDriver creates a new instance of the docker driver
+func NewDockerDriver(auth *auth.Module, c *Config) (*Docker, error) {
+	if c.ClusterName == "" {
+		c.ClusterName = "default"
+	}
+	if c.StatePath == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, helpers.Logger.LogError(helpers.GetRequestID(context.TODO()), "Unable to get home directory for docker driver", err, nil)
+		}
+		c.StatePath = filepath.Join(home, ".space-cloud", c.ClusterName)
+	}
+
+	opts := []client.Opt{client.FromEnv, client.WithAPIVersionNegotiation()}
+	if c.Host != "" {
+		opts = append(opts, client.WithHost(c.Host))
+	}
+
+	cli, err := client.NewClientWithOpts(opts...)
+	if err != nil {
+		return nil, helpers.Logger.LogError(helpers.GetRequestID(context.TODO()), "Unable to initialize docker client", err, nil)
+	}
+
+	if err := os.MkdirAll(getSecretsDir(c.StatePath), 0700); err != nil {
+		return nil, helpers.Logger.LogError(helpers.GetRequestID(context.TODO()), fmt.Sprintf("Unable to create secrets directory in (%s)", c.StatePath), err, nil)
+	}
+
+	return &Docker{auth: auth, config: c, client: cli}, nil
+}
+
+// Type returns the type of the driver
+func (d *Docker) Type() model.DriverType {
+	return model.TypeDocker
+}
+
+// CreateProject creates the docker network used by the services if it doesn't already exist
+func (d *Docker) CreateProject(ctx context.Context, project *model.Project) error {
+	return d.createNetworkIfNotExist(ctx)
+}
+
+// DeleteProject removes all the containers, routes and secrets of a project
+func (d *Docker) DeleteProject(ctx context.Context, projectID string) error {
+	if err := validateNames("project id", projectID); err != nil {
+		return helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Unable to delete project (%s)", projectID), err, nil)
+	}
+
+	containers, err := d.listContainers(ctx, filters.Arg("label", "project="+projectID))
+	if err != nil {
+		return err
+	}
+
+	for _, container := range containers {
+		if err := d.client.ContainerRemove(ctx, container.ID, types.ContainerRemoveOptions{Force: true}); err != nil {
+			return helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Unable to remove container (%s) of project (%s)", container.ID, projectID), err, nil)
+		}
+	}
+
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	routes, err := d.readRoutes()
+	if err != nil {
+		return err
+	}
+	delete(routes, projectID)
+	if err := d.writeRoutes(routes); err != nil {
+		return err
+	}
+
+	if err := os.RemoveAll(getProjectSecretsDir(d.config.StatePath, projectID)); err != nil {
+		return helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Unable to remove secrets of project (%s)", projectID), err, nil)
+	}
+	if err := os.RemoveAll(getProjectTempSecretsDir(d.config.StatePath, projectID)); err != nil {
+		return helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Unable to remove file secrets mounted in the services of project (%s)", projectID), err, nil)
+	}
+	return nil
+}
+
+// listContainers lists all containers of services managed by this driver matching the provided filters
+func (d *Docker) listContainers(ctx context.Context, args ...filters.KeyValuePair) ([]types.Container, error) {
+	args = append(args, filters.Arg("label", "app=service"), filters.Arg("label", "cluster="+d.config.ClusterName))
+	containers, err := d.client.ContainerList(ctx, types.ContainerListOptions{All: true, Filters: filters.NewArgs(args...)})
+	if err != nil {
+		return nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to list containers", err, nil)
+	}
+	return containers, nil
+}
+
+func (d *Docker) createNetworkIfNotExist(ctx context.Context) error {
+	name := getNetworkName(d.config.ClusterName)
+	if _, err := d.client.NetworkInspect(ctx, name, types.NetworkInspectOptions{}); err == nil {
+		return nil
+	} else if !client.IsErrNotFound(err) {
+		return helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Unable to inspect network (%s)", name), err, nil)
+	}
+
+	helpers.Logger.LogDebug(helpers.GetRequestID(ctx), fmt.Sprintf("Creating docker network (%s)", name), nil)
+	if _, err := d.client.NetworkCreate(ctx, name, types.NetworkCreate{Driver: "bridge", CheckDuplicate: true}); err != nil {
+		return helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Unable to create network (%s)", name), err, nil)
+	}
+	return nil
+}
@@ -0,0 +1,218 @@
+package docker
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/client"
+	"github.com/docker/docker/pkg/stdcopy"
+)
+
+// fakeContainer is a container stored by the fake docker daemon
+type fakeContainer struct {
+	ID         string
+	Name       string
+	State      string
+	Config     *container.Config
+	HostConfig *container.HostConfig
+	Networks   map[string]*network.EndpointSettings
+}
+
+// fakeDocker is an in memory implementation of the parts of the docker engine api used by the driver
+type fakeDocker struct {
+	lock       sync.Mutex
+	containers map[string]*fakeContainer
+	networks   map[string]bool
+	images     map[string]bool
+	pulls      []string
+	logs       string
+	counter    int
+}
+
+func newFakeDocker() *fakeDocker {
+	return &fakeDocker{containers: map[string]*fakeContainer{}, networks: map[string]bool{}, images: map[string]bool{}}
+}
+
+// newTestDriver starts a fake docker daemon and returns a driver connected to it
+func newTestDriver(t *testing.T) (*Docker, *fakeDocker) {
+	fake := newFakeDocker()
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	cli, err := client.NewClientWithOpts(client.WithHost("tcp://"+strings.TrimPrefix(server.URL, "http://")), client.WithVersion("1.40"))
+	if err != nil {
+		t.Fatalf("Unable to create docker client - %v", err)
+	}
+
+	return &Docker{config: &Config{ClusterName: "default", StatePath: t.TempDir()}, client: cli}, fake
+}
+
+func (f *fakeDocker) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	path := strings.TrimPrefix(r.URL.Path, "/v1.40")
+	arr := strings.Split(strings.Trim(path, "/"), "/")
+
+	switch {
+	case r.Method == http.MethodGet && path == "/containers/json":
+		f.listContainers(w, r)
+
+	case r.Method == http.MethodPost && path == "/containers/create":
+		c := new(struct {
+			*container.Config
+			HostConfig       *container.HostConfig
+			NetworkingConfig *network.NetworkingConfig
+		})
+		_ = json.NewDecoder(r.Body).Decode(c)
+		f.counter++
+		id := fmt.Sprintf("container-%d", f.counter)
+		networks := map[string]*network.EndpointSettings{}
+		if c.NetworkingConfig != nil {
+			networks = c.NetworkingConfig.EndpointsConfig
+		}
+		f.containers[id] = &fakeContainer{ID: id, Name: "/" + r.URL.Query().Get("name"), State: "created", Config: c.Config, HostConfig: c.HostConfig, Networks: networks}
+		_ = json.NewEncoder(w).Encode(container.ContainerCreateCreatedBody{ID: id})
+
+	case len(arr) == 3 && arr[0] == "containers" && arr[2] == "start":
+		c, p := f.containers[arr[1]]
+		if !p {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		c.State = "running"
+		w.WriteHeader(http.StatusNoContent)
+
+	case len(arr) == 3 && arr[0] == "containers" && arr[2] == "json":
+		c, p := f.containers[arr[1]]
+		if !p {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(types.ContainerJSON{
+			ContainerJSONBase: &types.ContainerJSONBase{ID: c.ID, Name: c.Name, State: &types.ContainerState{Status: c.State}, HostConfig: c.HostConfig},
+			Config:            c.Config,
+			NetworkSettings:   &types.NetworkSettings{Networks: c.Networks},
+		})
+
+	case len(arr) == 3 && arr[0] == "containers" && arr[2] == "logs":
+		w.WriteHeader(http.StatusOK)
+		_, _ = stdcopy.NewStdWriter(w, stdcopy.Stdout).Write([]byte(f.logs))
+
+	case r.Method == http.MethodDelete && len(arr) == 2 && arr[0] == "containers":
+		if _, p := f.containers[arr[1]]; !p {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		delete(f.containers, arr[1])
+		w.WriteHeader(http.StatusNoContent)
+
+	case r.Method == http.MethodGet && len(arr) == 2 && arr[0] == "networks":
+		if !f.networks[arr[1]] {
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(map[string]string{"message": "network not found"})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(types.NetworkResource{Name: arr[1]})
+
+	case r.Method == http.MethodPost && path == "/networks/create":
+		req := new(types.NetworkCreateRequest)
+		_ = json.NewDecoder(r.Body).Decode(req)
+		f.networks[req.Name] = true
+		_ = json.NewEncoder(w).Encode(types.NetworkCreateResponse{ID: req.Name})
+
+	case len(arr) == 3 && arr[0] == "networks" && (arr[2] == "connect" || arr[2] == "disconnect"):
+		req := new(types.NetworkConnect)
+		_ = json.NewDecoder(r.Body).Decode(req)
+		c, p := f.containers[req.Container]
+		if !p {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if arr[2] == "connect" {
+			c.Networks[arr[1]] = req.EndpointConfig
+		} else {
+			delete(c.Networks, arr[1])
+		}
+		w.WriteHeader(http.StatusOK)
+
+	case r.Method == http.MethodGet && arr[0] == "images":
+		name := strings.TrimSuffix(strings.TrimPrefix(path, "/images/"), "/json")
+		if !f.images[name] {
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(map[string]string{"message": "no such image"})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(types.ImageInspect{ID: name})
+
+	case r.Method == http.MethodPost && path == "/images/create":
+		name := r.URL.Query().Get("fromImage") + ":" + r.URL.Query().Get("tag")
+		f.images[name] = true
+		f.pulls = append(f.pulls, name)
+		_, _ = w.Write([]byte(`{"status":"Pull complete"}`))
+
+	default:
+		w.WriteHeader(http.StatusNotImplemented)
+	}
+}
+
+func (f *fakeDocker) listContainers(w http.ResponseWriter, r *http.Request) {
+	args := map[string]map[string]bool{}
+	if s := r.URL.Query().Get("filters"); s != "" {
+		_ = json.Unmarshal([]byte(s), &args)
+	}
+
+	result := make([]types.Container, 0)
+	for _, c := range f.containers {
+		if !matchFilters(c, args) {
+			continue
+		}
+		networks := map[string]*network.EndpointSettings{}
+		for k, v := range c.Networks {
+			// The list api doesn't return the aliases of the network
+			networks[k] = &network.EndpointSettings{NetworkID: v.NetworkID}
+		}
+		result = append(result, types.Container{ID: c.ID, Names: []string{c.Name}, State: c.State, Labels: c.Config.Labels, NetworkSettings: &types.SummaryNetworkSettings{Networks: networks}})
+	}
+	_ = json.NewEncoder(w).Encode(result)
+}
+
+func matchFilters(c *fakeContainer, args map[string]map[string]bool) bool {
+	for label := range args["label"] {
+		kv := strings.SplitN(label, "=", 2)
+		if v, p := c.Config.Labels[kv[0]]; !p || (len(kv) == 2 && v != kv[1]) {
+			return false
+		}
+	}
+	for name := range args["name"] {
+		if !strings.Contains(c.Name, name) {
+			return false
+		}
+	}
+	for status := range args["status"] {
+		if c.State != status {
+			return false
+		}
+	}
+	return true
+}
+
+// getContainersByName returns the containers stored in the fake daemon keyed by name
+func (f *fakeDocker) getContainersByName() map[string]*fakeContainer {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	containers := make(map[string]*fakeContainer, len(f.containers))
+	for _, c := range f.containers {
+		containers[strings.TrimPrefix(c.Name, "/")] = c
+	}
+	return containers
+}
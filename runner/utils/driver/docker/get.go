@@ -0,0 +1,103 @@
+package docker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/docker/docker/api/types/filters"
+	"github.com/spaceuptech/helpers"
+
+	"github.com/spaceuptech/space-cloud/runner/model"
+)
+
+// GetServices returns the services deployed in a project
+func (d *Docker) GetServices(ctx context.Context, projectID string) ([]*model.Service, error) {
+	containers, err := d.listContainers(ctx, filters.Arg("label", "project="+projectID), filters.Arg("label", "primary=true"))
+	if err != nil {
+		return nil, err
+	}
+
+	// Every replica carries the spec of the service. We need it only once per version
+	serviceMap := map[string]*model.Service{}
+	for _, container := range containers {
+		key := fmt.Sprintf("%s:%s", container.Labels["service"], container.Labels["version"])
+		if _, p := serviceMap[key]; p {
+			continue
+		}
+
+		service := new(model.Service)
+		if err := json.Unmarshal([]byte(container.Labels["spec"]), service); err != nil {
+			return nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Unable to parse spec of container (%s)", container.ID), err, nil)
+		}
+		serviceMap[key] = service
+	}
+
+	keys := make([]string, 0, len(serviceMap))
+	for key := range serviceMap {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	services := make([]*model.Service, len(keys))
+	for i, key := range keys {
+		services[i] = serviceMap[key]
+	}
+	return services, nil
+}
+
+// GetServiceStatus gets the status of the replicas of each service
+func (d *Docker) GetServiceStatus(ctx context.Context, projectID string) ([]*model.ServiceStatus, error) {
+	containers, err := d.listContainers(ctx, filters.Arg("label", "project="+projectID), filters.Arg("label", "primary=true"))
+	if err != nil {
+		return nil, err
+	}
+
+	statusMap := map[string]*model.ServiceStatus{}
+	keys := make([]string, 0)
+	for _, container := range containers {
+		serviceID := container.Labels["service"]
+		version := container.Labels["version"]
+		key := fmt.Sprintf("%s:%s", serviceID, version)
+
+		status, p := statusMap[key]
+		if !p {
+			service := new(model.Service)
+			if err := json.Unmarshal([]byte(container.Labels["spec"]), service); err != nil {
+				return nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Unable to parse spec of container (%s)", container.ID), err, nil)
+			}
+			replicas := int32(getReplicaCount(service))
+
+			status = &model.ServiceStatus{ServiceID: serviceID, Version: version, DesiredReplicas: &replicas, Replicas: make([]*model.ReplicaInfo, 0)}
+			statusMap[key] = status
+			keys = append(keys, key)
+		}
+
+		status.Replicas = append(status.Replicas, &model.ReplicaInfo{ID: getReplicaID(container.Names), Status: strings.ToUpper(container.State)})
+	}
+	sort.Strings(keys)
+
+	result := make([]*model.ServiceStatus, len(keys))
+	for i, key := range keys {
+		sort.Slice(statusMap[key].Replicas, func(a, b int) bool {
+			return statusMap[key].Replicas[a].ID < statusMap[key].Replicas[b].ID
+		})
+		result[i] = statusMap[key]
+	}
+	return result, nil
+}
+
+// GetServiceRole returns an empty list since docker has no notion of roles
+func (d *Docker) GetServiceRole(ctx context.Context, projectID string) ([]*model.Role, error) {
+	return []*model.Role{}, nil
+}
+
+// getReplicaID returns the name of the container without the leading slash docker adds
+func getReplicaID(names []string) string {
+	if len(names) == 0 {
+		return ""
+	}
+	return strings.TrimPrefix(names[0], "/")
+}
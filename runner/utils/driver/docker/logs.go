@@ -0,0 +1,97 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/spaceuptech/helpers"
+
+	"github.com/spaceuptech/space-cloud/runner/model"
+	"github.com/spaceuptech/space-cloud/runner/utils"
+)
+
+// GetLogs get logs of specified services. The replica id is the name of the primary container of a replica
+// as returned in the service status
+func (d *Docker) GetLogs(ctx context.Context, projectID string, info *model.LogRequest) (io.ReadCloser, error) {
+	containerID, err := d.getLogsContainer(ctx, projectID, info)
+	if err != nil {
+		return nil, err
+	}
+
+	options := types.ContainerLogsOptions{ShowStdout: true, ShowStderr: true, Follow: info.IsFollow}
+	if info.Tail != nil {
+		options.Tail = strconv.FormatInt(*info.Tail, 10)
+	}
+	if info.SinceTime != nil {
+		options.Since = strconv.FormatInt(info.SinceTime.Unix(), 10)
+	} else if info.Since != nil {
+		options.Since = fmt.Sprintf("%ds", *info.Since)
+	}
+
+	b, err := d.client.ContainerLogs(ctx, containerID, options)
+	if err != nil {
+		return nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Unable to get logs of container (%s)", containerID), err, nil)
+	}
+
+	pipeReader, pipeWriter := io.Pipe()
+	helpers.Logger.LogDebug(helpers.GetRequestID(ctx), "Sending logs to client", map[string]interface{}{})
+	go func() {
+		defer utils.CloseTheCloser(b)
+		defer utils.CloseTheCloser(pipeWriter)
+
+		// Docker multiplexes stdout and stderr in a single stream
+		if _, err := stdcopy.StdCopy(pipeWriter, pipeWriter, b); err != nil {
+			_ = helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to read logs from container", err, nil)
+			return
+		}
+		helpers.Logger.LogDebug(helpers.GetRequestID(ctx), "End of file reached for logs", map[string]interface{}{})
+	}()
+	return pipeReader, nil
+}
+
+// getLogsContainer finds the container of the requested task in the replica
+func (d *Docker) getLogsContainer(ctx context.Context, projectID string, info *model.LogRequest) (string, error) {
+	containers, err := d.listContainers(ctx, filters.Arg("label", "project="+projectID), filters.Arg("name", info.ReplicaID))
+	if err != nil {
+		return "", err
+	}
+
+	var primary *types.Container
+	for i, c := range containers {
+		if getReplicaID(c.Names) == info.ReplicaID {
+			primary = &containers[i]
+			break
+		}
+	}
+	if primary == nil {
+		return "", helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Replica (%s) not found", info.ReplicaID), nil, nil)
+	}
+
+	if info.TaskID == "" || info.TaskID == primary.Labels["task"] {
+		return primary.ID, nil
+	}
+
+	// The name of the container of another task in the same replica only differs in the task id
+	name := getContainerName(d.config.ClusterName, projectID, primary.Labels["service"], primary.Labels["version"], info.TaskID, atoi(primary.Labels["replica"]))
+	containers, err = d.listContainers(ctx, filters.Arg("label", "project="+projectID), filters.Arg("label", "task="+info.TaskID), filters.Arg("name", name))
+	if err != nil {
+		return "", err
+	}
+	for _, c := range containers {
+		if strings.TrimPrefix(c.Names[0], "/") == name {
+			return c.ID, nil
+		}
+	}
+	return "", helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Task (%s) not found in replica (%s)", info.TaskID, info.ReplicaID), nil, nil)
+}
+
+func atoi(s string) int {
+	i, _ := strconv.Atoi(s)
+	return i
+}
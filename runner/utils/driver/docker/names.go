@@ -0,0 +1,72 @@
+package docker
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// safeNameRegex matches the names which can be used as an element of a file path. Names can't start with a dot
+// to prevent them from referring to the parent directory
+var safeNameRegex = regexp.MustCompile(`^[a-zA-Z0-9_-][a-zA-Z0-9._-]*$`)
+
+// validateNames checks if the provided names can be safely used as elements of a file path
+func validateNames(kind string, names ...string) error {
+	for _, name := range names {
+		if !safeNameRegex.MatchString(name) {
+			return fmt.Errorf("invalid %s (%s) provided, only alphanumeric characters, '.', '_' and '-' are allowed", kind, name)
+		}
+	}
+	return nil
+}
+
+func getNetworkName(clusterName string) string {
+	if clusterName == "default" {
+		return "space-cloud"
+	}
+	return fmt.Sprintf("space-cloud-%s", clusterName)
+}
+
+func getContainerName(clusterName, projectID, serviceID, version, taskID string, replica int) string {
+	return fmt.Sprintf("%s--%s--%s--%s--%s--%d", getNetworkName(clusterName), projectID, serviceID, version, taskID, replica)
+}
+
+func getRoutingFilePath(statePath string) string {
+	return filepath.Join(statePath, "routing-config.json")
+}
+
+func getSecretsDir(statePath string) string {
+	return filepath.Join(statePath, "secrets")
+}
+
+func getProjectSecretsDir(statePath, projectID string) string {
+	return filepath.Join(getSecretsDir(statePath), projectID)
+}
+
+func getSecretPath(statePath, projectID, secretName string) string {
+	return filepath.Join(getProjectSecretsDir(statePath, projectID), secretName+".json")
+}
+
+// getProjectTempSecretsDir returns the directory where the file secrets mounted in the containers of a project are
+// written. It's kept out of the secrets directory so that it can't collide with the secrets of a project
+func getProjectTempSecretsDir(statePath, projectID string) string {
+	return filepath.Join(statePath, "temp-secrets", projectID)
+}
+
+func getTempSecretsDir(statePath, projectID, serviceID, version, secretName string) string {
+	return filepath.Join(getProjectTempSecretsDir(statePath, projectID), serviceID, version, secretName)
+}
+
+func isServiceDomain(s string) bool {
+	return strings.HasSuffix(s, ".svc.cluster.local")
+}
+
+func contains(arr []string, s string) bool {
+	for _, v := range arr {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,221 @@
+package docker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/network"
+	"github.com/spaceuptech/helpers"
+
+	"github.com/spaceuptech/space-cloud/runner/model"
+	"github.com/spaceuptech/space-cloud/runner/utils"
+)
+
+// routingConfig stores the routes of every service. It is keyed by project id and then service id
+type routingConfig map[string]map[string]model.Routes
+
+// ApplyServiceRoutes sets the routing rules of a service. Docker can't split traffic between versions, so the
+// general service domain is resolved to the version which receives the highest cumulative weight
+func (d *Docker) ApplyServiceRoutes(ctx context.Context, projectID, serviceID string, routes model.Routes) error {
+	for _, route := range routes {
+		if route.Source.Port == 0 {
+			return helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Invalid route (%s) provided for service (%s)", route.ID, serviceID), errors.New("port cannot be zero"), nil)
+		}
+		if len(route.Targets) == 0 {
+			return helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Invalid route (%s) provided for service (%s)", route.ID, serviceID), errors.New("at least one target needs to be provided"), nil)
+		}
+	}
+
+	if err := d.setServiceRoutes(projectID, serviceID, routes); err != nil {
+		return helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Unable to store routes of service (%s)", serviceID), err, nil)
+	}
+
+	return d.updateServiceAliases(ctx, projectID, serviceID, routes)
+}
+
+// GetServiceRoutes gets the routing rules of each service
+func (d *Docker) GetServiceRoutes(ctx context.Context, projectID string) (map[string]model.Routes, error) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	routes, err := d.readRoutes()
+	if err != nil {
+		return nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to read service routes", err, nil)
+	}
+
+	serviceRoutes := make(map[string]model.Routes, len(routes[projectID]))
+	for serviceID, r := range routes[projectID] {
+		serviceRoutes[serviceID] = r
+	}
+	return serviceRoutes, nil
+}
+
+// getOrCreateServiceRoutes returns the routes of a service. Routes pointing every port of the service
+// to the provided version are stored when no routes exist for the service yet
+func (d *Docker) getOrCreateServiceRoutes(service *model.Service) (model.Routes, error) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	routes, err := d.readRoutes()
+	if err != nil {
+		return nil, err
+	}
+
+	if r, p := routes[service.ProjectID][service.ID]; p && len(r) > 0 {
+		return r, nil
+	}
+
+	serviceRoutes := make(model.Routes, 0)
+	for _, task := range service.Tasks {
+		for _, port := range task.Ports {
+			serviceRoutes = append(serviceRoutes, &model.Route{
+				ID:             fmt.Sprintf("%s-%d", service.ID, port.Port),
+				RequestRetries: model.DefaultRequestRetries,
+				RequestTimeout: model.DefaultRequestTimeout,
+				Source:         model.RouteSource{Protocol: port.Protocol, Port: port.Port},
+				Targets:        []model.RouteTarget{{Type: model.RouteTargetVersion, Version: service.Version, Port: port.Port, Weight: 100}},
+			})
+		}
+	}
+
+	if routes[service.ProjectID] == nil {
+		routes[service.ProjectID] = map[string]model.Routes{}
+	}
+	routes[service.ProjectID][service.ID] = serviceRoutes
+	return serviceRoutes, d.writeRoutes(routes)
+}
+
+func (d *Docker) setServiceRoutes(projectID, serviceID string, serviceRoutes model.Routes) error {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	routes, err := d.readRoutes()
+	if err != nil {
+		return err
+	}
+
+	if routes[projectID] == nil {
+		routes[projectID] = map[string]model.Routes{}
+	}
+	routes[projectID][serviceID] = serviceRoutes
+	return d.writeRoutes(routes)
+}
+
+// updateServiceAliases reconnects the containers of a service to the network so that the general
+// service domain only resolves to the active version
+func (d *Docker) updateServiceAliases(ctx context.Context, projectID, serviceID string, routes model.Routes) error {
+	activeVersion := getActiveVersion(routes)
+	networkName := getNetworkName(d.config.ClusterName)
+
+	containers, err := d.listContainers(ctx, filters.Arg("label", "project="+projectID), filters.Arg("label", "service="+serviceID), filters.Arg("label", "primary=true"))
+	if err != nil {
+		return err
+	}
+
+	for _, container := range containers {
+		version := container.Labels["version"]
+		aliases := getServiceAliases(projectID, serviceID, version, activeVersion)
+
+		// The container list doesn't contain the aliases. Hence we need to inspect each container
+		info, err := d.client.ContainerInspect(ctx, container.ID)
+		if err != nil {
+			return helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Unable to inspect container (%s)", container.ID), err, nil)
+		}
+
+		// Skip the container if its aliases are already up to date
+		var settings *network.EndpointSettings
+		if info.NetworkSettings != nil {
+			settings = info.NetworkSettings.Networks[networkName]
+		}
+		if settings != nil && equalAliases(settings.Aliases, aliases) {
+			continue
+		}
+
+		helpers.Logger.LogDebug(helpers.GetRequestID(ctx), fmt.Sprintf("Updating network aliases of container (%s)", container.ID), map[string]interface{}{"aliases": aliases})
+		if settings != nil {
+			if err := d.client.NetworkDisconnect(ctx, networkName, container.ID, true); err != nil {
+				return helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Unable to disconnect container (%s) from network (%s)", container.ID, networkName), err, nil)
+			}
+		}
+		if err := d.client.NetworkConnect(ctx, networkName, container.ID, &network.EndpointSettings{Aliases: aliases}); err != nil {
+			return helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Unable to connect container (%s) to network (%s)", container.ID, networkName), err, nil)
+		}
+	}
+	return nil
+}
+
+// getActiveVersion returns the version which receives the highest cumulative weight across all routes
+func getActiveVersion(routes model.Routes) string {
+	weights := map[string]int32{}
+	var activeVersion string
+	for _, route := range routes {
+		for _, target := range route.Targets {
+			if target.Type != model.RouteTargetVersion && target.Type != "" {
+				continue
+			}
+			weights[target.Version] += target.Weight
+			if activeVersion == "" || weights[target.Version] > weights[activeVersion] {
+				activeVersion = target.Version
+			}
+		}
+	}
+	return activeVersion
+}
+
+func getServiceAliases(projectID, serviceID, version, activeVersion string) []string {
+	aliases := []string{utils.GetInternalServiceDomain(projectID, serviceID, version)}
+	if version == activeVersion {
+		aliases = append(aliases, utils.GetServiceDomain(projectID, serviceID))
+	}
+	return aliases
+}
+
+// equalAliases checks if the service domains assigned to a container match the wanted aliases. Other
+// aliases like the container id, which are added by docker itself, are ignored
+func equalAliases(current, wanted []string) bool {
+	domains := make([]string, 0, len(current))
+	for _, alias := range current {
+		if isServiceDomain(alias) {
+			domains = append(domains, alias)
+		}
+	}
+
+	if len(domains) != len(wanted) {
+		return false
+	}
+	for _, alias := range wanted {
+		if !contains(domains, alias) {
+			return false
+		}
+	}
+	return true
+}
+
+func (d *Docker) readRoutes() (routingConfig, error) {
+	data, err := ioutil.ReadFile(getRoutingFilePath(d.config.StatePath))
+	if os.IsNotExist(err) {
+		return routingConfig{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	routes := routingConfig{}
+	if err := json.Unmarshal(data, &routes); err != nil {
+		return nil, err
+	}
+	return routes, nil
+}
+
+func (d *Docker) writeRoutes(routes routingConfig) error {
+	data, err := json.Marshal(routes)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(getRoutingFilePath(d.config.StatePath), data, 0600)
+}
@@ -0,0 +1,61 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/spaceuptech/helpers"
+
+	"github.com/spaceuptech/space-cloud/runner/model"
+)
+
+// ScaleUp starts the containers of a service which has been scaled down to zero
+func (d *Docker) ScaleUp(ctx context.Context, projectID, serviceID, version string) error {
+	containers, err := d.listContainers(ctx, filters.Arg("label", "project="+projectID), filters.Arg("label", "service="+serviceID), filters.Arg("label", "version="+version))
+	if err != nil {
+		return err
+	}
+
+	// The primary containers need to be started first since the other tasks use their network
+	for _, primary := range []string{"true", "false"} {
+		for _, c := range containers {
+			if c.Labels["primary"] != primary || c.State == "running" {
+				continue
+			}
+			if err := d.client.ContainerStart(ctx, c.ID, types.ContainerStartOptions{}); err != nil {
+				return helpers.Logger.LogError(helpers.GetRequestID(ctx), "Unable to scale up service", err, map[string]interface{}{"project": projectID, "service": serviceID, "version": version})
+			}
+		}
+	}
+	return nil
+}
+
+// WaitForService waits till at least one replica of the service is running
+func (d *Docker) WaitForService(ctx context.Context, service *model.Service) error {
+	helpers.Logger.LogDebug(helpers.GetRequestID(ctx), fmt.Sprintf("Waiting for service (%s:%s:%s) to start", service.ProjectID, service.ID, service.Version), nil)
+
+	ticker := time.NewTicker(500 * time.Millisecond)
+	defer ticker.Stop()
+	timeout := time.After(5 * time.Minute)
+
+	for {
+		containers, err := d.listContainers(ctx, filters.Arg("label", "project="+service.ProjectID), filters.Arg("label", "service="+service.ID), filters.Arg("label", "version="+service.Version), filters.Arg("label", "primary=true"), filters.Arg("status", "running"))
+		if err != nil {
+			return err
+		}
+		if len(containers) > 0 {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("service (%s:%s) could not be started", service.ProjectID, service.ID), ctx.Err(), nil)
+		case <-timeout:
+			return helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("service (%s:%s) could not be started", service.ProjectID, service.ID), nil, nil)
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,222 @@
+package docker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spaceuptech/helpers"
+
+	"github.com/spaceuptech/space-cloud/runner/model"
+)
+
+// CreateSecret is used to upsert secret
+func (d *Docker) CreateSecret(ctx context.Context, projectID string, secretObj *model.Secret) error {
+	// check whether the secret type is correct!
+	if secretObj.Type != model.FileType && secretObj.Type != model.EnvType && secretObj.Type != model.DockerType {
+		return helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Invalid secret type (%s) provided", secretObj.Type), nil, nil)
+	}
+
+	if secretObj.Type == model.DockerType {
+		_, p1 := secretObj.Data["username"]
+		_, p2 := secretObj.Data["password"]
+		_, p3 := secretObj.Data["url"]
+		if !p1 || !p2 || !p3 {
+			return helpers.Logger.LogError(helpers.GetRequestID(ctx), "incorrect secret value provided for secret type docker", nil, nil)
+		}
+	}
+
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	oldSecret, err := d.readSecret(projectID, secretObj.ID)
+	if err != nil && !os.IsNotExist(err) {
+		return helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Failed to create secret (%s)", secretObj.ID), err, nil)
+	}
+	if oldSecret != nil && oldSecret.Type != secretObj.Type {
+		return helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Secret type mismatch. Wanted - %s; Got - %s", oldSecret.Type, secretObj.Type), nil, nil)
+	}
+
+	helpers.Logger.LogDebug(helpers.GetRequestID(ctx), fmt.Sprintf("Writing secret (%s)", secretObj.ID), nil)
+	if secretObj.Data == nil {
+		secretObj.Data = map[string]string{}
+	}
+	return d.writeSecret(projectID, secretObj)
+}
+
+// ListSecrets lists all the secrets of the provided project
+func (d *Docker) ListSecrets(ctx context.Context, projectID string) ([]*model.Secret, error) {
+	if err := validateNames("project id", projectID); err != nil {
+		return nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), "Failed to fetch list of secrets", err, nil)
+	}
+
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	files, err := ioutil.ReadDir(getProjectSecretsDir(d.config.StatePath, projectID))
+	if os.IsNotExist(err) {
+		return []*model.Secret{}, nil
+	}
+	if err != nil {
+		return nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), "Failed to fetch list of secrets", err, nil)
+	}
+
+	listOfSecrets := make([]*model.Secret, 0, len(files))
+	for _, file := range files {
+		if file.IsDir() || filepath.Ext(file.Name()) != ".json" {
+			continue
+		}
+
+		secret, err := d.readSecret(projectID, strings.TrimSuffix(file.Name(), ".json"))
+		if err != nil {
+			return nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Failed to read secret (%s)", file.Name()), err, nil)
+		}
+		listOfSecrets = append(listOfSecrets, secret)
+	}
+	return listOfSecrets, nil
+}
+
+// DeleteSecret is used to delete secrets!
+func (d *Docker) DeleteSecret(ctx context.Context, projectID string, secretName string) error {
+	if err := validateNames("secret name", projectID, secretName); err != nil {
+		return helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Failed to delete secret (%s)", secretName), err, nil)
+	}
+
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	err := os.Remove(getSecretPath(d.config.StatePath, projectID, secretName))
+	if os.IsNotExist(err) || err == nil {
+		return nil
+	}
+	return helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Failed to delete secret (%s)", secretName), err, nil)
+}
+
+// SetFileSecretRootPath is used to set the file secret root path
+func (d *Docker) SetFileSecretRootPath(ctx context.Context, projectID string, secretName, rootPath string) error {
+	if secretName == "" || rootPath == "" {
+		return helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Empty secret name (%s) or root path (%s) provided", secretName, rootPath), nil, nil)
+	}
+
+	return d.updateSecret(ctx, projectID, secretName, "set root path", func(secret *model.Secret) {
+		secret.RootPath = rootPath
+	})
+}
+
+// SetKey adds a new secret key-value pair
+func (d *Docker) SetKey(ctx context.Context, projectID string, secretName string, secretKey string, secretValObj *model.SecretValue) error {
+	if secretName == "" || secretValObj.Value == "" {
+		return helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("key/value not provided; got (%s,%s)", secretName, secretValObj.Value), nil, nil)
+	}
+
+	return d.updateSecret(ctx, projectID, secretName, "set key", func(secret *model.Secret) {
+		if secret.Data == nil {
+			secret.Data = make(map[string]string, 1)
+		}
+		secret.Data[secretKey] = secretValObj.Value
+	})
+}
+
+// DeleteKey is used to delete a key from the secret!
+func (d *Docker) DeleteKey(ctx context.Context, projectID string, secretName string, secretKey string) error {
+	return d.updateSecret(ctx, projectID, secretName, "delete key", func(secret *model.Secret) {
+		delete(secret.Data, secretKey)
+	})
+}
+
+// updateSecret applies the provided function on a file or env secret and persists it
+func (d *Docker) updateSecret(ctx context.Context, projectID, secretName, op string, fn func(secret *model.Secret)) error {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	secret, err := d.readSecret(projectID, secretName)
+	if os.IsNotExist(err) {
+		return helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("secret with name (%s) does not exist", secretName), err, nil)
+	}
+	if err != nil {
+		return helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Failed to read secret (%s)", secretName), err, nil)
+	}
+
+	switch secret.Type {
+	case model.DockerType:
+		return helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("%s operation cannot be performed on secrets with type docker", op), nil, nil)
+	case model.FileType, model.EnvType:
+		fn(secret)
+	default:
+		return helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("invalid secret type - %s", secret.Type), nil, nil)
+	}
+
+	return d.writeSecret(projectID, secret)
+}
+
+func (d *Docker) readSecret(projectID, secretName string) (*model.Secret, error) {
+	if err := validateNames("secret name", projectID, secretName); err != nil {
+		return nil, err
+	}
+
+	data, err := ioutil.ReadFile(getSecretPath(d.config.StatePath, projectID, secretName))
+	if err != nil {
+		return nil, err
+	}
+
+	secret := new(model.Secret)
+	if err := json.Unmarshal(data, secret); err != nil {
+		return nil, err
+	}
+	return secret, nil
+}
+
+func (d *Docker) writeSecret(projectID string, secret *model.Secret) error {
+	if err := validateNames("secret name", projectID, secret.ID); err != nil {
+		return err
+	}
+
+	// The keys of file secrets are used as the names of the files mounted in the container
+	if secret.Type == model.FileType {
+		for k := range secret.Data {
+			if err := validateNames("file secret key", k); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := os.MkdirAll(getProjectSecretsDir(d.config.StatePath, projectID), 0700); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(secret)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(getSecretPath(d.config.StatePath, projectID, secret.ID), data, 0600)
+}
+
+// getSecrets reads all the secrets required by a service
+func (d *Docker) getSecrets(ctx context.Context, service *model.Service) (map[string]*model.Secret, error) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	listOfSecrets := map[string]*model.Secret{}
+	for _, task := range service.Tasks {
+		names := append([]string{}, task.Secrets...)
+		if task.Docker.Secret != "" {
+			names = append(names, task.Docker.Secret)
+		}
+
+		for _, secretName := range names {
+			if _, p := listOfSecrets[secretName]; p {
+				continue
+			}
+			secret, err := d.readSecret(service.ProjectID, secretName)
+			if err != nil {
+				return nil, helpers.Logger.LogError(helpers.GetRequestID(ctx), fmt.Sprintf("Unable to read secret (%s) required by service (%s)", secretName, service.ID), err, nil)
+			}
+			listOfSecrets[secretName] = secret
+		}
+	}
+	return listOfSecrets, nil
+}
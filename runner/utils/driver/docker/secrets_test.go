@@ -0,0 +1,97 @@
+package docker
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/spaceuptech/space-cloud/runner/model"
+)
+
+func TestDocker_Secrets(t *testing.T) {
+	d, _ := newTestDriver(t)
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		secret  *model.Secret
+		wantErr bool
+	}{
+		{name: "env secret", secret: &model.Secret{ID: "env", Type: model.EnvType, Data: map[string]string{"foo": "bar"}}},
+		{name: "file secret", secret: &model.Secret{ID: "file", Type: model.FileType, RootPath: "/secrets", Data: map[string]string{"a.txt": "a"}}},
+		{name: "docker secret", secret: &model.Secret{ID: "docker", Type: model.DockerType, Data: map[string]string{"username": "u", "password": "p", "url": "registry"}}},
+		{name: "docker secret with missing keys", secret: &model.Secret{ID: "docker-invalid", Type: model.DockerType, Data: map[string]string{"username": "u"}}, wantErr: true},
+		{name: "invalid type", secret: &model.Secret{ID: "invalid", Type: "unknown"}, wantErr: true},
+		{name: "type mismatch", secret: &model.Secret{ID: "env", Type: model.FileType}, wantErr: true},
+		{name: "secret name referring to the parent directory", secret: &model.Secret{ID: "../../escape", Type: model.EnvType}, wantErr: true},
+		{name: "secret name with path separator", secret: &model.Secret{ID: "a/b", Type: model.EnvType}, wantErr: true},
+		{name: "hidden secret name", secret: &model.Secret{ID: "..", Type: model.EnvType}, wantErr: true},
+		{name: "file secret key referring to the parent directory", secret: &model.Secret{ID: "file-escape", Type: model.FileType, Data: map[string]string{"../a.txt": "a"}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := d.CreateSecret(ctx, "myproject", tt.secret); (err != nil) != tt.wantErr {
+				t.Errorf("CreateSecret() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	secrets, err := d.ListSecrets(ctx, "myproject")
+	if err != nil {
+		t.Fatalf("ListSecrets() error = %v", err)
+	}
+	if len(secrets) != 3 {
+		t.Errorf("ListSecrets() returned %d secrets, want 3", len(secrets))
+	}
+
+	if err := d.SetKey(ctx, "myproject", "env", "hello", &model.SecretValue{Value: "world"}); err != nil {
+		t.Errorf("SetKey() error = %v", err)
+	}
+	if err := d.DeleteKey(ctx, "myproject", "env", "foo"); err != nil {
+		t.Errorf("DeleteKey() error = %v", err)
+	}
+	if err := d.SetFileSecretRootPath(ctx, "myproject", "file", "/etc/files"); err != nil {
+		t.Errorf("SetFileSecretRootPath() error = %v", err)
+	}
+	if err := d.SetKey(ctx, "myproject", "docker", "hello", &model.SecretValue{Value: "world"}); err == nil {
+		t.Errorf("SetKey() expected error for docker secret")
+	}
+	if err := d.SetKey(ctx, "myproject", "unknown", "hello", &model.SecretValue{Value: "world"}); err == nil {
+		t.Errorf("SetKey() expected error for unknown secret")
+	}
+	if err := d.SetKey(ctx, "myproject", "file", "../../escape", &model.SecretValue{Value: "world"}); err == nil {
+		t.Errorf("SetKey() expected error for file secret key referring to the parent directory")
+	}
+	if err := d.DeleteSecret(ctx, "myproject", "../../routing-config"); err == nil {
+		t.Errorf("DeleteSecret() expected error for secret name referring to the parent directory")
+	}
+	if _, err := d.ListSecrets(ctx, "../myproject"); err == nil {
+		t.Errorf("ListSecrets() expected error for project id referring to the parent directory")
+	}
+
+	env, err := d.readSecret("myproject", "env")
+	if err != nil {
+		t.Fatalf("readSecret() error = %v", err)
+	}
+	if want := map[string]string{"hello": "world"}; !reflect.DeepEqual(env.Data, want) {
+		t.Errorf("SetKey()/DeleteKey() data = %v, want %v", env.Data, want)
+	}
+	file, err := d.readSecret("myproject", "file")
+	if err != nil {
+		t.Fatalf("readSecret() error = %v", err)
+	}
+	if file.RootPath != "/etc/files" {
+		t.Errorf("SetFileSecretRootPath() root path = %s, want /etc/files", file.RootPath)
+	}
+
+	if err := d.DeleteSecret(ctx, "myproject", "env"); err != nil {
+		t.Errorf("DeleteSecret() error = %v", err)
+	}
+	secrets, err = d.ListSecrets(ctx, "myproject")
+	if err != nil {
+		t.Fatalf("ListSecrets() error = %v", err)
+	}
+	if len(secrets) != 2 {
+		t.Errorf("ListSecrets() after delete returned %d secrets, want 2", len(secrets))
+	}
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/spaceuptech/space-cloud/runner/model"
 	"github.com/spaceuptech/space-cloud/runner/utils/auth"
+	"github.com/spaceuptech/space-cloud/runner/utils/driver/docker"
 	"github.com/spaceuptech/space-cloud/runner/utils/driver/istio"
 )
 
@@ -20,6 +21,7 @@ type Config struct {
 	ProxyPort      uint32
 	PrometheusAddr string
 	ClusterName    string
+	StatePath      string // Directory used by the docker driver to store its state
 }
 
 // Interface is the interface of the modules which interact with the deployment targets
@@ -83,6 +85,10 @@ func initDriver(auth *auth.Module, c *Config) (Interface, error) {
 
 		return istio.NewIstioDriver(auth, istioConfig)
 
+	case model.TypeDocker:
+		// The docker driver stores its state in the directory provided with the `--state-path` flag
+		return docker.NewDockerDriver(auth, &docker.Config{ClusterName: c.ClusterName, StatePath: c.StatePath})
+
 	default:
 		return nil, helpers.Logger.LogError(helpers.GetRequestID(context.TODO()), fmt.Sprintf("invalid driver type (%s) provided", c.DriverType), nil, nil)
 	}
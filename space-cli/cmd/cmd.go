@@ -12,6 +12,7 @@ import (
 	"github.com/spaceuptech/space-cloud/space-cli/cmd/modules/accounts"
 	"github.com/spaceuptech/space-cloud/space-cli/cmd/modules/addons"
 	"github.com/spaceuptech/space-cloud/space-cli/cmd/modules/deploy"
+	"github.com/spaceuptech/space-cloud/space-cli/cmd/modules/eventing"
 	"github.com/spaceuptech/space-cloud/space-cli/cmd/modules/login"
 	"github.com/spaceuptech/space-cloud/space-cli/cmd/modules/logs"
 	"github.com/spaceuptech/space-cloud/space-cli/cmd/modules/operations"
@@ -114,6 +115,8 @@ func GetRootCommand() *cobra.Command {
 	rootCmd.AddCommand(login.Commands()...)
 	rootCmd.AddCommand(accounts.Commands()...)
 	rootCmd.AddCommand(logs.GetSubCommands()...)
+	rootCmd.AddCommand(eventing.DLQCommands()...)
+	rootCmd.AddCommand(project.HistoryCommands()...)
 	rootCmd.AddCommand(completionCmd)
 	return rootCmd
 }
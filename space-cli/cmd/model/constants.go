@@ -29,6 +29,9 @@ const (
 	HelmMongoChartDownloadURL = "https://storage.googleapis.com/space-cloud/helm/mongo/mongo-0.1.0.tgz"
 	// HelmSpaceCloudNamespace space cloud namespace for helm
 	HelmSpaceCloudNamespace = "space-cloud"
+
+	// DLQBatchSize is the maximum number of events space cloud replays from the dead letter queue in a single request
+	DLQBatchSize = 1000
 )
 
 // Version version of space cli
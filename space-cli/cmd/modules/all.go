@@ -13,6 +13,7 @@ import (
 	"github.com/spaceuptech/space-cloud/space-cli/cmd/modules/database"
 	"github.com/spaceuptech/space-cloud/space-cli/cmd/modules/eventing"
 	"github.com/spaceuptech/space-cloud/space-cli/cmd/modules/filestore"
+	"github.com/spaceuptech/space-cloud/space-cli/cmd/modules/graphql"
 	"github.com/spaceuptech/space-cloud/space-cli/cmd/modules/ingress"
 	"github.com/spaceuptech/space-cloud/space-cli/cmd/modules/letsencrypt"
 	"github.com/spaceuptech/space-cloud/space-cli/cmd/modules/project"
@@ -205,6 +206,14 @@ func getAllProjects(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
+	objs, err = graphql.GetPersistedQueries(projectName, "graphql-persisted-queries", map[string]string{})
+	if err != nil {
+		return nil
+	}
+	if err := createConfigFile("20", "graphql-persisted-queries", objs); err != nil {
+		return nil
+	}
+
 	return nil
 }
 
@@ -70,7 +70,7 @@ func generateDBConfig() (*model.SpecObject, error) {
 	}
 
 	var dbType string
-	if err := input.Survey.AskOne(&survey.Select{Message: "Select database choice ", Options: []string{"mongo", "mysql", "postgres", "sqlserver", "embedded"}}, &dbType); err != nil {
+	if err := input.Survey.AskOne(&survey.Select{Message: "Select database choice ", Options: []string{"mongo", "mysql", "postgres", "sqlserver", "sqlite", "embedded"}}, &dbType); err != nil {
 		return nil, err
 	}
 
@@ -82,6 +82,9 @@ func generateDBConfig() (*model.SpecObject, error) {
 	case "sqlserver":
 
 		connDefault = "Data Source=localhost,1433;Initial Catalog=master;User ID=yourID;Password=yourPassword@#;"
+	case "sqlite":
+
+		connDefault = "space-cloud.db"
 	case "embedded":
 
 		connDefault = "Data.db"
@@ -228,7 +228,7 @@ func Test_generateDBConfig(t *testing.T) {
 				},
 				{
 					method:         "AskOne",
-					args:           []interface{}{&survey.Select{Message: "Select database choice ", Options: []string{"mongo", "mysql", "postgres", "sqlserver", "embedded"}}, &surveyReturnValue, mock.Anything},
+					args:           []interface{}{&survey.Select{Message: "Select database choice ", Options: []string{"mongo", "mysql", "postgres", "sqlserver", "sqlite", "embedded"}}, &surveyReturnValue, mock.Anything},
 					paramsReturned: []interface{}{nil, "postgres"},
 				},
 				{
@@ -249,7 +249,7 @@ func Test_generateDBConfig(t *testing.T) {
 				},
 				{
 					method:         "AskOne",
-					args:           []interface{}{&survey.Select{Message: "Select database choice ", Options: []string{"mongo", "mysql", "postgres", "sqlserver", "embedded"}}, &surveyReturnValue, mock.Anything},
+					args:           []interface{}{&survey.Select{Message: "Select database choice ", Options: []string{"mongo", "mysql", "postgres", "sqlserver", "sqlite", "embedded"}}, &surveyReturnValue, mock.Anything},
 					paramsReturned: []interface{}{nil, "postgres"},
 				},
 				{
@@ -275,7 +275,7 @@ func Test_generateDBConfig(t *testing.T) {
 				},
 				{
 					method:         "AskOne",
-					args:           []interface{}{&survey.Select{Message: "Select database choice ", Options: []string{"mongo", "mysql", "postgres", "sqlserver", "embedded"}}, &surveyReturnValue, mock.Anything},
+					args:           []interface{}{&survey.Select{Message: "Select database choice ", Options: []string{"mongo", "mysql", "postgres", "sqlserver", "sqlite", "embedded"}}, &surveyReturnValue, mock.Anything},
 					paramsReturned: []interface{}{nil, "postgres"},
 				},
 				{
@@ -331,7 +331,7 @@ func Test_generateDBConfig(t *testing.T) {
 				},
 				{
 					method:         "AskOne",
-					args:           []interface{}{&survey.Select{Message: "Select database choice ", Options: []string{"mongo", "mysql", "postgres", "sqlserver", "embedded"}}, &surveyReturnValue, mock.Anything},
+					args:           []interface{}{&survey.Select{Message: "Select database choice ", Options: []string{"mongo", "mysql", "postgres", "sqlserver", "sqlite", "embedded"}}, &surveyReturnValue, mock.Anything},
 					paramsReturned: []interface{}{nil, "sqlserver"},
 				},
 				{
@@ -352,7 +352,7 @@ func Test_generateDBConfig(t *testing.T) {
 				},
 				{
 					method:         "AskOne",
-					args:           []interface{}{&survey.Select{Message: "Select database choice ", Options: []string{"mongo", "mysql", "postgres", "sqlserver", "embedded"}}, &surveyReturnValue, mock.Anything},
+					args:           []interface{}{&survey.Select{Message: "Select database choice ", Options: []string{"mongo", "mysql", "postgres", "sqlserver", "sqlite", "embedded"}}, &surveyReturnValue, mock.Anything},
 					paramsReturned: []interface{}{nil, "embedded"},
 				},
 				{
@@ -373,7 +373,7 @@ func Test_generateDBConfig(t *testing.T) {
 				},
 				{
 					method:         "AskOne",
-					args:           []interface{}{&survey.Select{Message: "Select database choice ", Options: []string{"mongo", "mysql", "postgres", "sqlserver", "embedded"}}, &surveyReturnValue, mock.Anything},
+					args:           []interface{}{&survey.Select{Message: "Select database choice ", Options: []string{"mongo", "mysql", "postgres", "sqlserver", "sqlite", "embedded"}}, &surveyReturnValue, mock.Anything},
 					paramsReturned: []interface{}{nil, "mongo"},
 				},
 				{
@@ -394,7 +394,7 @@ func Test_generateDBConfig(t *testing.T) {
 				},
 				{
 					method:         "AskOne",
-					args:           []interface{}{&survey.Select{Message: "Select database choice ", Options: []string{"mongo", "mysql", "postgres", "sqlserver", "embedded"}}, &surveyReturnValue, mock.Anything},
+					args:           []interface{}{&survey.Select{Message: "Select database choice ", Options: []string{"mongo", "mysql", "postgres", "sqlserver", "sqlite", "embedded"}}, &surveyReturnValue, mock.Anything},
 					paramsReturned: []interface{}{nil, "mysql"},
 				},
 				{
@@ -405,6 +405,27 @@ func Test_generateDBConfig(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "dbtype sqlite case",
+			surveyMockArgs: []mockArgs{
+				{
+					method:         "AskOne",
+					args:           []interface{}{&survey.Input{Message: "Enter Project ID"}, &surveyReturnValue, mock.Anything},
+					paramsReturned: []interface{}{nil, ""},
+				},
+				{
+					method:         "AskOne",
+					args:           []interface{}{&survey.Select{Message: "Select database choice ", Options: []string{"mongo", "mysql", "postgres", "sqlserver", "sqlite", "embedded"}}, &surveyReturnValue, mock.Anything},
+					paramsReturned: []interface{}{nil, "sqlite"},
+				},
+				{
+					method:         "AskOne",
+					args:           []interface{}{mock.Anything, mock.Anything, mock.Anything},
+					paramsReturned: []interface{}{errors.New("unable to call AskOne"), ""},
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
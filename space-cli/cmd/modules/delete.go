@@ -5,6 +5,7 @@ import (
 	"github.com/spaceuptech/space-cloud/space-cli/cmd/modules/database"
 	"github.com/spaceuptech/space-cloud/space-cli/cmd/modules/eventing"
 	"github.com/spaceuptech/space-cloud/space-cli/cmd/modules/filestore"
+	"github.com/spaceuptech/space-cloud/space-cli/cmd/modules/graphql"
 	"github.com/spaceuptech/space-cloud/space-cli/cmd/modules/ingress"
 	"github.com/spaceuptech/space-cloud/space-cli/cmd/modules/letsencrypt"
 	"github.com/spaceuptech/space-cloud/space-cli/cmd/modules/project"
@@ -27,6 +28,7 @@ func FetchDeleteSubCommands() *cobra.Command {
 	deleteCmd.AddCommand(letsencrypt.DeleteSubCommands()...)
 	deleteCmd.AddCommand(project.DeleteSubCommands()...)
 	deleteCmd.AddCommand(remoteservices.DeleteSubCommands()...)
+	deleteCmd.AddCommand(graphql.DeleteSubCommands()...)
 
 	return deleteCmd
 }
@@ -0,0 +1,243 @@
+package eventing
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/ghodss/yaml"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/spaceuptech/space-cloud/space-cli/cmd/model"
+	"github.com/spaceuptech/space-cloud/space-cli/cmd/utils"
+	"github.com/spaceuptech/space-cloud/space-cli/cmd/utils/transport"
+)
+
+// DLQCommands is the list of commands to inspect and drain the dead letter queue of eventing
+func DLQCommands() []*cobra.Command {
+	bindFilterFlags := func(cmd *cobra.Command, args []string) {
+		for _, flag := range []string{"trigger", "status", "from", "to", "limit", "skip", "yes"} {
+			if cmd.Flags().Lookup(flag) == nil {
+				continue
+			}
+			if err := viper.BindPFlag(flag, cmd.Flags().Lookup(flag)); err != nil {
+				_ = utils.LogError(fmt.Sprintf("Unable to bind the flag ('%s')", flag), nil)
+			}
+		}
+	}
+
+	var dlq = &cobra.Command{
+		Use:   "dlq",
+		Short: "Inspect, replay and discard the failed events of eventing",
+	}
+
+	var list = &cobra.Command{
+		Use:     "list",
+		Short:   "List the failed events",
+		PreRun:  bindFilterFlags,
+		RunE:    actionListDLQEvents,
+		Example: "space-cli dlq list --trigger send-email --from 2020-10-01T00:00:00Z --project myproject",
+	}
+	list.Flags().StringP("status", "", "", "Status of the events to list (failed or discarded). Defaults to failed")
+	list.Flags().Int64P("limit", "", 100, "Maximum number of events to list")
+	list.Flags().Int64P("skip", "", 0, "Number of events to skip")
+
+	var get = &cobra.Command{
+		Use:     "get [event-id]",
+		Short:   "Get a failed event along with its invocations",
+		RunE:    actionGetDLQEvent,
+		Example: "space-cli dlq get 1kIgKTTsBxqE5QbVVzrhzhhQPLd --project myproject",
+	}
+
+	var replay = &cobra.Command{
+		Use:     "replay [event-ids...]",
+		Short:   "Replay the failed events",
+		PreRun:  bindFilterFlags,
+		RunE:    actionReplayDLQEvents,
+		Example: "1) space-cli dlq replay 1kIgKTTsBxqE5QbVVzrhzhhQPLd --project myproject\n2) space-cli dlq replay --trigger send-email --from 2020-10-01T00:00:00Z --project myproject",
+	}
+
+	var discard = &cobra.Command{
+		Use:     "discard [event-ids...]",
+		Short:   "Discard the failed events",
+		PreRun:  bindFilterFlags,
+		RunE:    actionDiscardDLQEvents,
+		Example: "space-cli dlq discard --trigger send-email --to 2020-10-01T00:00:00Z --project myproject",
+	}
+
+	for _, cmd := range []*cobra.Command{list, replay, discard} {
+		cmd.Flags().StringP("trigger", "", "", "Only select the events of this trigger")
+		cmd.Flags().StringP("from", "", "", "Only select the events scheduled after this time (RFC3339)")
+		cmd.Flags().StringP("to", "", "", "Only select the events scheduled before this time (RFC3339)")
+	}
+	for _, cmd := range []*cobra.Command{replay, discard} {
+		cmd.Flags().BoolP("yes", "y", false, "Skip the confirmation prompt")
+	}
+
+	dlq.AddCommand(list, get, replay, discard)
+	return []*cobra.Command{dlq}
+}
+
+func actionListDLQEvents(cmd *cobra.Command, args []string) error {
+	project, check := utils.GetProjectID()
+	if !check {
+		return utils.LogError("Project not specified in flag", nil)
+	}
+
+	params := map[string]string{}
+	for _, key := range []string{"trigger", "status", "from", "to"} {
+		if value := viper.GetString(key); value != "" {
+			params[key] = value
+		}
+	}
+	params["limit"] = strconv.FormatInt(viper.GetInt64("limit"), 10)
+	params["skip"] = strconv.FormatInt(viper.GetInt64("skip"), 10)
+
+	events, err := ListDLQEvents(project, params)
+	if err != nil {
+		return err
+	}
+	return printObject(events)
+}
+
+func actionGetDLQEvent(cmd *cobra.Command, args []string) error {
+	if len(args) != 1 {
+		return utils.LogError("incorrect number of arguments. Use -h to check usage instructions", nil)
+	}
+	project, check := utils.GetProjectID()
+	if !check {
+		return utils.LogError("Project not specified in flag", nil)
+	}
+
+	event, err := GetDLQEvent(project, args[0])
+	if err != nil {
+		return err
+	}
+	return printObject(event)
+}
+
+func actionReplayDLQEvents(cmd *cobra.Command, args []string) error {
+	project, check := utils.GetProjectID()
+	if !check {
+		return utils.LogError("Project not specified in flag", nil)
+	}
+
+	filter := getDLQFilter(args)
+	if !confirmDLQOperation("replay", filter) {
+		return nil
+	}
+
+	count, err := ReplayDLQEvents(project, filter)
+	if err != nil {
+		return err
+	}
+	utils.LogInfo(fmt.Sprintf("Successfully replayed %d events", count))
+	if count == model.DLQBatchSize {
+		utils.LogInfo("More events might be left in the dead letter queue. Run the command again to replay them")
+	}
+	return nil
+}
+
+func actionDiscardDLQEvents(cmd *cobra.Command, args []string) error {
+	project, check := utils.GetProjectID()
+	if !check {
+		return utils.LogError("Project not specified in flag", nil)
+	}
+
+	filter := getDLQFilter(args)
+	if !confirmDLQOperation("discard", filter) {
+		return nil
+	}
+
+	if err := DiscardDLQEvents(project, filter); err != nil {
+		return err
+	}
+	utils.LogInfo("Successfully discarded events")
+	return nil
+}
+
+// ListDLQEvents lists the failed events of the project
+func ListDLQEvents(project string, params map[string]string) ([]interface{}, error) {
+	url := fmt.Sprintf("/v1/external/projects/%s/eventing/dlq", project)
+
+	payload := new(model.Response)
+	if err := transport.Client.MakeHTTPRequest(http.MethodGet, url, params, payload); err != nil {
+		return nil, err
+	}
+	return payload.Result, nil
+}
+
+// GetDLQEvent gets a failed event along with its invocations
+func GetDLQEvent(project, id string) (map[string]interface{}, error) {
+	url := fmt.Sprintf("/v1/external/projects/%s/eventing/dlq/%s", project, id)
+
+	payload := new(struct {
+		Result map[string]interface{} `json:"result"`
+	})
+	if err := transport.Client.MakeHTTPRequest(http.MethodGet, url, map[string]string{}, payload); err != nil {
+		return nil, err
+	}
+	return payload.Result, nil
+}
+
+// ReplayDLQEvents replays the failed events matching the filter and returns the number of replayed events
+func ReplayDLQEvents(project string, filter map[string]interface{}) (int, error) {
+	url := fmt.Sprintf("/v1/external/projects/%s/eventing/dlq/replay", project)
+
+	payload := new(struct {
+		Result struct {
+			Replayed int `json:"replayed"`
+		} `json:"result"`
+	})
+	if err := transport.Client.MakeHTTPRequestWithBody(http.MethodPost, url, filter, payload); err != nil {
+		return 0, err
+	}
+	return payload.Result.Replayed, nil
+}
+
+// DiscardDLQEvents discards the failed events matching the filter
+func DiscardDLQEvents(project string, filter map[string]interface{}) error {
+	url := fmt.Sprintf("/v1/external/projects/%s/eventing/dlq/discard", project)
+	return transport.Client.MakeHTTPRequestWithBody(http.MethodPost, url, filter, new(model.Response))
+}
+
+func getDLQFilter(ids []string) map[string]interface{} {
+	filter := map[string]interface{}{}
+	if len(ids) > 0 {
+		filter["ids"] = ids
+	}
+	for _, key := range []string{"trigger", "from", "to"} {
+		if value := viper.GetString(key); value != "" {
+			filter[key] = value
+		}
+	}
+	return filter
+}
+
+func confirmDLQOperation(op string, filter map[string]interface{}) bool {
+	if viper.GetBool("yes") {
+		return true
+	}
+
+	message := fmt.Sprintf("All events matching the filter %v will be %sed. Do you want to continue?", filter, op)
+	if len(filter) == 0 {
+		message = fmt.Sprintf("All failed events of the project will be %sed. Do you want to continue?", op)
+	}
+
+	isOk := false
+	if err := survey.AskOne(&survey.Confirm{Message: message}, &isOk); err != nil {
+		return false
+	}
+	return isOk
+}
+
+func printObject(obj interface{}) error {
+	b, err := yaml.Marshal(obj)
+	if err != nil {
+		return err
+	}
+	fmt.Print(string(b))
+	return nil
+}
@@ -0,0 +1,89 @@
+package eventing
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/spaceuptech/space-cloud/space-cli/cmd/model"
+	"github.com/spaceuptech/space-cloud/space-cli/cmd/utils/transport"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestListDLQEvents(t *testing.T) {
+	tests := []struct {
+		name           string
+		params         map[string]string
+		paramsReturned []interface{}
+		want           []interface{}
+		wantErr        bool
+	}{
+		{
+			name:           "events are listed",
+			params:         map[string]string{"trigger": "send-email"},
+			paramsReturned: []interface{}{nil, model.Response{Result: []interface{}{map[string]interface{}{"_id": "1", "rule_name": "send-email"}}}},
+			want:           []interface{}{map[string]interface{}{"_id": "1", "rule_name": "send-email"}},
+		},
+		{
+			name:           "server returns an error",
+			params:         map[string]string{},
+			paramsReturned: []interface{}{errors.New("unauthorized"), model.Response{}},
+			wantErr:        true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTransport := transport.MocketAuthProviders{}
+			mockTransport.On("MakeHTTPRequest", "GET", "/v1/external/projects/myproject/eventing/dlq", tt.params, mock.Anything).Return(tt.paramsReturned...)
+			transport.Client = &mockTransport
+
+			got, err := ListDLQEvents("myproject", tt.params)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ListDLQEvents() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ListDLQEvents() got = %v, want %v", got, tt.want)
+			}
+			mockTransport.AssertExpectations(t)
+		})
+	}
+}
+
+func TestReplayDLQEvents(t *testing.T) {
+	tests := []struct {
+		name           string
+		filter         map[string]interface{}
+		paramsReturned []interface{}
+		want           int
+		wantErr        bool
+	}{
+		{
+			name:           "events are replayed",
+			filter:         map[string]interface{}{"ids": []string{"1", "2"}},
+			paramsReturned: []interface{}{nil, map[string]interface{}{"result": map[string]interface{}{"replayed": 2}}},
+			want:           2,
+		},
+		{
+			name:           "server returns an error",
+			filter:         map[string]interface{}{"trigger": "send-email"},
+			paramsReturned: []interface{}{errors.New("unauthorized"), map[string]interface{}{}},
+			wantErr:        true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTransport := transport.MocketAuthProviders{}
+			mockTransport.On("MakeHTTPRequestWithBody", "POST", "/v1/external/projects/myproject/eventing/dlq/replay", tt.filter, mock.Anything).Return(tt.paramsReturned...)
+			transport.Client = &mockTransport
+
+			got, err := ReplayDLQEvents("myproject", tt.filter)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ReplayDLQEvents() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ReplayDLQEvents() got = %v, want %v", got, tt.want)
+			}
+			mockTransport.AssertExpectations(t)
+		})
+	}
+}
@@ -56,7 +56,7 @@ func generateFilestoreConfig() (*model.SpecObject, error) {
 	}
 
 	storeType := ""
-	if err := input.Survey.AskOne(&survey.Select{Message: "Enter Storetype", Options: []string{"local", "amazon-s3", "gcp-storage"}}, &storeType); err != nil {
+	if err := input.Survey.AskOne(&survey.Select{Message: "Enter Storetype", Options: []string{"local", "amazon-s3", "gcp-storage", "azure-blob"}}, &storeType); err != nil {
 		return nil, err
 	}
 	bucket := ""
@@ -81,6 +81,13 @@ func generateFilestoreConfig() (*model.SpecObject, error) {
 		if err := input.Survey.AskOne(&survey.Input{Message: "Enter bucket"}, &bucket); err != nil {
 			return nil, err
 		}
+	case "azure-blob":
+		if err := input.Survey.AskOne(&survey.Input{Message: "Enter connection string or SAS url"}, &conn); err != nil {
+			return nil, err
+		}
+		if err := input.Survey.AskOne(&survey.Input{Message: "Enter container"}, &bucket); err != nil {
+			return nil, err
+		}
 	default:
 		return nil, fmt.Errorf("Invalid choice")
 	}
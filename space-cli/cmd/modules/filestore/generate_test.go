@@ -177,7 +177,7 @@ func Test_generateFilestoreConfig(t *testing.T) {
 				},
 				{
 					method:         "AskOne",
-					args:           []interface{}{&survey.Select{Message: "Enter Storetype", Options: []string{"local", "amazon-s3", "gcp-storage"}}, &surveyReturnValue, mock.Anything},
+					args:           []interface{}{&survey.Select{Message: "Enter Storetype", Options: []string{"local", "amazon-s3", "gcp-storage", "azure-blob"}}, &surveyReturnValue, mock.Anything},
 					paramsReturned: []interface{}{errors.New("unable to call AskOne"), ""},
 				},
 			},
@@ -193,7 +193,7 @@ func Test_generateFilestoreConfig(t *testing.T) {
 				},
 				{
 					method:         "AskOne",
-					args:           []interface{}{&survey.Select{Message: "Enter Storetype", Options: []string{"local", "amazon-s3", "gcp-storage"}}, &surveyReturnValue, mock.Anything},
+					args:           []interface{}{&survey.Select{Message: "Enter Storetype", Options: []string{"local", "amazon-s3", "gcp-storage", "azure-blob"}}, &surveyReturnValue, mock.Anything},
 					paramsReturned: []interface{}{nil, "default"},
 				},
 			},
@@ -209,7 +209,7 @@ func Test_generateFilestoreConfig(t *testing.T) {
 				},
 				{
 					method:         "AskOne",
-					args:           []interface{}{&survey.Select{Message: "Enter Storetype", Options: []string{"local", "amazon-s3", "gcp-storage"}}, &surveyReturnValue, mock.Anything},
+					args:           []interface{}{&survey.Select{Message: "Enter Storetype", Options: []string{"local", "amazon-s3", "gcp-storage", "azure-blob"}}, &surveyReturnValue, mock.Anything},
 					paramsReturned: []interface{}{nil, "local"},
 				},
 				{
@@ -230,7 +230,7 @@ func Test_generateFilestoreConfig(t *testing.T) {
 				},
 				{
 					method:         "AskOne",
-					args:           []interface{}{&survey.Select{Message: "Enter Storetype", Options: []string{"local", "amazon-s3", "gcp-storage"}}, &surveyReturnValue, mock.Anything},
+					args:           []interface{}{&survey.Select{Message: "Enter Storetype", Options: []string{"local", "amazon-s3", "gcp-storage", "azure-blob"}}, &surveyReturnValue, mock.Anything},
 					paramsReturned: []interface{}{nil, "amazon-s3"},
 				},
 				{
@@ -251,7 +251,7 @@ func Test_generateFilestoreConfig(t *testing.T) {
 				},
 				{
 					method:         "AskOne",
-					args:           []interface{}{&survey.Select{Message: "Enter Storetype", Options: []string{"local", "amazon-s3", "gcp-storage"}}, &surveyReturnValue, mock.Anything},
+					args:           []interface{}{&survey.Select{Message: "Enter Storetype", Options: []string{"local", "amazon-s3", "gcp-storage", "azure-blob"}}, &surveyReturnValue, mock.Anything},
 					paramsReturned: []interface{}{nil, "amazon-s3"},
 				},
 				{
@@ -277,7 +277,7 @@ func Test_generateFilestoreConfig(t *testing.T) {
 				},
 				{
 					method:         "AskOne",
-					args:           []interface{}{&survey.Select{Message: "Enter Storetype", Options: []string{"local", "amazon-s3", "gcp-storage"}}, &surveyReturnValue, mock.Anything},
+					args:           []interface{}{&survey.Select{Message: "Enter Storetype", Options: []string{"local", "amazon-s3", "gcp-storage", "azure-blob"}}, &surveyReturnValue, mock.Anything},
 					paramsReturned: []interface{}{nil, "amazon-s3"},
 				},
 				{
@@ -308,7 +308,7 @@ func Test_generateFilestoreConfig(t *testing.T) {
 				},
 				{
 					method:         "AskOne",
-					args:           []interface{}{&survey.Select{Message: "Enter Storetype", Options: []string{"local", "amazon-s3", "gcp-storage"}}, &surveyReturnValue, mock.Anything},
+					args:           []interface{}{&survey.Select{Message: "Enter Storetype", Options: []string{"local", "amazon-s3", "gcp-storage", "azure-blob"}}, &surveyReturnValue, mock.Anything},
 					paramsReturned: []interface{}{nil, "amazon-s3"},
 				},
 				{
@@ -353,7 +353,7 @@ func Test_generateFilestoreConfig(t *testing.T) {
 				},
 				{
 					method:         "AskOne",
-					args:           []interface{}{&survey.Select{Message: "Enter Storetype", Options: []string{"local", "amazon-s3", "gcp-storage"}}, &surveyReturnValue, mock.Anything},
+					args:           []interface{}{&survey.Select{Message: "Enter Storetype", Options: []string{"local", "amazon-s3", "gcp-storage", "azure-blob"}}, &surveyReturnValue, mock.Anything},
 					paramsReturned: []interface{}{nil, "gcp-storage"},
 				},
 				{
@@ -374,7 +374,7 @@ func Test_generateFilestoreConfig(t *testing.T) {
 				},
 				{
 					method:         "AskOne",
-					args:           []interface{}{&survey.Select{Message: "Enter Storetype", Options: []string{"local", "amazon-s3", "gcp-storage"}}, &surveyReturnValue, mock.Anything},
+					args:           []interface{}{&survey.Select{Message: "Enter Storetype", Options: []string{"local", "amazon-s3", "gcp-storage", "azure-blob"}}, &surveyReturnValue, mock.Anything},
 					paramsReturned: []interface{}{nil, "gcp-storage"},
 				},
 				{
@@ -399,6 +399,72 @@ func Test_generateFilestoreConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "error surveying container with storetype azure-blob",
+			surveyMockArgs: []mockArgs{
+				{
+					method:         "AskOne",
+					args:           []interface{}{&survey.Input{Message: "Enter Project ID"}, &surveyReturnValue, mock.Anything},
+					paramsReturned: []interface{}{nil, ""},
+				},
+				{
+					method:         "AskOne",
+					args:           []interface{}{&survey.Select{Message: "Enter Storetype", Options: []string{"local", "amazon-s3", "gcp-storage", "azure-blob"}}, &surveyReturnValue, mock.Anything},
+					paramsReturned: []interface{}{nil, "azure-blob"},
+				},
+				{
+					method:         "AskOne",
+					args:           []interface{}{&survey.Input{Message: "Enter connection string or SAS url"}, &surveyReturnValue, mock.Anything},
+					paramsReturned: []interface{}{nil, "connection-string"},
+				},
+				{
+					method:         "AskOne",
+					args:           []interface{}{&survey.Input{Message: "Enter container"}, &surveyReturnValue, mock.Anything},
+					paramsReturned: []interface{}{errors.New("unable to call AskOne"), ""},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "file store config spec object created with store type azure",
+			surveyMockArgs: []mockArgs{
+				{
+					method:         "AskOne",
+					args:           []interface{}{&survey.Input{Message: "Enter Project ID"}, &surveyReturnValue, mock.Anything},
+					paramsReturned: []interface{}{nil, "project"},
+				},
+				{
+					method:         "AskOne",
+					args:           []interface{}{&survey.Select{Message: "Enter Storetype", Options: []string{"local", "amazon-s3", "gcp-storage", "azure-blob"}}, &surveyReturnValue, mock.Anything},
+					paramsReturned: []interface{}{nil, "azure-blob"},
+				},
+				{
+					method:         "AskOne",
+					args:           []interface{}{&survey.Input{Message: "Enter connection string or SAS url"}, &surveyReturnValue, mock.Anything},
+					paramsReturned: []interface{}{nil, "connection-string"},
+				},
+				{
+					method:         "AskOne",
+					args:           []interface{}{&survey.Input{Message: "Enter container"}, &surveyReturnValue, mock.Anything},
+					paramsReturned: []interface{}{nil, "container"},
+				},
+			},
+			want: &model.SpecObject{
+				API:  "/v1/config/projects/{project}/file-storage/config/{id}",
+				Type: "filestore-config",
+				Meta: map[string]string{
+					"project": "project",
+					"id":      "filestore-config",
+				},
+				Spec: map[string]interface{}{
+					"bucket":    "container",
+					"conn":      "connection-string",
+					"enabled":   true,
+					"endpoint":  "",
+					"storeType": "azure-blob",
+				},
+			},
+		},
 		{
 			name: "file store config spec object created with store type local",
 			surveyMockArgs: []mockArgs{
@@ -409,7 +475,7 @@ func Test_generateFilestoreConfig(t *testing.T) {
 				},
 				{
 					method:         "AskOne",
-					args:           []interface{}{&survey.Select{Message: "Enter Storetype", Options: []string{"local", "amazon-s3", "gcp-storage"}}, &surveyReturnValue, mock.Anything},
+					args:           []interface{}{&survey.Select{Message: "Enter Storetype", Options: []string{"local", "amazon-s3", "gcp-storage", "azure-blob"}}, &surveyReturnValue, mock.Anything},
 					paramsReturned: []interface{}{nil, "local"},
 				},
 				{
@@ -8,6 +8,7 @@ import (
 	"github.com/spaceuptech/space-cloud/space-cli/cmd/modules/database"
 	"github.com/spaceuptech/space-cloud/space-cli/cmd/modules/eventing"
 	"github.com/spaceuptech/space-cloud/space-cli/cmd/modules/filestore"
+	"github.com/spaceuptech/space-cloud/space-cli/cmd/modules/graphql"
 	"github.com/spaceuptech/space-cloud/space-cli/cmd/modules/ingress"
 	"github.com/spaceuptech/space-cloud/space-cli/cmd/modules/letsencrypt"
 	"github.com/spaceuptech/space-cloud/space-cli/cmd/modules/project"
@@ -37,6 +38,7 @@ func FetchGetSubCommands() *cobra.Command {
 	getCmd.AddCommand(letsencrypt.GetSubCommands()...)
 	getCmd.AddCommand(project.GetSubCommands()...)
 	getCmd.AddCommand(remoteservices.GetSubCommands()...)
+	getCmd.AddCommand(graphql.GetSubCommands()...)
 	getCmd.AddCommand(services.GetSubCommands()...)
 	getCmd.AddCommand(getSubCommands()...)
 
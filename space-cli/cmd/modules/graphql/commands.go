@@ -0,0 +1,72 @@
+package graphql
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/spaceuptech/space-cloud/space-cli/cmd/utils"
+)
+
+// GetSubCommands is the list of commands the graphql module exposes
+func GetSubCommands() []*cobra.Command {
+
+	var getPersistedQueries = &cobra.Command{
+		Use:               "graphql-persisted-queries",
+		Aliases:           []string{"graphql-persisted-query"},
+		RunE:              actionGetPersistedQueries,
+		ValidArgsFunction: persistedQueriesAutoCompleteFun,
+		Example:           "space-cli get graphql-persisted-queries --project myproject",
+	}
+	return []*cobra.Command{getPersistedQueries}
+}
+
+func actionGetPersistedQueries(cmd *cobra.Command, args []string) error {
+	// Get the project and url parameters
+	project, check := utils.GetProjectID()
+	if !check {
+		return utils.LogError("Project not specified in flag", nil)
+	}
+	commandName := "graphql-persisted-query"
+
+	params := map[string]string{}
+	if len(args) != 0 {
+		params["id"] = args[0]
+	}
+
+	objs, err := GetPersistedQueries(project, commandName, params)
+	if err != nil {
+		return err
+	}
+
+	if err := utils.PrintYaml(objs); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DeleteSubCommands is the list of commands the graphql module exposes
+func DeleteSubCommands() []*cobra.Command {
+
+	var deletePersistedQueries = &cobra.Command{
+		Use:               "graphql-persisted-queries",
+		Aliases:           []string{"graphql-persisted-query"},
+		RunE:              actionDeletePersistedQueries,
+		ValidArgsFunction: persistedQueriesAutoCompleteFun,
+		Example:           "space-cli delete graphql-persisted-queries queryHash --project myproject",
+	}
+	return []*cobra.Command{deletePersistedQueries}
+}
+
+func actionDeletePersistedQueries(cmd *cobra.Command, args []string) error {
+	// Get the project
+	project, check := utils.GetProjectID()
+	if !check {
+		return utils.LogError("Project not specified in flag", nil)
+	}
+
+	prefix := ""
+	if len(args) != 0 {
+		prefix = args[0]
+	}
+
+	return deletePersistedQuery(project, prefix)
+}
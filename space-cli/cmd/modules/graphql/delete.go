@@ -0,0 +1,37 @@
+package graphql
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/spaceuptech/space-cloud/space-cli/cmd/model"
+	"github.com/spaceuptech/space-cloud/space-cli/cmd/utils/filter"
+	"github.com/spaceuptech/space-cloud/space-cli/cmd/utils/transport"
+)
+
+func deletePersistedQuery(project, prefix string) error {
+
+	objs, err := GetPersistedQueries(project, "graphql-persisted-query", map[string]string{})
+	if err != nil {
+		return err
+	}
+
+	queryIDs := []string{}
+	for _, spec := range objs {
+		queryIDs = append(queryIDs, spec.Meta["id"])
+	}
+
+	resourceID, err := filter.DeleteOptions(prefix, queryIDs)
+	if err != nil {
+		return err
+	}
+
+	// Delete the persisted query from the server
+	url := fmt.Sprintf("/v1/config/projects/%s/graphql/persisted-queries/%s", project, resourceID)
+
+	if err := transport.Client.MakeHTTPRequest(http.MethodDelete, url, map[string]string{}, new(model.Response)); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+package graphql
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/spaceuptech/space-cloud/space-cli/cmd/model"
+	"github.com/spaceuptech/space-cloud/space-cli/cmd/utils/transport"
+)
+
+func TestGetPersistedQueries(t *testing.T) {
+	type mockArgs struct {
+		method         string
+		args           []interface{}
+		paramsReturned []interface{}
+	}
+	type args struct {
+		project     string
+		commandName string
+		params      map[string]string
+	}
+	tests := []struct {
+		name              string
+		args              args
+		transportMockArgs []mockArgs
+		want              []*model.SpecObject
+		wantErr           bool
+	}{
+		{
+			name: "Successful test",
+			args: args{project: "myproject", commandName: "graphql-persisted-query", params: map[string]string{}},
+			transportMockArgs: []mockArgs{
+				{
+					method: "MakeHTTPRequest",
+					args:   []interface{}{http.MethodGet, "/v1/config/projects/myproject/graphql/persisted-queries", map[string]string{}, new(model.Response)},
+					paramsReturned: []interface{}{nil, model.Response{
+						Result: []interface{}{map[string]interface{}{"id": "7f56e67dd21ab3f30d1ff8b7bed08893f0a0db86449836189b361dd1e56ddb4b", "name": "typename", "query": "{ __typename }"}},
+					}},
+				},
+			},
+			want: []*model.SpecObject{
+				{
+					API:  "/v1/config/projects/{project}/graphql/persisted-queries/{id}",
+					Type: "graphql-persisted-query",
+					Meta: map[string]string{"project": "myproject", "id": "7f56e67dd21ab3f30d1ff8b7bed08893f0a0db86449836189b361dd1e56ddb4b"},
+					Spec: map[string]interface{}{"name": "typename", "query": "{ __typename }"},
+				},
+			},
+		},
+		{
+			name: "Unable to get persisted queries",
+			args: args{project: "myproject", commandName: "graphql-persisted-query", params: map[string]string{}},
+			transportMockArgs: []mockArgs{
+				{
+					method:         "MakeHTTPRequest",
+					args:           []interface{}{http.MethodGet, "/v1/config/projects/myproject/graphql/persisted-queries", map[string]string{}, new(model.Response)},
+					paramsReturned: []interface{}{fmt.Errorf("cannot unmarshal"), model.Response{}},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTransport := transport.MocketAuthProviders{}
+
+			for _, m := range tt.transportMockArgs {
+				mockTransport.On(m.method, m.args...).Return(m.paramsReturned...)
+			}
+
+			transport.Client = &mockTransport
+			got, err := GetPersistedQueries(tt.args.project, tt.args.commandName, tt.args.params)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetPersistedQueries() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetPersistedQueries() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -40,7 +40,7 @@ func generateIngressRouting() (*model.SpecObject, error) {
 	}
 
 	routingType := ""
-	if err := input.Survey.AskOne(&survey.Select{Message: "Select routing type", Options: []string{"prefix", "exact"}}, &routingType); err != nil {
+	if err := input.Survey.AskOne(&survey.Select{Message: "Select routing type", Options: []string{"prefix", "exact", "path", "regex"}}, &routingType); err != nil {
 		return nil, err
 	}
 	var target []interface{}
@@ -167,7 +167,7 @@ func Test_generateIngressRouting(t *testing.T) {
 				},
 				{
 					method:         "AskOne",
-					args:           []interface{}{&survey.Select{Message: "Select routing type", Options: []string{"prefix", "exact"}}, &surveyReturnValue, mock.Anything},
+					args:           []interface{}{&survey.Select{Message: "Select routing type", Options: []string{"prefix", "exact", "path", "regex"}}, &surveyReturnValue, mock.Anything},
 					paramsReturned: []interface{}{errors.New("unable to call AskOne"), ""},
 				},
 			},
@@ -203,7 +203,7 @@ func Test_generateIngressRouting(t *testing.T) {
 				},
 				{
 					method:         "AskOne",
-					args:           []interface{}{&survey.Select{Message: "Select routing type", Options: []string{"prefix", "exact"}}, &surveyReturnValue, mock.Anything},
+					args:           []interface{}{&survey.Select{Message: "Select routing type", Options: []string{"prefix", "exact", "path", "regex"}}, &surveyReturnValue, mock.Anything},
 					paramsReturned: []interface{}{nil, ""},
 				},
 				{
@@ -244,7 +244,7 @@ func Test_generateIngressRouting(t *testing.T) {
 				},
 				{
 					method:         "AskOne",
-					args:           []interface{}{&survey.Select{Message: "Select routing type", Options: []string{"prefix", "exact"}}, &surveyReturnValue, mock.Anything},
+					args:           []interface{}{&survey.Select{Message: "Select routing type", Options: []string{"prefix", "exact", "path", "regex"}}, &surveyReturnValue, mock.Anything},
 					paramsReturned: []interface{}{nil, ""},
 				},
 				{
@@ -290,7 +290,7 @@ func Test_generateIngressRouting(t *testing.T) {
 				},
 				{
 					method:         "AskOne",
-					args:           []interface{}{&survey.Select{Message: "Select routing type", Options: []string{"prefix", "exact"}}, &surveyReturnValue, mock.Anything},
+					args:           []interface{}{&survey.Select{Message: "Select routing type", Options: []string{"prefix", "exact", "path", "regex"}}, &surveyReturnValue, mock.Anything},
 					paramsReturned: []interface{}{nil, ""},
 				},
 				{
@@ -341,7 +341,7 @@ func Test_generateIngressRouting(t *testing.T) {
 				},
 				{
 					method:         "AskOne",
-					args:           []interface{}{&survey.Select{Message: "Select routing type", Options: []string{"prefix", "exact"}}, &surveyReturnValue, mock.Anything},
+					args:           []interface{}{&survey.Select{Message: "Select routing type", Options: []string{"prefix", "exact", "path", "regex"}}, &surveyReturnValue, mock.Anything},
 					paramsReturned: []interface{}{nil, ""},
 				},
 				{
@@ -397,7 +397,7 @@ func Test_generateIngressRouting(t *testing.T) {
 				},
 				{
 					method:         "AskOne",
-					args:           []interface{}{&survey.Select{Message: "Select routing type", Options: []string{"prefix", "exact"}}, &surveyReturnValue, mock.Anything},
+					args:           []interface{}{&survey.Select{Message: "Select routing type", Options: []string{"prefix", "exact", "path", "regex"}}, &surveyReturnValue, mock.Anything},
 					paramsReturned: []interface{}{nil, ""},
 				},
 				{
@@ -458,7 +458,7 @@ func Test_generateIngressRouting(t *testing.T) {
 				},
 				{
 					method:         "AskOne",
-					args:           []interface{}{&survey.Select{Message: "Select routing type", Options: []string{"prefix", "exact"}}, &surveyReturnValue, mock.Anything},
+					args:           []interface{}{&survey.Select{Message: "Select routing type", Options: []string{"prefix", "exact", "path", "regex"}}, &surveyReturnValue, mock.Anything},
 					paramsReturned: []interface{}{nil, ""},
 				},
 				{
@@ -518,7 +518,7 @@ func Test_generateIngressRouting(t *testing.T) {
 				},
 				{
 					method:         "AskOne",
-					args:           []interface{}{&survey.Select{Message: "Select routing type", Options: []string{"prefix", "exact"}}, &surveyReturnValue, mock.Anything},
+					args:           []interface{}{&survey.Select{Message: "Select routing type", Options: []string{"prefix", "exact", "path", "regex"}}, &surveyReturnValue, mock.Anything},
 					paramsReturned: []interface{}{nil, "prefix"},
 				},
 				{
@@ -183,12 +183,16 @@ func Commands() []*cobra.Command {
 			if err := viper.BindPFlag("retry", cmd.Flags().Lookup("retry")); err != nil {
 				_ = utils.LogError("Unable to bind the flag ('retry')", err)
 			}
+			if err := viper.BindPFlag("dry-run", cmd.Flags().Lookup("dry-run")); err != nil {
+				_ = utils.LogError("Unable to bind the flag ('dry-run')", err)
+			}
 		},
 	}
 	apply.Flags().DurationP("delay", "", time.Duration(0), "Adds a delay between 2 subsequent request made by space cli to space cloud")
 	apply.Flags().BoolP("force", "", false, "Doesn't show warning prompts if some risky changes are made to the config")
 	apply.Flags().StringP("file", "f", "", "Path to the resource yaml file or directory")
 	apply.Flags().IntP("retry", "r", 1, "Number of retries in case of failure")
+	apply.Flags().BoolP("dry-run", "", false, "Shows the changes applying the config would make without applying it")
 	err = viper.BindEnv("file", "FILE")
 	if err != nil {
 		_ = utils.LogError("Unable to bind flag ('file') to environment variables", nil)
@@ -291,6 +295,9 @@ func actionApply(cmd *cobra.Command, args []string) error {
 	} else {
 		dirName = file
 	}
+	if viper.GetBool("dry-run") {
+		return Plan(dirName)
+	}
 	return Apply(dirName, isForce, delay, retry)
 }
 
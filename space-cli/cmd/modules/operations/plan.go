@@ -0,0 +1,105 @@
+package operations
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ghodss/yaml"
+
+	"github.com/spaceuptech/space-cloud/space-cli/cmd/model"
+	"github.com/spaceuptech/space-cloud/space-cli/cmd/utils"
+	"github.com/spaceuptech/space-cloud/space-cli/cmd/utils/transport"
+)
+
+// Plan reads the config file(s) from the provided file / directory and shows the changes applying them would make to
+// the server without applying them. It returns an error if any of the spec objects is invalid
+func Plan(applyName string) error {
+	specs, err := readSpecObjects(applyName)
+	if err != nil {
+		return err
+	}
+
+	plan, err := PlanSpecs(specs)
+	if err != nil {
+		return utils.LogError("Unable to plan the spec objects", err)
+	}
+
+	b, err := yaml.Marshal(plan)
+	if err != nil {
+		return err
+	}
+	fmt.Print(string(b))
+
+	actions, invalid := summarisePlan(plan)
+	utils.LogInfo(fmt.Sprintf("Plan: %d to create, %d to update, %d unchanged, %d unsupported", actions["create"], actions["update"], actions["no-change"], actions["unsupported"]))
+	if invalid > 0 {
+		return utils.LogError(fmt.Sprintf("%d spec object(s) are invalid", invalid), nil)
+	}
+	return nil
+}
+
+// PlanSpecs sends the spec objects to the server in dry run mode and returns the plan for every spec object
+func PlanSpecs(specs []*model.SpecObject) ([]interface{}, error) {
+	payload := new(model.Response)
+	if err := transport.Client.MakeHTTPRequestWithBody(http.MethodPost, "/v1/config/batch-apply?dryRun=true", map[string]interface{}{"specs": specs}, payload); err != nil {
+		return nil, err
+	}
+	return payload.Result, nil
+}
+
+// summarisePlan returns the number of spec objects per action and the number of invalid spec objects
+func summarisePlan(plan []interface{}) (map[string]int, int) {
+	actions := map[string]int{}
+	invalid := 0
+	for _, item := range plan {
+		obj, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if action, ok := obj["action"].(string); ok {
+			actions[action]++
+		}
+		if errs, ok := obj["errors"].([]interface{}); ok && len(errs) > 0 {
+			invalid++
+		}
+	}
+	return actions, invalid
+}
+
+// readSpecObjects reads the spec objects from the provided file or from all the yaml files of the provided directory
+func readSpecObjects(applyName string) ([]*model.SpecObject, error) {
+	if strings.HasSuffix(applyName, ".yaml") {
+		specs, err := utils.ReadSpecObjectsFromFile(applyName)
+		if err != nil {
+			return nil, utils.LogError("Unable to read spec objects from file", err)
+		}
+		return specs, nil
+	}
+
+	files, err := ioutil.ReadDir(applyName)
+	if err != nil {
+		return nil, utils.LogError(fmt.Sprintf("Unable to fetch config files from %s", applyName), err)
+	}
+
+	var fileNames []string
+	for _, fileInfo := range files {
+		if !fileInfo.IsDir() && strings.HasSuffix(fileInfo.Name(), ".yaml") {
+			fileNames = append(fileNames, fileInfo.Name())
+		}
+	}
+	sort.Strings(fileNames)
+
+	var specs []*model.SpecObject
+	for _, fileName := range fileNames {
+		fileSpecs, err := utils.ReadSpecObjectsFromFile(filepath.Join(applyName, fileName))
+		if err != nil {
+			return nil, utils.LogError(fmt.Sprintf("Unable to read spec objects from file (%s)", fileName), err)
+		}
+		specs = append(specs, fileSpecs...)
+	}
+	return specs, nil
+}
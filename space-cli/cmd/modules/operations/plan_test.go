@@ -0,0 +1,82 @@
+package operations
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/spaceuptech/space-cloud/space-cli/cmd/model"
+	"github.com/spaceuptech/space-cloud/space-cli/cmd/utils/transport"
+)
+
+func TestPlanSpecs(t *testing.T) {
+	specs := []*model.SpecObject{
+		{
+			API:  "/v1/config/projects/{project}/database/{dbAlias}/collections/{col}/rules",
+			Type: "db-rules",
+			Meta: map[string]string{"project": "myproject", "dbAlias": "db", "col": "users"},
+			Spec: map[string]interface{}{"rules": map[string]interface{}{"read": map[string]interface{}{"rule": "deny"}}},
+		},
+	}
+	plan := []interface{}{
+		map[string]interface{}{
+			"type":       "db-rules",
+			"resourceId": "local--myproject--db-rule--db-users-rule",
+			"action":     "update",
+			"diff":       []interface{}{map[string]interface{}{"path": "rules.read.rule", "from": "allow", "to": "deny"}},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		paramsReturned []interface{}
+		want           []interface{}
+		wantErr        bool
+	}{
+		{
+			name:           "plan is returned",
+			paramsReturned: []interface{}{nil, model.Response{Result: plan}},
+			want:           plan,
+		},
+		{
+			name:           "server returns an error",
+			paramsReturned: []interface{}{errors.New("unauthorized"), model.Response{}},
+			wantErr:        true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTransport := transport.MocketAuthProviders{}
+			mockTransport.On("MakeHTTPRequestWithBody", "POST", "/v1/config/batch-apply?dryRun=true", map[string]interface{}{"specs": specs}, mock.Anything).Return(tt.paramsReturned...)
+			transport.Client = &mockTransport
+
+			got, err := PlanSpecs(specs)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("PlanSpecs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("PlanSpecs() got = %v, want %v", got, tt.want)
+			}
+			mockTransport.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_summarisePlan(t *testing.T) {
+	plan := []interface{}{
+		map[string]interface{}{"action": "create"},
+		map[string]interface{}{"action": "update", "errors": []interface{}{"rules.read: invalid rule type (magic) provided"}},
+		map[string]interface{}{"action": "update"},
+		map[string]interface{}{"action": "no-change"},
+	}
+
+	actions, invalid := summarisePlan(plan)
+	if want := map[string]int{"create": 1, "update": 2, "no-change": 1}; !reflect.DeepEqual(actions, want) {
+		t.Errorf("summarisePlan() actions = %v, want %v", actions, want)
+	}
+	if invalid != 1 {
+		t.Errorf("summarisePlan() invalid = %v, want 1", invalid)
+	}
+}
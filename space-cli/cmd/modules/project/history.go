@@ -0,0 +1,202 @@
+package project
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/ghodss/yaml"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/spaceuptech/space-cloud/space-cli/cmd/model"
+	"github.com/spaceuptech/space-cloud/space-cli/cmd/utils"
+	"github.com/spaceuptech/space-cloud/space-cli/cmd/utils/transport"
+)
+
+// HistoryCommands is the list of commands to inspect and roll back the config history of a project
+func HistoryCommands() []*cobra.Command {
+	bindFlags := func(cmd *cobra.Command, args []string) {
+		for _, flag := range []string{"resource-id", "from", "to", "version", "timestamp", "yes"} {
+			if cmd.Flags().Lookup(flag) == nil {
+				continue
+			}
+			if err := viper.BindPFlag(flag, cmd.Flags().Lookup(flag)); err != nil {
+				_ = utils.LogError(fmt.Sprintf("Unable to bind the flag ('%s')", flag), nil)
+			}
+		}
+	}
+
+	var history = &cobra.Command{
+		Use:   "history",
+		Short: "Inspect and roll back the changes made to the config of a project",
+	}
+
+	var list = &cobra.Command{
+		Use:     "list",
+		Short:   "List the changes made to the config",
+		PreRun:  bindFlags,
+		RunE:    actionListConfigHistory,
+		Example: "space-cli history list --resource-id local--myproject--db-rule--db-users-rule --project myproject",
+	}
+	list.Flags().StringP("resource-id", "", "", "Only list the changes of this resource")
+
+	var diff = &cobra.Command{
+		Use:     "diff",
+		Short:   "Show the differences of a resource between two versions",
+		PreRun:  bindFlags,
+		RunE:    actionDiffConfigHistory,
+		Example: "space-cli history diff --resource-id local--myproject--db-rule--db-users-rule --from 3 --to 5 --project myproject",
+	}
+	diff.Flags().StringP("resource-id", "", "", "Id of the resource to compare")
+	diff.Flags().Int64P("from", "", 0, "Version to compare from")
+	diff.Flags().Int64P("to", "", 0, "Version to compare to. Defaults to the latest version")
+
+	var rollback = &cobra.Command{
+		Use:     "rollback",
+		Short:   "Roll back a resource or the entire project to a version or a point in time",
+		PreRun:  bindFlags,
+		RunE:    actionRollbackConfig,
+		Example: "1) space-cli history rollback --resource-id local--myproject--db-rule--db-users-rule --version 3 --project myproject\n2) space-cli history rollback --timestamp 2020-10-01T00:00:00Z --project myproject",
+	}
+	rollback.Flags().StringP("resource-id", "", "", "Id of the resource to roll back. The entire project is rolled back if not provided")
+	rollback.Flags().Int64P("version", "", 0, "Version to roll back to")
+	rollback.Flags().StringP("timestamp", "", "", "Point in time to roll back to (RFC3339)")
+	rollback.Flags().BoolP("yes", "y", false, "Skip the confirmation prompt")
+
+	history.AddCommand(list, diff, rollback)
+	return []*cobra.Command{history}
+}
+
+func actionListConfigHistory(cmd *cobra.Command, args []string) error {
+	project, check := utils.GetProjectID()
+	if !check {
+		return utils.LogError("Project not specified in flag", nil)
+	}
+
+	params := map[string]string{}
+	if resourceID := viper.GetString("resource-id"); resourceID != "" {
+		params["resourceId"] = resourceID
+	}
+
+	entries, err := ListConfigHistory(project, params)
+	if err != nil {
+		return err
+	}
+	return printHistoryObject(entries)
+}
+
+func actionDiffConfigHistory(cmd *cobra.Command, args []string) error {
+	project, check := utils.GetProjectID()
+	if !check {
+		return utils.LogError("Project not specified in flag", nil)
+	}
+
+	resourceID := viper.GetString("resource-id")
+	if resourceID == "" {
+		return utils.LogError("Resource id not specified in flag", nil)
+	}
+	params := map[string]string{
+		"resourceId": resourceID,
+		"from":       strconv.FormatInt(viper.GetInt64("from"), 10),
+		"to":         strconv.FormatInt(viper.GetInt64("to"), 10),
+	}
+
+	diffs, err := DiffConfigHistory(project, params)
+	if err != nil {
+		return err
+	}
+	if len(diffs) == 0 {
+		utils.LogInfo("No differences found")
+		return nil
+	}
+	return printHistoryObject(diffs)
+}
+
+func actionRollbackConfig(cmd *cobra.Command, args []string) error {
+	project, check := utils.GetProjectID()
+	if !check {
+		return utils.LogError("Project not specified in flag", nil)
+	}
+
+	req := map[string]interface{}{}
+	target := ""
+	if version := viper.GetInt64("version"); version > 0 {
+		req["version"] = version
+		target = fmt.Sprintf("version %d", version)
+	}
+	if timestamp := viper.GetString("timestamp"); timestamp != "" {
+		if _, err := time.Parse(time.RFC3339, timestamp); err != nil {
+			return utils.LogError("Invalid timestamp provided. Timestamp must be in the RFC3339 format", err)
+		}
+		req["timestamp"] = timestamp
+		target = timestamp
+	}
+	if len(req) != 1 {
+		return utils.LogError("Either the version or the timestamp needs to be specified", nil)
+	}
+
+	subject := fmt.Sprintf("All resources of project (%s)", project)
+	if resourceID := viper.GetString("resource-id"); resourceID != "" {
+		req["resourceId"] = resourceID
+		subject = fmt.Sprintf("Resource (%s)", resourceID)
+	}
+
+	if !viper.GetBool("yes") {
+		isOk := false
+		if err := survey.AskOne(&survey.Confirm{Message: fmt.Sprintf("%s will be rolled back to %s. Do you want to continue?", subject, target)}, &isOk); err != nil || !isOk {
+			return nil
+		}
+	}
+
+	resourceIDs, err := RollbackConfig(project, req)
+	if err != nil {
+		return err
+	}
+	utils.LogInfo(fmt.Sprintf("Successfully rolled back %d resources", len(resourceIDs)))
+	return nil
+}
+
+// ListConfigHistory lists the changes made to the config of the project
+func ListConfigHistory(project string, params map[string]string) ([]interface{}, error) {
+	url := fmt.Sprintf("/v1/config/projects/%s/history", project)
+
+	payload := new(model.Response)
+	if err := transport.Client.MakeHTTPRequest(http.MethodGet, url, params, payload); err != nil {
+		return nil, err
+	}
+	return payload.Result, nil
+}
+
+// DiffConfigHistory gets the differences of a resource between two versions
+func DiffConfigHistory(project string, params map[string]string) ([]interface{}, error) {
+	url := fmt.Sprintf("/v1/config/projects/%s/history/diff", project)
+
+	payload := new(model.Response)
+	if err := transport.Client.MakeHTTPRequest(http.MethodGet, url, params, payload); err != nil {
+		return nil, err
+	}
+	return payload.Result, nil
+}
+
+// RollbackConfig rolls back a resource or the entire project and returns the ids of the resources which were changed
+func RollbackConfig(project string, req map[string]interface{}) ([]interface{}, error) {
+	url := fmt.Sprintf("/v1/config/projects/%s/history/rollback", project)
+
+	payload := new(model.Response)
+	if err := transport.Client.MakeHTTPRequestWithBody(http.MethodPost, url, req, payload); err != nil {
+		return nil, err
+	}
+	return payload.Result, nil
+}
+
+func printHistoryObject(obj interface{}) error {
+	b, err := yaml.Marshal(obj)
+	if err != nil {
+		return err
+	}
+	fmt.Print(string(b))
+	return nil
+}
@@ -0,0 +1,90 @@
+package project
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/spaceuptech/space-cloud/space-cli/cmd/model"
+	"github.com/spaceuptech/space-cloud/space-cli/cmd/utils/transport"
+)
+
+func TestListConfigHistory(t *testing.T) {
+	tests := []struct {
+		name           string
+		params         map[string]string
+		paramsReturned []interface{}
+		want           []interface{}
+		wantErr        bool
+	}{
+		{
+			name:           "history is listed",
+			params:         map[string]string{"resourceId": "local--myproject--db-rule--db-users-rule"},
+			paramsReturned: []interface{}{nil, model.Response{Result: []interface{}{map[string]interface{}{"version": float64(1), "author": "admin"}}}},
+			want:           []interface{}{map[string]interface{}{"version": float64(1), "author": "admin"}},
+		},
+		{
+			name:           "server returns an error",
+			params:         map[string]string{},
+			paramsReturned: []interface{}{errors.New("unauthorized"), model.Response{}},
+			wantErr:        true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTransport := transport.MocketAuthProviders{}
+			mockTransport.On("MakeHTTPRequest", "GET", "/v1/config/projects/myproject/history", tt.params, mock.Anything).Return(tt.paramsReturned...)
+			transport.Client = &mockTransport
+
+			got, err := ListConfigHistory("myproject", tt.params)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ListConfigHistory() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ListConfigHistory() got = %v, want %v", got, tt.want)
+			}
+			mockTransport.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRollbackConfig(t *testing.T) {
+	tests := []struct {
+		name           string
+		req            map[string]interface{}
+		paramsReturned []interface{}
+		want           []interface{}
+		wantErr        bool
+	}{
+		{
+			name:           "resource is rolled back",
+			req:            map[string]interface{}{"resourceId": "local--myproject--db-rule--db-users-rule", "version": int64(3)},
+			paramsReturned: []interface{}{nil, model.Response{Result: []interface{}{"local--myproject--db-rule--db-users-rule"}}},
+			want:           []interface{}{"local--myproject--db-rule--db-users-rule"},
+		},
+		{
+			name:           "server returns an error",
+			req:            map[string]interface{}{"timestamp": "2020-10-01T00:00:00Z"},
+			paramsReturned: []interface{}{errors.New("unauthorized"), model.Response{}},
+			wantErr:        true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTransport := transport.MocketAuthProviders{}
+			mockTransport.On("MakeHTTPRequestWithBody", "POST", "/v1/config/projects/myproject/history/rollback", tt.req, mock.Anything).Return(tt.paramsReturned...)
+			transport.Client = &mockTransport
+
+			got, err := RollbackConfig("myproject", tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("RollbackConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("RollbackConfig() got = %v, want %v", got, tt.want)
+			}
+			mockTransport.AssertExpectations(t)
+		})
+	}
+}
@@ -16,6 +16,7 @@ import (
 
 type transport interface {
 	MakeHTTPRequest(method, url string, params map[string]string, vPtr interface{}) error
+	MakeHTTPRequestWithBody(method, url string, body, vPtr interface{}) error
 	GetLogs(url string) error
 }
 
@@ -30,13 +31,25 @@ func init() {
 
 // MakeHTTPRequest gets spec object
 func (d *def) MakeHTTPRequest(method, url string, params map[string]string, vPtr interface{}) error {
+	return d.makeHTTPRequest(method, url, params, map[string]string{}, vPtr)
+}
+
+// MakeHTTPRequestWithBody sends the body as json
+func (d *def) MakeHTTPRequestWithBody(method, url string, body, vPtr interface{}) error {
+	return d.makeHTTPRequest(method, url, map[string]string{}, body, vPtr)
+}
+
+func (d *def) makeHTTPRequest(method, url string, params map[string]string, body, vPtr interface{}) error {
 	account, token, err := utils.LoginWithSelectedAccount()
 	if err != nil {
 		return utils.LogError("Couldn't get account details or login token", err)
 	}
 	url = fmt.Sprintf("%s%s", account.ServerURL, url)
 
-	reqBody, _ := json.Marshal(map[string]string{})
+	reqBody, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
 	req, err := http.NewRequest(method, url, bytes.NewBuffer(reqBody))
 	if err != nil {
 		return err
@@ -144,6 +157,14 @@ func (m *MocketAuthProviders) MakeHTTPRequest(method, url string, params map[str
 	return c.Error(0)
 }
 
+// MakeHTTPRequestWithBody sends the body during test
+func (m *MocketAuthProviders) MakeHTTPRequestWithBody(method, url string, body, vPtr interface{}) error {
+	c := m.Called(method, url, body, vPtr)
+	a, _ := json.Marshal(c[1])
+	_ = json.Unmarshal(a, vPtr)
+	return c.Error(0)
+}
+
 // GetLogs gets logs of service during test
 func (m *MocketAuthProviders) GetLogs(url string) error {
 	c := m.Called(url)